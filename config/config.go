@@ -1,16 +1,24 @@
 package config
 
 import (
-	"errors"
 	"fmt"
 	"gopkg.in/yaml.v3"
 	"os"
 )
 
 type Config struct {
-	Server   Server   `yaml:"server"`
-	Database Database `yaml:"database"`
-	App      App      `yaml:"app"`
+	Server      Server      `yaml:"server"`
+	Database    Database    `yaml:"database"`
+	App         App         `yaml:"app"`
+	Attachments Attachments `yaml:"attachments"`
+	Reminders   Reminders   `yaml:"reminders"`
+	Calendar    Calendar    `yaml:"calendar"`
+	DailyNotes  DailyNotes  `yaml:"dailyNotes"`
+	Import      Import      `yaml:"import"`
+	Webhooks    Webhooks    `yaml:"webhooks"`
+	Audit       Audit       `yaml:"audit"`
+	Collab      Collab      `yaml:"collab"`
+	NoteLocks   NoteLocks   `yaml:"noteLocks"`
 }
 
 type Server struct {
@@ -34,17 +42,77 @@ type App struct {
 	TokenTtlHours int    `yaml:"tokenTtlHours"`
 }
 
+type Attachments struct {
+	StoragePath      string `yaml:"storagePath"`
+	MaxFileSizeMb    int    `yaml:"maxFileSizeMb"`
+	ThumbnailSizes   []int  `yaml:"thumbnailSizes"`
+	ThumbnailWorkers int    `yaml:"thumbnailWorkers"`
+}
+
+type Reminders struct {
+	PollIntervalSeconds int  `yaml:"pollIntervalSeconds"`
+	Smtp                Smtp `yaml:"smtp"`
+}
+
+type Smtp struct {
+	Host     string `yaml:"host"`
+	Port     int    `yaml:"port"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+	From     string `yaml:"from"`
+}
+
+type Calendar struct {
+	PublicUrl string `yaml:"publicUrl"`
+	NoteUrl   string `yaml:"noteUrl"`
+}
+
+// DailyNotes - шаблон ежедневной заметки по умолчанию, если пользователь не выбрал свой
+type DailyNotes struct {
+	Title   string `yaml:"title"`
+	Content string `yaml:"content"`
+}
+
+// Import - фоновый импорт заметок из загруженных файлов
+type Import struct {
+	MaxFileSizeMb int `yaml:"maxFileSizeMb"`
+	Workers       int `yaml:"workers"`
+}
+
+// Webhooks - отправка событий на адреса пользователей
+type Webhooks struct {
+	PollIntervalSeconds int `yaml:"pollIntervalSeconds"`
+	MaxAttempts         int `yaml:"maxAttempts"`
+	RetentionDays       int `yaml:"retentionDays"`
+}
+
+// Audit - журнал аудита. AdminLogins - пользователи, которым доступен журнал всех пользователей.
+type Audit struct {
+	AdminLogins []string `yaml:"adminLogins"`
+}
+
+// Collab - совместное редактирование заметок. SnapshotIntervalSeconds - как часто текст
+// открытых документов сохраняется в заметки.
+type Collab struct {
+	SnapshotIntervalSeconds int `yaml:"snapshotIntervalSeconds"`
+}
+
+// NoteLocks - аренда заметок на время редактирования. LeaseSeconds - на сколько выдаётся или продлевается аренда.
+type NoteLocks struct {
+	LeaseSeconds int `yaml:"leaseSeconds"`
+}
+
 func MustLoad() (*Config, error) {
 	config := &Config{}
 
 	data, err := os.ReadFile("config/config.yaml")
 	if err != nil {
-		return nil, errors.New(fmt.Sprintf("failed to read config file: %w", err))
+		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
 	err = yaml.Unmarshal(data, &config)
 	if err != nil {
-		return nil, errors.New(fmt.Sprintf("failed to unmarshal config: %w", err))
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
 	return config, nil
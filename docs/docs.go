@@ -15,6 +15,114 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/api/admin/audit": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get audit entries of all users, newest first. Available to administrators listed in the configuration",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "audit"
+                ],
+                "summary": "Query audit log",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User who performed the action",
+                        "name": "actorId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Action, e.g. auth.login_failed",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity: user, note or folder",
+                        "name": "entity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Entity ID",
+                        "name": "entityId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Client IP address",
+                        "name": "ip",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Request trace ID",
+                        "name": "traceId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Period start, RFC 3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Period end, RFC 3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Return entries older than this one",
+                        "name": "beforeId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of entries, 50 by default, at most 200",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Audit entries",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.AuditEntryApi"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "The user is not an administrator",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
         "/api/auth/login": {
             "post": {
                 "description": "Login user and get authentication token",
@@ -49,89 +157,101 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/folder": {
+        "/api/calendar/token": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new folder for the authenticated user",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Issue a new secret iCalendar feed URL for the authenticated user. The previous URL stops working immediately",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "folders"
-                ],
-                "summary": "Create a new folder",
-                "parameters": [
-                    {
-                        "description": "Folder creation data",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.FolderReq"
-                        }
-                    }
+                    "calendar"
                 ],
+                "summary": "Regenerate calendar feed URL",
                 "responses": {
                     "200": {
-                        "description": "Returns ID of created folder",
+                        "description": "Returns the feed URL",
                         "schema": {
-                            "type": "integer"
+                            "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoke the iCalendar feed URL of the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Disable calendar feed",
+                "responses": {
+                    "200": {
+                        "description": "Feed disabled successfully"
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/folder/{id}": {
+        "/api/comments/{id}": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing folder for the authenticated user",
+                "description": "Edit the text of an own comment",
                 "consumes": [
                     "application/json"
                 ],
@@ -139,53 +259,59 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "folders"
+                    "comments"
                 ],
-                "summary": "Update a folder",
+                "summary": "Edit comment",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Folder ID",
+                        "description": "Comment ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Folder update data",
+                        "description": "New text",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.FolderReq"
+                            "$ref": "#/definitions/handler.CommentUpdateRq"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Folder updated successfully"
+                        "description": "Comment updated successfully"
                     },
                     "400": {
                         "description": "Invalid request data or ID",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "The comment belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "404": {
-                        "description": "Folder not found",
+                        "description": "Comment not found",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
@@ -196,18 +322,18 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete an existing folder for the authenticated user",
+                "description": "Delete an own comment. Deleting the first comment of a thread deletes the whole thread",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "folders"
+                    "comments"
                 ],
-                "summary": "Delete a folder",
+                "summary": "Delete comment",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Folder ID",
+                        "description": "Comment ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -215,226 +341,266 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Folder deleted successfully"
+                        "description": "Comment deleted successfully"
                     },
                     "400": {
                         "description": "Invalid ID",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "The comment belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "404": {
-                        "description": "Folder not found",
+                        "description": "Comment not found",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/notebook": {
-            "get": {
+        "/api/comments/{id}/resolve": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get the notebook data for the authenticated user",
+                "description": "Mark the discussion thread as resolved",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "notebooks"
+                    "comments"
+                ],
+                "summary": "Resolve thread",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID of the first comment of the thread",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get user's notebook",
                 "responses": {
                     "200": {
-                        "description": "Returns user's notebook data",
+                        "description": "Thread resolved"
+                    },
+                    "400": {
+                        "description": "Invalid ID or the comment is a reply",
                         "schema": {
-                            "$ref": "#/definitions/model.Notebook"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Comment not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/api/notes": {
-            "post": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new note for the authenticated user",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Mark the resolved discussion thread as open again",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "notes"
+                    "comments"
                 ],
-                "summary": "Create a new note",
+                "summary": "Reopen thread",
                 "parameters": [
                     {
-                        "description": "Note creation data",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.NoteRq"
-                        }
+                        "type": "integer",
+                        "description": "ID of the first comment of the thread",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Returns ID of created note",
-                        "schema": {
-                            "type": "integer"
-                        }
+                        "description": "Thread reopened"
                     },
                     "400": {
-                        "description": "Invalid request data",
+                        "description": "Invalid ID or the comment is a reply",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Comment not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/notes/favorites": {
+        "/api/events": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get all favorite notes for the authenticated user",
+                "description": "Receive the same events as over WebSocket as a text/event-stream. Every event carries its position in the change log as the SSE id, and its type as the SSE event name. A client reconnecting with the Last-Event-ID header (browsers send it automatically) receives every event it missed. Without a cursor the stream starts with new events. Browsers may pass the token in the access_token query parameter",
                 "produces": [
-                    "application/json"
+                    "text/event-stream"
                 ],
                 "tags": [
-                    "notes"
+                    "events"
+                ],
+                "summary": "Subscribe to changes over Server-Sent Events",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Id of the last received event",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Id of the last received event for clients that cannot set the header",
+                        "name": "lastEventId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JWT token for clients that cannot set the Authorization header",
+                        "name": "access_token",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get favorite notes",
                 "responses": {
                     "200": {
-                        "description": "Returns list of favorite notes",
+                        "description": "Stream of events",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Note"
-                            }
+                            "$ref": "#/definitions/model.Event"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Invalid Last-Event-ID",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/notes/search": {
+        "/api/export": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Search notes by query phrase for the authenticated user",
+                "description": "Download all folders and notes of the authenticated user. markdown - ZIP with folders as directories and notes as .md files with YAML front matter; json - versioned archive accepted by POST /api/import/json",
                 "produces": [
+                    "application/zip",
                     "application/json"
                 ],
                 "tags": [
-                    "notes"
+                    "import"
                 ],
-                "summary": "Search notes",
+                "summary": "Export the notebook",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Search phrase",
-                        "name": "query",
-                        "in": "query",
-                        "required": true
+                        "description": "Export format: markdown (default) or json",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Returns list of matching notes",
+                        "description": "Archive content",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Note"
-                            }
+                            "type": "file"
                         }
                     },
                     "400": {
-                        "description": "Empty query parameter",
+                        "description": "Unknown format",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/notes/{id}": {
-            "put": {
+        "/api/folder": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing note for the authenticated user",
+                "description": "Create a new folder for the authenticated user",
                 "consumes": [
                     "application/json"
                 ],
@@ -442,130 +608,144 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "notes"
+                    "folders"
                 ],
-                "summary": "Update a note",
+                "summary": "Create a new folder",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Note ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Note update data",
+                        "description": "Folder creation data",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.NoteRq"
+                            "$ref": "#/definitions/handler.FolderReq"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Note updated successfully"
+                        "description": "Returns ID of created folder",
+                        "schema": {
+                            "type": "integer"
+                        }
                     },
                     "400": {
-                        "description": "Invalid request data or ID",
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
-                    "404": {
-                        "description": "Note not found",
+                    "409": {
+                        "description": "Folder title already taken",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/folder/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete an existing note for the authenticated user",
+                "description": "Update an existing folder for the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "notes"
+                    "folders"
                 ],
-                "summary": "Delete a note",
+                "summary": "Update a folder",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Note ID",
+                        "description": "Folder ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Folder update data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.FolderReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Note deleted successfully"
+                        "description": "Folder updated successfully"
                     },
                     "400": {
-                        "description": "Invalid ID",
+                        "description": "Invalid request data or ID",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "404": {
-                        "description": "Note not found",
+                        "description": "Folder not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Folder title already taken",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/api/notes/{id}/favorites": {
-            "put": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Add note to favorites for the authenticated user",
+                "description": "Delete an existing folder for the authenticated user",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "notes"
+                    "folders"
                 ],
-                "summary": "Add note to favorites",
+                "summary": "Delete a folder",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Note ID",
+                        "description": "Folder ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -573,49 +753,54 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Note updated successfully",
-                        "schema": {
-                            "type": "string"
-                        }
+                        "description": "Folder deleted successfully"
                     },
                     "400": {
-                        "description": "Empty query parameter",
+                        "description": "Invalid ID",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Folder not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/folder/{id}/archive": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete note to favorites for the authenticated user",
+                "description": "Archive the folder together with all its notes",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "notes"
+                    "folders"
                 ],
-                "summary": "Delete note to favorites",
+                "summary": "Archive folder",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Note ID",
+                        "description": "Folder ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -623,142 +808,161 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Note updated successfully",
-                        "schema": {
-                            "type": "string"
-                        }
+                        "description": "Folder archived successfully"
                     },
                     "400": {
-                        "description": "Empty query parameter",
+                        "description": "Invalid ID",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Folder not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/api/notes/{id}/move": {
-            "put": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Move note from/out folder for the authenticated user",
+                "description": "Restore the folder together with all its notes",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "notes"
+                    "folders"
                 ],
-                "summary": "Moves note",
+                "summary": "Restore folder from archive",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Note ID",
+                        "description": "Folder ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Note update data",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.MoveNoteRq"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Note updated successfully",
-                        "schema": {
-                            "type": "string"
-                        }
+                        "description": "Folder restored successfully"
                     },
                     "400": {
-                        "description": "Empty query parameter",
+                        "description": "Invalid ID",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Folder not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
             }
         },
-        "/api/user": {
+        "/api/folder/{id}/export": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get profile information for the authenticated user",
+                "description": "Download notes of the folder as a single self-contained PDF or HTML document with a table of contents. Notes follow the folder order",
                 "produces": [
-                    "application/json"
+                    "application/pdf",
+                    "text/html"
                 ],
                 "tags": [
-                    "users"
+                    "folders"
+                ],
+                "summary": "Export a folder as a document",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Folder ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Document format: pdf (default) or html",
+                        "name": "format",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get user profile",
                 "responses": {
                     "200": {
-                        "description": "Returns user profile data",
+                        "description": "Document content",
                         "schema": {
-                            "$ref": "#/definitions/handler.UserRsp"
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or unknown format",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Folder not found",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/folder/{id}/position": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update profile information for the authenticated user",
+                "description": "Place the folder right after another folder, or first when AfterId is omitted",
                 "consumes": [
                     "application/json"
                 ],
@@ -766,329 +970,6514 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "folders"
                 ],
-                "summary": "Update user profile",
+                "summary": "Reorder folder",
                 "parameters": [
                     {
-                        "description": "User update data",
+                        "type": "integer",
+                        "description": "Folder ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Previous folder",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.UserReq"
+                            "$ref": "#/definitions/handler.PositionRq"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Profile updated successfully"
+                        "description": "Folder moved successfully"
                     },
                     "400": {
-                        "description": "Invalid request data",
+                        "description": "Invalid request data, ID or previous folder",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Folder not found",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/graph": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the graph of the user's notes: nodes, links between notes and dangling links to missing notes",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "links"
+                ],
+                "summary": "Get notes graph",
+                "responses": {
+                    "200": {
+                        "description": "Notes graph",
+                        "schema": {
+                            "$ref": "#/definitions/model.GraphApi"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/import/enex": {
             "post": {
-                "description": "Register a new user in the system",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Start asynchronous import of an ENEX file or a ZIP with several ENEX files. Each file becomes a folder named after it, notes are converted to Markdown, tags and dates are preserved and embedded files are saved as attachments",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "import"
                 ],
-                "summary": "Create a new user",
+                "summary": "Import an Evernote export",
                 "parameters": [
                     {
-                        "description": "User registration data",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.UserReq"
-                        }
+                        "type": "file",
+                        "description": "ENEX file or ZIP with ENEX files",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Title conflict strategy: skip (default), rename or overwrite",
+                        "name": "strategy",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "User created successfully",
+                    "202": {
+                        "description": "Returns ID of created import job",
                         "schema": {
                             "type": "integer"
                         }
                     },
                     "400": {
-                        "description": "Invalid request data",
+                        "description": "Invalid request data or file too large",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
-                    "409": {
-                        "description": "User already exists",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "Archive is too large",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/import/jobs/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete account for the authenticated user",
+                "description": "Get progress of the import job and the result of every imported file",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "import"
+                ],
+                "summary": "Get import job",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Import job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Delete user account",
                 "responses": {
                     "200": {
-                        "description": "Account deleted successfully"
+                        "description": "Import job",
+                        "schema": {
+                            "$ref": "#/definitions/model.ImportJobApi"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Import job not found",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
-                    },
+                    }
+                }
+            }
+        },
+        "/api/import/json": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Start asynchronous import of a notebook archive exported with GET /api/export?format=json. Folders, tags, checklists, favorite, pinned and archived flags and dates are restored",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "import"
+                ],
+                "summary": "Import a JSON archive",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "JSON archive",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Title conflict strategy: skip (default), rename or overwrite",
+                        "name": "strategy",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Returns ID of created import job",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data or file too large",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "Archive is too large",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/import/markdown": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Start asynchronous import of a ZIP archive with Markdown files (e.g. an Obsidian vault). Directories become folders, YAML front matter provides title, tags, favorite flag and date",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "import"
+                ],
+                "summary": "Import a Markdown vault",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "ZIP archive",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Title conflict strategy: skip (default), rename or overwrite",
+                        "name": "strategy",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Returns ID of created import job",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data or file too large",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "Archive is too large",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notebook": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the notebook data for the authenticated user, ordered by the user's sort preference. If the user has a page size preference, every list of notes is paginated",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notebooks"
+                ],
+                "summary": "Get user's notebook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number starting from 1",
+                        "name": "page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns user's notebook data",
+                        "schema": {
+                            "$ref": "#/definitions/model.Notebook"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid page",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notes": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new note for the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Create a new note",
+                "parameters": [
+                    {
+                        "description": "Note creation data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.NoteRq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns ID of created note",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Note title already taken",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notes/archived": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all archived notes for the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Get archived notes",
+                "responses": {
+                    "200": {
+                        "description": "Returns list of archived notes",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.NoteApi"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notes/daily/settings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the template and folder used for new daily notes",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "daily notes"
+                ],
+                "summary": "Get daily note settings",
+                "responses": {
+                    "200": {
+                        "description": "Daily note settings",
+                        "schema": {
+                            "$ref": "#/definitions/model.DailyNoteSettingsApi"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/handler.response"
+                            "$ref": "#/definitions/model.Problem"
                         }
                     }
                 }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set the template and folder for new daily notes. Omitted values reset to the defaults",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "daily notes"
+                ],
+                "summary": "Update daily note settings",
+                "parameters": [
+                    {
+                        "description": "Daily note settings",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.DailyNoteSettingsRq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Settings updated successfully"
+                    },
+                    "400": {
+                        "description": "Invalid request data or template with prompts",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Template or folder not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notes/daily/{date}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the note for the given day, creating it from the daily note template if it does not exist. Without a date the current day in the user's timezone is used",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "daily notes"
+                ],
+                "summary": "Get daily note",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Day in YYYY-MM-DD format",
+                        "name": "date",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Daily note",
+                        "schema": {
+                            "$ref": "#/definitions/model.NoteApi"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid date or template data",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Template or folder not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notes/favorites": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all favorite notes for the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Get favorite notes",
+                "responses": {
+                    "200": {
+                        "description": "Returns list of favorite notes",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Note"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notes/from-template/{id}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Render the template and create a note from it with the usual note validation. The body may be omitted if the template has no prompts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Create a note from a template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Values for template prompts",
+                        "name": "input",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handler.FromTemplateRq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns ID of created note",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data, ID or missing prompt values",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Template or folder not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Note title already taken",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notes/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Search notes by query phrase for the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Search notes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search phrase",
+                        "name": "query",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Pass archived to search archived notes too",
+                        "name": "include",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns list of matching notes",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Note"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Empty query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notes/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing note for the authenticated user. While the note is locked for editing, only the lock holder can update it by passing the lock token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Update a note",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Note update data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.NoteRq"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Rewrite [[title]] links in other notes when the title changes",
+                        "name": "rewriteLinks",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token of the edit lock held by the client",
+                        "name": "X-Lock-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Note updated successfully"
+                    },
+                    "400": {
+                        "description": "Invalid request data or ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Note not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Note title already taken",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "423": {
+                        "description": "The note is locked by another client",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete an existing note for the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Delete a note",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token of the edit lock held by the client",
+                        "name": "X-Lock-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Note deleted successfully"
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Note not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "423": {
+                        "description": "The note is locked by another client",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notes/{id}/archive": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hide the note from the notebook and favorites without deleting it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Archive note",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token of the edit lock held by the client",
+                        "name": "X-Lock-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Note archived successfully"
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Note not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "423": {
+                        "description": "The note is locked by another client",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Return the note to the notebook. A note from an archived folder is restored to the notebook root",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Restore note from archive",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token of the edit lock held by the client",
+                        "name": "X-Lock-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Note restored successfully"
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Note not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "423": {
+                        "description": "The note is locked by another client",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notes/{id}/attachments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all attachments of the note for the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Get note attachments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns list of attachments",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.AttachmentApi"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Note not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Attach a file to the note of the authenticated user. Thumbnails for images are generated in background",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Upload an attachment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "File to attach",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns ID of created attachment",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data or ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Note not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "413": {
+                        "description": "File is too large",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notes/{id}/attachments/{aid}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download the original file of the attachment for the authenticated user",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Download an attachment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attachment ID",
+                        "name": "aid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Attachment content",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Attachment not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete the attachment and its thumbnails for the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Delete an attachment",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attachment ID",
+                        "name": "aid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Attachment deleted successfully"
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notes/{id}/attachments/{aid}/thumbnail": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the thumbnail of an image attachment for the authenticated user",
+                "produces": [
+                    "image/jpeg",
+                    "image/png"
+                ],
+                "tags": [
+                    "attachments"
+                ],
+                "summary": "Get attachment thumbnail",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Attachment ID",
+                        "name": "aid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Thumbnail size in pixels, the smallest configured size by default",
+                        "name": "size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Thumbnail content",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or size",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Thumbnail not found or not generated yet",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notes/{id}/backlinks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get notes that reference the note with [[Title]] or [[note:id]] links, including archived ones",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "links"
+                ],
+                "summary": "Get note backlinks",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of referring notes",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.BacklinkApi"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Note not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notes/{id}/collab": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upgrade the connection to WebSocket and join the collaborative editing session of the note. The text is an RGA sequence CRDT: every character has an Id of a Lamport Counter and the Site of the participant who inserted it. The first message is init with the participant's Site, the document Clock, all characters including deleted ones and the participants. The client sends ops messages with insert operations (Id with its own Site and a Counter greater than any seen, After - the character to insert after, null for the start, Value - one character) and delete operations (Id of the character), and cursor messages with the Anchor and Head characters of its selection. The server sends ops of other participants and presence with the participants and their cursors. The text is saved to the note every few seconds and when the last participant leaves. If the text cannot be saved, participants get a save_failed message with the error; the edits stay in the document until the next edit makes it valid, and if the note was deleted the connection is closed. After a few seconds without edits the server removes deleted characters and sends init again with the next Generation; the client replaces its state with it and sends again its own edits that the new state does not contain. Every client message carries the Generation of the last init; messages of an older generation are ignored. A rejected message is answered with an error message and the connection is closed; the client should reconnect and start from the new init. Browsers may pass the token in the access_token query parameter",
+                "tags": [
+                    "collab"
+                ],
+                "summary": "Edit a note together over WebSocket",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "JWT token for clients that cannot set the Authorization header",
+                        "name": "access_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching protocols, then a stream of messages",
+                        "schema": {
+                            "$ref": "#/definitions/model.CollabServerMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or not a WebSocket request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Note not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notes/{id}/comments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get discussion threads of the note with their replies in order of creation. Pass NextAfterId as afterId to get the next page",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Get note comments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Return threads created after this one",
+                        "name": "afterId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of threads, 20 by default, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of threads",
+                        "schema": {
+                            "$ref": "#/definitions/model.CommentThreadsApi"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Note not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Start a discussion thread on the note or reply to one with ParentId. A thread may be anchored to a fragment of the note text: Start and End are character positions, the fragment text is saved with the thread",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "comments"
+                ],
+                "summary": "Comment on a note",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommentRq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns ID of created comment",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data or ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Note or parent comment not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notes/{id}/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download the note as a self-contained PDF or HTML document with title, tags, modification time and rendered Markdown content",
+                "produces": [
+                    "application/pdf",
+                    "text/html"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Export a note as a document",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Document format: pdf (default) or html",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Document content",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID or unknown format",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Note not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notes/{id}/favorites": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add note to favorites for the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Add note to favorites",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token of the edit lock held by the client",
+                        "name": "X-Lock-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Note updated successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Empty query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "423": {
+                        "description": "The note is locked by another client",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete note to favorites for the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Delete note to favorites",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token of the edit lock held by the client",
+                        "name": "X-Lock-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Note updated successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Empty query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "423": {
+                        "description": "The note is locked by another client",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notes/{id}/items": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add an item to the checklist note, to the end or at the given position",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "checklists"
+                ],
+                "summary": "Add checklist item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Item data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ChecklistItemRq"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token of the edit lock held by the client",
+                        "name": "X-Lock-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns ID of created item",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data, ID or note is not a checklist",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Note not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "423": {
+                        "description": "The note is locked by another client",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notes/{id}/items/{itemId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete the item from the checklist note",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "checklists"
+                ],
+                "summary": "Delete checklist item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Item ID",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token of the edit lock held by the client",
+                        "name": "X-Lock-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Item deleted successfully"
+                    },
+                    "400": {
+                        "description": "Invalid ID or note is not a checklist",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Note not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "423": {
+                        "description": "The note is locked by another client",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notes/{id}/items/{itemId}/checked": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark the checklist item as done",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "checklists"
+                ],
+                "summary": "Check checklist item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Item ID",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token of the edit lock held by the client",
+                        "name": "X-Lock-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Item checked successfully"
+                    },
+                    "400": {
+                        "description": "Invalid ID or note is not a checklist",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Note or item not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "423": {
+                        "description": "The note is locked by another client",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark the checklist item as not done",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "checklists"
+                ],
+                "summary": "Uncheck checklist item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Item ID",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token of the edit lock held by the client",
+                        "name": "X-Lock-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Item unchecked successfully"
+                    },
+                    "400": {
+                        "description": "Invalid ID or note is not a checklist",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Note or item not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "423": {
+                        "description": "The note is locked by another client",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notes/{id}/items/{itemId}/position": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move the checklist item to the given zero-based position",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "checklists"
+                ],
+                "summary": "Reorder checklist item",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Item ID",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New position",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ChecklistItemPositionRq"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token of the edit lock held by the client",
+                        "name": "X-Lock-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Item moved successfully"
+                    },
+                    "400": {
+                        "description": "Invalid request data, ID or note is not a checklist",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Note or item not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "423": {
+                        "description": "The note is locked by another client",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notes/{id}/lock": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Acquire a time-limited edit lock on the note. While the lock is active, only requests with its token can update the note. Pass the token of an active lock in X-Lock-Token to renew it. Expired locks are released automatically",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Lock note for editing",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token of the lock to renew",
+                        "name": "X-Lock-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Lock token and expiration time",
+                        "schema": {
+                            "$ref": "#/definitions/model.NoteLockGrantApi"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Note not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "423": {
+                        "description": "The note is locked by another client",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Release the edit lock held by the client",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Unlock note",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token of the lock",
+                        "name": "X-Lock-Token",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Lock released"
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Note not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "423": {
+                        "description": "The note is locked by another client",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notes/{id}/move": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move note from/out folder for the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Moves note",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Note update data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.MoveNoteRq"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token of the edit lock held by the client",
+                        "name": "X-Lock-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Note updated successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Empty query parameter",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "423": {
+                        "description": "The note is locked by another client",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notes/{id}/pin": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Pin the note so it is shown first in its folder",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Pin note",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token of the edit lock held by the client",
+                        "name": "X-Lock-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Note pinned successfully"
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Note not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "423": {
+                        "description": "The note is locked by another client",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Unpin the note in its folder",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Unpin note",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token of the edit lock held by the client",
+                        "name": "X-Lock-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Note unpinned successfully"
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Note not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "423": {
+                        "description": "The note is locked by another client",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notes/{id}/position": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Place the note right after another note of the same folder and pin state, or first when AfterId is omitted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notes"
+                ],
+                "summary": "Reorder note",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Previous note",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PositionRq"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token of the edit lock held by the client",
+                        "name": "X-Lock-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Note moved successfully"
+                    },
+                    "400": {
+                        "description": "Invalid request data, ID or previous note",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Note not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "423": {
+                        "description": "The note is locked by another client",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notes/{id}/reminder": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the reminder of the note for the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reminders"
+                ],
+                "summary": "Get note reminder",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns reminder",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReminderApi"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Reminder not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set or replace the reminder of the note. Recurrence is daily, weekly, monthly or an RRULE subset (FREQ, INTERVAL, COUNT, UNTIL)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reminders"
+                ],
+                "summary": "Set note reminder",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reminder settings",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ReminderRq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reminder set successfully"
+                    },
+                    "400": {
+                        "description": "Invalid request data or ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Note not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete the reminder of the note for the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reminders"
+                ],
+                "summary": "Delete note reminder",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reminder deleted successfully"
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notes/{id}/type": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Convert a text note to a checklist (one line per item) or a checklist back to text",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "checklists"
+                ],
+                "summary": "Convert note type",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Note ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Target note type: text or checklist",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.NoteTypeRq"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token of the edit lock held by the client",
+                        "name": "X-Lock-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Note converted successfully"
+                    },
+                    "400": {
+                        "description": "Invalid request data or ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Note not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "423": {
+                        "description": "The note is locked by another client",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notifications": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get notifications of the authenticated user, newest first: reminders and mentions of the user's login as @login in notes and comments. Pass NextBeforeId as beforeId to get the next page",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Get notifications",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Return notifications older than this one",
+                        "name": "beforeId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of notifications, 20 by default, at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return only unread notifications",
+                        "name": "unread",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of notifications",
+                        "schema": {
+                            "$ref": "#/definitions/model.NotificationsApi"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notifications/read": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark all notifications of the authenticated user as read",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Mark all notifications as read",
+                "responses": {
+                    "200": {
+                        "description": "Notifications marked as read"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notifications/unread-count": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the number of unread notifications of the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Get unread notification count",
+                "responses": {
+                    "200": {
+                        "description": "Unread notification count",
+                        "schema": {
+                            "$ref": "#/definitions/model.UnreadNotificationsApi"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notifications/{id}/read": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark one notification of the authenticated user as read",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Mark notification as read",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Notification marked as read"
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Notification not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reminders": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get reminder occurrences of the authenticated user within the interval (30 days from now by default, at most one year)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reminders"
+                ],
+                "summary": "Get upcoming reminders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Interval start, RFC 3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Interval end, RFC 3339",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns upcoming reminders ordered by time",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ReminderOccurrenceApi"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid interval",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/sync": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get notes and folders changed after the sync token together with tombstones of deleted ones. Without a token the whole notebook is returned. Pass the returned Token in the next request",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sync"
+                ],
+                "summary": "Get changes for offline clients",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sync token from the previous response",
+                        "name": "since",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Changes and the new sync token",
+                        "schema": {
+                            "$ref": "#/definitions/model.SyncChanges"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid sync token",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Apply a batch of note and folder changes in one transaction. New records carry a client-generated ClientId, of at most 64 characters, which later changes of the same batch may refer to. Update and delete carry the Version the change is based on; if the record changed on the server since, the result is a conflict with the current state. A repeated create with the same ClientId returns the existing record. A note locked for editing by another client can be updated or deleted only with its LockToken; otherwise the change fails with NOTE_LOCKED",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sync"
+                ],
+                "summary": "Apply changes made offline",
+                "parameters": [
+                    {
+                        "description": "Changes in order of execution",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.SyncRq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Result of every change",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.SyncResult"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data or too many changes",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/templates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all note templates of the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Get note templates",
+                "responses": {
+                    "200": {
+                        "description": "List of templates",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.TemplateApi"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a note template. Title and content may contain {{date}}, {{time}}, {{user.name}}, {{user.surname}}, {{user.login}} and {{prompt:Name}} placeholders",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Create a note template",
+                "parameters": [
+                    {
+                        "description": "Template data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.TemplateRq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Returns ID of created template",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Folder not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Template name already taken",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/templates/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a note template with the list of values it prompts for",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Get a note template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Template",
+                        "schema": {
+                            "$ref": "#/definitions/model.TemplateApi"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Template not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing note template",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Update a note template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Template data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.TemplateRq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Template updated successfully"
+                    },
+                    "400": {
+                        "description": "Invalid request data or ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Template or folder not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Template name already taken",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a note template. Notes created from it are kept",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "templates"
+                ],
+                "summary": "Delete a note template",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Template deleted successfully"
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get profile information for the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get user profile",
+                "responses": {
+                    "200": {
+                        "description": "Returns user profile data",
+                        "schema": {
+                            "$ref": "#/definitions/handler.UserRsp"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update profile information for the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update user profile",
+                "parameters": [
+                    {
+                        "description": "User update data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UserReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Profile updated successfully"
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Login already taken",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Register a new user in the system",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Create a new user",
+                "parameters": [
+                    {
+                        "description": "User registration data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UserReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "User created successfully",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "User already exists",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete account for the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Delete user account",
+                "responses": {
+                    "200": {
+                        "description": "Account deleted successfully"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/audit": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get actions of the authenticated user, newest first: logins including failed ones, profile and password changes, note and folder changes. Pass the Id of the last entry as beforeId to get the next page",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "audit"
+                ],
+                "summary": "Get own audit log",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Action, e.g. note.moved",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity: user, note or folder",
+                        "name": "entity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Entity ID",
+                        "name": "entityId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Period start, RFC 3339",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Period end, RFC 3339",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Return entries older than this one",
+                        "name": "beforeId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of entries, 50 by default, at most 200",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Audit entries",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.AuditEntryApi"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/user/preferences": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get preferences of the authenticated user. Defaults are returned for preferences that were never changed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get user preferences",
+                "responses": {
+                    "200": {
+                        "description": "User preferences",
+                        "schema": {
+                            "$ref": "#/definitions/model.PreferencesApi"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change some preferences of the authenticated user. SortOrder is manual, title or updated; Locale is ru or en; EditorFormat is markdown or plain; PageSize 0 disables notebook pagination; DefaultFolderId 0 resets the default folder; Notifications enables or disables inbox notifications by type: reminder or mention",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Update user preferences",
+                "parameters": [
+                    {
+                        "description": "Changed preferences",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PreferencesRq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Preferences updated successfully"
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "User or folder not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all webhooks of the user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get webhooks",
+                "responses": {
+                    "200": {
+                        "description": "List of webhooks",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.WebhookApi"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Subscribe an address to note and folder events. Every delivery is a POST signed with HMAC-SHA256: X-Notes-Signature is \"sha256=\" + hex(HMAC(secret, X-Notes-Timestamp + \".\" + body)). The secret is returned only once",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Create webhook",
+                "parameters": [
+                    {
+                        "description": "Webhook settings",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.WebhookRq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook id and signing secret",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get webhook settings by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebhookApi"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the address, events and activity of the webhook. The secret is kept",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Update webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Webhook settings",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.WebhookRq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook updated successfully"
+                    },
+                    "400": {
+                        "description": "Invalid request data or ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete the webhook together with its delivery log",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete webhook",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Webhook deleted successfully"
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the latest deliveries of the webhook with status, response codes and every attempt. Failed deliveries are retried with exponential backoff and marked dead when attempts run out",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Get webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Latest deliveries",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.WebhookDeliveryApi"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks/{id}/deliveries/{deliveryId}/retry": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Put the delivery back into the queue with a full set of attempts",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Retry webhook delivery",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "deliveryId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Delivery queued"
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook or delivery not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks/{id}/test": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Immediately send a signed webhook.test event to the webhook and return the result of the attempt. A failed test delivery is not retried",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Send test event",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Test delivery",
+                        "schema": {
+                            "$ref": "#/definitions/model.WebhookDeliveryApi"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Webhook not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/ws": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upgrade the connection to WebSocket and receive JSON events about changes of the user's notes and folders made on any device: note.created, note.updated, note.deleted, note.moved, note.favorite, folder.created, folder.renamed, folder.deleted, folder.updated. Browsers may pass the token in the access_token query parameter. The server closes the connection if the client falls behind; the client should then reload the notebook and reconnect",
+                "tags": [
+                    "events"
+                ],
+                "summary": "Subscribe to changes over WebSocket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JWT token for clients that cannot set the Authorization header",
+                        "name": "access_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching protocols, then a stream of events",
+                        "schema": {
+                            "$ref": "#/definitions/model.Event"
+                        }
+                    },
+                    "400": {
+                        "description": "Not a WebSocket request",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/calendar/{token}.ics": {
+            "get": {
+                "description": "Get the RFC 5545 feed of dated notes. Authorized by the secret token in the URL, so calendar clients can subscribe to it",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "calendar"
+                ],
+                "summary": "Get calendar feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Feed token with .ics extension",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar feed",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Feed not found",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/model.Problem"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handler.AuthReq": {
+            "description": "User authentication credentials",
+            "type": "object",
+            "required": [
+                "Login",
+                "Password"
+            ],
+            "properties": {
+                "Login": {
+                    "type": "string",
+                    "example": "user123456"
+                },
+                "Password": {
+                    "type": "string",
+                    "example": "securePassword123$"
+                }
+            }
+        },
+        "handler.ChecklistItemPositionRq": {
+            "type": "object",
+            "required": [
+                "Position"
+            ],
+            "properties": {
+                "Position": {
+                    "type": "integer",
+                    "example": 0
+                }
+            }
+        },
+        "handler.ChecklistItemRq": {
+            "type": "object",
+            "required": [
+                "Text"
+            ],
+            "properties": {
+                "Position": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "Text": {
+                    "type": "string",
+                    "example": "Buy milk"
+                }
+            }
+        },
+        "handler.CommentRq": {
+            "type": "object",
+            "required": [
+                "Content"
+            ],
+            "properties": {
+                "Anchor": {
+                    "$ref": "#/definitions/model.CommentAnchor"
+                },
+                "Content": {
+                    "type": "string",
+                    "example": "Стоит уточнить сроки"
+                },
+                "ParentId": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "handler.CommentUpdateRq": {
+            "type": "object",
+            "required": [
+                "Content"
+            ],
+            "properties": {
+                "Content": {
+                    "type": "string",
+                    "example": "Стоит уточнить сроки и бюджет"
+                }
+            }
+        },
+        "handler.DailyNoteSettingsRq": {
+            "type": "object",
+            "properties": {
+                "FolderId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "TemplateId": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "handler.FolderReq": {
+            "type": "object",
+            "required": [
+                "Title"
+            ],
+            "properties": {
+                "Title": {
+                    "type": "string",
+                    "example": "My Folder"
+                }
+            }
+        },
+        "handler.FromTemplateRq": {
+            "type": "object",
+            "properties": {
+                "Values": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "handler.MoveNoteRq": {
+            "type": "object",
+            "required": [
+                "FolderId"
+            ],
+            "properties": {
+                "FolderId": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "handler.NoteRq": {
+            "type": "object",
+            "required": [
+                "Title"
+            ],
+            "properties": {
+                "Content": {
+                    "type": "string",
+                    "example": "Note content"
+                },
+                "Tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "tag1",
+                        "tag2"
+                    ]
+                },
+                "Title": {
+                    "type": "string",
+                    "example": "My Note"
+                }
+            }
+        },
+        "handler.NoteTypeRq": {
+            "type": "object",
+            "required": [
+                "Type"
+            ],
+            "properties": {
+                "Type": {
+                    "type": "string",
+                    "example": "checklist"
+                }
+            }
+        },
+        "handler.PositionRq": {
+            "type": "object",
+            "properties": {
+                "AfterId": {
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "handler.PreferencesRq": {
+            "type": "object",
+            "properties": {
+                "DefaultFolderId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "EditorFormat": {
+                    "type": "string",
+                    "example": "markdown"
+                },
+                "Locale": {
+                    "type": "string",
+                    "example": "ru"
+                },
+                "Notifications": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "boolean"
+                    }
+                },
+                "PageSize": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "SortOrder": {
+                    "type": "string",
+                    "example": "manual"
+                },
+                "Timezone": {
+                    "type": "string",
+                    "example": "Europe/Moscow"
+                }
+            }
+        },
+        "handler.ReminderRq": {
+            "type": "object",
+            "required": [
+                "RemindAt"
+            ],
+            "properties": {
+                "Channels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "inbox",
+                        "email"
+                    ]
+                },
+                "DueAt": {
+                    "type": "string",
+                    "example": "2026-01-02T18:00:00Z"
+                },
+                "Email": {
+                    "type": "string",
+                    "example": "user@example.com"
+                },
+                "Recurrence": {
+                    "type": "string",
+                    "example": "FREQ=WEEKLY;INTERVAL=2;COUNT=10"
+                },
+                "RemindAt": {
+                    "type": "string",
+                    "example": "2026-01-01T09:00:00Z"
+                },
+                "WebhookUrl": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/reminders"
+                }
+            }
+        },
+        "handler.SyncRq": {
+            "type": "object",
+            "required": [
+                "Mutations"
+            ],
+            "properties": {
+                "Mutations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SyncMutation"
+                    }
+                }
+            }
+        },
+        "handler.TemplateRq": {
+            "type": "object",
+            "required": [
+                "Name",
+                "Title"
+            ],
+            "properties": {
+                "Content": {
+                    "type": "string",
+                    "example": "Ведущий: {{user.name}}"
+                },
+                "FolderId": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "Name": {
+                    "type": "string",
+                    "example": "Встреча"
+                },
+                "Tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "meeting"
+                    ]
+                },
+                "Title": {
+                    "type": "string",
+                    "example": "Встреча {{date}}: {{prompt:Тема}}"
+                }
+            }
+        },
+        "handler.UserReq": {
+            "description": "User creation/update request",
+            "type": "object",
+            "required": [
+                "Login",
+                "Password"
+            ],
+            "properties": {
+                "Login": {
+                    "type": "string",
+                    "example": "user123456"
+                },
+                "Name": {
+                    "type": "string",
+                    "example": "John"
+                },
+                "Password": {
+                    "type": "string",
+                    "example": "securePassword123$"
+                },
+                "Surname": {
+                    "type": "string",
+                    "example": "Doe"
+                },
+                "Timezone": {
+                    "type": "string",
+                    "example": "Europe/Moscow"
+                }
+            }
+        },
+        "handler.UserRsp": {
+            "description": "User response data",
+            "type": "object",
+            "properties": {
+                "Id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "Login": {
+                    "type": "string",
+                    "example": "user123456"
+                },
+                "Name": {
+                    "type": "string",
+                    "example": "John"
+                },
+                "Surname": {
+                    "type": "string",
+                    "example": "Doe"
+                },
+                "Timezone": {
+                    "type": "string",
+                    "example": "Europe/Moscow"
+                }
+            }
+        },
+        "handler.WebhookRq": {
+            "type": "object",
+            "required": [
+                "Events",
+                "Url"
+            ],
+            "properties": {
+                "Events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "note.created",
+                        "note.moved"
+                    ]
+                },
+                "IsActive": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "Url": {
+                    "type": "string",
+                    "example": "https://example.com/hooks/notes"
+                }
+            }
+        },
+        "model.AttachmentApi": {
+            "type": "object",
+            "properties": {
+                "ContentType": {
+                    "type": "string"
+                },
+                "FileName": {
+                    "type": "string"
+                },
+                "Id": {
+                    "type": "integer"
+                },
+                "NoteId": {
+                    "type": "integer"
+                },
+                "Size": {
+                    "type": "integer"
+                },
+                "ThumbnailStatus": {
+                    "$ref": "#/definitions/model.ThumbnailStatus"
+                },
+                "ThumbnailUrl": {
+                    "type": "string"
+                },
+                "Timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AuditAction": {
+            "type": "string",
+            "enum": [
+                "auth.login",
+                "auth.login_failed",
+                "user.updated",
+                "user.password_changed",
+                "user.deleted",
+                "note.created",
+                "note.updated",
+                "note.deleted",
+                "note.moved",
+                "note.favorite",
+                "folder.created",
+                "folder.renamed",
+                "folder.deleted",
+                "folder.updated",
+                "webhook.created",
+                "webhook.updated",
+                "webhook.deleted"
+            ],
+            "x-enum-varnames": [
+                "AuditLogin",
+                "AuditLoginFailed",
+                "AuditUserUpdated",
+                "AuditPasswordChanged",
+                "AuditUserDeleted",
+                "AuditNoteCreated",
+                "AuditNoteUpdated",
+                "AuditNoteDeleted",
+                "AuditNoteMoved",
+                "AuditNoteFavorite",
+                "AuditFolderCreated",
+                "AuditFolderRenamed",
+                "AuditFolderDeleted",
+                "AuditFolderUpdated",
+                "AuditWebhookCreated",
+                "AuditWebhookUpdated",
+                "AuditWebhookDeleted"
+            ]
+        },
+        "model.AuditEntity": {
+            "type": "string",
+            "enum": [
+                "user",
+                "note",
+                "folder",
+                "webhook"
+            ],
+            "x-enum-varnames": [
+                "AuditEntityUser",
+                "AuditEntityNote",
+                "AuditEntityFolder",
+                "AuditEntityWebhook"
+            ]
+        },
+        "model.AuditEntryApi": {
+            "type": "object",
+            "properties": {
+                "Action": {
+                    "$ref": "#/definitions/model.AuditAction"
+                },
+                "ActorId": {
+                    "type": "integer"
+                },
+                "After": {
+                    "type": "object"
+                },
+                "Before": {
+                    "type": "object"
+                },
+                "Entity": {
+                    "$ref": "#/definitions/model.AuditEntity"
+                },
+                "EntityId": {
+                    "type": "integer"
+                },
+                "Id": {
+                    "type": "integer"
+                },
+                "Ip": {
+                    "type": "string"
+                },
+                "Timestamp": {
+                    "type": "string"
+                },
+                "TraceId": {
+                    "type": "string"
+                },
+                "UserAgent": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BacklinkApi": {
+            "type": "object",
+            "properties": {
+                "IsArchived": {
+                    "type": "boolean"
+                },
+                "NoteId": {
+                    "type": "integer"
+                },
+                "Title": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ChecklistItemApi": {
+            "type": "object",
+            "properties": {
+                "Id": {
+                    "type": "integer"
+                },
+                "IsChecked": {
+                    "type": "boolean"
+                },
+                "Position": {
+                    "type": "integer"
+                },
+                "Text": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CollabCursor": {
+            "type": "object",
+            "properties": {
+                "Anchor": {
+                    "$ref": "#/definitions/model.CrdtId"
+                },
+                "Head": {
+                    "$ref": "#/definitions/model.CrdtId"
+                }
+            }
+        },
+        "model.CollabMessageType": {
+            "type": "string",
+            "enum": [
+                "init",
+                "ops",
+                "cursor",
+                "presence",
+                "error",
+                "save_failed"
+            ],
+            "x-enum-varnames": [
+                "CollabMessageInit",
+                "CollabMessageOps",
+                "CollabMessageCursor",
+                "CollabMessagePresence",
+                "CollabMessageError",
+                "CollabMessageSaveFailed"
+            ]
+        },
+        "model.CollabParticipant": {
+            "type": "object",
+            "properties": {
+                "Cursor": {
+                    "$ref": "#/definitions/model.CollabCursor"
+                },
+                "Login": {
+                    "type": "string"
+                },
+                "Site": {
+                    "type": "string"
+                },
+                "UserId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CollabServerMessage": {
+            "type": "object",
+            "properties": {
+                "Clock": {
+                    "type": "integer"
+                },
+                "Elements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CrdtElement"
+                    }
+                },
+                "Error": {
+                    "$ref": "#/definitions/model.Problem"
+                },
+                "Generation": {
+                    "type": "integer"
+                },
+                "NoteId": {
+                    "type": "integer"
+                },
+                "Ops": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CrdtOperation"
+                    }
+                },
+                "Participants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CollabParticipant"
+                    }
+                },
+                "Site": {
+                    "type": "string"
+                },
+                "Type": {
+                    "$ref": "#/definitions/model.CollabMessageType"
+                }
+            }
+        },
+        "model.CommentAnchor": {
+            "type": "object",
+            "properties": {
+                "End": {
+                    "type": "integer"
+                },
+                "Start": {
+                    "type": "integer"
+                },
+                "Text": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CommentApi": {
+            "type": "object",
+            "properties": {
+                "Anchor": {
+                    "$ref": "#/definitions/model.CommentAnchor"
+                },
+                "Content": {
+                    "type": "string"
+                },
+                "EditedAt": {
+                    "type": "string"
+                },
+                "Id": {
+                    "type": "integer"
+                },
+                "IsResolved": {
+                    "type": "boolean"
+                },
+                "NoteId": {
+                    "type": "integer"
+                },
+                "ParentId": {
+                    "type": "integer"
+                },
+                "Replies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CommentApi"
+                    }
+                },
+                "ResolvedAt": {
+                    "type": "string"
+                },
+                "ResolvedBy": {
+                    "type": "integer"
+                },
+                "Timestamp": {
+                    "type": "string"
+                },
+                "UserId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CommentThreadsApi": {
+            "type": "object",
+            "properties": {
+                "NextAfterId": {
+                    "type": "integer"
+                },
+                "Threads": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.CommentApi"
+                    }
+                }
+            }
+        },
+        "model.CrdtElement": {
+            "type": "object",
+            "properties": {
+                "Deleted": {
+                    "type": "boolean"
+                },
+                "Id": {
+                    "$ref": "#/definitions/model.CrdtId"
+                },
+                "Value": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CrdtId": {
+            "type": "object",
+            "properties": {
+                "Counter": {
+                    "type": "integer"
+                },
+                "Site": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CrdtOperation": {
+            "type": "object",
+            "properties": {
+                "After": {
+                    "$ref": "#/definitions/model.CrdtId"
+                },
+                "Id": {
+                    "$ref": "#/definitions/model.CrdtId"
+                },
+                "Type": {
+                    "$ref": "#/definitions/model.CrdtOperationType"
+                },
+                "Value": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CrdtOperationType": {
+            "type": "string",
+            "enum": [
+                "insert",
+                "delete"
+            ],
+            "x-enum-varnames": [
+                "CrdtOperationInsert",
+                "CrdtOperationDelete"
+            ]
+        },
+        "model.DailyNoteSettingsApi": {
+            "type": "object",
+            "properties": {
+                "FolderId": {
+                    "type": "integer"
+                },
+                "TemplateId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.DanglingLinkApi": {
+            "type": "object",
+            "properties": {
+                "Source": {
+                    "type": "integer"
+                },
+                "Target": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.EditorFormat": {
+            "type": "string",
+            "enum": [
+                "markdown",
+                "plain"
+            ],
+            "x-enum-varnames": [
+                "EditorFormatMarkdown",
+                "EditorFormatPlain"
+            ]
+        },
+        "model.ErrorCode": {
+            "type": "string",
+            "enum": [
+                "INTERNAL_ERROR",
+                "INVALID_REQUEST",
+                "UNAUTHORIZED",
+                "ENTITY_NOT_FOUND",
+                "DATABASE_ERROR",
+                "USER_NOT_FOUND",
+                "CONFLICT",
+                "INVALID_CREDENTIALS",
+                "NOTE_TYPE_UNKNOWN",
+                "NOTE_TITLE_EMPTY",
+                "NOTE_CONTENT_EMPTY",
+                "NOTE_CONTENT_TOO_LONG",
+                "NOTE_TOO_MANY_TAGS",
+                "NOTE_TITLE_TAKEN",
+                "NOTE_IS_NOT_CHECKLIST",
+                "POSITION_ANCHOR_NOT_FOUND",
+                "POSITION_INVALID",
+                "CHECKLIST_ITEM_EMPTY",
+                "CHECKLIST_ITEM_MULTILINE",
+                "CHECKLIST_ITEM_TOO_LONG",
+                "FOLDER_TITLE_EMPTY",
+                "FOLDER_TITLE_TAKEN",
+                "FOLDER_ARCHIVED",
+                "USER_NAME_EMPTY",
+                "USER_SURNAME_EMPTY",
+                "LOGIN_TOO_SHORT",
+                "LOGIN_TAKEN",
+                "PASSWORD_TOO_SHORT",
+                "PASSWORD_NO_UPPERCASE",
+                "PASSWORD_NO_LOWERCASE",
+                "PASSWORD_NO_DIGIT",
+                "PASSWORD_NO_SPECIAL",
+                "TIMEZONE_UNKNOWN",
+                "TOKEN_ISSUE_FAILED",
+                "TOKEN_SIGNING_METHOD_INVALID",
+                "TOKEN_INVALID",
+                "HASH_FAILED",
+                "HASH_CHECK_FAILED",
+                "LOCALE_UNSUPPORTED",
+                "SORT_ORDER_UNKNOWN",
+                "PAGE_SIZE_OUT_OF_RANGE",
+                "EDITOR_FORMAT_UNKNOWN",
+                "TEMPLATE_NAME_EMPTY",
+                "TEMPLATE_NAME_TAKEN",
+                "TEMPLATE_TITLE_EMPTY",
+                "TEMPLATE_PROMPT_NAME_EMPTY",
+                "TEMPLATE_VARIABLE_UNKNOWN",
+                "TEMPLATE_VALUES_MISSING",
+                "DAILY_TEMPLATE_HAS_PROMPTS",
+                "DATE_INVALID",
+                "FILE_NAME_EMPTY",
+                "FILE_NAME_TOO_LONG",
+                "FILE_EMPTY",
+                "FILE_TOO_LARGE",
+                "FILE_NOT_FOUND",
+                "FILE_STORAGE_ERROR",
+                "ATTACHMENT_READ_FAILED",
+                "THUMBNAIL_SIZE_UNSUPPORTED",
+                "THUMBNAIL_NOT_AVAILABLE",
+                "THUMBNAIL_PENDING",
+                "THUMBNAIL_FAILED",
+                "THUMBNAIL_ENCODE_FAILED",
+                "IMAGE_READ_FAILED",
+                "IMAGE_FORMAT_UNSUPPORTED",
+                "IMAGE_TOO_LARGE",
+                "IMAGE_DECODE_FAILED",
+                "RECURRENCE_COUNT_AND_UNTIL",
+                "RECURRENCE_UNSUPPORTED",
+                "REMINDER_IN_PAST",
+                "REMINDER_EMAIL_REQUIRED",
+                "REMINDER_WEBHOOK_REQUIRED",
+                "EMAIL_INVALID",
+                "WEBHOOK_URL_INVALID",
+                "NOTIFICATION_CHANNEL_UNKNOWN",
+                "NOTIFICATION_CHANNEL_DISABLED",
+                "INTERVAL_INVALID",
+                "INTERVAL_TOO_LONG",
+                "EMAIL_ADDRESS_MISSING",
+                "EMAIL_SEND_FAILED",
+                "WEBHOOK_URL_MISSING",
+                "WEBHOOK_PAYLOAD_FAILED",
+                "WEBHOOK_CALL_FAILED",
+                "WEBHOOK_BAD_STATUS",
+                "CALENDAR_TOKEN_FAILED",
+                "IMPORT_STRATEGY_UNKNOWN",
+                "IMPORT_ARCHIVE_INVALID",
+                "IMPORT_FILE_READ_FAILED",
+                "IMPORT_FRONT_MATTER_INVALID",
+                "IMPORT_VERSION_UNSUPPORTED",
+                "EXPORT_FORMAT_UNKNOWN",
+                "SYNC_TOKEN_INVALID",
+                "SYNC_TOO_MANY_MUTATIONS",
+                "SYNC_MUTATION_UNKNOWN",
+                "SYNC_CLIENT_ID_REQUIRED",
+                "SYNC_CLIENT_ID_UNKNOWN",
+                "SYNC_CLIENT_ID_TOO_LONG",
+                "SYNC_VERSION_CONFLICT",
+                "SYNC_ENTITY_DELETED",
+                "WEBHOOK_EVENTS_EMPTY",
+                "WEBHOOK_EVENT_UNKNOWN",
+                "WEBHOOK_TOO_MANY",
+                "WEBHOOK_SECRET_FAILED",
+                "WEBHOOK_DISABLED",
+                "FORBIDDEN",
+                "AUDIT_RANGE_INVALID",
+                "AUDIT_ACTION_UNKNOWN",
+                "COMMENT_EMPTY",
+                "COMMENT_TOO_LONG",
+                "COMMENT_ANCHOR_INVALID",
+                "COMMENT_NOT_THREAD",
+                "COMMENT_NOT_AUTHOR",
+                "NOTIFICATION_TYPE_UNKNOWN",
+                "CRDT_OPERATION_UNKNOWN",
+                "CRDT_ELEMENT_UNKNOWN",
+                "CRDT_INSERT_INVALID",
+                "COLLAB_SITE_FOREIGN",
+                "COLLAB_MESSAGE_UNKNOWN",
+                "NOTE_LOCKED",
+                "WEBHOOK_URL_FORBIDDEN",
+                "NOTE_LINK_REWRITE_LOCKED"
+            ],
+            "x-enum-varnames": [
+                "CodeInternalError",
+                "CodeInvalidRequest",
+                "CodeUnauthorized",
+                "CodeEntityNotFound",
+                "CodeDatabaseError",
+                "CodeUserNotFound",
+                "CodeConflict",
+                "CodeInvalidCredentials",
+                "CodeNoteTypeUnknown",
+                "CodeNoteTitleEmpty",
+                "CodeNoteContentEmpty",
+                "CodeNoteContentTooLong",
+                "CodeNoteTooManyTags",
+                "CodeNoteTitleTaken",
+                "CodeNoteIsNotChecklist",
+                "CodePositionAnchorNotFound",
+                "CodePositionInvalid",
+                "CodeChecklistItemEmpty",
+                "CodeChecklistItemMultiline",
+                "CodeChecklistItemTooLong",
+                "CodeFolderTitleEmpty",
+                "CodeFolderTitleTaken",
+                "CodeFolderArchived",
+                "CodeUserNameEmpty",
+                "CodeUserSurnameEmpty",
+                "CodeLoginTooShort",
+                "CodeLoginTaken",
+                "CodePasswordTooShort",
+                "CodePasswordNoUppercase",
+                "CodePasswordNoLowercase",
+                "CodePasswordNoDigit",
+                "CodePasswordNoSpecial",
+                "CodeTimezoneUnknown",
+                "CodeTokenIssueFailed",
+                "CodeTokenSigningMethod",
+                "CodeTokenInvalid",
+                "CodeHashFailed",
+                "CodeHashCheckFailed",
+                "CodeLocaleUnsupported",
+                "CodeSortOrderUnknown",
+                "CodePageSizeOutOfRange",
+                "CodeEditorFormatUnknown",
+                "CodeTemplateNameEmpty",
+                "CodeTemplateNameTaken",
+                "CodeTemplateTitleEmpty",
+                "CodeTemplatePromptNameEmpty",
+                "CodeTemplateVariableUnknown",
+                "CodeTemplateValuesMissing",
+                "CodeDailyTemplateHasPrompts",
+                "CodeDateInvalid",
+                "CodeFileNameEmpty",
+                "CodeFileNameTooLong",
+                "CodeFileEmpty",
+                "CodeFileTooLarge",
+                "CodeFileNotFound",
+                "CodeFileStorageError",
+                "CodeAttachmentReadFailed",
+                "CodeThumbnailSizeUnsupported",
+                "CodeThumbnailNotAvailable",
+                "CodeThumbnailPending",
+                "CodeThumbnailFailed",
+                "CodeThumbnailEncodeFailed",
+                "CodeImageReadFailed",
+                "CodeImageFormatUnsupported",
+                "CodeImageTooLarge",
+                "CodeImageDecodeFailed",
+                "CodeRecurrenceCountAndUntil",
+                "CodeRecurrenceUnsupported",
+                "CodeReminderInPast",
+                "CodeReminderEmailRequired",
+                "CodeReminderWebhookRequired",
+                "CodeEmailInvalid",
+                "CodeWebhookUrlInvalid",
+                "CodeNotificationChannelUnknown",
+                "CodeNotificationChannelOff",
+                "CodeIntervalInvalid",
+                "CodeIntervalTooLong",
+                "CodeEmailAddressMissing",
+                "CodeEmailSendFailed",
+                "CodeWebhookUrlMissing",
+                "CodeWebhookPayloadFailed",
+                "CodeWebhookCallFailed",
+                "CodeWebhookBadStatus",
+                "CodeCalendarTokenFailed",
+                "CodeImportStrategyUnknown",
+                "CodeImportArchiveInvalid",
+                "CodeImportFileReadFailed",
+                "CodeImportFrontMatterInvalid",
+                "CodeImportVersionUnsupported",
+                "CodeExportFormatUnknown",
+                "CodeSyncTokenInvalid",
+                "CodeSyncTooManyMutations",
+                "CodeSyncMutationUnknown",
+                "CodeSyncClientIdRequired",
+                "CodeSyncClientIdUnknown",
+                "CodeSyncClientIdTooLong",
+                "CodeSyncVersionConflict",
+                "CodeSyncEntityDeleted",
+                "CodeWebhookEventsEmpty",
+                "CodeWebhookEventUnknown",
+                "CodeWebhookTooMany",
+                "CodeWebhookSecretFailed",
+                "CodeWebhookDisabled",
+                "CodeForbidden",
+                "CodeAuditRangeInvalid",
+                "CodeAuditActionUnknown",
+                "CodeCommentEmpty",
+                "CodeCommentTooLong",
+                "CodeCommentAnchorInvalid",
+                "CodeCommentNotThread",
+                "CodeCommentNotAuthor",
+                "CodeNotificationTypeUnknown",
+                "CodeCrdtOperationUnknown",
+                "CodeCrdtElementUnknown",
+                "CodeCrdtInsertInvalid",
+                "CodeCollabSiteForeign",
+                "CodeCollabMessageUnknown",
+                "CodeNoteLocked",
+                "CodeWebhookUrlForbidden",
+                "CodeNoteLinkRewriteLocked"
+            ]
+        },
+        "model.ErrorParams": {
+            "type": "object",
+            "additionalProperties": {}
+        },
+        "model.Event": {
+            "type": "object",
+            "properties": {
+                "FolderId": {
+                    "type": "integer"
+                },
+                "Id": {
+                    "type": "integer"
+                },
+                "IsFavorite": {
+                    "type": "boolean"
+                },
+                "NoteId": {
+                    "type": "integer"
+                },
+                "Timestamp": {
+                    "type": "string"
+                },
+                "Title": {
+                    "type": "string"
+                },
+                "Type": {
+                    "$ref": "#/definitions/model.EventType"
+                }
+            }
+        },
+        "model.EventType": {
+            "type": "string",
+            "enum": [
+                "note.created",
+                "note.updated",
+                "note.deleted",
+                "note.moved",
+                "note.favorite",
+                "folder.created",
+                "folder.renamed",
+                "folder.deleted",
+                "folder.updated",
+                "webhook.test"
+            ],
+            "x-enum-varnames": [
+                "EventNoteCreated",
+                "EventNoteUpdated",
+                "EventNoteDeleted",
+                "EventNoteMoved",
+                "EventNoteFavorite",
+                "EventFolderCreated",
+                "EventFolderRenamed",
+                "EventFolderDeleted",
+                "EventFolderUpdated",
+                "EventWebhookTest"
+            ]
+        },
+        "model.FolderApi": {
+            "type": "object",
+            "properties": {
+                "Id": {
+                    "type": "integer"
+                },
+                "Notes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.NoteApi"
+                    }
+                },
+                "Position": {
+                    "type": "string"
+                },
+                "Timestamp": {
+                    "type": "string"
+                },
+                "Title": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.GraphApi": {
+            "type": "object",
+            "properties": {
+                "Dangling": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.DanglingLinkApi"
+                    }
+                },
+                "Edges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GraphEdgeApi"
+                    }
+                },
+                "Nodes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GraphNodeApi"
+                    }
+                }
+            }
+        },
+        "model.GraphEdgeApi": {
+            "type": "object",
+            "properties": {
+                "Source": {
+                    "type": "integer"
+                },
+                "Target": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.GraphNodeApi": {
+            "type": "object",
+            "properties": {
+                "FolderId": {
+                    "type": "integer"
+                },
+                "Id": {
+                    "type": "integer"
+                },
+                "IsArchived": {
+                    "type": "boolean"
+                },
+                "Title": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ImportConflictStrategy": {
+            "type": "string",
+            "enum": [
+                "skip",
+                "rename",
+                "overwrite"
+            ],
+            "x-enum-varnames": [
+                "ImportConflictSkip",
+                "ImportConflictRename",
+                "ImportConflictOverwrite"
+            ]
+        },
+        "model.ImportFileStatus": {
+            "type": "string",
+            "enum": [
+                "imported",
+                "renamed",
+                "overwritten",
+                "skipped",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "ImportFileStatusImported",
+                "ImportFileStatusRenamed",
+                "ImportFileStatusOverwritten",
+                "ImportFileStatusSkipped",
+                "ImportFileStatusFailed"
+            ]
+        },
+        "model.ImportJobApi": {
+            "type": "object",
+            "properties": {
+                "Error": {
+                    "type": "string"
+                },
+                "Failed": {
+                    "type": "integer"
+                },
+                "FileName": {
+                    "type": "string"
+                },
+                "Files": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ImportJobFileApi"
+                    }
+                },
+                "FinishedAt": {
+                    "type": "string"
+                },
+                "Id": {
+                    "type": "integer"
+                },
+                "Imported": {
+                    "type": "integer"
+                },
+                "Processed": {
+                    "type": "integer"
+                },
+                "Skipped": {
+                    "type": "integer"
+                },
+                "Source": {
+                    "$ref": "#/definitions/model.ImportSource"
+                },
+                "Status": {
+                    "$ref": "#/definitions/model.ImportJobStatus"
+                },
+                "Strategy": {
+                    "$ref": "#/definitions/model.ImportConflictStrategy"
+                },
+                "Timestamp": {
+                    "type": "string"
+                },
+                "Total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ImportJobFileApi": {
+            "type": "object",
+            "properties": {
+                "Code": {
+                    "$ref": "#/definitions/model.ErrorCode"
+                },
+                "Message": {
+                    "type": "string"
+                },
+                "NoteId": {
+                    "type": "integer"
+                },
+                "Path": {
+                    "type": "string"
+                },
+                "Status": {
+                    "$ref": "#/definitions/model.ImportFileStatus"
+                }
+            }
+        },
+        "model.ImportJobStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "running",
+                "completed",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "ImportJobStatusPending",
+                "ImportJobStatusRunning",
+                "ImportJobStatusCompleted",
+                "ImportJobStatusFailed"
+            ]
+        },
+        "model.ImportSource": {
+            "type": "string",
+            "enum": [
+                "markdown",
+                "json",
+                "enex"
+            ],
+            "x-enum-varnames": [
+                "ImportSourceMarkdown",
+                "ImportSourceJson",
+                "ImportSourceEnex"
+            ]
+        },
+        "model.Locale": {
+            "type": "string",
+            "enum": [
+                "ru",
+                "ru",
+                "en"
+            ],
+            "x-enum-varnames": [
+                "DefaultLocale",
+                "LocaleRu",
+                "LocaleEn"
+            ]
+        },
+        "model.Note": {
+            "type": "object",
+            "properties": {
+                "ChangeSeq": {
+                    "type": "integer"
+                },
+                "ClientId": {
+                    "type": "string"
+                },
+                "Content": {
+                    "type": "string"
+                },
+                "FolderId": {
+                    "type": "integer"
+                },
+                "Id": {
+                    "type": "integer"
+                },
+                "IsArchived": {
+                    "type": "boolean"
+                },
+                "IsFavorite": {
+                    "type": "boolean"
+                },
+                "IsPinned": {
+                    "type": "boolean"
+                },
+                "Position": {
+                    "type": "string"
+                },
+                "Tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "Timestamp": {
+                    "type": "string"
+                },
+                "Title": {
+                    "type": "string"
+                },
+                "Type": {
+                    "$ref": "#/definitions/model.NoteType"
+                },
+                "UserId": {
+                    "type": "integer"
+                },
+                "Version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.NoteApi": {
+            "type": "object",
+            "properties": {
+                "CommentCount": {
+                    "type": "integer"
+                },
+                "Completion": {
+                    "type": "integer"
+                },
+                "Content": {
+                    "type": "string"
+                },
+                "Id": {
+                    "type": "integer"
+                },
+                "IsArchived": {
+                    "type": "boolean"
+                },
+                "IsFavorite": {
+                    "type": "boolean"
+                },
+                "IsPinned": {
+                    "type": "boolean"
+                },
+                "Items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ChecklistItemApi"
+                    }
+                },
+                "Lock": {
+                    "$ref": "#/definitions/model.NoteLockApi"
+                },
+                "Position": {
+                    "type": "string"
+                },
+                "Tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "ThumbnailUrl": {
+                    "type": "string"
+                },
+                "Timestamp": {
+                    "type": "string"
+                },
+                "Title": {
+                    "type": "string"
+                },
+                "Type": {
+                    "$ref": "#/definitions/model.NoteType"
+                }
+            }
+        },
+        "model.NoteLockApi": {
+            "type": "object",
+            "properties": {
+                "ExpiresAt": {
+                    "type": "string"
+                },
+                "UserId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.NoteLockGrantApi": {
+            "type": "object",
+            "properties": {
+                "ExpiresAt": {
+                    "type": "string"
+                },
+                "Token": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.NoteType": {
+            "type": "string",
+            "enum": [
+                "text",
+                "checklist"
+            ],
+            "x-enum-varnames": [
+                "NoteTypeText",
+                "NoteTypeChecklist"
+            ]
+        },
+        "model.Notebook": {
+            "description": "Notebook information",
+            "type": "object",
+            "properties": {
+                "folders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.FolderApi"
+                    }
+                },
+                "hasMore": {
+                    "type": "boolean"
+                },
+                "notes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.NoteApi"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "pageSize": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.NotificationApi": {
+            "type": "object",
+            "properties": {
+                "ActorId": {
+                    "type": "integer"
+                },
+                "Body": {
+                    "type": "string"
+                },
+                "CommentId": {
+                    "type": "integer"
+                },
+                "Id": {
+                    "type": "integer"
+                },
+                "IsRead": {
+                    "type": "boolean"
+                },
+                "NoteId": {
+                    "type": "integer"
+                },
+                "Timestamp": {
+                    "type": "string"
+                },
+                "Title": {
+                    "type": "string"
+                },
+                "Type": {
+                    "$ref": "#/definitions/model.NotificationType"
+                }
+            }
+        },
+        "model.NotificationType": {
+            "type": "string",
+            "enum": [
+                "reminder",
+                "mention"
+            ],
+            "x-enum-varnames": [
+                "NotificationTypeReminder",
+                "NotificationTypeMention"
+            ]
+        },
+        "model.NotificationsApi": {
+            "type": "object",
+            "properties": {
+                "NextBeforeId": {
+                    "type": "integer"
+                },
+                "Notifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.NotificationApi"
+                    }
+                }
+            }
+        },
+        "model.PreferencesApi": {
+            "type": "object",
+            "properties": {
+                "DefaultFolderId": {
+                    "type": "integer"
+                },
+                "EditorFormat": {
+                    "$ref": "#/definitions/model.EditorFormat"
+                },
+                "Locale": {
+                    "$ref": "#/definitions/model.Locale"
+                },
+                "Notifications": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "boolean"
+                    }
+                },
+                "PageSize": {
+                    "type": "integer"
+                },
+                "SortOrder": {
+                    "$ref": "#/definitions/model.SortOrder"
+                },
+                "Timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Problem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.ErrorCode"
+                        }
+                    ],
+                    "example": "NOTE_TITLE_TAKEN"
+                },
+                "detail": {
+                    "type": "string",
+                    "example": "Заметка с таким названием уже добавлена"
+                },
+                "details": {
+                    "$ref": "#/definitions/model.ErrorParams"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ProblemField"
+                    }
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "urn:uuid:3f2b8c1e-6d0a-4a8e-9a63-0c9f5d0b7e21"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 409
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Conflict"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "/problems/note-title-taken"
+                }
+            }
+        },
+        "model.ProblemField": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.ErrorCode"
+                        }
+                    ],
+                    "example": "NOTE_TITLE_EMPTY"
+                },
+                "details": {
+                    "$ref": "#/definitions/model.ErrorParams"
+                },
+                "field": {
+                    "type": "string",
+                    "example": "Title"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Название заметки не может быть пустым"
+                }
+            }
+        },
+        "model.ReminderApi": {
+            "type": "object",
+            "properties": {
+                "Channels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "DueAt": {
+                    "type": "string"
+                },
+                "Email": {
+                    "type": "string"
+                },
+                "IsActive": {
+                    "type": "boolean"
+                },
+                "NoteId": {
+                    "type": "integer"
+                },
+                "Recurrence": {
+                    "type": "string"
+                },
+                "RemindAt": {
+                    "type": "string"
+                },
+                "WebhookUrl": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ReminderOccurrenceApi": {
+            "type": "object",
+            "properties": {
+                "DueAt": {
+                    "type": "string"
+                },
+                "NoteId": {
+                    "type": "integer"
+                },
+                "Recurrence": {
+                    "type": "string"
+                },
+                "RemindAt": {
+                    "type": "string"
+                },
+                "Title": {
+                    "type": "string"
+                }
             }
-        }
-    },
-    "definitions": {
-        "handler.AuthReq": {
-            "description": "User authentication credentials",
-            "type": "object",
-            "required": [
-                "Login",
-                "Password"
+        },
+        "model.SortOrder": {
+            "type": "string",
+            "enum": [
+                "manual",
+                "title",
+                "updated"
             ],
+            "x-enum-varnames": [
+                "SortOrderManual",
+                "SortOrderTitle",
+                "SortOrderUpdated"
+            ]
+        },
+        "model.SyncChanges": {
+            "type": "object",
             "properties": {
-                "Login": {
-                    "type": "string",
-                    "example": "user123"
+                "Folders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SyncFolder"
+                    }
                 },
-                "Password": {
-                    "type": "string",
-                    "example": "securePassword123"
+                "Notes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SyncNote"
+                    }
+                },
+                "Token": {
+                    "type": "string"
+                },
+                "Tombstones": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Tombstone"
+                    }
                 }
             }
         },
-        "handler.FolderReq": {
-            "type": "object",
-            "required": [
-                "Title"
+        "model.SyncEntity": {
+            "type": "string",
+            "enum": [
+                "note",
+                "folder"
             ],
+            "x-enum-varnames": [
+                "SyncEntityNote",
+                "SyncEntityFolder"
+            ]
+        },
+        "model.SyncFolder": {
+            "type": "object",
             "properties": {
+                "ClientId": {
+                    "type": "string"
+                },
+                "Id": {
+                    "type": "integer"
+                },
+                "IsArchived": {
+                    "type": "boolean"
+                },
+                "Position": {
+                    "type": "string"
+                },
+                "Timestamp": {
+                    "type": "string"
+                },
                 "Title": {
-                    "type": "string",
-                    "example": "My Folder"
+                    "type": "string"
+                },
+                "Version": {
+                    "type": "integer"
                 }
             }
         },
-        "handler.MoveNoteRq": {
+        "model.SyncMutation": {
             "type": "object",
-            "required": [
-                "FolderId"
-            ],
             "properties": {
+                "ClientId": {
+                    "type": "string"
+                },
+                "Content": {
+                    "type": "string"
+                },
+                "Entity": {
+                    "$ref": "#/definitions/model.SyncEntity"
+                },
+                "FolderClientId": {
+                    "type": "string"
+                },
                 "FolderId": {
-                    "type": "integer",
-                    "example": 1
+                    "type": "integer"
+                },
+                "Id": {
+                    "type": "integer"
+                },
+                "IsFavorite": {
+                    "type": "boolean"
+                },
+                "LockToken": {
+                    "description": "LockToken - токен аренды заметки, если клиент её арендовал",
+                    "type": "string"
+                },
+                "Operation": {
+                    "$ref": "#/definitions/model.SyncOperation"
+                },
+                "Tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "Title": {
+                    "type": "string"
+                },
+                "Version": {
+                    "type": "integer"
                 }
             }
         },
-        "handler.NoteRq": {
+        "model.SyncNote": {
             "type": "object",
-            "required": [
-                "Title"
-            ],
             "properties": {
+                "ClientId": {
+                    "type": "string"
+                },
                 "Content": {
-                    "type": "string",
-                    "example": "Note content"
+                    "type": "string"
+                },
+                "FolderId": {
+                    "type": "integer"
+                },
+                "Id": {
+                    "type": "integer"
+                },
+                "IsArchived": {
+                    "type": "boolean"
+                },
+                "IsFavorite": {
+                    "type": "boolean"
+                },
+                "IsPinned": {
+                    "type": "boolean"
+                },
+                "Items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ChecklistItemApi"
+                    }
+                },
+                "Position": {
+                    "type": "string"
                 },
                 "Tags": {
                     "type": "array",
                     "items": {
                         "type": "string"
-                    },
-                    "example": [
-                        "tag1",
-                        "tag2"
-                    ]
+                    }
+                },
+                "Timestamp": {
+                    "type": "string"
                 },
                 "Title": {
-                    "type": "string",
-                    "example": "My Note"
+                    "type": "string"
+                },
+                "Type": {
+                    "$ref": "#/definitions/model.NoteType"
+                },
+                "Version": {
+                    "type": "integer"
                 }
             }
         },
-        "handler.UserReq": {
-            "description": "User creation/update request",
-            "type": "object",
-            "required": [
-                "Login",
-                "Password"
+        "model.SyncOperation": {
+            "type": "string",
+            "enum": [
+                "create",
+                "update",
+                "delete"
             ],
+            "x-enum-varnames": [
+                "SyncOperationCreate",
+                "SyncOperationUpdate",
+                "SyncOperationDelete"
+            ]
+        },
+        "model.SyncResult": {
+            "type": "object",
             "properties": {
-                "Login": {
-                    "type": "string",
-                    "example": "user123"
+                "ClientId": {
+                    "type": "string"
                 },
-                "Name": {
-                    "type": "string",
-                    "example": "John"
+                "Code": {
+                    "$ref": "#/definitions/model.ErrorCode"
                 },
-                "Password": {
-                    "type": "string",
-                    "example": "securePassword123"
+                "Entity": {
+                    "$ref": "#/definitions/model.SyncEntity"
                 },
-                "Surname": {
-                    "type": "string",
-                    "example": "Doe"
+                "Folder": {
+                    "$ref": "#/definitions/model.SyncFolder"
+                },
+                "Id": {
+                    "type": "integer"
+                },
+                "Message": {
+                    "type": "string"
+                },
+                "Note": {
+                    "$ref": "#/definitions/model.SyncNote"
+                },
+                "Operation": {
+                    "$ref": "#/definitions/model.SyncOperation"
+                },
+                "Status": {
+                    "$ref": "#/definitions/model.SyncStatus"
+                },
+                "Version": {
+                    "type": "integer"
                 }
             }
         },
-        "handler.UserRsp": {
-            "description": "User response data",
+        "model.SyncStatus": {
+            "type": "string",
+            "enum": [
+                "applied",
+                "conflict",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "SyncStatusApplied",
+                "SyncStatusConflict",
+                "SyncStatusFailed"
+            ]
+        },
+        "model.TemplateApi": {
             "type": "object",
             "properties": {
-                "Id": {
-                    "type": "integer",
-                    "example": 1
+                "Content": {
+                    "type": "string"
                 },
-                "Login": {
-                    "type": "string",
-                    "example": "user123"
+                "FolderId": {
+                    "type": "integer"
+                },
+                "Id": {
+                    "type": "integer"
                 },
                 "Name": {
-                    "type": "string",
-                    "example": "John"
+                    "type": "string"
                 },
-                "Surname": {
-                    "type": "string",
-                    "example": "Doe"
+                "Prompts": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "Tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "Timestamp": {
+                    "type": "string"
+                },
+                "Title": {
+                    "type": "string"
                 }
             }
         },
-        "handler.response": {
+        "model.ThumbnailStatus": {
+            "type": "string",
+            "enum": [
+                "none",
+                "pending",
+                "ready",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "ThumbnailStatusNone",
+                "ThumbnailStatusPending",
+                "ThumbnailStatusReady",
+                "ThumbnailStatusFailed"
+            ]
+        },
+        "model.Tombstone": {
             "type": "object",
             "properties": {
-                "error": {
-                    "type": "string",
-                    "example": "message"
+                "Entity": {
+                    "$ref": "#/definitions/model.SyncEntity"
+                },
+                "EntityId": {
+                    "type": "integer"
+                },
+                "Timestamp": {
+                    "type": "string"
                 }
             }
         },
-        "model.Folder": {
+        "model.UnreadNotificationsApi": {
             "type": "object",
             "properties": {
-                "id": {
+                "Count": {
                     "type": "integer"
-                },
-                "notes": {
+                }
+            }
+        },
+        "model.WebhookApi": {
+            "type": "object",
+            "properties": {
+                "Events": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.Note"
+                        "type": "string"
                     }
                 },
-                "timestamp": {
+                "Id": {
+                    "type": "integer"
+                },
+                "IsActive": {
+                    "type": "boolean"
+                },
+                "Timestamp": {
                     "type": "string"
                 },
-                "title": {
+                "Url": {
                     "type": "string"
                 }
             }
         },
-        "model.Note": {
+        "model.WebhookAttemptApi": {
             "type": "object",
             "properties": {
-                "content": {
-                    "type": "string"
-                },
-                "id": {
+                "DurationMs": {
                     "type": "integer"
                 },
-                "isFavorite": {
-                    "type": "boolean"
-                },
-                "tags": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "timestamp": {
+                "Error": {
                     "type": "string"
                 },
-                "title": {
+                "StatusCode": {
+                    "type": "integer"
+                },
+                "Timestamp": {
                     "type": "string"
                 }
             }
         },
-        "model.Notebook": {
-            "description": "Notebook information",
+        "model.WebhookDeliveryApi": {
             "type": "object",
             "properties": {
-                "folders": {
+                "Attempts": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.Folder"
+                        "$ref": "#/definitions/model.WebhookAttemptApi"
                     }
                 },
-                "notes": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.Note"
-                    }
+                "DeliveredAt": {
+                    "type": "string"
+                },
+                "Error": {
+                    "type": "string"
+                },
+                "EventType": {
+                    "$ref": "#/definitions/model.EventType"
+                },
+                "Id": {
+                    "type": "integer"
+                },
+                "NextAttemptAt": {
+                    "type": "string"
+                },
+                "Payload": {
+                    "type": "object"
+                },
+                "Status": {
+                    "$ref": "#/definitions/model.WebhookDeliveryStatus"
+                },
+                "StatusCode": {
+                    "type": "integer"
+                },
+                "Timestamp": {
+                    "type": "string"
                 }
             }
+        },
+        "model.WebhookDeliveryStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "delivered",
+                "dead"
+            ],
+            "x-enum-varnames": [
+                "WebhookDeliveryStatusPending",
+                "WebhookDeliveryStatusDelivered",
+                "WebhookDeliveryStatusDead"
+            ]
         }
     },
     "securityDefinitions": {
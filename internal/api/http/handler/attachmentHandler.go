@@ -0,0 +1,241 @@
+package handler
+
+import (
+	"Notes/config"
+	"Notes/internal/model"
+	"Notes/internal/service"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+)
+
+type AttachmentHandler struct {
+	attachmentService service.AbstractAttachmentService
+	maxFileSize       int64
+}
+
+func NewAttachmentHandler(s service.AbstractAttachmentService, cfg *config.Config) *AttachmentHandler {
+	return &AttachmentHandler{
+		attachmentService: s,
+		maxFileSize:       int64(cfg.Attachments.MaxFileSizeMb) * 1024 * 1024,
+	}
+}
+
+// UploadAttachment godoc
+// @Summary Upload an attachment
+// @Description Attach a file to the note of the authenticated user. Thumbnails for images are generated in background
+// @Tags attachments
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Note ID"
+// @Param file formData file true "File to attach"
+// @Success 200 {object} int "Returns ID of created attachment"
+// @Failure 400 {object} model.Problem "Invalid request data or ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Note not found"
+// @Failure 413 {object} model.Problem "File is too large"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/notes/{id}/attachments [post]
+func (a *AttachmentHandler) UploadAttachment(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	noteId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	fileHeader, ok := formFile(c, "file", a.maxFileSize)
+	if !ok {
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("Invalid request: %s", err.Error()))
+		return
+	}
+	defer file.Close()
+
+	id, errUpload := a.attachmentService.UploadAttachment(userId, noteId, filepath.Base(fileHeader.Filename), fileHeader.Size, file)
+
+	if errUpload != nil {
+		apiError := model.GetAppropriateApiError(errUpload)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": id})
+}
+
+// GetAttachments godoc
+// @Summary Get note attachments
+// @Description Get all attachments of the note for the authenticated user
+// @Tags attachments
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Note ID"
+// @Success 200 {object} []model.AttachmentApi "Returns list of attachments"
+// @Failure 400 {object} model.Problem "Invalid ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Note not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/notes/{id}/attachments [get]
+func (a *AttachmentHandler) GetAttachments(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	noteId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	attachments, errGet := a.attachmentService.GetAttachments(userId, noteId)
+
+	if errGet != nil {
+		apiError := model.GetAppropriateApiError(errGet)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"attachments": attachments,
+	})
+}
+
+// DownloadAttachment godoc
+// @Summary Download an attachment
+// @Description Download the original file of the attachment for the authenticated user
+// @Tags attachments
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param id path int true "Note ID"
+// @Param aid path int true "Attachment ID"
+// @Success 200 {file} file "Attachment content"
+// @Failure 400 {object} model.Problem "Invalid ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Attachment not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/notes/{id}/attachments/{aid} [get]
+func (a *AttachmentHandler) DownloadAttachment(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	noteId, attachmentId, ok := parseAttachmentParams(c)
+	if !ok {
+		return
+	}
+
+	attachment, content, errGet := a.attachmentService.GetAttachmentContent(userId, noteId, attachmentId)
+
+	if errGet != nil {
+		apiError := model.GetAppropriateApiError(errGet)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+	defer content.Close()
+
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName})
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, content, map[string]string{
+		"Content-Disposition": disposition,
+	})
+}
+
+// GetThumbnail godoc
+// @Summary Get attachment thumbnail
+// @Description Get the thumbnail of an image attachment for the authenticated user
+// @Tags attachments
+// @Produce image/jpeg
+// @Produce image/png
+// @Security BearerAuth
+// @Param id path int true "Note ID"
+// @Param aid path int true "Attachment ID"
+// @Param size query int false "Thumbnail size in pixels, the smallest configured size by default"
+// @Success 200 {file} file "Thumbnail content"
+// @Failure 400 {object} model.Problem "Invalid ID or size"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Thumbnail not found or not generated yet"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/notes/{id}/attachments/{aid}/thumbnail [get]
+func (a *AttachmentHandler) GetThumbnail(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	noteId, attachmentId, ok := parseAttachmentParams(c)
+	if !ok {
+		return
+	}
+
+	size := 0
+	if sizeParam := c.Query("size"); sizeParam != "" {
+		sizeInt, err := strconv.Atoi(sizeParam)
+		if err != nil {
+			errorResponse(c, http.StatusBadRequest, "Invalid size")
+			return
+		}
+		size = sizeInt
+	}
+
+	thumbnail, content, errGet := a.attachmentService.GetThumbnail(userId, noteId, attachmentId, size)
+
+	if errGet != nil {
+		apiError := model.GetAppropriateApiError(errGet)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+	defer content.Close()
+
+	c.DataFromReader(http.StatusOK, -1, thumbnail.ContentType, content, map[string]string{
+		"Cache-Control": "private, max-age=86400",
+	})
+}
+
+// DeleteAttachment godoc
+// @Summary Delete an attachment
+// @Description Delete the attachment and its thumbnails for the authenticated user
+// @Tags attachments
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Note ID"
+// @Param aid path int true "Attachment ID"
+// @Success 200 "Attachment deleted successfully"
+// @Failure 400 {object} model.Problem "Invalid ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/notes/{id}/attachments/{aid} [delete]
+func (a *AttachmentHandler) DeleteAttachment(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	noteId, attachmentId, ok := parseAttachmentParams(c)
+	if !ok {
+		return
+	}
+
+	errDelete := a.attachmentService.DeleteAttachment(userId, noteId, attachmentId)
+
+	if errDelete != nil {
+		apiError := model.GetAppropriateApiError(errDelete)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+func parseAttachmentParams(c *gin.Context) (int, int, bool) {
+	noteId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return 0, 0, false
+	}
+
+	attachmentId, err := strconv.Atoi(c.Param("aid"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid attachment ID")
+		return 0, 0, false
+	}
+
+	return noteId, attachmentId, true
+}
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type AuditHandler struct {
+	auditService service.AbstractAuditService
+}
+
+func NewAuditHandler(s service.AbstractAuditService) *AuditHandler {
+	return &AuditHandler{auditService: s}
+}
+
+// GetUserAudit godoc
+// @Summary Get own audit log
+// @Description Get actions of the authenticated user, newest first: logins including failed ones, profile and password changes, note and folder changes. Pass the Id of the last entry as beforeId to get the next page
+// @Tags audit
+// @Produce json
+// @Security BearerAuth
+// @Param action query string false "Action, e.g. note.moved"
+// @Param entity query string false "Entity: user, note or folder"
+// @Param entityId query int false "Entity ID"
+// @Param from query string false "Period start, RFC 3339"
+// @Param to query string false "Period end, RFC 3339"
+// @Param beforeId query int false "Return entries older than this one"
+// @Param limit query int false "Number of entries, 50 by default, at most 200"
+// @Success 200 {array} model.AuditEntryApi "Audit entries"
+// @Failure 400 {object} model.Problem "Invalid filter"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Router /api/user/audit [get]
+func (a *AuditHandler) GetUserAudit(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	filter, ok := parseAuditFilter(c)
+	if !ok {
+		return
+	}
+
+	entries, err := a.auditService.GetUserAudit(userId, filter)
+	if err != nil {
+		apiError := model.GetAppropriateApiError(err)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+// QueryAudit godoc
+// @Summary Query audit log
+// @Description Get audit entries of all users, newest first. Available to administrators listed in the configuration
+// @Tags audit
+// @Produce json
+// @Security BearerAuth
+// @Param actorId query int false "User who performed the action"
+// @Param action query string false "Action, e.g. auth.login_failed"
+// @Param entity query string false "Entity: user, note or folder"
+// @Param entityId query int false "Entity ID"
+// @Param ip query string false "Client IP address"
+// @Param traceId query string false "Request trace ID"
+// @Param from query string false "Period start, RFC 3339"
+// @Param to query string false "Period end, RFC 3339"
+// @Param beforeId query int false "Return entries older than this one"
+// @Param limit query int false "Number of entries, 50 by default, at most 200"
+// @Success 200 {array} model.AuditEntryApi "Audit entries"
+// @Failure 400 {object} model.Problem "Invalid filter"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 403 {object} model.Problem "The user is not an administrator"
+// @Router /api/admin/audit [get]
+func (a *AuditHandler) QueryAudit(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	filter, ok := parseAuditFilter(c)
+	if !ok {
+		return
+	}
+
+	if value := c.Query("actorId"); value != "" {
+		actorId, err := strconv.Atoi(value)
+		if err != nil {
+			errorResponse(c, http.StatusBadRequest, "Invalid actorId")
+			return
+		}
+		filter.ActorId = &actorId
+	}
+	filter.Ip = c.Query("ip")
+	filter.TraceId = c.Query("traceId")
+
+	entries, err := a.auditService.QueryAudit(userId, filter)
+	if err != nil {
+		apiError := model.GetAppropriateApiError(err)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, entries)
+}
+
+// parseAuditFilter читает общие для обоих журналов условия. При ошибке ответ уже отправлен.
+func parseAuditFilter(c *gin.Context) (model.AuditFilter, bool) {
+	filter := model.AuditFilter{
+		Action: model.AuditAction(c.Query("action")),
+		Entity: model.AuditEntity(c.Query("entity")),
+	}
+
+	if value := c.Query("entityId"); value != "" {
+		entityId, err := strconv.Atoi(value)
+		if err != nil {
+			errorResponse(c, http.StatusBadRequest, "Invalid entityId")
+			return filter, false
+		}
+		filter.EntityId = &entityId
+	}
+
+	for name, target := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		if value := c.Query(name); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				errorResponse(c, http.StatusBadRequest, "Invalid "+name)
+				return filter, false
+			}
+			*target = &parsed
+		}
+	}
+
+	for name, target := range map[string]*int{"beforeId": &filter.BeforeId, "limit": &filter.Limit} {
+		if value := c.Query(name); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				errorResponse(c, http.StatusBadRequest, "Invalid "+name)
+				return filter, false
+			}
+			*target = parsed
+		}
+	}
+
+	return filter, true
+}
+
+// newAuditEntry заполняет запись журнала аудита данными запроса: кто, откуда и в каком запросе
+func newAuditEntry(c *gin.Context, action model.AuditAction, entity model.AuditEntity, entityId int) *model.AuditEntry {
+	var actorId *int
+	if userId, exists := c.Get("UserId"); exists {
+		id := userId.(int)
+		actorId = &id
+	}
+
+	var entityIdPtr *int
+	if entityId > 0 {
+		entityIdPtr = &entityId
+	}
+
+	entry := model.NewAuditEntry(actorId, action, entity, entityIdPtr)
+	entry.SetOrigin(auditOrigin(c))
+
+	return entry
+}
+
+// auditOrigin возвращает данные запроса, с которыми сервисы записывают изменения в журнал аудита
+func auditOrigin(c *gin.Context) model.AuditOrigin {
+	return model.AuditOrigin{
+		Ip:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+		TraceId:   c.GetString("traceID"),
+	}
+}
@@ -9,7 +9,8 @@ import (
 )
 
 type AuthHandler struct {
-	authService service.AbstractAuthService
+	authService  service.AbstractAuthService
+	auditService service.AbstractAuditService
 }
 
 // AuthReq represents authentication request structure
@@ -19,8 +20,8 @@ type AuthReq struct {
 	Password string `json:"Password" example:"securePassword123$" binding:"required"`
 }
 
-func NewAuthHandler(service service.AbstractAuthService) *AuthHandler {
-	return &AuthHandler{authService: service}
+func NewAuthHandler(service service.AbstractAuthService, audit service.AbstractAuditService) *AuthHandler {
+	return &AuthHandler{authService: service, auditService: audit}
 }
 
 // Login godoc
@@ -31,9 +32,9 @@ func NewAuthHandler(service service.AbstractAuthService) *AuthHandler {
 // @Produce json
 // @Param input body AuthReq true "User credentials"
 // @Success 200 {object} string "Returns JWT token"
-// @Failure 400 {object} response
-// @Failure 401 {object} response
-// @Failure 500 {object} response
+// @Failure 400 {object} model.Problem
+// @Failure 401 {object} model.Problem
+// @Failure 500 {object} model.Problem
 // @Router /api/auth/login [post]
 func (a *AuthHandler) Login(c *gin.Context) {
 	var req AuthReq
@@ -46,10 +47,15 @@ func (a *AuthHandler) Login(c *gin.Context) {
 	token, err := a.authService.AuthUser(req.Login, req.Password)
 
 	if err != nil {
+		if err.Type == model.ErrorTypeAuth {
+			a.auditService.RecordLogin(newAuditEntry(c, model.AuditLoginFailed, model.AuditEntityUser, 0), req.Login)
+		}
+
 		apiError := model.GetAppropriateApiError(err)
 		errorResponseFromApiError(c, apiError)
 		return
 	}
+	a.auditService.RecordLogin(newAuditEntry(c, model.AuditLogin, model.AuditEntityUser, 0), req.Login)
 
 	c.JSON(http.StatusOK, gin.H{
 		"token": token,
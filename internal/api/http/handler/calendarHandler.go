@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strings"
+)
+
+type CalendarHandler struct {
+	calendarService service.AbstractCalendarService
+}
+
+func NewCalendarHandler(s service.AbstractCalendarService) *CalendarHandler {
+	return &CalendarHandler{calendarService: s}
+}
+
+// RegenerateFeedUrl godoc
+// @Summary Regenerate calendar feed URL
+// @Description Issue a new secret iCalendar feed URL for the authenticated user. The previous URL stops working immediately
+// @Tags calendar
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} string "Returns the feed URL"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/calendar/token [post]
+func (ch *CalendarHandler) RegenerateFeedUrl(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	url, err := ch.calendarService.RegenerateFeedUrl(userId)
+
+	if err != nil {
+		apiError := model.GetAppropriateApiError(err)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"url": url})
+}
+
+// RevokeFeed godoc
+// @Summary Disable calendar feed
+// @Description Revoke the iCalendar feed URL of the authenticated user
+// @Tags calendar
+// @Produce json
+// @Security BearerAuth
+// @Success 200 "Feed disabled successfully"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/calendar/token [delete]
+func (ch *CalendarHandler) RevokeFeed(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	err := ch.calendarService.RevokeFeed(userId)
+
+	if err != nil {
+		apiError := model.GetAppropriateApiError(err)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// GetFeed godoc
+// @Summary Get calendar feed
+// @Description Get the RFC 5545 feed of dated notes. Authorized by the secret token in the URL, so calendar clients can subscribe to it
+// @Tags calendar
+// @Produce text/calendar
+// @Param token path string true "Feed token with .ics extension"
+// @Success 200 {file} file "iCalendar feed"
+// @Failure 404 {object} model.Problem "Feed not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /calendar/{token}.ics [get]
+func (ch *CalendarHandler) GetFeed(c *gin.Context) {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+
+	feed, err := ch.calendarService.GetFeed(token)
+
+	if err != nil {
+		apiError := model.GetAppropriateApiError(err)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.Header("Content-Disposition", `inline; filename="notes.ics"`)
+	c.Header("Cache-Control", "private, no-cache")
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(feed))
+}
@@ -0,0 +1,277 @@
+package handler
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/service"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type ChecklistHandler struct {
+	checklistService service.AbstractChecklistService
+}
+
+type NoteTypeRq struct {
+	Type string `json:"Type" example:"checklist" binding:"required"`
+}
+
+type ChecklistItemRq struct {
+	Text     string `json:"Text" example:"Buy milk" binding:"required"`
+	Position *int   `json:"Position" example:"0"`
+}
+
+type ChecklistItemPositionRq struct {
+	Position *int `json:"Position" example:"0" binding:"required"`
+}
+
+func NewChecklistHandler(s service.AbstractChecklistService) *ChecklistHandler {
+	return &ChecklistHandler{checklistService: s}
+}
+
+// ChangeNoteType godoc
+// @Summary Convert note type
+// @Description Convert a text note to a checklist (one line per item) or a checklist back to text
+// @Tags checklists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Note ID"
+// @Param input body NoteTypeRq true "Target note type: text or checklist"
+// @Param X-Lock-Token header string false "Token of the edit lock held by the client"
+// @Success 200 "Note converted successfully"
+// @Failure 400 {object} model.Problem "Invalid request data or ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Note not found"
+// @Failure 423 {object} model.Problem "The note is locked by another client"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/notes/{id}/type [put]
+func (ch *ChecklistHandler) ChangeNoteType(c *gin.Context) {
+	var req NoteTypeRq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("Invalid request: %s", err.Error()))
+		return
+	}
+
+	userId := c.MustGet("UserId").(int)
+
+	noteId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	noteType, errType := model.ParseNoteType(req.Type)
+	if errType != nil {
+		apiError := model.GetAppropriateApiError(errType)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	errChange := service.WithOrigin(ch.checklistService, auditOrigin(c)).ChangeNoteType(userId, noteId, noteType, c.GetHeader(lockTokenHeader))
+
+	if errChange != nil {
+		apiError := model.GetAppropriateApiError(errChange)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// AddItem godoc
+// @Summary Add checklist item
+// @Description Add an item to the checklist note, to the end or at the given position
+// @Tags checklists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Note ID"
+// @Param input body ChecklistItemRq true "Item data"
+// @Param X-Lock-Token header string false "Token of the edit lock held by the client"
+// @Success 200 {object} int "Returns ID of created item"
+// @Failure 400 {object} model.Problem "Invalid request data, ID or note is not a checklist"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Note not found"
+// @Failure 423 {object} model.Problem "The note is locked by another client"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/notes/{id}/items [post]
+func (ch *ChecklistHandler) AddItem(c *gin.Context) {
+	var req ChecklistItemRq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("Invalid request: %s", err.Error()))
+		return
+	}
+
+	userId := c.MustGet("UserId").(int)
+
+	noteId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	id, errAdd := service.WithOrigin(ch.checklistService, auditOrigin(c)).AddItem(userId, noteId, req.Text, req.Position, c.GetHeader(lockTokenHeader))
+
+	if errAdd != nil {
+		apiError := model.GetAppropriateApiError(errAdd)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": id})
+}
+
+// MoveItem godoc
+// @Summary Reorder checklist item
+// @Description Move the checklist item to the given zero-based position
+// @Tags checklists
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Note ID"
+// @Param itemId path int true "Item ID"
+// @Param input body ChecklistItemPositionRq true "New position"
+// @Param X-Lock-Token header string false "Token of the edit lock held by the client"
+// @Success 200 "Item moved successfully"
+// @Failure 400 {object} model.Problem "Invalid request data, ID or note is not a checklist"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Note or item not found"
+// @Failure 423 {object} model.Problem "The note is locked by another client"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/notes/{id}/items/{itemId}/position [put]
+func (ch *ChecklistHandler) MoveItem(c *gin.Context) {
+	var req ChecklistItemPositionRq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("Invalid request: %s", err.Error()))
+		return
+	}
+
+	userId := c.MustGet("UserId").(int)
+
+	noteId, itemId, ok := parseChecklistItemParams(c)
+	if !ok {
+		return
+	}
+
+	errMove := service.WithOrigin(ch.checklistService, auditOrigin(c)).MoveItem(userId, noteId, itemId, *req.Position, c.GetHeader(lockTokenHeader))
+
+	if errMove != nil {
+		apiError := model.GetAppropriateApiError(errMove)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// CheckItem godoc
+// @Summary Check checklist item
+// @Description Mark the checklist item as done
+// @Tags checklists
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Note ID"
+// @Param itemId path int true "Item ID"
+// @Param X-Lock-Token header string false "Token of the edit lock held by the client"
+// @Success 200 "Item checked successfully"
+// @Failure 400 {object} model.Problem "Invalid ID or note is not a checklist"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Note or item not found"
+// @Failure 423 {object} model.Problem "The note is locked by another client"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/notes/{id}/items/{itemId}/checked [put]
+func (ch *ChecklistHandler) CheckItem(c *gin.Context) {
+	ch.setItemChecked(c, true)
+}
+
+// UncheckItem godoc
+// @Summary Uncheck checklist item
+// @Description Mark the checklist item as not done
+// @Tags checklists
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Note ID"
+// @Param itemId path int true "Item ID"
+// @Param X-Lock-Token header string false "Token of the edit lock held by the client"
+// @Success 200 "Item unchecked successfully"
+// @Failure 400 {object} model.Problem "Invalid ID or note is not a checklist"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Note or item not found"
+// @Failure 423 {object} model.Problem "The note is locked by another client"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/notes/{id}/items/{itemId}/checked [delete]
+func (ch *ChecklistHandler) UncheckItem(c *gin.Context) {
+	ch.setItemChecked(c, false)
+}
+
+// DeleteItem godoc
+// @Summary Delete checklist item
+// @Description Delete the item from the checklist note
+// @Tags checklists
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Note ID"
+// @Param itemId path int true "Item ID"
+// @Param X-Lock-Token header string false "Token of the edit lock held by the client"
+// @Success 200 "Item deleted successfully"
+// @Failure 400 {object} model.Problem "Invalid ID or note is not a checklist"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Note not found"
+// @Failure 423 {object} model.Problem "The note is locked by another client"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/notes/{id}/items/{itemId} [delete]
+func (ch *ChecklistHandler) DeleteItem(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	noteId, itemId, ok := parseChecklistItemParams(c)
+	if !ok {
+		return
+	}
+
+	errDelete := service.WithOrigin(ch.checklistService, auditOrigin(c)).DeleteItem(userId, noteId, itemId, c.GetHeader(lockTokenHeader))
+
+	if errDelete != nil {
+		apiError := model.GetAppropriateApiError(errDelete)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+func (ch *ChecklistHandler) setItemChecked(c *gin.Context, isChecked bool) {
+	userId := c.MustGet("UserId").(int)
+
+	noteId, itemId, ok := parseChecklistItemParams(c)
+	if !ok {
+		return
+	}
+
+	errCheck := service.WithOrigin(ch.checklistService, auditOrigin(c)).SetItemChecked(userId, noteId, itemId, isChecked, c.GetHeader(lockTokenHeader))
+
+	if errCheck != nil {
+		apiError := model.GetAppropriateApiError(errCheck)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+func parseChecklistItemParams(c *gin.Context) (int, int, bool) {
+	noteId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return 0, 0, false
+	}
+
+	itemId, err := strconv.Atoi(c.Param("itemId"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid item ID")
+		return 0, 0, false
+	}
+
+	return noteId, itemId, true
+}
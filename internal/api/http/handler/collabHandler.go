@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// collabReadLimit - наибольший размер сообщения участника
+const collabReadLimit = 1 << 20
+
+type CollabHandler struct {
+	collabService service.AbstractCollabService
+	upgrader      websocket.Upgrader
+}
+
+func NewCollabHandler(s service.AbstractCollabService) *CollabHandler {
+	return &CollabHandler{
+		collabService: s,
+		upgrader: websocket.Upgrader{
+			// Доступ проверяется по токену, как и у /api/ws
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+}
+
+// Connect godoc
+// @Summary Edit a note together over WebSocket
+// @Description Upgrade the connection to WebSocket and join the collaborative editing session of the note. The text is an RGA sequence CRDT: every character has an Id of a Lamport Counter and the Site of the participant who inserted it. The first message is init with the participant's Site, the document Clock, all characters including deleted ones and the participants. The client sends ops messages with insert operations (Id with its own Site and a Counter greater than any seen, After - the character to insert after, null for the start, Value - one character) and delete operations (Id of the character), and cursor messages with the Anchor and Head characters of its selection. The server sends ops of other participants and presence with the participants and their cursors. The text is saved to the note every few seconds and when the last participant leaves. If the text cannot be saved, participants get a save_failed message with the error; the edits stay in the document until the next edit makes it valid, and if the note was deleted the connection is closed. After a few seconds without edits the server removes deleted characters and sends init again with the next Generation; the client replaces its state with it and sends again its own edits that the new state does not contain. Every client message carries the Generation of the last init; messages of an older generation are ignored. A rejected message is answered with an error message and the connection is closed; the client should reconnect and start from the new init. Browsers may pass the token in the access_token query parameter
+// @Tags collab
+// @Security BearerAuth
+// @Param id path int true "Note ID"
+// @Param access_token query string false "JWT token for clients that cannot set the Authorization header"
+// @Success 101 {object} model.CollabServerMessage "Switching protocols, then a stream of messages"
+// @Failure 400 {object} model.Problem "Invalid ID or not a WebSocket request"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Note not found"
+// @Router /api/notes/{id}/collab [get]
+func (h *CollabHandler) Connect(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	noteId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	connection, errJoin := h.collabService.Join(userId, noteId)
+	if errJoin != nil {
+		apiError := model.GetAppropriateApiError(errJoin)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+	defer connection.Close()
+
+	// При ошибке Upgrader сам отвечает клиенту
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	// Чтение заканчивается с nil при закрытии соединения и с ошибкой, если сообщение отклонено
+	done := make(chan *model.ApplicationError, 1)
+	go h.readLoop(conn, connection, done)
+
+	ticker := time.NewTicker(wsPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case errSend := <-done:
+			if errSend == nil {
+				return
+			}
+
+			_ = conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			problem := model.NewProblem(model.GetAppropriateApiError(errSend), requestLocale(c), c.GetString("traceID"))
+			_ = conn.WriteJSON(&model.CollabServerMessage{Type: model.CollabMessageError, NoteId: noteId, Error: problem})
+			_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "message rejected"))
+			return
+		case message, ok := <-connection.Messages:
+			_ = conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if !ok {
+				// Участник отстал и пропустил сообщения
+				_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "messages dropped"))
+				return
+			}
+
+			if message.SaveError != nil {
+				localized := *message
+				localized.Error = model.NewProblem(model.GetAppropriateApiError(message.SaveError), requestLocale(c), c.GetString("traceID"))
+				message = &localized
+			}
+
+			if err = conn.WriteJSON(message); err != nil {
+				log.Printf("Не удалось отправить сообщение участнику %s заметки %d: %v", connection.Site(), noteId, err)
+				return
+			}
+		case <-ticker.C:
+			_ = conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if err = conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readLoop передаёт сообщения участника в документ, пока соединение открыто и сообщения принимаются
+func (h *CollabHandler) readLoop(conn *websocket.Conn, connection *service.CollabConnection, done chan<- *model.ApplicationError) {
+	conn.SetReadLimit(collabReadLimit)
+	_ = conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	})
+
+	for {
+		var message model.CollabClientMessage
+		if err := conn.ReadJSON(&message); err != nil {
+			done <- nil
+			return
+		}
+
+		// Сообщения участника тоже продлевают соединение
+		_ = conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+
+		if err := connection.Send(&message); err != nil {
+			done <- err
+			return
+		}
+	}
+}
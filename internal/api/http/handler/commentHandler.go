@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/service"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type CommentHandler struct {
+	commentService service.AbstractCommentService
+}
+
+type CommentRq struct {
+	Content  string               `json:"Content" example:"Стоит уточнить сроки" binding:"required"`
+	ParentId *int                 `json:"ParentId" example:"1"`
+	Anchor   *model.CommentAnchor `json:"Anchor"`
+}
+
+type CommentUpdateRq struct {
+	Content string `json:"Content" example:"Стоит уточнить сроки и бюджет" binding:"required"`
+}
+
+func NewCommentHandler(s service.AbstractCommentService) *CommentHandler {
+	return &CommentHandler{commentService: s}
+}
+
+// CreateComment godoc
+// @Summary Comment on a note
+// @Description Start a discussion thread on the note or reply to one with ParentId. A thread may be anchored to a fragment of the note text: Start and End are character positions, the fragment text is saved with the thread
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Note ID"
+// @Param input body CommentRq true "Comment"
+// @Success 200 {object} int "Returns ID of created comment"
+// @Failure 400 {object} model.Problem "Invalid request data or ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Note or parent comment not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/notes/{id}/comments [post]
+func (h *CommentHandler) CreateComment(c *gin.Context) {
+	var req CommentRq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("Invalid request: %s", err.Error()))
+		return
+	}
+
+	userId := c.MustGet("UserId").(int)
+
+	noteId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	id, errCreate := h.commentService.CreateComment(userId, noteId, req.ParentId, req.Content, req.Anchor)
+	if errCreate != nil {
+		apiError := model.GetAppropriateApiError(errCreate)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": id})
+}
+
+// GetComments godoc
+// @Summary Get note comments
+// @Description Get discussion threads of the note with their replies in order of creation. Pass NextAfterId as afterId to get the next page
+// @Tags comments
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Note ID"
+// @Param afterId query int false "Return threads created after this one"
+// @Param limit query int false "Number of threads, 20 by default, at most 100"
+// @Success 200 {object} model.CommentThreadsApi "Page of threads"
+// @Failure 400 {object} model.Problem "Invalid ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Note not found"
+// @Router /api/notes/{id}/comments [get]
+func (h *CommentHandler) GetComments(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	noteId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	afterId, err := strconv.Atoi(c.DefaultQuery("afterId", "0"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid afterId")
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "0"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid limit")
+		return
+	}
+
+	comments, errGet := h.commentService.GetComments(userId, noteId, afterId, limit)
+	if errGet != nil {
+		apiError := model.GetAppropriateApiError(errGet)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, comments)
+}
+
+// UpdateComment godoc
+// @Summary Edit comment
+// @Description Edit the text of an own comment
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Comment ID"
+// @Param input body CommentUpdateRq true "New text"
+// @Success 200 "Comment updated successfully"
+// @Failure 400 {object} model.Problem "Invalid request data or ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 403 {object} model.Problem "The comment belongs to another user"
+// @Failure 404 {object} model.Problem "Comment not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/comments/{id} [put]
+func (h *CommentHandler) UpdateComment(c *gin.Context) {
+	var req CommentUpdateRq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("Invalid request: %s", err.Error()))
+		return
+	}
+
+	userId := c.MustGet("UserId").(int)
+
+	commentId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	if errUpdate := h.commentService.UpdateComment(userId, commentId, req.Content); errUpdate != nil {
+		apiError := model.GetAppropriateApiError(errUpdate)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// DeleteComment godoc
+// @Summary Delete comment
+// @Description Delete an own comment. Deleting the first comment of a thread deletes the whole thread
+// @Tags comments
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Comment ID"
+// @Success 200 "Comment deleted successfully"
+// @Failure 400 {object} model.Problem "Invalid ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 403 {object} model.Problem "The comment belongs to another user"
+// @Failure 404 {object} model.Problem "Comment not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/comments/{id} [delete]
+func (h *CommentHandler) DeleteComment(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	commentId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	if errDelete := h.commentService.DeleteComment(userId, commentId); errDelete != nil {
+		apiError := model.GetAppropriateApiError(errDelete)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// ResolveComment godoc
+// @Summary Resolve thread
+// @Description Mark the discussion thread as resolved
+// @Tags comments
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID of the first comment of the thread"
+// @Success 200 "Thread resolved"
+// @Failure 400 {object} model.Problem "Invalid ID or the comment is a reply"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Comment not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/comments/{id}/resolve [put]
+func (h *CommentHandler) ResolveComment(c *gin.Context) {
+	h.setResolved(c, true)
+}
+
+// UnresolveComment godoc
+// @Summary Reopen thread
+// @Description Mark the resolved discussion thread as open again
+// @Tags comments
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "ID of the first comment of the thread"
+// @Success 200 "Thread reopened"
+// @Failure 400 {object} model.Problem "Invalid ID or the comment is a reply"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Comment not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/comments/{id}/resolve [delete]
+func (h *CommentHandler) UnresolveComment(c *gin.Context) {
+	h.setResolved(c, false)
+}
+
+func (h *CommentHandler) setResolved(c *gin.Context, isResolved bool) {
+	userId := c.MustGet("UserId").(int)
+
+	commentId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	if errResolve := h.commentService.ResolveComment(userId, commentId, isResolved); errResolve != nil {
+		apiError := model.GetAppropriateApiError(errResolve)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/service"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type DailyNoteHandler struct {
+	dailyNoteService service.AbstractDailyNoteService
+}
+
+type DailyNoteSettingsRq struct {
+	TemplateId *int `json:"TemplateId" example:"1"`
+	FolderId   *int `json:"FolderId" example:"1"`
+}
+
+func NewDailyNoteHandler(s service.AbstractDailyNoteService) *DailyNoteHandler {
+	return &DailyNoteHandler{dailyNoteService: s}
+}
+
+// GetDailyNote godoc
+// @Summary Get daily note
+// @Description Get the note for the given day, creating it from the daily note template if it does not exist. Without a date the current day in the user's timezone is used
+// @Tags daily notes
+// @Produce json
+// @Security BearerAuth
+// @Param date path string false "Day in YYYY-MM-DD format"
+// @Success 200 {object} model.NoteApi "Daily note"
+// @Failure 400 {object} model.Problem "Invalid date or template data"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Template or folder not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/notes/daily/{date} [get]
+func (d *DailyNoteHandler) GetDailyNote(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	note, err := service.WithOrigin(d.dailyNoteService, auditOrigin(c)).GetDailyNote(userId, c.Param("date"))
+
+	if err != nil {
+		apiError := model.GetAppropriateApiError(err)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, note)
+}
+
+// GetSettings godoc
+// @Summary Get daily note settings
+// @Description Get the template and folder used for new daily notes
+// @Tags daily notes
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} model.DailyNoteSettingsApi "Daily note settings"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/notes/daily/settings [get]
+func (d *DailyNoteHandler) GetSettings(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	settings, err := d.dailyNoteService.GetSettings(userId)
+
+	if err != nil {
+		apiError := model.GetAppropriateApiError(err)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// UpdateSettings godoc
+// @Summary Update daily note settings
+// @Description Set the template and folder for new daily notes. Omitted values reset to the defaults
+// @Tags daily notes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body DailyNoteSettingsRq true "Daily note settings"
+// @Success 200 "Settings updated successfully"
+// @Failure 400 {object} model.Problem "Invalid request data or template with prompts"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Template or folder not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/notes/daily/settings [put]
+func (d *DailyNoteHandler) UpdateSettings(c *gin.Context) {
+	var req DailyNoteSettingsRq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("Invalid request: %s", err.Error()))
+		return
+	}
+
+	userId := c.MustGet("UserId").(int)
+
+	err := d.dailyNoteService.UpdateSettings(userId, req.TemplateId, req.FolderId)
+
+	if err != nil {
+		apiError := model.GetAppropriateApiError(err)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
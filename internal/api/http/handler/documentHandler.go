@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/service"
+	"github.com/gin-gonic/gin"
+	"mime"
+	"net/http"
+	"strconv"
+)
+
+type DocumentHandler struct {
+	documentService service.AbstractDocumentService
+}
+
+func NewDocumentHandler(s service.AbstractDocumentService) *DocumentHandler {
+	return &DocumentHandler{documentService: s}
+}
+
+// ExportNote godoc
+// @Summary Export a note as a document
+// @Description Download the note as a self-contained PDF or HTML document with title, tags, modification time and rendered Markdown content
+// @Tags notes
+// @Produce application/pdf
+// @Produce html
+// @Security BearerAuth
+// @Param id path int true "Note ID"
+// @Param format query string false "Document format: pdf (default) or html"
+// @Success 200 {file} file "Document content"
+// @Failure 400 {object} model.Problem "Invalid ID or unknown format"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Note not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/notes/{id}/export [get]
+func (d *DocumentHandler) ExportNote(c *gin.Context) {
+	d.export(c, d.documentService.ExportNote)
+}
+
+// ExportFolder godoc
+// @Summary Export a folder as a document
+// @Description Download notes of the folder as a single self-contained PDF or HTML document with a table of contents. Notes follow the folder order
+// @Tags folders
+// @Produce application/pdf
+// @Produce html
+// @Security BearerAuth
+// @Param id path int true "Folder ID"
+// @Param format query string false "Document format: pdf (default) or html"
+// @Success 200 {file} file "Document content"
+// @Failure 400 {object} model.Problem "Invalid ID or unknown format"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Folder not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/folder/{id}/export [get]
+func (d *DocumentHandler) ExportFolder(c *gin.Context) {
+	d.export(c, d.documentService.ExportFolder)
+}
+
+func (d *DocumentHandler) export(c *gin.Context, export func(userId int, id int, format model.DocumentFormat) (*model.DocumentFile, *model.ApplicationError)) {
+	userId := c.MustGet("UserId").(int)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	format, errFormat := model.ParseDocumentFormat(c.Query("format"))
+	if errFormat != nil {
+		apiError := model.GetAppropriateApiError(errFormat)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	document, errExport := export(userId, id, format)
+	if errExport != nil {
+		apiError := model.GetAppropriateApiError(errExport)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": document.FileName}))
+	c.Data(http.StatusOK, document.ContentType, document.Data)
+}
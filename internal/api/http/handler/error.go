@@ -3,16 +3,48 @@ package handler
 import (
 	"Notes/internal/model"
 	"github.com/gin-gonic/gin"
+	"net/http"
 )
 
-type response struct {
-	Error string `json:"error" example:"message"`
-}
-
 func errorResponseFromApiError(c *gin.Context, apiError *model.ApiError) {
-	c.AbortWithStatusJSON(apiError.Code, response{apiError.Message})
+	c.Header("Content-Type", model.ProblemContentType)
+	c.AbortWithStatusJSON(apiError.Code, model.NewProblem(apiError, requestLocale(c), c.GetString("traceID")))
 }
 
+// errorResponse отвечает ошибкой проверки запроса. Текст формирует обработчик, поэтому он не переводится.
 func errorResponse(c *gin.Context, code int, message string) {
-	c.AbortWithStatusJSON(code, response{message})
+	apiError := &model.ApiError{
+		Message:   message,
+		Code:      code,
+		ErrorCode: errorCodeByStatus(code),
+	}
+
+	c.Header("Content-Type", model.ProblemContentType)
+	c.AbortWithStatusJSON(code, model.NewProblem(apiError, model.DefaultLocale, c.GetString("traceID")))
+}
+
+func errorCodeByStatus(status int) model.ErrorCode {
+	switch status {
+	case http.StatusUnauthorized:
+		return model.CodeUnauthorized
+	case http.StatusForbidden:
+		return model.CodeForbidden
+	case http.StatusNotFound:
+		return model.CodeEntityNotFound
+	case http.StatusConflict:
+		return model.CodeConflict
+	case http.StatusInternalServerError:
+		return model.CodeInternalError
+	}
+
+	return model.CodeInvalidRequest
+}
+
+// requestLocale возвращает язык, выбранный LocaleMiddleware
+func requestLocale(c *gin.Context) model.Locale {
+	if locale, exists := c.Get("Locale"); exists {
+		return locale.(model.Locale)
+	}
+
+	return model.DefaultLocale
 }
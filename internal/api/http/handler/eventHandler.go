@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/service"
+	"encoding/json"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// wsWriteTimeout - сколько ждать отправки одного сообщения клиенту
+	wsWriteTimeout = 10 * time.Second
+	// wsPongTimeout - через сколько без ответа на ping соединение считается потерянным
+	wsPongTimeout  = 60 * time.Second
+	wsPingInterval = wsPongTimeout * 9 / 10
+	// sseHeartbeatInterval - как часто в поток SSE отправляется комментарий, чтобы прокси не закрывали соединение
+	sseHeartbeatInterval = 30 * time.Second
+)
+
+type EventHandler struct {
+	eventBus   service.AbstractEventBus
+	changeFeed service.AbstractChangeFeedService
+	upgrader   websocket.Upgrader
+}
+
+func NewEventHandler(s service.AbstractEventBus, changeFeed service.AbstractChangeFeedService) *EventHandler {
+	return &EventHandler{
+		eventBus:   s,
+		changeFeed: changeFeed,
+		upgrader: websocket.Upgrader{
+			// Доступ проверяется по токену, а не по cookie, поэтому подключение с чужого сайта
+			// без токена пользователя невозможно
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+}
+
+// Subscribe godoc
+// @Summary Subscribe to changes over WebSocket
+// @Description Upgrade the connection to WebSocket and receive JSON events about changes of the user's notes and folders made on any device: note.created, note.updated, note.deleted, note.moved, note.favorite, folder.created, folder.renamed, folder.deleted, folder.updated. Browsers may pass the token in the access_token query parameter. The server closes the connection if the client falls behind; the client should then reload the notebook and reconnect
+// @Tags events
+// @Security BearerAuth
+// @Param access_token query string false "JWT token for clients that cannot set the Authorization header"
+// @Success 101 {object} model.Event "Switching protocols, then a stream of events"
+// @Failure 400 {object} model.Problem "Not a WebSocket request"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Router /api/ws [get]
+func (e *EventHandler) Subscribe(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	// При ошибке Upgrader сам отвечает клиенту
+	conn, err := e.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	subscription := e.eventBus.Subscribe(userId)
+	defer subscription.Close()
+
+	closed := make(chan struct{})
+	go e.readLoop(conn, closed)
+
+	ticker := time.NewTicker(wsPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case event, ok := <-subscription.Events:
+			_ = conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if !ok {
+				// Клиент отстал и пропустил события
+				_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "events dropped"))
+				return
+			}
+
+			if err = conn.WriteJSON(event); err != nil {
+				log.Printf("Не удалось отправить событие пользователю %d: %v", userId, err)
+				return
+			}
+		case <-ticker.C:
+			_ = conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if err = conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readLoop читает входящие сообщения, чтобы обрабатывать pong и закрытие соединения.
+// Сообщения клиента не используются.
+func (e *EventHandler) readLoop(conn *websocket.Conn, closed chan<- struct{}) {
+	defer close(closed)
+
+	_ = conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	})
+
+	for {
+		if _, _, err := conn.NextReader(); err != nil {
+			return
+		}
+	}
+}
+
+// Stream godoc
+// @Summary Subscribe to changes over Server-Sent Events
+// @Description Receive the same events as over WebSocket as a text/event-stream. Every event carries its position in the change log as the SSE id, and its type as the SSE event name. A client reconnecting with the Last-Event-ID header (browsers send it automatically) receives every event it missed. Without a cursor the stream starts with new events. Browsers may pass the token in the access_token query parameter
+// @Tags events
+// @Security BearerAuth
+// @Produce text/event-stream
+// @Param Last-Event-ID header int false "Id of the last received event"
+// @Param lastEventId query int false "Id of the last received event for clients that cannot set the header"
+// @Param access_token query string false "JWT token for clients that cannot set the Authorization header"
+// @Success 200 {object} model.Event "Stream of events"
+// @Failure 400 {object} model.Problem "Invalid Last-Event-ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Router /api/events [get]
+func (e *EventHandler) Stream(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	cursor := c.GetHeader("Last-Event-ID")
+	if cursor == "" {
+		cursor = c.Query("lastEventId")
+	}
+
+	// Подписка оформляется до чтения журнала, чтобы не пропустить изменения между ними
+	subscription := e.eventBus.Subscribe(userId)
+	defer func() {
+		subscription.Close()
+	}()
+
+	lastId := 0
+	if cursor == "" {
+		lastId = e.changeFeed.GetLastChangeId(userId)
+	} else if id, err := strconv.Atoi(cursor); err != nil || id < 0 {
+		errorResponse(c, http.StatusBadRequest, "Invalid Last-Event-ID")
+		return
+	} else {
+		lastId = id
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	controller := http.NewResponseController(c.Writer)
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		// Ответ длится, пока клиент подключён, поэтому общий таймаут записи сервера продлевается перед каждой отправкой
+		_ = controller.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+
+		// События берутся из журнала, а шина только сообщает, что его пора перечитать
+		for changes := e.changeFeed.GetChanges(userId, lastId); len(changes) > 0; changes = e.changeFeed.GetChanges(userId, lastId) {
+			for _, event := range changes {
+				if err := writeSseEvent(c.Writer, event); err != nil {
+					return
+				}
+				lastId = event.Id
+			}
+		}
+		c.Writer.Flush()
+
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case _, ok := <-subscription.Events:
+			// Пропущенные шиной события всё равно будут прочитаны из журнала
+			if !ok {
+				subscription = e.eventBus.Subscribe(userId)
+			}
+		case <-heartbeat.C:
+			_ = controller.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if _, err := fmt.Fprint(c.Writer, ": ping\n\n"); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func writeSseEvent(w gin.ResponseWriter, event *model.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Id, event.Type, data)
+	return err
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/service"
+	"github.com/gin-gonic/gin"
+	"mime"
+	"net/http"
+	"time"
+)
+
+type ExportHandler struct {
+	exportService service.AbstractExportService
+}
+
+func NewExportHandler(s service.AbstractExportService) *ExportHandler {
+	return &ExportHandler{exportService: s}
+}
+
+// Export godoc
+// @Summary Export the notebook
+// @Description Download all folders and notes of the authenticated user. markdown - ZIP with folders as directories and notes as .md files with YAML front matter; json - versioned archive accepted by POST /api/import/json
+// @Tags import
+// @Produce application/zip
+// @Produce json
+// @Security BearerAuth
+// @Param format query string false "Export format: markdown (default) or json"
+// @Success 200 {file} file "Archive content"
+// @Failure 400 {object} model.Problem "Unknown format"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/export [get]
+func (e *ExportHandler) Export(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	format, err := model.ParseExportFormat(c.Query("format"))
+	if err != nil {
+		apiError := model.GetAppropriateApiError(err)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": format.FileName(time.Now())}))
+	c.Status(http.StatusOK)
+
+	if errExport := e.exportService.Export(userId, format, c.Writer); errExport != nil {
+		// После начала выгрузки статус уже отправлен, остаётся оборвать ответ
+		if c.Writer.Written() {
+			_ = c.Error(errExport)
+			c.Abort()
+			return
+		}
+
+		apiError := model.GetAppropriateApiError(errExport)
+		errorResponseFromApiError(c, apiError)
+	}
+}
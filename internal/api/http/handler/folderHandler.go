@@ -30,9 +30,10 @@ type FolderReq struct {
 // @Security BearerAuth
 // @Param input body FolderReq true "Folder creation data"
 // @Success 200 {object} int "Returns ID of created folder"
-// @Failure 400 {object} response
-// @Failure 401 {object} response
-// @Failure 500 {object} response
+// @Failure 400 {object} model.Problem
+// @Failure 401 {object} model.Problem
+// @Failure 409 {object} model.Problem "Folder title already taken"
+// @Failure 500 {object} model.Problem
 // @Router /api/folder [post]
 func (f *FolderHandler) CreateFolder(c *gin.Context) {
 	var req FolderReq
@@ -44,7 +45,7 @@ func (f *FolderHandler) CreateFolder(c *gin.Context) {
 
 	userId := c.MustGet("UserId").(int)
 
-	id, err := f.folderService.CreateFolder(userId, req.Title)
+	id, err := service.WithOrigin(f.folderService, auditOrigin(c)).CreateFolder(userId, req.Title)
 
 	if err != nil {
 		apiError := model.GetAppropriateApiError(err)
@@ -67,10 +68,11 @@ func (f *FolderHandler) CreateFolder(c *gin.Context) {
 // @Param id path int true "Folder ID"
 // @Param input body FolderReq true "Folder update data"
 // @Success 200 "Folder updated successfully"
-// @Failure 400 {object} response "Invalid request data or ID"
-// @Failure 401 {object} response "Unauthorized"
-// @Failure 404 {object} response "Folder not found"
-// @Failure 500 {object} response "Internal server error"
+// @Failure 400 {object} model.Problem "Invalid request data or ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 409 {object} model.Problem "Folder title already taken"
+// @Failure 404 {object} model.Problem "Folder not found"
+// @Failure 500 {object} model.Problem "Internal server error"
 // @Router /api/folder/{id} [put]
 func (f *FolderHandler) UpdateFolder(c *gin.Context) {
 	var req FolderReq
@@ -90,7 +92,7 @@ func (f *FolderHandler) UpdateFolder(c *gin.Context) {
 		return
 	}
 
-	errUpdate := f.folderService.UpdateFolder(userId, idInt, req.Title)
+	errUpdate := service.WithOrigin(f.folderService, auditOrigin(c)).UpdateFolder(userId, idInt, req.Title)
 
 	if errUpdate != nil {
 		apiError := model.GetAppropriateApiError(errUpdate)
@@ -109,10 +111,10 @@ func (f *FolderHandler) UpdateFolder(c *gin.Context) {
 // @Security BearerAuth
 // @Param id path int true "Folder ID"
 // @Success 200 "Folder deleted successfully"
-// @Failure 400 {object} response "Invalid ID"
-// @Failure 401 {object} response "Unauthorized"
-// @Failure 404 {object} response "Folder not found"
-// @Failure 500 {object} response "Internal server error"
+// @Failure 400 {object} model.Problem "Invalid ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Folder not found"
+// @Failure 500 {object} model.Problem "Internal server error"
 // @Router /api/folder/{id} [delete]
 func (f *FolderHandler) DeleteFolder(c *gin.Context) {
 	userId := c.MustGet("UserId").(int)
@@ -125,7 +127,7 @@ func (f *FolderHandler) DeleteFolder(c *gin.Context) {
 		return
 	}
 
-	errDelete := f.folderService.DeleteFolder(userId, idInt)
+	errDelete := service.WithOrigin(f.folderService, auditOrigin(c)).DeleteFolder(userId, idInt)
 
 	if errDelete != nil {
 		apiError := model.GetAppropriateApiError(errDelete)
@@ -135,3 +137,110 @@ func (f *FolderHandler) DeleteFolder(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{})
 }
+
+// ReorderFolder godoc
+// @Summary Reorder folder
+// @Description Place the folder right after another folder, or first when AfterId is omitted
+// @Tags folders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Folder ID"
+// @Param input body PositionRq true "Previous folder"
+// @Success 200 "Folder moved successfully"
+// @Failure 400 {object} model.Problem "Invalid request data, ID or previous folder"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Folder not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/folder/{id}/position [put]
+func (f *FolderHandler) ReorderFolder(c *gin.Context) {
+	var req PositionRq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("Invalid request: %s", err.Error()))
+		return
+	}
+
+	userId := c.MustGet("UserId").(int)
+
+	idInt, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	errReorder := service.WithOrigin(f.folderService, auditOrigin(c)).ReorderFolder(userId, idInt, req.AfterId)
+
+	if errReorder != nil {
+		apiError := model.GetAppropriateApiError(errReorder)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// ArchiveFolder godoc
+// @Summary Archive folder
+// @Description Archive the folder together with all its notes
+// @Tags folders
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Folder ID"
+// @Success 200 "Folder archived successfully"
+// @Failure 400 {object} model.Problem "Invalid ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Folder not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/folder/{id}/archive [put]
+func (f *FolderHandler) ArchiveFolder(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	idInt, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	errArchive := service.WithOrigin(f.folderService, auditOrigin(c)).ArchiveFolder(userId, idInt)
+
+	if errArchive != nil {
+		apiError := model.GetAppropriateApiError(errArchive)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// UnarchiveFolder godoc
+// @Summary Restore folder from archive
+// @Description Restore the folder together with all its notes
+// @Tags folders
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Folder ID"
+// @Success 200 "Folder restored successfully"
+// @Failure 400 {object} model.Problem "Invalid ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Folder not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/folder/{id}/archive [delete]
+func (f *FolderHandler) UnarchiveFolder(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	idInt, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	errUnarchive := service.WithOrigin(f.folderService, auditOrigin(c)).UnarchiveFolder(userId, idInt)
+
+	if errUnarchive != nil {
+		apiError := model.GetAppropriateApiError(errUnarchive)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"Notes/config"
+	"Notes/internal/model"
+	"Notes/internal/service"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"path/filepath"
+	"strconv"
+)
+
+type ImportHandler struct {
+	importService service.AbstractImportService
+	maxFileSize   int64
+}
+
+func NewImportHandler(s service.AbstractImportService, cfg *config.Config) *ImportHandler {
+	return &ImportHandler{
+		importService: s,
+		maxFileSize:   int64(cfg.Import.MaxFileSizeMb) * 1024 * 1024,
+	}
+}
+
+// StartMarkdownImport godoc
+// @Summary Import a Markdown vault
+// @Description Start asynchronous import of a ZIP archive with Markdown files (e.g. an Obsidian vault). Directories become folders, YAML front matter provides title, tags, favorite flag and date
+// @Tags import
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "ZIP archive"
+// @Param strategy formData string false "Title conflict strategy: skip (default), rename or overwrite"
+// @Success 202 {object} int "Returns ID of created import job"
+// @Failure 400 {object} model.Problem "Invalid request data or file too large"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 413 {object} model.Problem "Archive is too large"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/import/markdown [post]
+func (i *ImportHandler) StartMarkdownImport(c *gin.Context) {
+	i.startImport(c, model.ImportSourceMarkdown)
+}
+
+// StartJsonImport godoc
+// @Summary Import a JSON archive
+// @Description Start asynchronous import of a notebook archive exported with GET /api/export?format=json. Folders, tags, checklists, favorite, pinned and archived flags and dates are restored
+// @Tags import
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "JSON archive"
+// @Param strategy formData string false "Title conflict strategy: skip (default), rename or overwrite"
+// @Success 202 {object} int "Returns ID of created import job"
+// @Failure 400 {object} model.Problem "Invalid request data or file too large"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 413 {object} model.Problem "Archive is too large"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/import/json [post]
+func (i *ImportHandler) StartJsonImport(c *gin.Context) {
+	i.startImport(c, model.ImportSourceJson)
+}
+
+// StartEnexImport godoc
+// @Summary Import an Evernote export
+// @Description Start asynchronous import of an ENEX file or a ZIP with several ENEX files. Each file becomes a folder named after it, notes are converted to Markdown, tags and dates are preserved and embedded files are saved as attachments
+// @Tags import
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "ENEX file or ZIP with ENEX files"
+// @Param strategy formData string false "Title conflict strategy: skip (default), rename or overwrite"
+// @Success 202 {object} int "Returns ID of created import job"
+// @Failure 400 {object} model.Problem "Invalid request data or file too large"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 413 {object} model.Problem "Archive is too large"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/import/enex [post]
+func (i *ImportHandler) StartEnexImport(c *gin.Context) {
+	i.startImport(c, model.ImportSourceEnex)
+}
+
+// GetImportJob godoc
+// @Summary Get import job
+// @Description Get progress of the import job and the result of every imported file
+// @Tags import
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Import job ID"
+// @Success 200 {object} model.ImportJobApi "Import job"
+// @Failure 400 {object} model.Problem "Invalid ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Import job not found"
+// @Router /api/import/jobs/{id} [get]
+func (i *ImportHandler) GetImportJob(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	job, errGet := i.importService.GetImportJob(userId, id)
+	if errGet != nil {
+		apiError := model.GetAppropriateApiError(errGet)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+func (i *ImportHandler) startImport(c *gin.Context, source model.ImportSource) {
+	userId := c.MustGet("UserId").(int)
+
+	fileHeader, ok := formFile(c, "file", i.maxFileSize)
+	if !ok {
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("Invalid request: %s", err.Error()))
+		return
+	}
+	defer file.Close()
+
+	id, errStart := i.importService.StartImport(userId, source, c.PostForm("strategy"), filepath.Base(fileHeader.Filename), fileHeader.Size, file)
+	if errStart != nil {
+		apiError := model.GetAppropriateApiError(errStart)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"id": id})
+}
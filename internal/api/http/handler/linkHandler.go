@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type LinkHandler struct {
+	linkService service.AbstractLinkService
+}
+
+func NewLinkHandler(s service.AbstractLinkService) *LinkHandler {
+	return &LinkHandler{linkService: s}
+}
+
+// GetBacklinks godoc
+// @Summary Get note backlinks
+// @Description Get notes that reference the note with [[Title]] or [[note:id]] links, including archived ones
+// @Tags links
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Note ID"
+// @Success 200 {array} model.BacklinkApi "List of referring notes"
+// @Failure 400 {object} model.Problem "Invalid ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Note not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/notes/{id}/backlinks [get]
+func (l *LinkHandler) GetBacklinks(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	backlinks, errGet := l.linkService.GetBacklinks(userId, id)
+
+	if errGet != nil {
+		apiError := model.GetAppropriateApiError(errGet)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, backlinks)
+}
+
+// GetGraph godoc
+// @Summary Get notes graph
+// @Description Get the graph of the user's notes: nodes, links between notes and dangling links to missing notes
+// @Tags links
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} model.GraphApi "Notes graph"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Router /api/graph [get]
+func (l *LinkHandler) GetGraph(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	c.JSON(http.StatusOK, l.linkService.GetGraph(userId))
+}
@@ -23,6 +23,11 @@ type MoveNoteRq struct {
 	FolderId *int `json:"FolderId" example:"1" binding:"required"`
 }
 
+// PositionRq - новое место элемента в списке: после элемента AfterId или в начале, если AfterId не указан
+type PositionRq struct {
+	AfterId *int `json:"AfterId" example:"1"`
+}
+
 func NewNoteHandler(s service.AbstractNoteService) *NoteHandler {
 	return &NoteHandler{noteService: s}
 }
@@ -36,9 +41,10 @@ func NewNoteHandler(s service.AbstractNoteService) *NoteHandler {
 // @Security BearerAuth
 // @Param input body NoteRq true "Note creation data"
 // @Success 200 {object} int "Returns ID of created note"
-// @Failure 400 {object} response "Invalid request data"
-// @Failure 401 {object} response "Unauthorized"
-// @Failure 500 {object} response "Internal server error"
+// @Failure 400 {object} model.Problem "Invalid request data"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 409 {object} model.Problem "Note title already taken"
+// @Failure 500 {object} model.Problem "Internal server error"
 // @Router /api/notes [post]
 func (n *NoteHandler) CreateNote(c *gin.Context) {
 	var req NoteRq
@@ -49,7 +55,7 @@ func (n *NoteHandler) CreateNote(c *gin.Context) {
 
 	userId := c.MustGet("UserId").(int)
 
-	id, err := n.noteService.CreateNote(userId, req.Title, req.Content, req.Tags)
+	id, err := service.WithOrigin(n.noteService, auditOrigin(c)).CreateNote(userId, req.Title, req.Content, req.Tags)
 	if err != nil {
 		apiError := model.GetAppropriateApiError(err)
 		errorResponseFromApiError(c, apiError)
@@ -61,18 +67,22 @@ func (n *NoteHandler) CreateNote(c *gin.Context) {
 
 // UpdateNote godoc
 // @Summary Update a note
-// @Description Update an existing note for the authenticated user
+// @Description Update an existing note for the authenticated user. While the note is locked for editing, only the lock holder can update it by passing the lock token
 // @Tags notes
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Note ID"
 // @Param input body NoteRq true "Note update data"
+// @Param rewriteLinks query bool false "Rewrite [[title]] links in other notes when the title changes"
+// @Param X-Lock-Token header string false "Token of the edit lock held by the client"
 // @Success 200 "Note updated successfully"
-// @Failure 400 {object} response "Invalid request data or ID"
-// @Failure 401 {object} response "Unauthorized"
-// @Failure 404 {object} response "Note not found"
-// @Failure 500 {object} response "Internal server error"
+// @Failure 400 {object} model.Problem "Invalid request data or ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 409 {object} model.Problem "Note title already taken"
+// @Failure 404 {object} model.Problem "Note not found"
+// @Failure 423 {object} model.Problem "The note is locked by another client"
+// @Failure 500 {object} model.Problem "Internal server error"
 // @Router /api/notes/{id} [put]
 func (n *NoteHandler) UpdateNote(c *gin.Context) {
 	var req NoteRq
@@ -91,7 +101,9 @@ func (n *NoteHandler) UpdateNote(c *gin.Context) {
 		return
 	}
 
-	errUpdate := n.noteService.UpdateNote(userId, idInt, req.Title, req.Content, req.Tags)
+	rewriteLinks := c.Query("rewriteLinks") == "true"
+
+	errUpdate := service.WithOrigin(n.noteService, auditOrigin(c)).UpdateNote(userId, idInt, req.Title, req.Content, req.Tags, rewriteLinks, c.GetHeader(lockTokenHeader))
 
 	if errUpdate != nil {
 		apiError := model.GetAppropriateApiError(errUpdate)
@@ -108,11 +120,13 @@ func (n *NoteHandler) UpdateNote(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Note ID"
+// @Param X-Lock-Token header string false "Token of the edit lock held by the client"
 // @Success 200 "Note deleted successfully"
-// @Failure 400 {object} response "Invalid ID"
-// @Failure 401 {object} response "Unauthorized"
-// @Failure 404 {object} response "Note not found"
-// @Failure 500 {object} response "Internal server error"
+// @Failure 400 {object} model.Problem "Invalid ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Note not found"
+// @Failure 423 {object} model.Problem "The note is locked by another client"
+// @Failure 500 {object} model.Problem "Internal server error"
 // @Router /api/notes/{id} [delete]
 func (n *NoteHandler) DeleteNote(c *gin.Context) {
 	userId := c.MustGet("UserId").(int)
@@ -125,7 +139,7 @@ func (n *NoteHandler) DeleteNote(c *gin.Context) {
 		return
 	}
 
-	errDelete := n.noteService.DeleteNote(userId, idInt)
+	errDelete := service.WithOrigin(n.noteService, auditOrigin(c)).DeleteNote(userId, idInt, c.GetHeader(lockTokenHeader))
 
 	if errDelete != nil {
 		apiError := model.GetAppropriateApiError(errDelete)
@@ -142,8 +156,8 @@ func (n *NoteHandler) DeleteNote(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Success 200 {object} []model.Note "Returns list of favorite notes"
-// @Failure 401 {object} response "Unauthorized"
-// @Failure 500 {object} response "Internal server error"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 500 {object} model.Problem "Internal server error"
 // @Router /api/notes/favorites [get]
 func (n *NoteHandler) GetFavoriteNotes(c *gin.Context) {
 	userId := c.MustGet("UserId").(int)
@@ -155,6 +169,94 @@ func (n *NoteHandler) GetFavoriteNotes(c *gin.Context) {
 	})
 }
 
+// GetArchivedNotes godoc
+// @Summary Get archived notes
+// @Description Get all archived notes for the authenticated user
+// @Tags notes
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} []model.NoteApi "Returns list of archived notes"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/notes/archived [get]
+func (n *NoteHandler) GetArchivedNotes(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	archived := n.noteService.GetArchivedNotes(userId)
+
+	c.JSON(http.StatusOK, gin.H{
+		"notes": archived,
+	})
+}
+
+// ArchiveNote godoc
+// @Summary Archive note
+// @Description Hide the note from the notebook and favorites without deleting it
+// @Tags notes
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Note ID"
+// @Param X-Lock-Token header string false "Token of the edit lock held by the client"
+// @Success 200 "Note archived successfully"
+// @Failure 400 {object} model.Problem "Invalid ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Note not found"
+// @Failure 423 {object} model.Problem "The note is locked by another client"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/notes/{id}/archive [put]
+func (n *NoteHandler) ArchiveNote(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	idInt, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	errArchive := service.WithOrigin(n.noteService, auditOrigin(c)).ArchiveNote(userId, idInt, c.GetHeader(lockTokenHeader))
+
+	if errArchive != nil {
+		apiError := model.GetAppropriateApiError(errArchive)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// UnarchiveNote godoc
+// @Summary Restore note from archive
+// @Description Return the note to the notebook. A note from an archived folder is restored to the notebook root
+// @Tags notes
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Note ID"
+// @Param X-Lock-Token header string false "Token of the edit lock held by the client"
+// @Success 200 "Note restored successfully"
+// @Failure 400 {object} model.Problem "Invalid ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Note not found"
+// @Failure 423 {object} model.Problem "The note is locked by another client"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/notes/{id}/archive [delete]
+func (n *NoteHandler) UnarchiveNote(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	idInt, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	errUnarchive := service.WithOrigin(n.noteService, auditOrigin(c)).UnarchiveNote(userId, idInt, c.GetHeader(lockTokenHeader))
+
+	if errUnarchive != nil {
+		apiError := model.GetAppropriateApiError(errUnarchive)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
 // FindNotes godoc
 // @Summary Search notes
 // @Description Search notes by query phrase for the authenticated user
@@ -162,10 +264,11 @@ func (n *NoteHandler) GetFavoriteNotes(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param query query string true "Search phrase"
+// @Param include query string false "Pass archived to search archived notes too"
 // @Success 200 {object} []model.Note "Returns list of matching notes"
-// @Failure 400 {object} response "Empty query parameter"
-// @Failure 401 {object} response "Unauthorized"
-// @Failure 500 {object} response "Internal server error"
+// @Failure 400 {object} model.Problem "Empty query parameter"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 500 {object} model.Problem "Internal server error"
 // @Router /api/notes/search [get]
 func (n *NoteHandler) FindNotes(c *gin.Context) {
 	userId := c.MustGet("UserId").(int)
@@ -176,7 +279,9 @@ func (n *NoteHandler) FindNotes(c *gin.Context) {
 		return
 	}
 
-	notes := n.noteService.FindNotesByQueryPhrase(userId, queryPhrase)
+	includeArchived := c.Query("include") == "archived"
+
+	notes := n.noteService.FindNotesByQueryPhrase(userId, queryPhrase, includeArchived)
 	c.JSON(http.StatusOK, gin.H{
 		"notes": notes,
 	})
@@ -191,10 +296,12 @@ func (n *NoteHandler) FindNotes(c *gin.Context) {
 // @Security BearerAuth
 // @Param id path int true "Note ID"
 // @Param input body MoveNoteRq true "Note update data"
+// @Param X-Lock-Token header string false "Token of the edit lock held by the client"
 // @Success 200 {object} string "Note updated successfully"
-// @Failure 400 {object} response "Empty query parameter"
-// @Failure 401 {object} response "Unauthorized"
-// @Failure 500 {object} response "Internal server error"
+// @Failure 400 {object} model.Problem "Empty query parameter"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 423 {object} model.Problem "The note is locked by another client"
+// @Failure 500 {object} model.Problem "Internal server error"
 // @Router /api/notes/{id}/move [put]
 func (n *NoteHandler) MoveNote(c *gin.Context) {
 	var req MoveNoteRq
@@ -213,7 +320,7 @@ func (n *NoteHandler) MoveNote(c *gin.Context) {
 		return
 	}
 
-	errMove := n.noteService.MoveToFolder(userId, idInt, req.FolderId)
+	errMove := service.WithOrigin(n.noteService, auditOrigin(c)).MoveToFolder(userId, idInt, req.FolderId, c.GetHeader(lockTokenHeader))
 
 	if errMove != nil {
 		apiError := model.GetAppropriateApiError(errMove)
@@ -223,6 +330,116 @@ func (n *NoteHandler) MoveNote(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{})
 }
 
+// ReorderNote godoc
+// @Summary Reorder note
+// @Description Place the note right after another note of the same folder and pin state, or first when AfterId is omitted
+// @Tags notes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Note ID"
+// @Param input body PositionRq true "Previous note"
+// @Param X-Lock-Token header string false "Token of the edit lock held by the client"
+// @Success 200 "Note moved successfully"
+// @Failure 400 {object} model.Problem "Invalid request data, ID or previous note"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Note not found"
+// @Failure 423 {object} model.Problem "The note is locked by another client"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/notes/{id}/position [put]
+func (n *NoteHandler) ReorderNote(c *gin.Context) {
+	var req PositionRq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("Invalid request: %s", err.Error()))
+		return
+	}
+
+	userId := c.MustGet("UserId").(int)
+
+	idInt, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	errReorder := service.WithOrigin(n.noteService, auditOrigin(c)).ReorderNote(userId, idInt, req.AfterId, c.GetHeader(lockTokenHeader))
+
+	if errReorder != nil {
+		apiError := model.GetAppropriateApiError(errReorder)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// PinNote godoc
+// @Summary Pin note
+// @Description Pin the note so it is shown first in its folder
+// @Tags notes
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Note ID"
+// @Param X-Lock-Token header string false "Token of the edit lock held by the client"
+// @Success 200 "Note pinned successfully"
+// @Failure 400 {object} model.Problem "Invalid ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Note not found"
+// @Failure 423 {object} model.Problem "The note is locked by another client"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/notes/{id}/pin [put]
+func (n *NoteHandler) PinNote(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	idInt, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	errPin := service.WithOrigin(n.noteService, auditOrigin(c)).PinNote(userId, idInt, c.GetHeader(lockTokenHeader))
+
+	if errPin != nil {
+		apiError := model.GetAppropriateApiError(errPin)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// UnpinNote godoc
+// @Summary Unpin note
+// @Description Unpin the note in its folder
+// @Tags notes
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Note ID"
+// @Param X-Lock-Token header string false "Token of the edit lock held by the client"
+// @Success 200 "Note unpinned successfully"
+// @Failure 400 {object} model.Problem "Invalid ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Note not found"
+// @Failure 423 {object} model.Problem "The note is locked by another client"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/notes/{id}/pin [delete]
+func (n *NoteHandler) UnpinNote(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	idInt, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	errUnpin := service.WithOrigin(n.noteService, auditOrigin(c)).UnpinNote(userId, idInt, c.GetHeader(lockTokenHeader))
+
+	if errUnpin != nil {
+		apiError := model.GetAppropriateApiError(errUnpin)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{})
+}
+
 // AddToFavorites godoc
 // @Summary Add note to favorites
 // @Description Add note to favorites for the authenticated user
@@ -230,10 +447,12 @@ func (n *NoteHandler) MoveNote(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Note ID"
+// @Param X-Lock-Token header string false "Token of the edit lock held by the client"
 // @Success 200 {object} string "Note updated successfully"
-// @Failure 400 {object} response "Empty query parameter"
-// @Failure 401 {object} response "Unauthorized"
-// @Failure 500 {object} response "Internal server error"
+// @Failure 400 {object} model.Problem "Empty query parameter"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 423 {object} model.Problem "The note is locked by another client"
+// @Failure 500 {object} model.Problem "Internal server error"
 // @Router /api/notes/{id}/favorites [put]
 func (n *NoteHandler) AddToFavorites(c *gin.Context) {
 	userId := c.MustGet("UserId").(int)
@@ -246,7 +465,7 @@ func (n *NoteHandler) AddToFavorites(c *gin.Context) {
 		return
 	}
 
-	errMove := n.noteService.AddToFavorites(userId, idInt)
+	errMove := service.WithOrigin(n.noteService, auditOrigin(c)).AddToFavorites(userId, idInt, c.GetHeader(lockTokenHeader))
 
 	if errMove != nil {
 		apiError := model.GetAppropriateApiError(errMove)
@@ -263,10 +482,12 @@ func (n *NoteHandler) AddToFavorites(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Note ID"
+// @Param X-Lock-Token header string false "Token of the edit lock held by the client"
 // @Success 200 {object} string "Note updated successfully"
-// @Failure 400 {object} response "Empty query parameter"
-// @Failure 401 {object} response "Unauthorized"
-// @Failure 500 {object} response "Internal server error"
+// @Failure 400 {object} model.Problem "Empty query parameter"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 423 {object} model.Problem "The note is locked by another client"
+// @Failure 500 {object} model.Problem "Internal server error"
 // @Router /api/notes/{id}/favorites [delete]
 func (n *NoteHandler) DeleteFromFavorites(c *gin.Context) {
 	userId := c.MustGet("UserId").(int)
@@ -279,7 +500,7 @@ func (n *NoteHandler) DeleteFromFavorites(c *gin.Context) {
 		return
 	}
 
-	errDelete := n.noteService.DeleteFromFavorites(userId, idInt)
+	errDelete := service.WithOrigin(n.noteService, auditOrigin(c)).DeleteFromFavorites(userId, idInt, c.GetHeader(lockTokenHeader))
 
 	if errDelete != nil {
 		apiError := model.GetAppropriateApiError(errDelete)
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+// lockTokenHeader - заголовок, в котором клиент передаёт токен аренды заметки
+const lockTokenHeader = "X-Lock-Token"
+
+type NoteLockHandler struct {
+	noteLockService service.AbstractNoteLockService
+}
+
+func NewNoteLockHandler(s service.AbstractNoteLockService) *NoteLockHandler {
+	return &NoteLockHandler{noteLockService: s}
+}
+
+// AcquireLock godoc
+// @Summary Lock note for editing
+// @Description Acquire a time-limited edit lock on the note. While the lock is active, only requests with its token can update the note. Pass the token of an active lock in X-Lock-Token to renew it. Expired locks are released automatically
+// @Tags notes
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Note ID"
+// @Param X-Lock-Token header string false "Token of the lock to renew"
+// @Success 200 {object} model.NoteLockGrantApi "Lock token and expiration time"
+// @Failure 400 {object} model.Problem "Invalid ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Note not found"
+// @Failure 423 {object} model.Problem "The note is locked by another client"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/notes/{id}/lock [post]
+func (h *NoteLockHandler) AcquireLock(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	noteId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	lock, errLock := h.noteLockService.AcquireLock(userId, noteId, c.GetHeader(lockTokenHeader))
+	if errLock != nil {
+		apiError := model.GetAppropriateApiError(errLock)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, lock)
+}
+
+// ReleaseLock godoc
+// @Summary Unlock note
+// @Description Release the edit lock held by the client
+// @Tags notes
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Note ID"
+// @Param X-Lock-Token header string true "Token of the lock"
+// @Success 200 "Lock released"
+// @Failure 400 {object} model.Problem "Invalid ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Note not found"
+// @Failure 423 {object} model.Problem "The note is locked by another client"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/notes/{id}/lock [delete]
+func (h *NoteLockHandler) ReleaseLock(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	noteId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	if errLock := h.noteLockService.ReleaseLock(userId, noteId, c.GetHeader(lockTokenHeader)); errLock != nil {
+		apiError := model.GetAppropriateApiError(errLock)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
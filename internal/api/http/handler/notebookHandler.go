@@ -1,9 +1,11 @@
 package handler
 
 import (
+	"Notes/internal/model"
 	"Notes/internal/service"
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"strconv"
 )
 
 type NotebookHandler struct {
@@ -16,17 +18,32 @@ func NewNotebookHandler(s service.AbstractNotebookService) *NotebookHandler {
 
 // GetNotebook godoc
 // @Summary Get user's notebook
-// @Description Get the notebook data for the authenticated user
+// @Description Get the notebook data for the authenticated user, ordered by the user's sort preference. If the user has a page size preference, every list of notes is paginated
 // @Tags notebooks
 // @Produce json
 // @Security BearerAuth
+// @Param page query int false "Page number starting from 1"
 // @Success 200 {object} model.Notebook "Returns user's notebook data"
-// @Failure 401 {object} response "Unauthorized"
+// @Failure 400 {object} model.Problem "Invalid page"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 500 {object} model.Problem "Internal server error"
 // @Router /api/notebook [get]
 func (n *NotebookHandler) GetNotebook(c *gin.Context) {
 	userId := c.MustGet("UserId").(int)
 
-	notebook := n.notebookService.GetUserNotebook(userId)
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		errorResponse(c, http.StatusBadRequest, "Invalid page")
+		return
+	}
+
+	notebook, errGet := n.notebookService.GetUserNotebook(userId, page)
+
+	if errGet != nil {
+		apiError := model.GetAppropriateApiError(errGet)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"notebook": notebook,
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type NotificationHandler struct {
+	notificationService service.AbstractNotificationService
+}
+
+func NewNotificationHandler(s service.AbstractNotificationService) *NotificationHandler {
+	return &NotificationHandler{notificationService: s}
+}
+
+// GetNotifications godoc
+// @Summary Get notifications
+// @Description Get notifications of the authenticated user, newest first: reminders and mentions of the user's login as @login in notes and comments. Pass NextBeforeId as beforeId to get the next page
+// @Tags notifications
+// @Produce json
+// @Security BearerAuth
+// @Param beforeId query int false "Return notifications older than this one"
+// @Param limit query int false "Number of notifications, 20 by default, at most 100"
+// @Param unread query bool false "Return only unread notifications"
+// @Success 200 {object} model.NotificationsApi "Page of notifications"
+// @Failure 400 {object} model.Problem "Invalid query parameters"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Router /api/notifications [get]
+func (h *NotificationHandler) GetNotifications(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	beforeId, err := strconv.Atoi(c.DefaultQuery("beforeId", "0"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid beforeId")
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "0"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid limit")
+		return
+	}
+
+	unreadOnly, err := strconv.ParseBool(c.DefaultQuery("unread", "false"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid unread")
+		return
+	}
+
+	notifications, errGet := h.notificationService.GetNotifications(userId, beforeId, limit, unreadOnly)
+	if errGet != nil {
+		apiError := model.GetAppropriateApiError(errGet)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, notifications)
+}
+
+// GetUnreadCount godoc
+// @Summary Get unread notification count
+// @Description Get the number of unread notifications of the authenticated user
+// @Tags notifications
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} model.UnreadNotificationsApi "Unread notification count"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Router /api/notifications/unread-count [get]
+func (h *NotificationHandler) GetUnreadCount(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	count, errGet := h.notificationService.GetUnreadCount(userId)
+	if errGet != nil {
+		apiError := model.GetAppropriateApiError(errGet)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, count)
+}
+
+// MarkRead godoc
+// @Summary Mark notification as read
+// @Description Mark one notification of the authenticated user as read
+// @Tags notifications
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Notification ID"
+// @Success 200 "Notification marked as read"
+// @Failure 400 {object} model.Problem "Invalid ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Notification not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/notifications/{id}/read [put]
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	if errMark := h.notificationService.MarkRead(userId, id); errMark != nil {
+		apiError := model.GetAppropriateApiError(errMark)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// MarkAllRead godoc
+// @Summary Mark all notifications as read
+// @Description Mark all notifications of the authenticated user as read
+// @Tags notifications
+// @Produce json
+// @Security BearerAuth
+// @Success 200 "Notifications marked as read"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/notifications/read [put]
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	if errMark := h.notificationService.MarkAllRead(userId); errMark != nil {
+		apiError := model.GetAppropriateApiError(errMark)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/service"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type PreferenceHandler struct {
+	preferenceService service.AbstractPreferenceService
+}
+
+// PreferencesRq - изменяемые настройки, не переданные поля остаются без изменений
+type PreferencesRq struct {
+	Timezone        *string         `json:"Timezone" example:"Europe/Moscow"`
+	Locale          *string         `json:"Locale" example:"ru"`
+	SortOrder       *string         `json:"SortOrder" example:"manual"`
+	DefaultFolderId *int            `json:"DefaultFolderId" example:"1"`
+	PageSize        *int            `json:"PageSize" example:"50"`
+	EditorFormat    *string         `json:"EditorFormat" example:"markdown"`
+	Notifications   map[string]bool `json:"Notifications"`
+}
+
+func NewPreferenceHandler(s service.AbstractPreferenceService) *PreferenceHandler {
+	return &PreferenceHandler{preferenceService: s}
+}
+
+// GetPreferences godoc
+// @Summary Get user preferences
+// @Description Get preferences of the authenticated user. Defaults are returned for preferences that were never changed
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} model.PreferencesApi "User preferences"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "User not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/user/preferences [get]
+func (p *PreferenceHandler) GetPreferences(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	preferences, err := p.preferenceService.GetPreferences(userId)
+
+	if err != nil {
+		apiError := model.GetAppropriateApiError(err)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, preferences)
+}
+
+// UpdatePreferences godoc
+// @Summary Update user preferences
+// @Description Change some preferences of the authenticated user. SortOrder is manual, title or updated; Locale is ru or en; EditorFormat is markdown or plain; PageSize 0 disables notebook pagination; DefaultFolderId 0 resets the default folder; Notifications enables or disables inbox notifications by type: reminder or mention
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body PreferencesRq true "Changed preferences"
+// @Success 200 "Preferences updated successfully"
+// @Failure 400 {object} model.Problem "Invalid request data"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "User or folder not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/user/preferences [patch]
+func (p *PreferenceHandler) UpdatePreferences(c *gin.Context) {
+	var req PreferencesRq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("Invalid request: %s", err.Error()))
+		return
+	}
+
+	userId := c.MustGet("UserId").(int)
+
+	err := p.preferenceService.UpdatePreferences(userId, model.PreferencesPatch{
+		Timezone:        req.Timezone,
+		Locale:          req.Locale,
+		SortOrder:       req.SortOrder,
+		DefaultFolderId: req.DefaultFolderId,
+		PageSize:        req.PageSize,
+		EditorFormat:    req.EditorFormat,
+		Notifications:   req.Notifications,
+	})
+
+	if err != nil {
+		apiError := model.GetAppropriateApiError(err)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
@@ -0,0 +1,194 @@
+package handler
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/service"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const defaultRemindersRange = 30 * 24 * time.Hour
+
+type ReminderHandler struct {
+	reminderService service.AbstractReminderService
+}
+
+type ReminderRq struct {
+	RemindAt   time.Time  `json:"RemindAt" example:"2026-01-01T09:00:00Z" binding:"required"`
+	DueAt      *time.Time `json:"DueAt" example:"2026-01-02T18:00:00Z"`
+	Recurrence string     `json:"Recurrence" example:"FREQ=WEEKLY;INTERVAL=2;COUNT=10"`
+	Channels   []string   `json:"Channels" example:"inbox,email"`
+	Email      *string    `json:"Email" example:"user@example.com"`
+	WebhookUrl *string    `json:"WebhookUrl" example:"https://example.com/hooks/reminders"`
+}
+
+func NewReminderHandler(s service.AbstractReminderService) *ReminderHandler {
+	return &ReminderHandler{reminderService: s}
+}
+
+// SetReminder godoc
+// @Summary Set note reminder
+// @Description Set or replace the reminder of the note. Recurrence is daily, weekly, monthly or an RRULE subset (FREQ, INTERVAL, COUNT, UNTIL)
+// @Tags reminders
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Note ID"
+// @Param input body ReminderRq true "Reminder settings"
+// @Success 200 "Reminder set successfully"
+// @Failure 400 {object} model.Problem "Invalid request data or ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Note not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/notes/{id}/reminder [put]
+func (r *ReminderHandler) SetReminder(c *gin.Context) {
+	var req ReminderRq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("Invalid request: %s", err.Error()))
+		return
+	}
+
+	userId := c.MustGet("UserId").(int)
+
+	noteId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	errSet := r.reminderService.SetReminder(userId, noteId, model.ReminderSettings{
+		RemindAt:   req.RemindAt,
+		DueAt:      req.DueAt,
+		Recurrence: req.Recurrence,
+		Channels:   req.Channels,
+		Email:      req.Email,
+		WebhookUrl: req.WebhookUrl,
+	})
+
+	if errSet != nil {
+		apiError := model.GetAppropriateApiError(errSet)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// GetReminder godoc
+// @Summary Get note reminder
+// @Description Get the reminder of the note for the authenticated user
+// @Tags reminders
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Note ID"
+// @Success 200 {object} model.ReminderApi "Returns reminder"
+// @Failure 400 {object} model.Problem "Invalid ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Reminder not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/notes/{id}/reminder [get]
+func (r *ReminderHandler) GetReminder(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	noteId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	reminder, errGet := r.reminderService.GetReminder(userId, noteId)
+
+	if errGet != nil {
+		apiError := model.GetAppropriateApiError(errGet)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reminder": reminder,
+	})
+}
+
+// DeleteReminder godoc
+// @Summary Delete note reminder
+// @Description Delete the reminder of the note for the authenticated user
+// @Tags reminders
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Note ID"
+// @Success 200 "Reminder deleted successfully"
+// @Failure 400 {object} model.Problem "Invalid ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/notes/{id}/reminder [delete]
+func (r *ReminderHandler) DeleteReminder(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	noteId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	errDelete := r.reminderService.DeleteReminder(userId, noteId)
+
+	if errDelete != nil {
+		apiError := model.GetAppropriateApiError(errDelete)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// GetUpcomingReminders godoc
+// @Summary Get upcoming reminders
+// @Description Get reminder occurrences of the authenticated user within the interval (30 days from now by default, at most one year)
+// @Tags reminders
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "Interval start, RFC 3339"
+// @Param to query string false "Interval end, RFC 3339"
+// @Success 200 {object} []model.ReminderOccurrenceApi "Returns upcoming reminders ordered by time"
+// @Failure 400 {object} model.Problem "Invalid interval"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/reminders [get]
+func (r *ReminderHandler) GetUpcomingReminders(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	from := time.Now().UTC()
+	if value := c.Query("from"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			errorResponse(c, http.StatusBadRequest, "Invalid from")
+			return
+		}
+		from = parsed
+	}
+
+	to := from.Add(defaultRemindersRange)
+	if value := c.Query("to"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			errorResponse(c, http.StatusBadRequest, "Invalid to")
+			return
+		}
+		to = parsed
+	}
+
+	reminders, errGet := r.reminderService.GetUpcomingReminders(userId, from, to)
+
+	if errGet != nil {
+		apiError := model.GetAppropriateApiError(errGet)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"reminders": reminders,
+	})
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/service"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type SyncHandler struct {
+	syncService service.AbstractSyncService
+}
+
+func NewSyncHandler(s service.AbstractSyncService) *SyncHandler {
+	return &SyncHandler{syncService: s}
+}
+
+// SyncRq - изменения, накопленные клиентом без связи с сервером, в порядке их выполнения
+type SyncRq struct {
+	Mutations []*model.SyncMutation `json:"Mutations" binding:"required"`
+}
+
+// GetChanges godoc
+// @Summary Get changes for offline clients
+// @Description Get notes and folders changed after the sync token together with tombstones of deleted ones. Without a token the whole notebook is returned. Pass the returned Token in the next request
+// @Tags sync
+// @Produce json
+// @Security BearerAuth
+// @Param since query string false "Sync token from the previous response"
+// @Success 200 {object} model.SyncChanges "Changes and the new sync token"
+// @Failure 400 {object} model.Problem "Invalid sync token"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Router /api/sync [get]
+func (s *SyncHandler) GetChanges(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	changes, err := s.syncService.GetChanges(userId, c.Query("since"))
+	if err != nil {
+		apiError := model.GetAppropriateApiError(err)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, changes)
+}
+
+// ApplyMutations godoc
+// @Summary Apply changes made offline
+// @Description Apply a batch of note and folder changes in one transaction. New records carry a client-generated ClientId, of at most 64 characters, which later changes of the same batch may refer to. Update and delete carry the Version the change is based on; if the record changed on the server since, the result is a conflict with the current state. A repeated create with the same ClientId returns the existing record. A note locked for editing by another client can be updated or deleted only with its LockToken; otherwise the change fails with NOTE_LOCKED
+// @Tags sync
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body SyncRq true "Changes in order of execution"
+// @Success 200 {array} model.SyncResult "Result of every change"
+// @Failure 400 {object} model.Problem "Invalid request data or too many changes"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/sync [post]
+func (s *SyncHandler) ApplyMutations(c *gin.Context) {
+	var req SyncRq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("Invalid request: %s", err.Error()))
+		return
+	}
+
+	userId := c.MustGet("UserId").(int)
+
+	results, err := service.WithOrigin(s.syncService, auditOrigin(c)).ApplyMutations(userId, req.Mutations)
+	if err != nil {
+		apiError := model.GetAppropriateApiError(err)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
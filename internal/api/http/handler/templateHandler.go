@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/service"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+type TemplateHandler struct {
+	templateService service.AbstractTemplateService
+}
+
+type TemplateRq struct {
+	Name     string    `json:"Name" example:"Встреча" binding:"required"`
+	Title    string    `json:"Title" example:"Встреча {{date}}: {{prompt:Тема}}" binding:"required"`
+	Content  string    `json:"Content" example:"Ведущий: {{user.name}}"`
+	Tags     *[]string `json:"Tags" example:"meeting"`
+	FolderId *int      `json:"FolderId" example:"1"`
+}
+
+// FromTemplateRq - ответы на запросы шаблона вида {{prompt:Тема}}
+type FromTemplateRq struct {
+	Values map[string]string `json:"Values"`
+}
+
+func NewTemplateHandler(s service.AbstractTemplateService) *TemplateHandler {
+	return &TemplateHandler{templateService: s}
+}
+
+// CreateTemplate godoc
+// @Summary Create a note template
+// @Description Create a note template. Title and content may contain {{date}}, {{time}}, {{user.name}}, {{user.surname}}, {{user.login}} and {{prompt:Name}} placeholders
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body TemplateRq true "Template data"
+// @Success 200 {object} int "Returns ID of created template"
+// @Failure 400 {object} model.Problem "Invalid request data"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 409 {object} model.Problem "Template name already taken"
+// @Failure 404 {object} model.Problem "Folder not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/templates [post]
+func (t *TemplateHandler) CreateTemplate(c *gin.Context) {
+	var req TemplateRq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("Invalid request: %s", err.Error()))
+		return
+	}
+
+	userId := c.MustGet("UserId").(int)
+
+	id, err := t.templateService.CreateTemplate(userId, req.toSettings())
+	if err != nil {
+		apiError := model.GetAppropriateApiError(err)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": id})
+}
+
+// GetTemplates godoc
+// @Summary Get note templates
+// @Description Get all note templates of the authenticated user
+// @Tags templates
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} model.TemplateApi "List of templates"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Router /api/templates [get]
+func (t *TemplateHandler) GetTemplates(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	c.JSON(http.StatusOK, t.templateService.GetTemplates(userId))
+}
+
+// GetTemplate godoc
+// @Summary Get a note template
+// @Description Get a note template with the list of values it prompts for
+// @Tags templates
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Template ID"
+// @Success 200 {object} model.TemplateApi "Template"
+// @Failure 400 {object} model.Problem "Invalid ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Template not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/templates/{id} [get]
+func (t *TemplateHandler) GetTemplate(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	template, errGet := t.templateService.GetTemplate(userId, id)
+	if errGet != nil {
+		apiError := model.GetAppropriateApiError(errGet)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// UpdateTemplate godoc
+// @Summary Update a note template
+// @Description Update an existing note template
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Template ID"
+// @Param input body TemplateRq true "Template data"
+// @Success 200 "Template updated successfully"
+// @Failure 400 {object} model.Problem "Invalid request data or ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 409 {object} model.Problem "Template name already taken"
+// @Failure 404 {object} model.Problem "Template or folder not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/templates/{id} [put]
+func (t *TemplateHandler) UpdateTemplate(c *gin.Context) {
+	var req TemplateRq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("Invalid request: %s", err.Error()))
+		return
+	}
+
+	userId := c.MustGet("UserId").(int)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	errUpdate := t.templateService.UpdateTemplate(userId, id, req.toSettings())
+	if errUpdate != nil {
+		apiError := model.GetAppropriateApiError(errUpdate)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// DeleteTemplate godoc
+// @Summary Delete a note template
+// @Description Delete a note template. Notes created from it are kept
+// @Tags templates
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Template ID"
+// @Success 200 "Template deleted successfully"
+// @Failure 400 {object} model.Problem "Invalid ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/templates/{id} [delete]
+func (t *TemplateHandler) DeleteTemplate(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	errDelete := t.templateService.DeleteTemplate(userId, id)
+	if errDelete != nil {
+		apiError := model.GetAppropriateApiError(errDelete)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// CreateNoteFromTemplate godoc
+// @Summary Create a note from a template
+// @Description Render the template and create a note from it with the usual note validation. The body may be omitted if the template has no prompts
+// @Tags templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Template ID"
+// @Param input body FromTemplateRq false "Values for template prompts"
+// @Success 200 {object} int "Returns ID of created note"
+// @Failure 400 {object} model.Problem "Invalid request data, ID or missing prompt values"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 409 {object} model.Problem "Note title already taken"
+// @Failure 404 {object} model.Problem "Template or folder not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/notes/from-template/{id} [post]
+func (t *TemplateHandler) CreateNoteFromTemplate(c *gin.Context) {
+	var req FromTemplateRq
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("Invalid request: %s", err.Error()))
+		return
+	}
+
+	userId := c.MustGet("UserId").(int)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	noteId, errCreate := service.WithOrigin(t.templateService, auditOrigin(c)).CreateNoteFromTemplate(userId, id, req.Values)
+	if errCreate != nil {
+		apiError := model.GetAppropriateApiError(errCreate)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": noteId})
+}
+
+func (r TemplateRq) toSettings() model.TemplateSettings {
+	return model.TemplateSettings{
+		Name:     r.Name,
+		Title:    r.Title,
+		Content:  r.Content,
+		Tags:     r.Tags,
+		FolderId: r.FolderId,
+	}
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"Notes/internal/model"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"mime/multipart"
+	"net/http"
+)
+
+// multipartOverhead - запас сверх размера файла на заголовки частей и остальные поля формы
+const multipartOverhead = 1024 * 1024
+
+// formFile достаёт файл из multipart-формы. Тело запроса ограничивается размером maxFileSize с запасом на
+// разметку формы ещё до разбора, поэтому слишком большой запрос обрывается при чтении и не попадает на диск,
+// а клиент получает 413. Если файла нет, ответ уже отправлен и возвращается false.
+func formFile(c *gin.Context, name string, maxFileSize int64) (*multipart.FileHeader, bool) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxFileSize+multipartOverhead)
+
+	fileHeader, err := c.FormFile(name)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			params := model.ErrorParams{"maxMb": maxFileSize / 1024 / 1024}
+			apiError := model.GetAppropriateApiError(model.NewLocalizedError(model.ErrorTypeValidation, model.CodeFileTooLarge, params, err))
+			apiError.Code = http.StatusRequestEntityTooLarge
+			errorResponseFromApiError(c, apiError)
+			return nil, false
+		}
+
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("Invalid request: %s", err.Error()))
+		return nil, false
+	}
+
+	return fileHeader, true
+}
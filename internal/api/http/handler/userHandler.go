@@ -19,15 +19,17 @@ type UserReq struct {
 	Password string `json:"Password" example:"securePassword123$" binding:"required"`
 	Name     string `json:"Name" example:"John"`
 	Surname  string `json:"Surname" example:"Doe"`
+	Timezone string `json:"Timezone" example:"Europe/Moscow"`
 }
 
 // UserRsp represents user response structure
 // @Description User response data
 type UserRsp struct {
-	Id      int    `json:"Id" example:"1"`
-	Login   string `json:"Login" example:"user123456"`
-	Name    string `json:"Name" example:"John"`
-	Surname string `json:"Surname" example:"Doe"`
+	Id       int    `json:"Id" example:"1"`
+	Login    string `json:"Login" example:"user123456"`
+	Name     string `json:"Name" example:"John"`
+	Surname  string `json:"Surname" example:"Doe"`
+	Timezone string `json:"Timezone" example:"Europe/Moscow"`
 }
 
 func NewUserHandler(s service.AbstractUserService) *UserHandler {
@@ -42,9 +44,9 @@ func NewUserHandler(s service.AbstractUserService) *UserHandler {
 // @Produce json
 // @Param input body UserReq true "User registration data"
 // @Success 201 {object} int "User created successfully"
-// @Failure 400 {object} response "Invalid request data"
-// @Failure 409 {object} response "User already exists"
-// @Failure 500 {object} response "Internal server error"
+// @Failure 400 {object} model.Problem "Invalid request data"
+// @Failure 409 {object} model.Problem "User already exists"
+// @Failure 500 {object} model.Problem "Internal server error"
 // @Router /api/user [post]
 func (u UserHandler) CreateUser(c *gin.Context) {
 	var req UserReq
@@ -76,9 +78,9 @@ func (u UserHandler) CreateUser(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Success 200 {object} UserRsp "Returns user profile data"
-// @Failure 401 {object} response "Unauthorized"
-// @Failure 404 {object} response "User not found"
-// @Failure 500 {object} response "Internal server error"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "User not found"
+// @Failure 500 {object} model.Problem "Internal server error"
 // @Router /api/user [get]
 func (u UserHandler) GetUser(c *gin.Context) {
 	userId := c.MustGet("UserId").(int)
@@ -92,10 +94,11 @@ func (u UserHandler) GetUser(c *gin.Context) {
 	}
 
 	userRsp := UserRsp{
-		Id:      userId,
-		Login:   user.Login,
-		Name:    user.Name,
-		Surname: user.Surname,
+		Id:       userId,
+		Login:    user.Login,
+		Name:     user.Name,
+		Surname:  user.Surname,
+		Timezone: user.Location().String(),
 	}
 	c.JSON(http.StatusOK, gin.H{
 		"user": userRsp,
@@ -111,10 +114,11 @@ func (u UserHandler) GetUser(c *gin.Context) {
 // @Security BearerAuth
 // @Param input body UserReq true "User update data"
 // @Success 200 "Profile updated successfully"
-// @Failure 400 {object} response "Invalid request data"
-// @Failure 401 {object} response "Unauthorized"
-// @Failure 404 {object} response "User not found"
-// @Failure 500 {object} response "Internal server error"
+// @Failure 400 {object} model.Problem "Invalid request data"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 409 {object} model.Problem "Login already taken"
+// @Failure 404 {object} model.Problem "User not found"
+// @Failure 500 {object} model.Problem "Internal server error"
 // @Router /api/user [put]
 func (u UserHandler) UpdateUser(c *gin.Context) {
 	var req UserReq
@@ -125,7 +129,7 @@ func (u UserHandler) UpdateUser(c *gin.Context) {
 	}
 	userId := c.MustGet("UserId").(int)
 
-	errUpdate := u.userService.UpdateUser(userId, req.Login, req.Password, req.Name, req.Surname)
+	errUpdate := service.WithOrigin(u.userService, auditOrigin(c)).UpdateUser(userId, req.Login, req.Password, req.Name, req.Surname, req.Timezone)
 
 	if errUpdate != nil {
 		apiError := model.GetAppropriateApiError(errUpdate)
@@ -143,14 +147,14 @@ func (u UserHandler) UpdateUser(c *gin.Context) {
 // @Produce json
 // @Security BearerAuth
 // @Success 200 "Account deleted successfully"
-// @Failure 401 {object} response "Unauthorized"
-// @Failure 404 {object} response "User not found"
-// @Failure 500 {object} response "Internal server error"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "User not found"
+// @Failure 500 {object} model.Problem "Internal server error"
 // @Router /api/user [delete]
 func (u UserHandler) DeleteUser(c *gin.Context) {
 	userId := c.MustGet("UserId").(int)
 
-	err := u.userService.DeleteUser(userId)
+	err := service.WithOrigin(u.userService, auditOrigin(c)).DeleteUser(userId)
 
 	if err != nil {
 		apiError := model.GetAppropriateApiError(err)
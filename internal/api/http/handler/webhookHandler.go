@@ -0,0 +1,287 @@
+package handler
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/service"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type WebhookHandler struct {
+	webhookService service.AbstractWebhookService
+}
+
+type WebhookRq struct {
+	Url      string   `json:"Url" example:"https://example.com/hooks/notes" binding:"required"`
+	Events   []string `json:"Events" example:"note.created,note.moved" binding:"required"`
+	IsActive *bool    `json:"IsActive" example:"true"`
+}
+
+func NewWebhookHandler(s service.AbstractWebhookService) *WebhookHandler {
+	return &WebhookHandler{webhookService: s}
+}
+
+// CreateWebhook godoc
+// @Summary Create webhook
+// @Description Subscribe an address to note and folder events. Every delivery is a POST signed with HMAC-SHA256: X-Notes-Signature is "sha256=" + hex(HMAC(secret, X-Notes-Timestamp + "." + body)). The secret is returned only once
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param input body WebhookRq true "Webhook settings"
+// @Success 200 {object} map[string]interface{} "Webhook id and signing secret"
+// @Failure 400 {object} model.Problem "Invalid request data"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/webhooks [post]
+func (w *WebhookHandler) CreateWebhook(c *gin.Context) {
+	var req WebhookRq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("Invalid request: %s", err.Error()))
+		return
+	}
+
+	userId := c.MustGet("UserId").(int)
+
+	id, secret, errCreate := service.WithOrigin(w.webhookService, auditOrigin(c)).CreateWebhook(userId, webhookSettings(req))
+	if errCreate != nil {
+		apiError := model.GetAppropriateApiError(errCreate)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"id": id, "secret": secret})
+}
+
+// GetWebhooks godoc
+// @Summary Get webhooks
+// @Description Get all webhooks of the user
+// @Tags webhooks
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} model.WebhookApi "List of webhooks"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Router /api/webhooks [get]
+func (w *WebhookHandler) GetWebhooks(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	c.JSON(http.StatusOK, w.webhookService.GetWebhooks(userId))
+}
+
+// GetWebhook godoc
+// @Summary Get webhook
+// @Description Get webhook settings by ID
+// @Tags webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} model.WebhookApi "Webhook"
+// @Failure 400 {object} model.Problem "Invalid ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Webhook not found"
+// @Router /api/webhooks/{id} [get]
+func (w *WebhookHandler) GetWebhook(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	webhookId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	webhook, errGet := w.webhookService.GetWebhook(userId, webhookId)
+	if errGet != nil {
+		apiError := model.GetAppropriateApiError(errGet)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, webhook)
+}
+
+// UpdateWebhook godoc
+// @Summary Update webhook
+// @Description Replace the address, events and activity of the webhook. The secret is kept
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Webhook ID"
+// @Param input body WebhookRq true "Webhook settings"
+// @Success 200 "Webhook updated successfully"
+// @Failure 400 {object} model.Problem "Invalid request data or ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Webhook not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/webhooks/{id} [put]
+func (w *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	var req WebhookRq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		errorResponse(c, http.StatusBadRequest, fmt.Sprintf("Invalid request: %s", err.Error()))
+		return
+	}
+
+	userId := c.MustGet("UserId").(int)
+
+	webhookId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	if errUpdate := service.WithOrigin(w.webhookService, auditOrigin(c)).UpdateWebhook(userId, webhookId, webhookSettings(req)); errUpdate != nil {
+		apiError := model.GetAppropriateApiError(errUpdate)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// DeleteWebhook godoc
+// @Summary Delete webhook
+// @Description Delete the webhook together with its delivery log
+// @Tags webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Webhook ID"
+// @Success 200 "Webhook deleted successfully"
+// @Failure 400 {object} model.Problem "Invalid ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Webhook not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/webhooks/{id} [delete]
+func (w *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	webhookId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	if errDelete := service.WithOrigin(w.webhookService, auditOrigin(c)).DeleteWebhook(userId, webhookId); errDelete != nil {
+		apiError := model.GetAppropriateApiError(errDelete)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// GetDeliveries godoc
+// @Summary Get webhook deliveries
+// @Description Get the latest deliveries of the webhook with status, response codes and every attempt. Failed deliveries are retried with exponential backoff and marked dead when attempts run out
+// @Tags webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Webhook ID"
+// @Success 200 {array} model.WebhookDeliveryApi "Latest deliveries"
+// @Failure 400 {object} model.Problem "Invalid ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Webhook not found"
+// @Router /api/webhooks/{id}/deliveries [get]
+func (w *WebhookHandler) GetDeliveries(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	webhookId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	deliveries, errGet := w.webhookService.GetDeliveries(userId, webhookId)
+	if errGet != nil {
+		apiError := model.GetAppropriateApiError(errGet)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// RetryDelivery godoc
+// @Summary Retry webhook delivery
+// @Description Put the delivery back into the queue with a full set of attempts
+// @Tags webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Webhook ID"
+// @Param deliveryId path int true "Delivery ID"
+// @Success 200 "Delivery queued"
+// @Failure 400 {object} model.Problem "Invalid ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Webhook or delivery not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/webhooks/{id}/deliveries/{deliveryId}/retry [post]
+func (w *WebhookHandler) RetryDelivery(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	webhookId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	deliveryId, err := strconv.Atoi(c.Param("deliveryId"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid delivery ID")
+		return
+	}
+
+	if errRetry := w.webhookService.RetryDelivery(userId, webhookId, deliveryId); errRetry != nil {
+		apiError := model.GetAppropriateApiError(errRetry)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+// SendTestEvent godoc
+// @Summary Send test event
+// @Description Immediately send a signed webhook.test event to the webhook and return the result of the attempt. A failed test delivery is not retried
+// @Tags webhooks
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Webhook ID"
+// @Success 200 {object} model.WebhookDeliveryApi "Test delivery"
+// @Failure 400 {object} model.Problem "Invalid ID"
+// @Failure 401 {object} model.Problem "Unauthorized"
+// @Failure 404 {object} model.Problem "Webhook not found"
+// @Failure 500 {object} model.Problem "Internal server error"
+// @Router /api/webhooks/{id}/test [post]
+func (w *WebhookHandler) SendTestEvent(c *gin.Context) {
+	userId := c.MustGet("UserId").(int)
+
+	webhookId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Invalid ID")
+		return
+	}
+
+	delivery, errSend := w.webhookService.SendTestEvent(userId, webhookId)
+	if errSend != nil {
+		apiError := model.GetAppropriateApiError(errSend)
+		errorResponseFromApiError(c, apiError)
+		return
+	}
+
+	c.JSON(http.StatusOK, delivery)
+}
+
+// webhookSettings переводит запрос в настройки; без IsActive webhook включён
+func webhookSettings(req WebhookRq) model.WebhookSettings {
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	return model.WebhookSettings{
+		Url:      req.Url,
+		Events:   req.Events,
+		IsActive: isActive,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"Notes/internal/model"
 	"Notes/internal/service"
 	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
 	"net/http"
 	"strings"
 )
@@ -11,9 +12,13 @@ import (
 func AuthMiddleware(service service.AbstractAuthService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		tokenString := c.GetHeader("Authorization")
+		// Браузер не может задать заголовок при открытии WebSocket и EventSource, поэтому токен принимается из адреса
+		if tokenString == "" && (websocket.IsWebSocketUpgrade(c.Request) || c.GetHeader("Accept") == "text/event-stream") {
+			tokenString = c.Query("access_token")
+		}
+
 		if tokenString == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"message": "No token provided"})
-			c.Abort()
+			authErrorResponse(c, model.NewLocalizedError(model.ErrorTypeAuth, model.CodeUnauthorized, nil, nil))
 
 			return
 		}
@@ -25,14 +30,12 @@ func AuthMiddleware(service service.AbstractAuthService) gin.HandlerFunc {
 		if err != nil {
 			apiError := model.GetAppropriateApiError(err)
 
-			if apiError.Code == http.StatusBadRequest {
-				c.JSON(apiError.Code, gin.H{"message": apiError.Message})
+			if apiError.Code == http.StatusUnauthorized {
+				authErrorResponse(c, err)
 			} else {
-				c.JSON(http.StatusUnauthorized, gin.H{"message": "пользователь не найден"})
+				authErrorResponse(c, model.NewLocalizedError(model.ErrorTypeAuth, model.CodeUserNotFound, nil, nil))
 			}
 
-			c.Abort()
-
 			return
 		}
 
@@ -40,3 +43,13 @@ func AuthMiddleware(service service.AbstractAuthService) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+func authErrorResponse(c *gin.Context, err *model.ApplicationError) {
+	locale := model.DefaultLocale
+	if value, exists := c.Get("Locale"); exists {
+		locale = value.(model.Locale)
+	}
+
+	c.Header("Content-Type", model.ProblemContentType)
+	c.AbortWithStatusJSON(http.StatusUnauthorized, model.NewProblem(model.GetAppropriateApiError(err), locale, c.GetString("traceID")))
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// LocaleMiddleware выбирает язык сообщений: сначала по заголовку Accept-Language,
+// затем по настройкам пользователя, если он уже авторизован. Подключается глобально
+// и повторно после AuthMiddleware, чтобы учесть настройки пользователя.
+func LocaleMiddleware(service service.AbstractPreferenceService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if locale, found := model.ParseAcceptLanguage(c.GetHeader("Accept-Language")); found {
+			c.Set("Locale", locale)
+			c.Next()
+
+			return
+		}
+
+		locale := model.DefaultLocale
+		if userId, exists := c.Get("UserId"); exists {
+			if preferred, err := service.GetLocale(userId.(int)); err == nil {
+				locale = preferred
+			}
+		}
+
+		c.Set("Locale", locale)
+		c.Next()
+	}
+}
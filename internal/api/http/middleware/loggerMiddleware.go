@@ -13,6 +13,11 @@ func RequestLogger() gin.HandlerFunc {
 		traceID := uuid.New().String() // используем github.com/google/uuid
 		start := time.Now()
 
+		c.Set("traceID", traceID)
+
+		c.Next()
+
+		// UserId выставляет AuthMiddleware группы /api, поэтому читаем его после обработки запроса
 		var userID interface{}
 		if uid, exists := c.Get("UserId"); exists {
 			userID = uid.(int)
@@ -20,10 +25,6 @@ func RequestLogger() gin.HandlerFunc {
 			userID = 0
 		}
 
-		c.Set("traceID", traceID)
-
-		c.Next()
-
 		log.Println(
 			fmt.Sprintf("HTTP REQUEST. Method: %s, path: %s, traceId: %s, userId: %v, status: %d, duration: %d",
 				c.Request.Method, c.Request.URL.Path, traceID, userID, c.Writer.Status(), time.Since(start)))
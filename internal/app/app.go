@@ -29,11 +29,35 @@ import (
 )
 
 type Collection struct {
-	Auth     *handler.AuthHandler
-	User     *handler.UserHandler
-	Folder   *handler.FolderHandler
-	Notebook *handler.NotebookHandler
-	Note     *handler.NoteHandler
+	Auth         *handler.AuthHandler
+	User         *handler.UserHandler
+	Folder       *handler.FolderHandler
+	Notebook     *handler.NotebookHandler
+	Note         *handler.NoteHandler
+	Attachment   *handler.AttachmentHandler
+	Checklist    *handler.ChecklistHandler
+	Reminder     *handler.ReminderHandler
+	Calendar     *handler.CalendarHandler
+	Link         *handler.LinkHandler
+	Template     *handler.TemplateHandler
+	DailyNote    *handler.DailyNoteHandler
+	Preference   *handler.PreferenceHandler
+	Import       *handler.ImportHandler
+	Export       *handler.ExportHandler
+	Document     *handler.DocumentHandler
+	Event        *handler.EventHandler
+	Sync         *handler.SyncHandler
+	Webhook      *handler.WebhookHandler
+	Audit        *handler.AuditHandler
+	Comment      *handler.CommentHandler
+	Notification *handler.NotificationHandler
+	Collab       *handler.CollabHandler
+	NoteLock     *handler.NoteLockHandler
+}
+
+// Worker - фоновый процесс, работающий до отмены контекста
+type Worker interface {
+	Run(ctx context.Context)
 }
 
 type Dependencies struct {
@@ -41,6 +65,8 @@ type Dependencies struct {
 	Handlers         Collection
 	AuthMiddleware   gin.HandlerFunc
 	LoggerMiddleware gin.HandlerFunc
+	LocaleMiddleware gin.HandlerFunc
+	Workers          []Worker
 }
 
 func Run() {
@@ -55,7 +81,13 @@ func Run() {
 	}
 	defer deps.SQL.Close()
 
-	router := setupRouter(deps.Handlers, deps.AuthMiddleware, deps.LoggerMiddleware)
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+	for _, worker := range deps.Workers {
+		go worker.Run(workersCtx)
+	}
+
+	router := setupRouter(deps.Handlers, deps.AuthMiddleware, deps.LoggerMiddleware, deps.LocaleMiddleware)
 	srv := startHTTPServer(router, cfg.Server.Port)
 
 	quit := make(chan os.Signal, 1)
@@ -63,6 +95,7 @@ func Run() {
 	<-quit
 
 	log.Println("Shutting down server...")
+	stopWorkers()
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	if err := srv.Shutdown(ctx); err != nil {
@@ -71,8 +104,8 @@ func Run() {
 	log.Println("Server exited properly")
 }
 
-func connectAndMigrate(cfg config.Database) (*gorm.DB, *sql.DB) {
-	dsn := fmt.Sprintf(
+func databaseDsn(cfg config.Database) string {
+	return fmt.Sprintf(
 		"postgres://%s:%s@%s:%d/%s?sslmode=%s",
 		cfg.User,
 		cfg.Password,
@@ -81,6 +114,10 @@ func connectAndMigrate(cfg config.Database) (*gorm.DB, *sql.DB) {
 		cfg.Name,
 		cfg.SSLMode,
 	)
+}
+
+func connectAndMigrate(cfg config.Database) (*gorm.DB, *sql.DB) {
+	dsn := databaseDsn(cfg)
 
 	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
 	if err != nil {
@@ -125,25 +162,79 @@ func setupDependencies(cfg *config.Config) (*Dependencies, error) {
 	jwtService := service.NewConcreteJwtService(cfg)
 	hashService := service.NewConcreteHashService()
 	authService := service.NewConcreteAuthService(postgresRepo, jwtService)
-	folderService := service.NewConcreteFolderService(postgresRepo)
+	eventBus := service.NewConcreteEventBus(repository.NewPostgresEventBroker(gormDb, databaseDsn(cfg.Database)))
+	folderService := service.NewConcreteFolderService(postgresRepo, eventBus)
 	notebookService := service.NewConcreteNotebookService(postgresRepo)
-	noteService := service.NewConcreteNoteService(postgresRepo)
+	noteService := service.NewConcreteNoteService(postgresRepo, eventBus)
 	userService := service.NewConcreteUserService(postgresRepo, hashService)
+	blobStorage := repository.NewFileBlobStorage(cfg.Attachments.StoragePath)
+	thumbnailService := service.NewConcreteThumbnailService(postgresRepo, blobStorage, cfg)
+	attachmentService := service.NewConcreteAttachmentService(postgresRepo, blobStorage, thumbnailService, cfg)
+	checklistService := service.NewConcreteChecklistService(postgresRepo, eventBus)
+	reminderService := service.NewConcreteReminderService(postgresRepo, setupNotifiers(postgresRepo, cfg), cfg)
+	calendarService := service.NewConcreteCalendarService(postgresRepo, cfg)
+	linkService := service.NewConcreteLinkService(postgresRepo)
+	templateService := service.NewConcreteTemplateService(postgresRepo, noteService)
+	dailyNoteService := service.NewConcreteDailyNoteService(postgresRepo, noteService, cfg)
+	preferenceService := service.NewConcretePreferenceService(postgresRepo)
+	importService := service.NewConcreteImportService(postgresRepo, blobStorage, eventBus, noteService, folderService, attachmentService, cfg)
+	exportService := service.NewConcreteExportService(postgresRepo)
+	documentService := service.NewConcreteDocumentService(postgresRepo)
+	webhookService := service.NewConcreteWebhookService(postgresRepo, cfg)
+	auditService := service.NewConcreteAuditService(postgresRepo, cfg)
+	collabService := service.NewConcreteCollabService(postgresRepo, eventBus, cfg)
+	noteLockService := service.NewConcreteNoteLockService(postgresRepo, cfg)
 
 	return &Dependencies{
 		SQL: sqlDb,
 		Handlers: Collection{
-			Auth:     handler.NewAuthHandler(authService),
-			User:     handler.NewUserHandler(userService),
-			Folder:   handler.NewFolderHandler(folderService),
-			Notebook: handler.NewNotebookHandler(notebookService),
-			Note:     handler.NewNoteHandler(noteService),
+			Auth:         handler.NewAuthHandler(authService, auditService),
+			User:         handler.NewUserHandler(userService),
+			Folder:       handler.NewFolderHandler(folderService),
+			Notebook:     handler.NewNotebookHandler(notebookService),
+			Note:         handler.NewNoteHandler(noteService),
+			Attachment:   handler.NewAttachmentHandler(attachmentService, cfg),
+			Checklist:    handler.NewChecklistHandler(checklistService),
+			Reminder:     handler.NewReminderHandler(reminderService),
+			Calendar:     handler.NewCalendarHandler(calendarService),
+			Link:         handler.NewLinkHandler(linkService),
+			Template:     handler.NewTemplateHandler(templateService),
+			DailyNote:    handler.NewDailyNoteHandler(dailyNoteService),
+			Preference:   handler.NewPreferenceHandler(preferenceService),
+			Import:       handler.NewImportHandler(importService, cfg),
+			Export:       handler.NewExportHandler(exportService),
+			Document:     handler.NewDocumentHandler(documentService),
+			Event:        handler.NewEventHandler(eventBus, service.NewConcreteChangeFeedService(postgresRepo)),
+			Sync:         handler.NewSyncHandler(service.NewConcreteSyncService(postgresRepo, eventBus)),
+			Webhook:      handler.NewWebhookHandler(webhookService),
+			Audit:        handler.NewAuditHandler(auditService),
+			Comment:      handler.NewCommentHandler(service.NewConcreteCommentService(postgresRepo)),
+			Notification: handler.NewNotificationHandler(service.NewConcreteNotificationService(postgresRepo)),
+			Collab:       handler.NewCollabHandler(collabService),
+			NoteLock:     handler.NewNoteLockHandler(noteLockService),
 		},
 		AuthMiddleware:   middleware.AuthMiddleware(authService),
 		LoggerMiddleware: middleware.RequestLogger(),
+		LocaleMiddleware: middleware.LocaleMiddleware(preferenceService),
+		Workers:          []Worker{thumbnailService, attachmentService, reminderService, importService, eventBus, webhookService, collabService, noteLockService},
 	}, nil
 }
 
+func setupNotifiers(repo repository.AbstractRepository, cfg *config.Config) []service.Notifier {
+	notifiers := []service.Notifier{
+		service.NewInboxNotifier(repo),
+		service.NewWebhookNotifier(),
+	}
+
+	if cfg.Reminders.Smtp.Host != "" {
+		notifiers = append(notifiers, service.NewEmailNotifier(cfg.Reminders.Smtp))
+	} else {
+		log.Println("SMTP не настроен, email-напоминания отключены")
+	}
+
+	return notifiers
+}
+
 func startHTTPServer(handler http.Handler, port int) *http.Server {
 	srv := &http.Server{
 		Addr:         fmt.Sprintf(":%d", port),
@@ -163,22 +254,30 @@ func startHTTPServer(handler http.Handler, port int) *http.Server {
 	return srv
 }
 
-func setupRouter(h Collection, authMiddleware gin.HandlerFunc, loggerMiddleware gin.HandlerFunc) *gin.Engine {
+func setupRouter(h Collection, authMiddleware gin.HandlerFunc, loggerMiddleware gin.HandlerFunc, localeMiddleware gin.HandlerFunc) *gin.Engine {
 	r := gin.Default()
-	r.Use(authMiddleware)
 	r.Use(loggerMiddleware)
+	r.Use(localeMiddleware)
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 	protected := r.Group("/api")
-	protected.Use(authMiddleware)
+	protected.Use(authMiddleware, localeMiddleware)
 	{
 		protected.GET("/user", h.User.GetUser)
 		protected.PUT("/user", h.User.UpdateUser)
 		protected.DELETE("/user", h.User.DeleteUser)
+		protected.GET("/user/preferences", h.Preference.GetPreferences)
+		protected.PATCH("/user/preferences", h.Preference.UpdatePreferences)
+		protected.GET("/user/audit", h.Audit.GetUserAudit)
+		protected.GET("/admin/audit", h.Audit.QueryAudit)
 
 		protected.POST("/folder", h.Folder.CreateFolder)
 		protected.PUT("/folder/:id", h.Folder.UpdateFolder)
 		protected.DELETE("/folder/:id", h.Folder.DeleteFolder)
+		protected.PUT("/folder/:id/position", h.Folder.ReorderFolder)
+		protected.PUT("/folder/:id/archive", h.Folder.ArchiveFolder)
+		protected.DELETE("/folder/:id/archive", h.Folder.UnarchiveFolder)
+		protected.GET("/folder/:id/export", h.Document.ExportFolder)
 
 		protected.GET("/notebook", h.Notebook.GetNotebook)
 
@@ -187,13 +286,90 @@ func setupRouter(h Collection, authMiddleware gin.HandlerFunc, loggerMiddleware
 		protected.DELETE("/notes/:id", h.Note.DeleteNote)
 		protected.GET("/notes/favorites", h.Note.GetFavoriteNotes)
 		protected.GET("/notes/search", h.Note.FindNotes)
+		protected.GET("/notes/archived", h.Note.GetArchivedNotes)
 		protected.PUT("/notes/:id/move", h.Note.MoveNote)
+		protected.POST("/notes/:id/comments", h.Comment.CreateComment)
+		protected.GET("/notes/:id/comments", h.Comment.GetComments)
+		protected.GET("/notes/:id/collab", h.Collab.Connect)
+		protected.POST("/notes/:id/lock", h.NoteLock.AcquireLock)
+		protected.DELETE("/notes/:id/lock", h.NoteLock.ReleaseLock)
+		protected.PUT("/comments/:id", h.Comment.UpdateComment)
+		protected.DELETE("/comments/:id", h.Comment.DeleteComment)
+		protected.PUT("/comments/:id/resolve", h.Comment.ResolveComment)
+		protected.DELETE("/comments/:id/resolve", h.Comment.UnresolveComment)
+		protected.GET("/notifications", h.Notification.GetNotifications)
+		protected.GET("/notifications/unread-count", h.Notification.GetUnreadCount)
+		protected.PUT("/notifications/read", h.Notification.MarkAllRead)
+		protected.PUT("/notifications/:id/read", h.Notification.MarkRead)
+		protected.PUT("/notes/:id/position", h.Note.ReorderNote)
+		protected.PUT("/notes/:id/pin", h.Note.PinNote)
+		protected.DELETE("/notes/:id/pin", h.Note.UnpinNote)
+		protected.PUT("/notes/:id/archive", h.Note.ArchiveNote)
+		protected.DELETE("/notes/:id/archive", h.Note.UnarchiveNote)
 		protected.PUT("/notes/:id/favorites", h.Note.AddToFavorites)
 		protected.DELETE("/notes/:id/favorites", h.Note.DeleteFromFavorites)
+		protected.GET("/notes/:id/export", h.Document.ExportNote)
+
+		protected.POST("/notes/:id/attachments", h.Attachment.UploadAttachment)
+		protected.GET("/notes/:id/attachments", h.Attachment.GetAttachments)
+		protected.GET("/notes/:id/attachments/:aid", h.Attachment.DownloadAttachment)
+		protected.DELETE("/notes/:id/attachments/:aid", h.Attachment.DeleteAttachment)
+		protected.GET("/notes/:id/attachments/:aid/thumbnail", h.Attachment.GetThumbnail)
+
+		protected.PUT("/notes/:id/type", h.Checklist.ChangeNoteType)
+		protected.POST("/notes/:id/items", h.Checklist.AddItem)
+		protected.PUT("/notes/:id/items/:itemId/position", h.Checklist.MoveItem)
+		protected.PUT("/notes/:id/items/:itemId/checked", h.Checklist.CheckItem)
+		protected.DELETE("/notes/:id/items/:itemId/checked", h.Checklist.UncheckItem)
+		protected.DELETE("/notes/:id/items/:itemId", h.Checklist.DeleteItem)
+
+		protected.PUT("/notes/:id/reminder", h.Reminder.SetReminder)
+		protected.GET("/notes/:id/reminder", h.Reminder.GetReminder)
+		protected.DELETE("/notes/:id/reminder", h.Reminder.DeleteReminder)
+		protected.GET("/reminders", h.Reminder.GetUpcomingReminders)
+
+		protected.POST("/calendar/token", h.Calendar.RegenerateFeedUrl)
+		protected.DELETE("/calendar/token", h.Calendar.RevokeFeed)
+
+		protected.GET("/notes/:id/backlinks", h.Link.GetBacklinks)
+		protected.GET("/graph", h.Link.GetGraph)
+
+		protected.POST("/templates", h.Template.CreateTemplate)
+		protected.GET("/templates", h.Template.GetTemplates)
+		protected.GET("/templates/:id", h.Template.GetTemplate)
+		protected.PUT("/templates/:id", h.Template.UpdateTemplate)
+		protected.DELETE("/templates/:id", h.Template.DeleteTemplate)
+		protected.POST("/notes/from-template/:id", h.Template.CreateNoteFromTemplate)
+
+		protected.GET("/notes/daily", h.DailyNote.GetDailyNote)
+		protected.GET("/notes/daily/:date", h.DailyNote.GetDailyNote)
+		protected.GET("/notes/daily/settings", h.DailyNote.GetSettings)
+		protected.PUT("/notes/daily/settings", h.DailyNote.UpdateSettings)
+
+		protected.POST("/import/markdown", h.Import.StartMarkdownImport)
+		protected.POST("/import/json", h.Import.StartJsonImport)
+		protected.POST("/import/enex", h.Import.StartEnexImport)
+		protected.GET("/import/jobs/:id", h.Import.GetImportJob)
+		protected.GET("/export", h.Export.Export)
+
+		protected.GET("/ws", h.Event.Subscribe)
+		protected.GET("/events", h.Event.Stream)
+		protected.GET("/sync", h.Sync.GetChanges)
+		protected.POST("/sync", h.Sync.ApplyMutations)
+
+		protected.POST("/webhooks", h.Webhook.CreateWebhook)
+		protected.GET("/webhooks", h.Webhook.GetWebhooks)
+		protected.GET("/webhooks/:id", h.Webhook.GetWebhook)
+		protected.PUT("/webhooks/:id", h.Webhook.UpdateWebhook)
+		protected.DELETE("/webhooks/:id", h.Webhook.DeleteWebhook)
+		protected.GET("/webhooks/:id/deliveries", h.Webhook.GetDeliveries)
+		protected.POST("/webhooks/:id/deliveries/:deliveryId/retry", h.Webhook.RetryDelivery)
+		protected.POST("/webhooks/:id/test", h.Webhook.SendTestEvent)
 	}
 
 	r.POST("/api/auth/login", h.Auth.Login)
 	r.POST("/api/user", h.User.CreateUser)
+	r.GET("/calendar/:token", h.Calendar.GetFeed)
 
 	return r
 }
@@ -2,6 +2,4 @@ package constants
 
 const MaxContentLength = 200
 const MaxTagsCount = 3
-const FolderTitleIsNotFree = "Папка с таким же именем уже добавлена"
-const NoteNameIsNotFree = "Заметка с таким названием уже добавлена"
 const FakeId = -1
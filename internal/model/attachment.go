@@ -0,0 +1,124 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+type ThumbnailStatus string
+
+const (
+	ThumbnailStatusNone    ThumbnailStatus = "none"
+	ThumbnailStatusPending ThumbnailStatus = "pending"
+	ThumbnailStatusReady   ThumbnailStatus = "ready"
+	ThumbnailStatusFailed  ThumbnailStatus = "failed"
+)
+
+const MaxFileNameLength = 255
+
+var thumbnailContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/webp": true,
+}
+
+type Attachment struct {
+	Id              int
+	NoteId          int
+	UserId          int
+	FileName        string
+	ContentType     string
+	Size            int64
+	StorageKey      string
+	ThumbnailStatus ThumbnailStatus
+	Timestamp       time.Time
+}
+
+type AttachmentThumbnail struct {
+	Id           int
+	AttachmentId int
+	Size         int
+	ContentType  string
+	StorageKey   string
+	Timestamp    time.Time
+}
+
+// OrphanedBlob - файл вложения или миниатюры, строка которого уже удалена. Очередь заполняется
+// триггером в базе, в том числе при каскадном удалении заметки или пользователя.
+type OrphanedBlob struct {
+	Id         int
+	StorageKey string
+	Timestamp  time.Time
+}
+
+func NewAttachment(noteId int, userId int, fileName string, contentType string, size int64, storageKey string) (*Attachment, *ApplicationError) {
+	validationError := validateAttachment(fileName, size)
+
+	if validationError != nil {
+		return nil, validationError
+	}
+
+	status := ThumbnailStatusNone
+	if IsThumbnailSupported(contentType) {
+		status = ThumbnailStatusPending
+	}
+
+	return &Attachment{
+		Id:              0,
+		NoteId:          noteId,
+		UserId:          userId,
+		FileName:        fileName,
+		ContentType:     contentType,
+		Size:            size,
+		StorageKey:      storageKey,
+		ThumbnailStatus: status,
+	}, nil
+}
+
+func (a *Attachment) SetId(id int) {
+	a.Id = id
+}
+
+func (a *Attachment) GetId() int {
+	return a.Id
+}
+
+func (a *Attachment) SetTimestamp() {
+	a.Timestamp = time.Now()
+}
+
+func (a *Attachment) IsImage() bool {
+	return IsThumbnailSupported(a.ContentType)
+}
+
+func (t *AttachmentThumbnail) SetId(id int) {
+	t.Id = id
+}
+
+func (t *AttachmentThumbnail) GetId() int {
+	return t.Id
+}
+
+func (t *AttachmentThumbnail) SetTimestamp() {
+	t.Timestamp = time.Now()
+}
+
+func IsThumbnailSupported(contentType string) bool {
+	return thumbnailContentTypes[strings.ToLower(contentType)]
+}
+
+func validateAttachment(fileName string, size int64) *ApplicationError {
+	if len(fileName) == 0 {
+		return NewLocalizedError(ErrorTypeValidation, CodeFileNameEmpty, nil, nil)
+	}
+
+	if len(fileName) > MaxFileNameLength {
+		return NewLocalizedError(ErrorTypeValidation, CodeFileNameTooLong, ErrorParams{"max": MaxFileNameLength}, nil)
+	}
+
+	if size <= 0 {
+		return NewLocalizedError(ErrorTypeValidation, CodeFileEmpty, nil, nil)
+	}
+
+	return nil
+}
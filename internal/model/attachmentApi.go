@@ -0,0 +1,53 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+type AttachmentApi struct {
+	Id              int
+	NoteId          int
+	FileName        string
+	ContentType     string
+	Size            int64
+	ThumbnailStatus ThumbnailStatus
+	ThumbnailUrl    *string `json:",omitempty"`
+	Timestamp       time.Time
+}
+
+func GetThumbnailUrl(noteId int, attachmentId int) string {
+	return fmt.Sprintf("/api/notes/%d/attachments/%d/thumbnail", noteId, attachmentId)
+}
+
+func ToAttachmentApi(dbAttachment *Attachment) *AttachmentApi {
+	if dbAttachment == nil {
+		return nil
+	}
+
+	var thumbnailUrl *string
+	if dbAttachment.ThumbnailStatus == ThumbnailStatusReady {
+		url := GetThumbnailUrl(dbAttachment.NoteId, dbAttachment.Id)
+		thumbnailUrl = &url
+	}
+
+	return &AttachmentApi{
+		Id:              dbAttachment.Id,
+		NoteId:          dbAttachment.NoteId,
+		FileName:        dbAttachment.FileName,
+		ContentType:     dbAttachment.ContentType,
+		Size:            dbAttachment.Size,
+		ThumbnailStatus: dbAttachment.ThumbnailStatus,
+		ThumbnailUrl:    thumbnailUrl,
+		Timestamp:       dbAttachment.Timestamp,
+	}
+}
+
+func ToAttachmentsApi(dbAttachments []*Attachment) []*AttachmentApi {
+	attachments := make([]*AttachmentApi, 0, len(dbAttachments))
+	for i := range dbAttachments {
+		attachments = append(attachments, ToAttachmentApi(dbAttachments[i]))
+	}
+
+	return attachments
+}
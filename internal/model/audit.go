@@ -0,0 +1,250 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const DefaultAuditLimit = 50
+const MaxAuditLimit = 200
+
+// AuditAction - действие, которое попадает в журнал аудита
+type AuditAction string
+
+const (
+	AuditLogin           AuditAction = "auth.login"
+	AuditLoginFailed     AuditAction = "auth.login_failed"
+	AuditUserUpdated     AuditAction = "user.updated"
+	AuditPasswordChanged AuditAction = "user.password_changed"
+	AuditUserDeleted     AuditAction = "user.deleted"
+	AuditNoteCreated     AuditAction = "note.created"
+	AuditNoteUpdated     AuditAction = "note.updated"
+	AuditNoteDeleted     AuditAction = "note.deleted"
+	AuditNoteMoved       AuditAction = "note.moved"
+	AuditNoteFavorite    AuditAction = "note.favorite"
+	AuditFolderCreated   AuditAction = "folder.created"
+	AuditFolderRenamed   AuditAction = "folder.renamed"
+	AuditFolderDeleted   AuditAction = "folder.deleted"
+	AuditFolderUpdated   AuditAction = "folder.updated"
+	AuditWebhookCreated  AuditAction = "webhook.created"
+	AuditWebhookUpdated  AuditAction = "webhook.updated"
+	AuditWebhookDeleted  AuditAction = "webhook.deleted"
+)
+
+var auditActions = []AuditAction{
+	AuditLogin,
+	AuditLoginFailed,
+	AuditUserUpdated,
+	AuditPasswordChanged,
+	AuditUserDeleted,
+	AuditNoteCreated,
+	AuditNoteUpdated,
+	AuditNoteDeleted,
+	AuditNoteMoved,
+	AuditNoteFavorite,
+	AuditFolderCreated,
+	AuditFolderRenamed,
+	AuditFolderDeleted,
+	AuditFolderUpdated,
+	AuditWebhookCreated,
+	AuditWebhookUpdated,
+	AuditWebhookDeleted,
+}
+
+type AuditEntity string
+
+const (
+	AuditEntityUser    AuditEntity = "user"
+	AuditEntityNote    AuditEntity = "note"
+	AuditEntityFolder  AuditEntity = "folder"
+	AuditEntityWebhook AuditEntity = "webhook"
+)
+
+// AuditSummary - краткое состояние записи до или после действия. Текст заметок и пароли в него не попадают.
+type AuditSummary map[string]interface{}
+
+// AuditEntry - запись журнала аудита: кто, что и с какой записью сделал, откуда и в каком запросе.
+// ActorId пуст, если пользователя определить не удалось, например при входе с неизвестным логином.
+type AuditEntry struct {
+	Id        int
+	ActorId   *int
+	Action    AuditAction
+	Entity    AuditEntity
+	EntityId  *int
+	Ip        string
+	UserAgent string
+	TraceId   string
+	Before    *string
+	After     *string
+	Timestamp time.Time
+}
+
+// AuditOrigin - откуда пришло действие: адрес и клиент пользователя и traceId запроса.
+// У фоновых действий, например импорта или сохранения совместного редактирования, он пуст.
+type AuditOrigin struct {
+	Ip        string
+	UserAgent string
+	TraceId   string
+}
+
+// AuditFilter - условия выборки журнала. BeforeId - курсор: возвращаются записи старше него.
+type AuditFilter struct {
+	ActorId  *int
+	Action   AuditAction
+	Entity   AuditEntity
+	EntityId *int
+	Ip       string
+	TraceId  string
+	From     *time.Time
+	To       *time.Time
+	BeforeId int
+	Limit    int
+}
+
+type AuditEntryApi struct {
+	Id        int
+	ActorId   *int `json:",omitempty"`
+	Action    AuditAction
+	Entity    AuditEntity
+	EntityId  *int `json:",omitempty"`
+	Ip        string
+	UserAgent string
+	TraceId   string
+	Before    json.RawMessage `json:",omitempty" swaggertype:"object"`
+	After     json.RawMessage `json:",omitempty" swaggertype:"object"`
+	Timestamp time.Time
+}
+
+func NewAuditEntry(actorId *int, action AuditAction, entity AuditEntity, entityId *int) *AuditEntry {
+	return &AuditEntry{
+		ActorId:  actorId,
+		Action:   action,
+		Entity:   entity,
+		EntityId: entityId,
+	}
+}
+
+func (a *AuditEntry) SetOrigin(origin AuditOrigin) {
+	a.Ip = origin.Ip
+	a.UserAgent = origin.UserAgent
+	a.TraceId = origin.TraceId
+}
+
+// SetChange сохраняет состояние записи до и после действия
+func (a *AuditEntry) SetChange(before AuditSummary, after AuditSummary) {
+	a.Before = auditSummaryJson(before)
+	a.After = auditSummaryJson(after)
+}
+
+func (a *AuditEntry) SetId(id int) {
+	a.Id = id
+}
+
+func (a *AuditEntry) GetId() int {
+	return a.Id
+}
+
+func (a *AuditEntry) SetTimestamp() {
+	a.Timestamp = time.Now()
+}
+
+// Validate проверяет фильтр и приводит количество записей к допустимому
+func (f *AuditFilter) Validate() *ApplicationError {
+	if f.Action != "" && !isAuditAction(f.Action) {
+		return NewLocalizedError(ErrorTypeValidation, CodeAuditActionUnknown, ErrorParams{"action": f.Action}, nil)
+	}
+
+	if f.From != nil && f.To != nil && !f.From.Before(*f.To) {
+		return NewLocalizedError(ErrorTypeValidation, CodeAuditRangeInvalid, nil, nil)
+	}
+
+	if f.Limit <= 0 {
+		f.Limit = DefaultAuditLimit
+	}
+	if f.Limit > MaxAuditLimit {
+		f.Limit = MaxAuditLimit
+	}
+	return nil
+}
+
+func NoteAuditSummary(note *Note) AuditSummary {
+	return AuditSummary{
+		"Title":    note.Title,
+		"FolderId": note.FolderId,
+		"Tags":     note.Tags,
+	}
+}
+
+func FolderAuditSummary(folder *Folder) AuditSummary {
+	return AuditSummary{
+		"Title": folder.Title,
+	}
+}
+
+func UserAuditSummary(user *User) AuditSummary {
+	return AuditSummary{
+		"Login":    user.Login,
+		"Name":     user.Name,
+		"Surname":  user.Surname,
+		"Timezone": user.Timezone,
+	}
+}
+
+// WebhookAuditSummary не содержит секрет подписи
+func WebhookAuditSummary(webhook *Webhook) AuditSummary {
+	return AuditSummary{
+		"Url":      webhook.Url,
+		"Events":   webhook.Events,
+		"IsActive": webhook.IsActive,
+	}
+}
+
+func ToAuditEntriesApi(entries []*AuditEntry) []*AuditEntryApi {
+	result := make([]*AuditEntryApi, 0, len(entries))
+	for _, entry := range entries {
+		entryApi := &AuditEntryApi{
+			Id:        entry.Id,
+			ActorId:   entry.ActorId,
+			Action:    entry.Action,
+			Entity:    entry.Entity,
+			EntityId:  entry.EntityId,
+			Ip:        entry.Ip,
+			UserAgent: entry.UserAgent,
+			TraceId:   entry.TraceId,
+			Timestamp: entry.Timestamp,
+		}
+
+		if entry.Before != nil {
+			entryApi.Before = json.RawMessage(*entry.Before)
+		}
+		if entry.After != nil {
+			entryApi.After = json.RawMessage(*entry.After)
+		}
+
+		result = append(result, entryApi)
+	}
+	return result
+}
+
+func isAuditAction(action AuditAction) bool {
+	for _, known := range auditActions {
+		if action == known {
+			return true
+		}
+	}
+	return false
+}
+
+func auditSummaryJson(summary AuditSummary) *string {
+	if summary == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return nil
+	}
+
+	value := string(data)
+	return &value
+}
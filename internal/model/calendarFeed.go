@@ -0,0 +1,47 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+)
+
+const calendarTokenBytes = 32
+
+// CalendarFeed - секретная ссылка на iCalendar-ленту пользователя. В БД хранится только хэш токена.
+type CalendarFeed struct {
+	Id        int
+	UserId    int
+	TokenHash string
+	Timestamp time.Time
+}
+
+// NewCalendarToken генерирует новый токен ленты и его хэш для хранения
+func NewCalendarToken() (string, string, *ApplicationError) {
+	buffer := make([]byte, calendarTokenBytes)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", "", NewLocalizedError(ErrorTypeInternal, CodeCalendarTokenFailed, nil, err)
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(buffer)
+	return token, HashCalendarToken(token), nil
+}
+
+func HashCalendarToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+func (c *CalendarFeed) SetId(id int) {
+	c.Id = id
+}
+
+func (c *CalendarFeed) GetId() int {
+	return c.Id
+}
+
+func (c *CalendarFeed) SetTimestamp() {
+	c.Timestamp = time.Now()
+}
@@ -0,0 +1,139 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+const MaxChecklistItemLength = 255
+
+type ChecklistItem struct {
+	Id        int
+	NoteId    int
+	Text      string
+	IsChecked bool
+	Position  int
+	Timestamp time.Time
+}
+
+func NewChecklistItem(noteId int, text string) (*ChecklistItem, *ApplicationError) {
+	text = strings.TrimSpace(text)
+
+	validationError := validateChecklistItemText(text)
+	if validationError != nil {
+		return nil, validationError
+	}
+
+	return &ChecklistItem{
+		Id:     0,
+		NoteId: noteId,
+		Text:   text,
+	}, nil
+}
+
+func (c *ChecklistItem) SetId(id int) {
+	c.Id = id
+}
+
+func (c *ChecklistItem) GetId() int {
+	return c.Id
+}
+
+func (c *ChecklistItem) SetTimestamp() {
+	c.Timestamp = time.Now()
+}
+
+// ChecklistFromContent разбивает текст заметки на пункты списка, по одному на строку.
+// Пункты из existing с тем же текстом переиспользуются, чтобы не терять id и отметку о выполнении.
+func ChecklistFromContent(noteId int, content string, existing []*ChecklistItem) ([]*ChecklistItem, *ApplicationError) {
+	used := make(map[int]bool)
+	items := make([]*ChecklistItem, 0)
+
+	for _, line := range strings.Split(content, "\n") {
+		text := strings.TrimSpace(line)
+		if text == "" {
+			continue
+		}
+
+		item := findUnusedItem(existing, text, used)
+		if item == nil {
+			newItem, err := NewChecklistItem(noteId, text)
+			if err != nil {
+				return nil, err
+			}
+			item = newItem
+		}
+
+		items = append(items, item)
+	}
+
+	NormalizePositions(items)
+	return items, nil
+}
+
+// RenderChecklist возвращает текстовое представление списка, которое хранится в Note.Content
+func RenderChecklist(items []*ChecklistItem) string {
+	lines := make([]string, 0, len(items))
+	for _, item := range items {
+		lines = append(lines, item.Text)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func ValidateChecklist(items []*ChecklistItem) *ApplicationError {
+	content := RenderChecklist(items)
+	if len(content) == 0 {
+		return nil
+	}
+
+	return validateContent(content)
+}
+
+func NormalizePositions(items []*ChecklistItem) {
+	for i, item := range items {
+		item.Position = i
+	}
+}
+
+func GetChecklistCompletion(items []*ChecklistItem) int {
+	if len(items) == 0 {
+		return 0
+	}
+
+	checked := 0
+	for _, item := range items {
+		if item.IsChecked {
+			checked++
+		}
+	}
+
+	return checked * 100 / len(items)
+}
+
+func findUnusedItem(items []*ChecklistItem, text string, used map[int]bool) *ChecklistItem {
+	for _, item := range items {
+		if item.Text == text && !used[item.Id] {
+			used[item.Id] = true
+			return item
+		}
+	}
+
+	return nil
+}
+
+func validateChecklistItemText(text string) *ApplicationError {
+	if len(text) == 0 {
+		return NewLocalizedError(ErrorTypeValidation, CodeChecklistItemEmpty, nil, nil)
+	}
+
+	if strings.Contains(text, "\n") {
+		return NewLocalizedError(ErrorTypeValidation, CodeChecklistItemMultiline, nil, nil)
+	}
+
+	if len(text) > MaxChecklistItemLength {
+		return NewLocalizedError(ErrorTypeValidation, CodeChecklistItemTooLong, ErrorParams{"max": MaxChecklistItemLength}, nil)
+	}
+
+	return nil
+}
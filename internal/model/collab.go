@@ -0,0 +1,65 @@
+package model
+
+type CollabMessageType string
+
+const (
+	// CollabMessageInit - первое сообщение участнику: его идентификатор, состояние документа и участники.
+	// Повторно приходит после сжатия документа с новым Generation, участник заменяет им своё состояние
+	// и заново отправляет свои правки, которых в нём нет.
+	CollabMessageInit CollabMessageType = "init"
+	// CollabMessageOps - операции над документом. От сервера приходят операции других участников.
+	CollabMessageOps CollabMessageType = "ops"
+	// CollabMessageCursor - участник сообщает о положении своего курсора
+	CollabMessageCursor CollabMessageType = "cursor"
+	// CollabMessagePresence - изменился состав участников или положение их курсоров
+	CollabMessagePresence CollabMessageType = "presence"
+	// CollabMessageError - сервер отклонил операции участника. Состояние участника разошлось
+	// с сервером, ему нужно подключиться заново.
+	CollabMessageError CollabMessageType = "error"
+	// CollabMessageSaveFailed - текст документа не удалось сохранить в заметку, Error объясняет почему.
+	// Правки остаются в документе, следующий снимок сохраняется после новых правок.
+	CollabMessageSaveFailed CollabMessageType = "save_failed"
+)
+
+// CollabCursor - выделение участника. Anchor и Head - символы, после которых стоят начало и конец
+// выделения, nil - начало текста. Для курсора без выделения они совпадают.
+type CollabCursor struct {
+	Anchor *CrdtId `json:",omitempty"`
+	Head   *CrdtId `json:",omitempty"`
+}
+
+// CollabParticipant - подключённый к документу клиент. У одного пользователя может быть несколько
+// подключений, Site отличает их друг от друга и служит участником в идентификаторах символов.
+type CollabParticipant struct {
+	Site   string
+	UserId int
+	Login  string
+	Cursor *CollabCursor `json:",omitempty"`
+}
+
+// CollabClientMessage - сообщение участника серверу: Ops для CollabMessageOps, Cursor для CollabMessageCursor.
+// Generation - поколение документа из последнего init участника. Сообщения прошлого поколения
+// ссылаются на символы, убранные сжатием, и сервер их пропускает.
+type CollabClientMessage struct {
+	Type       CollabMessageType
+	Generation int              `json:",omitempty"`
+	Ops        []*CrdtOperation `json:",omitempty"`
+	Cursor     *CollabCursor    `json:",omitempty"`
+}
+
+// CollabServerMessage - сообщение сервера участнику. Site в CollabMessageOps - автор операций,
+// Generation в CollabMessageInit - сколько раз документ сжимался.
+type CollabServerMessage struct {
+	Type         CollabMessageType
+	NoteId       int
+	Site         string               `json:",omitempty"`
+	Generation   int                  `json:",omitempty"`
+	Clock        int                  `json:",omitempty"`
+	Elements     []*CrdtElement       `json:",omitempty"`
+	Ops          []*CrdtOperation     `json:",omitempty"`
+	Participants []*CollabParticipant `json:",omitempty"`
+	Error        *Problem             `json:",omitempty"`
+	// SaveError - ошибка сохранения для CollabMessageSaveFailed. Обработчик переводит её в Error
+	// на языке участника.
+	SaveError *ApplicationError `json:"-"`
+}
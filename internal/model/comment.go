@@ -0,0 +1,148 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+const MaxCommentLength = 2000
+
+// Comment - комментарий к заметке. Комментарии без ParentId начинают ветку обсуждения,
+// ответы всегда относятся к первому комментарию ветки. Только у ветки бывает привязка к тексту
+// и отметка о решении.
+type Comment struct {
+	Id          int
+	NoteId      int
+	UserId      int
+	ParentId    *int
+	Content     string
+	AnchorStart *int
+	AnchorEnd   *int
+	AnchorText  *string
+	IsResolved  bool
+	ResolvedBy  *int
+	ResolvedAt  *time.Time
+	EditedAt    *time.Time
+	Timestamp   time.Time
+}
+
+// CommentAnchor - фрагмент текста заметки, к которому относится ветка. Start и End - позиции
+// в символах, Text - сам фрагмент на момент создания, чтобы его можно было показать и после правки заметки.
+type CommentAnchor struct {
+	Start int
+	End   int
+	Text  string `json:",omitempty"`
+}
+
+// Anchor возвращает привязку ветки к тексту заметки или nil
+func (c *Comment) Anchor() *CommentAnchor {
+	if c.AnchorStart == nil || c.AnchorEnd == nil {
+		return nil
+	}
+
+	anchor := &CommentAnchor{Start: *c.AnchorStart, End: *c.AnchorEnd}
+	if c.AnchorText != nil {
+		anchor.Text = *c.AnchorText
+	}
+	return anchor
+}
+
+// NewComment создаёт комментарий к note. Ответ на ответ попадает в ветку его первого комментария.
+func NewComment(note *Note, userId int, parent *Comment, content string, anchor *CommentAnchor) (*Comment, *ApplicationError) {
+	content = strings.TrimSpace(content)
+	if err := validateCommentContent(content); err != nil {
+		return nil, err
+	}
+
+	comment := &Comment{
+		NoteId:  note.Id,
+		UserId:  userId,
+		Content: content,
+	}
+
+	if parent != nil {
+		if anchor != nil {
+			return nil, NewLocalizedError(ErrorTypeValidation, CodeCommentNotThread, nil, nil)
+		}
+
+		threadId := parent.Id
+		if parent.ParentId != nil {
+			threadId = *parent.ParentId
+		}
+		comment.ParentId = &threadId
+		return comment, nil
+	}
+
+	if anchor != nil {
+		text := []rune(note.Content)
+		if anchor.Start < 0 || anchor.Start >= anchor.End || anchor.End > len(text) {
+			params := ErrorParams{"length": len(text)}
+			return nil, NewLocalizedError(ErrorTypeValidation, CodeCommentAnchorInvalid, params, nil)
+		}
+
+		start, end, anchorText := anchor.Start, anchor.End, string(text[anchor.Start:anchor.End])
+		comment.AnchorStart = &start
+		comment.AnchorEnd = &end
+		comment.AnchorText = &anchorText
+	}
+
+	return comment, nil
+}
+
+// Edit меняет текст комментария
+func (c *Comment) Edit(content string) *ApplicationError {
+	content = strings.TrimSpace(content)
+	if err := validateCommentContent(content); err != nil {
+		return err
+	}
+
+	editedAt := time.Now()
+	c.Content = content
+	c.EditedAt = &editedAt
+	return nil
+}
+
+// SetResolved отмечает ветку решённой или снова открывает её
+func (c *Comment) SetResolved(userId int, isResolved bool) *ApplicationError {
+	if c.ParentId != nil {
+		return NewLocalizedError(ErrorTypeValidation, CodeCommentNotThread, nil, nil)
+	}
+
+	c.IsResolved = isResolved
+	if !isResolved {
+		c.ResolvedBy = nil
+		c.ResolvedAt = nil
+		return nil
+	}
+
+	resolvedAt := time.Now()
+	c.ResolvedBy = &userId
+	c.ResolvedAt = &resolvedAt
+	return nil
+}
+
+func (c *Comment) SetId(id int) {
+	c.Id = id
+}
+
+func (c *Comment) GetId() int {
+	return c.Id
+}
+
+// SetTimestamp запоминает время создания, время правки хранится в EditedAt
+func (c *Comment) SetTimestamp() {
+	if c.Timestamp.IsZero() {
+		c.Timestamp = time.Now()
+	}
+}
+
+func validateCommentContent(content string) *ApplicationError {
+	if content == "" {
+		return NewLocalizedError(ErrorTypeValidation, CodeCommentEmpty, nil, nil)
+	}
+
+	if len([]rune(content)) > MaxCommentLength {
+		return NewLocalizedError(ErrorTypeValidation, CodeCommentTooLong, ErrorParams{"max": MaxCommentLength}, nil)
+	}
+	return nil
+}
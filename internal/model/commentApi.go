@@ -0,0 +1,66 @@
+package model
+
+import "time"
+
+type CommentApi struct {
+	Id         int
+	NoteId     int
+	UserId     int
+	ParentId   *int `json:",omitempty"`
+	Content    string
+	Anchor     *CommentAnchor `json:",omitempty"`
+	IsResolved bool           `json:",omitempty"`
+	ResolvedBy *int           `json:",omitempty"`
+	ResolvedAt *time.Time     `json:",omitempty"`
+	EditedAt   *time.Time     `json:",omitempty"`
+	Timestamp  time.Time
+	Replies    []*CommentApi `json:",omitempty"`
+}
+
+// CommentThreadsApi - страница веток обсуждения. NextAfterId передаётся в следующий запрос,
+// пока он не пуст.
+type CommentThreadsApi struct {
+	Threads     []*CommentApi
+	NextAfterId *int `json:",omitempty"`
+}
+
+func ToCommentApi(comment *Comment) *CommentApi {
+	return &CommentApi{
+		Id:         comment.Id,
+		NoteId:     comment.NoteId,
+		UserId:     comment.UserId,
+		ParentId:   comment.ParentId,
+		Content:    comment.Content,
+		Anchor:     comment.Anchor(),
+		IsResolved: comment.IsResolved,
+		ResolvedBy: comment.ResolvedBy,
+		ResolvedAt: comment.ResolvedAt,
+		EditedAt:   comment.EditedAt,
+		Timestamp:  comment.Timestamp,
+	}
+}
+
+// ToCommentThreadsApi собирает ветки с ответами. Ответы ожидаются упорядоченными по id.
+func ToCommentThreadsApi(threads []*Comment, replies []*Comment) []*CommentApi {
+	repliesByThread := make(map[int][]*CommentApi)
+	for _, reply := range replies {
+		if reply.ParentId != nil {
+			repliesByThread[*reply.ParentId] = append(repliesByThread[*reply.ParentId], ToCommentApi(reply))
+		}
+	}
+
+	result := make([]*CommentApi, 0, len(threads))
+	for _, thread := range threads {
+		threadApi := ToCommentApi(thread)
+		threadApi.Replies = repliesByThread[thread.Id]
+		result = append(result, threadApi)
+	}
+	return result
+}
+
+// SetCommentCounts заполняет CommentCount заметок
+func SetCommentCounts(notes []*NoteApi, counts map[int]int) {
+	for _, note := range notes {
+		note.CommentCount = counts[note.Id]
+	}
+}
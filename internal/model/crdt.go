@@ -0,0 +1,205 @@
+package model
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+type CrdtOperationType string
+
+const (
+	CrdtOperationInsert CrdtOperationType = "insert"
+	CrdtOperationDelete CrdtOperationType = "delete"
+)
+
+// CrdtSeedSite - участник, от имени которого в документ загружается сохранённый текст заметки
+const CrdtSeedSite = "seed"
+
+// CrdtId - идентификатор символа документа: счётчик Лэмпорта и участник, вставивший символ.
+// Идентификаторы упорядочены сначала по счётчику, затем по участнику.
+type CrdtId struct {
+	Counter int
+	Site    string
+}
+
+func (id CrdtId) Less(other CrdtId) bool {
+	if id.Counter != other.Counter {
+		return id.Counter < other.Counter
+	}
+	return id.Site < other.Site
+}
+
+func (id CrdtId) String() string {
+	return fmt.Sprintf("%d@%s", id.Counter, id.Site)
+}
+
+// CrdtElement - символ документа. Удалённые символы остаются в документе, пока его не сожмут,
+// чтобы на них могли ссылаться операции, отправленные до удаления.
+type CrdtElement struct {
+	Id      CrdtId
+	Value   string
+	Deleted bool `json:",omitempty"`
+}
+
+// CrdtOperation - изменение документа. Вставка добавляет символ Value с идентификатором Id
+// после символа After (nil - в начало текста), удаление скрывает символ Id.
+type CrdtOperation struct {
+	Type  CrdtOperationType
+	Id    CrdtId
+	After *CrdtId `json:",omitempty"`
+	Value string  `json:",omitempty"`
+}
+
+// CrdtDocument - текст как реплицируемый массив RGA. Операции, применённые в любом порядке,
+// который сохраняет причинность (символ вставляется после того, как появился его сосед слева),
+// приводят к одному и тому же тексту.
+type CrdtDocument struct {
+	elements []*CrdtElement
+	byId     map[CrdtId]*CrdtElement
+	clock    int
+	length   int
+	// deleted - сколько удалённых символов хранится в документе
+	deleted int
+}
+
+// NewCrdtDocument создаёт документ с текстом text, символы которого вставлены участником CrdtSeedSite
+func NewCrdtDocument(text string) *CrdtDocument {
+	document := &CrdtDocument{byId: make(map[CrdtId]*CrdtElement)}
+
+	for _, value := range text {
+		document.clock++
+		element := &CrdtElement{Id: CrdtId{Counter: document.clock, Site: CrdtSeedSite}, Value: string(value)}
+		document.elements = append(document.elements, element)
+		document.byId[element.Id] = element
+	}
+	document.length = len(text)
+
+	return document
+}
+
+// Apply применяет операцию. Повторное применение уже известной операции ничего не меняет.
+func (d *CrdtDocument) Apply(operation *CrdtOperation) *ApplicationError {
+	switch operation.Type {
+	case CrdtOperationInsert:
+		return d.insert(operation)
+	case CrdtOperationDelete:
+		element, ok := d.byId[operation.Id]
+		if !ok {
+			return NewLocalizedError(ErrorTypeValidation, CodeCrdtElementUnknown, ErrorParams{"id": operation.Id.String()}, nil)
+		}
+
+		if !element.Deleted {
+			element.Deleted = true
+			d.length -= len(element.Value)
+			d.deleted++
+		}
+		return nil
+	default:
+		return NewLocalizedError(ErrorTypeValidation, CodeCrdtOperationUnknown, ErrorParams{"type": operation.Type}, nil)
+	}
+}
+
+func (d *CrdtDocument) insert(operation *CrdtOperation) *ApplicationError {
+	if utf8.RuneCountInString(operation.Value) != 1 || operation.Id.Counter <= 0 {
+		return NewLocalizedError(ErrorTypeValidation, CodeCrdtInsertInvalid, nil, nil)
+	}
+
+	if _, ok := d.byId[operation.Id]; ok {
+		return nil
+	}
+
+	position := 0
+	if operation.After != nil {
+		after, ok := d.byId[*operation.After]
+		if !ok {
+			return NewLocalizedError(ErrorTypeValidation, CodeCrdtElementUnknown, ErrorParams{"id": operation.After.String()}, nil)
+		}
+		position = d.indexOf(after) + 1
+	}
+
+	// Правило RGA: символы, вставленные после того же соседа позже (с большим идентификатором),
+	// стоят левее. Их продолжения имеют ещё большие идентификаторы и пропускаются вместе с ними.
+	for position < len(d.elements) && operation.Id.Less(d.elements[position].Id) {
+		position++
+	}
+
+	element := &CrdtElement{Id: operation.Id, Value: operation.Value}
+	d.elements = append(d.elements, nil)
+	copy(d.elements[position+1:], d.elements[position:])
+	d.elements[position] = element
+	d.byId[element.Id] = element
+
+	d.clock = max(d.clock, operation.Id.Counter)
+	d.length += len(element.Value)
+	return nil
+}
+
+func (d *CrdtDocument) indexOf(element *CrdtElement) int {
+	for i, candidate := range d.elements {
+		if candidate == element {
+			return i
+		}
+	}
+	return -1
+}
+
+// Compact убирает из документа удалённые символы и возвращает, сколько их было. Операции, которые
+// ссылаются на убранные символы, после этого отклоняются. Вставка после оставшегося символа
+// встаёт на то же место, что и до сжатия, если её автор видел все операции документа: её
+// идентификатор больше всех идентификаторов документа, и правило RGA ничего не пропускает.
+func (d *CrdtDocument) Compact() int {
+	removed := d.deleted
+	if removed == 0 {
+		return 0
+	}
+
+	elements := make([]*CrdtElement, 0, len(d.elements)-removed)
+	for _, element := range d.elements {
+		if element.Deleted {
+			delete(d.byId, element.Id)
+			continue
+		}
+		elements = append(elements, element)
+	}
+
+	d.elements = elements
+	d.deleted = 0
+	return removed
+}
+
+// Has сообщает, есть ли в документе символ id, в том числе удалённый
+func (d *CrdtDocument) Has(id CrdtId) bool {
+	_, ok := d.byId[id]
+	return ok
+}
+
+// Text возвращает видимый текст документа
+func (d *CrdtDocument) Text() string {
+	text := make([]byte, 0, d.length)
+	for _, element := range d.elements {
+		if !element.Deleted {
+			text = append(text, element.Value...)
+		}
+	}
+	return string(text)
+}
+
+// Length возвращает длину видимого текста в байтах, как её считает проверка текста заметки
+func (d *CrdtDocument) Length() int {
+	return d.length
+}
+
+// Clock возвращает наибольший счётчик в документе. Новый участник начинает свои вставки после него.
+func (d *CrdtDocument) Clock() int {
+	return d.clock
+}
+
+// Elements возвращает копию всех символов документа, включая удалённые
+func (d *CrdtDocument) Elements() []*CrdtElement {
+	elements := make([]*CrdtElement, 0, len(d.elements))
+	for _, element := range d.elements {
+		copied := *element
+		elements = append(elements, &copied)
+	}
+	return elements
+}
@@ -0,0 +1,83 @@
+package model
+
+import (
+	"time"
+)
+
+const DailyNoteDateLayout = "2006-01-02"
+
+// DailyNote связывает заметку пользователя с календарным днём. Уникальность пары
+// (пользователь, день) в БД гарантирует одну заметку на день при параллельных запросах.
+// День хранится как полночь UTC, чтобы дата не сдвигалась при записи в колонку DATE.
+type DailyNote struct {
+	Id        int
+	UserId    int
+	Date      time.Time `gorm:"type:date"`
+	NoteId    int
+	Timestamp time.Time
+}
+
+// DailyNoteSettings - шаблон и папка для автоматически создаваемых ежедневных заметок
+type DailyNoteSettings struct {
+	Id         int
+	UserId     int
+	TemplateId *int
+	FolderId   *int
+	Timestamp  time.Time
+}
+
+type DailyNoteSettingsApi struct {
+	TemplateId *int
+	FolderId   *int
+}
+
+func (d *DailyNote) SetId(id int) {
+	d.Id = id
+}
+
+func (d *DailyNote) GetId() int {
+	return d.Id
+}
+
+func (d *DailyNote) SetTimestamp() {
+	d.Timestamp = time.Now()
+}
+
+func (d *DailyNoteSettings) SetId(id int) {
+	d.Id = id
+}
+
+func (d *DailyNoteSettings) GetId() int {
+	return d.Id
+}
+
+func (d *DailyNoteSettings) SetTimestamp() {
+	d.Timestamp = time.Now()
+}
+
+// ParseDailyNoteDate разбирает дату вида 2006-01-02
+func ParseDailyNoteDate(value string) (time.Time, *ApplicationError) {
+	date, err := time.Parse(DailyNoteDateLayout, value)
+	if err != nil {
+		return time.Time{}, NewLocalizedError(ErrorTypeValidation, CodeDateInvalid, ErrorParams{"date": value}, nil)
+	}
+
+	return date, nil
+}
+
+// DailyNoteDay возвращает календарный день момента now в часовом поясе location
+func DailyNoteDay(now time.Time, location *time.Location) time.Time {
+	local := now.In(location)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func ToDailyNoteSettingsApi(settings *DailyNoteSettings) *DailyNoteSettingsApi {
+	if settings == nil {
+		return &DailyNoteSettingsApi{}
+	}
+
+	return &DailyNoteSettingsApi{
+		TemplateId: settings.TemplateId,
+		FolderId:   settings.FolderId,
+	}
+}
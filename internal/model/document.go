@@ -0,0 +1,105 @@
+package model
+
+import (
+	"strings"
+	"time"
+)
+
+type DocumentFormat string
+
+const (
+	DocumentFormatPdf  DocumentFormat = "pdf"
+	DocumentFormatHtml DocumentFormat = "html"
+)
+
+// ExportDocument - заметка или папка, подготовленная к выводу в один документ.
+// Время заметок и GeneratedAt уже переведены в часовой пояс пользователя.
+type ExportDocument struct {
+	Title       string
+	IsFolder    bool
+	Notes       []*DocumentNote
+	GeneratedAt time.Time
+}
+
+// DocumentNote - заметка документа. Content - текст в Markdown, пункты чек-листа переведены в "- [x] ".
+type DocumentNote struct {
+	Id        int
+	Title     string
+	Tags      []string
+	Timestamp time.Time
+	Content   string
+}
+
+// DocumentFile - готовый документ для скачивания
+type DocumentFile struct {
+	FileName    string
+	ContentType string
+	Data        []byte
+}
+
+// ParseDocumentFormat по умолчанию выводит PDF
+func ParseDocumentFormat(value string) (DocumentFormat, *ApplicationError) {
+	switch DocumentFormat(value) {
+	case "":
+		return DocumentFormatPdf, nil
+	case DocumentFormatPdf, DocumentFormatHtml:
+		return DocumentFormat(value), nil
+	}
+
+	return "", NewLocalizedError(ErrorTypeValidation, CodeExportFormatUnknown, ErrorParams{"format": value}, nil)
+}
+
+func (f DocumentFormat) ContentType() string {
+	if f == DocumentFormatHtml {
+		return "text/html; charset=utf-8"
+	}
+
+	return "application/pdf"
+}
+
+// FileName - имя файла по названию документа без символов, запрещённых в именах файлов
+func (f DocumentFormat) FileName(title string) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`/\:*?"<>|`, r) || r < ' ' {
+			return '_'
+		}
+		return r
+	}, strings.TrimSpace(title))
+
+	if name == "" {
+		name = "note"
+	}
+
+	return name + "." + string(f)
+}
+
+// ToDocumentNote переводит заметку в заметку документа. Для чек-листа текст собирается из пунктов.
+func ToDocumentNote(note *Note, items []*ChecklistItem, location *time.Location) *DocumentNote {
+	documentNote := &DocumentNote{
+		Id:        note.Id,
+		Title:     note.Title,
+		Tags:      note.Tags,
+		Timestamp: note.Timestamp.In(location),
+		Content:   note.Content,
+	}
+
+	if documentNote.Tags == nil {
+		documentNote.Tags = make([]string, 0)
+	}
+
+	if note.IsChecklist() {
+		var content strings.Builder
+		for _, item := range items {
+			if item.IsChecked {
+				content.WriteString("- [x] ")
+			} else {
+				content.WriteString("- [ ] ")
+			}
+			content.WriteString(item.Text)
+			content.WriteString("\n")
+		}
+		documentNote.Content = content.String()
+	}
+
+	return documentNote
+}
@@ -0,0 +1,173 @@
+package model
+
+import (
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"path"
+	"strings"
+	"time"
+)
+
+const EnexExtension = ".enex"
+
+const enexTimeLayout = "20060102T150405Z"
+
+// EnexNote - заметка из файла экспорта Evernote
+type EnexNote struct {
+	Title     string          `xml:"title"`
+	Content   string          `xml:"content"`
+	Created   string          `xml:"created"`
+	Updated   string          `xml:"updated"`
+	Tags      []string        `xml:"tag"`
+	Resources []*EnexResource `xml:"resource"`
+}
+
+// EnexResource - файл, встроенный в заметку Evernote. Data закодирован в base64.
+type EnexResource struct {
+	Data     string `xml:"data"`
+	Mime     string `xml:"mime"`
+	FileName string `xml:"resource-attributes>file-name"`
+}
+
+// EnexReader читает заметки из ENEX по одной, не разбирая весь файл в память
+type EnexReader struct {
+	decoder *xml.Decoder
+}
+
+func NewEnexReader(r io.Reader) *EnexReader {
+	return &EnexReader{decoder: xml.NewDecoder(r)}
+}
+
+// Next возвращает следующую заметку или io.EOF, когда заметки закончились
+func (e *EnexReader) Next() (*EnexNote, error) {
+	for {
+		token, err := e.decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "note" {
+			continue
+		}
+
+		var note EnexNote
+		if err = e.decoder.DecodeElement(&note, &start); err != nil {
+			return nil, err
+		}
+		return &note, nil
+	}
+}
+
+// CountEnexNotes считает заметки в ENEX, пропуская их содержимое
+func CountEnexNotes(r io.Reader) (int, error) {
+	decoder := xml.NewDecoder(r)
+	count := 0
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return count, nil
+		}
+		if err != nil {
+			return count, err
+		}
+
+		if start, ok := token.(xml.StartElement); ok && start.Name.Local == "note" {
+			count++
+			if err = decoder.Skip(); err != nil {
+				return count, err
+			}
+		}
+	}
+}
+
+// ToImportedNote переводит заметку Evernote в заметку для импорта в папку folder.
+// Текст переводится из ENML в Markdown, ресурсы становятся вложениями.
+// Дата заметки - дата изменения в Evernote или, если её нет, дата создания.
+func (n *EnexNote) ToImportedNote(folder string, index int) (*ImportedNote, *ApplicationError) {
+	title := strings.TrimSpace(n.Title)
+	notePath := n.Path(folder, index)
+	params := ErrorParams{"path": notePath}
+
+	attachments := make([]*ImportedAttachment, 0, len(n.Resources))
+	media := make(map[string]string, len(n.Resources))
+
+	for i, resource := range n.Resources {
+		data, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(resource.Data), ""))
+		if err != nil {
+			return nil, NewLocalizedError(ErrorTypeValidation, CodeImportFileReadFailed, params, err)
+		}
+
+		hash := md5.Sum(data)
+		hashHex := hex.EncodeToString(hash[:])
+
+		fileName := strings.TrimSpace(resource.FileName)
+		if fileName == "" {
+			fileName = fmt.Sprintf("attachment-%d%s", i+1, enexResourceExtension(resource.Mime))
+		}
+
+		media[hashHex] = fileName
+		attachments = append(attachments, &ImportedAttachment{FileName: fileName, Data: data})
+	}
+
+	content, err := EnmlToMarkdown(n.Content, media)
+	if err != nil {
+		return nil, NewLocalizedError(ErrorTypeValidation, CodeImportFileReadFailed, params, err)
+	}
+
+	tags := make([]string, 0, len(n.Tags))
+	for _, tag := range n.Tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	note := &ImportedNote{
+		Path:        notePath,
+		Folder:      folder,
+		Title:       title,
+		Content:     content,
+		Tags:        tags,
+		Attachments: attachments,
+	}
+
+	for _, value := range []string{n.Updated, n.Created} {
+		if timestamp, errParse := time.Parse(enexTimeLayout, strings.TrimSpace(value)); errParse == nil {
+			note.Timestamp = &timestamp
+			break
+		}
+	}
+
+	return note, nil
+}
+
+// Path - путь заметки для отчёта об импорте. Заметки без названия различаются по номеру в файле.
+func (n *EnexNote) Path(folder string, index int) string {
+	name := strings.TrimSpace(n.Title)
+	if name == "" {
+		name = fmt.Sprintf("#%d", index)
+	}
+
+	return path.Join(folder, name)
+}
+
+func enexResourceExtension(contentType string) string {
+	// Для JPEG список расширений начинается с редкого .jfif
+	if contentType == "image/jpeg" {
+		return ".jpg"
+	}
+
+	extensions, err := mime.ExtensionsByType(contentType)
+	if err != nil || len(extensions) == 0 {
+		return ""
+	}
+
+	return extensions[0]
+}
@@ -0,0 +1,314 @@
+package model
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// enmlList - открытый список ul или ol. number - номер следующего пункта ol.
+type enmlList struct {
+	ordered bool
+	number  int
+}
+
+// enmlConverter переводит ENML (XHTML-разметку заметок Evernote) в Markdown.
+// Неизвестные теги отбрасываются, их текст сохраняется.
+type enmlConverter struct {
+	out        strings.Builder
+	media      map[string]string
+	lists      []enmlList
+	links      []string
+	quoteDepth int
+	preDepth   int
+	skipDepth  int
+	// tableRows и rowCells нужны для строки-разделителя после первой строки таблицы
+	tableRows int
+	rowCells  int
+	// lineStart - на текущей строке ещё нет текста, blank - предыдущая строка пустая
+	lineStart bool
+	blank     bool
+	// pendingSpace - пробел из текста, который выводится перед следующим словом, но не в начале строки
+	pendingSpace bool
+}
+
+// EnmlToMarkdown переводит содержимое заметки Evernote в Markdown. media - имена файлов вложений
+// по MD5-хешу, на который ссылаются теги en-media.
+// Строки Evernote (div) становятся строками, абзацы и заголовки отделяются пустой строкой.
+func EnmlToMarkdown(enml string, media map[string]string) (string, error) {
+	decoder := xml.NewDecoder(strings.NewReader(enml))
+	decoder.Strict = false
+	decoder.AutoClose = xml.HTMLAutoClose
+	decoder.Entity = xml.HTMLEntity
+
+	converter := &enmlConverter{media: media, lineStart: true, blank: true}
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			converter.start(t)
+		case xml.EndElement:
+			converter.end(strings.ToLower(t.Name.Local))
+		case xml.CharData:
+			converter.text(string(t))
+		}
+	}
+
+	return strings.TrimSpace(converter.out.String()), nil
+}
+
+func (c *enmlConverter) start(element xml.StartElement) {
+	name := strings.ToLower(element.Name.Local)
+
+	if c.skipDepth > 0 || name == "en-crypt" || name == "style" || name == "script" || name == "head" {
+		c.skipDepth++
+		return
+	}
+
+	switch name {
+	case "div":
+		c.endLine()
+	case "tr":
+		c.endLine()
+		c.rowCells = 0
+	case "table":
+		c.block()
+		c.tableRows = 0
+	case "p":
+		c.block()
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		c.block()
+		c.write(strings.Repeat("#", int(name[1]-'0')) + " ")
+	case "br":
+		c.newline()
+	case "hr":
+		c.block()
+		c.write("---")
+		c.block()
+	case "b", "strong":
+		c.inline("**")
+	case "i", "em":
+		c.inline("*")
+	case "s", "strike", "del":
+		c.inline("~~")
+	case "code":
+		if c.preDepth == 0 {
+			c.inline("`")
+		}
+	case "pre":
+		c.block()
+		c.write("```")
+		c.newline()
+		c.preDepth++
+	case "blockquote":
+		c.block()
+		c.quoteDepth++
+		c.write("> ")
+		c.lineStart = true
+	case "ul", "ol":
+		if len(c.lists) == 0 {
+			c.block()
+		}
+		c.lists = append(c.lists, enmlList{ordered: name == "ol", number: 1})
+	case "li":
+		c.listItem()
+	case "a":
+		c.links = append(c.links, enmlAttr(element, "href"))
+		c.inline("[")
+	case "en-todo":
+		if enmlAttr(element, "checked") == "true" {
+			c.inline("[x] ")
+		} else {
+			c.inline("[ ] ")
+		}
+	case "en-media":
+		c.mediaLink(enmlAttr(element, "hash"), enmlAttr(element, "type"))
+	case "td", "th":
+		c.write("| ")
+		c.rowCells++
+	}
+}
+
+func (c *enmlConverter) end(name string) {
+	if c.skipDepth > 0 {
+		c.skipDepth--
+		return
+	}
+
+	switch name {
+	case "div":
+		c.endLine()
+	case "p", "table", "h1", "h2", "h3", "h4", "h5", "h6":
+		c.block()
+	case "b", "strong":
+		c.write("**")
+	case "i", "em":
+		c.write("*")
+	case "s", "strike", "del":
+		c.write("~~")
+	case "code":
+		if c.preDepth == 0 {
+			c.write("`")
+		}
+	case "pre":
+		c.preDepth = max(0, c.preDepth-1)
+		c.endLine()
+		c.write("```")
+		c.block()
+	case "blockquote":
+		c.quoteDepth = max(0, c.quoteDepth-1)
+		c.block()
+	case "ul", "ol":
+		if len(c.lists) > 0 {
+			c.lists = c.lists[:len(c.lists)-1]
+		}
+		if len(c.lists) == 0 {
+			c.block()
+		}
+	case "a":
+		href := ""
+		if len(c.links) > 0 {
+			href = c.links[len(c.links)-1]
+			c.links = c.links[:len(c.links)-1]
+		}
+		c.write("](" + href + ")")
+	case "td", "th":
+		c.write(" ")
+	case "tr":
+		c.write("|")
+		c.tableRows++
+		if c.tableRows == 1 {
+			c.newline()
+			c.write("|" + strings.Repeat(" --- |", c.rowCells))
+		}
+		c.endLine()
+	}
+}
+
+func (c *enmlConverter) text(text string) {
+	if c.skipDepth > 0 {
+		return
+	}
+
+	if c.preDepth > 0 {
+		lines := strings.Split(text, "\n")
+		for i, line := range lines {
+			if i > 0 {
+				c.newline()
+			}
+			if line != "" {
+				c.write(line)
+			}
+		}
+		return
+	}
+
+	// Переводы строк и повторные пробелы в разметке не значимы, как и в HTML
+	runes := []rune(strings.ReplaceAll(text, "\u00a0", " "))
+	words := strings.Fields(string(runes))
+	if len(words) == 0 {
+		c.pendingSpace = c.pendingSpace || len(runes) > 0
+		return
+	}
+
+	if unicode.IsSpace(runes[0]) {
+		c.pendingSpace = true
+	}
+
+	c.inline(strings.Join(words, " "))
+	c.pendingSpace = unicode.IsSpace(runes[len(runes)-1])
+}
+
+func (c *enmlConverter) listItem() {
+	c.endLine()
+
+	marker := "- "
+	if len(c.lists) > 0 {
+		list := &c.lists[len(c.lists)-1]
+		if list.ordered {
+			marker = strconv.Itoa(list.number) + ". "
+			list.number++
+		}
+		marker = strings.Repeat("  ", len(c.lists)-1) + marker
+	}
+
+	c.write(marker)
+	// Текст пункта часто обёрнут в div, он не должен переносить строку после маркера
+	c.lineStart = true
+}
+
+func (c *enmlConverter) mediaLink(hash string, contentType string) {
+	fileName, exists := c.media[hash]
+	if !exists {
+		return
+	}
+
+	if strings.HasPrefix(contentType, "image/") {
+		c.inline("![" + fileName + "](" + fileName + ")")
+	} else {
+		c.inline("[" + fileName + "](" + fileName + ")")
+	}
+}
+
+// inline выводит текст или открывающую разметку, вставляя накопленный пробел
+func (c *enmlConverter) inline(text string) {
+	if c.pendingSpace && !c.lineStart && !strings.HasSuffix(c.out.String(), " ") {
+		c.out.WriteString(" ")
+	}
+
+	c.write(text)
+}
+
+func (c *enmlConverter) write(text string) {
+	c.out.WriteString(text)
+	c.pendingSpace = false
+	c.lineStart = false
+	c.blank = false
+}
+
+func (c *enmlConverter) newline() {
+	c.blank = c.lineStart
+	c.out.WriteString("\n")
+	if c.quoteDepth > 0 {
+		c.out.WriteString(strings.Repeat("> ", c.quoteDepth))
+	}
+
+	c.lineStart = true
+	c.pendingSpace = false
+}
+
+// endLine переносит строку, если на текущей уже есть текст
+func (c *enmlConverter) endLine() {
+	if !c.lineStart {
+		c.newline()
+	}
+}
+
+// block отделяет следующий абзац пустой строкой. Внутри списков абзацы не разделяются.
+func (c *enmlConverter) block() {
+	c.endLine()
+	if !c.blank && len(c.lists) == 0 {
+		c.newline()
+	}
+}
+
+func enmlAttr(element xml.StartElement, name string) string {
+	for _, attr := range element.Attr {
+		if strings.EqualFold(attr.Name.Local, name) {
+			return attr.Value
+		}
+	}
+
+	return ""
+}
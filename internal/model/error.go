@@ -10,12 +10,75 @@ const (
 	ErrorTypeNotFound   ErrorType = "NOT_FOUND_ERROR"
 	ErrorTypeInternal   ErrorType = "INTERNAL_ERROR"
 	ErrorTypeAuth       ErrorType = "AUTH_ERROR"
+	ErrorTypeConflict   ErrorType = "CONFLICT_ERROR"
+	ErrorTypeForbidden  ErrorType = "FORBIDDEN_ERROR"
+	ErrorTypeLocked     ErrorType = "LOCKED_ERROR"
 )
 
+// ApplicationError - ошибка приложения. Code и Params позволяют клиенту получить сообщение
+// на своём языке, Message содержит текст на языке по умолчанию.
 type ApplicationError struct {
 	Type    ErrorType
+	Code    ErrorCode
+	Params  ErrorParams
 	Message string
 	Err     error
+	// Fields - ошибки отдельных полей, если проверка нашла их несколько
+	Fields []*FieldError
+}
+
+// FieldError - ошибка проверки одного поля запроса
+type FieldError struct {
+	Field   string
+	Code    ErrorCode
+	Params  ErrorParams
+	Message string
+}
+
+// LocalizedMessage возвращает сообщение об ошибке поля на выбранном языке
+func (f *FieldError) LocalizedMessage(locale Locale) string {
+	if locale == DefaultLocale {
+		return f.Message
+	}
+
+	if message, exists := LocalizeMessage(locale, f.Code, f.Params); exists {
+		return message
+	}
+
+	return f.Message
+}
+
+// validationErrors собирает ошибки всех полей, чтобы клиент получил их одним ответом
+type validationErrors []*FieldError
+
+func (v *validationErrors) add(field string, err *ApplicationError) {
+	if err == nil {
+		return
+	}
+
+	*v = append(*v, &FieldError{
+		Field:   field,
+		Code:    err.Code,
+		Params:  err.Params,
+		Message: err.Message,
+	})
+}
+
+// toError возвращает nil, если ошибок нет. Код и сообщение берутся из первой ошибки,
+// поэтому при одном неверном поле ошибка не отличается от обычной.
+func (v validationErrors) toError() *ApplicationError {
+	if len(v) == 0 {
+		return nil
+	}
+
+	first := v[0]
+	return &ApplicationError{
+		Type:    ErrorTypeValidation,
+		Code:    first.Code,
+		Params:  first.Params,
+		Message: first.Message,
+		Fields:  v,
+	}
 }
 
 func (a *ApplicationError) Error() string {
@@ -30,18 +93,47 @@ func (a *ApplicationError) Unwrap() error {
 	return a.Err
 }
 
+var defaultErrorCodes = map[ErrorType]ErrorCode{
+	ErrorTypeDatabase:   CodeDatabaseError,
+	ErrorTypeValidation: CodeInvalidRequest,
+	ErrorTypeNotFound:   CodeEntityNotFound,
+	ErrorTypeInternal:   CodeInternalError,
+	ErrorTypeAuth:       CodeUnauthorized,
+	ErrorTypeConflict:   CodeConflict,
+	ErrorTypeForbidden:  CodeForbidden,
+	ErrorTypeLocked:     CodeNoteLocked,
+}
+
+// NewApplicationError создаёт ошибку с готовым текстом и общим для её типа кодом
 func NewApplicationError(errorType ErrorType, message string, err error) *ApplicationError {
 	return &ApplicationError{
 		Type:    errorType,
+		Code:    defaultErrorCodes[errorType],
+		Message: message,
+		Err:     err,
+	}
+}
+
+// NewLocalizedError создаёт ошибку с кодом из каталога сообщений
+func NewLocalizedError(errorType ErrorType, code ErrorCode, params ErrorParams, err error) *ApplicationError {
+	message, _ := LocalizeMessage(DefaultLocale, code, params)
+
+	return &ApplicationError{
+		Type:    errorType,
+		Code:    code,
+		Params:  params,
 		Message: message,
 		Err:     err,
 	}
 }
 
 type ApiError struct {
-	Message string
-	Err     error
-	Code    int
+	Message   string
+	Err       error
+	Code      int
+	ErrorCode ErrorCode
+	Params    ErrorParams
+	Fields    []*FieldError
 }
 
 func (a *ApiError) Error() string {
@@ -56,26 +148,48 @@ func (a *ApiError) Unwrap() error {
 	return a.Err
 }
 
-func newApiError(code int, message string, err error) *ApiError {
+// LocalizedMessage возвращает сообщение на выбранном языке. Message уже содержит текст
+// на языке по умолчанию, для остальных языков он берётся из каталога по коду.
+func (a *ApiError) LocalizedMessage(locale Locale) string {
+	if locale == DefaultLocale {
+		return a.Message
+	}
+
+	if message, exists := LocalizeMessage(locale, a.ErrorCode, a.Params); exists {
+		return message
+	}
+
+	return a.Message
+}
+
+func newApiError(code int, appError *ApplicationError) *ApiError {
 	return &ApiError{
-		Message: message,
-		Err:     err,
-		Code:    code,
+		Message:   appError.Message,
+		Err:       appError.Err,
+		Code:      code,
+		ErrorCode: appError.Code,
+		Params:    appError.Params,
+		Fields:    appError.Fields,
 	}
 }
 
 func GetAppropriateApiError(appError *ApplicationError) *ApiError {
 	switch appError.Type {
-	case ErrorTypeDatabase:
-	case ErrorTypeInternal:
-		return newApiError(500, appError.Message, appError.Err)
+	case ErrorTypeDatabase, ErrorTypeInternal:
+		return newApiError(500, appError)
 	case ErrorTypeValidation:
-		return newApiError(400, appError.Message, appError.Err)
+		return newApiError(400, appError)
 	case ErrorTypeNotFound:
-		return newApiError(404, appError.Message, appError.Err)
+		return newApiError(404, appError)
 	case ErrorTypeAuth:
-		return newApiError(400, appError.Message, appError.Err)
+		return newApiError(401, appError)
+	case ErrorTypeConflict:
+		return newApiError(409, appError)
+	case ErrorTypeForbidden:
+		return newApiError(403, appError)
+	case ErrorTypeLocked:
+		return newApiError(423, appError)
 	}
 
-	return newApiError(500, "Ошибка сервера", nil)
+	return newApiError(500, NewLocalizedError(ErrorTypeInternal, CodeInternalError, nil, nil))
 }
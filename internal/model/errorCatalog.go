@@ -0,0 +1,360 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultLocale - язык сообщений, если клиент не выбрал другой
+const DefaultLocale = LocaleRu
+
+// errorCatalog - тексты ошибок по языкам. Значения параметров подставляются вместо {имя}.
+var errorCatalog = map[Locale]map[ErrorCode]string{
+	LocaleRu: {
+		CodeInternalError:      "Ошибка сервера",
+		CodeInvalidRequest:     "Некорректный запрос",
+		CodeUnauthorized:       "Не передан токен авторизации",
+		CodeEntityNotFound:     "сущность не найдена",
+		CodeDatabaseError:      " внутрення ошибка БД",
+		CodeUserNotFound:       "пользователь не найден",
+		CodeConflict:           "Конфликт с текущим состоянием данных",
+		CodeInvalidCredentials: "Неверный логин или пароль",
+
+		CodeNoteTypeUnknown:        "Неизвестный тип заметки: {type}",
+		CodeNoteTitleEmpty:         "Название заметки не может быть пустым",
+		CodeNoteContentEmpty:       "Заметка не может быть пустой",
+		CodeNoteContentTooLong:     "Длина заметки не может превышать {max} символов",
+		CodeNoteTooManyTags:        "Нельзя добавить больше, чем {max} тегов к заметке.",
+		CodeNoteTitleTaken:         "Заметка с таким названием уже добавлена",
+		CodeNoteIsNotChecklist:     "Заметка не является списком",
+		CodePositionAnchorNotFound: "Элемент {id} не найден среди соседних",
+		CodePositionInvalid:        "Некорректная позиция элемента",
+
+		CodeChecklistItemEmpty:     "Пункт списка не может быть пустым",
+		CodeChecklistItemMultiline: "Пункт списка не может содержать перевод строки",
+		CodeChecklistItemTooLong:   "Длина пункта списка не может превышать {max} символов",
+
+		CodeFolderTitleEmpty: "Название папки не может быть пустым",
+		CodeFolderTitleTaken: "Папка с таким же именем уже добавлена",
+		CodeFolderArchived:   "Папка находится в архиве",
+
+		CodeUserNameEmpty:       "Имя не может быть пустым",
+		CodeUserSurnameEmpty:    "Фамилия не может быть пустой",
+		CodeLoginTooShort:       "Логин слишком короткий. Пожалуйста, создайте логин длинной не меньше {min} символов",
+		CodeLoginTaken:          "пользователь с таким логином уже добавлен",
+		CodePasswordTooShort:    "Пароль слишком короткий. Пожалуйста, создайте пароль длиной не менее {min} символов",
+		CodePasswordNoUppercase: "Пароль должен содержать букву верхнего регистра.",
+		CodePasswordNoLowercase: "Пароль должен содержать букву нижнего регистра.",
+		CodePasswordNoDigit:     "Пароль должен содержать число.",
+		CodePasswordNoSpecial:   "Пароль должен содержать спецсимволы.",
+		CodeTimezoneUnknown:     "Неизвестный часовой пояс: {timezone}",
+
+		CodeTokenIssueFailed:   "Ошибка при формировании токена",
+		CodeTokenSigningMethod: "Неверный метод подписи",
+		CodeTokenInvalid:       "Невалидный токен",
+		CodeHashFailed:         "Ошибка при создании хэша",
+		CodeHashCheckFailed:    "Ошибка при проверке хэша",
+
+		CodeLocaleUnsupported:   "Неподдерживаемый язык: {locale}",
+		CodeSortOrderUnknown:    "Неизвестный порядок сортировки: {sortOrder}",
+		CodePageSizeOutOfRange:  "Размер страницы должен быть от 0 до {max}",
+		CodeEditorFormatUnknown: "Неизвестный формат редактора: {format}",
+
+		CodeTemplateNameEmpty:       "Название шаблона не может быть пустым",
+		CodeTemplateNameTaken:       "Шаблон с таким названием уже добавлен",
+		CodeTemplateTitleEmpty:      "Заголовок заметки в шаблоне не может быть пустым",
+		CodeTemplatePromptNameEmpty: "Имя запрашиваемого значения шаблона не может быть пустым",
+		CodeTemplateVariableUnknown: "Неизвестная переменная шаблона: {variable}",
+		CodeTemplateValuesMissing:   "Не заполнены значения шаблона: {prompts}",
+		CodeDailyTemplateHasPrompts: "Шаблон ежедневной заметки не может запрашивать значения",
+		CodeDateInvalid:             "Неверный формат даты: {date}, ожидается ГГГГ-ММ-ДД",
+
+		CodeFileNameEmpty:            "Имя файла не может быть пустым",
+		CodeFileNameTooLong:          "Имя файла не может превышать {max} символов",
+		CodeFileEmpty:                "Файл не может быть пустым",
+		CodeFileTooLarge:             "Размер файла не может превышать {maxMb} МБ",
+		CodeFileNotFound:             "файл не найден",
+		CodeFileStorageError:         "ошибка файлового хранилища",
+		CodeAttachmentReadFailed:     "Ошибка при чтении вложения",
+		CodeThumbnailSizeUnsupported: "Размер миниатюры {size} не поддерживается",
+		CodeThumbnailNotAvailable:    "Для этого файла миниатюра не формируется",
+		CodeThumbnailPending:         "Миниатюра еще не сформирована",
+		CodeThumbnailFailed:          "Не удалось сформировать миниатюру",
+		CodeThumbnailEncodeFailed:    "Ошибка при кодировании миниатюры",
+		CodeImageReadFailed:          "Ошибка при чтении изображения",
+		CodeImageFormatUnsupported:   "Неподдерживаемый формат изображения",
+		CodeImageTooLarge:            "Изображение слишком большое",
+		CodeImageDecodeFailed:        "Не удалось декодировать изображение",
+
+		CodeRecurrenceCountAndUntil:    "В правиле повторения нельзя одновременно указывать COUNT и UNTIL",
+		CodeRecurrenceUnsupported:      "Неподдерживаемое правило повторения: {rule}",
+		CodeReminderInPast:             "Время напоминания должно быть в будущем",
+		CodeReminderEmailRequired:      "Для отправки напоминания на почту укажите адрес",
+		CodeReminderWebhookRequired:    "Для отправки напоминания через webhook укажите адрес",
+		CodeEmailInvalid:               "Некорректный адрес электронной почты",
+		CodeWebhookUrlInvalid:          "Некорректный адрес webhook",
+		CodeNotificationChannelUnknown: "Неизвестный канал уведомлений: {channel}",
+		CodeNotificationChannelOff:     "Канал уведомлений {channel} не настроен",
+		CodeIntervalInvalid:            "Конец интервала должен быть позже начала",
+		CodeIntervalTooLong:            "Интервал не может превышать один год",
+		CodeEmailAddressMissing:        "Не указан адрес электронной почты",
+		CodeEmailSendFailed:            "Ошибка при отправке письма",
+		CodeWebhookUrlMissing:          "Не указан адрес webhook",
+		CodeWebhookPayloadFailed:       "Ошибка при формировании уведомления",
+		CodeWebhookCallFailed:          "Ошибка при вызове webhook",
+		CodeWebhookBadStatus:           "Webhook вернул статус {status}",
+		CodeCalendarTokenFailed:        "Ошибка при генерации токена календаря",
+
+		CodeImportStrategyUnknown:    "Неизвестная стратегия разрешения конфликтов: {strategy}",
+		CodeImportArchiveInvalid:     "Не удалось прочитать архив",
+		CodeImportFileReadFailed:     "Не удалось прочитать файл {path}",
+		CodeImportFrontMatterInvalid: "Некорректный YAML-заголовок в файле {path}",
+		CodeImportVersionUnsupported: "Неподдерживаемая версия архива: {version}",
+		CodeExportFormatUnknown:      "Неизвестный формат выгрузки: {format}",
+
+		CodeSyncTokenInvalid:     "Некорректный токен синхронизации",
+		CodeSyncTooManyMutations: "За один раз можно передать не больше {max} изменений",
+		CodeSyncMutationUnknown:  "Неизвестное изменение: {operation} {entity}",
+		CodeSyncClientIdRequired: "Для новой записи нужен ClientId",
+		CodeSyncClientIdUnknown:  "Запись с ClientId {clientId} не найдена",
+		CodeSyncClientIdTooLong:  "ClientId не может быть длиннее {max} символов",
+		CodeSyncVersionConflict:  "Запись изменена на сервере после версии {version}",
+		CodeSyncEntityDeleted:    "Запись удалена на сервере",
+
+		CodeWebhookEventsEmpty:  "Выберите хотя бы одно событие",
+		CodeWebhookEventUnknown: "Неизвестное событие: {event}",
+		CodeWebhookTooMany:      "Можно добавить не больше {max} webhook",
+		CodeWebhookSecretFailed: "Ошибка при генерации секрета webhook",
+		CodeWebhookDisabled:     "Webhook отключён",
+
+		CodeForbidden:          "Недостаточно прав",
+		CodeAuditRangeInvalid:  "Начало периода должно быть раньше его конца",
+		CodeAuditActionUnknown: "Неизвестное действие: {action}",
+
+		CodeCommentEmpty:         "Комментарий не может быть пустым",
+		CodeCommentTooLong:       "Комментарий не может быть длиннее {max} символов",
+		CodeCommentAnchorInvalid: "Фрагмент должен находиться в тексте заметки длиной {length} символов",
+		CodeCommentNotThread:     "Привязка к тексту и отметка о решении есть только у первого комментария ветки",
+		CodeCommentNotAuthor:     "Изменять и удалять комментарий может только его автор",
+
+		CodeNotificationTypeUnknown: "Неизвестный тип уведомлений: {type}",
+
+		CodeCrdtOperationUnknown: "Неизвестная операция над документом: {type}",
+		CodeCrdtElementUnknown:   "Символ {id} не найден в документе",
+		CodeCrdtInsertInvalid:    "Вставка должна содержать ровно один символ и положительный счётчик",
+		CodeCollabSiteForeign:    "Участник может вставлять символы только со своим идентификатором",
+		CodeCollabMessageUnknown: "Неизвестный тип сообщения: {type}",
+
+		CodeNoteLocked: "Заметку редактирует другой клиент, блокировка действует до {expiresAt}",
+
+		CodeWebhookUrlForbidden: "Адрес webhook указывает на внутреннюю сеть",
+
+		CodeNoteLinkRewriteLocked: "Заметку «{title}» со ссылкой на эту заметку редактирует другой клиент, переименование не выполнено",
+	},
+	LocaleEn: {
+		CodeInternalError:      "Internal server error",
+		CodeInvalidRequest:     "Invalid request",
+		CodeUnauthorized:       "No token provided",
+		CodeEntityNotFound:     "Entity not found",
+		CodeDatabaseError:      "Internal database error",
+		CodeUserNotFound:       "User not found",
+		CodeConflict:           "The request conflicts with the current state of the data",
+		CodeInvalidCredentials: "Invalid login or password",
+
+		CodeNoteTypeUnknown:        "Unknown note type: {type}",
+		CodeNoteTitleEmpty:         "Note title cannot be empty",
+		CodeNoteContentEmpty:       "Note cannot be empty",
+		CodeNoteContentTooLong:     "Note length cannot exceed {max} characters",
+		CodeNoteTooManyTags:        "A note cannot have more than {max} tags.",
+		CodeNoteTitleTaken:         "A note with this title already exists",
+		CodeNoteIsNotChecklist:     "The note is not a checklist",
+		CodePositionAnchorNotFound: "Item {id} was not found among the neighbours",
+		CodePositionInvalid:        "Invalid item position",
+
+		CodeChecklistItemEmpty:     "A checklist item cannot be empty",
+		CodeChecklistItemMultiline: "A checklist item cannot contain line breaks",
+		CodeChecklistItemTooLong:   "A checklist item cannot exceed {max} characters",
+
+		CodeFolderTitleEmpty: "Folder title cannot be empty",
+		CodeFolderTitleTaken: "A folder with this title already exists",
+		CodeFolderArchived:   "The folder is archived",
+
+		CodeUserNameEmpty:       "Name cannot be empty",
+		CodeUserSurnameEmpty:    "Surname cannot be empty",
+		CodeLoginTooShort:       "Login is too short. Please use at least {min} characters",
+		CodeLoginTaken:          "A user with this login already exists",
+		CodePasswordTooShort:    "Password is too short. Please use at least {min} characters",
+		CodePasswordNoUppercase: "Password must contain an uppercase letter.",
+		CodePasswordNoLowercase: "Password must contain a lowercase letter.",
+		CodePasswordNoDigit:     "Password must contain a digit.",
+		CodePasswordNoSpecial:   "Password must contain special characters.",
+		CodeTimezoneUnknown:     "Unknown time zone: {timezone}",
+
+		CodeTokenIssueFailed:   "Failed to issue a token",
+		CodeTokenSigningMethod: "Invalid signing method",
+		CodeTokenInvalid:       "Invalid token",
+		CodeHashFailed:         "Failed to create a hash",
+		CodeHashCheckFailed:    "Failed to verify a hash",
+
+		CodeLocaleUnsupported:   "Unsupported language: {locale}",
+		CodeSortOrderUnknown:    "Unknown sort order: {sortOrder}",
+		CodePageSizeOutOfRange:  "Page size must be between 0 and {max}",
+		CodeEditorFormatUnknown: "Unknown editor format: {format}",
+
+		CodeTemplateNameEmpty:       "Template name cannot be empty",
+		CodeTemplateNameTaken:       "A template with this name already exists",
+		CodeTemplateTitleEmpty:      "Template note title cannot be empty",
+		CodeTemplatePromptNameEmpty: "Template prompt name cannot be empty",
+		CodeTemplateVariableUnknown: "Unknown template variable: {variable}",
+		CodeTemplateValuesMissing:   "Missing template values: {prompts}",
+		CodeDailyTemplateHasPrompts: "A daily note template cannot ask for values",
+		CodeDateInvalid:             "Invalid date: {date}, expected YYYY-MM-DD",
+
+		CodeFileNameEmpty:            "File name cannot be empty",
+		CodeFileNameTooLong:          "File name cannot exceed {max} characters",
+		CodeFileEmpty:                "File cannot be empty",
+		CodeFileTooLarge:             "File size cannot exceed {maxMb} MB",
+		CodeFileNotFound:             "File not found",
+		CodeFileStorageError:         "File storage error",
+		CodeAttachmentReadFailed:     "Failed to read the attachment",
+		CodeThumbnailSizeUnsupported: "Thumbnail size {size} is not supported",
+		CodeThumbnailNotAvailable:    "Thumbnails are not generated for this file",
+		CodeThumbnailPending:         "The thumbnail is not ready yet",
+		CodeThumbnailFailed:          "Failed to generate the thumbnail",
+		CodeThumbnailEncodeFailed:    "Failed to encode the thumbnail",
+		CodeImageReadFailed:          "Failed to read the image",
+		CodeImageFormatUnsupported:   "Unsupported image format",
+		CodeImageTooLarge:            "The image is too large",
+		CodeImageDecodeFailed:        "Failed to decode the image",
+
+		CodeRecurrenceCountAndUntil:    "A recurrence rule cannot specify both COUNT and UNTIL",
+		CodeRecurrenceUnsupported:      "Unsupported recurrence rule: {rule}",
+		CodeReminderInPast:             "Reminder time must be in the future",
+		CodeReminderEmailRequired:      "Specify an address to send the reminder by email",
+		CodeReminderWebhookRequired:    "Specify an address to send the reminder by webhook",
+		CodeEmailInvalid:               "Invalid email address",
+		CodeWebhookUrlInvalid:          "Invalid webhook address",
+		CodeNotificationChannelUnknown: "Unknown notification channel: {channel}",
+		CodeNotificationChannelOff:     "Notification channel {channel} is not configured",
+		CodeIntervalInvalid:            "The interval end must be after its start",
+		CodeIntervalTooLong:            "The interval cannot exceed one year",
+		CodeEmailAddressMissing:        "Email address is missing",
+		CodeEmailSendFailed:            "Failed to send the email",
+		CodeWebhookUrlMissing:          "Webhook address is missing",
+		CodeWebhookPayloadFailed:       "Failed to build the notification",
+		CodeWebhookCallFailed:          "Failed to call the webhook",
+		CodeWebhookBadStatus:           "Webhook returned status {status}",
+		CodeCalendarTokenFailed:        "Failed to generate the calendar token",
+
+		CodeImportStrategyUnknown:    "Unknown conflict strategy: {strategy}",
+		CodeImportArchiveInvalid:     "Failed to read the archive",
+		CodeImportFileReadFailed:     "Failed to read file {path}",
+		CodeImportFrontMatterInvalid: "Invalid YAML front matter in file {path}",
+		CodeImportVersionUnsupported: "Unsupported archive version: {version}",
+		CodeExportFormatUnknown:      "Unknown export format: {format}",
+
+		CodeSyncTokenInvalid:     "Invalid sync token",
+		CodeSyncTooManyMutations: "No more than {max} changes can be sent at once",
+		CodeSyncMutationUnknown:  "Unknown change: {operation} {entity}",
+		CodeSyncClientIdRequired: "A new record requires a ClientId",
+		CodeSyncClientIdUnknown:  "Record with ClientId {clientId} not found",
+		CodeSyncClientIdTooLong:  "ClientId cannot be longer than {max} characters",
+		CodeSyncVersionConflict:  "The record was changed on the server after version {version}",
+		CodeSyncEntityDeleted:    "The record was deleted on the server",
+
+		CodeWebhookEventsEmpty:  "Select at least one event",
+		CodeWebhookEventUnknown: "Unknown event: {event}",
+		CodeWebhookTooMany:      "No more than {max} webhooks can be added",
+		CodeWebhookSecretFailed: "Failed to generate the webhook secret",
+		CodeWebhookDisabled:     "The webhook is disabled",
+
+		CodeForbidden:          "Access denied",
+		CodeAuditRangeInvalid:  "The start of the period must be before its end",
+		CodeAuditActionUnknown: "Unknown action: {action}",
+
+		CodeCommentEmpty:         "The comment cannot be empty",
+		CodeCommentTooLong:       "The comment cannot be longer than {max} characters",
+		CodeCommentAnchorInvalid: "The fragment must lie within the note text of {length} characters",
+		CodeCommentNotThread:     "Only the first comment of a thread can be anchored to the text or resolved",
+		CodeCommentNotAuthor:     "Only the author can edit or delete the comment",
+
+		CodeNotificationTypeUnknown: "Unknown notification type: {type}",
+
+		CodeCrdtOperationUnknown: "Unknown document operation: {type}",
+		CodeCrdtElementUnknown:   "Character {id} is not in the document",
+		CodeCrdtInsertInvalid:    "An insert must contain exactly one character and a positive counter",
+		CodeCollabSiteForeign:    "A participant can only insert characters with its own identifier",
+		CodeCollabMessageUnknown: "Unknown message type: {type}",
+
+		CodeNoteLocked: "The note is being edited by another client, the lock expires at {expiresAt}",
+
+		CodeWebhookUrlForbidden: "The webhook address points to an internal network",
+
+		CodeNoteLinkRewriteLocked: "The note \"{title}\" that links to this note is being edited by another client, the rename was not applied",
+	},
+}
+
+// LocalizeMessage возвращает текст ошибки на выбранном языке. Если перевода нет,
+// используется язык по умолчанию; второе значение false, если код не найден вовсе.
+func LocalizeMessage(locale Locale, code ErrorCode, params ErrorParams) (string, bool) {
+	message, exists := errorCatalog[locale][code]
+	if !exists {
+		message, exists = errorCatalog[DefaultLocale][code]
+		if !exists {
+			return "", false
+		}
+	}
+
+	for name, value := range params {
+		message = strings.ReplaceAll(message, "{"+name+"}", fmt.Sprint(value))
+	}
+
+	return message, true
+}
+
+// ParseLocale возвращает поддерживаемый язык по значению вида "en" или "en-US"
+func ParseLocale(value string) (Locale, bool) {
+	language, _, _ := strings.Cut(strings.TrimSpace(value), "-")
+	locale := Locale(strings.ToLower(language))
+
+	_, supported := errorCatalog[locale]
+	return locale, supported
+}
+
+// ParseAcceptLanguage выбирает из заголовка Accept-Language поддерживаемый язык с наибольшим весом
+func ParseAcceptLanguage(header string) (Locale, bool) {
+	type candidate struct {
+		locale Locale
+		weight float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		tag, options, _ := strings.Cut(part, ";")
+
+		weight := 1.0
+		if value, found := strings.CutPrefix(strings.TrimSpace(options), "q="); found {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			weight = parsed
+		}
+
+		if locale, supported := ParseLocale(tag); supported && weight > 0 {
+			candidates = append(candidates, candidate{locale, weight})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return DefaultLocale, false
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].weight > candidates[j].weight
+	})
+
+	return candidates[0].locale, true
+}
@@ -0,0 +1,150 @@
+package model
+
+// ErrorCode - стабильный машиночитаемый код ошибки, по которому клиенты различают ошибки
+// независимо от языка сообщения
+type ErrorCode string
+
+// ErrorParams - значения, подставляемые в сообщение ошибки из каталога
+type ErrorParams map[string]any
+
+const (
+	CodeInternalError      ErrorCode = "INTERNAL_ERROR"
+	CodeInvalidRequest     ErrorCode = "INVALID_REQUEST"
+	CodeUnauthorized       ErrorCode = "UNAUTHORIZED"
+	CodeEntityNotFound     ErrorCode = "ENTITY_NOT_FOUND"
+	CodeDatabaseError      ErrorCode = "DATABASE_ERROR"
+	CodeUserNotFound       ErrorCode = "USER_NOT_FOUND"
+	CodeConflict           ErrorCode = "CONFLICT"
+	CodeInvalidCredentials ErrorCode = "INVALID_CREDENTIALS"
+
+	CodeNoteTypeUnknown        ErrorCode = "NOTE_TYPE_UNKNOWN"
+	CodeNoteTitleEmpty         ErrorCode = "NOTE_TITLE_EMPTY"
+	CodeNoteContentEmpty       ErrorCode = "NOTE_CONTENT_EMPTY"
+	CodeNoteContentTooLong     ErrorCode = "NOTE_CONTENT_TOO_LONG"
+	CodeNoteTooManyTags        ErrorCode = "NOTE_TOO_MANY_TAGS"
+	CodeNoteTitleTaken         ErrorCode = "NOTE_TITLE_TAKEN"
+	CodeNoteIsNotChecklist     ErrorCode = "NOTE_IS_NOT_CHECKLIST"
+	CodePositionAnchorNotFound ErrorCode = "POSITION_ANCHOR_NOT_FOUND"
+	CodePositionInvalid        ErrorCode = "POSITION_INVALID"
+
+	CodeChecklistItemEmpty     ErrorCode = "CHECKLIST_ITEM_EMPTY"
+	CodeChecklistItemMultiline ErrorCode = "CHECKLIST_ITEM_MULTILINE"
+	CodeChecklistItemTooLong   ErrorCode = "CHECKLIST_ITEM_TOO_LONG"
+
+	CodeFolderTitleEmpty ErrorCode = "FOLDER_TITLE_EMPTY"
+	CodeFolderTitleTaken ErrorCode = "FOLDER_TITLE_TAKEN"
+	CodeFolderArchived   ErrorCode = "FOLDER_ARCHIVED"
+
+	CodeUserNameEmpty       ErrorCode = "USER_NAME_EMPTY"
+	CodeUserSurnameEmpty    ErrorCode = "USER_SURNAME_EMPTY"
+	CodeLoginTooShort       ErrorCode = "LOGIN_TOO_SHORT"
+	CodeLoginTaken          ErrorCode = "LOGIN_TAKEN"
+	CodePasswordTooShort    ErrorCode = "PASSWORD_TOO_SHORT"
+	CodePasswordNoUppercase ErrorCode = "PASSWORD_NO_UPPERCASE"
+	CodePasswordNoLowercase ErrorCode = "PASSWORD_NO_LOWERCASE"
+	CodePasswordNoDigit     ErrorCode = "PASSWORD_NO_DIGIT"
+	CodePasswordNoSpecial   ErrorCode = "PASSWORD_NO_SPECIAL"
+	CodeTimezoneUnknown     ErrorCode = "TIMEZONE_UNKNOWN"
+
+	CodeTokenIssueFailed   ErrorCode = "TOKEN_ISSUE_FAILED"
+	CodeTokenSigningMethod ErrorCode = "TOKEN_SIGNING_METHOD_INVALID"
+	CodeTokenInvalid       ErrorCode = "TOKEN_INVALID"
+	CodeHashFailed         ErrorCode = "HASH_FAILED"
+	CodeHashCheckFailed    ErrorCode = "HASH_CHECK_FAILED"
+
+	CodeLocaleUnsupported   ErrorCode = "LOCALE_UNSUPPORTED"
+	CodeSortOrderUnknown    ErrorCode = "SORT_ORDER_UNKNOWN"
+	CodePageSizeOutOfRange  ErrorCode = "PAGE_SIZE_OUT_OF_RANGE"
+	CodeEditorFormatUnknown ErrorCode = "EDITOR_FORMAT_UNKNOWN"
+
+	CodeTemplateNameEmpty       ErrorCode = "TEMPLATE_NAME_EMPTY"
+	CodeTemplateNameTaken       ErrorCode = "TEMPLATE_NAME_TAKEN"
+	CodeTemplateTitleEmpty      ErrorCode = "TEMPLATE_TITLE_EMPTY"
+	CodeTemplatePromptNameEmpty ErrorCode = "TEMPLATE_PROMPT_NAME_EMPTY"
+	CodeTemplateVariableUnknown ErrorCode = "TEMPLATE_VARIABLE_UNKNOWN"
+	CodeTemplateValuesMissing   ErrorCode = "TEMPLATE_VALUES_MISSING"
+	CodeDailyTemplateHasPrompts ErrorCode = "DAILY_TEMPLATE_HAS_PROMPTS"
+	CodeDateInvalid             ErrorCode = "DATE_INVALID"
+
+	CodeFileNameEmpty            ErrorCode = "FILE_NAME_EMPTY"
+	CodeFileNameTooLong          ErrorCode = "FILE_NAME_TOO_LONG"
+	CodeFileEmpty                ErrorCode = "FILE_EMPTY"
+	CodeFileTooLarge             ErrorCode = "FILE_TOO_LARGE"
+	CodeFileNotFound             ErrorCode = "FILE_NOT_FOUND"
+	CodeFileStorageError         ErrorCode = "FILE_STORAGE_ERROR"
+	CodeAttachmentReadFailed     ErrorCode = "ATTACHMENT_READ_FAILED"
+	CodeThumbnailSizeUnsupported ErrorCode = "THUMBNAIL_SIZE_UNSUPPORTED"
+	CodeThumbnailNotAvailable    ErrorCode = "THUMBNAIL_NOT_AVAILABLE"
+	CodeThumbnailPending         ErrorCode = "THUMBNAIL_PENDING"
+	CodeThumbnailFailed          ErrorCode = "THUMBNAIL_FAILED"
+	CodeThumbnailEncodeFailed    ErrorCode = "THUMBNAIL_ENCODE_FAILED"
+	CodeImageReadFailed          ErrorCode = "IMAGE_READ_FAILED"
+	CodeImageFormatUnsupported   ErrorCode = "IMAGE_FORMAT_UNSUPPORTED"
+	CodeImageTooLarge            ErrorCode = "IMAGE_TOO_LARGE"
+	CodeImageDecodeFailed        ErrorCode = "IMAGE_DECODE_FAILED"
+
+	CodeRecurrenceCountAndUntil    ErrorCode = "RECURRENCE_COUNT_AND_UNTIL"
+	CodeRecurrenceUnsupported      ErrorCode = "RECURRENCE_UNSUPPORTED"
+	CodeReminderInPast             ErrorCode = "REMINDER_IN_PAST"
+	CodeReminderEmailRequired      ErrorCode = "REMINDER_EMAIL_REQUIRED"
+	CodeReminderWebhookRequired    ErrorCode = "REMINDER_WEBHOOK_REQUIRED"
+	CodeEmailInvalid               ErrorCode = "EMAIL_INVALID"
+	CodeWebhookUrlInvalid          ErrorCode = "WEBHOOK_URL_INVALID"
+	CodeNotificationChannelUnknown ErrorCode = "NOTIFICATION_CHANNEL_UNKNOWN"
+	CodeNotificationChannelOff     ErrorCode = "NOTIFICATION_CHANNEL_DISABLED"
+	CodeIntervalInvalid            ErrorCode = "INTERVAL_INVALID"
+	CodeIntervalTooLong            ErrorCode = "INTERVAL_TOO_LONG"
+	CodeEmailAddressMissing        ErrorCode = "EMAIL_ADDRESS_MISSING"
+	CodeEmailSendFailed            ErrorCode = "EMAIL_SEND_FAILED"
+	CodeWebhookUrlMissing          ErrorCode = "WEBHOOK_URL_MISSING"
+	CodeWebhookPayloadFailed       ErrorCode = "WEBHOOK_PAYLOAD_FAILED"
+	CodeWebhookCallFailed          ErrorCode = "WEBHOOK_CALL_FAILED"
+	CodeWebhookBadStatus           ErrorCode = "WEBHOOK_BAD_STATUS"
+	CodeCalendarTokenFailed        ErrorCode = "CALENDAR_TOKEN_FAILED"
+
+	CodeImportStrategyUnknown    ErrorCode = "IMPORT_STRATEGY_UNKNOWN"
+	CodeImportArchiveInvalid     ErrorCode = "IMPORT_ARCHIVE_INVALID"
+	CodeImportFileReadFailed     ErrorCode = "IMPORT_FILE_READ_FAILED"
+	CodeImportFrontMatterInvalid ErrorCode = "IMPORT_FRONT_MATTER_INVALID"
+	CodeImportVersionUnsupported ErrorCode = "IMPORT_VERSION_UNSUPPORTED"
+	CodeExportFormatUnknown      ErrorCode = "EXPORT_FORMAT_UNKNOWN"
+
+	CodeSyncTokenInvalid     ErrorCode = "SYNC_TOKEN_INVALID"
+	CodeSyncTooManyMutations ErrorCode = "SYNC_TOO_MANY_MUTATIONS"
+	CodeSyncMutationUnknown  ErrorCode = "SYNC_MUTATION_UNKNOWN"
+	CodeSyncClientIdRequired ErrorCode = "SYNC_CLIENT_ID_REQUIRED"
+	CodeSyncClientIdUnknown  ErrorCode = "SYNC_CLIENT_ID_UNKNOWN"
+	CodeSyncClientIdTooLong  ErrorCode = "SYNC_CLIENT_ID_TOO_LONG"
+	CodeSyncVersionConflict  ErrorCode = "SYNC_VERSION_CONFLICT"
+	CodeSyncEntityDeleted    ErrorCode = "SYNC_ENTITY_DELETED"
+
+	CodeWebhookEventsEmpty  ErrorCode = "WEBHOOK_EVENTS_EMPTY"
+	CodeWebhookEventUnknown ErrorCode = "WEBHOOK_EVENT_UNKNOWN"
+	CodeWebhookTooMany      ErrorCode = "WEBHOOK_TOO_MANY"
+	CodeWebhookSecretFailed ErrorCode = "WEBHOOK_SECRET_FAILED"
+	CodeWebhookDisabled     ErrorCode = "WEBHOOK_DISABLED"
+
+	CodeForbidden          ErrorCode = "FORBIDDEN"
+	CodeAuditRangeInvalid  ErrorCode = "AUDIT_RANGE_INVALID"
+	CodeAuditActionUnknown ErrorCode = "AUDIT_ACTION_UNKNOWN"
+
+	CodeCommentEmpty         ErrorCode = "COMMENT_EMPTY"
+	CodeCommentTooLong       ErrorCode = "COMMENT_TOO_LONG"
+	CodeCommentAnchorInvalid ErrorCode = "COMMENT_ANCHOR_INVALID"
+	CodeCommentNotThread     ErrorCode = "COMMENT_NOT_THREAD"
+	CodeCommentNotAuthor     ErrorCode = "COMMENT_NOT_AUTHOR"
+
+	CodeNotificationTypeUnknown ErrorCode = "NOTIFICATION_TYPE_UNKNOWN"
+
+	CodeCrdtOperationUnknown ErrorCode = "CRDT_OPERATION_UNKNOWN"
+	CodeCrdtElementUnknown   ErrorCode = "CRDT_ELEMENT_UNKNOWN"
+	CodeCrdtInsertInvalid    ErrorCode = "CRDT_INSERT_INVALID"
+	CodeCollabSiteForeign    ErrorCode = "COLLAB_SITE_FOREIGN"
+	CodeCollabMessageUnknown ErrorCode = "COLLAB_MESSAGE_UNKNOWN"
+
+	CodeNoteLocked ErrorCode = "NOTE_LOCKED"
+
+	CodeWebhookUrlForbidden ErrorCode = "WEBHOOK_URL_FORBIDDEN"
+
+	CodeNoteLinkRewriteLocked ErrorCode = "NOTE_LINK_REWRITE_LOCKED"
+)
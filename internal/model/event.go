@@ -0,0 +1,119 @@
+package model
+
+import "time"
+
+// EventType - вид изменения данных пользователя, о котором сообщается подключённым клиентам
+type EventType string
+
+const (
+	EventNoteCreated   EventType = "note.created"
+	EventNoteUpdated   EventType = "note.updated"
+	EventNoteDeleted   EventType = "note.deleted"
+	EventNoteMoved     EventType = "note.moved"
+	EventNoteFavorite  EventType = "note.favorite"
+	EventFolderCreated EventType = "folder.created"
+	EventFolderRenamed EventType = "folder.renamed"
+	EventFolderDeleted EventType = "folder.deleted"
+	EventFolderUpdated EventType = "folder.updated"
+)
+
+// Event - изменение заметки или папки. Клиент по нему решает, что перечитать.
+// FolderId у событий заметки - папка, в которой заметка находится после изменения.
+// События хранятся в журнале изменений, Id - позиция события в нём, возрастающая со временем.
+// summary - состояние записи после изменения для журнала аудита, клиентам оно не отправляется.
+type Event struct {
+	Id         int
+	Type       EventType
+	UserId     int    `json:"-"`
+	NoteId     *int   `json:",omitempty"`
+	FolderId   *int   `json:",omitempty"`
+	Title      string `json:",omitempty"`
+	IsFavorite *bool  `json:",omitempty"`
+	Timestamp  time.Time
+	summary    AuditSummary
+}
+
+func NewNoteEvent(eventType EventType, note *Note) *Event {
+	noteId := note.Id
+
+	event := &Event{
+		Type:      eventType,
+		UserId:    note.UserId,
+		NoteId:    &noteId,
+		FolderId:  note.FolderId,
+		Title:     note.Title,
+		Timestamp: time.Now(),
+		summary:   NoteAuditSummary(note),
+	}
+
+	if eventType == EventNoteFavorite {
+		isFavorite := note.IsFavorite
+		event.IsFavorite = &isFavorite
+	}
+
+	return event
+}
+
+func NewFolderEvent(eventType EventType, folder *Folder) *Event {
+	folderId := folder.Id
+
+	return &Event{
+		Type:      eventType,
+		UserId:    folder.UserId,
+		FolderId:  &folderId,
+		Title:     folder.Title,
+		Timestamp: time.Now(),
+		summary:   FolderAuditSummary(folder),
+	}
+}
+
+// IsDeletion сообщает, что запись события удалена
+func (e *Event) IsDeletion() bool {
+	return e.Type == EventNoteDeleted || e.Type == EventFolderDeleted
+}
+
+// AuditEntry возвращает запись журнала аудита об этом изменении. Состояние до изменения известно
+// только у удаления, для остальных изменений его дополняет тот, кто сохраняет запись.
+func (e *Event) AuditEntry() *AuditEntry {
+	actorId := e.UserId
+
+	entity, entityId := AuditEntityFolder, e.FolderId
+	if e.NoteId != nil {
+		entity, entityId = AuditEntityNote, e.NoteId
+	}
+
+	entry := NewAuditEntry(&actorId, AuditAction(e.Type), entity, entityId)
+	if e.IsDeletion() {
+		entry.SetChange(e.summary, nil)
+	} else {
+		entry.SetChange(nil, e.summary)
+	}
+	return entry
+}
+
+func (e *Event) SetId(id int) {
+	e.Id = id
+}
+
+func (e *Event) GetId() int {
+	return e.Id
+}
+
+func (e *Event) SetTimestamp() {
+	e.Timestamp = time.Now()
+}
+
+// EventSubscription - поток событий одного пользователя. Канал Events закрывается после Close
+// или если подписчик не успевает забирать события: тогда клиенту нужно перечитать данные целиком.
+type EventSubscription struct {
+	Events <-chan *Event
+	close  func()
+}
+
+func NewEventSubscription(events <-chan *Event, close func()) *EventSubscription {
+	return &EventSubscription{Events: events, close: close}
+}
+
+func (s *EventSubscription) Close() {
+	s.close()
+}
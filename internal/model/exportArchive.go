@@ -0,0 +1,155 @@
+package model
+
+import (
+	"fmt"
+	"path"
+	"time"
+)
+
+// ExportArchiveVersion - версия формата JSON-архива. Увеличивается при несовместимых изменениях,
+// импорт принимает только известные версии.
+const ExportArchiveVersion = 1
+
+type ExportFormat string
+
+const (
+	ExportFormatMarkdown ExportFormat = "markdown"
+	ExportFormatJson     ExportFormat = "json"
+)
+
+// ExportArchive - JSON-архив блокнота. Заметки ссылаются на папки по названию.
+type ExportArchive struct {
+	Version    int
+	ExportedAt time.Time
+	Folders    []*ExportFolder
+	Notes      []*ExportNote
+}
+
+type ExportFolder struct {
+	Title      string
+	IsArchived bool `json:",omitempty"`
+}
+
+type ExportNote struct {
+	Title      string
+	Folder     string `json:",omitempty"`
+	Content    string
+	Type       NoteType
+	Tags       []string
+	IsFavorite bool                   `json:",omitempty"`
+	IsPinned   bool                   `json:",omitempty"`
+	IsArchived bool                   `json:",omitempty"`
+	Items      []*ExportChecklistItem `json:",omitempty"`
+	Timestamp  time.Time
+}
+
+type ExportChecklistItem struct {
+	Text      string
+	IsChecked bool
+}
+
+// ParseExportFormat по умолчанию выгружает Markdown
+func ParseExportFormat(value string) (ExportFormat, *ApplicationError) {
+	switch ExportFormat(value) {
+	case "":
+		return ExportFormatMarkdown, nil
+	case ExportFormatMarkdown, ExportFormatJson:
+		return ExportFormat(value), nil
+	}
+
+	return "", NewLocalizedError(ErrorTypeValidation, CodeExportFormatUnknown, ErrorParams{"format": value}, nil)
+}
+
+func (f ExportFormat) ContentType() string {
+	if f == ExportFormatJson {
+		return "application/json"
+	}
+
+	return "application/zip"
+}
+
+func (f ExportFormat) FileName(now time.Time) string {
+	extension := "zip"
+	if f == ExportFormatJson {
+		extension = "json"
+	}
+
+	return fmt.Sprintf("notes-%s.%s", now.Format("2006-01-02"), extension)
+}
+
+func ToExportFolders(folders []*Folder) []*ExportFolder {
+	exportFolders := make([]*ExportFolder, 0, len(folders))
+	for _, folder := range folders {
+		exportFolders = append(exportFolders, &ExportFolder{Title: folder.Title, IsArchived: folder.IsArchived})
+	}
+
+	return exportFolders
+}
+
+// ToExportNote переводит заметку в формат архива. folderTitles - названия папок пользователя по id.
+func ToExportNote(note *Note, folderTitles map[int]string, items []*ChecklistItem) *ExportNote {
+	exportNote := &ExportNote{
+		Title:      note.Title,
+		Content:    note.Content,
+		Type:       note.Type,
+		Tags:       note.Tags,
+		IsFavorite: note.IsFavorite,
+		IsPinned:   note.IsPinned,
+		IsArchived: note.IsArchived,
+		Timestamp:  note.Timestamp,
+	}
+
+	if exportNote.Type == "" {
+		exportNote.Type = NoteTypeText
+	}
+
+	if exportNote.Tags == nil {
+		exportNote.Tags = make([]string, 0)
+	}
+
+	if note.FolderId != nil {
+		exportNote.Folder = folderTitles[*note.FolderId]
+	}
+
+	if note.IsChecklist() {
+		exportNote.Items = make([]*ExportChecklistItem, 0, len(items))
+		for _, item := range items {
+			exportNote.Items = append(exportNote.Items, &ExportChecklistItem{Text: item.Text, IsChecked: item.IsChecked})
+		}
+	}
+
+	return exportNote
+}
+
+// ToImportedNote переводит заметку из JSON-архива в заметку для импорта
+func (n *ExportNote) ToImportedNote() *ImportedNote {
+	timestamp := n.Timestamp
+	tags := n.Tags
+	if tags == nil {
+		tags = make([]string, 0)
+	}
+
+	note := &ImportedNote{
+		Path:       path.Join(n.Folder, n.Title),
+		Folder:     n.Folder,
+		Title:      n.Title,
+		Content:    n.Content,
+		Tags:       tags,
+		IsFavorite: n.IsFavorite,
+		IsPinned:   n.IsPinned,
+		IsArchived: n.IsArchived,
+	}
+
+	if !timestamp.IsZero() {
+		note.Timestamp = &timestamp
+	}
+
+	if n.Type == NoteTypeChecklist {
+		note.Items = n.Items
+		if note.Items == nil {
+			note.Items = make([]*ExportChecklistItem, 0)
+		}
+	}
+
+	return note
+}
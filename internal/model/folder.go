@@ -5,16 +5,21 @@ import (
 )
 
 type Folder struct {
-	Id        int
-	Title     string
-	Timestamp time.Time
-	UserId    int
-	Notes     []Note
+	Id         int
+	Title      string
+	Timestamp  time.Time
+	UserId     int
+	Notes      []Note
+	Position   string
+	IsArchived bool
+	Version    int
+	ChangeSeq  int64
+	ClientId   *string
 }
 
 func NewFolder(title string, userId int) (*Folder, *ApplicationError) {
 	if len(title) == 0 {
-		return nil, NewApplicationError(ErrorTypeValidation, "Название папки не может быть пустым", nil)
+		return nil, NewLocalizedError(ErrorTypeValidation, CodeFolderTitleEmpty, nil, nil)
 	}
 
 	return &Folder{
@@ -43,3 +48,16 @@ func (f *Folder) GetNotes() []Note {
 func (f *Folder) SetTimestamp() {
 	f.Timestamp = time.Now()
 }
+
+func (f *Folder) GetUserId() int {
+	return f.UserId
+}
+
+func (f *Folder) GetSyncEntity() SyncEntity {
+	return SyncEntityFolder
+}
+
+func (f *Folder) SetChange(changeSeq int64) {
+	f.Version++
+	f.ChangeSeq = changeSeq
+}
@@ -8,6 +8,7 @@ type FolderApi struct {
 	Timestamp time.Time
 	UserId    int `json:"-"`
 	Notes     []NoteApi
+	Position  string
 }
 
 func (f *FolderApi) AppendNotes(notes []NoteApi) {
@@ -29,6 +30,7 @@ func ToFolderApi(dbFolder *Folder) *FolderApi {
 		Title:     dbFolder.Title,
 		Timestamp: dbFolder.Timestamp,
 		UserId:    dbFolder.UserId,
+		Position:  dbFolder.Position,
 	}
 }
 
@@ -40,6 +42,7 @@ func ToFoldersApi(dbFolders []*Folder) []*FolderApi {
 			Title:     dbFolders[i].Title,
 			Timestamp: dbFolders[i].Timestamp,
 			UserId:    dbFolders[i].UserId,
+			Position:  dbFolders[i].Position,
 		})
 	}
 
@@ -0,0 +1,208 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+type ImportSource string
+
+const (
+	ImportSourceMarkdown ImportSource = "markdown"
+	ImportSourceJson     ImportSource = "json"
+	ImportSourceEnex     ImportSource = "enex"
+)
+
+type ImportJobStatus string
+
+const (
+	ImportJobStatusPending   ImportJobStatus = "pending"
+	ImportJobStatusRunning   ImportJobStatus = "running"
+	ImportJobStatusCompleted ImportJobStatus = "completed"
+	ImportJobStatusFailed    ImportJobStatus = "failed"
+)
+
+type ImportFileStatus string
+
+const (
+	ImportFileStatusImported    ImportFileStatus = "imported"
+	ImportFileStatusRenamed     ImportFileStatus = "renamed"
+	ImportFileStatusOverwritten ImportFileStatus = "overwritten"
+	ImportFileStatusSkipped     ImportFileStatus = "skipped"
+	ImportFileStatusFailed      ImportFileStatus = "failed"
+)
+
+// ImportConflictStrategy - что делать, если заметка с таким названием уже есть
+type ImportConflictStrategy string
+
+const (
+	ImportConflictSkip      ImportConflictStrategy = "skip"
+	ImportConflictRename    ImportConflictStrategy = "rename"
+	ImportConflictOverwrite ImportConflictStrategy = "overwrite"
+)
+
+// ImportJob - фоновый импорт загруженного файла. Processed позволяет продолжить
+// прерванный импорт с того же места, не создавая заметки повторно.
+type ImportJob struct {
+	Id         int
+	UserId     int
+	Source     ImportSource
+	Strategy   ImportConflictStrategy
+	Status     ImportJobStatus
+	FileName   string
+	StorageKey string
+	Total      int
+	Processed  int
+	Imported   int
+	Skipped    int
+	Failed     int
+	Error      string
+	FinishedAt *time.Time
+	Timestamp  time.Time
+}
+
+// ImportJobFile - результат импорта одного файла
+type ImportJobFile struct {
+	Id        int
+	JobId     int
+	Path      string
+	Status    ImportFileStatus
+	NoteId    *int
+	Code      ErrorCode
+	Message   string
+	Timestamp time.Time
+}
+
+// ImportedNote - заметка, прочитанная из импортируемого файла, до проверки и сохранения.
+// Folder - название папки, пустое для корня блокнота. Items задан только для заметок-списков.
+// Attachments - файлы, встроенные в заметку, сохраняются вложениями после её создания.
+type ImportedNote struct {
+	Path        string
+	Folder      string
+	Title       string
+	Content     string
+	Tags        []string
+	IsFavorite  bool
+	IsPinned    bool
+	IsArchived  bool
+	Items       []*ExportChecklistItem
+	Attachments []*ImportedAttachment
+	Timestamp   *time.Time
+}
+
+type ImportedAttachment struct {
+	FileName string
+	Data     []byte
+}
+
+// ImportedFolder - папка, перечисленная в импортируемом файле, в том числе пустая
+type ImportedFolder struct {
+	Title      string
+	IsArchived bool
+}
+
+func NewImportJob(userId int, source ImportSource, strategy string, fileName string, storageKey string) (*ImportJob, *ApplicationError) {
+	conflictStrategy, err := ParseImportConflictStrategy(strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ImportJob{
+		UserId:     userId,
+		Source:     source,
+		Strategy:   conflictStrategy,
+		Status:     ImportJobStatusPending,
+		FileName:   fileName,
+		StorageKey: storageKey,
+	}, nil
+}
+
+// ParseImportConflictStrategy по умолчанию пропускает заметки с занятыми названиями
+func ParseImportConflictStrategy(value string) (ImportConflictStrategy, *ApplicationError) {
+	switch ImportConflictStrategy(value) {
+	case "":
+		return ImportConflictSkip, nil
+	case ImportConflictSkip, ImportConflictRename, ImportConflictOverwrite:
+		return ImportConflictStrategy(value), nil
+	}
+
+	return "", NewLocalizedError(ErrorTypeValidation, CodeImportStrategyUnknown, ErrorParams{"strategy": value}, nil)
+}
+
+func (j *ImportJob) SetId(id int) {
+	j.Id = id
+}
+
+func (j *ImportJob) GetId() int {
+	return j.Id
+}
+
+func (j *ImportJob) SetTimestamp() {
+	j.Timestamp = time.Now()
+}
+
+// AddResult учитывает результат очередного файла в счётчиках задачи
+func (j *ImportJob) AddResult(file *ImportJobFile) {
+	j.Processed++
+
+	switch file.Status {
+	case ImportFileStatusSkipped:
+		j.Skipped++
+	case ImportFileStatusFailed:
+		j.Failed++
+	default:
+		j.Imported++
+	}
+}
+
+// Finish завершает задачу. err - ошибка, из-за которой не удалось прочитать файл целиком.
+func (j *ImportJob) Finish(now time.Time, err *ApplicationError) {
+	j.Status = ImportJobStatusCompleted
+	if err != nil {
+		j.Status = ImportJobStatusFailed
+		j.Error = err.Message
+	}
+
+	j.FinishedAt = &now
+}
+
+func (f *ImportJobFile) SetId(id int) {
+	f.Id = id
+}
+
+func (f *ImportJobFile) GetId() int {
+	return f.Id
+}
+
+func (f *ImportJobFile) SetTimestamp() {
+	f.Timestamp = time.Now()
+}
+
+func NewImportedFile(jobId int, path string, status ImportFileStatus, noteId int) *ImportJobFile {
+	return &ImportJobFile{
+		JobId:  jobId,
+		Path:   path,
+		Status: status,
+		NoteId: &noteId,
+	}
+}
+
+func NewFailedImportFile(jobId int, path string, status ImportFileStatus, err *ApplicationError) *ImportJobFile {
+	return &ImportJobFile{
+		JobId:   jobId,
+		Path:    path,
+		Status:  status,
+		Code:    err.Code,
+		Message: err.Message,
+	}
+}
+
+// RenameImportedTitle подбирает свободное название вида "Название (2)"
+func RenameImportedTitle(title string, isFree func(title string) bool) string {
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s (%d)", title, i)
+		if isFree(candidate) {
+			return candidate
+		}
+	}
+}
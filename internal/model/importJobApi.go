@@ -0,0 +1,62 @@
+package model
+
+import "time"
+
+type ImportJobApi struct {
+	Id         int
+	Source     ImportSource
+	Strategy   ImportConflictStrategy
+	Status     ImportJobStatus
+	FileName   string `json:",omitempty"`
+	Total      int
+	Processed  int
+	Imported   int
+	Skipped    int
+	Failed     int
+	Error      string     `json:",omitempty"`
+	FinishedAt *time.Time `json:",omitempty"`
+	Files      []*ImportJobFileApi
+	Timestamp  time.Time
+}
+
+type ImportJobFileApi struct {
+	Path    string
+	Status  ImportFileStatus
+	NoteId  *int      `json:",omitempty"`
+	Code    ErrorCode `json:",omitempty"`
+	Message string    `json:",omitempty"`
+}
+
+func ToImportJobApi(job *ImportJob, files []*ImportJobFile) *ImportJobApi {
+	if job == nil {
+		return nil
+	}
+
+	filesApi := make([]*ImportJobFileApi, 0, len(files))
+	for _, file := range files {
+		filesApi = append(filesApi, &ImportJobFileApi{
+			Path:    file.Path,
+			Status:  file.Status,
+			NoteId:  file.NoteId,
+			Code:    file.Code,
+			Message: file.Message,
+		})
+	}
+
+	return &ImportJobApi{
+		Id:         job.Id,
+		Source:     job.Source,
+		Strategy:   job.Strategy,
+		Status:     job.Status,
+		FileName:   job.FileName,
+		Total:      job.Total,
+		Processed:  job.Processed,
+		Imported:   job.Imported,
+		Skipped:    job.Skipped,
+		Failed:     job.Failed,
+		Error:      job.Error,
+		FinishedAt: job.FinishedAt,
+		Files:      filesApi,
+		Timestamp:  job.Timestamp,
+	}
+}
@@ -0,0 +1,220 @@
+package model
+
+import (
+	"gopkg.in/yaml.v3"
+	"path"
+	"strings"
+	"time"
+)
+
+const MarkdownExtension = ".md"
+
+const frontMatterDelimiter = "---"
+
+// FolderPathSeparator разделяет вложенные каталоги в названии папки: папки в блокноте одноуровневые
+const FolderPathSeparator = " / "
+
+var frontMatterTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ParseMarkdownNote разбирает Markdown-файл с необязательным YAML-заголовком (front matter).
+// Из заголовка берутся title, tags, favorite и дата изменения (updated, modified, created или date).
+func ParseMarkdownNote(filePath string, data []byte) (*ImportedNote, *ApplicationError) {
+	text := strings.TrimPrefix(string(data), "\ufeff")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	note := &ImportedNote{
+		Path:   filePath,
+		Folder: folderTitleFromPath(path.Dir(filePath)),
+		Title:  strings.TrimSuffix(path.Base(filePath), path.Ext(filePath)),
+		Tags:   make([]string, 0),
+	}
+
+	frontMatter, body, found := splitFrontMatter(text)
+	note.Content = strings.TrimLeft(body, "\n")
+
+	if !found {
+		return note, nil
+	}
+
+	var fields map[string]any
+	if err := yaml.Unmarshal([]byte(frontMatter), &fields); err != nil {
+		return nil, NewLocalizedError(ErrorTypeValidation, CodeImportFrontMatterInvalid, ErrorParams{"path": filePath}, err)
+	}
+
+	if title, ok := fields["title"].(string); ok && strings.TrimSpace(title) != "" {
+		note.Title = strings.TrimSpace(title)
+	}
+
+	note.Tags = parseFrontMatterTags(fields["tags"])
+
+	for _, key := range []string{"favorite", "favourite", "starred"} {
+		if favorite, ok := fields[key].(bool); ok {
+			note.IsFavorite = favorite
+			break
+		}
+	}
+
+	for _, key := range []string{"updated", "modified", "created", "date"} {
+		if timestamp, ok := parseFrontMatterTime(fields[key]); ok {
+			note.Timestamp = &timestamp
+			break
+		}
+	}
+
+	return note, nil
+}
+
+// splitFrontMatter отделяет YAML-заголовок между строками "---" в начале файла от текста заметки
+func splitFrontMatter(text string) (string, string, bool) {
+	if !strings.HasPrefix(text, frontMatterDelimiter+"\n") {
+		return "", text, false
+	}
+
+	rest := "\n" + text[len(frontMatterDelimiter)+1:]
+	end := strings.Index(rest, "\n"+frontMatterDelimiter+"\n")
+	if end < 0 {
+		if !strings.HasSuffix(rest, "\n"+frontMatterDelimiter) {
+			return "", text, false
+		}
+		end = len(rest) - len(frontMatterDelimiter) - 1
+	}
+
+	bodyStart := min(end+len(frontMatterDelimiter)+2, len(rest))
+	return strings.TrimPrefix(rest[:end], "\n"), rest[bodyStart:], true
+}
+
+// parseFrontMatterTags принимает список или строку с тегами через запятую или пробел, "#" в начале отбрасывается
+func parseFrontMatterTags(value any) []string {
+	var raw []string
+
+	switch v := value.(type) {
+	case []any:
+		for _, item := range v {
+			if tag, ok := item.(string); ok {
+				raw = append(raw, tag)
+			}
+		}
+	case string:
+		raw = strings.FieldsFunc(v, func(r rune) bool {
+			return r == ',' || r == ' '
+		})
+	}
+
+	tags := make([]string, 0, len(raw))
+	for _, tag := range raw {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "#")
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+func parseFrontMatterTime(value any) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case string:
+		for _, layout := range frontMatterTimeLayouts {
+			if timestamp, err := time.Parse(layout, strings.TrimSpace(v)); err == nil {
+				return timestamp, true
+			}
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func folderTitleFromPath(dir string) string {
+	if dir == "." || dir == "/" || dir == "" {
+		return ""
+	}
+
+	return strings.Join(strings.Split(strings.Trim(dir, "/"), "/"), FolderPathSeparator)
+}
+
+// markdownFrontMatter - YAML-заголовок выгружаемой заметки в том виде, в котором его читает ParseMarkdownNote
+type markdownFrontMatter struct {
+	Title    string   `yaml:"title"`
+	Tags     []string `yaml:"tags,omitempty"`
+	Favorite bool     `yaml:"favorite,omitempty"`
+	Updated  string   `yaml:"updated"`
+}
+
+// RenderMarkdownNote возвращает текст заметки с YAML-заголовком. Пункты списка выгружаются как задачи Markdown.
+func RenderMarkdownNote(note *ExportNote) ([]byte, *ApplicationError) {
+	frontMatter, err := yaml.Marshal(&markdownFrontMatter{
+		Title:    note.Title,
+		Tags:     note.Tags,
+		Favorite: note.IsFavorite,
+		Updated:  note.Timestamp.Format(time.RFC3339),
+	})
+	if err != nil {
+		return nil, NewLocalizedError(ErrorTypeInternal, CodeInternalError, nil, err)
+	}
+
+	content := note.Content
+	if note.Items != nil {
+		lines := make([]string, 0, len(note.Items))
+		for _, item := range note.Items {
+			mark := " "
+			if item.IsChecked {
+				mark = "x"
+			}
+			lines = append(lines, "- ["+mark+"] "+item.Text)
+		}
+		content = strings.Join(lines, "\n")
+	}
+
+	var builder strings.Builder
+	builder.WriteString(frontMatterDelimiter + "\n")
+	builder.Write(frontMatter)
+	builder.WriteString(frontMatterDelimiter + "\n\n")
+	builder.WriteString(content)
+	builder.WriteString("\n")
+
+	return []byte(builder.String()), nil
+}
+
+// MarkdownFolderPath переводит название папки в путь каталога, обратно к folderTitleFromPath
+func MarkdownFolderPath(folder string) string {
+	if folder == "" {
+		return ""
+	}
+
+	segments := strings.Split(folder, FolderPathSeparator)
+	for i, segment := range segments {
+		segments[i] = markdownFileName(segment)
+	}
+
+	return path.Join(segments...)
+}
+
+// MarkdownFilePath возвращает путь файла заметки без расширения. Символы, недопустимые
+// в именах файлов, заменяются на "_": исходное название сохраняется в заголовке.
+func MarkdownFilePath(folder string, title string) string {
+	return path.Join(MarkdownFolderPath(folder), markdownFileName(title))
+}
+
+func markdownFileName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`/\:*?"<>|`, r) || r < ' ' {
+			return '_'
+		}
+		return r
+	}, name)
+
+	name = strings.Trim(name, " .")
+	if name == "" {
+		return "_"
+	}
+
+	return name
+}
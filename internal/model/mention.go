@@ -0,0 +1,94 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+)
+
+// MentionBodyLength - сколько символов текста вокруг упоминания попадает в уведомление
+const MentionBodyLength = 200
+
+// mentionPattern находит @login. Перед @ не должно быть буквы или цифры, чтобы адреса почты
+// не считались упоминаниями.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_@.])@([\p{L}\p{N}_.\-]+)`)
+
+// ParseMentions возвращает логины, упомянутые в тексте, без повторов и в порядке появления
+func ParseMentions(content string) []string {
+	logins := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for _, match := range mentionPattern.FindAllStringSubmatch(content, -1) {
+		// Точка или дефис в конце - это знак препинания после упоминания, а не часть логина
+		login := strings.TrimRight(match[1], ".-")
+		if login == "" || seen[login] {
+			continue
+		}
+
+		seen[login] = true
+		logins = append(logins, login)
+	}
+	return logins
+}
+
+// NewMentions возвращает логины, которые упомянуты в after, но не в before.
+// Так правка текста не уведомляет повторно тех, кто уже был упомянут.
+func NewMentions(before string, after string) []string {
+	previous := make(map[string]bool)
+	for _, login := range ParseMentions(before) {
+		previous[login] = true
+	}
+
+	logins := make([]string, 0)
+	for _, login := range ParseMentions(after) {
+		if !previous[login] {
+			logins = append(logins, login)
+		}
+	}
+	return logins
+}
+
+// NewMentionNotification создаёт уведомление для userId об упоминании login в заметке или, если comment
+// не nil, в комментарии к ней. В текст уведомления попадает фрагмент вокруг упоминания.
+func NewMentionNotification(userId int, login string, actorId int, note *Note, comment *Comment) *Notification {
+	noteId := note.Id
+	notification := &Notification{
+		UserId:  userId,
+		Type:    NotificationTypeMention,
+		Title:   note.Title,
+		NoteId:  &noteId,
+		ActorId: &actorId,
+	}
+
+	text := note.Content
+	if comment != nil {
+		commentId := comment.Id
+		notification.CommentId = &commentId
+		text = comment.Content
+	}
+
+	notification.Body = mentionExcerpt(text, login)
+	return notification
+}
+
+// mentionExcerpt возвращает не больше MentionBodyLength символов текста, начиная чуть раньше упоминания
+func mentionExcerpt(text string, login string) string {
+	runes := []rune(text)
+	if len(runes) <= MentionBodyLength {
+		return text
+	}
+
+	start := 0
+	if index := strings.Index(text, "@"+login); index > 0 {
+		start = max(len([]rune(text[:index]))-MentionBodyLength/4, 0)
+	}
+	end := min(start+MentionBodyLength, len(runes))
+
+	excerpt := strings.TrimSpace(string(runes[start:end]))
+	if start > 0 {
+		excerpt = "…" + excerpt
+	}
+	if end < len(runes) {
+		excerpt += "…"
+	}
+	return excerpt
+}
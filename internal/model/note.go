@@ -2,11 +2,17 @@ package model
 
 import (
 	"Notes/internal/constants"
-	"fmt"
 	"github.com/lib/pq"
 	"time"
 )
 
+type NoteType string
+
+const (
+	NoteTypeText      NoteType = "text"
+	NoteTypeChecklist NoteType = "checklist"
+)
+
 type Note struct {
 	Id         int
 	Title      string
@@ -14,8 +20,15 @@ type Note struct {
 	UserId     int
 	IsFavorite bool
 	Timestamp  time.Time
-	Tags       pq.StringArray `gorm:"type:text[]"`
+	Tags       pq.StringArray `gorm:"type:text[]" swaggertype:"array,string"`
 	FolderId   *int
+	Type       NoteType `gorm:"default:text"`
+	IsPinned   bool
+	Position   string
+	IsArchived bool
+	Version    int
+	ChangeSeq  int64
+	ClientId   *string
 }
 
 func (n *Note) SetId(id int) {
@@ -30,6 +43,32 @@ func (n *Note) SetTimestamp() {
 	n.Timestamp = time.Now()
 }
 
+func (n *Note) GetUserId() int {
+	return n.UserId
+}
+
+func (n *Note) GetSyncEntity() SyncEntity {
+	return SyncEntityNote
+}
+
+func (n *Note) SetChange(changeSeq int64) {
+	n.Version++
+	n.ChangeSeq = changeSeq
+}
+
+func (n *Note) IsChecklist() bool {
+	return n.Type == NoteTypeChecklist
+}
+
+func ParseNoteType(value string) (NoteType, *ApplicationError) {
+	switch NoteType(value) {
+	case NoteTypeText, NoteTypeChecklist:
+		return NoteType(value), nil
+	}
+
+	return "", NewLocalizedError(ErrorTypeValidation, CodeNoteTypeUnknown, ErrorParams{"type": value}, nil)
+}
+
 func NewNote(title string, content string, userId int, tags *[]string) (*Note, *ApplicationError) {
 	validationError := validateNote(title, content, tags)
 
@@ -48,29 +87,17 @@ func NewNote(title string, content string, userId int, tags *[]string) (*Note, *
 }
 
 func validateNote(title string, content string, tags *[]string) *ApplicationError {
-	titleValidationError := validateTitle(title)
-	if titleValidationError != nil {
-		return titleValidationError
-	}
-
-	contentValidationError := validateContent(content)
+	var errs validationErrors
+	errs.add("Title", validateTitle(title))
+	errs.add("Content", validateContent(content))
+	errs.add("Tags", validateTags(tags))
 
-	if contentValidationError != nil {
-		return contentValidationError
-	}
-
-	tagsValidationError := validateTags(tags)
-
-	if tagsValidationError != nil {
-		return tagsValidationError
-	}
-
-	return nil
+	return errs.toError()
 }
 
 func validateTitle(title string) *ApplicationError {
 	if len(title) == 0 {
-		return NewApplicationError(ErrorTypeValidation, "Название заметки не может быть пустым", nil)
+		return NewLocalizedError(ErrorTypeValidation, CodeNoteTitleEmpty, nil, nil)
 	}
 
 	return nil
@@ -78,12 +105,11 @@ func validateTitle(title string) *ApplicationError {
 
 func validateContent(content string) *ApplicationError {
 	if len(content) == 0 {
-		return NewApplicationError(ErrorTypeValidation, "Заметка не может быть пустой", nil)
+		return NewLocalizedError(ErrorTypeValidation, CodeNoteContentEmpty, nil, nil)
 	}
 
 	if len(content) > constants.MaxContentLength {
-		message := fmt.Sprintf("Длина заметки не может превышать %d символов", constants.MaxContentLength)
-		return NewApplicationError(ErrorTypeValidation, message, nil)
+		return NewLocalizedError(ErrorTypeValidation, CodeNoteContentTooLong, ErrorParams{"max": constants.MaxContentLength}, nil)
 	}
 
 	return nil
@@ -95,8 +121,7 @@ func validateTags(tags *[]string) *ApplicationError {
 	}
 
 	if len(*tags) > constants.MaxTagsCount {
-		message := fmt.Sprintf("Нельзя добавить больше, чем %d тегов к заметке.", constants.MaxTagsCount)
-		return NewApplicationError(ErrorTypeValidation, message, nil)
+		return NewLocalizedError(ErrorTypeValidation, CodeNoteTooManyTags, ErrorParams{"max": constants.MaxTagsCount}, nil)
 	}
 
 	return nil
@@ -5,14 +5,30 @@ import (
 )
 
 type NoteApi struct {
-	Id         int
-	Title      string
-	Content    string
-	UserId     int `json:"-"`
-	IsFavorite bool
-	Timestamp  time.Time
-	Tags       []string
-	FolderId   *int `json:"-"`
+	Id           int
+	Title        string
+	Content      string
+	UserId       int `json:"-"`
+	IsFavorite   bool
+	Timestamp    time.Time
+	Tags         []string
+	FolderId     *int                `json:"-"`
+	ThumbnailUrl *string             `json:",omitempty"`
+	Type         NoteType            `json:",omitempty"`
+	Items        []*ChecklistItemApi `json:",omitempty"`
+	Completion   *int                `json:",omitempty"`
+	IsPinned     bool
+	Position     string
+	IsArchived   bool `json:",omitempty"`
+	CommentCount int
+	Lock         *NoteLockApi `json:",omitempty"`
+}
+
+type ChecklistItemApi struct {
+	Id        int
+	Text      string
+	IsChecked bool
+	Position  int
 }
 
 func ToNoteApi(dbNote *Note) *NoteApi {
@@ -27,6 +43,10 @@ func ToNoteApi(dbNote *Note) *NoteApi {
 		Timestamp:  dbNote.Timestamp,
 		Tags:       dbNote.Tags,
 		FolderId:   dbNote.FolderId,
+		Type:       dbNote.Type,
+		IsPinned:   dbNote.IsPinned,
+		Position:   dbNote.Position,
+		IsArchived: dbNote.IsArchived,
 	}
 }
 
@@ -41,7 +61,62 @@ func ToNotesApi(dbNotes []*Note) []*NoteApi {
 			Timestamp:  dbNotes[i].Timestamp,
 			Tags:       dbNotes[i].Tags,
 			FolderId:   dbNotes[i].FolderId,
+			Type:       dbNotes[i].Type,
+			IsPinned:   dbNotes[i].IsPinned,
+			Position:   dbNotes[i].Position,
+			IsArchived: dbNotes[i].IsArchived,
 		})
 	}
 	return notes
 }
+
+// SetPreviews заполняет ThumbnailUrl заметок миниатюрой первого вложения-изображения.
+// Вложения должны быть упорядочены по id.
+func SetPreviews(notes []*NoteApi, attachments []*Attachment) {
+	previews := make(map[int]string)
+	for _, attachment := range attachments {
+		if _, exists := previews[attachment.NoteId]; exists || attachment.ThumbnailStatus != ThumbnailStatusReady {
+			continue
+		}
+		previews[attachment.NoteId] = GetThumbnailUrl(attachment.NoteId, attachment.Id)
+	}
+
+	for _, note := range notes {
+		if url, exists := previews[note.Id]; exists {
+			note.ThumbnailUrl = &url
+		}
+	}
+}
+
+// SetChecklists заполняет пункты и процент выполнения заметок-списков. Пункты должны быть упорядочены по позиции.
+func SetChecklists(notes []*NoteApi, items []*ChecklistItem) {
+	itemsByNote := make(map[int][]*ChecklistItem)
+	for _, item := range items {
+		itemsByNote[item.NoteId] = append(itemsByNote[item.NoteId], item)
+	}
+
+	for _, note := range notes {
+		if note.Type != NoteTypeChecklist {
+			continue
+		}
+
+		noteItems := itemsByNote[note.Id]
+		completion := GetChecklistCompletion(noteItems)
+		note.Items = ToChecklistItemsApi(noteItems)
+		note.Completion = &completion
+	}
+}
+
+func ToChecklistItemsApi(dbItems []*ChecklistItem) []*ChecklistItemApi {
+	items := make([]*ChecklistItemApi, 0, len(dbItems))
+	for i := range dbItems {
+		items = append(items, &ChecklistItemApi{
+			Id:        dbItems[i].Id,
+			Text:      dbItems[i].Text,
+			IsChecked: dbItems[i].IsChecked,
+			Position:  dbItems[i].Position,
+		})
+	}
+
+	return items
+}
@@ -0,0 +1,138 @@
+package model
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type NoteLinkKind string
+
+const (
+	NoteLinkKindTitle NoteLinkKind = "title"
+	NoteLinkKindId    NoteLinkKind = "id"
+)
+
+const noteLinkIdPrefix = "note:"
+
+// wikiLinkPattern находит ссылки вида [[Название]], [[Название|подпись]] и [[note:123]]
+var wikiLinkPattern = regexp.MustCompile(`\[\[([^\[\]\n]+?)\]\]`)
+
+// NoteLink - ссылка из текста заметки. Хранится в исходном виде и разрешается при чтении,
+// поэтому ссылка на ещё не созданную заметку начнёт работать, как только заметка появится.
+type NoteLink struct {
+	Id           int
+	SourceNoteId int
+	UserId       int
+	Kind         NoteLinkKind
+	TargetNoteId *int
+	TargetTitle  string
+	Timestamp    time.Time
+}
+
+// ParseNoteLinks извлекает уникальные ссылки из текста заметки
+func ParseNoteLinks(sourceNoteId int, userId int, content string) []*NoteLink {
+	links := make([]*NoteLink, 0)
+	seen := make(map[string]bool)
+
+	for _, match := range wikiLinkPattern.FindAllStringSubmatch(content, -1) {
+		target, _ := splitWikiLink(match[1])
+		if target == "" || seen[target] {
+			continue
+		}
+		seen[target] = true
+
+		link := &NoteLink{
+			SourceNoteId: sourceNoteId,
+			UserId:       userId,
+			Kind:         NoteLinkKindTitle,
+			TargetTitle:  target,
+		}
+
+		if idValue, found := strings.CutPrefix(target, noteLinkIdPrefix); found {
+			if id, err := strconv.Atoi(idValue); err == nil {
+				link.Kind = NoteLinkKindId
+				link.TargetNoteId = &id
+				link.TargetTitle = ""
+			}
+		}
+
+		links = append(links, link)
+	}
+
+	return links
+}
+
+// RewriteTitleLinks заменяет ссылки на заметку oldTitle ссылками на newTitle, сохраняя подписи
+func RewriteTitleLinks(content string, oldTitle string, newTitle string) string {
+	return wikiLinkPattern.ReplaceAllStringFunc(content, func(match string) string {
+		target, label := splitWikiLink(match[2 : len(match)-2])
+		if target != oldTitle {
+			return match
+		}
+
+		if label != "" {
+			return "[[" + newTitle + "|" + label + "]]"
+		}
+		return "[[" + newTitle + "]]"
+	})
+}
+
+// RenameLinkTarget переписывает ссылки на заметку oldTitle в тексте заметки. Возвращает false,
+// если текст не изменился, и ошибку валидации, если новый текст превышает допустимую длину.
+func (n *Note) RenameLinkTarget(oldTitle string, newTitle string) (bool, *ApplicationError) {
+	content := RewriteTitleLinks(n.Content, oldTitle, newTitle)
+	if content == n.Content {
+		return false, nil
+	}
+
+	if err := validateContent(content); err != nil {
+		return false, err
+	}
+
+	n.Content = content
+	return true, nil
+}
+
+// Resolve возвращает id заметки, на которую указывает ссылка, или false для висячей ссылки
+func (l *NoteLink) Resolve(notesById map[int]*Note, notesByTitle map[string]*Note) (int, bool) {
+	if l.Kind == NoteLinkKindId {
+		if l.TargetNoteId == nil {
+			return 0, false
+		}
+		_, exists := notesById[*l.TargetNoteId]
+		return *l.TargetNoteId, exists
+	}
+
+	note, exists := notesByTitle[l.TargetTitle]
+	if !exists {
+		return 0, false
+	}
+	return note.Id, true
+}
+
+// Target возвращает ссылку в том виде, в котором она записана в тексте
+func (l *NoteLink) Target() string {
+	if l.Kind == NoteLinkKindId && l.TargetNoteId != nil {
+		return noteLinkIdPrefix + strconv.Itoa(*l.TargetNoteId)
+	}
+	return l.TargetTitle
+}
+
+func (l *NoteLink) SetId(id int) {
+	l.Id = id
+}
+
+func (l *NoteLink) GetId() int {
+	return l.Id
+}
+
+func (l *NoteLink) SetTimestamp() {
+	l.Timestamp = time.Now()
+}
+
+func splitWikiLink(value string) (string, string) {
+	target, label, _ := strings.Cut(value, "|")
+	return strings.TrimSpace(target), strings.TrimSpace(label)
+}
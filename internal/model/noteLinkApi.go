@@ -0,0 +1,31 @@
+package model
+
+type BacklinkApi struct {
+	NoteId     int
+	Title      string
+	IsArchived bool `json:",omitempty"`
+}
+
+type GraphApi struct {
+	Nodes    []*GraphNodeApi
+	Edges    []*GraphEdgeApi
+	Dangling []*DanglingLinkApi
+}
+
+type GraphNodeApi struct {
+	Id         int
+	Title      string
+	FolderId   *int `json:",omitempty"`
+	IsArchived bool `json:",omitempty"`
+}
+
+type GraphEdgeApi struct {
+	Source int
+	Target int
+}
+
+// DanglingLinkApi - ссылка на несуществующую заметку
+type DanglingLinkApi struct {
+	Source int
+	Target string
+}
@@ -0,0 +1,53 @@
+package model
+
+import "time"
+
+// NoteLock - аренда права изменять заметку. Пока аренда не истекла, заметку может изменить только клиент,
+// получивший Token. Истёкшая аренда считается свободной и может быть взята другим клиентом.
+type NoteLock struct {
+	NoteId    int `gorm:"primaryKey"`
+	UserId    int
+	Token     string
+	ExpiresAt time.Time
+}
+
+// NoteLockApi - держатель аренды в заметке. Token не показывается: он есть только у держателя.
+type NoteLockApi struct {
+	UserId    int
+	ExpiresAt time.Time
+}
+
+// NoteLockGrantApi - выданная или продлённая аренда. Token передаётся в заголовке X-Lock-Token
+// при изменении заметки, продлении и снятии аренды.
+type NoteLockGrantApi struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+func NewNoteLock(noteId int, userId int, token string, expiresAt time.Time) *NoteLock {
+	return &NoteLock{
+		NoteId:    noteId,
+		UserId:    userId,
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}
+}
+
+// LockedError сообщает, что заметку изменяет держатель аренды
+func (l *NoteLock) LockedError() *ApplicationError {
+	params := ErrorParams{"userId": l.UserId, "expiresAt": l.ExpiresAt.UTC().Format(time.RFC3339)}
+	return NewLocalizedError(ErrorTypeLocked, CodeNoteLocked, params, nil)
+}
+
+func ToNoteLockApi(lock *NoteLock) *NoteLockApi {
+	if lock == nil {
+		return nil
+	}
+	return &NoteLockApi{UserId: lock.UserId, ExpiresAt: lock.ExpiresAt}
+}
+
+func SetNoteLocks(notes []*NoteApi, locks map[int]*NoteLock) {
+	for _, note := range notes {
+		note.Lock = ToNoteLockApi(locks[note.Id])
+	}
+}
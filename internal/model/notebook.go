@@ -3,6 +3,9 @@ package model
 // Notebook represents the notebook API response
 // @Description Notebook information
 type Notebook struct {
-	Folders []FolderApi `json:"folders"`
-	Notes   []NoteApi   `json:"notes"`
+	Folders  []FolderApi `json:"folders"`
+	Notes    []NoteApi   `json:"notes"`
+	Page     int         `json:"page,omitempty"`
+	PageSize int         `json:"pageSize,omitempty"`
+	HasMore  bool        `json:"hasMore,omitempty"`
 }
@@ -0,0 +1,46 @@
+package model
+
+import "time"
+
+type NotificationType string
+
+const (
+	NotificationTypeReminder NotificationType = "reminder"
+	NotificationTypeMention  NotificationType = "mention"
+)
+
+// NotificationTypes - типы уведомлений, которые пользователь может отключить в настройках
+var NotificationTypes = []NotificationType{NotificationTypeReminder, NotificationTypeMention}
+
+// Notification - уведомление во входящих пользователя. ActorId - кто вызвал уведомление,
+// CommentId заполнен для упоминаний в комментариях.
+type Notification struct {
+	Id        int
+	UserId    int
+	Type      NotificationType
+	Title     string
+	Body      string
+	NoteId    *int
+	CommentId *int
+	ActorId   *int
+	IsRead    bool
+	Timestamp time.Time
+}
+
+// NotificationTarget - адреса доставки для каналов, которым недостаточно идентификатора пользователя
+type NotificationTarget struct {
+	Email      *string
+	WebhookUrl *string
+}
+
+func (n *Notification) SetId(id int) {
+	n.Id = id
+}
+
+func (n *Notification) GetId() int {
+	return n.Id
+}
+
+func (n *Notification) SetTimestamp() {
+	n.Timestamp = time.Now()
+}
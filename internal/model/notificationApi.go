@@ -0,0 +1,48 @@
+package model
+
+import "time"
+
+type NotificationApi struct {
+	Id        int
+	Type      NotificationType
+	Title     string
+	Body      string
+	NoteId    *int `json:",omitempty"`
+	CommentId *int `json:",omitempty"`
+	ActorId   *int `json:",omitempty"`
+	IsRead    bool
+	Timestamp time.Time
+}
+
+// NotificationsApi - страница входящих, новые первыми. NextBeforeId передаётся в следующий запрос,
+// пока он не пуст.
+type NotificationsApi struct {
+	Notifications []*NotificationApi
+	NextBeforeId  *int `json:",omitempty"`
+}
+
+type UnreadNotificationsApi struct {
+	Count int
+}
+
+func ToNotificationApi(notification *Notification) *NotificationApi {
+	return &NotificationApi{
+		Id:        notification.Id,
+		Type:      notification.Type,
+		Title:     notification.Title,
+		Body:      notification.Body,
+		NoteId:    notification.NoteId,
+		CommentId: notification.CommentId,
+		ActorId:   notification.ActorId,
+		IsRead:    notification.IsRead,
+		Timestamp: notification.Timestamp,
+	}
+}
+
+func ToNotificationsApi(notifications []*Notification) []*NotificationApi {
+	result := make([]*NotificationApi, 0, len(notifications))
+	for _, notification := range notifications {
+		result = append(result, ToNotificationApi(notification))
+	}
+	return result
+}
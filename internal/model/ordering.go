@@ -0,0 +1,92 @@
+package model
+
+import "sort"
+
+// SortNotes упорядочивает заметки так, как они показываются в папке:
+// сначала закреплённые, затем по позиции. Позиции - ключи дробной индексации, сравниваются как строки.
+func SortNotes(notes []*Note) {
+	sort.SliceStable(notes, func(i, j int) bool {
+		return isOrderedBefore(notes[i].IsPinned, notes[i].Position, notes[i].Id, notes[j].IsPinned, notes[j].Position, notes[j].Id)
+	})
+}
+
+func SortNotesApi(notes []*NoteApi) {
+	sort.SliceStable(notes, func(i, j int) bool {
+		return isOrderedBefore(notes[i].IsPinned, notes[i].Position, notes[i].Id, notes[j].IsPinned, notes[j].Position, notes[j].Id)
+	})
+}
+
+func SortFolders(folders []*Folder) {
+	sort.SliceStable(folders, func(i, j int) bool {
+		return isOrderedBefore(false, folders[i].Position, folders[i].Id, false, folders[j].Position, folders[j].Id)
+	})
+}
+
+func SortFoldersApi(folders []*FolderApi) {
+	sort.SliceStable(folders, func(i, j int) bool {
+		return isOrderedBefore(false, folders[i].Position, folders[i].Id, false, folders[j].Position, folders[j].Id)
+	})
+}
+
+// SortNotesApiBy упорядочивает заметки по выбранному пользователем порядку.
+// Закреплённые заметки при любом порядке остаются первыми.
+func SortNotesApiBy(notes []*NoteApi, order SortOrder) {
+	switch order {
+	case SortOrderTitle:
+		sort.SliceStable(notes, func(i, j int) bool {
+			if notes[i].IsPinned != notes[j].IsPinned {
+				return notes[i].IsPinned
+			}
+			if notes[i].Title != notes[j].Title {
+				return notes[i].Title < notes[j].Title
+			}
+			return notes[i].Id < notes[j].Id
+		})
+	case SortOrderUpdated:
+		sort.SliceStable(notes, func(i, j int) bool {
+			if notes[i].IsPinned != notes[j].IsPinned {
+				return notes[i].IsPinned
+			}
+			if !notes[i].Timestamp.Equal(notes[j].Timestamp) {
+				return notes[i].Timestamp.After(notes[j].Timestamp)
+			}
+			return notes[i].Id < notes[j].Id
+		})
+	default:
+		SortNotesApi(notes)
+	}
+}
+
+func SortFoldersApiBy(folders []*FolderApi, order SortOrder) {
+	switch order {
+	case SortOrderTitle:
+		sort.SliceStable(folders, func(i, j int) bool {
+			if folders[i].Title != folders[j].Title {
+				return folders[i].Title < folders[j].Title
+			}
+			return folders[i].Id < folders[j].Id
+		})
+	case SortOrderUpdated:
+		sort.SliceStable(folders, func(i, j int) bool {
+			if !folders[i].Timestamp.Equal(folders[j].Timestamp) {
+				return folders[i].Timestamp.After(folders[j].Timestamp)
+			}
+			return folders[i].Id < folders[j].Id
+		})
+	default:
+		SortFoldersApi(folders)
+	}
+}
+
+func isOrderedBefore(pinnedA bool, positionA string, idA int, pinnedB bool, positionB string, idB int) bool {
+	if pinnedA != pinnedB {
+		return pinnedA
+	}
+
+	if positionA != positionB {
+		return positionA < positionB
+	}
+
+	// Одинаковые позиции возможны при одновременной вставке, порядок между ними определяет id
+	return idA < idB
+}
@@ -0,0 +1,179 @@
+package model
+
+import (
+	"github.com/lib/pq"
+	"slices"
+	"time"
+)
+
+type Locale string
+
+const (
+	LocaleRu Locale = "ru"
+	LocaleEn Locale = "en"
+)
+
+type SortOrder string
+
+const (
+	// SortOrderManual - закреплённые заметки первыми, затем ручной порядок
+	SortOrderManual  SortOrder = "manual"
+	SortOrderTitle   SortOrder = "title"
+	SortOrderUpdated SortOrder = "updated"
+)
+
+type EditorFormat string
+
+const (
+	EditorFormatMarkdown EditorFormat = "markdown"
+	EditorFormatPlain    EditorFormat = "plain"
+)
+
+const MaxNotebookPageSize = 100
+
+// Preferences - настройки пользователя. Часовой пояс хранится в User, остальные настройки здесь.
+// PageSize = 0 означает, что блокнот возвращается целиком.
+// MutedNotifications - типы уведомлений, которые не попадают во входящие.
+type Preferences struct {
+	Id                 int
+	UserId             int
+	Locale             Locale
+	SortOrder          SortOrder
+	DefaultFolderId    *int
+	PageSize           int
+	EditorFormat       EditorFormat
+	MutedNotifications pq.StringArray `gorm:"type:text[]"`
+	Timestamp          time.Time
+}
+
+// PreferencesPatch - частичное изменение настроек: nil означает, что значение не меняется.
+// DefaultFolderId = 0 сбрасывает папку по умолчанию. В Notifications передаются только
+// типы уведомлений, которые нужно включить или отключить.
+type PreferencesPatch struct {
+	Timezone        *string
+	Locale          *string
+	SortOrder       *string
+	DefaultFolderId *int
+	PageSize        *int
+	EditorFormat    *string
+	Notifications   map[string]bool
+}
+
+type PreferencesApi struct {
+	Timezone        string
+	Locale          Locale
+	SortOrder       SortOrder
+	DefaultFolderId *int
+	PageSize        int
+	EditorFormat    EditorFormat
+	Notifications   map[NotificationType]bool
+}
+
+func DefaultPreferences(userId int) *Preferences {
+	return &Preferences{
+		UserId:             userId,
+		Locale:             LocaleRu,
+		SortOrder:          SortOrderManual,
+		PageSize:           0,
+		EditorFormat:       EditorFormatMarkdown,
+		MutedNotifications: pq.StringArray{},
+	}
+}
+
+// IsNotificationEnabled сообщает, нужно ли класть уведомления этого типа во входящие
+func (p *Preferences) IsNotificationEnabled(notificationType NotificationType) bool {
+	return !slices.Contains(p.MutedNotifications, string(notificationType))
+}
+
+func (p *Preferences) SetId(id int) {
+	p.Id = id
+}
+
+func (p *Preferences) GetId() int {
+	return p.Id
+}
+
+func (p *Preferences) SetTimestamp() {
+	p.Timestamp = time.Now()
+}
+
+// Apply проверяет и применяет изменения, кроме часового пояса и папки по умолчанию:
+// их проверка требует обращения к другим сущностям
+func (p *Preferences) Apply(patch PreferencesPatch) *ApplicationError {
+	if patch.Locale != nil {
+		switch Locale(*patch.Locale) {
+		case LocaleRu, LocaleEn:
+			p.Locale = Locale(*patch.Locale)
+		default:
+			return NewLocalizedError(ErrorTypeValidation, CodeLocaleUnsupported, ErrorParams{"locale": *patch.Locale}, nil)
+		}
+	}
+
+	if patch.SortOrder != nil {
+		switch SortOrder(*patch.SortOrder) {
+		case SortOrderManual, SortOrderTitle, SortOrderUpdated:
+			p.SortOrder = SortOrder(*patch.SortOrder)
+		default:
+			return NewLocalizedError(ErrorTypeValidation, CodeSortOrderUnknown, ErrorParams{"sortOrder": *patch.SortOrder}, nil)
+		}
+	}
+
+	if patch.PageSize != nil {
+		if *patch.PageSize < 0 || *patch.PageSize > MaxNotebookPageSize {
+			return NewLocalizedError(ErrorTypeValidation, CodePageSizeOutOfRange, ErrorParams{"max": MaxNotebookPageSize}, nil)
+		}
+		p.PageSize = *patch.PageSize
+	}
+
+	if patch.EditorFormat != nil {
+		switch EditorFormat(*patch.EditorFormat) {
+		case EditorFormatMarkdown, EditorFormatPlain:
+			p.EditorFormat = EditorFormat(*patch.EditorFormat)
+		default:
+			return NewLocalizedError(ErrorTypeValidation, CodeEditorFormatUnknown, ErrorParams{"format": *patch.EditorFormat}, nil)
+		}
+	}
+
+	for notificationType := range patch.Notifications {
+		if !slices.Contains(NotificationTypes, NotificationType(notificationType)) {
+			return NewLocalizedError(ErrorTypeValidation, CodeNotificationTypeUnknown, ErrorParams{"type": notificationType}, nil)
+		}
+	}
+
+	if len(patch.Notifications) == 0 {
+		return nil
+	}
+
+	// Порядок отключённых типов не зависит от порядка ключей в запросе
+	muted := make(pq.StringArray, 0, len(NotificationTypes))
+	for _, notificationType := range NotificationTypes {
+		isEnabled, changed := patch.Notifications[string(notificationType)]
+		if !changed {
+			isEnabled = p.IsNotificationEnabled(notificationType)
+		}
+
+		if !isEnabled {
+			muted = append(muted, string(notificationType))
+		}
+	}
+	p.MutedNotifications = muted
+
+	return nil
+}
+
+func ToPreferencesApi(preferences *Preferences, user *User) *PreferencesApi {
+	notifications := make(map[NotificationType]bool, len(NotificationTypes))
+	for _, notificationType := range NotificationTypes {
+		notifications[notificationType] = preferences.IsNotificationEnabled(notificationType)
+	}
+
+	return &PreferencesApi{
+		Timezone:        user.Location().String(),
+		Locale:          preferences.Locale,
+		SortOrder:       preferences.SortOrder,
+		DefaultFolderId: preferences.DefaultFolderId,
+		PageSize:        preferences.PageSize,
+		EditorFormat:    preferences.EditorFormat,
+		Notifications:   notifications,
+	}
+}
@@ -0,0 +1,61 @@
+package model
+
+import (
+	"net/http"
+	"strings"
+)
+
+const ProblemContentType = "application/problem+json"
+
+// Problem - описание ошибки в формате RFC 7807. Code и Details - расширения формата
+// со стабильным кодом ошибки и параметрами сообщения.
+type Problem struct {
+	Type     string          `json:"type" example:"/problems/note-title-taken"`
+	Title    string          `json:"title" example:"Conflict"`
+	Status   int             `json:"status" example:"409"`
+	Detail   string          `json:"detail" example:"Заметка с таким названием уже добавлена"`
+	Instance string          `json:"instance,omitempty" example:"urn:uuid:3f2b8c1e-6d0a-4a8e-9a63-0c9f5d0b7e21"`
+	Code     ErrorCode       `json:"code" example:"NOTE_TITLE_TAKEN"`
+	Details  ErrorParams     `json:"details,omitempty"`
+	Errors   []*ProblemField `json:"errors,omitempty"`
+}
+
+// ProblemField - ошибка отдельного поля запроса
+type ProblemField struct {
+	Field   string      `json:"field" example:"Title"`
+	Code    ErrorCode   `json:"code" example:"NOTE_TITLE_EMPTY"`
+	Message string      `json:"message" example:"Название заметки не может быть пустым"`
+	Details ErrorParams `json:"details,omitempty"`
+}
+
+// NewProblem формирует ответ с ошибкой на выбранном языке. traceId попадает в instance,
+// чтобы ответ можно было сопоставить с записью в журнале запросов.
+func NewProblem(apiError *ApiError, locale Locale, traceId string) *Problem {
+	problem := &Problem{
+		Type:    problemType(apiError.ErrorCode),
+		Title:   http.StatusText(apiError.Code),
+		Status:  apiError.Code,
+		Detail:  apiError.LocalizedMessage(locale),
+		Code:    apiError.ErrorCode,
+		Details: apiError.Params,
+	}
+
+	if traceId != "" {
+		problem.Instance = "urn:uuid:" + traceId
+	}
+
+	for _, field := range apiError.Fields {
+		problem.Errors = append(problem.Errors, &ProblemField{
+			Field:   field.Field,
+			Code:    field.Code,
+			Message: field.LocalizedMessage(locale),
+			Details: field.Params,
+		})
+	}
+
+	return problem
+}
+
+func problemType(code ErrorCode) string {
+	return "/problems/" + strings.ReplaceAll(strings.ToLower(string(code)), "_", "-")
+}
@@ -0,0 +1,319 @@
+package model
+
+import (
+	"fmt"
+	"github.com/lib/pq"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type ReminderChannel string
+
+const (
+	ReminderChannelInbox   ReminderChannel = "inbox"
+	ReminderChannelEmail   ReminderChannel = "email"
+	ReminderChannelWebhook ReminderChannel = "webhook"
+)
+
+type Frequency string
+
+const (
+	FrequencyDaily   Frequency = "DAILY"
+	FrequencyWeekly  Frequency = "WEEKLY"
+	FrequencyMonthly Frequency = "MONTHLY"
+)
+
+const rruleUntilLayout = "20060102T150405Z"
+
+// Recurrence - поддерживаемое подмножество RRULE (RFC 5545): FREQ, INTERVAL, COUNT, UNTIL
+type Recurrence struct {
+	Frequency Frequency
+	Interval  int
+	Count     int
+	Until     *time.Time
+}
+
+type Reminder struct {
+	Id          int
+	NoteId      int
+	UserId      int
+	StartsAt    time.Time
+	RemindAt    time.Time
+	DueAt       *time.Time
+	Recurrence  string
+	FiredCount  int
+	IsActive    bool `gorm:"default:true"`
+	LastFiredAt *time.Time
+	Channels    pq.StringArray `gorm:"type:text[]"`
+	Email       *string
+	WebhookUrl  *string
+	Timestamp   time.Time
+}
+
+// ReminderSettings - параметры напоминания, задаваемые пользователем
+type ReminderSettings struct {
+	RemindAt   time.Time
+	DueAt      *time.Time
+	Recurrence string
+	Channels   []string
+	Email      *string
+	WebhookUrl *string
+}
+
+func NewReminder(noteId int, userId int, settings ReminderSettings) (*Reminder, *ApplicationError) {
+	rule, err := ParseRecurrence(settings.Recurrence)
+	if err != nil {
+		return nil, err
+	}
+
+	channels := settings.Channels
+	if len(channels) == 0 {
+		channels = []string{string(ReminderChannelInbox)}
+	}
+
+	validationError := validateReminder(settings.RemindAt, channels, settings.Email, settings.WebhookUrl)
+	if validationError != nil {
+		return nil, validationError
+	}
+
+	normalized := ""
+	if rule != nil {
+		normalized = rule.String()
+	}
+
+	var dueAt *time.Time
+	if settings.DueAt != nil {
+		utc := settings.DueAt.UTC()
+		dueAt = &utc
+	}
+
+	return &Reminder{
+		Id:         0,
+		NoteId:     noteId,
+		UserId:     userId,
+		StartsAt:   settings.RemindAt.UTC(),
+		RemindAt:   settings.RemindAt.UTC(),
+		DueAt:      dueAt,
+		Recurrence: normalized,
+		IsActive:   true,
+		Channels:   channels,
+		Email:      settings.Email,
+		WebhookUrl: settings.WebhookUrl,
+	}, nil
+}
+
+func (r *Reminder) SetId(id int) {
+	r.Id = id
+}
+
+func (r *Reminder) GetId() int {
+	return r.Id
+}
+
+func (r *Reminder) SetTimestamp() {
+	r.Timestamp = time.Now()
+}
+
+func (r *Reminder) GetRecurrence() *Recurrence {
+	rule, _ := ParseRecurrence(r.Recurrence)
+	return rule
+}
+
+// Advance отмечает срабатывание напоминания и переносит его на первое повторение позже now.
+// Пропущенные повторения (например, пока приложение было остановлено) не отправляются повторно.
+func (r *Reminder) Advance(now time.Time) {
+	r.LastFiredAt = &now
+
+	rule := r.GetRecurrence()
+	if rule == nil {
+		r.FiredCount++
+		r.IsActive = false
+		return
+	}
+
+	for index := r.FiredCount + 1; ; index++ {
+		next, ok := rule.Occurrence(r.StartsAt, index)
+		if !ok {
+			r.FiredCount = index
+			r.IsActive = false
+			return
+		}
+
+		if next.After(now) {
+			r.FiredCount = index
+			r.RemindAt = next
+			return
+		}
+	}
+}
+
+// Occurrences возвращает ближайшие срабатывания напоминания в интервале [from, to]
+func (r *Reminder) Occurrences(from time.Time, to time.Time, limit int) []time.Time {
+	occurrences := make([]time.Time, 0)
+	if !r.IsActive {
+		return occurrences
+	}
+
+	rule := r.GetRecurrence()
+	if rule == nil {
+		if !r.RemindAt.Before(from) && !r.RemindAt.After(to) {
+			occurrences = append(occurrences, r.RemindAt)
+		}
+		return occurrences
+	}
+
+	for index := r.FiredCount; len(occurrences) < limit; index++ {
+		occurrence, ok := rule.Occurrence(r.StartsAt, index)
+		if !ok || occurrence.After(to) {
+			break
+		}
+
+		if !occurrence.Before(from) {
+			occurrences = append(occurrences, occurrence)
+		}
+	}
+
+	return occurrences
+}
+
+// ParseRecurrence разбирает daily/weekly/monthly или RRULE вида FREQ=WEEKLY;INTERVAL=2;COUNT=10.
+// Пустая строка означает однократное напоминание.
+func ParseRecurrence(value string) (*Recurrence, *ApplicationError) {
+	value = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(value), "RRULE:"))
+	if value == "" {
+		return nil, nil
+	}
+
+	switch strings.ToUpper(value) {
+	case string(FrequencyDaily), string(FrequencyWeekly), string(FrequencyMonthly):
+		return &Recurrence{Frequency: Frequency(strings.ToUpper(value)), Interval: 1}, nil
+	}
+
+	rule := &Recurrence{Interval: 1}
+	for _, part := range strings.Split(value, ";") {
+		key, partValue, found := strings.Cut(part, "=")
+		if !found {
+			return nil, newRecurrenceError(value)
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Frequency = Frequency(strings.ToUpper(partValue))
+		case "INTERVAL":
+			interval, err := strconv.Atoi(partValue)
+			if err != nil || interval < 1 {
+				return nil, newRecurrenceError(value)
+			}
+			rule.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(partValue)
+			if err != nil || count < 1 {
+				return nil, newRecurrenceError(value)
+			}
+			rule.Count = count
+		case "UNTIL":
+			until, err := time.Parse(rruleUntilLayout, partValue)
+			if err != nil {
+				return nil, newRecurrenceError(value)
+			}
+			rule.Until = &until
+		default:
+			return nil, newRecurrenceError(value)
+		}
+	}
+
+	switch rule.Frequency {
+	case FrequencyDaily, FrequencyWeekly, FrequencyMonthly:
+	default:
+		return nil, newRecurrenceError(value)
+	}
+
+	if rule.Count > 0 && rule.Until != nil {
+		return nil, NewLocalizedError(ErrorTypeValidation, CodeRecurrenceCountAndUntil, nil, nil)
+	}
+
+	return rule, nil
+}
+
+// Occurrence возвращает повторение с номером index (0 - первое), считая от start
+func (r *Recurrence) Occurrence(start time.Time, index int) (time.Time, bool) {
+	if r.Count > 0 && index >= r.Count {
+		return time.Time{}, false
+	}
+
+	var occurrence time.Time
+	switch r.Frequency {
+	case FrequencyDaily:
+		occurrence = start.AddDate(0, 0, index*r.Interval)
+	case FrequencyWeekly:
+		occurrence = start.AddDate(0, 0, 7*index*r.Interval)
+	case FrequencyMonthly:
+		occurrence = addMonthsClamped(start, index*r.Interval)
+	}
+
+	if r.Until != nil && occurrence.After(*r.Until) {
+		return time.Time{}, false
+	}
+
+	return occurrence, true
+}
+
+func (r *Recurrence) String() string {
+	parts := []string{"FREQ=" + string(r.Frequency)}
+	if r.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, fmt.Sprintf("COUNT=%d", r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(rruleUntilLayout))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// addMonthsClamped прибавляет месяцы, не перескакивая в следующий месяц: 31 января + 1 месяц = 28/29 февраля
+func addMonthsClamped(start time.Time, months int) time.Time {
+	year, month, day := start.Date()
+	firstOfMonth := time.Date(year, month+time.Month(months), 1, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+
+	return firstOfMonth.AddDate(0, 0, min(day, lastDay)-1)
+}
+
+func newRecurrenceError(value string) *ApplicationError {
+	return NewLocalizedError(ErrorTypeValidation, CodeRecurrenceUnsupported, ErrorParams{"rule": value}, nil)
+}
+
+func validateReminder(remindAt time.Time, channels []string, email *string, webhookUrl *string) *ApplicationError {
+	if !remindAt.After(time.Now()) {
+		return NewLocalizedError(ErrorTypeValidation, CodeReminderInPast, nil, nil)
+	}
+
+	for _, channel := range channels {
+		switch ReminderChannel(channel) {
+		case ReminderChannelInbox:
+		case ReminderChannelEmail:
+			if email == nil {
+				return NewLocalizedError(ErrorTypeValidation, CodeReminderEmailRequired, nil, nil)
+			}
+			if _, err := mail.ParseAddress(*email); err != nil {
+				return NewLocalizedError(ErrorTypeValidation, CodeEmailInvalid, nil, nil)
+			}
+		case ReminderChannelWebhook:
+			if webhookUrl == nil {
+				return NewLocalizedError(ErrorTypeValidation, CodeReminderWebhookRequired, nil, nil)
+			}
+			if err := validateWebhookUrl(*webhookUrl); err != nil {
+				return err
+			}
+		default:
+			return NewLocalizedError(ErrorTypeValidation, CodeNotificationChannelUnknown, ErrorParams{"channel": channel}, nil)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+type ReminderApi struct {
+	NoteId     int
+	RemindAt   time.Time
+	DueAt      *time.Time `json:",omitempty"`
+	Recurrence string     `json:",omitempty"`
+	Channels   []string
+	Email      *string `json:",omitempty"`
+	WebhookUrl *string `json:",omitempty"`
+	IsActive   bool
+}
+
+type ReminderOccurrenceApi struct {
+	NoteId     int
+	Title      string
+	RemindAt   time.Time
+	DueAt      *time.Time `json:",omitempty"`
+	Recurrence string     `json:",omitempty"`
+}
+
+func ToReminderApi(dbReminder *Reminder) *ReminderApi {
+	if dbReminder == nil {
+		return nil
+	}
+
+	return &ReminderApi{
+		NoteId:     dbReminder.NoteId,
+		RemindAt:   dbReminder.RemindAt,
+		DueAt:      dbReminder.DueAt,
+		Recurrence: dbReminder.Recurrence,
+		Channels:   dbReminder.Channels,
+		Email:      dbReminder.Email,
+		WebhookUrl: dbReminder.WebhookUrl,
+		IsActive:   dbReminder.IsActive,
+	}
+}
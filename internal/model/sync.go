@@ -0,0 +1,198 @@
+package model
+
+import (
+	"strconv"
+	"time"
+)
+
+// SyncEntity - вид сущности, которую клиент синхронизирует с сервером
+type SyncEntity string
+
+const (
+	SyncEntityNote   SyncEntity = "note"
+	SyncEntityFolder SyncEntity = "folder"
+)
+
+type SyncOperation string
+
+const (
+	SyncOperationCreate SyncOperation = "create"
+	SyncOperationUpdate SyncOperation = "update"
+	SyncOperationDelete SyncOperation = "delete"
+)
+
+// SyncStatus - итог применения одного изменения клиента
+type SyncStatus string
+
+const (
+	SyncStatusApplied  SyncStatus = "applied"
+	SyncStatusConflict SyncStatus = "conflict"
+	SyncStatusFailed   SyncStatus = "failed"
+)
+
+// VersionedEntity - сущность, изменения которой получают клиенты синхронизации. Каждое сохранение
+// увеличивает версию и назначает сущности новую позицию в общей последовательности изменений.
+type VersionedEntity interface {
+	BusinessEntity
+	GetUserId() int
+	GetSyncEntity() SyncEntity
+	SetChange(changeSeq int64)
+}
+
+// Tombstone - след удалённой заметки или папки, по которому клиент узнаёт об удалении
+type Tombstone struct {
+	Id        int `json:"-"`
+	UserId    int `json:"-"`
+	Entity    SyncEntity
+	EntityId  int
+	ChangeSeq int64 `json:"-"`
+	Timestamp time.Time
+}
+
+func NewTombstone(entity VersionedEntity, changeSeq int64) *Tombstone {
+	return &Tombstone{
+		UserId:    entity.GetUserId(),
+		Entity:    entity.GetSyncEntity(),
+		EntityId:  entity.GetId(),
+		ChangeSeq: changeSeq,
+		Timestamp: time.Now(),
+	}
+}
+
+// SyncNote - заметка в том виде, в котором её получает клиент синхронизации
+type SyncNote struct {
+	Id         int
+	ClientId   *string `json:",omitempty"`
+	Version    int
+	Title      string
+	Content    string
+	Type       NoteType
+	Tags       []string
+	FolderId   *int
+	IsFavorite bool
+	IsPinned   bool
+	Position   string
+	IsArchived bool
+	Items      []*ChecklistItemApi `json:",omitempty"`
+	Timestamp  time.Time
+}
+
+type SyncFolder struct {
+	Id         int
+	ClientId   *string `json:",omitempty"`
+	Version    int
+	Title      string
+	Position   string
+	IsArchived bool
+	Timestamp  time.Time
+}
+
+// SyncChanges - изменения после позиции, переданной клиентом. Token нужно передать в следующем запросе.
+type SyncChanges struct {
+	Notes      []*SyncNote
+	Folders    []*SyncFolder
+	Tombstones []*Tombstone
+	Token      string
+}
+
+// SyncMutation - изменение, сделанное клиентом без связи с сервером. Новые сущности клиент помечает
+// своим ClientId и может ссылаться на них в следующих изменениях того же пакета, в том числе
+// через FolderClientId. Version - версия, на основе которой клиент сделал изменение.
+type SyncMutation struct {
+	Entity         SyncEntity
+	Operation      SyncOperation
+	Id             *int
+	ClientId       string
+	Version        int
+	Title          string
+	Content        string
+	Tags           *[]string
+	FolderId       *int
+	FolderClientId string
+	IsFavorite     bool
+	// LockToken - токен аренды заметки, если клиент её арендовал
+	LockToken string
+}
+
+// SyncResult - итог применения изменения. При конфликте Note или Folder содержат текущее состояние
+// на сервере, если сущность не удалена.
+type SyncResult struct {
+	Entity    SyncEntity
+	Operation SyncOperation
+	ClientId  string `json:",omitempty"`
+	Id        *int   `json:",omitempty"`
+	Version   int    `json:",omitempty"`
+	Status    SyncStatus
+	Code      ErrorCode   `json:",omitempty"`
+	Message   string      `json:",omitempty"`
+	Note      *SyncNote   `json:",omitempty"`
+	Folder    *SyncFolder `json:",omitempty"`
+}
+
+func NewSyncResult(mutation *SyncMutation) *SyncResult {
+	return &SyncResult{
+		Entity:    mutation.Entity,
+		Operation: mutation.Operation,
+		ClientId:  mutation.ClientId,
+		Status:    SyncStatusApplied,
+	}
+}
+
+func (r *SyncResult) SetEntity(id int, version int) {
+	r.Id = &id
+	r.Version = version
+}
+
+func (r *SyncResult) SetFailed(status SyncStatus, err *ApplicationError) {
+	r.Status = status
+	r.Code = err.Code
+	r.Message = err.Message
+}
+
+// ParseSyncToken возвращает позицию в последовательности изменений. Пустой токен означает первую синхронизацию.
+func ParseSyncToken(token string) (int64, *ApplicationError) {
+	if token == "" {
+		return 0, nil
+	}
+
+	changeSeq, err := strconv.ParseInt(token, 10, 64)
+	if err != nil || changeSeq < 0 {
+		return 0, NewLocalizedError(ErrorTypeValidation, CodeSyncTokenInvalid, nil, nil)
+	}
+
+	return changeSeq, nil
+}
+
+func FormatSyncToken(changeSeq int64) string {
+	return strconv.FormatInt(changeSeq, 10)
+}
+
+func ToSyncNote(note *Note) *SyncNote {
+	return &SyncNote{
+		Id:         note.Id,
+		ClientId:   note.ClientId,
+		Version:    note.Version,
+		Title:      note.Title,
+		Content:    note.Content,
+		Type:       note.Type,
+		Tags:       note.Tags,
+		FolderId:   note.FolderId,
+		IsFavorite: note.IsFavorite,
+		IsPinned:   note.IsPinned,
+		Position:   note.Position,
+		IsArchived: note.IsArchived,
+		Timestamp:  note.Timestamp,
+	}
+}
+
+func ToSyncFolder(folder *Folder) *SyncFolder {
+	return &SyncFolder{
+		Id:         folder.Id,
+		ClientId:   folder.ClientId,
+		Version:    folder.Version,
+		Title:      folder.Title,
+		Position:   folder.Position,
+		IsArchived: folder.IsArchived,
+		Timestamp:  folder.Timestamp,
+	}
+}
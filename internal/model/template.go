@@ -0,0 +1,201 @@
+package model
+
+import (
+	"github.com/lib/pq"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	TemplateVariableDate        = "date"
+	TemplateVariableTime        = "time"
+	TemplateVariableUserName    = "user.name"
+	TemplateVariableUserSurname = "user.surname"
+	TemplateVariableUserLogin   = "user.login"
+)
+
+var templateVariables = map[string]bool{
+	TemplateVariableDate:        true,
+	TemplateVariableTime:        true,
+	TemplateVariableUserName:    true,
+	TemplateVariableUserSurname: true,
+	TemplateVariableUserLogin:   true,
+}
+
+const templatePromptPrefix = "prompt:"
+const templateDateLayout = "2006-01-02"
+const templateTimeLayout = "15:04"
+
+// templatePlaceholderPattern находит переменные вида {{date}} и запросы значений вида {{prompt:Тема}}
+var templatePlaceholderPattern = regexp.MustCompile(`\{\{\s*([^{}]+?)\s*\}\}`)
+
+type Template struct {
+	Id        int
+	UserId    int
+	Name      string
+	Title     string
+	Content   string
+	Tags      pq.StringArray `gorm:"type:text[]"`
+	FolderId  *int
+	Timestamp time.Time
+}
+
+// TemplateSettings - параметры шаблона, задаваемые пользователем
+type TemplateSettings struct {
+	Name     string
+	Title    string
+	Content  string
+	Tags     *[]string
+	FolderId *int
+}
+
+// TemplateContext - значения встроенных переменных шаблона
+type TemplateContext struct {
+	Now  time.Time
+	User *User
+}
+
+// RenderedTemplate - заметка, полученная из шаблона
+type RenderedTemplate struct {
+	Title    string
+	Content  string
+	Tags     []string
+	FolderId *int
+}
+
+func NewTemplate(userId int, settings TemplateSettings) (*Template, *ApplicationError) {
+	validationError := validateTemplate(settings)
+	if validationError != nil {
+		return nil, validationError
+	}
+
+	return &Template{
+		Id:       0,
+		UserId:   userId,
+		Name:     settings.Name,
+		Title:    settings.Title,
+		Content:  settings.Content,
+		Tags:     getTags(settings.Tags),
+		FolderId: settings.FolderId,
+	}, nil
+}
+
+func (t *Template) SetId(id int) {
+	t.Id = id
+}
+
+func (t *Template) GetId() int {
+	return t.Id
+}
+
+func (t *Template) SetTimestamp() {
+	t.Timestamp = time.Now()
+}
+
+// Prompts возвращает имена значений, которые пользователь должен ввести при создании заметки
+func (t *Template) Prompts() []string {
+	prompts := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for _, text := range []string{t.Title, t.Content} {
+		for _, match := range templatePlaceholderPattern.FindAllStringSubmatch(text, -1) {
+			prompt, isPrompt := strings.CutPrefix(match[1], templatePromptPrefix)
+			prompt = strings.TrimSpace(prompt)
+			if isPrompt && !seen[prompt] {
+				seen[prompt] = true
+				prompts = append(prompts, prompt)
+			}
+		}
+	}
+
+	return prompts
+}
+
+// Render подставляет значения переменных и ответы на запросы в название и текст шаблона
+func (t *Template) Render(context TemplateContext, values map[string]string) (*RenderedTemplate, *ApplicationError) {
+	missing := make([]string, 0)
+	for _, prompt := range t.Prompts() {
+		if _, exists := values[prompt]; !exists {
+			missing = append(missing, prompt)
+		}
+	}
+
+	if len(missing) > 0 {
+		params := ErrorParams{"prompts": strings.Join(missing, ", ")}
+		return nil, NewLocalizedError(ErrorTypeValidation, CodeTemplateValuesMissing, params, nil)
+	}
+
+	variables := context.variables()
+	render := func(text string) string {
+		return templatePlaceholderPattern.ReplaceAllStringFunc(text, func(placeholder string) string {
+			name := templatePlaceholderPattern.FindStringSubmatch(placeholder)[1]
+			if prompt, isPrompt := strings.CutPrefix(name, templatePromptPrefix); isPrompt {
+				return values[strings.TrimSpace(prompt)]
+			}
+			return variables[name]
+		})
+	}
+
+	tags := make([]string, 0, len(t.Tags))
+	tags = append(tags, t.Tags...)
+
+	return &RenderedTemplate{
+		Title:    render(t.Title),
+		Content:  render(t.Content),
+		Tags:     tags,
+		FolderId: t.FolderId,
+	}, nil
+}
+
+func (c TemplateContext) variables() map[string]string {
+	variables := map[string]string{
+		TemplateVariableDate: c.Now.Format(templateDateLayout),
+		TemplateVariableTime: c.Now.Format(templateTimeLayout),
+	}
+
+	if c.User != nil {
+		variables[TemplateVariableUserName] = c.User.Name
+		variables[TemplateVariableUserSurname] = c.User.Surname
+		variables[TemplateVariableUserLogin] = c.User.Login
+	}
+
+	return variables
+}
+
+func validateTemplate(settings TemplateSettings) *ApplicationError {
+	if len(settings.Name) == 0 {
+		return NewLocalizedError(ErrorTypeValidation, CodeTemplateNameEmpty, nil, nil)
+	}
+
+	if len(settings.Title) == 0 {
+		return NewLocalizedError(ErrorTypeValidation, CodeTemplateTitleEmpty, nil, nil)
+	}
+
+	for _, text := range []string{settings.Title, settings.Content} {
+		if err := validateTemplatePlaceholders(text); err != nil {
+			return err
+		}
+	}
+
+	return validateTags(settings.Tags)
+}
+
+func validateTemplatePlaceholders(text string) *ApplicationError {
+	for _, match := range templatePlaceholderPattern.FindAllStringSubmatch(text, -1) {
+		name := match[1]
+
+		if prompt, isPrompt := strings.CutPrefix(name, templatePromptPrefix); isPrompt {
+			if strings.TrimSpace(prompt) == "" {
+				return NewLocalizedError(ErrorTypeValidation, CodeTemplatePromptNameEmpty, nil, nil)
+			}
+			continue
+		}
+
+		if !templateVariables[name] {
+			return NewLocalizedError(ErrorTypeValidation, CodeTemplateVariableUnknown, ErrorParams{"variable": match[0]}, nil)
+		}
+	}
+
+	return nil
+}
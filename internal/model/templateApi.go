@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+type TemplateApi struct {
+	Id        int
+	Name      string
+	Title     string
+	Content   string
+	Tags      []string
+	FolderId  *int `json:",omitempty"`
+	Prompts   []string
+	Timestamp time.Time
+}
+
+func ToTemplateApi(dbTemplate *Template) *TemplateApi {
+	if dbTemplate == nil {
+		return nil
+	}
+
+	return &TemplateApi{
+		Id:        dbTemplate.Id,
+		Name:      dbTemplate.Name,
+		Title:     dbTemplate.Title,
+		Content:   dbTemplate.Content,
+		Tags:      dbTemplate.Tags,
+		FolderId:  dbTemplate.FolderId,
+		Prompts:   dbTemplate.Prompts(),
+		Timestamp: dbTemplate.Timestamp,
+	}
+}
@@ -1,13 +1,13 @@
 package model
 
 import (
-	"fmt"
 	"time"
 	"unicode"
 )
 
 const MinLoginLength = 8
 const MinPasswordLength = 10
+const DefaultTimezone = "UTC"
 
 type User struct {
 	Id        int
@@ -15,6 +15,7 @@ type User struct {
 	Surname   string
 	Login     string
 	Password  string
+	Timezone  string `gorm:"default:UTC"`
 	Timestamp time.Time
 }
 
@@ -45,42 +46,51 @@ func (u *User) SetTimestamp() {
 	u.Timestamp = time.Now()
 }
 
-func validateUser(name string, surname string, login string, password string) *ApplicationError {
-	personalDataValidationError := validatePersonalData(name, surname)
-	if personalDataValidationError != nil {
-		return personalDataValidationError
-	}
-
-	credentialsValidationError := validateCredentials(login, password)
-
-	if credentialsValidationError != nil {
-		return credentialsValidationError
+// SetTimezone задает часовой пояс пользователя в формате базы IANA, например Europe/Moscow
+func (u *User) SetTimezone(timezone string) *ApplicationError {
+	if _, err := time.LoadLocation(timezone); err != nil || timezone == "" || timezone == "Local" {
+		return NewLocalizedError(ErrorTypeValidation, CodeTimezoneUnknown, ErrorParams{"timezone": timezone}, nil)
 	}
 
+	u.Timezone = timezone
 	return nil
 }
 
-func validatePersonalData(name string, surname string) *ApplicationError {
-	if len(name) == 0 {
-		return NewApplicationError(ErrorTypeValidation, "Имя не может быть пустым", nil)
+// Location возвращает часовой пояс пользователя, по умолчанию UTC
+func (u *User) Location() *time.Location {
+	if u.Timezone == "" {
+		return time.UTC
 	}
 
-	if len(surname) == 0 {
-		return NewApplicationError(ErrorTypeValidation, "Фамилия не может быть пустой", nil)
+	location, err := time.LoadLocation(u.Timezone)
+	if err != nil {
+		return time.UTC
 	}
 
-	return nil
+	return location
+}
+
+func validateUser(name string, surname string, login string, password string) *ApplicationError {
+	var errs validationErrors
+	errs.add("Name", validateName(name))
+	errs.add("Surname", validateSurname(surname))
+	errs.add("Login", validateLogin(login))
+	errs.add("Password", validatePassword(password))
+
+	return errs.toError()
 }
 
-func validateCredentials(login string, password string) *ApplicationError {
-	loginValidation := validateLogin(login)
-	if loginValidation != nil {
-		return loginValidation
+func validateName(name string) *ApplicationError {
+	if len(name) == 0 {
+		return NewLocalizedError(ErrorTypeValidation, CodeUserNameEmpty, nil, nil)
 	}
 
-	passwordValidation := validatePassword(password)
-	if passwordValidation != nil {
-		return passwordValidation
+	return nil
+}
+
+func validateSurname(surname string) *ApplicationError {
+	if len(surname) == 0 {
+		return NewLocalizedError(ErrorTypeValidation, CodeUserSurnameEmpty, nil, nil)
 	}
 
 	return nil
@@ -88,8 +98,7 @@ func validateCredentials(login string, password string) *ApplicationError {
 
 func validateLogin(login string) *ApplicationError {
 	if len(login) < MinLoginLength {
-		message := fmt.Sprintf("Логин слишком короткий. Пожалуйста, создайте логин длинной не меньше %d символов", MinLoginLength)
-		return NewApplicationError(ErrorTypeValidation, message, nil)
+		return NewLocalizedError(ErrorTypeValidation, CodeLoginTooShort, ErrorParams{"min": MinLoginLength}, nil)
 	}
 
 	return nil
@@ -97,8 +106,7 @@ func validateLogin(login string) *ApplicationError {
 
 func validatePassword(password string) *ApplicationError {
 	if len(password) < MinPasswordLength {
-		message := fmt.Sprintf("Пароль слишком короткий. Пожалуйста, создайте пароль длиной не менее %d символов", MinPasswordLength)
-		return NewApplicationError(ErrorTypeValidation, message, nil)
+		return NewLocalizedError(ErrorTypeValidation, CodePasswordTooShort, ErrorParams{"min": MinPasswordLength}, nil)
 	}
 
 	hasUpper := false
@@ -120,16 +128,16 @@ func validatePassword(password string) *ApplicationError {
 	}
 
 	if !hasUpper {
-		return NewApplicationError(ErrorTypeValidation, "Пароль должен содержать букву верхнего регистра.", nil)
+		return NewLocalizedError(ErrorTypeValidation, CodePasswordNoUppercase, nil, nil)
 	}
 	if !hasLower {
-		return NewApplicationError(ErrorTypeValidation, "Пароль должен содержать букву нижнего регистра.", nil)
+		return NewLocalizedError(ErrorTypeValidation, CodePasswordNoLowercase, nil, nil)
 	}
 	if !hasNumber {
-		return NewApplicationError(ErrorTypeValidation, "Пароль должен содержать число.", nil)
+		return NewLocalizedError(ErrorTypeValidation, CodePasswordNoDigit, nil, nil)
 	}
 	if !hasSpecial {
-		return NewApplicationError(ErrorTypeValidation, "Пароль должен содержать спецсимволы.", nil)
+		return NewLocalizedError(ErrorTypeValidation, CodePasswordNoSpecial, nil, nil)
 	}
 
 	return nil
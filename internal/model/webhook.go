@@ -0,0 +1,205 @@
+package model
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/lib/pq"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const webhookSecretBytes = 32
+
+// EventWebhookTest - событие, которое пользователь отправляет вручную, чтобы проверить свой обработчик
+const EventWebhookTest EventType = "webhook.test"
+
+// WebhookEventTypes - события, на которые можно подписать webhook
+var WebhookEventTypes = []EventType{
+	EventNoteCreated,
+	EventNoteUpdated,
+	EventNoteDeleted,
+	EventNoteMoved,
+	EventNoteFavorite,
+	EventFolderCreated,
+	EventFolderRenamed,
+	EventFolderDeleted,
+	EventFolderUpdated,
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusDelivered WebhookDeliveryStatus = "delivered"
+	// WebhookDeliveryStatusDead - попытки исчерпаны, доставку можно только повторить вручную
+	WebhookDeliveryStatusDead WebhookDeliveryStatus = "dead"
+)
+
+// Webhook - адрес, на который отправляются события пользователя. Secret подписывает каждую отправку
+// и показывается пользователю только при создании.
+type Webhook struct {
+	Id        int
+	UserId    int
+	Url       string
+	Secret    string
+	Events    pq.StringArray `gorm:"type:text[]"`
+	IsActive  bool
+	Timestamp time.Time
+}
+
+type WebhookSettings struct {
+	Url      string
+	Events   []string
+	IsActive bool
+}
+
+// WebhookDelivery - отправка одного события на webhook. Payload сохраняется в момент изменения,
+// поэтому повторные попытки отправляют то же самое тело.
+type WebhookDelivery struct {
+	Id            int
+	WebhookId     int
+	UserId        int
+	EventType     EventType
+	Payload       string
+	Status        WebhookDeliveryStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	StatusCode    *int
+	Error         string
+	DeliveredAt   *time.Time
+	Timestamp     time.Time
+}
+
+// WebhookAttempt - запись журнала об одной попытке отправки
+type WebhookAttempt struct {
+	Id         int
+	DeliveryId int
+	StatusCode *int
+	Error      string
+	DurationMs int
+	Timestamp  time.Time
+}
+
+func NewWebhook(userId int, settings WebhookSettings) (*Webhook, *ApplicationError) {
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	webhook := &Webhook{
+		UserId: userId,
+		Secret: secret,
+	}
+
+	if err = webhook.Apply(settings); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// Apply проверяет настройки и переносит их в webhook. Секрет не меняется.
+func (w *Webhook) Apply(settings WebhookSettings) *ApplicationError {
+	if err := validateWebhookUrl(settings.Url); err != nil {
+		return err
+	}
+
+	if len(settings.Events) == 0 {
+		return NewLocalizedError(ErrorTypeValidation, CodeWebhookEventsEmpty, nil, nil)
+	}
+
+	for _, event := range settings.Events {
+		if !isWebhookEventType(EventType(event)) {
+			return NewLocalizedError(ErrorTypeValidation, CodeWebhookEventUnknown, ErrorParams{"event": event}, nil)
+		}
+	}
+
+	w.Url = settings.Url
+	w.Events = settings.Events
+	w.IsActive = settings.IsActive
+	return nil
+}
+
+// Sign возвращает подпись отправки: HMAC-SHA256 секрета над строкой "<timestamp>.<payload>".
+// Время входит в подпись, чтобы перехваченный запрос нельзя было повторить позже.
+func (w *Webhook) Sign(timestamp int64, payload string) string {
+	mac := hmac.New(sha256.New, []byte(w.Secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "." + payload))
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (w *Webhook) SetId(id int) {
+	w.Id = id
+}
+
+func (w *Webhook) GetId() int {
+	return w.Id
+}
+
+func (w *Webhook) SetTimestamp() {
+	w.Timestamp = time.Now()
+}
+
+func NewWebhookDelivery(webhook *Webhook, eventType EventType, payload string) *WebhookDelivery {
+	return &WebhookDelivery{
+		WebhookId:     webhook.Id,
+		UserId:        webhook.UserId,
+		EventType:     eventType,
+		Payload:       payload,
+		Status:        WebhookDeliveryStatusPending,
+		NextAttemptAt: time.Now(),
+	}
+}
+
+func (d *WebhookDelivery) SetId(id int) {
+	d.Id = id
+}
+
+func (d *WebhookDelivery) GetId() int {
+	return d.Id
+}
+
+func (d *WebhookDelivery) SetTimestamp() {
+	d.Timestamp = time.Now()
+}
+
+func (a *WebhookAttempt) SetId(id int) {
+	a.Id = id
+}
+
+func (a *WebhookAttempt) GetId() int {
+	return a.Id
+}
+
+func (a *WebhookAttempt) SetTimestamp() {
+	a.Timestamp = time.Now()
+}
+
+func newWebhookSecret() (string, *ApplicationError) {
+	buffer := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", NewLocalizedError(ErrorTypeInternal, CodeWebhookSecretFailed, nil, err)
+	}
+
+	return hex.EncodeToString(buffer), nil
+}
+
+func isWebhookEventType(eventType EventType) bool {
+	for _, known := range WebhookEventTypes {
+		if eventType == known {
+			return true
+		}
+	}
+	return false
+}
+
+func validateWebhookUrl(value string) *ApplicationError {
+	parsed, err := url.ParseRequestURI(value)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+		return NewLocalizedError(ErrorTypeValidation, CodeWebhookUrlInvalid, nil, nil)
+	}
+	return nil
+}
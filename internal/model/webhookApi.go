@@ -0,0 +1,89 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type WebhookApi struct {
+	Id        int
+	Url       string
+	Events    []string
+	IsActive  bool
+	Timestamp time.Time
+}
+
+type WebhookDeliveryApi struct {
+	Id            int
+	EventType     EventType
+	Payload       json.RawMessage `swaggertype:"object"`
+	Status        WebhookDeliveryStatus
+	NextAttemptAt *time.Time `json:",omitempty"`
+	StatusCode    *int       `json:",omitempty"`
+	Error         string     `json:",omitempty"`
+	DeliveredAt   *time.Time `json:",omitempty"`
+	Attempts      []*WebhookAttemptApi
+	Timestamp     time.Time
+}
+
+type WebhookAttemptApi struct {
+	StatusCode *int   `json:",omitempty"`
+	Error      string `json:",omitempty"`
+	DurationMs int
+	Timestamp  time.Time
+}
+
+func ToWebhookApi(webhook *Webhook) *WebhookApi {
+	return &WebhookApi{
+		Id:        webhook.Id,
+		Url:       webhook.Url,
+		Events:    webhook.Events,
+		IsActive:  webhook.IsActive,
+		Timestamp: webhook.Timestamp,
+	}
+}
+
+func ToWebhooksApi(webhooks []*Webhook) []*WebhookApi {
+	result := make([]*WebhookApi, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		result = append(result, ToWebhookApi(webhook))
+	}
+	return result
+}
+
+// ToWebhookDeliveryApi собирает доставку вместе с журналом её попыток. Время следующей попытки
+// показывается только у доставок, которые ещё ждут отправки.
+func ToWebhookDeliveryApi(delivery *WebhookDelivery, attempts []*WebhookAttempt) *WebhookDeliveryApi {
+	attemptsApi := make([]*WebhookAttemptApi, 0, len(attempts))
+	for _, attempt := range attempts {
+		if attempt.DeliveryId != delivery.Id {
+			continue
+		}
+
+		attemptsApi = append(attemptsApi, &WebhookAttemptApi{
+			StatusCode: attempt.StatusCode,
+			Error:      attempt.Error,
+			DurationMs: attempt.DurationMs,
+			Timestamp:  attempt.Timestamp,
+		})
+	}
+
+	deliveryApi := &WebhookDeliveryApi{
+		Id:          delivery.Id,
+		EventType:   delivery.EventType,
+		Payload:     json.RawMessage(delivery.Payload),
+		Status:      delivery.Status,
+		StatusCode:  delivery.StatusCode,
+		Error:       delivery.Error,
+		DeliveredAt: delivery.DeliveredAt,
+		Attempts:    attemptsApi,
+		Timestamp:   delivery.Timestamp,
+	}
+
+	if delivery.Status == WebhookDeliveryStatusPending {
+		nextAttemptAt := delivery.NextAttemptAt
+		deliveryApi.NextAttemptAt = &nextAttemptAt
+	}
+
+	return deliveryApi
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"Notes/internal/model"
+	"io"
+)
+
+//go:generate mockgen -source=abstractBlobStorage.go -destination=../../internal/service/mock/abstractBlobStorage.go -package=mock
+
+type AbstractBlobStorage interface {
+	Put(key string, content io.Reader) *model.ApplicationError
+	Get(key string) (io.ReadCloser, *model.ApplicationError)
+	Delete(key string) *model.ApplicationError
+}
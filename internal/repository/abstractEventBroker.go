@@ -0,0 +1,12 @@
+package repository
+
+import "context"
+
+//go:generate mockgen -source=abstractEventBroker.go -destination=../../internal/service/mock/abstractEventBroker.go -package=mock
+
+// AbstractEventBroker пересылает события между экземплярами приложения
+type AbstractEventBroker interface {
+	Publish(payload []byte) error
+	// Listen передаёт в handle сообщения всех экземпляров, включая собственные, до отмены контекста
+	Listen(ctx context.Context, handle func(payload []byte)) error
+}
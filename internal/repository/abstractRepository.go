@@ -1,6 +1,9 @@
 package repository
 
-import "Notes/internal/model"
+import (
+	"Notes/internal/model"
+	"time"
+)
 
 //go:generate mockgen -source=abstractRepository.go -destination=../../internal/service/mock/abstractRepository.go -package=mock
 
@@ -13,5 +16,72 @@ type AbstractRepository interface {
 	GetUser(login, password string) (*model.User, *model.ApplicationError)
 	GetFoldersByUserId(userId int) []*model.Folder
 	GetNotesByUserId(userId int) []*model.Note
+	GetNotesByUserIdInBatches(userId int, batchSize int, handle func(notes []*model.Note) *model.ApplicationError) *model.ApplicationError
+	SetFolderArchived(folder *model.Folder, isArchived bool) *model.ApplicationError
 	GetUsers() []*model.User
+	GetAttachmentById(id int) (*model.Attachment, *model.ApplicationError)
+	GetAttachmentsByNoteId(noteId int) []*model.Attachment
+	GetImageAttachmentsByUserId(userId int) []*model.Attachment
+	GetAttachmentsByThumbnailStatus(status model.ThumbnailStatus) []*model.Attachment
+	GetAttachmentThumbnail(attachmentId int, size int) (*model.AttachmentThumbnail, *model.ApplicationError)
+	GetAttachmentThumbnails(attachmentId int) []*model.AttachmentThumbnail
+	GetOrphanedBlobs(limit int) []*model.OrphanedBlob
+	DeleteOrphanedBlobs(ids []int) *model.ApplicationError
+	GetChecklistItemsByNoteId(noteId int) []*model.ChecklistItem
+	GetChecklistItemsByUserId(userId int) []*model.ChecklistItem
+	GetChecklistItemsByNoteIds(noteIds []int) []*model.ChecklistItem
+	SaveChecklist(note *model.Note, items []*model.ChecklistItem) *model.ApplicationError
+	GetReminderByNoteId(noteId int, userId int) (*model.Reminder, *model.ApplicationError)
+	GetActiveRemindersByUserId(userId int) []*model.Reminder
+	GetRemindersByUserId(userId int) []*model.Reminder
+	ClaimDueReminder(now time.Time, claim func(reminder *model.Reminder)) (*model.Reminder, *model.ApplicationError)
+	GetCalendarFeedByUserId(userId int) (*model.CalendarFeed, *model.ApplicationError)
+	GetCalendarFeedByTokenHash(tokenHash string) (*model.CalendarFeed, *model.ApplicationError)
+	GetNoteLinksByUserId(userId int) []*model.NoteLink
+	ReplaceNoteLinks(noteId int, links []*model.NoteLink) *model.ApplicationError
+	GetTemplateById(id int, userId int) (*model.Template, *model.ApplicationError)
+	GetTemplatesByUserId(userId int) []*model.Template
+	GetDailyNote(userId int, date time.Time) (*model.DailyNote, *model.ApplicationError)
+	ClaimDailyNote(dailyNote *model.DailyNote) (*model.DailyNote, *model.ApplicationError)
+	GetDailyNoteSettings(userId int) (*model.DailyNoteSettings, *model.ApplicationError)
+	GetPreferences(userId int) (*model.Preferences, *model.ApplicationError)
+	SetNoteTimestamp(noteId int, timestamp time.Time) *model.ApplicationError
+	GetImportJobById(id int, userId int) (*model.ImportJob, *model.ApplicationError)
+	GetImportJobFiles(jobId int) []*model.ImportJobFile
+	GetImportJobsToProcess(staleBefore time.Time) []*model.ImportJob
+	ClaimImportJob(id int, staleBefore time.Time) (*model.ImportJob, *model.ApplicationError)
+	Transaction(fn func(tx AbstractRepository) *model.ApplicationError) *model.ApplicationError
+	SnapshotTransaction(fn func(tx AbstractRepository) *model.ApplicationError) *model.ApplicationError
+	GetEventsAfter(userId int, afterId int, limit int) []*model.Event
+	GetLastEventId(userId int) int
+	GetNoteByClientId(clientId string, userId int) (*model.Note, *model.ApplicationError)
+	LockUserChanges(userId int) *model.ApplicationError
+	GetFolderByClientId(clientId string, userId int) (*model.Folder, *model.ApplicationError)
+	GetNotesChangedAfter(userId int, changeSeq int64) []*model.Note
+	GetFoldersChangedAfter(userId int, changeSeq int64) []*model.Folder
+	GetTombstonesAfter(userId int, changeSeq int64) []*model.Tombstone
+	GetWebhookById(id int, userId int) (*model.Webhook, *model.ApplicationError)
+	GetWebhooksByUserId(userId int) []*model.Webhook
+	EnqueueWebhookDeliveries(userId int, eventType model.EventType, payload string) *model.ApplicationError
+	ClaimWebhookDeliveries(now time.Time, leaseUntil time.Time, limit int) ([]*model.WebhookDelivery, *model.ApplicationError)
+	GetWebhookDeliveries(webhookId int, limit int) []*model.WebhookDelivery
+	GetWebhookDeliveryById(id int, webhookId int) (*model.WebhookDelivery, *model.ApplicationError)
+	GetWebhookAttempts(deliveryIds []int) []*model.WebhookAttempt
+	DeleteWebhookDeliveriesBefore(before time.Time) *model.ApplicationError
+	GetUserByLogin(login string) (*model.User, *model.ApplicationError)
+	GetAuditEntries(filter model.AuditFilter) []*model.AuditEntry
+	GetCommentById(id int) (*model.Comment, *model.ApplicationError)
+	GetCommentThreads(noteId int, afterId int, limit int) []*model.Comment
+	GetCommentReplies(threadIds []int) []*model.Comment
+	GetCommentCounts(noteIds []int) map[int]int
+	GetUsersByLogins(logins []string) []*model.User
+	GetNotifications(userId int, beforeId int, limit int, unreadOnly bool) []*model.Notification
+	CountUnreadNotifications(userId int) int
+	MarkNotificationRead(id int, userId int) *model.ApplicationError
+	MarkAllNotificationsRead(userId int) *model.ApplicationError
+	GetNoteLock(noteId int, now time.Time) (*model.NoteLock, *model.ApplicationError)
+	GetNoteLocks(noteIds []int, now time.Time) map[int]*model.NoteLock
+	AcquireNoteLock(lock *model.NoteLock, now time.Time) (*model.NoteLock, *model.ApplicationError)
+	ReleaseNoteLock(noteId int, token string) *model.ApplicationError
+	DeleteExpiredNoteLocks(now time.Time) *model.ApplicationError
 }
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"Notes/internal/model"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	BlobNotFoundError = model.NewLocalizedError(model.ErrorTypeNotFound, model.CodeFileNotFound, nil, nil)
+	BlobStorageError  = model.NewLocalizedError(model.ErrorTypeInternal, model.CodeFileStorageError, nil, nil)
+)
+
+type FileBlobStorage struct {
+	root string
+}
+
+func NewFileBlobStorage(root string) AbstractBlobStorage {
+	return &FileBlobStorage{root: root}
+}
+
+func (f *FileBlobStorage) Put(key string, content io.Reader) *model.ApplicationError {
+	path, err := f.resolve(key)
+	if err != nil {
+		return err
+	}
+
+	if mkdirErr := os.MkdirAll(filepath.Dir(path), 0o755); mkdirErr != nil {
+		return BlobStorageError
+	}
+
+	// Пишем во временный файл, чтобы читатели никогда не видели недописанный blob
+	tmp, createErr := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if createErr != nil {
+		return BlobStorageError
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, copyErr := io.Copy(tmp, content); copyErr != nil {
+		tmp.Close()
+		return BlobStorageError
+	}
+
+	if closeErr := tmp.Close(); closeErr != nil {
+		return BlobStorageError
+	}
+
+	if renameErr := os.Rename(tmp.Name(), path); renameErr != nil {
+		return BlobStorageError
+	}
+
+	return nil
+}
+
+func (f *FileBlobStorage) Get(key string) (io.ReadCloser, *model.ApplicationError) {
+	path, err := f.resolve(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, openErr := os.Open(path)
+	if openErr != nil {
+		if errors.Is(openErr, fs.ErrNotExist) {
+			return nil, BlobNotFoundError
+		}
+		return nil, BlobStorageError
+	}
+
+	return file, nil
+}
+
+func (f *FileBlobStorage) Delete(key string) *model.ApplicationError {
+	path, err := f.resolve(key)
+	if err != nil {
+		return err
+	}
+
+	if removeErr := os.Remove(path); removeErr != nil && !errors.Is(removeErr, fs.ErrNotExist) {
+		return BlobStorageError
+	}
+
+	return nil
+}
+
+func (f *FileBlobStorage) resolve(key string) (string, *model.ApplicationError) {
+	cleanKey := filepath.Clean("/" + key)
+	if cleanKey == "/" || strings.Contains(key, "..") {
+		return "", BlobStorageError
+	}
+
+	return filepath.Join(f.root, cleanKey), nil
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+	"log"
+	"time"
+)
+
+// eventChannel - канал PostgreSQL LISTEN/NOTIFY для событий
+const eventChannel = "notes_events"
+
+// eventListenerPingInterval - как часто проверяется соединение слушателя, если уведомлений нет
+const eventListenerPingInterval = 90 * time.Second
+
+// PostgresEventBroker рассылает события через LISTEN/NOTIFY. Слушатель держит отдельное
+// соединение с БД и переподключается сам; уведомления, отправленные во время разрыва, теряются.
+type PostgresEventBroker struct {
+	db  *gorm.DB
+	dsn string
+}
+
+func NewPostgresEventBroker(db *gorm.DB, dsn string) AbstractEventBroker {
+	return &PostgresEventBroker{db: db, dsn: dsn}
+}
+
+func (p *PostgresEventBroker) Publish(payload []byte) error {
+	return p.db.Exec("SELECT pg_notify(?, ?)", eventChannel, string(payload)).Error
+}
+
+func (p *PostgresEventBroker) Listen(ctx context.Context, handle func(payload []byte)) error {
+	listener := pq.NewListener(p.dsn, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("Ошибка соединения слушателя событий: %v", err)
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(eventChannel); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(eventListenerPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case notification := <-listener.Notify:
+			// nil приходит после переподключения
+			if notification != nil {
+				handle([]byte(notification.Extra))
+			}
+		case <-ticker.C:
+			go func() {
+				_ = listener.Ping()
+			}()
+		}
+	}
+}
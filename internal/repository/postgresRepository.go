@@ -4,14 +4,19 @@ import (
 	"Notes/internal/constants"
 	"Notes/internal/model"
 	"Notes/internal/utils"
+	"database/sql"
 	"errors"
 	"gorm.io/gorm"
-	"log"
+	"gorm.io/gorm/clause"
+	"time"
 )
 
+// changeLockKey - пространство рекомендательных блокировок, которыми упорядочиваются изменения одного пользователя
+const changeLockKey = 1
+
 var (
-	EntityNotFoundError = model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil)
-	DataBaseError       = model.NewApplicationError(model.ErrorTypeDatabase, " внутрення ошибка БД", nil)
+	EntityNotFoundError = model.NewLocalizedError(model.ErrorTypeNotFound, model.CodeEntityNotFound, nil, nil)
+	DataBaseError       = model.NewLocalizedError(model.ErrorTypeDatabase, model.CodeDatabaseError, nil, nil)
 )
 
 type PostgresRepository struct {
@@ -24,7 +29,14 @@ func NewPostgresRepository(db *gorm.DB) AbstractRepository {
 
 func (p *PostgresRepository) SaveEntity(entity model.BusinessEntity) (int, *model.ApplicationError) {
 	entity.SetTimestamp()
-	log.Println(entity)
+	if versioned, ok := entity.(model.VersionedEntity); ok {
+		return p.saveVersionedEntity(versioned)
+	}
+
+	return p.saveEntity(entity)
+}
+
+func (p *PostgresRepository) saveEntity(entity model.BusinessEntity) (int, *model.ApplicationError) {
 	if entity.GetId() == 0 {
 		return p.createEntity(entity)
 	} else {
@@ -32,6 +44,53 @@ func (p *PostgresRepository) SaveEntity(entity model.BusinessEntity) (int, *mode
 	}
 }
 
+// saveVersionedEntity сохраняет заметку или папку с новой версией и позицией в последовательности изменений
+func (p *PostgresRepository) saveVersionedEntity(entity model.VersionedEntity) (int, *model.ApplicationError) {
+	id := constants.FakeId
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		changeSeq, err := nextChangeSeq(tx, entity.GetUserId())
+		if err != nil {
+			return err
+		}
+
+		entity.SetChange(changeSeq)
+
+		var appErr *model.ApplicationError
+		if id, appErr = (&PostgresRepository{db: tx}).saveEntity(entity); appErr != nil {
+			return appErr
+		}
+		return nil
+	})
+
+	if err != nil {
+		return constants.FakeId, DataBaseError
+	}
+	return id, nil
+}
+
+// nextChangeSeq выдаёт следующую позицию в последовательности изменений. Блокировка пользователя держится
+// до конца транзакции, поэтому его изменения фиксируются в порядке своих позиций, и клиент синхронизации,
+// запомнивший позицию, не пропустит изменение, которое зафиксируется позже.
+func nextChangeSeq(tx *gorm.DB, userId int) (int64, error) {
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(?, ?)", changeLockKey, userId).Error; err != nil {
+		return 0, err
+	}
+
+	var changeSeq int64
+	err := tx.Raw("SELECT nextval('change_seq')").Scan(&changeSeq).Error
+
+	return changeSeq, err
+}
+
+// LockUserChanges берёт до конца транзакции ту же блокировку, что и каждое сохранение заметки или папки
+// пользователя. Пока она держится, прочитанные в транзакции версии не могут устареть до записи.
+func (p *PostgresRepository) LockUserChanges(userId int) *model.ApplicationError {
+	if err := p.db.Exec("SELECT pg_advisory_xact_lock(?, ?)", changeLockKey, userId).Error; err != nil {
+		return DataBaseError
+	}
+	return nil
+}
+
 func (p *PostgresRepository) createEntity(entity model.BusinessEntity) (int, *model.ApplicationError) {
 	result := p.db.Create(entity)
 
@@ -65,12 +124,114 @@ func (p *PostgresRepository) updateEntity(entity model.BusinessEntity) (int, *mo
 		}
 		return e.Id, nil
 
+	case *model.Attachment:
+		result := p.db.Save(e)
+		if result.Error != nil {
+			return -1, DataBaseError
+		}
+		return e.Id, nil
+
+	case *model.AttachmentThumbnail:
+		result := p.db.Save(e)
+		if result.Error != nil {
+			return -1, DataBaseError
+		}
+		return e.Id, nil
+
+	case *model.ChecklistItem:
+		result := p.db.Save(e)
+		if result.Error != nil {
+			return -1, DataBaseError
+		}
+		return e.Id, nil
+
+	case *model.Reminder:
+		result := p.db.Save(e)
+		if result.Error != nil {
+			return -1, DataBaseError
+		}
+		return e.Id, nil
+
+	case *model.Notification:
+		result := p.db.Save(e)
+		if result.Error != nil {
+			return -1, DataBaseError
+		}
+		return e.Id, nil
+
+	case *model.CalendarFeed:
+		result := p.db.Save(e)
+		if result.Error != nil {
+			return -1, DataBaseError
+		}
+		return e.Id, nil
+
+	case *model.Template:
+		result := p.db.Save(e)
+		if result.Error != nil {
+			return -1, DataBaseError
+		}
+		return e.Id, nil
+
+	case *model.DailyNoteSettings:
+		result := p.db.Save(e)
+		if result.Error != nil {
+			return -1, DataBaseError
+		}
+		return e.Id, nil
+
+	case *model.Preferences:
+		result := p.db.Save(e)
+		if result.Error != nil {
+			return -1, DataBaseError
+		}
+		return e.Id, nil
+
+	case *model.ImportJob:
+		result := p.db.Save(e)
+		if result.Error != nil {
+			return -1, DataBaseError
+		}
+		return e.Id, nil
+
+	case *model.ImportJobFile:
+		result := p.db.Save(e)
+		if result.Error != nil {
+			return -1, DataBaseError
+		}
+		return e.Id, nil
+
+	case *model.Webhook:
+		result := p.db.Save(e)
+		if result.Error != nil {
+			return -1, DataBaseError
+		}
+		return e.Id, nil
+
+	case *model.WebhookDelivery:
+		result := p.db.Save(e)
+		if result.Error != nil {
+			return -1, DataBaseError
+		}
+		return e.Id, nil
+
+	case *model.Comment:
+		result := p.db.Save(e)
+		if result.Error != nil {
+			return -1, DataBaseError
+		}
+		return e.Id, nil
+
 	default:
 		return constants.FakeId, DataBaseError
 	}
 }
 
 func (p *PostgresRepository) DeleteEntity(entity model.BusinessEntity) *model.ApplicationError {
+	if versioned, ok := entity.(model.VersionedEntity); ok {
+		return p.deleteVersionedEntity(versioned)
+	}
+
 	result := p.db.Delete(entity)
 
 	if result.Error != nil {
@@ -80,6 +241,37 @@ func (p *PostgresRepository) DeleteEntity(entity model.BusinessEntity) *model.Ap
 	return nil
 }
 
+// deleteVersionedEntity удаляет заметку или папку и оставляет надгробие, по которому клиенты синхронизации узнают об удалении
+func (p *PostgresRepository) deleteVersionedEntity(entity model.VersionedEntity) *model.ApplicationError {
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		changeSeq, err := nextChangeSeq(tx, entity.GetUserId())
+		if err != nil {
+			return err
+		}
+
+		// Заметки удаляемой папки переходят в корень блокнота, для клиентов это тоже изменение
+		if folder, ok := entity.(*model.Folder); ok {
+			err = tx.Model(&model.Note{}).
+				Where("folder_id = ? AND user_id = ?", folder.Id, folder.UserId).
+				Updates(map[string]interface{}{"folder_id": nil, "version": gorm.Expr("version + 1"), "change_seq": changeSeq}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		if err = tx.Delete(entity).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(model.NewTombstone(entity, changeSeq)).Error
+	})
+
+	if err != nil {
+		return DataBaseError
+	}
+	return nil
+}
+
 func (p *PostgresRepository) GetUserById(id int) (*model.User, *model.ApplicationError) {
 	var user model.User
 	result := p.db.First(&user, id) // где id - идентификатор пользователя
@@ -138,8 +330,8 @@ func (p *PostgresRepository) GetUser(login, password string) (*model.User, *mode
 		return nil, err
 	}
 
-	if arePasswordsEqual {
-		return &user, nil
+	if !arePasswordsEqual {
+		return nil, EntityNotFoundError
 	}
 
 	return &user, nil
@@ -164,6 +356,59 @@ func (p *PostgresRepository) GetNotesByUserId(userId int) []*model.Note {
 	return notes
 }
 
+// GetNotesByUserIdInBatches читает заметки пользователя порциями по batchSize в порядке id,
+// не загружая их все в память. Ошибка из handle прерывает чтение и возвращается как есть.
+func (p *PostgresRepository) GetNotesByUserIdInBatches(userId int, batchSize int, handle func(notes []*model.Note) *model.ApplicationError) *model.ApplicationError {
+	var notes []*model.Note
+	var handleErr *model.ApplicationError
+
+	result := p.db.Where("user_id = ?", userId).FindInBatches(&notes, batchSize, func(tx *gorm.DB, batch int) error {
+		if handleErr = handle(notes); handleErr != nil {
+			return handleErr
+		}
+		return nil
+	})
+
+	if handleErr != nil {
+		return handleErr
+	}
+	if result.Error != nil {
+		return DataBaseError
+	}
+	return nil
+}
+
+// SetFolderArchived архивирует или восстанавливает папку вместе со всеми её заметками в одной транзакции
+func (p *PostgresRepository) SetFolderArchived(folder *model.Folder, isArchived bool) *model.ApplicationError {
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		changeSeq, err := nextChangeSeq(tx, folder.UserId)
+		if err != nil {
+			return err
+		}
+
+		folder.IsArchived = isArchived
+		folder.SetTimestamp()
+		folder.SetChange(changeSeq)
+		if err = tx.Save(folder).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&model.Note{}).
+			Where("folder_id = ? AND user_id = ?", folder.Id, folder.UserId).
+			Updates(map[string]interface{}{
+				"is_archived": isArchived,
+				"timestamp":   time.Now(),
+				"version":     gorm.Expr("version + 1"),
+				"change_seq":  changeSeq,
+			}).Error
+	})
+
+	if err != nil {
+		return DataBaseError
+	}
+	return nil
+}
+
 func (p *PostgresRepository) GetUsers() []*model.User {
 	var users []*model.User
 	result := p.db.Find(&users)
@@ -174,3 +419,894 @@ func (p *PostgresRepository) GetUsers() []*model.User {
 
 	return users
 }
+
+func (p *PostgresRepository) GetAttachmentById(id int) (*model.Attachment, *model.ApplicationError) {
+	var attachment model.Attachment
+	result := p.db.First(&attachment, id)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, EntityNotFoundError
+		}
+		return nil, DataBaseError
+	}
+	return &attachment, nil
+}
+
+func (p *PostgresRepository) GetAttachmentsByNoteId(noteId int) []*model.Attachment {
+	var attachments []*model.Attachment
+	result := p.db.Where("note_id = ?", noteId).Order("id").Find(&attachments)
+
+	if result.Error != nil {
+		return make([]*model.Attachment, 0)
+	}
+	return attachments
+}
+
+func (p *PostgresRepository) GetImageAttachmentsByUserId(userId int) []*model.Attachment {
+	var attachments []*model.Attachment
+	result := p.db.Where("user_id = ? AND thumbnail_status <> ?", userId, model.ThumbnailStatusNone).
+		Order("id").
+		Find(&attachments)
+
+	if result.Error != nil {
+		return make([]*model.Attachment, 0)
+	}
+	return attachments
+}
+
+func (p *PostgresRepository) GetAttachmentsByThumbnailStatus(status model.ThumbnailStatus) []*model.Attachment {
+	var attachments []*model.Attachment
+	result := p.db.Where("thumbnail_status = ?", status).Order("id").Find(&attachments)
+
+	if result.Error != nil {
+		return make([]*model.Attachment, 0)
+	}
+	return attachments
+}
+
+func (p *PostgresRepository) GetAttachmentThumbnail(attachmentId int, size int) (*model.AttachmentThumbnail, *model.ApplicationError) {
+	var thumbnail model.AttachmentThumbnail
+	result := p.db.Where("attachment_id = ? AND size = ?", attachmentId, size).First(&thumbnail)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, EntityNotFoundError
+		}
+		return nil, DataBaseError
+	}
+	return &thumbnail, nil
+}
+
+func (p *PostgresRepository) GetAttachmentThumbnails(attachmentId int) []*model.AttachmentThumbnail {
+	var thumbnails []*model.AttachmentThumbnail
+	result := p.db.Where("attachment_id = ?", attachmentId).Find(&thumbnails)
+
+	if result.Error != nil {
+		return make([]*model.AttachmentThumbnail, 0)
+	}
+	return thumbnails
+}
+
+func (p *PostgresRepository) GetOrphanedBlobs(limit int) []*model.OrphanedBlob {
+	var blobs []*model.OrphanedBlob
+	result := p.db.Order("id").Limit(limit).Find(&blobs)
+
+	if result.Error != nil {
+		return make([]*model.OrphanedBlob, 0)
+	}
+	return blobs
+}
+
+func (p *PostgresRepository) DeleteOrphanedBlobs(ids []int) *model.ApplicationError {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	result := p.db.Where("id IN ?", ids).Delete(&model.OrphanedBlob{})
+
+	if result.Error != nil {
+		return DataBaseError
+	}
+	return nil
+}
+
+func (p *PostgresRepository) GetChecklistItemsByNoteId(noteId int) []*model.ChecklistItem {
+	var items []*model.ChecklistItem
+	result := p.db.Where("note_id = ?", noteId).Order("position, id").Find(&items)
+
+	if result.Error != nil {
+		return make([]*model.ChecklistItem, 0)
+	}
+	return items
+}
+
+func (p *PostgresRepository) GetChecklistItemsByUserId(userId int) []*model.ChecklistItem {
+	var items []*model.ChecklistItem
+	result := p.db.Joins("JOIN notes ON notes.id = checklist_items.note_id").
+		Where("notes.user_id = ?", userId).
+		Order("checklist_items.note_id, checklist_items.position, checklist_items.id").
+		Find(&items)
+
+	if result.Error != nil {
+		return make([]*model.ChecklistItem, 0)
+	}
+	return items
+}
+
+func (p *PostgresRepository) GetChecklistItemsByNoteIds(noteIds []int) []*model.ChecklistItem {
+	var items []*model.ChecklistItem
+	result := p.db.Where("note_id IN ?", noteIds).Order("note_id, position, id").Find(&items)
+
+	if result.Error != nil {
+		return make([]*model.ChecklistItem, 0)
+	}
+	return items
+}
+
+// SaveChecklist атомарно сохраняет заметку и полный набор ее пунктов: пункты, которых нет в items, удаляются
+func (p *PostgresRepository) SaveChecklist(note *model.Note, items []*model.ChecklistItem) *model.ApplicationError {
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		changeSeq, err := nextChangeSeq(tx, note.UserId)
+		if err != nil {
+			return err
+		}
+
+		note.SetTimestamp()
+		note.SetChange(changeSeq)
+		if err = tx.Save(note).Error; err != nil {
+			return err
+		}
+
+		keepIds := make([]int, 0, len(items))
+		for _, item := range items {
+			if item.Id != 0 {
+				keepIds = append(keepIds, item.Id)
+			}
+		}
+
+		query := tx.Where("note_id = ?", note.Id)
+		if len(keepIds) > 0 {
+			query = query.Where("id NOT IN ?", keepIds)
+		}
+		if err := query.Delete(&model.ChecklistItem{}).Error; err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			item.NoteId = note.Id
+			item.SetTimestamp()
+			if err := tx.Save(item).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return DataBaseError
+	}
+	return nil
+}
+
+func (p *PostgresRepository) GetReminderByNoteId(noteId int, userId int) (*model.Reminder, *model.ApplicationError) {
+	var reminder model.Reminder
+	result := p.db.Where("note_id = ? AND user_id = ?", noteId, userId).First(&reminder)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, EntityNotFoundError
+		}
+		return nil, DataBaseError
+	}
+	return &reminder, nil
+}
+
+func (p *PostgresRepository) GetActiveRemindersByUserId(userId int) []*model.Reminder {
+	var reminders []*model.Reminder
+	result := p.db.Where("user_id = ? AND is_active = true", userId).Order("remind_at").Find(&reminders)
+
+	if result.Error != nil {
+		return make([]*model.Reminder, 0)
+	}
+	return reminders
+}
+
+func (p *PostgresRepository) GetRemindersByUserId(userId int) []*model.Reminder {
+	var reminders []*model.Reminder
+	result := p.db.Where("user_id = ?", userId).Order("id").Find(&reminders)
+
+	if result.Error != nil {
+		return make([]*model.Reminder, 0)
+	}
+	return reminders
+}
+
+// ClaimDueReminder блокирует одно наступившее напоминание (FOR UPDATE SKIP LOCKED), вызывает claim
+// и сохраняет изменения в той же транзакции. Поэтому при нескольких репликах каждое напоминание
+// забирает ровно одна из них. Отправлять напоминание нужно после возврата, когда блокировка уже снята.
+// Возвращает nil, если наступивших напоминаний нет.
+func (p *PostgresRepository) ClaimDueReminder(now time.Time, claim func(reminder *model.Reminder)) (*model.Reminder, *model.ApplicationError) {
+	var claimed *model.Reminder
+
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		var reminders []*model.Reminder
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("is_active = true AND remind_at <= ?", now).
+			Order("remind_at").
+			Limit(1).
+			Find(&reminders)
+
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if len(reminders) == 0 {
+			return nil
+		}
+
+		reminder := reminders[0]
+		claim(reminder)
+		reminder.SetTimestamp()
+
+		if err := tx.Save(reminder).Error; err != nil {
+			return err
+		}
+
+		claimed = reminder
+		return nil
+	})
+
+	if err != nil {
+		return nil, DataBaseError
+	}
+	return claimed, nil
+}
+
+func (p *PostgresRepository) GetCalendarFeedByUserId(userId int) (*model.CalendarFeed, *model.ApplicationError) {
+	var feed model.CalendarFeed
+	result := p.db.Where("user_id = ?", userId).First(&feed)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, EntityNotFoundError
+		}
+		return nil, DataBaseError
+	}
+	return &feed, nil
+}
+
+func (p *PostgresRepository) GetCalendarFeedByTokenHash(tokenHash string) (*model.CalendarFeed, *model.ApplicationError) {
+	var feed model.CalendarFeed
+	result := p.db.Where("token_hash = ?", tokenHash).First(&feed)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, EntityNotFoundError
+		}
+		return nil, DataBaseError
+	}
+	return &feed, nil
+}
+
+func (p *PostgresRepository) GetNoteLinksByUserId(userId int) []*model.NoteLink {
+	var links []*model.NoteLink
+	result := p.db.Where("user_id = ?", userId).Order("id").Find(&links)
+
+	if result.Error != nil {
+		return make([]*model.NoteLink, 0)
+	}
+	return links
+}
+
+// ReplaceNoteLinks заменяет все исходящие ссылки заметки новым набором в одной транзакции
+func (p *PostgresRepository) ReplaceNoteLinks(noteId int, links []*model.NoteLink) *model.ApplicationError {
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("source_note_id = ?", noteId).Delete(&model.NoteLink{}).Error; err != nil {
+			return err
+		}
+
+		for _, link := range links {
+			link.SourceNoteId = noteId
+			link.SetTimestamp()
+			if err := tx.Create(link).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return DataBaseError
+	}
+	return nil
+}
+
+func (p *PostgresRepository) GetTemplateById(id int, userId int) (*model.Template, *model.ApplicationError) {
+	var template model.Template
+	result := p.db.Where("id = ? AND user_id = ?", id, userId).First(&template)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, EntityNotFoundError
+		}
+		return nil, DataBaseError
+	}
+	return &template, nil
+}
+
+func (p *PostgresRepository) GetTemplatesByUserId(userId int) []*model.Template {
+	var templates []*model.Template
+	result := p.db.Where("user_id = ?", userId).Order("name").Find(&templates)
+
+	if result.Error != nil {
+		return make([]*model.Template, 0)
+	}
+	return templates
+}
+
+func (p *PostgresRepository) GetDailyNote(userId int, date time.Time) (*model.DailyNote, *model.ApplicationError) {
+	var dailyNote model.DailyNote
+	result := p.db.Where("user_id = ? AND date = ?", userId, date.Format(model.DailyNoteDateLayout)).First(&dailyNote)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, EntityNotFoundError
+		}
+		return nil, DataBaseError
+	}
+	return &dailyNote, nil
+}
+
+// ClaimDailyNote закрепляет заметку за днём, если день ещё свободен, и возвращает итоговую запись.
+// Если другой запрос успел раньше, возвращается его запись, а переданная не сохраняется.
+func (p *PostgresRepository) ClaimDailyNote(dailyNote *model.DailyNote) (*model.DailyNote, *model.ApplicationError) {
+	dailyNote.SetTimestamp()
+	result := p.db.Clauses(clause.OnConflict{DoNothing: true}).Create(dailyNote)
+
+	if result.Error != nil {
+		return nil, DataBaseError
+	}
+
+	return p.GetDailyNote(dailyNote.UserId, dailyNote.Date)
+}
+
+func (p *PostgresRepository) GetDailyNoteSettings(userId int) (*model.DailyNoteSettings, *model.ApplicationError) {
+	var settings model.DailyNoteSettings
+	result := p.db.Where("user_id = ?", userId).First(&settings)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, EntityNotFoundError
+		}
+		return nil, DataBaseError
+	}
+	return &settings, nil
+}
+
+func (p *PostgresRepository) GetPreferences(userId int) (*model.Preferences, *model.ApplicationError) {
+	var preferences model.Preferences
+	result := p.db.Where("user_id = ?", userId).First(&preferences)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, EntityNotFoundError
+		}
+		return nil, DataBaseError
+	}
+	return &preferences, nil
+}
+
+// SetNoteTimestamp задаёт время изменения заметки, не обновляя его на текущее, как SaveEntity.
+// Используется при импорте, чтобы сохранить даты из исходных файлов.
+func (p *PostgresRepository) SetNoteTimestamp(noteId int, timestamp time.Time) *model.ApplicationError {
+	result := p.db.Model(&model.Note{}).Where("id = ?", noteId).UpdateColumn("timestamp", timestamp)
+
+	if result.Error != nil {
+		return DataBaseError
+	}
+	return nil
+}
+
+func (p *PostgresRepository) GetImportJobById(id int, userId int) (*model.ImportJob, *model.ApplicationError) {
+	var job model.ImportJob
+	result := p.db.Where("id = ? AND user_id = ?", id, userId).First(&job)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, EntityNotFoundError
+		}
+		return nil, DataBaseError
+	}
+	return &job, nil
+}
+
+func (p *PostgresRepository) GetImportJobFiles(jobId int) []*model.ImportJobFile {
+	var files []*model.ImportJobFile
+	p.db.Where("job_id = ?", jobId).Order("id").Find(&files)
+
+	return files
+}
+
+// GetImportJobsToProcess возвращает ожидающие задачи и задачи, которые выполнялись,
+// но не обновлялись с момента staleBefore (реплика, выполнявшая их, остановилась)
+func (p *PostgresRepository) GetImportJobsToProcess(staleBefore time.Time) []*model.ImportJob {
+	var jobs []*model.ImportJob
+	p.db.Where("status = ? OR (status = ? AND timestamp < ?)",
+		model.ImportJobStatusPending, model.ImportJobStatusRunning, staleBefore).
+		Order("id").
+		Find(&jobs)
+
+	return jobs
+}
+
+// ClaimImportJob переводит задачу в статус running, если её не выполняет другая реплика.
+// Строка блокируется (FOR UPDATE SKIP LOCKED), поэтому задачу забирает ровно одна реплика.
+func (p *PostgresRepository) ClaimImportJob(id int, staleBefore time.Time) (*model.ImportJob, *model.ApplicationError) {
+	var claimed *model.ImportJob
+
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		var jobs []*model.ImportJob
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("id = ? AND (status = ? OR (status = ? AND timestamp < ?))",
+				id, model.ImportJobStatusPending, model.ImportJobStatusRunning, staleBefore).
+			Find(&jobs)
+
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if len(jobs) == 0 {
+			return nil
+		}
+
+		claimed = jobs[0]
+		claimed.Status = model.ImportJobStatusRunning
+		claimed.SetTimestamp()
+
+		return tx.Save(claimed).Error
+	})
+
+	if err != nil {
+		return nil, DataBaseError
+	}
+
+	if claimed == nil {
+		return nil, EntityNotFoundError
+	}
+	return claimed, nil
+}
+
+// Transaction выполняет fn в одной транзакции: изменения, сделанные через tx, фиксируются,
+// только если fn не вернула ошибку
+func (p *PostgresRepository) Transaction(fn func(tx AbstractRepository) *model.ApplicationError) *model.ApplicationError {
+	var appErr *model.ApplicationError
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		if appErr = fn(&PostgresRepository{db: tx}); appErr != nil {
+			return appErr
+		}
+		return nil
+	})
+
+	if appErr != nil {
+		return appErr
+	}
+	if err != nil {
+		return DataBaseError
+	}
+	return nil
+}
+
+// SnapshotTransaction выполняет fn в транзакции только для чтения на уровне REPEATABLE READ:
+// все запросы fn видят один снимок базы, даже если между ними фиксируются другие транзакции
+func (p *PostgresRepository) SnapshotTransaction(fn func(tx AbstractRepository) *model.ApplicationError) *model.ApplicationError {
+	var appErr *model.ApplicationError
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		if appErr = fn(&PostgresRepository{db: tx}); appErr != nil {
+			return appErr
+		}
+		return nil
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+
+	if appErr != nil {
+		return appErr
+	}
+	if err != nil {
+		return DataBaseError
+	}
+	return nil
+}
+
+func (p *PostgresRepository) GetEventsAfter(userId int, afterId int, limit int) []*model.Event {
+	var events []*model.Event
+	p.db.Where("user_id = ? AND id > ?", userId, afterId).Order("id").Limit(limit).Find(&events)
+
+	return events
+}
+
+func (p *PostgresRepository) GetLastEventId(userId int) int {
+	var lastId int
+	p.db.Model(&model.Event{}).Where("user_id = ?", userId).Select("COALESCE(MAX(id), 0)").Scan(&lastId)
+
+	return lastId
+}
+
+func (p *PostgresRepository) GetNoteByClientId(clientId string, userId int) (*model.Note, *model.ApplicationError) {
+	var note model.Note
+	result := p.db.Where("client_id = ? AND user_id = ?", clientId, userId).First(&note)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, EntityNotFoundError
+		}
+		return nil, DataBaseError
+	}
+	return &note, nil
+}
+
+func (p *PostgresRepository) GetFolderByClientId(clientId string, userId int) (*model.Folder, *model.ApplicationError) {
+	var folder model.Folder
+	result := p.db.Where("client_id = ? AND user_id = ?", clientId, userId).First(&folder)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, EntityNotFoundError
+		}
+		return nil, DataBaseError
+	}
+	return &folder, nil
+}
+
+func (p *PostgresRepository) GetNotesChangedAfter(userId int, changeSeq int64) []*model.Note {
+	var notes []*model.Note
+	p.db.Where("user_id = ? AND change_seq > ?", userId, changeSeq).Order("change_seq").Find(&notes)
+
+	return notes
+}
+
+func (p *PostgresRepository) GetFoldersChangedAfter(userId int, changeSeq int64) []*model.Folder {
+	var folders []*model.Folder
+	p.db.Where("user_id = ? AND change_seq > ?", userId, changeSeq).Order("change_seq").Find(&folders)
+
+	return folders
+}
+
+func (p *PostgresRepository) GetTombstonesAfter(userId int, changeSeq int64) []*model.Tombstone {
+	var tombstones []*model.Tombstone
+	p.db.Where("user_id = ? AND change_seq > ?", userId, changeSeq).Order("change_seq").Find(&tombstones)
+
+	return tombstones
+}
+
+func (p *PostgresRepository) GetWebhookById(id int, userId int) (*model.Webhook, *model.ApplicationError) {
+	var webhook model.Webhook
+	result := p.db.Where("id = ? AND user_id = ?", id, userId).First(&webhook)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, EntityNotFoundError
+		}
+		return nil, DataBaseError
+	}
+	return &webhook, nil
+}
+
+func (p *PostgresRepository) GetWebhooksByUserId(userId int) []*model.Webhook {
+	var webhooks []*model.Webhook
+	p.db.Where("user_id = ?", userId).Order("id").Find(&webhooks)
+
+	return webhooks
+}
+
+// EnqueueWebhookDeliveries ставит событие в очередь отправки на все активные webhook пользователя,
+// подписанные на этот тип событий
+func (p *PostgresRepository) EnqueueWebhookDeliveries(userId int, eventType model.EventType, payload string) *model.ApplicationError {
+	result := p.db.Exec(`INSERT INTO webhook_deliveries (webhook_id, user_id, event_type, payload, status, next_attempt_at, timestamp)
+		SELECT id, user_id, ?, ?, ?, now(), now() FROM webhooks WHERE user_id = ? AND is_active AND ? = ANY(events)`,
+		eventType, payload, model.WebhookDeliveryStatusPending, userId, eventType)
+
+	if result.Error != nil {
+		return DataBaseError
+	}
+	return nil
+}
+
+// ClaimWebhookDeliveries забирает доставки, время попытки которых наступило, и откладывает их следующую
+// попытку до leaseUntil. Строки блокируются (FOR UPDATE SKIP LOCKED), поэтому каждую доставку забирает
+// одна реплика, а если она не успеет отправить, доставка вернётся в очередь после leaseUntil.
+func (p *PostgresRepository) ClaimWebhookDeliveries(now time.Time, leaseUntil time.Time, limit int) ([]*model.WebhookDelivery, *model.ApplicationError) {
+	var deliveries []*model.WebhookDelivery
+
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", model.WebhookDeliveryStatusPending, now).
+			Order("next_attempt_at").
+			Limit(limit).
+			Find(&deliveries)
+
+		if result.Error != nil || len(deliveries) == 0 {
+			return result.Error
+		}
+
+		ids := make([]int, 0, len(deliveries))
+		for _, delivery := range deliveries {
+			delivery.NextAttemptAt = leaseUntil
+			ids = append(ids, delivery.Id)
+		}
+
+		return tx.Model(&model.WebhookDelivery{}).Where("id IN ?", ids).Update("next_attempt_at", leaseUntil).Error
+	})
+
+	if err != nil {
+		return nil, DataBaseError
+	}
+	return deliveries, nil
+}
+
+func (p *PostgresRepository) GetWebhookDeliveries(webhookId int, limit int) []*model.WebhookDelivery {
+	var deliveries []*model.WebhookDelivery
+	p.db.Where("webhook_id = ?", webhookId).Order("id DESC").Limit(limit).Find(&deliveries)
+
+	return deliveries
+}
+
+func (p *PostgresRepository) GetWebhookDeliveryById(id int, webhookId int) (*model.WebhookDelivery, *model.ApplicationError) {
+	var delivery model.WebhookDelivery
+	result := p.db.Where("id = ? AND webhook_id = ?", id, webhookId).First(&delivery)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, EntityNotFoundError
+		}
+		return nil, DataBaseError
+	}
+	return &delivery, nil
+}
+
+func (p *PostgresRepository) GetWebhookAttempts(deliveryIds []int) []*model.WebhookAttempt {
+	var attempts []*model.WebhookAttempt
+	if len(deliveryIds) == 0 {
+		return attempts
+	}
+
+	p.db.Where("delivery_id IN ?", deliveryIds).Order("id").Find(&attempts)
+
+	return attempts
+}
+
+// DeleteWebhookDeliveriesBefore удаляет из журнала завершённые доставки старше before
+func (p *PostgresRepository) DeleteWebhookDeliveriesBefore(before time.Time) *model.ApplicationError {
+	result := p.db.Where("status <> ? AND timestamp < ?", model.WebhookDeliveryStatusPending, before).
+		Delete(&model.WebhookDelivery{})
+
+	if result.Error != nil {
+		return DataBaseError
+	}
+	return nil
+}
+
+func (p *PostgresRepository) GetUserByLogin(login string) (*model.User, *model.ApplicationError) {
+	var user model.User
+	result := p.db.Where("login = ?", login).First(&user)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, EntityNotFoundError
+		}
+		return nil, DataBaseError
+	}
+	return &user, nil
+}
+
+// GetAuditEntries возвращает записи журнала аудита по фильтру, новые первыми
+func (p *PostgresRepository) GetAuditEntries(filter model.AuditFilter) []*model.AuditEntry {
+	var entries []*model.AuditEntry
+	query := p.db.Model(&model.AuditEntry{})
+
+	if filter.ActorId != nil {
+		query = query.Where("actor_id = ?", *filter.ActorId)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.Entity != "" {
+		query = query.Where("entity = ?", filter.Entity)
+	}
+	if filter.EntityId != nil {
+		query = query.Where("entity_id = ?", *filter.EntityId)
+	}
+	if filter.Ip != "" {
+		query = query.Where("ip = ?", filter.Ip)
+	}
+	if filter.TraceId != "" {
+		query = query.Where("trace_id = ?", filter.TraceId)
+	}
+	if filter.From != nil {
+		query = query.Where("timestamp >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("timestamp < ?", *filter.To)
+	}
+	if filter.BeforeId > 0 {
+		query = query.Where("id < ?", filter.BeforeId)
+	}
+
+	query.Order("id DESC").Limit(filter.Limit).Find(&entries)
+
+	return entries
+}
+
+func (p *PostgresRepository) GetCommentById(id int) (*model.Comment, *model.ApplicationError) {
+	var comment model.Comment
+	result := p.db.Where("id = ?", id).First(&comment)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, EntityNotFoundError
+		}
+		return nil, DataBaseError
+	}
+	return &comment, nil
+}
+
+// GetCommentThreads возвращает первые комментарии веток заметки после afterId в порядке создания
+func (p *PostgresRepository) GetCommentThreads(noteId int, afterId int, limit int) []*model.Comment {
+	var threads []*model.Comment
+	p.db.Where("note_id = ? AND parent_id IS NULL AND id > ?", noteId, afterId).Order("id").Limit(limit).Find(&threads)
+
+	return threads
+}
+
+func (p *PostgresRepository) GetCommentReplies(threadIds []int) []*model.Comment {
+	var replies []*model.Comment
+	if len(threadIds) == 0 {
+		return replies
+	}
+
+	p.db.Where("parent_id IN ?", threadIds).Order("id").Find(&replies)
+
+	return replies
+}
+
+// GetCommentCounts возвращает количество комментариев вместе с ответами по заметкам
+func (p *PostgresRepository) GetCommentCounts(noteIds []int) map[int]int {
+	counts := make(map[int]int)
+	if len(noteIds) == 0 {
+		return counts
+	}
+
+	var rows []struct {
+		NoteId int
+		Count  int
+	}
+	p.db.Model(&model.Comment{}).Select("note_id, count(*) AS count").
+		Where("note_id IN ?", noteIds).Group("note_id").Scan(&rows)
+
+	for _, row := range rows {
+		counts[row.NoteId] = row.Count
+	}
+	return counts
+}
+
+func (p *PostgresRepository) GetUsersByLogins(logins []string) []*model.User {
+	var users []*model.User
+	if len(logins) == 0 {
+		return users
+	}
+
+	p.db.Where("login IN ?", logins).Find(&users)
+
+	return users
+}
+
+// GetNotifications возвращает входящие пользователя, новые первыми. beforeId = 0 означает первую страницу.
+func (p *PostgresRepository) GetNotifications(userId int, beforeId int, limit int, unreadOnly bool) []*model.Notification {
+	var notifications []*model.Notification
+	query := p.db.Where("user_id = ?", userId)
+
+	if beforeId > 0 {
+		query = query.Where("id < ?", beforeId)
+	}
+	if unreadOnly {
+		query = query.Where("is_read = ?", false)
+	}
+
+	query.Order("id DESC").Limit(limit).Find(&notifications)
+
+	return notifications
+}
+
+func (p *PostgresRepository) CountUnreadNotifications(userId int) int {
+	var count int64
+	p.db.Model(&model.Notification{}).Where("user_id = ? AND is_read = ?", userId, false).Count(&count)
+
+	return int(count)
+}
+
+func (p *PostgresRepository) MarkNotificationRead(id int, userId int) *model.ApplicationError {
+	result := p.db.Model(&model.Notification{}).Where("id = ? AND user_id = ?", id, userId).Update("is_read", true)
+
+	if result.Error != nil {
+		return DataBaseError
+	}
+	if result.RowsAffected == 0 {
+		return EntityNotFoundError
+	}
+	return nil
+}
+
+func (p *PostgresRepository) MarkAllNotificationsRead(userId int) *model.ApplicationError {
+	result := p.db.Model(&model.Notification{}).Where("user_id = ? AND is_read = ?", userId, false).Update("is_read", true)
+
+	if result.Error != nil {
+		return DataBaseError
+	}
+	return nil
+}
+
+// GetNoteLock возвращает действующую аренду заметки. Истёкшая аренда не возвращается.
+func (p *PostgresRepository) GetNoteLock(noteId int, now time.Time) (*model.NoteLock, *model.ApplicationError) {
+	var lock model.NoteLock
+	result := p.db.Where("note_id = ? AND expires_at > ?", noteId, now).First(&lock)
+
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, EntityNotFoundError
+		}
+		return nil, DataBaseError
+	}
+	return &lock, nil
+}
+
+func (p *PostgresRepository) GetNoteLocks(noteIds []int, now time.Time) map[int]*model.NoteLock {
+	locks := make(map[int]*model.NoteLock)
+	if len(noteIds) == 0 {
+		return locks
+	}
+
+	var rows []*model.NoteLock
+	p.db.Where("note_id IN ? AND expires_at > ?", noteIds, now).Find(&rows)
+
+	for _, lock := range rows {
+		locks[lock.NoteId] = lock
+	}
+	return locks
+}
+
+// AcquireNoteLock берёт аренду, если заметка свободна, аренда истекла или уже принадлежит lock.Token,
+// и возвращает действующую аренду: выданную или чужую
+func (p *PostgresRepository) AcquireNoteLock(lock *model.NoteLock, now time.Time) (*model.NoteLock, *model.ApplicationError) {
+	result := p.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "note_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"user_id", "token", "expires_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			gorm.Expr("note_locks.expires_at <= ? OR note_locks.token = ?", now, lock.Token),
+		}},
+	}).Create(lock)
+
+	if result.Error != nil {
+		return nil, DataBaseError
+	}
+
+	return p.GetNoteLock(lock.NoteId, now)
+}
+
+func (p *PostgresRepository) ReleaseNoteLock(noteId int, token string) *model.ApplicationError {
+	result := p.db.Where("note_id = ? AND token = ?", noteId, token).Delete(&model.NoteLock{})
+
+	if result.Error != nil {
+		return DataBaseError
+	}
+	return nil
+}
+
+func (p *PostgresRepository) DeleteExpiredNoteLocks(now time.Time) *model.ApplicationError {
+	result := p.db.Where("expires_at <= ?", now).Delete(&model.NoteLock{})
+
+	if result.Error != nil {
+		return DataBaseError
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package service
+
+//go:generate mockgen -source=attachmentService.go -destination=mock/attachmentService.go -package=mock
+
+import (
+	"Notes/config"
+	"Notes/internal/constants"
+	"Notes/internal/model"
+	"Notes/internal/repository"
+	"bufio"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"io"
+	"log"
+	"net/http"
+	"time"
+)
+
+const sniffLength = 512
+const orphanedBlobCleanupInterval = time.Minute
+const orphanedBlobBatchSize = 100
+
+type AbstractAttachmentService interface {
+	Run(ctx context.Context)
+	UploadAttachment(userId int, noteId int, fileName string, size int64, content io.Reader) (int, *model.ApplicationError)
+	GetAttachments(userId int, noteId int) ([]*model.AttachmentApi, *model.ApplicationError)
+	GetAttachmentContent(userId int, noteId int, id int) (*model.Attachment, io.ReadCloser, *model.ApplicationError)
+	GetThumbnail(userId int, noteId int, id int, size int) (*model.AttachmentThumbnail, io.ReadCloser, *model.ApplicationError)
+	DeleteAttachment(userId int, noteId int, id int) *model.ApplicationError
+}
+
+type AttachmentService struct {
+	repo        repository.AbstractRepository
+	storage     repository.AbstractBlobStorage
+	thumbnails  AbstractThumbnailService
+	maxFileSize int64
+}
+
+func NewConcreteAttachmentService(repository repository.AbstractRepository, storage repository.AbstractBlobStorage, thumbnailService AbstractThumbnailService, cfg *config.Config) AbstractAttachmentService {
+	return &AttachmentService{
+		repo:        repository,
+		storage:     storage,
+		thumbnails:  thumbnailService,
+		maxFileSize: int64(cfg.Attachments.MaxFileSizeMb) * 1024 * 1024,
+	}
+}
+
+func (a *AttachmentService) UploadAttachment(userId int, noteId int, fileName string, size int64, content io.Reader) (int, *model.ApplicationError) {
+	if size > a.maxFileSize {
+		params := model.ErrorParams{"maxMb": a.maxFileSize / 1024 / 1024}
+		return constants.FakeId, model.NewLocalizedError(model.ErrorTypeValidation, model.CodeFileTooLarge, params, nil)
+	}
+
+	_, err := a.repo.GetNoteById(noteId, userId)
+	if err != nil {
+		return constants.FakeId, err
+	}
+
+	// Тип содержимого определяем по самому файлу, а не по заголовку клиента
+	buffered := bufio.NewReaderSize(content, sniffLength)
+	head, _ := buffered.Peek(sniffLength)
+	contentType := http.DetectContentType(head)
+
+	storageKey := fmt.Sprintf("attachments/%d/%s", userId, uuid.New().String())
+	attachment, err := model.NewAttachment(noteId, userId, fileName, contentType, size, storageKey)
+	if err != nil {
+		return constants.FakeId, err
+	}
+
+	if err = a.storage.Put(storageKey, buffered); err != nil {
+		return constants.FakeId, err
+	}
+
+	id, err := a.repo.SaveEntity(attachment)
+	if err != nil {
+		_ = a.storage.Delete(storageKey)
+		return constants.FakeId, err
+	}
+
+	if attachment.ThumbnailStatus == model.ThumbnailStatusPending {
+		a.thumbnails.Enqueue(id)
+	}
+
+	return id, nil
+}
+
+func (a *AttachmentService) GetAttachments(userId int, noteId int) ([]*model.AttachmentApi, *model.ApplicationError) {
+	_, err := a.repo.GetNoteById(noteId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.ToAttachmentsApi(a.repo.GetAttachmentsByNoteId(noteId)), nil
+}
+
+func (a *AttachmentService) GetAttachmentContent(userId int, noteId int, id int) (*model.Attachment, io.ReadCloser, *model.ApplicationError) {
+	attachment, err := a.getAttachment(userId, noteId, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := a.storage.Get(attachment.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return attachment, content, nil
+}
+
+func (a *AttachmentService) GetThumbnail(userId int, noteId int, id int, size int) (*model.AttachmentThumbnail, io.ReadCloser, *model.ApplicationError) {
+	if size == 0 {
+		size = a.thumbnails.GetDefaultSize()
+	}
+
+	if !a.thumbnails.IsSizeSupported(size) {
+		params := model.ErrorParams{"size": size}
+		return nil, nil, model.NewLocalizedError(model.ErrorTypeValidation, model.CodeThumbnailSizeUnsupported, params, nil)
+	}
+
+	attachment, err := a.getAttachment(userId, noteId, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch attachment.ThumbnailStatus {
+	case model.ThumbnailStatusNone:
+		return nil, nil, model.NewLocalizedError(model.ErrorTypeValidation, model.CodeThumbnailNotAvailable, nil, nil)
+	case model.ThumbnailStatusPending:
+		return nil, nil, model.NewLocalizedError(model.ErrorTypeNotFound, model.CodeThumbnailPending, nil, nil)
+	case model.ThumbnailStatusFailed:
+		return nil, nil, model.NewLocalizedError(model.ErrorTypeNotFound, model.CodeThumbnailFailed, nil, nil)
+	}
+
+	thumbnail, err := a.repo.GetAttachmentThumbnail(attachment.Id, size)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := a.storage.Get(thumbnail.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return thumbnail, content, nil
+}
+
+func (a *AttachmentService) DeleteAttachment(userId int, noteId int, id int) *model.ApplicationError {
+	attachment, err := a.getAttachment(userId, noteId, id)
+	if err != nil {
+		if err.Type == model.ErrorTypeNotFound {
+			return nil
+		}
+		return err
+	}
+
+	thumbnails := a.repo.GetAttachmentThumbnails(attachment.Id)
+
+	if err = a.repo.DeleteEntity(attachment); err != nil {
+		return err
+	}
+
+	for _, thumbnail := range thumbnails {
+		_ = a.storage.Delete(thumbnail.StorageKey)
+	}
+
+	return a.storage.Delete(attachment.StorageKey)
+}
+
+// Run удаляет из хранилища файлы вложений, строки которых уже удалены (например, вместе с заметкой),
+// до отмены контекста.
+func (a *AttachmentService) Run(ctx context.Context) {
+	ticker := time.NewTicker(orphanedBlobCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			a.deleteOrphanedBlobs()
+		}
+	}
+}
+
+// deleteOrphanedBlobs удаляет одну пачку файлов из очереди. Файл, который не удалось удалить,
+// остаётся в очереди до следующего прохода.
+func (a *AttachmentService) deleteOrphanedBlobs() {
+	blobs := a.repo.GetOrphanedBlobs(orphanedBlobBatchSize)
+
+	deleted := make([]int, 0, len(blobs))
+	for _, blob := range blobs {
+		if err := a.storage.Delete(blob.StorageKey); err != nil {
+			log.Printf("Не удалось удалить файл %s: %v", blob.StorageKey, err)
+			continue
+		}
+		deleted = append(deleted, blob.Id)
+	}
+
+	if err := a.repo.DeleteOrphanedBlobs(deleted); err != nil {
+		log.Printf("Не удалось очистить очередь удаляемых файлов: %v", err)
+	}
+}
+
+func (a *AttachmentService) getAttachment(userId int, noteId int, id int) (*model.Attachment, *model.ApplicationError) {
+	attachment, err := a.repo.GetAttachmentById(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if attachment.UserId != userId || attachment.NoteId != noteId {
+		return nil, repository.EntityNotFoundError
+	}
+
+	return attachment, nil
+}
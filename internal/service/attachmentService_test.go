@@ -0,0 +1,374 @@
+package service
+
+import (
+	"Notes/config"
+	"Notes/internal/constants"
+	"Notes/internal/model"
+	"Notes/internal/repository"
+	mocks "Notes/internal/service/mock"
+	"bytes"
+	"github.com/golang/mock/gomock"
+	"io"
+	"testing"
+)
+
+type attachmentTestArgs struct {
+	userId       int
+	noteId       int
+	attachmentId int
+	fileName     string
+	size         int64
+	content      []byte
+	thumbnail    int
+}
+
+type attachmentTestExpect struct {
+	id    int
+	error *model.ApplicationError
+}
+
+var pngSignature = []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n', 0, 0, 0, 0}
+
+func initAttachmentServiceTest(t *testing.T) (AbstractAttachmentService, *mocks.MockAbstractRepository, *mocks.MockAbstractBlobStorage, *mocks.MockAbstractThumbnailService) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockAbstractRepository(ctrl)
+	mockStorage := mocks.NewMockAbstractBlobStorage(ctrl)
+	mockThumbnailService := mocks.NewMockAbstractThumbnailService(ctrl)
+	cfg := &config.Config{Attachments: config.Attachments{MaxFileSizeMb: 1}}
+
+	return NewConcreteAttachmentService(mockRepository, mockStorage, mockThumbnailService, cfg), mockRepository, mockStorage, mockThumbnailService
+}
+
+func TestConcreteAttachmentService_UploadAttachment(t *testing.T) {
+	attachmentService, repo, storage, thumbnails := initAttachmentServiceTest(t)
+
+	tests := []struct {
+		name    string
+		mock    func()
+		args    attachmentTestArgs
+		want    attachmentTestExpect
+		wantErr bool
+	}{
+		{
+			name: "file too large",
+			mock: func() {},
+			args: attachmentTestArgs{
+				userId:   1,
+				noteId:   1,
+				fileName: "file.txt",
+				size:     2 * 1024 * 1024,
+				content:  []byte("content"),
+			},
+			want: attachmentTestExpect{
+				id:    constants.FakeId,
+				error: model.NewApplicationError(model.ErrorTypeValidation, "Размер файла не может превышать 1 МБ", nil),
+			},
+			wantErr: true,
+		},
+		{
+			name: "note not found",
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(nil, repository.EntityNotFoundError)
+			},
+			args: attachmentTestArgs{
+				userId:   1,
+				noteId:   1,
+				fileName: "file.txt",
+				size:     7,
+				content:  []byte("content"),
+			},
+			want: attachmentTestExpect{
+				id:    constants.FakeId,
+				error: repository.EntityNotFoundError,
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty file name",
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(&model.Note{Id: 1, UserId: 1}, nil)
+			},
+			args: attachmentTestArgs{
+				userId:   1,
+				noteId:   1,
+				fileName: "",
+				size:     7,
+				content:  []byte("content"),
+			},
+			want: attachmentTestExpect{
+				id:    constants.FakeId,
+				error: model.NewApplicationError(model.ErrorTypeValidation, "Имя файла не может быть пустым", nil),
+			},
+			wantErr: true,
+		},
+		{
+			name: "blob removed when saving fails",
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(&model.Note{Id: 1, UserId: 1}, nil)
+				storage.EXPECT().Put(gomock.Any(), gomock.Any()).Return(nil)
+				repo.EXPECT().SaveEntity(gomock.Any()).Return(constants.FakeId, repository.DataBaseError)
+				storage.EXPECT().Delete(gomock.Any()).Return(nil)
+			},
+			args: attachmentTestArgs{
+				userId:   1,
+				noteId:   1,
+				fileName: "file.txt",
+				size:     7,
+				content:  []byte("content"),
+			},
+			want: attachmentTestExpect{
+				id:    constants.FakeId,
+				error: repository.DataBaseError,
+			},
+			wantErr: true,
+		},
+		{
+			name: "text file saved without thumbnails",
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(&model.Note{Id: 1, UserId: 1}, nil)
+				storage.EXPECT().Put(gomock.Any(), gomock.Any()).DoAndReturn(func(key string, content io.Reader) *model.ApplicationError {
+					data, _ := io.ReadAll(content)
+					if string(data) != "content" {
+						t.Errorf("storage.Put() content = %s, want content", data)
+					}
+					return nil
+				})
+				repo.EXPECT().SaveEntity(gomock.Any()).DoAndReturn(func(entity model.BusinessEntity) (int, *model.ApplicationError) {
+					attachment := entity.(*model.Attachment)
+					if attachment.ThumbnailStatus != model.ThumbnailStatusNone {
+						t.Errorf("attachment.ThumbnailStatus = %s, want none", attachment.ThumbnailStatus)
+					}
+					return 5, nil
+				})
+			},
+			args: attachmentTestArgs{
+				userId:   1,
+				noteId:   1,
+				fileName: "file.txt",
+				size:     7,
+				content:  []byte("content"),
+			},
+			want: attachmentTestExpect{
+				id: 5,
+			},
+			wantErr: false,
+		},
+		{
+			name: "image saved and queued for thumbnails",
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(&model.Note{Id: 1, UserId: 1}, nil)
+				storage.EXPECT().Put(gomock.Any(), gomock.Any()).Return(nil)
+				repo.EXPECT().SaveEntity(gomock.Any()).DoAndReturn(func(entity model.BusinessEntity) (int, *model.ApplicationError) {
+					attachment := entity.(*model.Attachment)
+					if attachment.ContentType != "image/png" || attachment.ThumbnailStatus != model.ThumbnailStatusPending {
+						t.Errorf("attachment = %v, want pending image/png", attachment)
+					}
+					return 6, nil
+				})
+				thumbnails.EXPECT().Enqueue(6)
+			},
+			args: attachmentTestArgs{
+				userId:   1,
+				noteId:   1,
+				fileName: "image.png",
+				size:     int64(len(pngSignature)),
+				content:  pngSignature,
+			},
+			want: attachmentTestExpect{
+				id: 6,
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attachmentService := attachmentService
+
+			tt.mock()
+
+			got, err := attachmentService.UploadAttachment(tt.args.userId, tt.args.noteId, tt.args.fileName, tt.args.size, bytes.NewReader(tt.args.content))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AttachmentService.UploadAttachment() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got != tt.want.id || (err != nil && (err.Type != tt.want.error.Type || err.Message != tt.want.error.Message)) {
+				t.Errorf("AttachmentService.UploadAttachment() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestConcreteAttachmentService_GetThumbnail(t *testing.T) {
+	attachmentService, repo, storage, thumbnails := initAttachmentServiceTest(t)
+
+	tests := []struct {
+		name    string
+		mock    func()
+		args    attachmentTestArgs
+		want    attachmentTestExpect
+		wantErr bool
+	}{
+		{
+			name: "unsupported size",
+			mock: func() {
+				thumbnails.EXPECT().IsSizeSupported(100).Return(false)
+			},
+			args: attachmentTestArgs{
+				userId:       1,
+				noteId:       1,
+				attachmentId: 1,
+				thumbnail:    100,
+			},
+			want: attachmentTestExpect{
+				error: model.NewApplicationError(model.ErrorTypeValidation, "Размер миниатюры 100 не поддерживается", nil),
+			},
+			wantErr: true,
+		},
+		{
+			name: "attachment of another user",
+			mock: func() {
+				thumbnails.EXPECT().GetDefaultSize().Return(128)
+				thumbnails.EXPECT().IsSizeSupported(128).Return(true)
+				repo.EXPECT().GetAttachmentById(1).Return(&model.Attachment{Id: 1, NoteId: 1, UserId: 2}, nil)
+			},
+			args: attachmentTestArgs{
+				userId:       1,
+				noteId:       1,
+				attachmentId: 1,
+			},
+			want: attachmentTestExpect{
+				error: repository.EntityNotFoundError,
+			},
+			wantErr: true,
+		},
+		{
+			name: "thumbnail not generated yet",
+			mock: func() {
+				thumbnails.EXPECT().IsSizeSupported(256).Return(true)
+				repo.EXPECT().GetAttachmentById(1).Return(&model.Attachment{Id: 1, NoteId: 1, UserId: 1, ThumbnailStatus: model.ThumbnailStatusPending}, nil)
+			},
+			args: attachmentTestArgs{
+				userId:       1,
+				noteId:       1,
+				attachmentId: 1,
+				thumbnail:    256,
+			},
+			want: attachmentTestExpect{
+				error: model.NewApplicationError(model.ErrorTypeNotFound, "Миниатюра еще не сформирована", nil),
+			},
+			wantErr: true,
+		},
+		{
+			name: "thumbnail returned",
+			mock: func() {
+				thumbnails.EXPECT().IsSizeSupported(256).Return(true)
+				repo.EXPECT().GetAttachmentById(1).Return(&model.Attachment{Id: 1, NoteId: 1, UserId: 1, ThumbnailStatus: model.ThumbnailStatusReady}, nil)
+				repo.EXPECT().GetAttachmentThumbnail(1, 256).Return(&model.AttachmentThumbnail{Id: 2, AttachmentId: 1, Size: 256, StorageKey: "thumbnails/1/256"}, nil)
+				storage.EXPECT().Get("thumbnails/1/256").Return(io.NopCloser(bytes.NewReader(nil)), nil)
+			},
+			args: attachmentTestArgs{
+				userId:       1,
+				noteId:       1,
+				attachmentId: 1,
+				thumbnail:    256,
+			},
+			want: attachmentTestExpect{
+				id: 2,
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attachmentService := attachmentService
+
+			tt.mock()
+
+			got, _, err := attachmentService.GetThumbnail(tt.args.userId, tt.args.noteId, tt.args.attachmentId, tt.args.thumbnail)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AttachmentService.GetThumbnail() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if (got != nil && got.Id != tt.want.id) || (err != nil && (err.Type != tt.want.error.Type || err.Message != tt.want.error.Message)) {
+				t.Errorf("AttachmentService.GetThumbnail() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestConcreteAttachmentService_DeleteAttachment(t *testing.T) {
+	attachmentService, repo, storage, _ := initAttachmentServiceTest(t)
+
+	tests := []struct {
+		name    string
+		mock    func()
+		args    attachmentTestArgs
+		wantErr bool
+	}{
+		{
+			name: "missing attachment is ignored",
+			mock: func() {
+				repo.EXPECT().GetAttachmentById(1).Return(nil, repository.EntityNotFoundError)
+			},
+			args: attachmentTestArgs{
+				userId:       1,
+				noteId:       1,
+				attachmentId: 1,
+			},
+			wantErr: false,
+		},
+		{
+			name: "attachment and thumbnails removed",
+			mock: func() {
+				attachment := &model.Attachment{Id: 1, NoteId: 1, UserId: 1, StorageKey: "attachments/1/key"}
+				repo.EXPECT().GetAttachmentById(1).Return(attachment, nil)
+				repo.EXPECT().GetAttachmentThumbnails(1).Return([]*model.AttachmentThumbnail{
+					{Id: 2, AttachmentId: 1, StorageKey: "thumbnails/1/128"},
+				})
+				repo.EXPECT().DeleteEntity(attachment).Return(nil)
+				storage.EXPECT().Delete("thumbnails/1/128").Return(nil)
+				storage.EXPECT().Delete("attachments/1/key").Return(nil)
+			},
+			args: attachmentTestArgs{
+				userId:       1,
+				noteId:       1,
+				attachmentId: 1,
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attachmentService := attachmentService
+
+			tt.mock()
+
+			err := attachmentService.DeleteAttachment(tt.args.userId, tt.args.noteId, tt.args.attachmentId)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("AttachmentService.DeleteAttachment() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConcreteAttachmentService_deleteOrphanedBlobs(t *testing.T) {
+	attachmentService, repo, storage, _ := initAttachmentServiceTest(t)
+
+	repo.EXPECT().GetOrphanedBlobs(orphanedBlobBatchSize).Return([]*model.OrphanedBlob{
+		{Id: 1, StorageKey: "attachments/1/key"},
+		{Id: 2, StorageKey: "thumbnails/1/128"},
+	})
+	storage.EXPECT().Delete("attachments/1/key").Return(nil)
+	storage.EXPECT().Delete("thumbnails/1/128").Return(repository.BlobStorageError)
+	// Файл, который не удалось удалить, остаётся в очереди
+	repo.EXPECT().DeleteOrphanedBlobs([]int{1}).Return(nil)
+
+	attachmentService.(*AttachmentService).deleteOrphanedBlobs()
+}
@@ -0,0 +1,121 @@
+package service
+
+//go:generate mockgen -source=auditService.go -destination=mock/auditService.go -package=mock
+
+import (
+	"Notes/config"
+	"Notes/internal/model"
+	"Notes/internal/repository"
+	"log"
+)
+
+type AbstractAuditService interface {
+	RecordLogin(entry *model.AuditEntry, login string)
+	GetUserAudit(userId int, filter model.AuditFilter) ([]*model.AuditEntryApi, *model.ApplicationError)
+	QueryAudit(userId int, filter model.AuditFilter) ([]*model.AuditEntryApi, *model.ApplicationError)
+}
+
+// AuditService записывает попытки входа и читает журнал аудита. Изменения данных записываются
+// в журнал сервисами, которые их выполняют, в той же транзакции (см. saveAuditEntry).
+type AuditService struct {
+	repo        repository.AbstractRepository
+	adminLogins map[string]bool
+}
+
+func NewConcreteAuditService(repository repository.AbstractRepository, cfg *config.Config) AbstractAuditService {
+	adminLogins := make(map[string]bool)
+	for _, login := range cfg.Audit.AdminLogins {
+		adminLogins[login] = true
+	}
+
+	return &AuditService{
+		repo:        repository,
+		adminLogins: adminLogins,
+	}
+}
+
+// record сохраняет запись о попытке входа. Вход к этому моменту уже выполнен или отклонён,
+// поэтому ошибка записи только логируется.
+func (a *AuditService) record(entry *model.AuditEntry) {
+	if _, err := a.repo.SaveEntity(entry); err != nil {
+		log.Printf("Не удалось записать в журнал аудита %s %s %v: %v", entry.Action, entry.Entity, entry.EntityId, err)
+	}
+}
+
+// RecordLogin записывает попытку входа. Пользователь определяется по логину, поэтому неудачные
+// попытки попадают и в его собственный журнал.
+func (a *AuditService) RecordLogin(entry *model.AuditEntry, login string) {
+	if user, err := a.repo.GetUserByLogin(login); err == nil {
+		userId := user.Id
+		entry.ActorId = &userId
+		entry.EntityId = &userId
+	}
+
+	entry.SetChange(nil, model.AuditSummary{"Login": login})
+	a.record(entry)
+}
+
+// GetUserAudit возвращает действия пользователя
+func (a *AuditService) GetUserAudit(userId int, filter model.AuditFilter) ([]*model.AuditEntryApi, *model.ApplicationError) {
+	filter.ActorId = &userId
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	return model.ToAuditEntriesApi(a.repo.GetAuditEntries(filter)), nil
+}
+
+// QueryAudit возвращает журнал всех пользователей. Доступен только администраторам из настроек.
+func (a *AuditService) QueryAudit(userId int, filter model.AuditFilter) ([]*model.AuditEntryApi, *model.ApplicationError) {
+	user, err := a.repo.GetUserById(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !a.adminLogins[user.Login] {
+		return nil, model.NewLocalizedError(model.ErrorTypeForbidden, model.CodeForbidden, nil, nil)
+	}
+
+	if err = filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	return model.ToAuditEntriesApi(a.repo.GetAuditEntries(filter)), nil
+}
+
+// originBinder - сервис, который записывает изменения в журнал аудита с данными запроса
+type originBinder interface {
+	withOrigin(origin model.AuditOrigin) any
+}
+
+// WithOrigin возвращает копию сервиса, которая записывает изменения в журнал аудита с данными
+// запроса origin. Сервис, который ничего не пишет в журнал, возвращается как есть.
+func WithOrigin[T any](service T, origin model.AuditOrigin) T {
+	if binder, ok := any(service).(originBinder); ok {
+		return binder.withOrigin(origin).(T)
+	}
+	return service
+}
+
+// saveAuditEntry записывает действие в журнал аудита через tx. Вызывается в транзакции самого
+// изменения, поэтому изменение не сохраняется без записи о нём.
+func saveAuditEntry(tx repository.AbstractRepository, origin model.AuditOrigin, entry *model.AuditEntry) *model.ApplicationError {
+	entry.SetOrigin(origin)
+	_, err := tx.SaveEntity(entry)
+	return err
+}
+
+// saveEventAudit записывает в журнал аудита изменение из журнала изменений. Состояние записи
+// до изменения - состояние после предыдущего изменения той же записи в журнале аудита.
+func saveEventAudit(tx repository.AbstractRepository, origin model.AuditOrigin, event *model.Event) *model.ApplicationError {
+	entry := event.AuditEntry()
+
+	if entry.Before == nil && entry.EntityId != nil && event.Type != model.EventNoteCreated && event.Type != model.EventFolderCreated {
+		filter := model.AuditFilter{Entity: entry.Entity, EntityId: entry.EntityId, Limit: 1}
+		if previous := tx.GetAuditEntries(filter); len(previous) > 0 {
+			entry.Before = previous[0].After
+		}
+	}
+
+	return saveAuditEntry(tx, origin, entry)
+}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"Notes/config"
+	"Notes/internal/constants"
+	"Notes/internal/model"
+	"Notes/internal/repository"
+	mocks "Notes/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"strings"
+	"testing"
+	"time"
+)
+
+func initAuditServiceTest(t *testing.T) (AbstractAuditService, *mocks.MockAbstractRepository) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockAbstractRepository(ctrl)
+	cfg := &config.Config{Audit: config.Audit{AdminLogins: []string{"administrator"}}}
+
+	return NewConcreteAuditService(mockRepository, cfg), mockRepository
+}
+
+// expectAuditLog разрешает транзакции и записи журнала аудита, которые сервисы делают вместе с изменениями
+func expectAuditLog(repo *mocks.MockAbstractRepository) {
+	repo.EXPECT().Transaction(gomock.Any()).DoAndReturn(func(fn func(tx repository.AbstractRepository) *model.ApplicationError) *model.ApplicationError {
+		return fn(repo)
+	}).AnyTimes()
+	repo.EXPECT().SaveEntity(gomock.AssignableToTypeOf(&model.AuditEntry{})).Return(1, nil).AnyTimes()
+}
+
+func TestConcreteAuditService_RecordLogin(t *testing.T) {
+	auditService, repo := initAuditServiceTest(t)
+
+	tests := []struct {
+		name        string
+		mock        func()
+		login       string
+		wantActorId *int
+	}{
+		{
+			name: "failed login of a known user is attributed to the user",
+			mock: func() {
+				repo.EXPECT().GetUserByLogin("user123456").Return(&model.User{Id: 4, Login: "user123456"}, nil)
+			},
+			login:       "user123456",
+			wantActorId: func() *int { id := 4; return &id }(),
+		},
+		{
+			name: "unknown login is recorded without an actor",
+			mock: func() {
+				repo.EXPECT().GetUserByLogin("nobody1234").Return(nil, repository.EntityNotFoundError)
+			},
+			login: "nobody1234",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			entry := model.NewAuditEntry(nil, model.AuditLoginFailed, model.AuditEntityUser, nil)
+			repo.EXPECT().SaveEntity(entry).Return(1, nil)
+
+			auditService.RecordLogin(entry, tt.login)
+
+			if (entry.ActorId == nil) != (tt.wantActorId == nil) || (entry.ActorId != nil && *entry.ActorId != *tt.wantActorId) {
+				t.Errorf("ActorId = %v, want %v", entry.ActorId, tt.wantActorId)
+			}
+			if entry.After == nil || *entry.After != `{"Login":"`+tt.login+`"}` {
+				t.Errorf("After = %v, want the attempted login", entry.After)
+			}
+		})
+	}
+}
+
+func TestSaveEventAudit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockAbstractRepository(ctrl)
+	origin := model.AuditOrigin{Ip: "203.0.113.5", UserAgent: "notes-client", TraceId: "trace-1"}
+	folderId := 2
+	note := &model.Note{Id: 1, UserId: 1, Title: "План", Content: "секрет", FolderId: &folderId}
+	previous := `{"FolderId":null,"Tags":null,"Title":"Черновик"}`
+
+	t.Run("update takes the previous state from the last audit entry", func(t *testing.T) {
+		repo.EXPECT().GetAuditEntries(gomock.Any()).DoAndReturn(func(filter model.AuditFilter) []*model.AuditEntry {
+			if filter.Entity != model.AuditEntityNote || filter.EntityId == nil || *filter.EntityId != 1 || filter.Limit != 1 {
+				t.Errorf("filter = %+v, want the last entry of note 1", filter)
+			}
+			return []*model.AuditEntry{{Id: 3, After: &previous}}
+		})
+		repo.EXPECT().SaveEntity(gomock.Any()).DoAndReturn(func(entity model.BusinessEntity) (int, *model.ApplicationError) {
+			entry := entity.(*model.AuditEntry)
+			if entry.Action != model.AuditNoteUpdated || *entry.ActorId != 1 || entry.TraceId != "trace-1" || entry.Ip != "203.0.113.5" {
+				t.Errorf("entry = %+v, want note.updated by user 1 with the request origin", entry)
+			}
+			if entry.Before == nil || *entry.Before != previous {
+				t.Errorf("Before = %v, want %s", entry.Before, previous)
+			}
+			if entry.After == nil || !strings.Contains(*entry.After, "План") || strings.Contains(*entry.After, "секрет") {
+				t.Errorf("After = %v, want the title without the content", entry.After)
+			}
+			return 4, nil
+		})
+
+		if err := saveEventAudit(repo, origin, model.NewNoteEvent(model.EventNoteUpdated, note)); err != nil {
+			t.Errorf("saveEventAudit() error = %v", err)
+		}
+	})
+
+	t.Run("deletion records the deleted state without a lookup", func(t *testing.T) {
+		repo.EXPECT().SaveEntity(gomock.Any()).DoAndReturn(func(entity model.BusinessEntity) (int, *model.ApplicationError) {
+			entry := entity.(*model.AuditEntry)
+			if entry.Action != model.AuditNoteDeleted || entry.Before == nil || entry.After != nil {
+				t.Errorf("entry = %+v, want note.deleted with the state before deletion only", entry)
+			}
+			return 5, nil
+		})
+
+		if err := saveEventAudit(repo, origin, model.NewNoteEvent(model.EventNoteDeleted, note)); err != nil {
+			t.Errorf("saveEventAudit() error = %v", err)
+		}
+	})
+
+	t.Run("failed write is returned to roll back the change", func(t *testing.T) {
+		repo.EXPECT().SaveEntity(gomock.Any()).Return(constants.FakeId, repository.DataBaseError)
+
+		if err := saveEventAudit(repo, origin, model.NewNoteEvent(model.EventNoteCreated, note)); err == nil {
+			t.Errorf("saveEventAudit() error = nil, want the database error")
+		}
+	})
+}
+
+func TestWithOrigin(t *testing.T) {
+	noteService := &NoteService{}
+	origin := model.AuditOrigin{Ip: "203.0.113.5", TraceId: "trace-1"}
+
+	bound := WithOrigin[AbstractNoteService](noteService, origin).(*NoteService)
+	if bound == noteService || bound.origin != origin {
+		t.Errorf("WithOrigin() = %+v, want a copy with the origin", bound)
+	}
+	if noteService.origin != (model.AuditOrigin{}) {
+		t.Errorf("origin of the shared service = %+v, want empty", noteService.origin)
+	}
+}
+
+func TestConcreteAuditService_GetUserAudit(t *testing.T) {
+	auditService, repo := initAuditServiceTest(t)
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	tests := []struct {
+		name    string
+		mock    func()
+		filter  model.AuditFilter
+		wantErr bool
+	}{
+		{
+			name: "only own entries are requested, limit gets the default",
+			mock: func() {
+				repo.EXPECT().GetAuditEntries(gomock.Any()).DoAndReturn(func(filter model.AuditFilter) []*model.AuditEntry {
+					if filter.ActorId == nil || *filter.ActorId != 1 || filter.Limit != model.DefaultAuditLimit {
+						t.Errorf("filter = %+v, want actor 1 and the default limit", filter)
+					}
+					return []*model.AuditEntry{{Id: 5, Action: model.AuditNoteMoved}}
+				})
+			},
+			filter: model.AuditFilter{ActorId: func() *int { id := 2; return &id }(), Action: model.AuditNoteMoved},
+		},
+		{
+			name:    "unknown action",
+			mock:    func() {},
+			filter:  model.AuditFilter{Action: "note.shared"},
+			wantErr: true,
+		},
+		{
+			name:    "period ends before it starts",
+			mock:    func() {},
+			filter:  model.AuditFilter{From: &to, To: &from},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			_, err := auditService.GetUserAudit(1, tt.filter)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("GetUserAudit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConcreteAuditService_QueryAudit(t *testing.T) {
+	auditService, repo := initAuditServiceTest(t)
+
+	t.Run("administrator gets entries of other users", func(t *testing.T) {
+		repo.EXPECT().GetUserById(1).Return(&model.User{Id: 1, Login: "administrator"}, nil)
+		repo.EXPECT().GetAuditEntries(gomock.Any()).DoAndReturn(func(filter model.AuditFilter) []*model.AuditEntry {
+			if filter.ActorId == nil || *filter.ActorId != 7 || filter.Limit != model.MaxAuditLimit {
+				t.Errorf("filter = %+v, want actor 7 and the maximal limit", filter)
+			}
+			return []*model.AuditEntry{{Id: 3, Action: model.AuditLoginFailed}}
+		})
+
+		actorId := 7
+		entries, err := auditService.QueryAudit(1, model.AuditFilter{ActorId: &actorId, Limit: 1000})
+		if err != nil || len(entries) != 1 {
+			t.Errorf("QueryAudit() = %v, %v, want one entry", entries, err)
+		}
+	})
+
+	t.Run("regular user is forbidden", func(t *testing.T) {
+		repo.EXPECT().GetUserById(2).Return(&model.User{Id: 2, Login: "user123456"}, nil)
+
+		_, err := auditService.QueryAudit(2, model.AuditFilter{})
+		if err == nil || err.Type != model.ErrorTypeForbidden {
+			t.Errorf("QueryAudit() error = %v, want forbidden", err)
+		}
+	})
+}
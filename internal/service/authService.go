@@ -28,6 +28,10 @@ func (a *ConcreteAuthService) AuthUser(login, password string) (string, *model.A
 	user, err := a.repo.GetUser(login, password)
 
 	if err != nil {
+		// Не сообщаем, что именно неверно: логин или пароль
+		if err.Type == model.ErrorTypeNotFound {
+			return "", model.NewLocalizedError(model.ErrorTypeAuth, model.CodeInvalidCredentials, nil, nil)
+		}
 		return "", err
 	}
 
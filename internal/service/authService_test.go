@@ -99,6 +99,21 @@ func TestConcreteAuthService_AuthUser(t *testing.T) {
 	}
 }
 
+func TestConcreteAuthService_AuthUser_InvalidCredentials(t *testing.T) {
+	authService, repo, _ := initAuthServiceTests(t)
+
+	repo.EXPECT().GetUser("login", "wrong password").Return(nil, model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil))
+
+	_, err := authService.AuthUser("login", "wrong password")
+	if err == nil || err.Type != model.ErrorTypeAuth || err.Code != model.CodeInvalidCredentials {
+		t.Errorf("AuthService.AuthUser() error = %v, want %v", err, model.CodeInvalidCredentials)
+	}
+
+	if code := model.GetAppropriateApiError(err).Code; code != 401 {
+		t.Errorf("GetAppropriateApiError() code = %d, want 401", code)
+	}
+}
+
 func TestConcreteAuthService_ValidateToken(t *testing.T) {
 	authService, repo, jwtService := initAuthServiceTests(t)
 
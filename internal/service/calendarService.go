@@ -0,0 +1,144 @@
+package service
+
+//go:generate mockgen -source=calendarService.go -destination=mock/calendarService.go -package=mock
+
+import (
+	"Notes/config"
+	"Notes/internal/model"
+	"Notes/internal/repository"
+	"Notes/internal/utils"
+	"fmt"
+	"strings"
+)
+
+const calendarProductId = "-//Notes//Notes Calendar//RU"
+const calendarName = "Заметки"
+
+type AbstractCalendarService interface {
+	RegenerateFeedUrl(userId int) (string, *model.ApplicationError)
+	RevokeFeed(userId int) *model.ApplicationError
+	GetFeed(token string) (string, *model.ApplicationError)
+}
+
+type CalendarService struct {
+	repo      repository.AbstractRepository
+	publicUrl string
+	noteUrl   string
+}
+
+func NewConcreteCalendarService(repository repository.AbstractRepository, cfg *config.Config) AbstractCalendarService {
+	return &CalendarService{
+		repo:      repository,
+		publicUrl: strings.TrimRight(cfg.Calendar.PublicUrl, "/"),
+		noteUrl:   cfg.Calendar.NoteUrl,
+	}
+}
+
+// RegenerateFeedUrl выпускает новый токен ленты. Старая ссылка перестаёт работать сразу,
+// так как у пользователя хранится только один хэш токена.
+func (c *CalendarService) RegenerateFeedUrl(userId int) (string, *model.ApplicationError) {
+	token, tokenHash, err := model.NewCalendarToken()
+	if err != nil {
+		return "", err
+	}
+
+	feed, err := c.repo.GetCalendarFeedByUserId(userId)
+	if err != nil {
+		if err.Type != model.ErrorTypeNotFound {
+			return "", err
+		}
+		feed = &model.CalendarFeed{UserId: userId}
+	}
+
+	feed.TokenHash = tokenHash
+
+	_, err = c.repo.SaveEntity(feed)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s/calendar/%s.ics", c.publicUrl, token), nil
+}
+
+func (c *CalendarService) RevokeFeed(userId int) *model.ApplicationError {
+	feed, err := c.repo.GetCalendarFeedByUserId(userId)
+	if err != nil {
+		if err.Type == model.ErrorTypeNotFound {
+			return nil
+		}
+		return err
+	}
+
+	return c.repo.DeleteEntity(feed)
+}
+
+// GetFeed возвращает ленту в формате RFC 5545: заметка со сроком выполнения - VTODO,
+// заметка только с напоминанием - VEVENT с правилом повторения
+func (c *CalendarService) GetFeed(token string) (string, *model.ApplicationError) {
+	feed, err := c.repo.GetCalendarFeedByTokenHash(model.HashCalendarToken(token))
+	if err != nil {
+		return "", err
+	}
+
+	reminders := c.repo.GetRemindersByUserId(feed.UserId)
+
+	notes := make(map[int]*model.Note)
+	if len(reminders) > 0 {
+		for _, note := range c.repo.GetNotesByUserId(feed.UserId) {
+			notes[note.Id] = note
+		}
+	}
+
+	calendar := utils.NewICalendar(calendarProductId, calendarName)
+	for _, reminder := range reminders {
+		note, exists := notes[reminder.NoteId]
+		if !exists {
+			continue
+		}
+
+		c.writeNote(calendar, note, reminder)
+	}
+
+	return calendar.String(), nil
+}
+
+func (c *CalendarService) writeNote(calendar *utils.ICalendar, note *model.Note, reminder *model.Reminder) {
+	component := "VEVENT"
+	if reminder.DueAt != nil {
+		component = "VTODO"
+	}
+
+	stamp := reminder.Timestamp
+	if note.Timestamp.After(stamp) {
+		stamp = note.Timestamp
+	}
+
+	calendar.Begin(component)
+	calendar.Text("UID", fmt.Sprintf("note-%d@notes", note.Id))
+	calendar.Time("DTSTAMP", stamp)
+	calendar.Time("LAST-MODIFIED", stamp)
+	calendar.Text("SUMMARY", note.Title)
+
+	if note.Content != "" {
+		calendar.Text("DESCRIPTION", note.Content)
+	}
+
+	if c.noteUrl != "" {
+		calendar.Property("URL", fmt.Sprintf(c.noteUrl, note.Id))
+	}
+
+	if len(note.Tags) > 0 {
+		calendar.List("CATEGORIES", note.Tags)
+	}
+
+	if reminder.DueAt != nil {
+		calendar.Time("DUE", *reminder.DueAt)
+	} else {
+		calendar.Time("DTSTART", reminder.StartsAt)
+		if reminder.Recurrence != "" {
+			calendar.Property("RRULE", reminder.Recurrence)
+		}
+	}
+
+	calendar.End(component)
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"Notes/config"
+	"Notes/internal/model"
+	mocks "Notes/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"strings"
+	"testing"
+	"time"
+)
+
+func initCalendarServiceTest(t *testing.T) (AbstractCalendarService, *mocks.MockAbstractRepository) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockAbstractRepository(ctrl)
+	cfg := &config.Config{
+		Calendar: config.Calendar{
+			PublicUrl: "https://notes.example.com/",
+			NoteUrl:   "https://notes.example.com/notes/%d",
+		},
+	}
+
+	return NewConcreteCalendarService(mockRepository, cfg), mockRepository
+}
+
+func TestConcreteCalendarService_RegenerateFeedUrl(t *testing.T) {
+	calendarService, repo := initCalendarServiceTest(t)
+
+	tests := []struct {
+		name    string
+		mock    func(savedHash *string)
+		want    *model.ApplicationError
+		wantErr bool
+	}{
+		{
+			name: "first feed created",
+			mock: func(savedHash *string) {
+				repo.EXPECT().GetCalendarFeedByUserId(1).Return(nil, model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil))
+				repo.EXPECT().SaveEntity(gomock.Any()).DoAndReturn(func(entity model.BusinessEntity) (int, *model.ApplicationError) {
+					feed := entity.(*model.CalendarFeed)
+					if feed.Id != 0 || feed.UserId != 1 {
+						t.Errorf("CalendarService.RegenerateFeedUrl() saved feed = %v", feed)
+					}
+					*savedHash = feed.TokenHash
+					return 1, nil
+				})
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "existing feed gets new token",
+			mock: func(savedHash *string) {
+				repo.EXPECT().GetCalendarFeedByUserId(1).Return(&model.CalendarFeed{Id: 3, UserId: 1, TokenHash: "old hash"}, nil)
+				repo.EXPECT().SaveEntity(gomock.Any()).DoAndReturn(func(entity model.BusinessEntity) (int, *model.ApplicationError) {
+					feed := entity.(*model.CalendarFeed)
+					if feed.Id != 3 || feed.TokenHash == "old hash" {
+						t.Errorf("CalendarService.RegenerateFeedUrl() saved feed = %v", feed)
+					}
+					*savedHash = feed.TokenHash
+					return 3, nil
+				})
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "error while saving returns error",
+			mock: func(savedHash *string) {
+				repo.EXPECT().GetCalendarFeedByUserId(1).Return(nil, model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil))
+				repo.EXPECT().SaveEntity(gomock.Any()).Return(-1, model.NewApplicationError(model.ErrorTypeDatabase, "внутрення ошибка БД", nil))
+			},
+			want:    model.NewApplicationError(model.ErrorTypeDatabase, "внутрення ошибка БД", nil),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calendarService := calendarService
+
+			var savedHash string
+			tt.mock(&savedHash)
+
+			got, err := calendarService.RegenerateFeedUrl(1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CalendarService.RegenerateFeedUrl() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil {
+				if err.Type != tt.want.Type || err.Message != tt.want.Message {
+					t.Errorf("CalendarService.RegenerateFeedUrl() unexpected error = %v, want %v", err, tt.want)
+				}
+				return
+			}
+
+			token, found := strings.CutPrefix(got, "https://notes.example.com/calendar/")
+			token, hasSuffix := strings.CutSuffix(token, ".ics")
+			if !found || !hasSuffix || model.HashCalendarToken(token) != savedHash {
+				t.Errorf("CalendarService.RegenerateFeedUrl() = %v does not match saved token hash", got)
+			}
+		})
+	}
+}
+
+func TestConcreteCalendarService_GetFeed(t *testing.T) {
+	calendarService, repo := initCalendarServiceTest(t)
+	fixedTime := time.Date(2030, 1, 1, 8, 0, 0, 0, time.UTC)
+	dueAt := time.Date(2030, 1, 5, 18, 0, 0, 0, time.UTC)
+	longContent := "Обсудить план, бюджет; сроки\nи " + strings.Repeat("очень ", 10) + "длинное описание"
+
+	tests := []struct {
+		name    string
+		mock    func()
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "unknown token",
+			mock: func() {
+				repo.EXPECT().GetCalendarFeedByTokenHash(model.HashCalendarToken("unknown")).Return(nil, model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil))
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "empty feed",
+			mock: func() {
+				repo.EXPECT().GetCalendarFeedByTokenHash(model.HashCalendarToken("token")).Return(&model.CalendarFeed{Id: 1, UserId: 1}, nil)
+				repo.EXPECT().GetRemindersByUserId(1).Return([]*model.Reminder{})
+			},
+			want: []string{
+				"BEGIN:VCALENDAR",
+				"VERSION:2.0",
+				"PRODID:-//Notes//Notes Calendar//RU",
+				"CALSCALE:GREGORIAN",
+				"METHOD:PUBLISH",
+				"X-WR-CALNAME:Заметки",
+				"END:VCALENDAR",
+			},
+			wantErr: false,
+		},
+		{
+			name: "recurring event and task with due date",
+			mock: func() {
+				repo.EXPECT().GetCalendarFeedByTokenHash(model.HashCalendarToken("token")).Return(&model.CalendarFeed{Id: 1, UserId: 1}, nil)
+				repo.EXPECT().GetRemindersByUserId(1).Return([]*model.Reminder{
+					{Id: 1, NoteId: 1, UserId: 1, StartsAt: fixedTime, RemindAt: fixedTime, Recurrence: "FREQ=WEEKLY;COUNT=4", IsActive: true, Timestamp: fixedTime},
+					{Id: 2, NoteId: 2, UserId: 1, StartsAt: fixedTime, RemindAt: fixedTime, DueAt: &dueAt, Timestamp: fixedTime},
+					{Id: 3, NoteId: 3, UserId: 1, StartsAt: fixedTime, RemindAt: fixedTime, Timestamp: fixedTime},
+				})
+				repo.EXPECT().GetNotesByUserId(1).Return([]*model.Note{
+					{Id: 1, Title: "Планёрка", UserId: 1, Tags: []string{"work", "a,b"}, Timestamp: fixedTime.Add(-time.Hour)},
+					{Id: 2, Title: "Отчёт", Content: longContent, UserId: 1, Timestamp: fixedTime.Add(time.Hour)},
+				})
+			},
+			want: []string{
+				"BEGIN:VCALENDAR",
+				"VERSION:2.0",
+				"PRODID:-//Notes//Notes Calendar//RU",
+				"CALSCALE:GREGORIAN",
+				"METHOD:PUBLISH",
+				"X-WR-CALNAME:Заметки",
+				"BEGIN:VEVENT",
+				"UID:note-1@notes",
+				"DTSTAMP:20300101T080000Z",
+				"LAST-MODIFIED:20300101T080000Z",
+				"SUMMARY:Планёрка",
+				"URL:https://notes.example.com/notes/1",
+				`CATEGORIES:work,a\,b`,
+				"DTSTART:20300101T080000Z",
+				"RRULE:FREQ=WEEKLY;COUNT=4",
+				"END:VEVENT",
+				"BEGIN:VTODO",
+				"UID:note-2@notes",
+				"DTSTAMP:20300101T090000Z",
+				"LAST-MODIFIED:20300101T090000Z",
+				"SUMMARY:Отчёт",
+				`DESCRIPTION:Обсудить план\, бюджет\; сроки\nи оч`,
+				" ень очень очень очень очень очень очень ",
+				" очень очень очень длинное описание",
+				"URL:https://notes.example.com/notes/2",
+				"DUE:20300105T180000Z",
+				"END:VTODO",
+				"END:VCALENDAR",
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calendarService := calendarService
+
+			tt.mock()
+
+			token := "token"
+			if tt.wantErr {
+				token = "unknown"
+			}
+
+			got, err := calendarService.GetFeed(token)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CalendarService.GetFeed() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			want := strings.Join(tt.want, "\r\n") + "\r\n"
+			if got != want {
+				t.Errorf("CalendarService.GetFeed() = %q, want %q", got, want)
+			}
+
+			for _, line := range strings.Split(strings.TrimSuffix(got, "\r\n"), "\r\n") {
+				if len(line) > 75 {
+					t.Errorf("CalendarService.GetFeed() line is longer than 75 octets: %q", line)
+				}
+			}
+		})
+	}
+}
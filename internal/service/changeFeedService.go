@@ -0,0 +1,79 @@
+package service
+
+//go:generate mockgen -source=changeFeedService.go -destination=mock/changeFeedService.go -package=mock
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/repository"
+	"encoding/json"
+)
+
+// changeFeedBatchSize - сколько событий журнала отдаётся клиенту за один раз
+const changeFeedBatchSize = 100
+
+type AbstractChangeFeedService interface {
+	GetChanges(userId int, afterId int) []*model.Event
+	GetLastChangeId(userId int) int
+}
+
+// ChangeFeedService читает журнал изменений, по которому клиент догоняет пропущенные события
+type ChangeFeedService struct {
+	repo repository.AbstractRepository
+}
+
+func NewConcreteChangeFeedService(repository repository.AbstractRepository) AbstractChangeFeedService {
+	return &ChangeFeedService{repo: repository}
+}
+
+// GetChanges возвращает не больше changeFeedBatchSize событий после afterId в порядке их записи
+func (c *ChangeFeedService) GetChanges(userId int, afterId int) []*model.Event {
+	return c.repo.GetEventsAfter(userId, afterId, changeFeedBatchSize)
+}
+
+func (c *ChangeFeedService) GetLastChangeId(userId int) int {
+	return c.repo.GetLastEventId(userId)
+}
+
+// commitChanges выполняет change в транзакции вместе с записью его событий в журнал изменений,
+// журнал аудита и очередь webhook, а после фиксации рассылает события подписчикам.
+// origin - откуда пришёл запрос, он попадает в журнал аудита.
+func commitChanges(repo repository.AbstractRepository, events AbstractEventBus, origin model.AuditOrigin,
+	change func(tx repository.AbstractRepository) ([]*model.Event, *model.ApplicationError)) *model.ApplicationError {
+	var changes []*model.Event
+
+	err := repo.Transaction(func(tx repository.AbstractRepository) *model.ApplicationError {
+		var err *model.ApplicationError
+		if changes, err = change(tx); err != nil {
+			return err
+		}
+
+		for _, event := range changes {
+			if _, err = tx.SaveEntity(event); err != nil {
+				return err
+			}
+
+			if err = saveEventAudit(tx, origin, event); err != nil {
+				return err
+			}
+
+			payload, marshalErr := json.Marshal(event)
+			if marshalErr != nil {
+				return model.NewLocalizedError(model.ErrorTypeInternal, model.CodeWebhookPayloadFailed, nil, marshalErr)
+			}
+
+			if err = tx.EnqueueWebhookDeliveries(event.UserId, event.Type, string(payload)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	for _, event := range changes {
+		events.Publish(event)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/repository"
+	mocks "Notes/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"testing"
+)
+
+// expectChangeLog выполняет транзакции на том же моке и принимает записи журнала изменений,
+// выдавая им возрастающие номера, и постановку событий в очередь webhook
+func expectChangeLog(repo *mocks.MockAbstractRepository) {
+	lastId := 0
+
+	repo.EXPECT().Transaction(gomock.Any()).DoAndReturn(func(fn func(tx repository.AbstractRepository) *model.ApplicationError) *model.ApplicationError {
+		return fn(repo)
+	}).AnyTimes()
+	repo.EXPECT().SaveEntity(gomock.AssignableToTypeOf(&model.Event{})).DoAndReturn(func(entity model.BusinessEntity) (int, *model.ApplicationError) {
+		lastId++
+		entity.SetId(lastId)
+		return lastId, nil
+	}).AnyTimes()
+	repo.EXPECT().SaveEntity(gomock.AssignableToTypeOf(&model.AuditEntry{})).Return(1, nil).AnyTimes()
+	repo.EXPECT().GetAuditEntries(gomock.Any()).Return(nil).AnyTimes()
+	repo.EXPECT().EnqueueWebhookDeliveries(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+}
+
+func TestCommitChanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockAbstractRepository(ctrl)
+	expectChangeLog(repo)
+
+	eventBus := NewConcreteEventBus(nil)
+	subscription := eventBus.Subscribe(1)
+	defer subscription.Close()
+
+	err := commitChanges(repo, eventBus, model.AuditOrigin{}, func(tx repository.AbstractRepository) ([]*model.Event, *model.ApplicationError) {
+		return []*model.Event{
+			model.NewNoteEvent(model.EventNoteUpdated, &model.Note{Id: 1, UserId: 1}),
+			model.NewNoteEvent(model.EventNoteUpdated, &model.Note{Id: 2, UserId: 1}),
+		}, nil
+	})
+	if err != nil {
+		t.Fatalf("commitChanges() error = %v", err)
+	}
+
+	for wantId := 1; wantId <= 2; wantId++ {
+		if event := receiveEvent(t, subscription); event.Id != wantId || *event.NoteId != wantId {
+			t.Errorf("event = %+v, want change %d of note %d", event, wantId, wantId)
+		}
+	}
+
+	// Неудачное изменение не попадает ни в журнал, ни к подписчикам
+	err = commitChanges(repo, eventBus, model.AuditOrigin{}, func(tx repository.AbstractRepository) ([]*model.Event, *model.ApplicationError) {
+		return nil, repository.DataBaseError
+	})
+	if err == nil {
+		t.Fatalf("commitChanges() error = nil, want error")
+	}
+	if len(subscription.Events) != 0 {
+		t.Errorf("event of failed change was published")
+	}
+}
+
+func TestConcreteChangeFeedService_GetChanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockAbstractRepository(ctrl)
+	changeFeedService := NewConcreteChangeFeedService(repo)
+
+	events := []*model.Event{{Id: 6, UserId: 1, Type: model.EventFolderCreated}}
+	repo.EXPECT().GetEventsAfter(1, 5, changeFeedBatchSize).Return(events)
+	repo.EXPECT().GetLastEventId(1).Return(6)
+
+	if got := changeFeedService.GetChanges(1, 5); len(got) != 1 || got[0].Id != 6 {
+		t.Errorf("GetChanges() = %v, want event 6", got)
+	}
+	if got := changeFeedService.GetLastChangeId(1); got != 6 {
+		t.Errorf("GetLastChangeId() = %d, want 6", got)
+	}
+}
@@ -0,0 +1,239 @@
+package service
+
+//go:generate mockgen -source=checklistService.go -destination=mock/checklistService.go -package=mock
+
+import (
+	"Notes/internal/constants"
+	"Notes/internal/model"
+	"Notes/internal/repository"
+)
+
+type AbstractChecklistService interface {
+	ChangeNoteType(userId int, noteId int, noteType model.NoteType, lockToken string) *model.ApplicationError
+	AddItem(userId int, noteId int, text string, position *int, lockToken string) (int, *model.ApplicationError)
+	MoveItem(userId int, noteId int, itemId int, position int, lockToken string) *model.ApplicationError
+	SetItemChecked(userId int, noteId int, itemId int, isChecked bool, lockToken string) *model.ApplicationError
+	DeleteItem(userId int, noteId int, itemId int, lockToken string) *model.ApplicationError
+}
+
+type ChecklistService struct {
+	repo   repository.AbstractRepository
+	events AbstractEventBus
+	origin model.AuditOrigin
+}
+
+func NewConcreteChecklistService(repository repository.AbstractRepository, events AbstractEventBus) AbstractChecklistService {
+	return &ChecklistService{
+		repo:   repository,
+		events: events,
+	}
+}
+
+func (c *ChecklistService) withOrigin(origin model.AuditOrigin) any {
+	service := *c
+	service.origin = origin
+	return &service
+}
+
+func (c *ChecklistService) ChangeNoteType(userId int, noteId int, noteType model.NoteType, lockToken string) *model.ApplicationError {
+	note, err := c.repo.GetNoteById(noteId, userId)
+	if err != nil {
+		return err
+	}
+
+	if note.IsChecklist() == (noteType == model.NoteTypeChecklist) {
+		return nil
+	}
+
+	if noteType == model.NoteTypeChecklist {
+		items, err := model.ChecklistFromContent(note.Id, note.Content, nil)
+		if err != nil {
+			return err
+		}
+
+		note.Type = model.NoteTypeChecklist
+		note.Content = model.RenderChecklist(items)
+
+		return c.commitChecklist(note, items, lockToken)
+	}
+
+	items := c.repo.GetChecklistItemsByNoteId(note.Id)
+	content := model.RenderChecklist(items)
+
+	// Проверяем получившийся текст по тем же правилам, что и обычную заметку
+	if _, err = model.NewNote(note.Title, content, userId, nil); err != nil {
+		return err
+	}
+
+	note.Type = model.NoteTypeText
+	note.Content = content
+
+	return c.commitChecklist(note, []*model.ChecklistItem{}, lockToken)
+}
+
+func (c *ChecklistService) AddItem(userId int, noteId int, text string, position *int, lockToken string) (int, *model.ApplicationError) {
+	note, items, err := c.getChecklist(userId, noteId)
+	if err != nil {
+		return constants.FakeId, err
+	}
+
+	item, err := model.NewChecklistItem(note.Id, text)
+	if err != nil {
+		return constants.FakeId, err
+	}
+
+	index := len(items)
+	if position != nil {
+		index = clampPosition(*position, len(items))
+	}
+
+	items = append(items[:index], append([]*model.ChecklistItem{item}, items[index:]...)...)
+
+	if err = c.saveChecklist(note, items, lockToken); err != nil {
+		return constants.FakeId, err
+	}
+
+	return item.Id, nil
+}
+
+func (c *ChecklistService) MoveItem(userId int, noteId int, itemId int, position int, lockToken string) *model.ApplicationError {
+	note, items, err := c.getChecklist(userId, noteId)
+	if err != nil {
+		return err
+	}
+
+	index := findItemIndex(items, itemId)
+	if index < 0 {
+		return repository.EntityNotFoundError
+	}
+
+	item := items[index]
+	items = append(items[:index], items[index+1:]...)
+	position = clampPosition(position, len(items))
+	items = append(items[:position], append([]*model.ChecklistItem{item}, items[position:]...)...)
+
+	return c.saveChecklist(note, items, lockToken)
+}
+
+func (c *ChecklistService) SetItemChecked(userId int, noteId int, itemId int, isChecked bool, lockToken string) *model.ApplicationError {
+	note, items, err := c.getChecklist(userId, noteId)
+	if err != nil {
+		return err
+	}
+
+	index := findItemIndex(items, itemId)
+	if index < 0 {
+		return repository.EntityNotFoundError
+	}
+
+	item := items[index]
+	if item.IsChecked == isChecked {
+		return nil
+	}
+
+	// Отметка не меняет текст заметки, но заметка сохраняется вместе с пунктами,
+	// чтобы изменение получило новую версию и дошло до клиентов синхронизации
+	item.IsChecked = isChecked
+
+	return commitChanges(c.repo, c.events, c.origin, func(tx repository.AbstractRepository) ([]*model.Event, *model.ApplicationError) {
+		if err := saveChecklistItems(tx, note, items, lockToken); err != nil {
+			return nil, err
+		}
+
+		return []*model.Event{model.NewNoteEvent(model.EventNoteUpdated, note)}, nil
+	})
+}
+
+func (c *ChecklistService) DeleteItem(userId int, noteId int, itemId int, lockToken string) *model.ApplicationError {
+	note, items, err := c.getChecklist(userId, noteId)
+	if err != nil {
+		return err
+	}
+
+	index := findItemIndex(items, itemId)
+	if index < 0 {
+		return nil
+	}
+
+	items = append(items[:index], items[index+1:]...)
+
+	return c.saveChecklist(note, items, lockToken)
+}
+
+func (c *ChecklistService) getChecklist(userId int, noteId int) (*model.Note, []*model.ChecklistItem, *model.ApplicationError) {
+	note, err := c.repo.GetNoteById(noteId, userId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !note.IsChecklist() {
+		return nil, nil, model.NewLocalizedError(model.ErrorTypeValidation, model.CodeNoteIsNotChecklist, nil, nil)
+	}
+
+	return note, c.repo.GetChecklistItemsByNoteId(note.Id), nil
+}
+
+func (c *ChecklistService) saveChecklist(note *model.Note, items []*model.ChecklistItem, lockToken string) *model.ApplicationError {
+	if err := model.ValidateChecklist(items); err != nil {
+		return err
+	}
+
+	model.NormalizePositions(items)
+	note.Content = model.RenderChecklist(items)
+
+	return c.commitChecklist(note, items, lockToken)
+}
+
+// commitChecklist сохраняет заметку-список с пунктами и обновляет ссылки из её текста
+// в одной транзакции с событием об изменении заметки
+func (c *ChecklistService) commitChecklist(note *model.Note, items []*model.ChecklistItem, lockToken string) *model.ApplicationError {
+	return commitChanges(c.repo, c.events, c.origin, func(tx repository.AbstractRepository) ([]*model.Event, *model.ApplicationError) {
+		if err := saveChecklistWithLinks(tx, note, items, lockToken); err != nil {
+			return nil, err
+		}
+
+		return []*model.Event{model.NewNoteEvent(model.EventNoteUpdated, note)}, nil
+	})
+}
+
+// saveChecklistWithLinks сохраняет заметку-список с пунктами и обновляет ссылки из её текста:
+// текст пунктов может содержать ссылки на другие заметки
+func saveChecklistWithLinks(repo repository.AbstractRepository, note *model.Note, items []*model.ChecklistItem, lockToken string) *model.ApplicationError {
+	if err := saveChecklistItems(repo, note, items, lockToken); err != nil {
+		return err
+	}
+
+	return repo.ReplaceNoteLinks(note.Id, model.ParseNoteLinks(note.Id, note.UserId, note.Content))
+}
+
+// saveChecklistItems сохраняет заметку-список с пунктами. Арендованную заметку можно изменить
+// только с токеном аренды lockToken.
+func saveChecklistItems(repo repository.AbstractRepository, note *model.Note, items []*model.ChecklistItem, lockToken string) *model.ApplicationError {
+	if err := checkNoteLock(repo, note.Id, lockToken); err != nil {
+		return err
+	}
+
+	return repo.SaveChecklist(note, items)
+}
+
+func findItemIndex(items []*model.ChecklistItem, itemId int) int {
+	for i, item := range items {
+		if item.Id == itemId {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func clampPosition(position int, length int) int {
+	if position < 0 {
+		return 0
+	}
+
+	if position > length {
+		return length
+	}
+
+	return position
+}
@@ -0,0 +1,488 @@
+package service
+
+import (
+	"Notes/internal/constants"
+	"Notes/internal/model"
+	"Notes/internal/repository"
+	mocks "Notes/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"testing"
+	"time"
+)
+
+type checklistTestArgs struct {
+	userId    int
+	noteId    int
+	itemId    int
+	text      string
+	position  *int
+	noteType  model.NoteType
+	isChecked bool
+}
+
+type checklistTestExpect struct {
+	id    int
+	error *model.ApplicationError
+}
+
+func initChecklistServiceTest(t *testing.T) (AbstractChecklistService, *mocks.MockAbstractRepository) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockAbstractRepository(ctrl)
+	expectChangeLog(mockRepository)
+	mockEventBus := mocks.NewMockAbstractEventBus(ctrl)
+	mockEventBus.EXPECT().Publish(gomock.Any()).AnyTimes()
+
+	return NewConcreteChecklistService(mockRepository, mockEventBus), mockRepository
+}
+
+func getTestChecklistItems() []*model.ChecklistItem {
+	return []*model.ChecklistItem{
+		{Id: 10, NoteId: 1, Text: "milk", Position: 0},
+		{Id: 11, NoteId: 1, Text: "bread", IsChecked: true, Position: 1},
+		{Id: 12, NoteId: 1, Text: "eggs", Position: 2},
+	}
+}
+
+func TestConcreteChecklistService_ChangeNoteType(t *testing.T) {
+	checklistService, repo := initChecklistServiceTest(t)
+	expectNoNoteLock(repo)
+
+	tests := []struct {
+		name    string
+		mock    func()
+		args    checklistTestArgs
+		want    checklistTestExpect
+		wantErr bool
+	}{
+		{
+			name: "note not found",
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(nil, repository.EntityNotFoundError)
+			},
+			args: checklistTestArgs{
+				userId:   1,
+				noteId:   1,
+				noteType: model.NoteTypeChecklist,
+			},
+			want: checklistTestExpect{
+				error: repository.EntityNotFoundError,
+			},
+			wantErr: true,
+		},
+		{
+			name: "same type is not changed",
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(&model.Note{Id: 1, Content: "content", Type: model.NoteTypeText}, nil)
+			},
+			args: checklistTestArgs{
+				userId:   1,
+				noteId:   1,
+				noteType: model.NoteTypeText,
+			},
+			wantErr: false,
+		},
+		{
+			name: "text converted to checklist line by line",
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(&model.Note{Id: 1, Title: "title", Content: " milk \n\nbread", UserId: 1}, nil)
+				repo.EXPECT().SaveChecklist(&model.Note{
+					Id:      1,
+					Title:   "title",
+					Content: "milk\nbread",
+					UserId:  1,
+					Type:    model.NoteTypeChecklist,
+				}, []*model.ChecklistItem{
+					{NoteId: 1, Text: "milk", Position: 0},
+					{NoteId: 1, Text: "bread", Position: 1},
+				}).Return(nil)
+				repo.EXPECT().ReplaceNoteLinks(1, []*model.NoteLink{}).Return(nil)
+			},
+			args: checklistTestArgs{
+				userId:   1,
+				noteId:   1,
+				noteType: model.NoteTypeChecklist,
+			},
+			wantErr: false,
+		},
+		{
+			name: "empty checklist can not become text",
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(&model.Note{Id: 1, Title: "title", UserId: 1, Type: model.NoteTypeChecklist}, nil)
+				repo.EXPECT().GetChecklistItemsByNoteId(1).Return([]*model.ChecklistItem{})
+			},
+			args: checklistTestArgs{
+				userId:   1,
+				noteId:   1,
+				noteType: model.NoteTypeText,
+			},
+			want: checklistTestExpect{
+				error: model.NewApplicationError(model.ErrorTypeValidation, "Заметка не может быть пустой", nil),
+			},
+			wantErr: true,
+		},
+		{
+			name: "checklist converted to text",
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(&model.Note{Id: 1, Title: "title", Content: "milk\nbread\neggs", UserId: 1, Type: model.NoteTypeChecklist}, nil)
+				repo.EXPECT().GetChecklistItemsByNoteId(1).Return(getTestChecklistItems())
+				repo.EXPECT().SaveChecklist(&model.Note{
+					Id:      1,
+					Title:   "title",
+					Content: "milk\nbread\neggs",
+					UserId:  1,
+					Type:    model.NoteTypeText,
+				}, []*model.ChecklistItem{}).Return(nil)
+				repo.EXPECT().ReplaceNoteLinks(1, []*model.NoteLink{}).Return(nil)
+			},
+			args: checklistTestArgs{
+				userId:   1,
+				noteId:   1,
+				noteType: model.NoteTypeText,
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checklistService := checklistService
+
+			tt.mock()
+
+			err := checklistService.ChangeNoteType(tt.args.userId, tt.args.noteId, tt.args.noteType, "")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ChecklistService.ChangeNoteType() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil && (err.Type != tt.want.error.Type || err.Message != tt.want.error.Message) {
+				t.Errorf("ChecklistService.ChangeNoteType() unexpected error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestConcreteChecklistService_AddItem(t *testing.T) {
+	checklistService, repo := initChecklistServiceTest(t)
+	expectNoNoteLock(repo)
+	position := 1
+
+	tests := []struct {
+		name    string
+		mock    func()
+		args    checklistTestArgs
+		want    checklistTestExpect
+		wantErr bool
+	}{
+		{
+			name: "text note has no items",
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(&model.Note{Id: 1, Content: "content"}, nil)
+			},
+			args: checklistTestArgs{
+				userId: 1,
+				noteId: 1,
+				text:   "butter",
+			},
+			want: checklistTestExpect{
+				id:    constants.FakeId,
+				error: model.NewLocalizedError(model.ErrorTypeValidation, model.CodeNoteIsNotChecklist, nil, nil),
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty item text",
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(&model.Note{Id: 1, Type: model.NoteTypeChecklist}, nil)
+				repo.EXPECT().GetChecklistItemsByNoteId(1).Return(getTestChecklistItems())
+			},
+			args: checklistTestArgs{
+				userId: 1,
+				noteId: 1,
+				text:   "  ",
+			},
+			want: checklistTestExpect{
+				id:    constants.FakeId,
+				error: model.NewApplicationError(model.ErrorTypeValidation, "Пункт списка не может быть пустым", nil),
+			},
+			wantErr: true,
+		},
+		{
+			name: "item inserted at position",
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(&model.Note{Id: 1, Type: model.NoteTypeChecklist}, nil)
+				repo.EXPECT().GetChecklistItemsByNoteId(1).Return(getTestChecklistItems())
+				repo.EXPECT().SaveChecklist(&model.Note{
+					Id:      1,
+					Content: "milk\nbutter\nbread\neggs",
+					Type:    model.NoteTypeChecklist,
+				}, []*model.ChecklistItem{
+					{Id: 10, NoteId: 1, Text: "milk", Position: 0},
+					{Id: 0, NoteId: 1, Text: "butter", Position: 1},
+					{Id: 11, NoteId: 1, Text: "bread", IsChecked: true, Position: 2},
+					{Id: 12, NoteId: 1, Text: "eggs", Position: 3},
+				}).DoAndReturn(func(note *model.Note, items []*model.ChecklistItem) *model.ApplicationError {
+					items[1].Id = 13
+					return nil
+				})
+				repo.EXPECT().ReplaceNoteLinks(1, []*model.NoteLink{}).Return(nil)
+			},
+			args: checklistTestArgs{
+				userId:   1,
+				noteId:   1,
+				text:     "butter",
+				position: &position,
+			},
+			want: checklistTestExpect{
+				id: 13,
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checklistService := checklistService
+
+			tt.mock()
+
+			got, err := checklistService.AddItem(tt.args.userId, tt.args.noteId, tt.args.text, tt.args.position, "")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ChecklistService.AddItem() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got != tt.want.id || (err != nil && (err.Type != tt.want.error.Type || err.Message != tt.want.error.Message)) {
+				t.Errorf("ChecklistService.AddItem() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestConcreteChecklistService_MoveItem(t *testing.T) {
+	checklistService, repo := initChecklistServiceTest(t)
+	expectNoNoteLock(repo)
+
+	tests := []struct {
+		name    string
+		mock    func()
+		args    checklistTestArgs
+		wantErr bool
+	}{
+		{
+			name: "unknown item",
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(&model.Note{Id: 1, Type: model.NoteTypeChecklist}, nil)
+				repo.EXPECT().GetChecklistItemsByNoteId(1).Return(getTestChecklistItems())
+			},
+			args: checklistTestArgs{
+				userId: 1,
+				noteId: 1,
+				itemId: 99,
+			},
+			wantErr: true,
+		},
+		{
+			name: "item moved to the end",
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(&model.Note{Id: 1, Type: model.NoteTypeChecklist}, nil)
+				repo.EXPECT().GetChecklistItemsByNoteId(1).Return(getTestChecklistItems())
+				repo.EXPECT().SaveChecklist(&model.Note{
+					Id:      1,
+					Content: "bread\neggs\nmilk",
+					Type:    model.NoteTypeChecklist,
+				}, []*model.ChecklistItem{
+					{Id: 11, NoteId: 1, Text: "bread", IsChecked: true, Position: 0},
+					{Id: 12, NoteId: 1, Text: "eggs", Position: 1},
+					{Id: 10, NoteId: 1, Text: "milk", Position: 2},
+				}).Return(nil)
+				repo.EXPECT().ReplaceNoteLinks(1, []*model.NoteLink{}).Return(nil)
+			},
+			args: checklistTestArgs{
+				userId:   1,
+				noteId:   1,
+				itemId:   10,
+				position: intPointer(100),
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checklistService := checklistService
+
+			tt.mock()
+
+			position := 0
+			if tt.args.position != nil {
+				position = *tt.args.position
+			}
+
+			err := checklistService.MoveItem(tt.args.userId, tt.args.noteId, tt.args.itemId, position, "")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ChecklistService.MoveItem() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConcreteChecklistService_SetItemChecked(t *testing.T) {
+	checklistService, repo := initChecklistServiceTest(t)
+	expectNoNoteLock(repo)
+
+	tests := []struct {
+		name    string
+		mock    func()
+		args    checklistTestArgs
+		wantErr bool
+	}{
+		{
+			name: "already checked item is not saved",
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(&model.Note{Id: 1, Type: model.NoteTypeChecklist}, nil)
+				repo.EXPECT().GetChecklistItemsByNoteId(1).Return(getTestChecklistItems())
+			},
+			args: checklistTestArgs{
+				userId:    1,
+				noteId:    1,
+				itemId:    11,
+				isChecked: true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "note is saved with the checked item",
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(&model.Note{Id: 1, Type: model.NoteTypeChecklist}, nil)
+				repo.EXPECT().GetChecklistItemsByNoteId(1).Return(getTestChecklistItems())
+				repo.EXPECT().SaveChecklist(gomock.Any(), gomock.Any()).DoAndReturn(func(note *model.Note, items []*model.ChecklistItem) *model.ApplicationError {
+					if !items[0].IsChecked || items[1].IsChecked != getTestChecklistItems()[1].IsChecked {
+						t.Errorf("SaveChecklist() items = %v, want only item 10 checked", items)
+					}
+					return nil
+				})
+			},
+			args: checklistTestArgs{
+				userId:    1,
+				noteId:    1,
+				itemId:    10,
+				isChecked: true,
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checklistService := checklistService
+
+			tt.mock()
+
+			err := checklistService.SetItemChecked(tt.args.userId, tt.args.noteId, tt.args.itemId, tt.args.isChecked, "")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ChecklistService.SetItemChecked() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConcreteChecklistService_DeleteItem(t *testing.T) {
+	checklistService, repo := initChecklistServiceTest(t)
+	expectNoNoteLock(repo)
+
+	tests := []struct {
+		name    string
+		mock    func()
+		args    checklistTestArgs
+		wantErr bool
+	}{
+		{
+			name: "missing item is ignored",
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(&model.Note{Id: 1, Type: model.NoteTypeChecklist}, nil)
+				repo.EXPECT().GetChecklistItemsByNoteId(1).Return(getTestChecklistItems())
+			},
+			args: checklistTestArgs{
+				userId: 1,
+				noteId: 1,
+				itemId: 99,
+			},
+			wantErr: false,
+		},
+		{
+			name: "item deleted",
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(&model.Note{Id: 1, Type: model.NoteTypeChecklist}, nil)
+				repo.EXPECT().GetChecklistItemsByNoteId(1).Return(getTestChecklistItems())
+				repo.EXPECT().SaveChecklist(&model.Note{
+					Id:      1,
+					Content: "milk\neggs",
+					Type:    model.NoteTypeChecklist,
+				}, []*model.ChecklistItem{
+					{Id: 10, NoteId: 1, Text: "milk", Position: 0},
+					{Id: 12, NoteId: 1, Text: "eggs", Position: 1},
+				}).Return(nil)
+				repo.EXPECT().ReplaceNoteLinks(1, []*model.NoteLink{}).Return(nil)
+			},
+			args: checklistTestArgs{
+				userId: 1,
+				noteId: 1,
+				itemId: 11,
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checklistService := checklistService
+
+			tt.mock()
+
+			err := checklistService.DeleteItem(tt.args.userId, tt.args.noteId, tt.args.itemId, "")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ChecklistService.DeleteItem() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConcreteChecklistService_Locked(t *testing.T) {
+	checklistService, repo := initChecklistServiceTest(t)
+	lock := model.NewNoteLock(1, 1, "held-token", time.Now().UTC().Add(time.Minute))
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{name: "lock holder checks the item", token: "held-token"},
+		{name: "request without the lock token is rejected", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo.EXPECT().GetNoteById(1, 1).Return(&model.Note{Id: 1, Type: model.NoteTypeChecklist}, nil)
+			repo.EXPECT().GetChecklistItemsByNoteId(1).Return(getTestChecklistItems())
+			repo.EXPECT().GetNoteLock(1, gomock.Any()).Return(lock, nil)
+			if !tt.wantErr {
+				repo.EXPECT().SaveChecklist(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			err := checklistService.SetItemChecked(1, 1, 10, true, tt.token)
+			if tt.wantErr {
+				if err == nil || model.GetAppropriateApiError(err).Code != 423 {
+					t.Errorf("SetItemChecked() error = %v, want 423 Locked", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("SetItemChecked() error = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,451 @@
+package service
+
+import (
+	"Notes/config"
+	"Notes/internal/constants"
+	"Notes/internal/model"
+	"Notes/internal/repository"
+	"context"
+	"github.com/google/uuid"
+	"log"
+	"sync"
+	"time"
+)
+
+// collabMessageBuffer - сколько сообщений может ждать отправки одному участнику
+const collabMessageBuffer = 256
+const defaultCollabSnapshotInterval = 5 * time.Second
+
+type AbstractCollabService interface {
+	Run(ctx context.Context)
+	Join(userId int, noteId int) (*CollabConnection, *model.ApplicationError)
+	SaveSnapshots()
+}
+
+// CollabService ведёт документы совместного редактирования заметок. Документ живёт в памяти, пока
+// к нему подключён хотя бы один участник, и периодически сохраняется в текст заметки.
+// Пока документ открыт, он считается основным: изменения текста заметки в обход документа
+// будут перезаписаны следующим снимком. Документы не пересылаются между экземплярами приложения,
+// поэтому подключения к одной заметке должны приходить на один экземпляр.
+type CollabService struct {
+	repo             repository.AbstractRepository
+	events           AbstractEventBus
+	snapshotInterval time.Duration
+	mu               sync.Mutex
+	documents        map[int]*collabDocument
+}
+
+// collabDocument - открытый документ заметки. Поля меняются под mu.
+type collabDocument struct {
+	noteId      int
+	ownerId     int
+	mu          sync.Mutex
+	document    *model.CrdtDocument
+	connections []*CollabConnection
+	// dirty - в документе есть изменения, ещё не сохранённые в заметку
+	dirty bool
+	// rejected - последний снимок не прошёл проверку заметки. Он повторится после новых правок,
+	// а до тех пор документ остаётся в памяти, даже если все участники отключились.
+	rejected bool
+	// locked - заметку арендовал другой клиент. Снимок повторяется, пока аренда не закончится,
+	// а участникам об этом сообщается один раз.
+	locked bool
+	// generation - сколько раз документ сжимался. Сообщения участников с прошлым поколением пропускаются.
+	generation int
+}
+
+// CollabConnection - подключение участника к документу. Канал Messages закрывается после Close
+// или если участник не успевает забирать сообщения: тогда ему нужно подключиться заново.
+type CollabConnection struct {
+	Messages    <-chan *model.CollabServerMessage
+	messages    chan *model.CollabServerMessage
+	document    *collabDocument
+	participant *model.CollabParticipant
+	// closed меняется под мьютексом документа
+	closed bool
+}
+
+func NewConcreteCollabService(repository repository.AbstractRepository, events AbstractEventBus, cfg *config.Config) AbstractCollabService {
+	snapshotInterval := time.Duration(cfg.Collab.SnapshotIntervalSeconds) * time.Second
+	if snapshotInterval <= 0 {
+		snapshotInterval = defaultCollabSnapshotInterval
+	}
+
+	return &CollabService{
+		repo:             repository,
+		events:           events,
+		snapshotInterval: snapshotInterval,
+		documents:        make(map[int]*collabDocument),
+	}
+}
+
+// Join подключает пользователя к документу заметки. Первым сообщением участник получает
+// свой идентификатор, состояние документа и список участников.
+func (s *CollabService) Join(userId int, noteId int) (*CollabConnection, *model.ApplicationError) {
+	note, err := getAccessibleNote(s.repo, userId, noteId)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.GetUserById(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	document, ok := s.documents[noteId]
+	if !ok {
+		document = &collabDocument{
+			noteId:   note.Id,
+			ownerId:  note.UserId,
+			document: model.NewCrdtDocument(note.Content),
+		}
+		s.documents[noteId] = document
+	}
+	document.mu.Lock()
+	s.mu.Unlock()
+	defer document.mu.Unlock()
+
+	messages := make(chan *model.CollabServerMessage, collabMessageBuffer)
+	connection := &CollabConnection{
+		Messages:    messages,
+		messages:    messages,
+		document:    document,
+		participant: &model.CollabParticipant{Site: uuid.New().String(), UserId: userId, Login: user.Login},
+	}
+	document.connections = append(document.connections, connection)
+
+	connection.messages <- document.initMessage(connection, document.document.Elements())
+	document.broadcastPresence(connection)
+
+	return connection, nil
+}
+
+// Run сохраняет снимки документов до отмены контекста, а после отмены сохраняет их в последний раз
+func (s *CollabService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.snapshotInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.SaveSnapshots()
+			return
+		case <-ticker.C:
+			s.SaveSnapshots()
+		}
+	}
+}
+
+// SaveSnapshots сохраняет изменённые документы в заметки, сжимает документы без новых правок
+// и закрывает документы, от которых отключились все участники
+func (s *CollabService) SaveSnapshots() {
+	s.mu.Lock()
+	documents := make([]*collabDocument, 0, len(s.documents))
+	for _, document := range s.documents {
+		documents = append(documents, document)
+	}
+	s.mu.Unlock()
+
+	for _, document := range documents {
+		s.saveSnapshot(document)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for noteId, document := range s.documents {
+		document.mu.Lock()
+		if len(document.connections) == 0 && !document.dirty {
+			delete(s.documents, noteId)
+		}
+		document.mu.Unlock()
+	}
+}
+
+func (s *CollabService) saveSnapshot(document *collabDocument) {
+	document.mu.Lock()
+	if !document.dirty {
+		document.compact()
+		document.mu.Unlock()
+		return
+	}
+	if document.rejected {
+		document.mu.Unlock()
+		return
+	}
+	text := document.document.Text()
+	document.dirty = false
+	document.mu.Unlock()
+
+	err := s.saveNoteContent(document.noteId, document.ownerId, text)
+
+	document.mu.Lock()
+	defer document.mu.Unlock()
+
+	if err == nil {
+		document.locked = false
+		return
+	}
+
+	switch err.Type {
+	case model.ErrorTypeNotFound:
+		// Заметка удалена, сохранять правки некуда: участники узнают об этом и отключаются
+		log.Printf("Заметка %d не найдена, документ закрыт: %v", document.noteId, err)
+		document.closeAll(err)
+	case model.ErrorTypeValidation:
+		// Ошибка проверки повторится и при следующей попытке, поэтому снимок ждёт новых правок
+		log.Printf("Снимок заметки %d не сохранён: %v", document.noteId, err)
+		if !document.dirty {
+			// Правки, пришедшие во время сохранения, следующий снимок проверит заново
+			document.rejected = true
+		}
+		document.dirty = true
+		document.broadcast(nil, &model.CollabServerMessage{Type: model.CollabMessageSaveFailed, NoteId: document.noteId, SaveError: err})
+	case model.ErrorTypeLocked:
+		document.dirty = true
+		if !document.locked {
+			document.locked = true
+			document.broadcast(nil, &model.CollabServerMessage{Type: model.CollabMessageSaveFailed, NoteId: document.noteId, SaveError: err})
+		}
+	default:
+		log.Printf("Не удалось сохранить снимок заметки %d: %v", document.noteId, err)
+		document.dirty = true
+	}
+}
+
+func (s *CollabService) saveNoteContent(noteId int, ownerId int, text string) *model.ApplicationError {
+	note, err := s.repo.GetNoteById(noteId, ownerId)
+	if err != nil {
+		return err
+	}
+
+	if note.Content == text {
+		return nil
+	}
+
+	if _, err = model.NewNote(note.Title, text, note.UserId, nil); err != nil {
+		return err
+	}
+	note.Content = text
+
+	return commitChanges(s.repo, s.events, model.AuditOrigin{}, func(tx repository.AbstractRepository) ([]*model.Event, *model.ApplicationError) {
+		if err := saveNoteWithLinks(tx, note, ""); err != nil {
+			return nil, err
+		}
+
+		return []*model.Event{model.NewNoteEvent(model.EventNoteUpdated, note)}, nil
+	})
+}
+
+// Site возвращает идентификатор участника, которым он помечает свои вставки
+func (c *CollabConnection) Site() string {
+	return c.participant.Site
+}
+
+// Send обрабатывает сообщение участника. Операции применяются по порядку до первой ошибки,
+// применённые операции рассылаются остальным участникам.
+func (c *CollabConnection) Send(message *model.CollabClientMessage) *model.ApplicationError {
+	document := c.document
+	document.mu.Lock()
+	defer document.mu.Unlock()
+
+	// Отключённый участник узнает об этом по закрытому каналу
+	if c.closed {
+		return nil
+	}
+
+	// Сообщение отправлено до сжатия и может ссылаться на убранные символы. Участнику уже отправлен
+	// init нового поколения: получив его, участник заменит состояние и повторит свои правки.
+	if message.Generation != document.generation {
+		return nil
+	}
+
+	switch message.Type {
+	case model.CollabMessageOps:
+		applied, err := document.apply(c.participant.Site, message.Ops)
+		if len(applied) > 0 {
+			document.dirty = true
+			document.rejected = false
+			document.broadcast(c, &model.CollabServerMessage{
+				Type:   model.CollabMessageOps,
+				NoteId: document.noteId,
+				Site:   c.participant.Site,
+				Ops:    applied,
+			})
+		}
+		return err
+	case model.CollabMessageCursor:
+		cursor := message.Cursor
+		if cursor != nil {
+			for _, id := range []*model.CrdtId{cursor.Anchor, cursor.Head} {
+				if id != nil && !document.document.Has(*id) {
+					return model.NewLocalizedError(model.ErrorTypeValidation, model.CodeCrdtElementUnknown, model.ErrorParams{"id": id.String()}, nil)
+				}
+			}
+			cursor = &model.CollabCursor{Anchor: cursor.Anchor, Head: cursor.Head}
+		}
+
+		c.participant.Cursor = cursor
+		document.broadcastPresence(c)
+		return nil
+	default:
+		return model.NewLocalizedError(model.ErrorTypeValidation, model.CodeCollabMessageUnknown, model.ErrorParams{"type": message.Type}, nil)
+	}
+}
+
+// Close отключает участника от документа, остальные участники получают новый список
+func (c *CollabConnection) Close() {
+	c.document.mu.Lock()
+	defer c.document.mu.Unlock()
+
+	if c.document.remove(c) {
+		c.document.broadcastPresence(nil)
+	}
+}
+
+// apply вызывается под мьютексом документа
+func (d *collabDocument) apply(site string, operations []*model.CrdtOperation) ([]*model.CrdtOperation, *model.ApplicationError) {
+	applied := make([]*model.CrdtOperation, 0, len(operations))
+
+	for _, operation := range operations {
+		if operation.Type == model.CrdtOperationInsert {
+			if operation.Id.Site != site {
+				return applied, model.NewLocalizedError(model.ErrorTypeValidation, model.CodeCollabSiteForeign, nil, nil)
+			}
+
+			if d.document.Length()+len(operation.Value) > constants.MaxContentLength {
+				return applied, model.NewLocalizedError(model.ErrorTypeValidation, model.CodeNoteContentTooLong, model.ErrorParams{"max": constants.MaxContentLength}, nil)
+			}
+		}
+
+		if err := d.document.Apply(operation); err != nil {
+			return applied, err
+		}
+		applied = append(applied, operation)
+	}
+
+	return applied, nil
+}
+
+// initMessage возвращает участнику состояние документа elements. Вызывается под мьютексом документа.
+func (d *collabDocument) initMessage(connection *CollabConnection, elements []*model.CrdtElement) *model.CollabServerMessage {
+	return &model.CollabServerMessage{
+		Type:         model.CollabMessageInit,
+		NoteId:       d.noteId,
+		Site:         connection.participant.Site,
+		Generation:   d.generation,
+		Clock:        d.document.Clock(),
+		Elements:     elements,
+		Participants: d.participants(),
+	}
+}
+
+// compact убирает из документа удалённые символы, когда в документе не было правок целый интервал снимков.
+// Медленный участник мог ещё не получить все операции, поэтому документ переходит в новое поколение:
+// участники получают сжатое состояние, а их сообщения прошлого поколения пропускаются.
+// Курсоры на удалённых символах сбрасываются. Вызывается под мьютексом документа.
+func (d *collabDocument) compact() {
+	if d.document.Compact() == 0 {
+		return
+	}
+	d.generation++
+
+	for _, connection := range d.connections {
+		cursor := connection.participant.Cursor
+		if cursor == nil {
+			continue
+		}
+		for _, id := range []*model.CrdtId{cursor.Anchor, cursor.Head} {
+			if id != nil && !d.document.Has(*id) {
+				connection.participant.Cursor = nil
+				break
+			}
+		}
+	}
+
+	elements := d.document.Elements()
+	d.send(nil, func(connection *CollabConnection) *model.CollabServerMessage {
+		return d.initMessage(connection, elements)
+	})
+}
+
+// closeAll сообщает участникам об ошибке err и отключает их. Вызывается под мьютексом документа.
+func (d *collabDocument) closeAll(err *model.ApplicationError) {
+	message := &model.CollabServerMessage{Type: model.CollabMessageSaveFailed, NoteId: d.noteId, SaveError: err}
+
+	for _, connection := range append([]*CollabConnection(nil), d.connections...) {
+		select {
+		case connection.messages <- message:
+		default:
+		}
+		d.remove(connection)
+	}
+	d.dirty = false
+	d.rejected = false
+}
+
+// participants вызывается под мьютексом документа
+func (d *collabDocument) participants() []*model.CollabParticipant {
+	participants := make([]*model.CollabParticipant, 0, len(d.connections))
+	for _, connection := range d.connections {
+		participant := *connection.participant
+		participants = append(participants, &participant)
+	}
+	return participants
+}
+
+// broadcastPresence рассылает список участников всем, кроме except. Вызывается под мьютексом документа.
+func (d *collabDocument) broadcastPresence(except *CollabConnection) {
+	d.broadcast(except, &model.CollabServerMessage{
+		Type:         model.CollabMessagePresence,
+		NoteId:       d.noteId,
+		Participants: d.participants(),
+	})
+}
+
+// broadcast отправляет сообщение всем участникам, кроме except. Вызывается под мьютексом документа.
+func (d *collabDocument) broadcast(except *CollabConnection, message *model.CollabServerMessage) {
+	d.send(except, func(*CollabConnection) *model.CollabServerMessage {
+		return message
+	})
+}
+
+// send отправляет каждому участнику, кроме except, его сообщение. Участники, которые не успевают
+// забирать сообщения, отключаются, и остальным рассылается новый список участников.
+// Вызывается под мьютексом документа.
+func (d *collabDocument) send(except *CollabConnection, messageFor func(connection *CollabConnection) *model.CollabServerMessage) {
+	dropped := false
+	for _, connection := range append([]*CollabConnection(nil), d.connections...) {
+		if connection == except {
+			continue
+		}
+
+		select {
+		case connection.messages <- messageFor(connection):
+		default:
+			dropped = d.remove(connection) || dropped
+		}
+	}
+
+	if dropped {
+		d.broadcastPresence(nil)
+	}
+}
+
+// remove отключает участника и закрывает его канал. Вызывается под мьютексом документа.
+func (d *collabDocument) remove(connection *CollabConnection) bool {
+	if connection.closed {
+		return false
+	}
+
+	for i, candidate := range d.connections {
+		if candidate == connection {
+			d.connections = append(d.connections[:i], d.connections[i+1:]...)
+			break
+		}
+	}
+	connection.closed = true
+	close(connection.messages)
+	return true
+}
@@ -0,0 +1,296 @@
+package service
+
+import (
+	"Notes/config"
+	"Notes/internal/model"
+	"Notes/internal/repository"
+	mocks "Notes/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"testing"
+)
+
+func initCollabServiceTest(t *testing.T) (AbstractCollabService, *mocks.MockAbstractRepository) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockAbstractRepository(ctrl)
+	expectChangeLog(mockRepository)
+	mockEventBus := mocks.NewMockAbstractEventBus(ctrl)
+	mockEventBus.EXPECT().Publish(gomock.Any()).AnyTimes()
+
+	return NewConcreteCollabService(mockRepository, mockEventBus, &config.Config{}), mockRepository
+}
+
+func receiveCollabMessage(t *testing.T, connection *CollabConnection, messageType model.CollabMessageType) *model.CollabServerMessage {
+	t.Helper()
+
+	select {
+	case message := <-connection.Messages:
+		if message.Type != messageType {
+			t.Fatalf("received %s message, want %s", message.Type, messageType)
+		}
+		return message
+	default:
+		t.Fatalf("no %s message received", messageType)
+		return nil
+	}
+}
+
+func TestCrdtDocument_ConcurrentInsertsConverge(t *testing.T) {
+	seed := model.CrdtId{Counter: 1, Site: model.CrdtSeedSite}
+	operations := []*model.CrdtOperation{
+		{Type: model.CrdtOperationInsert, Id: model.CrdtId{Counter: 3, Site: "a"}, After: &seed, Value: "x"},
+		{Type: model.CrdtOperationInsert, Id: model.CrdtId{Counter: 4, Site: "a"}, After: &model.CrdtId{Counter: 3, Site: "a"}, Value: "y"},
+		{Type: model.CrdtOperationInsert, Id: model.CrdtId{Counter: 3, Site: "b"}, After: &seed, Value: "z"},
+		{Type: model.CrdtOperationDelete, Id: model.CrdtId{Counter: 2, Site: model.CrdtSeedSite}},
+	}
+
+	texts := make([]string, 0, 2)
+	for _, order := range [][]int{{0, 1, 2, 3}, {2, 3, 0, 1, 3}} {
+		document := model.NewCrdtDocument("ab")
+		for _, i := range order {
+			if err := document.Apply(operations[i]); err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+		}
+		texts = append(texts, document.Text())
+	}
+
+	if texts[0] != texts[1] || texts[0] != "azxy" {
+		t.Errorf("documents diverged: %q and %q, want \"azxy\"", texts[0], texts[1])
+	}
+}
+
+func TestConcreteCollabService_Session(t *testing.T) {
+	collabService, repo := initCollabServiceTest(t)
+	expectNoNoteLock(repo)
+	note := &model.Note{Id: 5, UserId: 1, Title: "План", Content: "ab"}
+
+	repo.EXPECT().GetNoteById(5, 1).Return(note, nil).Times(2)
+	repo.EXPECT().GetUserById(1).Return(&model.User{Id: 1, Login: "login123"}, nil).Times(2)
+
+	first, err := collabService.Join(1, 5)
+	if err != nil {
+		t.Fatalf("Join() error = %v", err)
+	}
+	init := receiveCollabMessage(t, first, model.CollabMessageInit)
+	if len(init.Elements) != 2 || init.Clock != 2 || len(init.Participants) != 1 {
+		t.Errorf("init = %+v, want the note text and one participant", init)
+	}
+
+	second, err := collabService.Join(1, 5)
+	if err != nil {
+		t.Fatalf("Join() error = %v", err)
+	}
+	receiveCollabMessage(t, second, model.CollabMessageInit)
+	if presence := receiveCollabMessage(t, first, model.CollabMessagePresence); len(presence.Participants) != 2 {
+		t.Errorf("presence = %+v, want two participants", presence)
+	}
+
+	t.Run("operations are sent to other participants", func(t *testing.T) {
+		last := init.Elements[1].Id
+		err := first.Send(&model.CollabClientMessage{Type: model.CollabMessageOps, Ops: []*model.CrdtOperation{
+			{Type: model.CrdtOperationInsert, Id: model.CrdtId{Counter: 3, Site: first.Site()}, After: &last, Value: "c"},
+		}})
+		if err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+
+		if ops := receiveCollabMessage(t, second, model.CollabMessageOps); len(ops.Ops) != 1 || ops.Site != first.Site() {
+			t.Errorf("ops = %+v, want the insert of the first participant", ops)
+		}
+		if len(first.Messages) != 0 {
+			t.Errorf("the author received its own operations")
+		}
+	})
+
+	t.Run("insert with another participant's site is rejected", func(t *testing.T) {
+		err := second.Send(&model.CollabClientMessage{Type: model.CollabMessageOps, Ops: []*model.CrdtOperation{
+			{Type: model.CrdtOperationInsert, Id: model.CrdtId{Counter: 4, Site: first.Site()}, Value: "d"},
+		}})
+		if err == nil || err.Code != model.CodeCollabSiteForeign {
+			t.Errorf("Send() error = %v, want %s", err, model.CodeCollabSiteForeign)
+		}
+	})
+
+	t.Run("cursor is broadcast as presence", func(t *testing.T) {
+		anchor := init.Elements[0].Id
+		if err := second.Send(&model.CollabClientMessage{Type: model.CollabMessageCursor, Cursor: &model.CollabCursor{Anchor: &anchor, Head: &anchor}}); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+
+		presence := receiveCollabMessage(t, first, model.CollabMessagePresence)
+		if presence.Participants[1].Cursor == nil || *presence.Participants[1].Cursor.Head != anchor {
+			t.Errorf("presence = %+v, want the cursor of the second participant", presence.Participants[1])
+		}
+	})
+
+	t.Run("snapshot is saved to the note and closed document is released", func(t *testing.T) {
+		repo.EXPECT().GetNoteById(5, 1).Return(note, nil)
+		repo.EXPECT().SaveEntity(note).Return(5, nil)
+		repo.EXPECT().ReplaceNoteLinks(5, gomock.Any()).Return(nil)
+
+		first.Close()
+		second.Close()
+		collabService.SaveSnapshots()
+
+		if note.Content != "abc" {
+			t.Errorf("note content = %q, want \"abc\"", note.Content)
+		}
+		if _, ok := <-first.Messages; ok {
+			t.Errorf("messages of a closed connection are not closed")
+		}
+		if documents := collabService.(*CollabService).documents; len(documents) != 0 {
+			t.Errorf("documents = %v, want the document released", documents)
+		}
+	})
+}
+
+func TestCrdtDocument_Compact(t *testing.T) {
+	document := model.NewCrdtDocument("abc")
+	if err := document.Apply(&model.CrdtOperation{Type: model.CrdtOperationDelete, Id: model.CrdtId{Counter: 2, Site: model.CrdtSeedSite}}); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	if removed := document.Compact(); removed != 1 {
+		t.Errorf("Compact() = %d, want 1", removed)
+	}
+	if elements := document.Elements(); len(elements) != 2 || document.Text() != "ac" || document.Clock() != 3 {
+		t.Errorf("document = %v %q, want \"ac\" without the deleted character and the same clock", elements, document.Text())
+	}
+	if document.Has(model.CrdtId{Counter: 2, Site: model.CrdtSeedSite}) {
+		t.Errorf("deleted character is still in the document")
+	}
+	if removed := document.Compact(); removed != 0 {
+		t.Errorf("second Compact() = %d, want 0", removed)
+	}
+}
+
+// joinCollabTest подключает участника к заметке note и забирает его первое сообщение
+func joinCollabTest(t *testing.T, collabService AbstractCollabService, repo *mocks.MockAbstractRepository, note *model.Note) (*CollabConnection, *model.CollabServerMessage) {
+	t.Helper()
+
+	repo.EXPECT().GetNoteById(note.Id, note.UserId).Return(note, nil)
+	repo.EXPECT().GetUserById(note.UserId).Return(&model.User{Id: note.UserId, Login: "login123"}, nil)
+
+	connection, err := collabService.Join(note.UserId, note.Id)
+	if err != nil {
+		t.Fatalf("Join() error = %v", err)
+	}
+	return connection, receiveCollabMessage(t, connection, model.CollabMessageInit)
+}
+
+func TestConcreteCollabService_SaveSnapshotsFailure(t *testing.T) {
+	t.Run("rejected snapshot keeps the edits and is reported", func(t *testing.T) {
+		collabService, repo := initCollabServiceTest(t)
+		note := &model.Note{Id: 5, UserId: 1, Title: "", Content: "ab"}
+		connection, init := joinCollabTest(t, collabService, repo, note)
+
+		last := init.Elements[1].Id
+		err := connection.Send(&model.CollabClientMessage{Type: model.CollabMessageOps, Ops: []*model.CrdtOperation{
+			{Type: model.CrdtOperationInsert, Id: model.CrdtId{Counter: 3, Site: connection.Site()}, After: &last, Value: "c"},
+		}})
+		if err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+
+		repo.EXPECT().GetNoteById(5, 1).Return(note, nil)
+		collabService.SaveSnapshots()
+
+		failed := receiveCollabMessage(t, connection, model.CollabMessageSaveFailed)
+		if failed.SaveError == nil || failed.SaveError.Type != model.ErrorTypeValidation {
+			t.Errorf("save_failed = %+v, want the validation error", failed)
+		}
+
+		// Без новых правок снимок не повторяется, а документ не закрывается и без участников
+		connection.Close()
+		collabService.SaveSnapshots()
+		if _, ok := collabService.(*CollabService).documents[5]; !ok {
+			t.Errorf("document with unsaved edits was released")
+		}
+	})
+
+	t.Run("deleted note closes the document", func(t *testing.T) {
+		collabService, repo := initCollabServiceTest(t)
+		note := &model.Note{Id: 6, UserId: 1, Title: "План", Content: "ab"}
+		connection, init := joinCollabTest(t, collabService, repo, note)
+
+		err := connection.Send(&model.CollabClientMessage{Type: model.CollabMessageOps, Ops: []*model.CrdtOperation{
+			{Type: model.CrdtOperationDelete, Id: init.Elements[0].Id},
+		}})
+		if err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+
+		repo.EXPECT().GetNoteById(6, 1).Return(nil, repository.EntityNotFoundError)
+		collabService.SaveSnapshots()
+
+		if failed := receiveCollabMessage(t, connection, model.CollabMessageSaveFailed); failed.SaveError == nil {
+			t.Errorf("save_failed = %+v, want the error", failed)
+		}
+		if _, ok := <-connection.Messages; ok {
+			t.Errorf("connection to a deleted note is not closed")
+		}
+		if documents := collabService.(*CollabService).documents; len(documents) != 0 {
+			t.Errorf("documents = %v, want the document released", documents)
+		}
+	})
+}
+
+func TestConcreteCollabService_CompactIdleDocument(t *testing.T) {
+	collabService, repo := initCollabServiceTest(t)
+	expectNoNoteLock(repo)
+	note := &model.Note{Id: 5, UserId: 1, Title: "План", Content: "ab"}
+	connection, init := joinCollabTest(t, collabService, repo, note)
+
+	deleted := init.Elements[0].Id
+	if err := connection.Send(&model.CollabClientMessage{Type: model.CollabMessageOps, Ops: []*model.CrdtOperation{
+		{Type: model.CrdtOperationDelete, Id: deleted},
+	}}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if err := connection.Send(&model.CollabClientMessage{Type: model.CollabMessageCursor, Cursor: &model.CollabCursor{Anchor: &deleted, Head: &deleted}}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	repo.EXPECT().GetNoteById(5, 1).Return(note, nil)
+	repo.EXPECT().SaveEntity(note).Return(5, nil)
+	repo.EXPECT().ReplaceNoteLinks(5, gomock.Any()).Return(nil)
+
+	// Первый снимок сохраняет правки, следующий - после интервала без правок - сжимает документ
+	collabService.SaveSnapshots()
+	if len(connection.Messages) != 0 {
+		t.Fatalf("document was compacted right after the edits")
+	}
+	collabService.SaveSnapshots()
+
+	compacted := receiveCollabMessage(t, connection, model.CollabMessageInit)
+	if len(compacted.Elements) != 1 || compacted.Site != connection.Site() || compacted.Participants[0].Cursor != nil {
+		t.Errorf("init = %+v, want one character, own site and the cursor on the deleted character reset", compacted)
+	}
+	if note.Content != "b" {
+		t.Errorf("note content = %q, want \"b\"", note.Content)
+	}
+	if compacted.Generation != init.Generation+1 {
+		t.Errorf("init generation = %d, want %d", compacted.Generation, init.Generation+1)
+	}
+
+	// Вставка, отправленная до сжатия, ссылается на убранный символ и пропускается без ошибки
+	stale := connection.Send(&model.CollabClientMessage{Type: model.CollabMessageOps, Generation: init.Generation, Ops: []*model.CrdtOperation{
+		{Type: model.CrdtOperationInsert, Id: model.CrdtId{Counter: 10, Site: connection.Site()}, After: &deleted, Value: "x"},
+	}})
+	if stale != nil {
+		t.Errorf("Send() of the previous generation error = %v, want it ignored", stale)
+	}
+
+	last := compacted.Elements[0].Id
+	err := connection.Send(&model.CollabClientMessage{Type: model.CollabMessageOps, Generation: compacted.Generation, Ops: []*model.CrdtOperation{
+		{Type: model.CrdtOperationInsert, Id: model.CrdtId{Counter: 11, Site: connection.Site()}, After: &last, Value: "c"},
+	}})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if text := collabService.(*CollabService).documents[5].document.Text(); text != "bc" {
+		t.Errorf("document text = %q, want \"bc\"", text)
+	}
+}
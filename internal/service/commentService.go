@@ -0,0 +1,164 @@
+package service
+
+//go:generate mockgen -source=commentService.go -destination=mock/commentService.go -package=mock
+
+import (
+	"Notes/internal/constants"
+	"Notes/internal/model"
+	"Notes/internal/repository"
+)
+
+const defaultCommentThreadsLimit = 20
+const maxCommentThreadsLimit = 100
+
+type AbstractCommentService interface {
+	CreateComment(userId int, noteId int, parentId *int, content string, anchor *model.CommentAnchor) (int, *model.ApplicationError)
+	GetComments(userId int, noteId int, afterId int, limit int) (*model.CommentThreadsApi, *model.ApplicationError)
+	UpdateComment(userId int, commentId int, content string) *model.ApplicationError
+	DeleteComment(userId int, commentId int) *model.ApplicationError
+	ResolveComment(userId int, commentId int, isResolved bool) *model.ApplicationError
+}
+
+type CommentService struct {
+	repo repository.AbstractRepository
+}
+
+func NewConcreteCommentService(repository repository.AbstractRepository) AbstractCommentService {
+	return &CommentService{repo: repository}
+}
+
+func (s *CommentService) CreateComment(userId int, noteId int, parentId *int, content string, anchor *model.CommentAnchor) (int, *model.ApplicationError) {
+	note, err := getAccessibleNote(s.repo, userId, noteId)
+	if err != nil {
+		return constants.FakeId, err
+	}
+
+	var parent *model.Comment
+	if parentId != nil {
+		parent, err = s.repo.GetCommentById(*parentId)
+		if err != nil {
+			return constants.FakeId, err
+		}
+
+		if parent.NoteId != note.Id {
+			return constants.FakeId, repository.EntityNotFoundError
+		}
+	}
+
+	comment, err := model.NewComment(note, userId, parent, content, anchor)
+	if err != nil {
+		return constants.FakeId, err
+	}
+
+	id, err := s.repo.SaveEntity(comment)
+	if err != nil {
+		return constants.FakeId, err
+	}
+
+	comment.Id = id
+	notifyMentions(s.repo, userId, note, comment, model.ParseMentions(comment.Content))
+
+	return id, nil
+}
+
+// GetComments возвращает ветки обсуждения заметки с ответами, не больше limit веток после afterId
+func (s *CommentService) GetComments(userId int, noteId int, afterId int, limit int) (*model.CommentThreadsApi, *model.ApplicationError) {
+	if _, err := getAccessibleNote(s.repo, userId, noteId); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = defaultCommentThreadsLimit
+	}
+	if limit > maxCommentThreadsLimit {
+		limit = maxCommentThreadsLimit
+	}
+
+	// Лишняя ветка показывает, что есть следующая страница
+	threads := s.repo.GetCommentThreads(noteId, afterId, limit+1)
+
+	result := &model.CommentThreadsApi{}
+	if len(threads) > limit {
+		threads = threads[:limit]
+		nextAfterId := threads[limit-1].Id
+		result.NextAfterId = &nextAfterId
+	}
+
+	threadIds := make([]int, 0, len(threads))
+	for _, thread := range threads {
+		threadIds = append(threadIds, thread.Id)
+	}
+
+	result.Threads = model.ToCommentThreadsApi(threads, s.repo.GetCommentReplies(threadIds))
+	return result, nil
+}
+
+func (s *CommentService) UpdateComment(userId int, commentId int, content string) *model.ApplicationError {
+	comment, note, err := s.getOwnComment(userId, commentId)
+	if err != nil {
+		return err
+	}
+
+	previousContent := comment.Content
+	if err = comment.Edit(content); err != nil {
+		return err
+	}
+
+	if _, err = s.repo.SaveEntity(comment); err != nil {
+		return err
+	}
+
+	notifyMentions(s.repo, userId, note, comment, model.NewMentions(previousContent, comment.Content))
+	return nil
+}
+
+// DeleteComment удаляет комментарий вместе с ответами, если это первый комментарий ветки
+func (s *CommentService) DeleteComment(userId int, commentId int) *model.ApplicationError {
+	comment, _, err := s.getOwnComment(userId, commentId)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.DeleteEntity(comment)
+}
+
+// ResolveComment отмечает ветку решённой или снова открывает её. Это может сделать любой участник обсуждения.
+func (s *CommentService) ResolveComment(userId int, commentId int, isResolved bool) *model.ApplicationError {
+	comment, _, err := s.getAccessibleComment(userId, commentId)
+	if err != nil {
+		return err
+	}
+
+	if err = comment.SetResolved(userId, isResolved); err != nil {
+		return err
+	}
+
+	_, err = s.repo.SaveEntity(comment)
+	return err
+}
+
+// getAccessibleComment возвращает комментарий и его заметку, если пользователю доступна заметка
+func (s *CommentService) getAccessibleComment(userId int, commentId int) (*model.Comment, *model.Note, *model.ApplicationError) {
+	comment, err := s.repo.GetCommentById(commentId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	note, err := getAccessibleNote(s.repo, userId, comment.NoteId)
+	if err != nil {
+		return nil, nil, err
+	}
+	return comment, note, nil
+}
+
+func (s *CommentService) getOwnComment(userId int, commentId int) (*model.Comment, *model.Note, *model.ApplicationError) {
+	comment, note, err := s.getAccessibleComment(userId, commentId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if comment.UserId != userId {
+		return nil, nil, model.NewLocalizedError(model.ErrorTypeForbidden, model.CodeCommentNotAuthor, nil, nil)
+	}
+	return comment, note, nil
+}
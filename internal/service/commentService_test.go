@@ -0,0 +1,275 @@
+package service
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/repository"
+	mocks "Notes/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"testing"
+)
+
+func initCommentServiceTest(t *testing.T) (AbstractCommentService, *mocks.MockAbstractRepository) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockAbstractRepository(ctrl)
+
+	return NewConcreteCommentService(mockRepository), mockRepository
+}
+
+func TestConcreteCommentService_CreateComment(t *testing.T) {
+	commentService, repo := initCommentServiceTest(t)
+
+	note := &model.Note{Id: 1, UserId: 1, Title: "План", Content: "Купить молоко и хлеб"}
+	threadId := 10
+	replyId := 11
+
+	tests := []struct {
+		name      string
+		mock      func()
+		parentId  *int
+		content   string
+		anchor    *model.CommentAnchor
+		wantSaved func(comment *model.Comment) bool
+		wantErr   bool
+	}{
+		{
+			name: "thread anchored to a fragment keeps the fragment text",
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(note, nil)
+			},
+			content: "Какое молоко?",
+			anchor:  &model.CommentAnchor{Start: 7, End: 13},
+			wantSaved: func(comment *model.Comment) bool {
+				return comment.ParentId == nil && comment.AnchorText != nil && *comment.AnchorText == "молоко"
+			},
+		},
+		{
+			name: "reply to a reply joins the thread",
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(note, nil)
+				repo.EXPECT().GetCommentById(replyId).Return(&model.Comment{Id: replyId, NoteId: 1, ParentId: &threadId}, nil)
+			},
+			parentId: &replyId,
+			content:  "Обычное",
+			wantSaved: func(comment *model.Comment) bool {
+				return comment.ParentId != nil && *comment.ParentId == threadId
+			},
+		},
+		{
+			name: "parent comment of another note",
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(note, nil)
+				repo.EXPECT().GetCommentById(threadId).Return(&model.Comment{Id: threadId, NoteId: 2}, nil)
+			},
+			parentId: &threadId,
+			content:  "Ответ",
+			wantErr:  true,
+		},
+		{
+			name: "fragment outside the note text",
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(note, nil)
+			},
+			content: "Что здесь?",
+			anchor:  &model.CommentAnchor{Start: 5, End: 100},
+			wantErr: true,
+		},
+		{
+			name: "empty comment",
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(note, nil)
+			},
+			content: "   ",
+			wantErr: true,
+		},
+		{
+			name: "note of another user",
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(nil, repository.EntityNotFoundError)
+			},
+			content: "Комментарий",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+			if !tt.wantErr {
+				repo.EXPECT().SaveEntity(gomock.AssignableToTypeOf(&model.Comment{})).DoAndReturn(func(entity model.BusinessEntity) (int, *model.ApplicationError) {
+					if !tt.wantSaved(entity.(*model.Comment)) {
+						t.Errorf("saved comment = %+v", entity)
+					}
+					return 12, nil
+				})
+			}
+
+			_, err := commentService.CreateComment(1, 1, tt.parentId, tt.content, tt.anchor)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CreateComment() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConcreteCommentService_GetComments(t *testing.T) {
+	commentService, repo := initCommentServiceTest(t)
+	threadId := 1
+
+	repo.EXPECT().GetNoteById(5, 1).Return(&model.Note{Id: 5, UserId: 1}, nil)
+	repo.EXPECT().GetCommentThreads(5, 0, 3).Return([]*model.Comment{{Id: 1, NoteId: 5}, {Id: 2, NoteId: 5}, {Id: 3, NoteId: 5}})
+	repo.EXPECT().GetCommentReplies([]int{1, 2}).Return([]*model.Comment{{Id: 4, NoteId: 5, ParentId: &threadId}})
+
+	page, err := commentService.GetComments(1, 5, 0, 2)
+	if err != nil {
+		t.Fatalf("GetComments() error = %v", err)
+	}
+
+	if len(page.Threads) != 2 || page.NextAfterId == nil || *page.NextAfterId != 2 {
+		t.Errorf("GetComments() = %+v, want two threads and the next page after 2", page)
+	}
+	if len(page.Threads[0].Replies) != 1 || len(page.Threads[1].Replies) != 0 {
+		t.Errorf("replies are not attached to their threads: %+v", page.Threads)
+	}
+}
+
+func TestConcreteCommentService_UpdateComment(t *testing.T) {
+	commentService, repo := initCommentServiceTest(t)
+
+	tests := []struct {
+		name     string
+		authorId int
+		wantErr  bool
+	}{
+		{name: "author edits the comment", authorId: 1},
+		{name: "another participant cannot edit", authorId: 2, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo.EXPECT().GetCommentById(7).Return(&model.Comment{Id: 7, NoteId: 5, UserId: tt.authorId, Content: "Было"}, nil)
+			repo.EXPECT().GetNoteById(5, 1).Return(&model.Note{Id: 5, UserId: 1}, nil)
+			if !tt.wantErr {
+				repo.EXPECT().SaveEntity(gomock.AssignableToTypeOf(&model.Comment{})).DoAndReturn(func(entity model.BusinessEntity) (int, *model.ApplicationError) {
+					comment := entity.(*model.Comment)
+					if comment.Content != "Стало" || comment.EditedAt == nil {
+						t.Errorf("saved comment = %+v, want edited text", comment)
+					}
+					return 7, nil
+				})
+			}
+
+			err := commentService.UpdateComment(1, 7, "Стало")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UpdateComment() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConcreteCommentService_ResolveComment(t *testing.T) {
+	commentService, repo := initCommentServiceTest(t)
+	threadId := 7
+
+	t.Run("thread is resolved and reopened", func(t *testing.T) {
+		comment := &model.Comment{Id: 7, NoteId: 5, UserId: 2}
+		repo.EXPECT().GetCommentById(7).Return(comment, nil).Times(2)
+		repo.EXPECT().GetNoteById(5, 1).Return(&model.Note{Id: 5, UserId: 1}, nil).Times(2)
+		repo.EXPECT().SaveEntity(comment).Return(7, nil).Times(2)
+
+		if err := commentService.ResolveComment(1, 7, true); err != nil || !comment.IsResolved || *comment.ResolvedBy != 1 {
+			t.Errorf("ResolveComment() = %v, comment = %+v", err, comment)
+		}
+		if err := commentService.ResolveComment(1, 7, false); err != nil || comment.IsResolved || comment.ResolvedBy != nil {
+			t.Errorf("ResolveComment() = %v, comment = %+v", err, comment)
+		}
+	})
+
+	t.Run("reply cannot be resolved", func(t *testing.T) {
+		repo.EXPECT().GetCommentById(8).Return(&model.Comment{Id: 8, NoteId: 5, ParentId: &threadId}, nil)
+		repo.EXPECT().GetNoteById(5, 1).Return(&model.Note{Id: 5, UserId: 1}, nil)
+
+		if err := commentService.ResolveComment(1, 8, true); err == nil {
+			t.Errorf("ResolveComment() error = nil, want an error")
+		}
+	})
+}
+
+func TestConcreteCommentService_CreateComment_Mentions(t *testing.T) {
+	commentService, repo := initCommentServiceTest(t)
+
+	note := &model.Note{Id: 1, UserId: 1, Title: "План", Content: "Купить молоко"}
+
+	tests := []struct {
+		name       string
+		mock       func()
+		wantNotify bool
+	}{
+		{
+			name: "user with access to the note is notified",
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 2).Return(note, nil)
+				repo.EXPECT().GetPreferences(2).Return(nil, repository.EntityNotFoundError)
+			},
+			wantNotify: true,
+		},
+		{
+			name: "user without access to the note is not notified",
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 2).Return(nil, repository.EntityNotFoundError)
+			},
+		},
+		{
+			name: "user who muted mentions is not notified",
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 2).Return(note, nil)
+				repo.EXPECT().GetPreferences(2).Return(&model.Preferences{UserId: 2, MutedNotifications: []string{"mention"}}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo.EXPECT().GetNoteById(1, 1).Return(note, nil)
+			repo.EXPECT().SaveEntity(gomock.AssignableToTypeOf(&model.Comment{})).Return(12, nil)
+			repo.EXPECT().GetUsersByLogins([]string{"colleague", "author01"}).Return([]*model.User{
+				{Id: 2, Login: "colleague"},
+				{Id: 1, Login: "author01"},
+			})
+			tt.mock()
+			if tt.wantNotify {
+				repo.EXPECT().SaveEntity(gomock.AssignableToTypeOf(&model.Notification{})).DoAndReturn(func(entity model.BusinessEntity) (int, *model.ApplicationError) {
+					notification := entity.(*model.Notification)
+					if notification.UserId != 2 || notification.Type != model.NotificationTypeMention ||
+						*notification.CommentId != 12 || *notification.ActorId != 1 || notification.Title != "План" {
+						t.Errorf("saved notification = %+v", notification)
+					}
+					return 1, nil
+				})
+			}
+
+			if _, err := commentService.CreateComment(1, 1, nil, "@colleague, посмотри. И @author01.", nil); err != nil {
+				t.Errorf("CreateComment() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestConcreteCommentService_UpdateComment_NewMentionsOnly(t *testing.T) {
+	commentService, repo := initCommentServiceTest(t)
+
+	note := &model.Note{Id: 5, UserId: 1, Title: "План"}
+
+	repo.EXPECT().GetCommentById(7).Return(&model.Comment{Id: 7, NoteId: 5, UserId: 1, Content: "@colleague посмотри"}, nil)
+	repo.EXPECT().GetNoteById(5, 1).Return(note, nil)
+	repo.EXPECT().SaveEntity(gomock.AssignableToTypeOf(&model.Comment{})).Return(7, nil)
+	repo.EXPECT().GetUsersByLogins([]string{"reviewer"}).Return([]*model.User{{Id: 3, Login: "reviewer"}})
+	repo.EXPECT().GetNoteById(5, 3).Return(note, nil)
+	repo.EXPECT().GetPreferences(3).Return(nil, repository.EntityNotFoundError)
+	repo.EXPECT().SaveEntity(gomock.AssignableToTypeOf(&model.Notification{})).Return(1, nil)
+
+	if err := commentService.UpdateComment(1, 7, "@colleague и @reviewer посмотрите"); err != nil {
+		t.Errorf("UpdateComment() error = %v", err)
+	}
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"Notes/config"
+	"Notes/internal/constants"
+	"Notes/internal/model"
+	"Notes/internal/repository"
+	"time"
+)
+
+//go:generate mockgen -source=dailyNoteService.go -destination=mock/dailyNoteService.go -package=mock
+
+type AbstractDailyNoteService interface {
+	GetDailyNote(userId int, date string) (*model.NoteApi, *model.ApplicationError)
+	GetSettings(userId int) (*model.DailyNoteSettingsApi, *model.ApplicationError)
+	UpdateSettings(userId int, templateId *int, folderId *int) *model.ApplicationError
+}
+
+type DailyNoteService struct {
+	repo            repository.AbstractRepository
+	noteService     AbstractNoteService
+	defaultTemplate *model.Template
+	now             func() time.Time
+}
+
+func NewConcreteDailyNoteService(repository repository.AbstractRepository, noteService AbstractNoteService, cfg *config.Config) AbstractDailyNoteService {
+	return &DailyNoteService{
+		repo:        repository,
+		noteService: noteService,
+		defaultTemplate: &model.Template{
+			Title:   cfg.DailyNotes.Title,
+			Content: cfg.DailyNotes.Content,
+		},
+		now: time.Now,
+	}
+}
+
+func (d *DailyNoteService) withOrigin(origin model.AuditOrigin) any {
+	service := *d
+	service.noteService = WithOrigin(d.noteService, origin)
+	return &service
+}
+
+// GetDailyNote возвращает заметку за день date (по умолчанию - сегодня в часовом поясе пользователя),
+// создавая её по шаблону, если её ещё нет.
+func (d *DailyNoteService) GetDailyNote(userId int, date string) (*model.NoteApi, *model.ApplicationError) {
+	user, err := d.repo.GetUserById(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := d.now().In(user.Location())
+	day := model.DailyNoteDay(now, user.Location())
+
+	if date != "" {
+		if day, err = model.ParseDailyNoteDate(date); err != nil {
+			return nil, err
+		}
+	}
+
+	dailyNote, err := d.repo.GetDailyNote(userId, day)
+	if err == nil {
+		return d.getNote(userId, dailyNote.NoteId)
+	}
+
+	if err.Type != model.ErrorTypeNotFound {
+		return nil, err
+	}
+
+	noteId, err := d.createDailyNote(user, day, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.getNote(userId, noteId)
+}
+
+func (d *DailyNoteService) GetSettings(userId int) (*model.DailyNoteSettingsApi, *model.ApplicationError) {
+	settings, err := d.getSettings(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.ToDailyNoteSettingsApi(settings), nil
+}
+
+func (d *DailyNoteService) UpdateSettings(userId int, templateId *int, folderId *int) *model.ApplicationError {
+	if templateId != nil {
+		template, err := d.repo.GetTemplateById(*templateId, userId)
+		if err != nil {
+			return err
+		}
+
+		// Ежедневная заметка создаётся без участия пользователя, поэтому отвечать на запросы некому
+		if len(template.Prompts()) > 0 {
+			return model.NewLocalizedError(model.ErrorTypeValidation, model.CodeDailyTemplateHasPrompts, nil, nil)
+		}
+	}
+
+	if folderId != nil {
+		if _, err := d.repo.GetFolderById(*folderId, userId); err != nil {
+			return err
+		}
+	}
+
+	settings, err := d.getSettings(userId)
+	if err != nil {
+		return err
+	}
+
+	settings.TemplateId = templateId
+	settings.FolderId = folderId
+
+	_, err = d.repo.SaveEntity(settings)
+	return err
+}
+
+// createDailyNote создаёт заметку за день и закрепляет её за этим днём. Если параллельный запрос
+// успел закрепить свою заметку раньше, созданная здесь удаляется и возвращается заметка победителя.
+func (d *DailyNoteService) createDailyNote(user *model.User, day time.Time, now time.Time) (int, *model.ApplicationError) {
+	template, folderId, err := d.getTemplate(user.Id)
+	if err != nil {
+		return constants.FakeId, err
+	}
+
+	at := time.Date(day.Year(), day.Month(), day.Day(), now.Hour(), now.Minute(), now.Second(), 0, now.Location())
+
+	rendered, err := template.Render(model.TemplateContext{Now: at, User: user}, map[string]string{})
+	if err != nil {
+		return constants.FakeId, err
+	}
+
+	if folderId == nil {
+		folderId = rendered.FolderId
+	}
+
+	isCreated := true
+	noteId, err := createNoteInFolder(d.noteService, user.Id, rendered.Title, rendered.Content, rendered.Tags, folderId)
+	if err != nil {
+		// Заметка с таким названием уже есть: её создал параллельный запрос или сам пользователь
+		if err.Code != model.CodeNoteTitleTaken {
+			return constants.FakeId, err
+		}
+
+		existing := d.findNoteByTitle(user.Id, rendered.Title)
+		if existing == nil {
+			return constants.FakeId, err
+		}
+
+		isCreated = false
+		noteId = existing.Id
+	}
+
+	claimed, err := d.repo.ClaimDailyNote(&model.DailyNote{UserId: user.Id, Date: day, NoteId: noteId})
+	if err != nil {
+		return constants.FakeId, err
+	}
+
+	if isCreated && claimed.NoteId != noteId {
+		if err = d.noteService.DeleteNote(user.Id, noteId, ""); err != nil {
+			return constants.FakeId, err
+		}
+	}
+
+	return claimed.NoteId, nil
+}
+
+// getTemplate возвращает шаблон ежедневной заметки и папку из настроек пользователя
+func (d *DailyNoteService) getTemplate(userId int) (*model.Template, *int, *model.ApplicationError) {
+	settings, err := d.getSettings(userId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if settings.TemplateId == nil {
+		return d.defaultTemplate, settings.FolderId, nil
+	}
+
+	template, err := d.repo.GetTemplateById(*settings.TemplateId, userId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return template, settings.FolderId, nil
+}
+
+func (d *DailyNoteService) getSettings(userId int) (*model.DailyNoteSettings, *model.ApplicationError) {
+	settings, err := d.repo.GetDailyNoteSettings(userId)
+	if err != nil {
+		if err.Type != model.ErrorTypeNotFound {
+			return nil, err
+		}
+		return &model.DailyNoteSettings{UserId: userId}, nil
+	}
+
+	return settings, nil
+}
+
+func (d *DailyNoteService) getNote(userId int, noteId int) (*model.NoteApi, *model.ApplicationError) {
+	note, err := d.repo.GetNoteById(noteId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	notes := []*model.NoteApi{model.ToNoteApi(note)}
+	decorateNotes(d.repo, userId, notes)
+
+	return notes[0], nil
+}
+
+func (d *DailyNoteService) findNoteByTitle(userId int, title string) *model.Note {
+	for _, note := range d.repo.GetNotesByUserId(userId) {
+		if note.Title == title {
+			return note
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"Notes/config"
+	"Notes/internal/constants"
+	"Notes/internal/model"
+	mocks "Notes/internal/service/mock"
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	"testing"
+	"time"
+)
+
+func initDailyNoteServiceTest(t *testing.T) (AbstractDailyNoteService, *mocks.MockAbstractRepository, *mocks.MockAbstractNoteService) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockAbstractRepository(ctrl)
+	mockNoteService := mocks.NewMockAbstractNoteService(ctrl)
+
+	cfg := &config.Config{DailyNotes: config.DailyNotes{Title: "{{date}}", Content: "Заметки за {{date}}"}}
+
+	dailyNoteService := NewConcreteDailyNoteService(mockRepository, mockNoteService, cfg)
+	dailyNoteService.(*DailyNoteService).now = func() time.Time {
+		return time.Date(2026, 3, 4, 22, 30, 0, 0, time.UTC)
+	}
+
+	return dailyNoteService, mockRepository, mockNoteService
+}
+
+func TestConcreteDailyNoteService_GetDailyNote(t *testing.T) {
+	dailyNoteService, repo, noteService := initDailyNoteServiceTest(t)
+
+	user := &model.User{Id: 1, Name: "Иван", Timezone: "Europe/Moscow"}
+	notFound := model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil)
+	today := time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC)
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		mock    func()
+		date    string
+		want    *model.NoteApi
+		wantErr *model.ApplicationError
+	}{
+		{
+			name: "invalid date",
+			mock: func() {
+				repo.EXPECT().GetUserById(1).Return(user, nil)
+			},
+			date:    "02.03.2026",
+			wantErr: model.NewApplicationError(model.ErrorTypeValidation, "Неверный формат даты: 02.03.2026, ожидается ГГГГ-ММ-ДД", nil),
+		},
+		{
+			name: "existing note for today in user timezone",
+			mock: func() {
+				repo.EXPECT().GetUserById(1).Return(user, nil)
+				repo.EXPECT().GetDailyNote(1, today).Return(&model.DailyNote{UserId: 1, Date: today, NoteId: 4}, nil)
+				repo.EXPECT().GetNoteById(4, 1).Return(&model.Note{Id: 4, Title: "2026-03-05", Content: "content", UserId: 1}, nil)
+				repo.EXPECT().GetCommentCounts(gomock.Any()).Return(map[int]int{})
+				repo.EXPECT().GetNoteLocks(gomock.Any(), gomock.Any()).Return(map[int]*model.NoteLock{})
+				repo.EXPECT().GetImageAttachmentsByUserId(1).Return([]*model.Attachment{})
+			},
+			want: &model.NoteApi{Id: 4, Title: "2026-03-05", Content: "content", UserId: 1},
+		},
+		{
+			name: "note created from default template",
+			mock: func() {
+				repo.EXPECT().GetUserById(1).Return(user, nil)
+				repo.EXPECT().GetDailyNote(1, day).Return(nil, notFound)
+				repo.EXPECT().GetDailyNoteSettings(1).Return(nil, notFound)
+				noteService.EXPECT().CreateNote(1, "2026-03-02", "Заметки за 2026-03-02", &[]string{}).Return(5, nil)
+				repo.EXPECT().ClaimDailyNote(&model.DailyNote{UserId: 1, Date: day, NoteId: 5}).Return(&model.DailyNote{Id: 1, UserId: 1, Date: day, NoteId: 5}, nil)
+				repo.EXPECT().GetNoteById(5, 1).Return(&model.Note{Id: 5, Title: "2026-03-02", Content: "Заметки за 2026-03-02", UserId: 1}, nil)
+				repo.EXPECT().GetCommentCounts(gomock.Any()).Return(map[int]int{})
+				repo.EXPECT().GetNoteLocks(gomock.Any(), gomock.Any()).Return(map[int]*model.NoteLock{})
+				repo.EXPECT().GetImageAttachmentsByUserId(1).Return([]*model.Attachment{})
+			},
+			date: "2026-03-02",
+			want: &model.NoteApi{Id: 5, Title: "2026-03-02", Content: "Заметки за 2026-03-02", UserId: 1},
+		},
+		{
+			name: "note created from user template in configured folder",
+			mock: func() {
+				repo.EXPECT().GetUserById(1).Return(user, nil)
+				repo.EXPECT().GetDailyNote(1, day).Return(nil, notFound)
+				repo.EXPECT().GetDailyNoteSettings(1).Return(&model.DailyNoteSettings{UserId: 1, TemplateId: intPointer(2), FolderId: intPointer(7)}, nil)
+				repo.EXPECT().GetTemplateById(2, 1).Return(&model.Template{Id: 2, UserId: 1, Title: "День {{date}}", Content: "{{time}} {{user.name}}"}, nil)
+				noteService.EXPECT().CreateNote(1, "День 2026-03-02", "01:30 Иван", &[]string{}).Return(5, nil)
+				noteService.EXPECT().MoveToFolder(1, 5, intPointer(7), "").Return(nil)
+				repo.EXPECT().ClaimDailyNote(&model.DailyNote{UserId: 1, Date: day, NoteId: 5}).Return(&model.DailyNote{Id: 1, UserId: 1, Date: day, NoteId: 5}, nil)
+				repo.EXPECT().GetNoteById(5, 1).Return(&model.Note{Id: 5, Title: "День 2026-03-02", Content: "01:30 Иван", UserId: 1, FolderId: intPointer(7)}, nil)
+				repo.EXPECT().GetCommentCounts(gomock.Any()).Return(map[int]int{})
+				repo.EXPECT().GetNoteLocks(gomock.Any(), gomock.Any()).Return(map[int]*model.NoteLock{})
+				repo.EXPECT().GetImageAttachmentsByUserId(1).Return([]*model.Attachment{})
+			},
+			date: "2026-03-02",
+			want: &model.NoteApi{Id: 5, Title: "День 2026-03-02", Content: "01:30 Иван", UserId: 1, FolderId: intPointer(7)},
+		},
+		{
+			name: "concurrent request claimed the day first",
+			mock: func() {
+				repo.EXPECT().GetUserById(1).Return(user, nil)
+				repo.EXPECT().GetDailyNote(1, day).Return(nil, notFound)
+				repo.EXPECT().GetDailyNoteSettings(1).Return(nil, notFound)
+				noteService.EXPECT().CreateNote(1, "2026-03-02", "Заметки за 2026-03-02", &[]string{}).Return(5, nil)
+				repo.EXPECT().ClaimDailyNote(&model.DailyNote{UserId: 1, Date: day, NoteId: 5}).Return(&model.DailyNote{Id: 1, UserId: 1, Date: day, NoteId: 6}, nil)
+				noteService.EXPECT().DeleteNote(1, 5, "").Return(nil)
+				repo.EXPECT().GetNoteById(6, 1).Return(&model.Note{Id: 6, Title: "2026-03-02", Content: "content", UserId: 1}, nil)
+				repo.EXPECT().GetCommentCounts(gomock.Any()).Return(map[int]int{})
+				repo.EXPECT().GetNoteLocks(gomock.Any(), gomock.Any()).Return(map[int]*model.NoteLock{})
+				repo.EXPECT().GetImageAttachmentsByUserId(1).Return([]*model.Attachment{})
+			},
+			date: "2026-03-02",
+			want: &model.NoteApi{Id: 6, Title: "2026-03-02", Content: "content", UserId: 1},
+		},
+		{
+			name: "existing note with the same title is adopted",
+			mock: func() {
+				repo.EXPECT().GetUserById(1).Return(user, nil)
+				repo.EXPECT().GetDailyNote(1, day).Return(nil, notFound)
+				repo.EXPECT().GetDailyNoteSettings(1).Return(nil, notFound)
+				noteService.EXPECT().CreateNote(1, "2026-03-02", "Заметки за 2026-03-02", &[]string{}).
+					Return(constants.FakeId, model.NewLocalizedError(model.ErrorTypeConflict, model.CodeNoteTitleTaken, nil, nil))
+				repo.EXPECT().GetNotesByUserId(1).Return([]*model.Note{{Id: 3, Title: "2026-03-02", Content: "manual", UserId: 1}})
+				repo.EXPECT().ClaimDailyNote(&model.DailyNote{UserId: 1, Date: day, NoteId: 3}).Return(&model.DailyNote{Id: 1, UserId: 1, Date: day, NoteId: 3}, nil)
+				repo.EXPECT().GetNoteById(3, 1).Return(&model.Note{Id: 3, Title: "2026-03-02", Content: "manual", UserId: 1}, nil)
+				repo.EXPECT().GetCommentCounts(gomock.Any()).Return(map[int]int{})
+				repo.EXPECT().GetNoteLocks(gomock.Any(), gomock.Any()).Return(map[int]*model.NoteLock{})
+				repo.EXPECT().GetImageAttachmentsByUserId(1).Return([]*model.Attachment{})
+			},
+			date: "2026-03-02",
+			want: &model.NoteApi{Id: 3, Title: "2026-03-02", Content: "manual", UserId: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := dailyNoteService.GetDailyNote(1, tt.date)
+			if tt.wantErr != nil {
+				if err == nil || err.Type != tt.wantErr.Type || err.Message != tt.wantErr.Message {
+					t.Errorf("DailyNoteService.GetDailyNote() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("DailyNoteService.GetDailyNote() unexpected error = %v", err)
+				return
+			}
+
+			gotJson, _ := json.Marshal(got)
+			expectedJson, _ := json.Marshal(tt.want)
+			if string(gotJson) != string(expectedJson) {
+				t.Errorf("DailyNoteService.GetDailyNote() = %v, want %v", string(gotJson), string(expectedJson))
+			}
+		})
+	}
+}
+
+func TestConcreteDailyNoteService_UpdateSettings(t *testing.T) {
+	dailyNoteService, repo, _ := initDailyNoteServiceTest(t)
+
+	tests := []struct {
+		name       string
+		mock       func()
+		templateId *int
+		folderId   *int
+		wantErr    *model.ApplicationError
+	}{
+		{
+			name: "template with prompts is rejected",
+			mock: func() {
+				repo.EXPECT().GetTemplateById(2, 1).Return(&model.Template{Id: 2, UserId: 1, Title: "{{prompt:Тема}}"}, nil)
+			},
+			templateId: intPointer(2),
+			wantErr:    model.NewLocalizedError(model.ErrorTypeValidation, model.CodeDailyTemplateHasPrompts, nil, nil),
+		},
+		{
+			name: "settings saved",
+			mock: func() {
+				repo.EXPECT().GetTemplateById(2, 1).Return(&model.Template{Id: 2, UserId: 1, Title: "{{date}}"}, nil)
+				repo.EXPECT().GetFolderById(7, 1).Return(&model.Folder{Id: 7, UserId: 1}, nil)
+				repo.EXPECT().GetDailyNoteSettings(1).Return(nil, model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil))
+				repo.EXPECT().SaveEntity(&model.DailyNoteSettings{UserId: 1, TemplateId: intPointer(2), FolderId: intPointer(7)}).Return(1, nil)
+			},
+			templateId: intPointer(2),
+			folderId:   intPointer(7),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			err := dailyNoteService.UpdateSettings(1, tt.templateId, tt.folderId)
+			if tt.wantErr != nil {
+				if err == nil || err.Type != tt.wantErr.Type || err.Message != tt.wantErr.Message {
+					t.Errorf("DailyNoteService.UpdateSettings() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("DailyNoteService.UpdateSettings() unexpected error = %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package service
+
+//go:generate mockgen -source=documentService.go -destination=mock/documentService.go -package=mock
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/repository"
+	"Notes/internal/utils"
+	"time"
+)
+
+type AbstractDocumentService interface {
+	ExportNote(userId int, noteId int, format model.DocumentFormat) (*model.DocumentFile, *model.ApplicationError)
+	ExportFolder(userId int, folderId int, format model.DocumentFormat) (*model.DocumentFile, *model.ApplicationError)
+}
+
+type DocumentService struct {
+	repo repository.AbstractRepository
+	now  func() time.Time
+}
+
+func NewConcreteDocumentService(repository repository.AbstractRepository) AbstractDocumentService {
+	return &DocumentService{
+		repo: repository,
+		now:  time.Now,
+	}
+}
+
+// ExportNote выводит заметку в один PDF- или HTML-документ
+func (d *DocumentService) ExportNote(userId int, noteId int, format model.DocumentFormat) (*model.DocumentFile, *model.ApplicationError) {
+	user, err := d.repo.GetUserById(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	note, err := d.repo.GetNoteById(noteId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	document := &model.ExportDocument{
+		Title:       note.Title,
+		Notes:       []*model.DocumentNote{d.documentNote(note, user.Location())},
+		GeneratedAt: d.now().In(user.Location()),
+	}
+
+	return d.render(document, format)
+}
+
+// ExportFolder выводит заметки папки в порядке списка с оглавлением в начале документа.
+// Архивные заметки попадают в документ, только если архивирована сама папка.
+func (d *DocumentService) ExportFolder(userId int, folderId int, format model.DocumentFormat) (*model.DocumentFile, *model.ApplicationError) {
+	user, err := d.repo.GetUserById(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	folder, err := d.repo.GetFolderById(folderId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	folderNotes := make([]*model.Note, 0)
+	for _, note := range d.repo.GetNotesByUserId(userId) {
+		if isSameFolder(note.FolderId, &folder.Id) && (!note.IsArchived || folder.IsArchived) {
+			folderNotes = append(folderNotes, note)
+		}
+	}
+	model.SortNotes(folderNotes)
+
+	document := &model.ExportDocument{
+		Title:       folder.Title,
+		IsFolder:    true,
+		Notes:       make([]*model.DocumentNote, 0, len(folderNotes)),
+		GeneratedAt: d.now().In(user.Location()),
+	}
+
+	for _, note := range folderNotes {
+		document.Notes = append(document.Notes, d.documentNote(note, user.Location()))
+	}
+
+	return d.render(document, format)
+}
+
+func (d *DocumentService) documentNote(note *model.Note, location *time.Location) *model.DocumentNote {
+	var items []*model.ChecklistItem
+	if note.IsChecklist() {
+		items = d.repo.GetChecklistItemsByNoteId(note.Id)
+	}
+
+	return model.ToDocumentNote(note, items, location)
+}
+
+func (d *DocumentService) render(document *model.ExportDocument, format model.DocumentFormat) (*model.DocumentFile, *model.ApplicationError) {
+	var data []byte
+	var err error
+
+	if format == model.DocumentFormatHtml {
+		data, err = utils.RenderHtmlDocument(document)
+	} else {
+		data, err = utils.RenderPdfDocument(document)
+	}
+
+	if err != nil {
+		return nil, exportError(err)
+	}
+
+	return &model.DocumentFile{
+		FileName:    format.FileName(document.Title),
+		ContentType: format.ContentType(),
+		Data:        data,
+	}, nil
+}
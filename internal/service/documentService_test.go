@@ -0,0 +1,151 @@
+package service
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/repository"
+	mocks "Notes/internal/service/mock"
+	"bytes"
+	"github.com/golang/mock/gomock"
+	"strings"
+	"testing"
+	"time"
+)
+
+func initDocumentServiceTest(t *testing.T) (AbstractDocumentService, *mocks.MockAbstractRepository) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockAbstractRepository(ctrl)
+
+	documentService := NewConcreteDocumentService(mockRepository)
+	documentService.(*DocumentService).now = func() time.Time {
+		return time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	}
+
+	return documentService, mockRepository
+}
+
+func TestConcreteDocumentService_ExportNote(t *testing.T) {
+	timestamp := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+	note := &model.Note{Id: 1, Title: "План: Q1", Content: "# Цели\n\n**Важно** <script>alert(1)</script>", UserId: 1, Tags: []string{"работа"}, Timestamp: timestamp}
+
+	tests := []struct {
+		name            string
+		format          model.DocumentFormat
+		wantFileName    string
+		wantContentType string
+		want            []string
+		wantNot         []string
+	}{
+		{
+			name:            "html",
+			format:          model.DocumentFormatHtml,
+			wantFileName:    "План_ Q1.html",
+			wantContentType: "text/html; charset=utf-8",
+			want:            []string{"<h1>План: Q1</h1>", "<h2>Цели</h2>", "<strong>Важно</strong>", "Теги: работа", "Изменено: 02.01.2025 13:00"},
+			wantNot:         []string{"<script>"},
+		},
+		{
+			name:            "pdf",
+			format:          model.DocumentFormatPdf,
+			wantFileName:    "План_ Q1.pdf",
+			wantContentType: "application/pdf",
+			want:            []string{"%PDF-1.7", "%%EOF", "/FontFile2"},
+		},
+	}
+
+	documentService, repo := initDocumentServiceTest(t)
+	repo.EXPECT().GetUserById(1).Return(&model.User{Id: 1, Timezone: "Europe/Moscow"}, nil).AnyTimes()
+	repo.EXPECT().GetNoteById(1, 1).Return(note, nil).AnyTimes()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			document, err := documentService.ExportNote(1, 1, tt.format)
+			if err != nil {
+				t.Fatalf("DocumentService.ExportNote() error = %v", err)
+			}
+
+			if document.FileName != tt.wantFileName || document.ContentType != tt.wantContentType {
+				t.Errorf("DocumentService.ExportNote() = %s %s, want %s %s", document.FileName, document.ContentType, tt.wantFileName, tt.wantContentType)
+			}
+
+			for _, want := range tt.want {
+				if !bytes.Contains(document.Data, []byte(want)) {
+					t.Errorf("DocumentService.ExportNote() document does not contain %q", want)
+				}
+			}
+
+			for _, wantNot := range tt.wantNot {
+				if bytes.Contains(document.Data, []byte(wantNot)) {
+					t.Errorf("DocumentService.ExportNote() document contains %q", wantNot)
+				}
+			}
+		})
+	}
+}
+
+func TestConcreteDocumentService_ExportFolder(t *testing.T) {
+	documentService, repo := initDocumentServiceTest(t)
+
+	folderId := 2
+	otherFolderId := 3
+
+	repo.EXPECT().GetUserById(1).Return(&model.User{Id: 1}, nil)
+	repo.EXPECT().GetFolderById(2, 1).Return(&model.Folder{Id: 2, Title: "Работа", UserId: 1}, nil)
+	repo.EXPECT().GetNotesByUserId(1).Return([]*model.Note{
+		{Id: 1, Title: "Вторая", UserId: 1, FolderId: &folderId, Position: "b"},
+		{Id: 2, Title: "Покупки", UserId: 1, FolderId: &folderId, Type: model.NoteTypeChecklist, Position: "c", IsPinned: true},
+		{Id: 3, Title: "Архивная", UserId: 1, FolderId: &folderId, IsArchived: true},
+		{Id: 4, Title: "Другая папка", UserId: 1, FolderId: &otherFolderId},
+		{Id: 5, Title: "Без папки", UserId: 1},
+	})
+	repo.EXPECT().GetChecklistItemsByNoteId(2).Return([]*model.ChecklistItem{
+		{Id: 1, NoteId: 2, Text: "Хлеб", IsChecked: true},
+		{Id: 2, NoteId: 2, Text: "Молоко"},
+	})
+
+	document, err := documentService.ExportFolder(1, 2, model.DocumentFormatHtml)
+	if err != nil {
+		t.Fatalf("DocumentService.ExportFolder() error = %v", err)
+	}
+
+	html := string(document.Data)
+	if document.FileName != "Работа.html" {
+		t.Errorf("DocumentService.ExportFolder() file name = %s, want Работа.html", document.FileName)
+	}
+
+	// Оглавление и заметки идут в порядке папки: закреплённые первыми
+	order := []string{
+		`<a href="#note-2">Покупки</a>`,
+		`<a href="#note-1">Вторая</a>`,
+		`<article class="note" id="note-2">`,
+		`<input type="checkbox" checked disabled> Хлеб`,
+		`<input type="checkbox" disabled> Молоко`,
+		`<article class="note" id="note-1">`,
+	}
+	last := -1
+	for _, want := range order {
+		index := strings.Index(html, want)
+		if index <= last {
+			t.Errorf("DocumentService.ExportFolder() document has %q at %d, want after %d", want, index, last)
+		}
+		last = index
+	}
+
+	for _, wantNot := range []string{"Архивная", "Другая папка", "Без папки"} {
+		if strings.Contains(html, wantNot) {
+			t.Errorf("DocumentService.ExportFolder() document contains %q", wantNot)
+		}
+	}
+}
+
+func TestConcreteDocumentService_ExportFolderNotFound(t *testing.T) {
+	documentService, repo := initDocumentServiceTest(t)
+
+	repo.EXPECT().GetUserById(1).Return(&model.User{Id: 1}, nil)
+	repo.EXPECT().GetFolderById(2, 1).Return(nil, repository.EntityNotFoundError)
+
+	if _, err := documentService.ExportFolder(1, 2, model.DocumentFormatPdf); err != repository.EntityNotFoundError {
+		t.Errorf("DocumentService.ExportFolder() error = %v, want %v", err, repository.EntityNotFoundError)
+	}
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"Notes/config"
+	"Notes/internal/model"
+	"fmt"
+	"mime"
+	"net/smtp"
+	"strings"
+)
+
+type EmailNotifier struct {
+	cfg config.Smtp
+}
+
+func NewEmailNotifier(cfg config.Smtp) Notifier {
+	return &EmailNotifier{cfg: cfg}
+}
+
+func (e *EmailNotifier) Channel() model.ReminderChannel {
+	return model.ReminderChannelEmail
+}
+
+func (e *EmailNotifier) Notify(notification *model.Notification, target model.NotificationTarget) *model.ApplicationError {
+	if target.Email == nil {
+		return model.NewLocalizedError(model.ErrorTypeValidation, model.CodeEmailAddressMissing, nil, nil)
+	}
+
+	var auth smtp.Auth
+	if e.cfg.User != "" {
+		auth = smtp.PlainAuth("", e.cfg.User, e.cfg.Password, e.cfg.Host)
+	}
+
+	address := fmt.Sprintf("%s:%d", e.cfg.Host, e.cfg.Port)
+	err := smtp.SendMail(address, auth, e.cfg.From, []string{*target.Email}, e.buildMessage(notification, *target.Email))
+	if err != nil {
+		return model.NewLocalizedError(model.ErrorTypeInternal, model.CodeEmailSendFailed, nil, err)
+	}
+
+	return nil
+}
+
+func (e *EmailNotifier) buildMessage(notification *model.Notification, to string) []byte {
+	var builder strings.Builder
+
+	builder.WriteString("From: " + e.cfg.From + "\r\n")
+	builder.WriteString("To: " + to + "\r\n")
+	builder.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", notification.Title) + "\r\n")
+	builder.WriteString("MIME-Version: 1.0\r\n")
+	builder.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	builder.WriteString("\r\n")
+	builder.WriteString(strings.ReplaceAll(notification.Body, "\n", "\r\n"))
+	builder.WriteString("\r\n")
+
+	return []byte(builder.String())
+}
@@ -0,0 +1,150 @@
+package service
+
+//go:generate mockgen -source=eventBus.go -destination=mock/eventBus.go -package=mock
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/repository"
+	"context"
+	"encoding/json"
+	"log"
+	"sync"
+	"time"
+)
+
+// eventSubscriptionBuffer - сколько событий может ждать отправки одному подписчику
+const eventSubscriptionBuffer = 64
+
+// eventListenRetryInterval - пауза перед повторным подключением к брокеру после ошибки
+const eventListenRetryInterval = 5 * time.Second
+
+type AbstractEventBus interface {
+	Run(ctx context.Context)
+	Publish(event *model.Event)
+	Subscribe(userId int) *model.EventSubscription
+}
+
+// eventSubscriber - получатель событий на этом экземпляре
+type eventSubscriber struct {
+	events chan *model.Event
+	userId int
+	once   sync.Once
+}
+
+// eventMessage - событие в том виде, в котором оно пересылается через брокер
+type eventMessage struct {
+	UserId int
+	Event  *model.Event
+}
+
+// EventBus раздаёт события подписчикам этого экземпляра. Если задан брокер, события сначала
+// отправляются через него и доходят до подписчиков всех экземпляров, включая этот.
+type EventBus struct {
+	broker      repository.AbstractEventBroker
+	mu          sync.Mutex
+	subscribers map[int]map[*eventSubscriber]struct{}
+}
+
+func NewConcreteEventBus(broker repository.AbstractEventBroker) AbstractEventBus {
+	return &EventBus{
+		broker:      broker,
+		subscribers: make(map[int]map[*eventSubscriber]struct{}),
+	}
+}
+
+// Publish не возвращает ошибку: изменение уже сохранено, и сбой рассылки не должен его отменять
+func (e *EventBus) Publish(event *model.Event) {
+	if e.broker == nil {
+		e.deliver(event.UserId, event)
+		return
+	}
+
+	payload, err := json.Marshal(&eventMessage{UserId: event.UserId, Event: event})
+	if err == nil {
+		err = e.broker.Publish(payload)
+	}
+
+	if err != nil {
+		log.Printf("Не удалось разослать событие %s: %v", event.Type, err)
+		e.deliver(event.UserId, event)
+	}
+}
+
+func (e *EventBus) Subscribe(userId int) *model.EventSubscription {
+	subscriber := &eventSubscriber{events: make(chan *model.Event, eventSubscriptionBuffer), userId: userId}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.subscribers[userId] == nil {
+		e.subscribers[userId] = make(map[*eventSubscriber]struct{})
+	}
+	e.subscribers[userId][subscriber] = struct{}{}
+
+	return model.NewEventSubscription(subscriber.events, func() {
+		e.unsubscribe(subscriber)
+	})
+}
+
+// Run получает события других экземпляров через брокер до отмены контекста
+func (e *EventBus) Run(ctx context.Context) {
+	if e.broker == nil {
+		return
+	}
+
+	for {
+		err := e.broker.Listen(ctx, e.receive)
+		if ctx.Err() != nil {
+			return
+		}
+
+		log.Printf("Ошибка получения событий: %v", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(eventListenRetryInterval):
+		}
+	}
+}
+
+func (e *EventBus) receive(payload []byte) {
+	var message eventMessage
+	if err := json.Unmarshal(payload, &message); err != nil || message.Event == nil {
+		log.Printf("Получено некорректное событие: %s", payload)
+		return
+	}
+
+	e.deliver(message.UserId, message.Event)
+}
+
+func (e *EventBus) deliver(userId int, event *model.Event) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for subscriber := range e.subscribers[userId] {
+		select {
+		case subscriber.events <- event:
+		default:
+			e.remove(subscriber)
+		}
+	}
+}
+
+func (e *EventBus) unsubscribe(subscriber *eventSubscriber) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.remove(subscriber)
+}
+
+// remove вызывается под мьютексом
+func (e *EventBus) remove(subscriber *eventSubscriber) {
+	subscriber.once.Do(func() {
+		delete(e.subscribers[subscriber.userId], subscriber)
+		if len(e.subscribers[subscriber.userId]) == 0 {
+			delete(e.subscribers, subscriber.userId)
+		}
+		close(subscriber.events)
+	})
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"Notes/internal/model"
+	mocks "Notes/internal/service/mock"
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"testing"
+	"time"
+)
+
+func receiveEvent(t *testing.T, subscription *model.EventSubscription) *model.Event {
+	t.Helper()
+
+	select {
+	case event := <-subscription.Events:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("event was not delivered")
+		return nil
+	}
+}
+
+func TestEventBus_PublishLocal(t *testing.T) {
+	eventBus := NewConcreteEventBus(nil)
+
+	first := eventBus.Subscribe(1)
+	second := eventBus.Subscribe(1)
+	other := eventBus.Subscribe(2)
+	defer other.Close()
+
+	eventBus.Publish(model.NewNoteEvent(model.EventNoteCreated, &model.Note{Id: 5, UserId: 1, Title: "Новая"}))
+
+	for _, subscription := range []*model.EventSubscription{first, second} {
+		event := receiveEvent(t, subscription)
+		if event.Type != model.EventNoteCreated || *event.NoteId != 5 || event.Title != "Новая" {
+			t.Errorf("event = %+v, want note.created of note 5", event)
+		}
+	}
+
+	if len(other.Events) != 0 {
+		t.Errorf("event of user 1 was delivered to user 2")
+	}
+
+	first.Close()
+	if _, ok := <-first.Events; ok {
+		t.Errorf("Events is not closed after Close()")
+	}
+	// Повторное закрытие не должно паниковать
+	first.Close()
+	second.Close()
+}
+
+func TestEventBus_SlowSubscriberDropped(t *testing.T) {
+	eventBus := NewConcreteEventBus(nil)
+	subscription := eventBus.Subscribe(1)
+	defer subscription.Close()
+
+	for i := 0; i <= eventSubscriptionBuffer; i++ {
+		eventBus.Publish(model.NewFolderEvent(model.EventFolderCreated, &model.Folder{Id: i, UserId: 1}))
+	}
+
+	received := 0
+	for range subscription.Events {
+		received++
+	}
+
+	if received != eventSubscriptionBuffer {
+		t.Errorf("received %d events before close, want %d", received, eventSubscriptionBuffer)
+	}
+}
+
+func TestEventBus_PublishThroughBroker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	broker := mocks.NewMockAbstractEventBroker(ctrl)
+	eventBus := NewConcreteEventBus(broker)
+
+	subscription := eventBus.Subscribe(1)
+	defer subscription.Close()
+
+	// Брокер возвращает сообщения всех экземпляров, включая собственные
+	published := make(chan []byte, 1)
+	broker.EXPECT().Publish(gomock.Any()).DoAndReturn(func(payload []byte) error {
+		published <- payload
+		return nil
+	})
+	broker.EXPECT().Listen(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, handle func(payload []byte)) error {
+		handle(<-published)
+		<-ctx.Done()
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		eventBus.Run(ctx)
+		close(done)
+	}()
+
+	eventBus.Publish(model.NewFolderEvent(model.EventFolderRenamed, &model.Folder{Id: 3, UserId: 1, Title: "Работа"}))
+
+	event := receiveEvent(t, subscription)
+	if event.Type != model.EventFolderRenamed || *event.FolderId != 3 || event.Title != "Работа" {
+		t.Errorf("event = %+v, want folder.renamed of folder 3", event)
+	}
+
+	cancel()
+	<-done
+}
+
+func TestEventBus_BrokerFailureDeliversLocally(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	broker := mocks.NewMockAbstractEventBroker(ctrl)
+	broker.EXPECT().Publish(gomock.Any()).Return(errors.New("connection refused"))
+
+	eventBus := NewConcreteEventBus(broker)
+	subscription := eventBus.Subscribe(1)
+	defer subscription.Close()
+
+	eventBus.Publish(model.NewNoteEvent(model.EventNoteDeleted, &model.Note{Id: 7, UserId: 1}))
+
+	if event := receiveEvent(t, subscription); event.Type != model.EventNoteDeleted {
+		t.Errorf("event = %+v, want note.deleted", event)
+	}
+}
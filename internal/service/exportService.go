@@ -0,0 +1,172 @@
+package service
+
+//go:generate mockgen -source=exportService.go -destination=mock/exportService.go -package=mock
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/repository"
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// exportBatchSize - сколько заметок читается из БД за один запрос при выгрузке
+const exportBatchSize = 100
+
+type AbstractExportService interface {
+	Export(userId int, format model.ExportFormat, w io.Writer) *model.ApplicationError
+}
+
+type ExportService struct {
+	repo repository.AbstractRepository
+	now  func() time.Time
+}
+
+func NewConcreteExportService(repository repository.AbstractRepository) AbstractExportService {
+	return &ExportService{
+		repo: repository,
+		now:  time.Now,
+	}
+}
+
+// Export пишет все папки и заметки пользователя в w по мере чтения из БД
+func (e *ExportService) Export(userId int, format model.ExportFormat, w io.Writer) *model.ApplicationError {
+	folders := e.repo.GetFoldersByUserId(userId)
+
+	folderTitles := make(map[int]string, len(folders))
+	for _, folder := range folders {
+		folderTitles[folder.Id] = folder.Title
+	}
+
+	if format == model.ExportFormatJson {
+		return e.exportJson(userId, folders, folderTitles, w)
+	}
+
+	return e.exportMarkdown(userId, folders, folderTitles, w)
+}
+
+// exportJson пишет ExportArchive, не собирая список заметок целиком: заголовок архива
+// формируется вручную, а заметки дописываются в массив Notes по одной
+func (e *ExportService) exportJson(userId int, folders []*model.Folder, folderTitles map[int]string, w io.Writer) *model.ApplicationError {
+	exportedAt, err := json.Marshal(e.now())
+	if err != nil {
+		return exportError(err)
+	}
+
+	exportFolders, err := json.Marshal(model.ToExportFolders(folders))
+	if err != nil {
+		return exportError(err)
+	}
+
+	if _, err = fmt.Fprintf(w, `{"Version":%d,"ExportedAt":%s,"Folders":%s,"Notes":[`, model.ExportArchiveVersion, exportedAt, exportFolders); err != nil {
+		return exportError(err)
+	}
+
+	first := true
+	errExport := e.forEachNote(userId, folderTitles, func(note *model.ExportNote) *model.ApplicationError {
+		data, err := json.Marshal(note)
+		if err != nil {
+			return exportError(err)
+		}
+
+		if !first {
+			data = append([]byte(","), data...)
+		}
+		first = false
+
+		if _, err = w.Write(data); err != nil {
+			return exportError(err)
+		}
+		return nil
+	})
+	if errExport != nil {
+		return errExport
+	}
+
+	if _, err = io.WriteString(w, "]}"); err != nil {
+		return exportError(err)
+	}
+	return nil
+}
+
+// exportMarkdown пишет ZIP: папки - каталоги, заметки - файлы .md с YAML-заголовком
+func (e *ExportService) exportMarkdown(userId int, folders []*model.Folder, folderTitles map[int]string, w io.Writer) *model.ApplicationError {
+	archive := zip.NewWriter(w)
+
+	// Каталоги пишутся отдельно, чтобы пустые папки тоже попали в архив
+	for _, folder := range folders {
+		if _, err := archive.Create(model.MarkdownFolderPath(folder.Title) + "/"); err != nil {
+			return exportError(err)
+		}
+	}
+
+	usedPaths := make(map[string]bool)
+	errExport := e.forEachNote(userId, folderTitles, func(note *model.ExportNote) *model.ApplicationError {
+		content, err := model.RenderMarkdownNote(note)
+		if err != nil {
+			return err
+		}
+
+		filePath := uniqueMarkdownPath(model.MarkdownFilePath(note.Folder, note.Title), usedPaths)
+		file, errCreate := archive.CreateHeader(&zip.FileHeader{Name: filePath, Method: zip.Deflate, Modified: note.Timestamp})
+		if errCreate != nil {
+			return exportError(errCreate)
+		}
+
+		if _, errWrite := file.Write(content); errWrite != nil {
+			return exportError(errWrite)
+		}
+		return nil
+	})
+	if errExport != nil {
+		return errExport
+	}
+
+	if err := archive.Close(); err != nil {
+		return exportError(err)
+	}
+	return nil
+}
+
+func (e *ExportService) forEachNote(userId int, folderTitles map[int]string, handle func(note *model.ExportNote) *model.ApplicationError) *model.ApplicationError {
+	return e.repo.GetNotesByUserIdInBatches(userId, exportBatchSize, func(notes []*model.Note) *model.ApplicationError {
+		itemsByNote := make(map[int][]*model.ChecklistItem)
+
+		checklistIds := make([]int, 0)
+		for _, note := range notes {
+			if note.IsChecklist() {
+				checklistIds = append(checklistIds, note.Id)
+			}
+		}
+
+		if len(checklistIds) > 0 {
+			for _, item := range e.repo.GetChecklistItemsByNoteIds(checklistIds) {
+				itemsByNote[item.NoteId] = append(itemsByNote[item.NoteId], item)
+			}
+		}
+
+		for _, note := range notes {
+			if err := handle(model.ToExportNote(note, folderTitles, itemsByNote[note.Id])); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// uniqueMarkdownPath добавляет к имени файла номер, если заметка с таким именем уже выгружена в тот же каталог
+func uniqueMarkdownPath(basePath string, usedPaths map[string]bool) string {
+	filePath := basePath + model.MarkdownExtension
+	for i := 2; usedPaths[filePath]; i++ {
+		filePath = fmt.Sprintf("%s (%d)%s", basePath, i, model.MarkdownExtension)
+	}
+
+	usedPaths[filePath] = true
+	return filePath
+}
+
+func exportError(err error) *model.ApplicationError {
+	return model.NewLocalizedError(model.ErrorTypeInternal, model.CodeInternalError, nil, err)
+}
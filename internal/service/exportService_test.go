@@ -0,0 +1,147 @@
+package service
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/repository"
+	mocks "Notes/internal/service/mock"
+	"bytes"
+	"github.com/golang/mock/gomock"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func initExportServiceTest(t *testing.T) (AbstractExportService, *mocks.MockAbstractRepository) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockAbstractRepository(ctrl)
+
+	exportService := NewConcreteExportService(mockRepository)
+	exportService.(*ExportService).now = func() time.Time {
+		return time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	}
+
+	return exportService, mockRepository
+}
+
+func mockExportedNotebook(repo *mocks.MockAbstractRepository) {
+	folderId := 2
+	timestamp := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	repo.EXPECT().GetFoldersByUserId(1).Return([]*model.Folder{
+		{Id: 2, Title: "Работа / Проекты", UserId: 1},
+		{Id: 3, Title: "Пустая", UserId: 1, IsArchived: true},
+	})
+	repo.EXPECT().GetNotesByUserIdInBatches(1, exportBatchSize, gomock.Any()).DoAndReturn(func(userId int, batchSize int, handle func(notes []*model.Note) *model.ApplicationError) *model.ApplicationError {
+		if err := handle([]*model.Note{
+			{Id: 1, Title: "План: Q1", Content: "Текст", UserId: 1, Tags: []string{"работа"}, FolderId: &folderId, IsFavorite: true, Timestamp: timestamp},
+			{Id: 2, Title: "Покупки", Content: "Хлеб\nМолоко", UserId: 1, Type: model.NoteTypeChecklist, IsPinned: true, Timestamp: timestamp},
+		}); err != nil {
+			return err
+		}
+		return handle([]*model.Note{
+			{Id: 3, Title: "План? Q1", Content: "Другой", UserId: 1, FolderId: &folderId, IsArchived: true, Timestamp: timestamp},
+		})
+	})
+	repo.EXPECT().GetChecklistItemsByNoteIds([]int{2}).Return([]*model.ChecklistItem{
+		{Id: 1, NoteId: 2, Text: "Хлеб", IsChecked: true},
+		{Id: 2, NoteId: 2, Text: "Молоко"},
+	})
+}
+
+func TestConcreteExportService_ExportJson(t *testing.T) {
+	exportService, repo := initExportServiceTest(t)
+	mockExportedNotebook(repo)
+
+	var buf bytes.Buffer
+	if err := exportService.Export(1, model.ExportFormatJson, &buf); err != nil {
+		t.Fatalf("ExportService.Export() error = %v", err)
+	}
+
+	var folders []*model.ImportedFolder
+	var notes []*model.ImportedNote
+	err := readJsonArchive("notes.json", bytes.NewReader(buf.Bytes()), int64(buf.Len()), func(total int, importedFolders []*model.ImportedFolder) {
+		if total != 3 {
+			t.Errorf("total = %d, want 3", total)
+		}
+		folders = importedFolders
+	}, func(entry importEntry) {
+		notes = append(notes, entry.Note)
+	})
+	if err != nil {
+		t.Fatalf("readJsonArchive() error = %v", err)
+	}
+
+	wantFolders := []*model.ImportedFolder{{Title: "Работа / Проекты"}, {Title: "Пустая", IsArchived: true}}
+	if !reflect.DeepEqual(folders, wantFolders) {
+		t.Errorf("folders = %v, want %v", folders, wantFolders)
+	}
+
+	timestamp := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+	wantNotes := []*model.ImportedNote{
+		{Path: "Работа / Проекты/План: Q1", Folder: "Работа / Проекты", Title: "План: Q1", Content: "Текст", Tags: []string{"работа"}, IsFavorite: true, Timestamp: &timestamp},
+		{Path: "Покупки", Title: "Покупки", Content: "Хлеб\nМолоко", Tags: []string{}, IsPinned: true, Timestamp: &timestamp,
+			Items: []*model.ExportChecklistItem{{Text: "Хлеб", IsChecked: true}, {Text: "Молоко"}}},
+		{Path: "Работа / Проекты/План? Q1", Folder: "Работа / Проекты", Title: "План? Q1", Content: "Другой", Tags: []string{}, IsArchived: true, Timestamp: &timestamp},
+	}
+	if !reflect.DeepEqual(notes, wantNotes) {
+		t.Errorf("notes = %v, want %v", notes, wantNotes)
+	}
+}
+
+func TestConcreteExportService_ExportMarkdown(t *testing.T) {
+	exportService, repo := initExportServiceTest(t)
+	mockExportedNotebook(repo)
+
+	var buf bytes.Buffer
+	if err := exportService.Export(1, model.ExportFormatMarkdown, &buf); err != nil {
+		t.Fatalf("ExportService.Export() error = %v", err)
+	}
+
+	var notes []*model.ImportedNote
+	err := readMarkdownArchive("notes.zip", bytes.NewReader(buf.Bytes()), int64(buf.Len()), func(total int, folders []*model.ImportedFolder) {}, func(entry importEntry) {
+		if entry.Err != nil {
+			t.Errorf("entry %s error = %v", entry.Path, entry.Err)
+			return
+		}
+		notes = append(notes, entry.Note)
+	})
+	if err != nil {
+		t.Fatalf("readMarkdownArchive() error = %v", err)
+	}
+
+	timestamp := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+	wantNotes := []*model.ImportedNote{
+		{Path: "Работа/Проекты/План_ Q1.md", Folder: "Работа / Проекты", Title: "План: Q1", Content: "Текст\n", Tags: []string{"работа"}, IsFavorite: true},
+		{Path: "Покупки.md", Title: "Покупки", Content: "- [x] Хлеб\n- [ ] Молоко\n", Tags: []string{}},
+		{Path: "Работа/Проекты/План_ Q1 (2).md", Folder: "Работа / Проекты", Title: "План? Q1", Content: "Другой\n", Tags: []string{}},
+	}
+	if len(notes) != len(wantNotes) {
+		t.Fatalf("notes = %d, want %d", len(notes), len(wantNotes))
+	}
+
+	for i, want := range wantNotes {
+		got := notes[i]
+		if got.Timestamp == nil || !got.Timestamp.Equal(timestamp) {
+			t.Errorf("notes[%d].Timestamp = %v, want %v", i, got.Timestamp, timestamp)
+		}
+
+		got.Timestamp = nil
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("notes[%d] = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestConcreteExportService_ExportFailed(t *testing.T) {
+	exportService, repo := initExportServiceTest(t)
+
+	repo.EXPECT().GetFoldersByUserId(1).Return([]*model.Folder{})
+	repo.EXPECT().GetNotesByUserIdInBatches(1, exportBatchSize, gomock.Any()).Return(repository.DataBaseError)
+
+	var buf bytes.Buffer
+	if err := exportService.Export(1, model.ExportFormatJson, &buf); err != repository.DataBaseError {
+		t.Errorf("ExportService.Export() error = %v, want %v", err, repository.DataBaseError)
+	}
+}
@@ -12,18 +12,29 @@ type AbstractFolderService interface {
 	CreateFolder(userId int, title string) (int, *model.ApplicationError)
 	UpdateFolder(userId int, folderId int, title string) *model.ApplicationError
 	DeleteFolder(userId int, folderId int) *model.ApplicationError
+	ReorderFolder(userId int, folderId int, afterId *int) *model.ApplicationError
+	ArchiveFolder(userId int, folderId int) *model.ApplicationError
+	UnarchiveFolder(userId int, folderId int) *model.ApplicationError
 }
 
 type FolderService struct {
-	repo repository.AbstractRepository
+	repo   repository.AbstractRepository
+	events AbstractEventBus
+	origin model.AuditOrigin
 }
 
-func NewConcreteFolderService(repository repository.AbstractRepository) AbstractFolderService {
+func NewConcreteFolderService(repository repository.AbstractRepository, events AbstractEventBus) AbstractFolderService {
 	return &FolderService{
-		repo: repository,
+		repo:   repository,
+		events: events,
 	}
 }
 
+func (f FolderService) withOrigin(origin model.AuditOrigin) any {
+	f.origin = origin
+	return &f
+}
+
 func (f FolderService) CreateFolder(userId int, title string) (int, *model.ApplicationError) {
 	folder, err := model.NewFolder(title, userId)
 
@@ -31,17 +42,34 @@ func (f FolderService) CreateFolder(userId int, title string) (int, *model.Appli
 		return constants.FakeId, err
 	}
 
-	if !f.isTitleIsFree(folder.Title, userId, 0) {
-		return constants.FakeId, model.NewApplicationError(model.ErrorTypeValidation, constants.FolderTitleIsNotFree, nil)
+	folders := f.repo.GetFoldersByUserId(userId)
+
+	if !isFolderTitleFree(folders, folder.Title, 0) {
+		return constants.FakeId, model.NewLocalizedError(model.ErrorTypeConflict, model.CodeFolderTitleTaken, nil, nil)
 	}
 
-	id, err := f.repo.SaveEntity(folder)
+	position, err := getPositionAtEnd(getFolderSiblings(folders, 0))
+	if err != nil {
+		return constants.FakeId, err
+	}
+
+	folder.Position = position
+
+	err = commitChanges(f.repo, f.events, f.origin, func(tx repository.AbstractRepository) ([]*model.Event, *model.ApplicationError) {
+		id, err := tx.SaveEntity(folder)
+		if err != nil {
+			return nil, err
+		}
+
+		folder.Id = id
+		return []*model.Event{model.NewFolderEvent(model.EventFolderCreated, folder)}, nil
+	})
 
 	if err != nil {
 		return constants.FakeId, err
 	}
 
-	return id, nil
+	return folder.Id, nil
 }
 
 func (f FolderService) UpdateFolder(userId int, folderId int, title string) *model.ApplicationError {
@@ -51,8 +79,8 @@ func (f FolderService) UpdateFolder(userId int, folderId int, title string) *mod
 		return err
 	}
 
-	if !f.isTitleIsFree(folder.Title, userId, folderId) {
-		return model.NewApplicationError(model.ErrorTypeValidation, constants.FolderTitleIsNotFree, nil)
+	if !isFolderTitleFree(f.repo.GetFoldersByUserId(userId), folder.Title, folderId) {
+		return model.NewLocalizedError(model.ErrorTypeConflict, model.CodeFolderTitleTaken, nil, nil)
 	}
 
 	folderDb, err := f.repo.GetFolderById(folderId, userId)
@@ -63,8 +91,13 @@ func (f FolderService) UpdateFolder(userId int, folderId int, title string) *mod
 
 	folderDb.Title = title
 
-	_, errSave := f.repo.SaveEntity(folderDb)
-	return errSave
+	return commitChanges(f.repo, f.events, f.origin, func(tx repository.AbstractRepository) ([]*model.Event, *model.ApplicationError) {
+		if _, err := tx.SaveEntity(folderDb); err != nil {
+			return nil, err
+		}
+
+		return []*model.Event{model.NewFolderEvent(model.EventFolderRenamed, folderDb)}, nil
+	})
 }
 
 func (f FolderService) DeleteFolder(userId int, folderId int) *model.ApplicationError {
@@ -77,11 +110,82 @@ func (f FolderService) DeleteFolder(userId int, folderId int) *model.Application
 		return err
 	}
 
-	return f.repo.DeleteEntity(folderDb)
+	return commitChanges(f.repo, f.events, f.origin, func(tx repository.AbstractRepository) ([]*model.Event, *model.ApplicationError) {
+		if err := tx.DeleteEntity(folderDb); err != nil {
+			return nil, err
+		}
+
+		return []*model.Event{model.NewFolderEvent(model.EventFolderDeleted, folderDb)}, nil
+	})
 }
 
-func (f FolderService) isTitleIsFree(title string, userId int, folderId int) bool {
-	folders := f.repo.GetFoldersByUserId(userId)
+func (f FolderService) ReorderFolder(userId int, folderId int, afterId *int) *model.ApplicationError {
+	folderDb, err := f.repo.GetFolderById(folderId, userId)
+
+	if err != nil {
+		return err
+	}
+
+	if afterId != nil && *afterId == folderId {
+		return nil
+	}
+
+	position, err := getPositionAfter(getFolderSiblings(f.repo.GetFoldersByUserId(userId), folderId), afterId)
+	if err != nil {
+		return err
+	}
+
+	folderDb.Position = position
+
+	return commitChanges(f.repo, f.events, f.origin, func(tx repository.AbstractRepository) ([]*model.Event, *model.ApplicationError) {
+		if _, err := tx.SaveEntity(folderDb); err != nil {
+			return nil, err
+		}
+
+		return []*model.Event{model.NewFolderEvent(model.EventFolderUpdated, folderDb)}, nil
+	})
+}
+
+// ArchiveFolder скрывает папку из блокнота вместе со всеми её заметками
+func (f FolderService) ArchiveFolder(userId int, folderId int) *model.ApplicationError {
+	return f.setArchived(userId, folderId, true)
+}
+
+// UnarchiveFolder возвращает папку и все её заметки из архива
+func (f FolderService) UnarchiveFolder(userId int, folderId int) *model.ApplicationError {
+	return f.setArchived(userId, folderId, false)
+}
+
+func (f FolderService) setArchived(userId int, folderId int, isArchived bool) *model.ApplicationError {
+	folderDb, err := f.repo.GetFolderById(folderId, userId)
+
+	if err != nil {
+		return err
+	}
+
+	// Признак архива меняется у всех заметок папки, поэтому событие получает и каждая из них
+	notes := make([]*model.Note, 0)
+	for _, note := range f.repo.GetNotesByUserId(userId) {
+		if note.FolderId != nil && *note.FolderId == folderDb.Id {
+			notes = append(notes, note)
+		}
+	}
+
+	return commitChanges(f.repo, f.events, f.origin, func(tx repository.AbstractRepository) ([]*model.Event, *model.ApplicationError) {
+		if err := tx.SetFolderArchived(folderDb, isArchived); err != nil {
+			return nil, err
+		}
+
+		changes := []*model.Event{model.NewFolderEvent(model.EventFolderUpdated, folderDb)}
+		for _, note := range notes {
+			note.IsArchived = isArchived
+			changes = append(changes, model.NewNoteEvent(model.EventNoteUpdated, note))
+		}
+		return changes, nil
+	})
+}
+
+func isFolderTitleFree(folders []*model.Folder, title string, folderId int) bool {
 	for _, folder := range folders {
 		if folder.Title == title && folder.Id != folderId {
 			return false
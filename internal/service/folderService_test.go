@@ -25,8 +25,11 @@ func initFolderServiceTest(t *testing.T) (AbstractFolderService, *mocks.MockAbst
 	defer ctrl.Finish()
 
 	mockRepository := mocks.NewMockAbstractRepository(ctrl)
+	expectChangeLog(mockRepository)
+	mockEventBus := mocks.NewMockAbstractEventBus(ctrl)
+	mockEventBus.EXPECT().Publish(gomock.Any()).AnyTimes()
 
-	return NewConcreteFolderService(mockRepository), mockRepository
+	return NewConcreteFolderService(mockRepository, mockEventBus), mockRepository
 }
 
 func TestConcreteFolderService_CreateFolder(t *testing.T) {
@@ -71,7 +74,7 @@ func TestConcreteFolderService_CreateFolder(t *testing.T) {
 			},
 			want: folderTestExpect{
 				id:    -1,
-				error: model.NewApplicationError(model.ErrorTypeValidation, constants.FolderTitleIsNotFree, nil),
+				error: model.NewLocalizedError(model.ErrorTypeConflict, model.CodeFolderTitleTaken, nil, nil),
 			},
 			wantErr: true,
 		},
@@ -80,17 +83,19 @@ func TestConcreteFolderService_CreateFolder(t *testing.T) {
 			mock: func() {
 				repo.EXPECT().GetFoldersByUserId(1).Return([]*model.Folder{
 					{
-						Id:     1,
-						Title:  "title",
-						UserId: 1,
-						Notes:  nil,
+						Id:       1,
+						Title:    "title",
+						UserId:   1,
+						Notes:    nil,
+						Position: "a0",
 					},
 				})
 				repo.EXPECT().SaveEntity(&model.Folder{
-					Id:     0,
-					Title:  "original title",
-					UserId: 1,
-					Notes:  nil,
+					Id:       0,
+					Title:    "original title",
+					UserId:   1,
+					Notes:    nil,
+					Position: "a1",
 				}).Return(constants.FakeId, model.NewApplicationError(model.ErrorTypeDatabase, " внутрення ошибка БД", nil))
 			},
 			args: folderTestArgs{
@@ -108,17 +113,19 @@ func TestConcreteFolderService_CreateFolder(t *testing.T) {
 			mock: func() {
 				repo.EXPECT().GetFoldersByUserId(1).Return([]*model.Folder{
 					{
-						Id:     1,
-						Title:  "title",
-						UserId: 1,
-						Notes:  nil,
+						Id:       1,
+						Title:    "title",
+						UserId:   1,
+						Notes:    nil,
+						Position: "a0",
 					},
 				})
 				repo.EXPECT().SaveEntity(&model.Folder{
-					Id:     0,
-					Title:  "original title",
-					UserId: 1,
-					Notes:  nil,
+					Id:       0,
+					Title:    "original title",
+					UserId:   1,
+					Notes:    nil,
+					Position: "a1",
 				}).Return(2, nil)
 			},
 			args: folderTestArgs{
@@ -199,7 +206,7 @@ func TestConcreteFolderService_UpdateFolder(t *testing.T) {
 				title:  "duplicate title",
 			},
 			want: folderTestExpect{
-				error: model.NewApplicationError(model.ErrorTypeValidation, constants.FolderTitleIsNotFree, nil),
+				error: model.NewLocalizedError(model.ErrorTypeConflict, model.CodeFolderTitleTaken, nil, nil),
 			},
 			wantErr: true,
 		},
@@ -394,3 +401,101 @@ func TestConcreteFolderService_DeleteFolder(t *testing.T) {
 		})
 	}
 }
+
+func TestConcreteFolderService_ReorderFolder(t *testing.T) {
+	folderService, repo := initFolderServiceTest(t)
+	afterId := 1
+
+	tests := []struct {
+		name    string
+		mock    func()
+		args    folderTestArgs
+		afterId *int
+		want    folderTestExpect
+		wantErr bool
+	}{
+		{
+			name: "no folder with such id",
+			mock: func() {
+				repo.EXPECT().GetFolderById(2, 1).Return(nil, model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil))
+			},
+			args: folderTestArgs{
+				userId:   1,
+				folderId: 2,
+			},
+			afterId: nil,
+			want: folderTestExpect{
+				error: model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil),
+			},
+			wantErr: true,
+		},
+		{
+			name: "folder moved after another folder",
+			mock: func() {
+				repo.EXPECT().GetFolderById(3, 1).Return(&model.Folder{Id: 3, Title: "third", UserId: 1, Position: "a2"}, nil)
+				repo.EXPECT().GetFoldersByUserId(1).Return([]*model.Folder{
+					{Id: 2, Title: "second", UserId: 1, Position: "a1"},
+					{Id: 3, Title: "third", UserId: 1, Position: "a2"},
+					{Id: 1, Title: "first", UserId: 1, Position: "a0"},
+				})
+				repo.EXPECT().SaveEntity(&model.Folder{Id: 3, Title: "third", UserId: 1, Position: "a0V"}).Return(3, nil)
+			},
+			args: folderTestArgs{
+				userId:   1,
+				folderId: 3,
+			},
+			afterId: &afterId,
+			want:    folderTestExpect{},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			folderService := folderService
+
+			tt.mock()
+
+			err := folderService.ReorderFolder(tt.args.userId, tt.args.folderId, tt.afterId)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FolderService.ReorderFolder() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil && (err.Type != tt.want.error.Type || err.Message != tt.want.error.Message) {
+				t.Errorf("FolderService.ReorderFolder() unexpected error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestConcreteFolderService_ArchiveFolder(t *testing.T) {
+	folderService, repo := initFolderServiceTest(t)
+
+	repo.EXPECT().GetFolderById(2, 1).Return(nil, model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil))
+	err := folderService.ArchiveFolder(1, 2)
+	if err == nil || err.Type != model.ErrorTypeNotFound {
+		t.Errorf("FolderService.ArchiveFolder() error = %v, want not found", err)
+	}
+
+	folder := &model.Folder{Id: 1, Title: "title", UserId: 1}
+	folderId := 1
+	note := &model.Note{Id: 5, Title: "note", UserId: 1, FolderId: &folderId}
+	repo.EXPECT().GetFolderById(1, 1).Return(folder, nil)
+	repo.EXPECT().GetNotesByUserId(1).Return([]*model.Note{note, {Id: 6, Title: "root", UserId: 1}})
+	repo.EXPECT().SetFolderArchived(folder, true).Return(nil)
+	if err := folderService.ArchiveFolder(1, 1); err != nil {
+		t.Errorf("FolderService.ArchiveFolder() unexpected error = %v", err)
+	}
+
+	if !note.IsArchived {
+		t.Errorf("FolderService.ArchiveFolder() note event is not archived")
+	}
+
+	repo.EXPECT().GetFolderById(1, 1).Return(folder, nil)
+	repo.EXPECT().GetNotesByUserId(1).Return([]*model.Note{})
+	repo.EXPECT().SetFolderArchived(folder, false).Return(nil)
+	if err := folderService.UnarchiveFolder(1, 1); err != nil {
+		t.Errorf("FolderService.UnarchiveFolder() unexpected error = %v", err)
+	}
+}
@@ -21,7 +21,7 @@ func NewConcreteHashService() AbstractHashService {
 func (h *ConcreteHashService) GetHash(stringToHash string) (string, *model.ApplicationError) {
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(stringToHash), bcrypt.DefaultCost)
 	if err != nil {
-		return "", model.NewApplicationError(model.ErrorTypeInternal, "Ошибка при создании хэша", err)
+		return "", model.NewLocalizedError(model.ErrorTypeInternal, model.CodeHashFailed, nil, err)
 	}
 	return string(hashedPassword), nil
 }
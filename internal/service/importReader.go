@@ -0,0 +1,249 @@
+package service
+
+import (
+	"Notes/internal/model"
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"io"
+	"path"
+	"strings"
+)
+
+const zipSignature = "PK\x03\x04"
+
+// maxImportedFileSize ограничивает распакованный размер одного файла архива
+const maxImportedFileSize = 10 * 1024 * 1024
+
+// importEntry - один файл импортируемого архива: прочитанная заметка или ошибка его разбора
+type importEntry struct {
+	Path string
+	Note *model.ImportedNote
+	Err  *model.ApplicationError
+}
+
+// importReader разбирает загруженный файл с именем name и передаёт заметки по одной в handle.
+// Файл читается по частям через content, целиком в память он не загружается.
+// start вызывается до первой заметки с их числом и папками, перечисленными в файле отдельно от заметок.
+// Порядок заметок должен быть одинаковым при каждом чтении одного и того же файла:
+// прерванный импорт продолжается по номеру заметки.
+type importReader func(name string, content io.ReaderAt, size int64, start func(total int, folders []*model.ImportedFolder), handle func(entry importEntry)) *model.ApplicationError
+
+// readMarkdownArchive читает ZIP с Markdown-файлами (например, хранилище Obsidian).
+// Служебные каталоги и файлы, начинающиеся с точки, а также файлы других типов пропускаются.
+func readMarkdownArchive(name string, content io.ReaderAt, size int64, start func(total int, folders []*model.ImportedFolder), handle func(entry importEntry)) *model.ApplicationError {
+	archive, err := zip.NewReader(content, size)
+	if err != nil {
+		return model.NewLocalizedError(model.ErrorTypeValidation, model.CodeImportArchiveInvalid, nil, err)
+	}
+
+	files := make([]*zip.File, 0, len(archive.File))
+	for _, file := range archive.File {
+		if isMarkdownArchiveFile(file) {
+			files = append(files, file)
+		}
+	}
+
+	start(len(files), nil)
+
+	for _, file := range files {
+		filePath := archiveFilePath(file.Name)
+
+		content, readErr := readArchiveFile(file)
+		if readErr != nil {
+			handle(importEntry{Path: filePath, Err: readErr})
+			continue
+		}
+
+		note, parseErr := model.ParseMarkdownNote(filePath, content)
+		handle(importEntry{Path: filePath, Note: note, Err: parseErr})
+	}
+
+	return nil
+}
+
+// readJsonArchive читает архив, выгруженный в формате model.ExportArchive
+func readJsonArchive(name string, content io.ReaderAt, size int64, start func(total int, folders []*model.ImportedFolder), handle func(entry importEntry)) *model.ApplicationError {
+	var archive model.ExportArchive
+	if err := json.NewDecoder(io.NewSectionReader(content, 0, size)).Decode(&archive); err != nil {
+		return model.NewLocalizedError(model.ErrorTypeValidation, model.CodeImportArchiveInvalid, nil, err)
+	}
+
+	if archive.Version != model.ExportArchiveVersion {
+		params := model.ErrorParams{"version": archive.Version}
+		return model.NewLocalizedError(model.ErrorTypeValidation, model.CodeImportVersionUnsupported, params, nil)
+	}
+
+	folders := make([]*model.ImportedFolder, 0, len(archive.Folders))
+	for _, folder := range archive.Folders {
+		folders = append(folders, &model.ImportedFolder{Title: folder.Title, IsArchived: folder.IsArchived})
+	}
+
+	start(len(archive.Notes), folders)
+
+	for _, exportNote := range archive.Notes {
+		note := exportNote.ToImportedNote()
+		handle(importEntry{Path: note.Path, Note: note})
+	}
+
+	return nil
+}
+
+// readEnexFile читает экспорт Evernote: один файл ENEX или ZIP с несколькими ENEX.
+// Evernote выгружает каждый блокнот в отдельный файл, поэтому имя файла становится названием папки.
+func readEnexFile(name string, content io.ReaderAt, size int64, start func(total int, folders []*model.ImportedFolder), handle func(entry importEntry)) *model.ApplicationError {
+	notebooks := []*enexNotebook{{
+		title: enexNotebookTitle(name),
+		open: func() (io.ReadCloser, error) {
+			return io.NopCloser(io.NewSectionReader(content, 0, size)), nil
+		},
+	}}
+
+	if isZipContent(content) {
+		archive, err := zip.NewReader(content, size)
+		if err != nil {
+			return model.NewLocalizedError(model.ErrorTypeValidation, model.CodeImportArchiveInvalid, nil, err)
+		}
+
+		notebooks = notebooks[:0]
+		for _, file := range archive.File {
+			if isArchiveFile(file, model.EnexExtension) {
+				notebooks = append(notebooks, &enexNotebook{
+					title: enexNotebookTitle(file.Name),
+					open:  file.Open,
+				})
+			}
+		}
+	}
+
+	total := 0
+	folders := make([]*model.ImportedFolder, 0, len(notebooks))
+	for _, notebook := range notebooks {
+		count, err := notebook.count()
+		if err != nil {
+			return model.NewLocalizedError(model.ErrorTypeValidation, model.CodeImportArchiveInvalid, nil, err)
+		}
+
+		total += count
+		if notebook.title != "" {
+			folders = append(folders, &model.ImportedFolder{Title: notebook.title})
+		}
+	}
+
+	start(total, folders)
+
+	for _, notebook := range notebooks {
+		if err := notebook.read(handle); err != nil {
+			return model.NewLocalizedError(model.ErrorTypeValidation, model.CodeImportArchiveInvalid, nil, err)
+		}
+	}
+
+	return nil
+}
+
+// enexNotebook - файл ENEX с заметками одного блокнота
+type enexNotebook struct {
+	title string
+	open  func() (io.ReadCloser, error)
+}
+
+// enexNotebookTitle - название блокнота по имени файла без каталога и расширения
+func enexNotebookTitle(fileName string) string {
+	if fileName == "" {
+		return ""
+	}
+
+	base := path.Base(archiveFilePath(fileName))
+	return strings.TrimSuffix(base, path.Ext(base))
+}
+
+func (n *enexNotebook) count() (int, error) {
+	reader, err := n.open()
+	if err != nil {
+		return 0, err
+	}
+	defer reader.Close()
+
+	return model.CountEnexNotes(reader)
+}
+
+func (n *enexNotebook) read(handle func(entry importEntry)) error {
+	reader, err := n.open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	enex := model.NewEnexReader(reader)
+	for index := 1; ; index++ {
+		enexNote, errNext := enex.Next()
+		if errors.Is(errNext, io.EOF) {
+			return nil
+		}
+		if errNext != nil {
+			return errNext
+		}
+
+		note, errConvert := enexNote.ToImportedNote(n.title, index)
+		if errConvert != nil {
+			handle(importEntry{Path: enexNote.Path(n.title, index), Err: errConvert})
+			continue
+		}
+		handle(importEntry{Path: note.Path, Note: note})
+	}
+}
+
+// isZipContent проверяет сигнатуру ZIP в начале файла
+func isZipContent(content io.ReaderAt) bool {
+	signature := make([]byte, len(zipSignature))
+	if _, err := content.ReadAt(signature, 0); err != nil {
+		return false
+	}
+
+	return string(signature) == zipSignature
+}
+
+func isMarkdownArchiveFile(file *zip.File) bool {
+	return isArchiveFile(file, model.MarkdownExtension)
+}
+
+// isArchiveFile отбирает файлы с расширением extension, пропуская служебные каталоги и файлы, начинающиеся с точки
+func isArchiveFile(file *zip.File, extension string) bool {
+	if file.FileInfo().IsDir() {
+		return false
+	}
+
+	filePath := archiveFilePath(file.Name)
+	if !strings.EqualFold(path.Ext(filePath), extension) {
+		return false
+	}
+
+	for _, segment := range strings.Split(filePath, "/") {
+		if strings.HasPrefix(segment, ".") || segment == "__MACOSX" {
+			return false
+		}
+	}
+
+	return true
+}
+
+func archiveFilePath(name string) string {
+	return strings.TrimPrefix(path.Clean(strings.ReplaceAll(name, "\\", "/")), "/")
+}
+
+func readArchiveFile(file *zip.File) ([]byte, *model.ApplicationError) {
+	params := model.ErrorParams{"path": archiveFilePath(file.Name)}
+
+	reader, err := file.Open()
+	if err != nil {
+		return nil, model.NewLocalizedError(model.ErrorTypeValidation, model.CodeImportFileReadFailed, params, err)
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(io.LimitReader(reader, maxImportedFileSize+1))
+	if err != nil || len(content) > maxImportedFileSize {
+		return nil, model.NewLocalizedError(model.ErrorTypeValidation, model.CodeImportFileReadFailed, params, err)
+	}
+
+	return content, nil
+}
@@ -0,0 +1,564 @@
+package service
+
+//go:generate mockgen -source=importService.go -destination=mock/importService.go -package=mock
+
+import (
+	"Notes/config"
+	"Notes/internal/constants"
+	"Notes/internal/model"
+	"Notes/internal/repository"
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+const importQueueSize = 100
+const importRescanInterval = time.Minute
+
+// importStaleAfter - задача в статусе running, не обновлявшаяся дольше этого времени,
+// считается прерванной и продолжается с последнего обработанного файла
+const importStaleAfter = 10 * time.Minute
+
+type AbstractImportService interface {
+	Run(ctx context.Context)
+	StartImport(userId int, source model.ImportSource, strategy string, fileName string, size int64, content io.Reader) (int, *model.ApplicationError)
+	GetImportJob(userId int, jobId int) (*model.ImportJobApi, *model.ApplicationError)
+	ProcessJob(jobId int) *model.ApplicationError
+}
+
+type ImportService struct {
+	repo              repository.AbstractRepository
+	storage           repository.AbstractBlobStorage
+	events            AbstractEventBus
+	noteService       AbstractNoteService
+	folderService     AbstractFolderService
+	attachmentService AbstractAttachmentService
+	readers           map[model.ImportSource]importReader
+	maxFileSize       int64
+	workers           int
+	queue             chan int
+	now               func() time.Time
+}
+
+func NewConcreteImportService(repository repository.AbstractRepository, storage repository.AbstractBlobStorage, events AbstractEventBus, noteService AbstractNoteService, folderService AbstractFolderService, attachmentService AbstractAttachmentService, cfg *config.Config) AbstractImportService {
+	return &ImportService{
+		repo:              repository,
+		storage:           storage,
+		events:            events,
+		noteService:       noteService,
+		folderService:     folderService,
+		attachmentService: attachmentService,
+		readers: map[model.ImportSource]importReader{
+			model.ImportSourceMarkdown: readMarkdownArchive,
+			model.ImportSourceJson:     readJsonArchive,
+			model.ImportSourceEnex:     readEnexFile,
+		},
+		maxFileSize: int64(cfg.Import.MaxFileSizeMb) * 1024 * 1024,
+		workers:     max(1, cfg.Import.Workers),
+		queue:       make(chan int, importQueueSize),
+		now:         time.Now,
+	}
+}
+
+// StartImport сохраняет загруженный файл и ставит задачу импорта в очередь
+func (i *ImportService) StartImport(userId int, source model.ImportSource, strategy string, fileName string, size int64, content io.Reader) (int, *model.ApplicationError) {
+	if size > i.maxFileSize {
+		params := model.ErrorParams{"maxMb": i.maxFileSize / 1024 / 1024}
+		return constants.FakeId, model.NewLocalizedError(model.ErrorTypeValidation, model.CodeFileTooLarge, params, nil)
+	}
+
+	storageKey := fmt.Sprintf("imports/%d/%s", userId, uuid.New().String())
+	job, err := model.NewImportJob(userId, source, strategy, fileName, storageKey)
+	if err != nil {
+		return constants.FakeId, err
+	}
+
+	if err = i.storage.Put(storageKey, content); err != nil {
+		return constants.FakeId, err
+	}
+
+	id, err := i.repo.SaveEntity(job)
+	if err != nil {
+		_ = i.storage.Delete(storageKey)
+		return constants.FakeId, err
+	}
+
+	i.enqueue(id)
+	return id, nil
+}
+
+func (i *ImportService) GetImportJob(userId int, jobId int) (*model.ImportJobApi, *model.ApplicationError) {
+	job, err := i.repo.GetImportJobById(jobId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.ToImportJobApi(job, i.repo.GetImportJobFiles(job.Id)), nil
+}
+
+// ProcessJob выполняет задачу, если её не выполняет другая реплика
+func (i *ImportService) ProcessJob(jobId int) *model.ApplicationError {
+	job, err := i.repo.ClaimImportJob(jobId, i.now().Add(-importStaleAfter))
+	if err != nil {
+		if err.Type == model.ErrorTypeNotFound {
+			return nil
+		}
+		return err
+	}
+
+	readErr := i.readJob(job)
+
+	job.Finish(i.now(), readErr)
+	if _, err = i.repo.SaveEntity(job); err != nil {
+		return err
+	}
+
+	_ = i.storage.Delete(job.StorageKey)
+	return nil
+}
+
+// Run запускает воркеры и периодически подбирает ожидающие и прерванные задачи. Блокируется до отмены ctx.
+func (i *ImportService) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for w := 0; w < i.workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			i.work(ctx)
+		}()
+	}
+
+	ticker := time.NewTicker(importRescanInterval)
+	defer ticker.Stop()
+
+	i.enqueuePending()
+	for {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case <-ticker.C:
+			i.enqueuePending()
+		}
+	}
+}
+
+func (i *ImportService) readJob(job *model.ImportJob) *model.ApplicationError {
+	reader, exists := i.readers[job.Source]
+	if !exists {
+		return model.NewLocalizedError(model.ErrorTypeInternal, model.CodeInternalError, nil, nil)
+	}
+
+	content, err := i.storage.Get(job.StorageKey)
+	if err != nil {
+		return err
+	}
+
+	file, size, readErr := openImportContent(content)
+	if readErr != nil {
+		return model.NewLocalizedError(model.ErrorTypeInternal, model.CodeImportArchiveInvalid, nil, readErr)
+	}
+	defer file.Close()
+
+	session := newImportSession(i, job)
+	index := 0
+
+	errRead := reader(job.FileName, file, size, func(total int, folders []*model.ImportedFolder) {
+		job.Total = total
+		session.createFolders(folders)
+	}, func(entry importEntry) {
+		index++
+		// Файлы, обработанные до прерывания задачи, уже учтены
+		if index <= job.Processed {
+			return
+		}
+
+		file := session.importEntry(entry)
+		if _, err := i.repo.SaveEntity(file); err != nil {
+			log.Printf("Ошибка при сохранении результата импорта файла %s: %v", entry.Path, err)
+		}
+
+		job.AddResult(file)
+		if _, err := i.repo.SaveEntity(job); err != nil {
+			log.Printf("Ошибка при сохранении прогресса импорта %d: %v", job.Id, err)
+		}
+	})
+	if errRead != nil {
+		return errRead
+	}
+
+	return session.archiveFolders()
+}
+
+// importContent - загруженный файл с произвольным доступом: ZIP читается с конца, а ENEX - дважды
+type importContent interface {
+	io.ReaderAt
+	io.Closer
+}
+
+// openImportContent возвращает файл из хранилища с произвольным доступом. Если хранилище отдаёт
+// только поток, он копируется во временный файл, который удаляется при закрытии.
+func openImportContent(content io.ReadCloser) (importContent, int64, error) {
+	if file, ok := content.(*os.File); ok {
+		info, err := file.Stat()
+		if err != nil {
+			file.Close()
+			return nil, 0, err
+		}
+		return file, info.Size(), nil
+	}
+	defer content.Close()
+
+	tmp, err := os.CreateTemp("", "import-*")
+	if err != nil {
+		return nil, 0, err
+	}
+
+	size, err := io.Copy(tmp, content)
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return nil, 0, err
+	}
+
+	return &tempImportFile{File: tmp}, size, nil
+}
+
+// tempImportFile удаляет временную копию загруженного файла при закрытии
+type tempImportFile struct {
+	*os.File
+}
+
+func (t *tempImportFile) Close() error {
+	err := t.File.Close()
+	os.Remove(t.File.Name())
+	return err
+}
+
+func (i *ImportService) enqueue(jobId int) {
+	select {
+	case i.queue <- jobId:
+	default:
+		// Очередь заполнена: задача останется pending и будет подобрана при следующем проходе
+	}
+}
+
+func (i *ImportService) enqueuePending() {
+	for _, job := range i.repo.GetImportJobsToProcess(i.now().Add(-importStaleAfter)) {
+		i.enqueue(job.Id)
+	}
+}
+
+func (i *ImportService) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case jobId := <-i.queue:
+			if err := i.ProcessJob(jobId); err != nil {
+				log.Printf("Ошибка при импорте %d: %v", jobId, err)
+			}
+		}
+	}
+}
+
+// importSession хранит названия заметок и папок пользователя на время одной задачи,
+// чтобы не перечитывать их для каждого файла
+type importSession struct {
+	service        *ImportService
+	job            *model.ImportJob
+	notesByTitle   map[string]*model.Note
+	foldersByTitle map[string]int
+	// archivedFolders архивируются после импорта всех заметок: в архивную папку заметку не перенести
+	archivedFolders []int
+}
+
+func newImportSession(service *ImportService, job *model.ImportJob) *importSession {
+	session := &importSession{
+		service:        service,
+		job:            job,
+		notesByTitle:   make(map[string]*model.Note),
+		foldersByTitle: make(map[string]int),
+	}
+
+	for _, note := range service.repo.GetNotesByUserId(job.UserId) {
+		session.notesByTitle[note.Title] = note
+	}
+
+	for _, folder := range service.repo.GetFoldersByUserId(job.UserId) {
+		session.foldersByTitle[folder.Title] = folder.Id
+	}
+
+	return session
+}
+
+func (s *importSession) importEntry(entry importEntry) *model.ImportJobFile {
+	if entry.Err != nil {
+		return model.NewFailedImportFile(s.job.Id, entry.Path, model.ImportFileStatusFailed, entry.Err)
+	}
+
+	noteId, status, err := s.importNote(entry.Note)
+	if err != nil {
+		if status == "" {
+			status = model.ImportFileStatusFailed
+		}
+		return model.NewFailedImportFile(s.job.Id, entry.Path, status, err)
+	}
+
+	file := model.NewImportedFile(s.job.Id, entry.Path, status, noteId)
+
+	// Заметка уже создана, поэтому ошибка вложения не отменяет импорт, а попадает в отчёт по файлу
+	if err = s.importAttachments(noteId, entry.Note.Attachments); err != nil {
+		file.Code = err.Code
+		file.Message = err.Message
+	}
+
+	return file
+}
+
+func (s *importSession) importNote(note *model.ImportedNote) (int, model.ImportFileStatus, *model.ApplicationError) {
+	userId := s.job.UserId
+	noteService := s.service.noteService
+
+	folderId, err := s.getFolderId(note.Folder)
+	if err != nil {
+		return constants.FakeId, "", err
+	}
+
+	title := note.Title
+	status := model.ImportFileStatusImported
+	var noteId int
+
+	existing := s.notesByTitle[title]
+	if existing != nil {
+		switch s.job.Strategy {
+		case model.ImportConflictSkip:
+			err = model.NewLocalizedError(model.ErrorTypeConflict, model.CodeNoteTitleTaken, nil, nil)
+			return constants.FakeId, model.ImportFileStatusSkipped, err
+		case model.ImportConflictRename:
+			title = model.RenameImportedTitle(title, func(candidate string) bool {
+				return s.notesByTitle[candidate] == nil
+			})
+			status = model.ImportFileStatusRenamed
+		case model.ImportConflictOverwrite:
+			status = model.ImportFileStatusOverwritten
+		}
+	}
+
+	if status == model.ImportFileStatusOverwritten {
+		noteId = existing.Id
+		if err = noteService.UpdateNote(userId, noteId, title, note.Content, &note.Tags, false, ""); err != nil {
+			return constants.FakeId, "", err
+		}
+
+		if err = s.resetOverwrittenNote(existing, note, folderId); err != nil {
+			return constants.FakeId, "", err
+		}
+	} else {
+		noteId, err = createNoteInFolder(noteService, userId, title, note.Content, note.Tags, folderId)
+		if err != nil {
+			return constants.FakeId, "", err
+		}
+	}
+
+	s.notesByTitle[title] = &model.Note{
+		Id:         noteId,
+		Title:      title,
+		FolderId:   folderId,
+		IsFavorite: note.IsFavorite,
+		IsPinned:   note.IsPinned,
+		IsArchived: note.IsArchived,
+	}
+
+	if note.Items != nil {
+		if err = s.restoreChecklist(noteId, note.Items); err != nil {
+			return constants.FakeId, "", err
+		}
+	}
+
+	if note.IsFavorite {
+		if err = noteService.AddToFavorites(userId, noteId, ""); err != nil {
+			return constants.FakeId, "", err
+		}
+	}
+
+	if note.IsPinned {
+		if err = noteService.PinNote(userId, noteId, ""); err != nil {
+			return constants.FakeId, "", err
+		}
+	}
+
+	if note.IsArchived {
+		if err = noteService.ArchiveNote(userId, noteId, ""); err != nil {
+			return constants.FakeId, "", err
+		}
+	}
+
+	if note.Timestamp != nil {
+		if err = s.restoreTimestamp(&model.Note{Id: noteId, UserId: userId, Title: title, FolderId: folderId}, *note.Timestamp); err != nil {
+			return constants.FakeId, "", err
+		}
+	}
+
+	return noteId, status, nil
+}
+
+// resetOverwrittenNote переносит перезаписанную заметку в папку из импорта (в том числе в корень)
+// и снимает отметки, которых нет в импортированном файле. Недостающие отметки ставит importNote.
+func (s *importSession) resetOverwrittenNote(existing *model.Note, note *model.ImportedNote, folderId *int) *model.ApplicationError {
+	userId := s.job.UserId
+	noteService := s.service.noteService
+
+	// Из архива заметка достаётся до переноса: в архивную папку перенести её нельзя
+	if existing.IsArchived && !note.IsArchived {
+		if err := noteService.UnarchiveNote(userId, existing.Id, ""); err != nil {
+			return err
+		}
+	}
+
+	if !isSameFolder(existing.FolderId, folderId) {
+		if err := noteService.MoveToFolder(userId, existing.Id, folderId, ""); err != nil {
+			return err
+		}
+	}
+
+	if existing.IsFavorite && !note.IsFavorite {
+		if err := noteService.DeleteFromFavorites(userId, existing.Id, ""); err != nil {
+			return err
+		}
+	}
+
+	if existing.IsPinned && !note.IsPinned {
+		if err := noteService.UnpinNote(userId, existing.Id, ""); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getFolderId находит папку по названию или создаёт её. Пустое название - корень блокнота.
+func (s *importSession) getFolderId(title string) (*int, *model.ApplicationError) {
+	if title == "" {
+		return nil, nil
+	}
+
+	if folderId, exists := s.foldersByTitle[title]; exists {
+		return &folderId, nil
+	}
+
+	folderId, err := s.service.folderService.CreateFolder(s.job.UserId, title)
+	if err != nil {
+		return nil, err
+	}
+
+	s.foldersByTitle[title] = folderId
+	return &folderId, nil
+}
+
+func (s *importSession) importAttachments(noteId int, attachments []*model.ImportedAttachment) *model.ApplicationError {
+	var firstErr *model.ApplicationError
+
+	for _, attachment := range attachments {
+		_, err := s.service.attachmentService.UploadAttachment(s.job.UserId, noteId, attachment.FileName, int64(len(attachment.Data)), bytes.NewReader(attachment.Data))
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+// createFolders создаёт перечисленные в файле папки, которых ещё нет у пользователя.
+// Ошибка не прерывает импорт: заметки этой папки получат ту же ошибку при разборе.
+func (s *importSession) createFolders(folders []*model.ImportedFolder) {
+	for _, folder := range folders {
+		if _, exists := s.foldersByTitle[folder.Title]; exists {
+			continue
+		}
+
+		folderId, err := s.getFolderId(folder.Title)
+		if err != nil {
+			log.Printf("Ошибка при создании папки %s при импорте %d: %v", folder.Title, s.job.Id, err)
+			continue
+		}
+
+		if folder.IsArchived {
+			s.archivedFolders = append(s.archivedFolders, *folderId)
+		}
+	}
+}
+
+// archiveFolders помечает созданные папки архивными. Заметки не затрагиваются:
+// их признак архива восстановлен при импорте каждой заметки.
+func (s *importSession) archiveFolders() *model.ApplicationError {
+	for _, folderId := range s.archivedFolders {
+		folder, err := s.service.repo.GetFolderById(folderId, s.job.UserId)
+		if err != nil {
+			return err
+		}
+
+		folder.IsArchived = true
+		err = commitChanges(s.service.repo, s.service.events, model.AuditOrigin{}, func(tx repository.AbstractRepository) ([]*model.Event, *model.ApplicationError) {
+			if _, err := tx.SaveEntity(folder); err != nil {
+				return nil, err
+			}
+
+			return []*model.Event{model.NewFolderEvent(model.EventFolderUpdated, folder)}, nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// restoreChecklist превращает созданную заметку в список, сохраняя отметки о выполнении пунктов
+func (s *importSession) restoreChecklist(noteId int, exportItems []*model.ExportChecklistItem) *model.ApplicationError {
+	note, err := s.service.repo.GetNoteById(noteId, s.job.UserId)
+	if err != nil {
+		return err
+	}
+
+	items := make([]*model.ChecklistItem, 0, len(exportItems))
+	for _, exportItem := range exportItems {
+		item, itemErr := model.NewChecklistItem(note.Id, exportItem.Text)
+		if itemErr != nil {
+			return itemErr
+		}
+
+		item.IsChecked = exportItem.IsChecked
+		items = append(items, item)
+	}
+
+	model.NormalizePositions(items)
+	note.Type = model.NoteTypeChecklist
+	note.Content = model.RenderChecklist(items)
+
+	return commitChanges(s.service.repo, s.service.events, model.AuditOrigin{}, func(tx repository.AbstractRepository) ([]*model.Event, *model.ApplicationError) {
+		if err := saveChecklistItems(tx, note, items, ""); err != nil {
+			return nil, err
+		}
+
+		return []*model.Event{model.NewNoteEvent(model.EventNoteUpdated, note)}, nil
+	})
+}
+
+// restoreTimestamp возвращает заметке время изменения из импортированного файла. Выполняется последним:
+// любое другое сохранение заметки обновляет это время.
+func (s *importSession) restoreTimestamp(note *model.Note, timestamp time.Time) *model.ApplicationError {
+	return commitChanges(s.service.repo, s.service.events, model.AuditOrigin{}, func(tx repository.AbstractRepository) ([]*model.Event, *model.ApplicationError) {
+		if err := tx.SetNoteTimestamp(note.Id, timestamp); err != nil {
+			return nil, err
+		}
+
+		return []*model.Event{model.NewNoteEvent(model.EventNoteUpdated, note)}, nil
+	})
+}
@@ -0,0 +1,448 @@
+package service
+
+import (
+	"Notes/config"
+	"Notes/internal/constants"
+	"Notes/internal/model"
+	"Notes/internal/repository"
+	mocks "Notes/internal/service/mock"
+	"archive/zip"
+	"bytes"
+	"github.com/golang/mock/gomock"
+	"io"
+	"os"
+	"testing"
+	"time"
+)
+
+func initImportServiceTest(t *testing.T) (AbstractImportService, *mocks.MockAbstractRepository, *mocks.MockAbstractBlobStorage, *mocks.MockAbstractNoteService, *mocks.MockAbstractFolderService, *mocks.MockAbstractAttachmentService) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockAbstractRepository(ctrl)
+	mockStorage := mocks.NewMockAbstractBlobStorage(ctrl)
+	mockNoteService := mocks.NewMockAbstractNoteService(ctrl)
+	mockFolderService := mocks.NewMockAbstractFolderService(ctrl)
+	mockAttachmentService := mocks.NewMockAbstractAttachmentService(ctrl)
+	expectChangeLog(mockRepository)
+	mockEventBus := mocks.NewMockAbstractEventBus(ctrl)
+	mockEventBus.EXPECT().Publish(gomock.Any()).AnyTimes()
+
+	cfg := &config.Config{Import: config.Import{MaxFileSizeMb: 1, Workers: 1}}
+
+	importService := NewConcreteImportService(mockRepository, mockStorage, mockEventBus, mockNoteService, mockFolderService, mockAttachmentService, cfg)
+	importService.(*ImportService).now = func() time.Time {
+		return time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	}
+
+	return importService, mockRepository, mockStorage, mockNoteService, mockFolderService, mockAttachmentService
+}
+
+func zipArchive(t *testing.T, files [][2]string) []byte {
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+
+	for _, file := range files {
+		w, err := writer.Create(file[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = w.Write([]byte(file[1])); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestConcreteImportService_StartImport(t *testing.T) {
+	importService, repo, storage, _, _, _ := initImportServiceTest(t)
+
+	tests := []struct {
+		name     string
+		mock     func()
+		strategy string
+		size     int64
+		want     int
+		wantErr  *model.ApplicationError
+	}{
+		{
+			name:    "file too large",
+			mock:    func() {},
+			size:    2 * 1024 * 1024,
+			want:    constants.FakeId,
+			wantErr: model.NewLocalizedError(model.ErrorTypeValidation, model.CodeFileTooLarge, model.ErrorParams{"maxMb": 1}, nil),
+		},
+		{
+			name:     "unknown strategy",
+			mock:     func() {},
+			strategy: "merge",
+			size:     10,
+			want:     constants.FakeId,
+			wantErr:  model.NewLocalizedError(model.ErrorTypeValidation, model.CodeImportStrategyUnknown, model.ErrorParams{"strategy": "merge"}, nil),
+		},
+		{
+			name: "blob removed when saving fails",
+			mock: func() {
+				storage.EXPECT().Put(gomock.Any(), gomock.Any()).Return(nil)
+				repo.EXPECT().SaveEntity(gomock.Any()).Return(constants.FakeId, repository.DataBaseError)
+				storage.EXPECT().Delete(gomock.Any()).Return(nil)
+			},
+			size:    10,
+			want:    constants.FakeId,
+			wantErr: repository.DataBaseError,
+		},
+		{
+			name: "job queued with default strategy",
+			mock: func() {
+				storage.EXPECT().Put(gomock.Any(), gomock.Any()).Return(nil)
+				repo.EXPECT().SaveEntity(gomock.Any()).DoAndReturn(func(entity model.BusinessEntity) (int, *model.ApplicationError) {
+					job := entity.(*model.ImportJob)
+					if job.UserId != 1 || job.Source != model.ImportSourceMarkdown || job.Strategy != model.ImportConflictSkip || job.Status != model.ImportJobStatusPending || job.FileName != "vault.zip" {
+						t.Errorf("job = %v, want pending markdown job with skip strategy", job)
+					}
+					return 3, nil
+				})
+			},
+			size: 10,
+			want: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := importService.StartImport(1, model.ImportSourceMarkdown, tt.strategy, "vault.zip", tt.size, bytes.NewReader(nil))
+			if (err != nil) != (tt.wantErr != nil) {
+				t.Errorf("ImportService.StartImport() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got != tt.want || (err != nil && (err.Type != tt.wantErr.Type || err.Message != tt.wantErr.Message)) {
+				t.Errorf("ImportService.StartImport() = %v, %v, want %v, %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConcreteImportService_ProcessJob(t *testing.T) {
+	importService, repo, storage, noteService, folderService, _ := initImportServiceTest(t)
+
+	archive := zipArchive(t, [][2]string{
+		{".obsidian/workspace.md", "служебный файл"},
+		{"image.png", "не заметка"},
+		{"Projects/Work/Plan.md", "---\ntitle: План\ntags: [работа, \"#план\"]\nfavorite: true\nupdated: 2025-01-02\n---\n\nТекст плана"},
+		{"Existing.md", "Новый текст"},
+		{"Broken.md", "---\ntags: [\n---\nТекст"},
+	})
+	updated := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	folderId := 4
+
+	type fileResult struct {
+		path   string
+		status model.ImportFileStatus
+		code   model.ErrorCode
+	}
+
+	tests := []struct {
+		name       string
+		strategy   model.ImportConflictStrategy
+		processed  int
+		mock       func()
+		wantFiles  []fileResult
+		wantStatus model.ImportJobStatus
+	}{
+		{
+			name:     "title conflict skipped",
+			strategy: model.ImportConflictSkip,
+			mock: func() {
+				folderService.EXPECT().CreateFolder(1, "Projects / Work").Return(folderId, nil)
+				noteService.EXPECT().CreateNote(1, "План", "Текст плана", &[]string{"работа", "план"}).Return(10, nil)
+				noteService.EXPECT().MoveToFolder(1, 10, &folderId, "").Return(nil)
+				noteService.EXPECT().AddToFavorites(1, 10, "").Return(nil)
+				repo.EXPECT().SetNoteTimestamp(10, updated).Return(nil)
+			},
+			wantFiles: []fileResult{
+				{"Projects/Work/Plan.md", model.ImportFileStatusImported, ""},
+				{"Existing.md", model.ImportFileStatusSkipped, model.CodeNoteTitleTaken},
+				{"Broken.md", model.ImportFileStatusFailed, model.CodeImportFrontMatterInvalid},
+			},
+			wantStatus: model.ImportJobStatusCompleted,
+		},
+		{
+			name:      "title conflict renamed after resume",
+			strategy:  model.ImportConflictRename,
+			processed: 1,
+			mock: func() {
+				noteService.EXPECT().CreateNote(1, "Existing (2)", "Новый текст", &[]string{}).Return(11, nil)
+			},
+			wantFiles: []fileResult{
+				{"Existing.md", model.ImportFileStatusRenamed, ""},
+				{"Broken.md", model.ImportFileStatusFailed, model.CodeImportFrontMatterInvalid},
+			},
+			wantStatus: model.ImportJobStatusCompleted,
+		},
+		{
+			name:      "title conflict overwritten",
+			strategy:  model.ImportConflictOverwrite,
+			processed: 1,
+			mock: func() {
+				noteService.EXPECT().UpdateNote(1, 7, "Existing", "Новый текст", &[]string{}, false, "").Return(nil)
+			},
+			wantFiles: []fileResult{
+				{"Existing.md", model.ImportFileStatusOverwritten, ""},
+				{"Broken.md", model.ImportFileStatusFailed, model.CodeImportFrontMatterInvalid},
+			},
+			wantStatus: model.ImportJobStatusCompleted,
+		},
+	}
+
+	var files []*model.ImportJobFile
+	repo.EXPECT().SaveEntity(gomock.Any()).DoAndReturn(func(entity model.BusinessEntity) (int, *model.ApplicationError) {
+		if file, ok := entity.(*model.ImportJobFile); ok {
+			files = append(files, file)
+		}
+		return entity.GetId(), nil
+	}).AnyTimes()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &model.ImportJob{Id: 1, UserId: 1, Source: model.ImportSourceMarkdown, Strategy: tt.strategy, Status: model.ImportJobStatusRunning, StorageKey: "imports/1/key", Processed: tt.processed}
+			files = nil
+
+			repo.EXPECT().ClaimImportJob(1, gomock.Any()).Return(job, nil)
+			storage.EXPECT().Get("imports/1/key").Return(io.NopCloser(bytes.NewReader(archive)), nil)
+			repo.EXPECT().GetNotesByUserId(1).Return([]*model.Note{{Id: 7, Title: "Existing", UserId: 1}})
+			repo.EXPECT().GetFoldersByUserId(1).Return([]*model.Folder{})
+			storage.EXPECT().Delete("imports/1/key").Return(nil)
+			tt.mock()
+
+			if err := importService.ProcessJob(1); err != nil {
+				t.Errorf("ImportService.ProcessJob() error = %v", err)
+				return
+			}
+
+			if job.Status != tt.wantStatus || job.Total != 3 || job.Processed != 3 || job.FinishedAt == nil {
+				t.Errorf("job = %v, want %s with 3 processed files", job, tt.wantStatus)
+			}
+
+			if len(files) != len(tt.wantFiles) {
+				t.Fatalf("files = %d, want %d", len(files), len(tt.wantFiles))
+			}
+
+			for i, want := range tt.wantFiles {
+				if files[i].Path != want.path || files[i].Status != want.status || files[i].Code != want.code {
+					t.Errorf("files[%d] = %v, want %v", i, files[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestConcreteImportService_ProcessJobOverwriteResetsNote(t *testing.T) {
+	importService, repo, storage, noteService, _, _ := initImportServiceTest(t)
+
+	archive := zipArchive(t, [][2]string{
+		{"Existing.md", "Новый текст"},
+	})
+	oldFolderId := 3
+	job := &model.ImportJob{Id: 1, UserId: 1, Source: model.ImportSourceMarkdown, Strategy: model.ImportConflictOverwrite, Status: model.ImportJobStatusRunning, StorageKey: "imports/1/key"}
+
+	repo.EXPECT().ClaimImportJob(1, gomock.Any()).Return(job, nil)
+	storage.EXPECT().Get("imports/1/key").Return(io.NopCloser(bytes.NewReader(archive)), nil)
+	repo.EXPECT().GetNotesByUserId(1).Return([]*model.Note{
+		{Id: 7, Title: "Existing", UserId: 1, FolderId: &oldFolderId, IsFavorite: true, IsPinned: true, IsArchived: true},
+	})
+	repo.EXPECT().GetFoldersByUserId(1).Return([]*model.Folder{})
+	repo.EXPECT().SaveEntity(gomock.Any()).DoAndReturn(func(entity model.BusinessEntity) (int, *model.ApplicationError) {
+		return entity.GetId(), nil
+	}).AnyTimes()
+	storage.EXPECT().Delete("imports/1/key").Return(nil)
+
+	// Файл лежит в корне архива и без отметок, поэтому заметка переносится в корень и теряет старые отметки
+	gomock.InOrder(
+		noteService.EXPECT().UpdateNote(1, 7, "Existing", "Новый текст", &[]string{}, false, "").Return(nil),
+		noteService.EXPECT().UnarchiveNote(1, 7, "").Return(nil),
+		noteService.EXPECT().MoveToFolder(1, 7, nil, "").Return(nil),
+		noteService.EXPECT().DeleteFromFavorites(1, 7, "").Return(nil),
+		noteService.EXPECT().UnpinNote(1, 7, "").Return(nil),
+	)
+
+	if err := importService.ProcessJob(1); err != nil {
+		t.Errorf("ImportService.ProcessJob() error = %v", err)
+	}
+}
+
+func TestConcreteImportService_ProcessJobNotClaimed(t *testing.T) {
+	importService, repo, _, _, _, _ := initImportServiceTest(t)
+
+	repo.EXPECT().ClaimImportJob(1, time.Date(2026, 3, 4, 11, 50, 0, 0, time.UTC)).Return(nil, model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil))
+
+	if err := importService.ProcessJob(1); err != nil {
+		t.Errorf("ImportService.ProcessJob() error = %v, want nil", err)
+	}
+}
+
+func TestConcreteImportService_ProcessJobInvalidArchive(t *testing.T) {
+	importService, repo, storage, _, _, _ := initImportServiceTest(t)
+
+	job := &model.ImportJob{Id: 1, UserId: 1, Source: model.ImportSourceMarkdown, Strategy: model.ImportConflictSkip, StorageKey: "imports/1/key"}
+
+	repo.EXPECT().ClaimImportJob(1, gomock.Any()).Return(job, nil)
+	storage.EXPECT().Get("imports/1/key").Return(io.NopCloser(bytes.NewReader([]byte("not a zip"))), nil)
+	repo.EXPECT().GetNotesByUserId(1).Return([]*model.Note{})
+	repo.EXPECT().GetFoldersByUserId(1).Return([]*model.Folder{})
+	repo.EXPECT().SaveEntity(job).Return(1, nil)
+	storage.EXPECT().Delete("imports/1/key").Return(nil)
+
+	if err := importService.ProcessJob(1); err != nil {
+		t.Errorf("ImportService.ProcessJob() error = %v", err)
+	}
+
+	if job.Status != model.ImportJobStatusFailed || job.Error == "" {
+		t.Errorf("job = %v, want failed with error", job)
+	}
+}
+
+func TestConcreteImportService_ProcessJobJson(t *testing.T) {
+	importService, repo, storage, noteService, folderService, _ := initImportServiceTest(t)
+	expectNoNoteLock(repo)
+
+	archive := `{"Version":1,"ExportedAt":"2026-03-01T00:00:00Z",
+		"Folders":[{"Title":"Работа"},{"Title":"Старое","IsArchived":true}],
+		"Notes":[{"Title":"Покупки","Folder":"Работа","Content":"Хлеб\nМолоко","Type":"checklist","Tags":[],"IsPinned":true,
+			"Items":[{"Text":"Хлеб","IsChecked":true},{"Text":"Молоко"}],"Timestamp":"2025-01-02T10:00:00Z"},
+			{"Title":"Архив","Content":"Текст","Type":"text","Tags":["старое"],"IsArchived":true,"Timestamp":"2025-01-02T10:00:00Z"}]}`
+	job := &model.ImportJob{Id: 1, UserId: 1, Source: model.ImportSourceJson, Strategy: model.ImportConflictSkip, StorageKey: "imports/1/key"}
+	timestamp := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+	workFolderId := 4
+	oldFolder := &model.Folder{Id: 5, Title: "Старое", UserId: 1}
+
+	repo.EXPECT().ClaimImportJob(1, gomock.Any()).Return(job, nil)
+	storage.EXPECT().Get("imports/1/key").Return(io.NopCloser(bytes.NewReader([]byte(archive))), nil)
+	repo.EXPECT().GetNotesByUserId(1).Return([]*model.Note{})
+	repo.EXPECT().GetFoldersByUserId(1).Return([]*model.Folder{})
+	folderService.EXPECT().CreateFolder(1, "Работа").Return(workFolderId, nil)
+	folderService.EXPECT().CreateFolder(1, "Старое").Return(5, nil)
+
+	noteService.EXPECT().CreateNote(1, "Покупки", "Хлеб\nМолоко", &[]string{}).Return(10, nil)
+	noteService.EXPECT().MoveToFolder(1, 10, &workFolderId, "").Return(nil)
+	repo.EXPECT().GetNoteById(10, 1).Return(&model.Note{Id: 10, Title: "Покупки", Content: "Хлеб\nМолоко", UserId: 1}, nil)
+	repo.EXPECT().SaveChecklist(gomock.Any(), gomock.Any()).DoAndReturn(func(note *model.Note, items []*model.ChecklistItem) *model.ApplicationError {
+		if note.Type != model.NoteTypeChecklist || len(items) != 2 || !items[0].IsChecked || items[1].IsChecked || items[1].Text != "Молоко" {
+			t.Errorf("SaveChecklist() = %v, %v, want checklist with first item checked", note, items)
+		}
+		return nil
+	})
+	noteService.EXPECT().PinNote(1, 10, "").Return(nil)
+	repo.EXPECT().SetNoteTimestamp(10, timestamp).Return(nil)
+
+	noteService.EXPECT().CreateNote(1, "Архив", "Текст", &[]string{"старое"}).Return(11, nil)
+	noteService.EXPECT().ArchiveNote(1, 11, "").Return(nil)
+	repo.EXPECT().SetNoteTimestamp(11, timestamp).Return(nil)
+
+	repo.EXPECT().GetFolderById(5, 1).Return(oldFolder, nil)
+	repo.EXPECT().SaveEntity(gomock.Any()).Return(1, nil).AnyTimes()
+	storage.EXPECT().Delete("imports/1/key").Return(nil)
+
+	if err := importService.ProcessJob(1); err != nil {
+		t.Fatalf("ImportService.ProcessJob() error = %v", err)
+	}
+
+	if job.Status != model.ImportJobStatusCompleted || job.Imported != 2 || !oldFolder.IsArchived {
+		t.Errorf("job = %v, folder = %v, want completed job with 2 notes and archived folder", job, oldFolder)
+	}
+}
+
+func TestConcreteImportService_ProcessJobEnex(t *testing.T) {
+	importService, repo, storage, noteService, folderService, attachmentService := initImportServiceTest(t)
+
+	enex := `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE en-export SYSTEM "http://xml.evernote.com/pub/evernote-export4.dtd">
+<en-export export-date="20260301T120000Z" application="Evernote" version="10.0">
+  <note>
+    <title>Поездка</title>
+    <created>20250102T080000Z</created>
+    <updated>20250103T093000Z</updated>
+    <tag>отпуск</tag>
+    <tag>планы</tag>
+    <content><![CDATA[<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE en-note SYSTEM "http://xml.evernote.com/pub/enml2.dtd">
+<en-note><div><b>Маршрут</b> по <a href="https://example.com">ссылке</a>&nbsp;и карте</div><div><br/></div>
+<ul><li><div><en-todo checked="true"/>Билеты</div></li><li><div><en-todo/>Отель</div></li></ul>
+<div><en-media hash="9a0364b9e99bb480dd25e1f0284c8555" type="image/png"/></div></en-note>]]></content>
+    <resource>
+      <data encoding="base64">Y29udGVudA==</data>
+      <mime>image/png</mime>
+      <resource-attributes><file-name>map.png</file-name></resource-attributes>
+    </resource>
+  </note>
+  <note>
+    <title></title>
+    <content><![CDATA[<en-note>Без названия</en-note>]]></content>
+  </note>
+</en-export>`
+	job := &model.ImportJob{Id: 1, UserId: 1, Source: model.ImportSourceEnex, Strategy: model.ImportConflictSkip, FileName: "Путешествия.enex", StorageKey: "imports/1/key"}
+	updated := time.Date(2025, 1, 3, 9, 30, 0, 0, time.UTC)
+	folderId := 4
+	var files []*model.ImportJobFile
+
+	repo.EXPECT().ClaimImportJob(1, gomock.Any()).Return(job, nil)
+	storage.EXPECT().Get("imports/1/key").Return(io.NopCloser(bytes.NewReader([]byte(enex))), nil)
+	repo.EXPECT().GetNotesByUserId(1).Return([]*model.Note{})
+	repo.EXPECT().GetFoldersByUserId(1).Return([]*model.Folder{})
+	folderService.EXPECT().CreateFolder(1, "Путешествия").Return(folderId, nil)
+
+	content := "**Маршрут** по [ссылке](https://example.com) и карте\n\n- [x] Билеты\n- [ ] Отель\n\n![map.png](map.png)"
+	noteService.EXPECT().CreateNote(1, "Поездка", content, &[]string{"отпуск", "планы"}).Return(10, nil)
+	noteService.EXPECT().MoveToFolder(1, 10, &folderId, "").Return(nil)
+	repo.EXPECT().SetNoteTimestamp(10, updated).Return(nil)
+	attachmentService.EXPECT().UploadAttachment(1, 10, "map.png", int64(7), gomock.Any()).Return(20, nil)
+
+	noteService.EXPECT().CreateNote(1, "", "Без названия", &[]string{}).Return(constants.FakeId, model.NewLocalizedError(model.ErrorTypeValidation, model.CodeNoteTitleEmpty, nil, nil))
+
+	repo.EXPECT().SaveEntity(gomock.Any()).DoAndReturn(func(entity model.BusinessEntity) (int, *model.ApplicationError) {
+		if file, ok := entity.(*model.ImportJobFile); ok {
+			files = append(files, file)
+		}
+		return 1, nil
+	}).AnyTimes()
+	storage.EXPECT().Delete("imports/1/key").Return(nil)
+
+	if err := importService.ProcessJob(1); err != nil {
+		t.Fatalf("ImportService.ProcessJob() error = %v", err)
+	}
+
+	if job.Status != model.ImportJobStatusCompleted || job.Total != 2 || job.Imported != 1 || job.Failed != 1 {
+		t.Errorf("job = %v, want completed job with 1 imported and 1 failed note", job)
+	}
+
+	if len(files) != 2 || files[0].Path != "Путешествия/Поездка" || files[1].Path != "Путешествия/#2" || files[1].Code != model.CodeNoteTitleEmpty {
+		t.Errorf("files = %v, want imported note and failed untitled note", files)
+	}
+}
+
+func TestOpenImportContent(t *testing.T) {
+	file, size, err := openImportContent(io.NopCloser(bytes.NewReader([]byte("PK\x03\x04 content"))))
+	if err != nil {
+		t.Fatalf("openImportContent() error = %v", err)
+	}
+
+	tmp := file.(*tempImportFile).Name()
+	if size != 12 || !isZipContent(file) {
+		t.Errorf("openImportContent() size = %d, want 12 bytes of zip content", size)
+	}
+
+	if err = file.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+
+	if _, statErr := os.Stat(tmp); !os.IsNotExist(statErr) {
+		t.Errorf("temporary file %s was not removed", tmp)
+	}
+}
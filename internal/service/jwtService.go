@@ -39,7 +39,7 @@ func (j JwtService) GetToken(id int) (string, *model.ApplicationError) {
 	signedToken, err := token.SignedString([]byte(j.cfg.App.Secret))
 
 	if err != nil {
-		return "", model.NewApplicationError(model.ErrorTypeInternal, "Ошибка при формировании токена", err)
+		return "", model.NewLocalizedError(model.ErrorTypeInternal, model.CodeTokenIssueFailed, nil, err)
 	}
 	return signedToken, nil
 }
@@ -48,18 +48,18 @@ func (j JwtService) ParseToken(tokenString string) (*model.Claims, *model.Applic
 	token, err := jwt.ParseWithClaims(tokenString, &model.Claims{}, func(token *jwt.Token) (interface{}, error) {
 		// Проверка алгоритма подписи
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, model.NewApplicationError(model.ErrorTypeAuth, "Неверный метод подписи", nil)
+			return nil, model.NewLocalizedError(model.ErrorTypeAuth, model.CodeTokenSigningMethod, nil, nil)
 		}
 		return []byte(j.cfg.App.Secret), nil
 	})
 
 	if err != nil || !token.Valid {
-		return nil, model.NewApplicationError(model.ErrorTypeAuth, "Невалидный токен", nil)
+		return nil, model.NewLocalizedError(model.ErrorTypeAuth, model.CodeTokenInvalid, nil, nil)
 	}
 
 	if claims, ok := token.Claims.(*model.Claims); ok && token.Valid {
 		return claims, nil
 	}
 
-	return nil, model.NewApplicationError(model.ErrorTypeAuth, "Невалидный токен", nil)
+	return nil, model.NewLocalizedError(model.ErrorTypeAuth, model.CodeTokenInvalid, nil, nil)
 }
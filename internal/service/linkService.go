@@ -0,0 +1,119 @@
+package service
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/repository"
+	"sort"
+)
+
+//go:generate mockgen -source=linkService.go -destination=mock/linkService.go -package=mock
+
+type AbstractLinkService interface {
+	GetBacklinks(userId int, noteId int) ([]*model.BacklinkApi, *model.ApplicationError)
+	GetGraph(userId int) *model.GraphApi
+}
+
+type LinkService struct {
+	repo repository.AbstractRepository
+}
+
+func NewConcreteLinkService(repository repository.AbstractRepository) AbstractLinkService {
+	return &LinkService{
+		repo: repository,
+	}
+}
+
+// GetBacklinks возвращает заметки, ссылающиеся на заметку noteId. Заметки из архива тоже учитываются.
+func (l *LinkService) GetBacklinks(userId int, noteId int) ([]*model.BacklinkApi, *model.ApplicationError) {
+	if _, err := l.repo.GetNoteById(noteId, userId); err != nil {
+		return nil, err
+	}
+
+	notes := l.repo.GetNotesByUserId(userId)
+	notesById, notesByTitle := indexNotes(notes)
+
+	backlinks := make([]*model.BacklinkApi, 0)
+	added := make(map[int]bool)
+
+	for _, link := range l.repo.GetNoteLinksByUserId(userId) {
+		targetId, resolved := link.Resolve(notesById, notesByTitle)
+		if !resolved || targetId != noteId || link.SourceNoteId == noteId || added[link.SourceNoteId] {
+			continue
+		}
+
+		source, exists := notesById[link.SourceNoteId]
+		if !exists {
+			continue
+		}
+
+		added[source.Id] = true
+		backlinks = append(backlinks, &model.BacklinkApi{
+			NoteId:     source.Id,
+			Title:      source.Title,
+			IsArchived: source.IsArchived,
+		})
+	}
+
+	return backlinks, nil
+}
+
+// GetGraph возвращает граф заметок пользователя: вершины, разрешённые ссылки и висячие ссылки
+func (l *LinkService) GetGraph(userId int) *model.GraphApi {
+	notes := l.repo.GetNotesByUserId(userId)
+	sort.Slice(notes, func(i, j int) bool { return notes[i].Id < notes[j].Id })
+	notesById, notesByTitle := indexNotes(notes)
+
+	graph := &model.GraphApi{
+		Nodes:    make([]*model.GraphNodeApi, 0, len(notes)),
+		Edges:    make([]*model.GraphEdgeApi, 0),
+		Dangling: make([]*model.DanglingLinkApi, 0),
+	}
+
+	for _, note := range notes {
+		graph.Nodes = append(graph.Nodes, &model.GraphNodeApi{
+			Id:         note.Id,
+			Title:      note.Title,
+			FolderId:   note.FolderId,
+			IsArchived: note.IsArchived,
+		})
+	}
+
+	edges := make(map[model.GraphEdgeApi]bool)
+
+	for _, link := range l.repo.GetNoteLinksByUserId(userId) {
+		if _, exists := notesById[link.SourceNoteId]; !exists {
+			continue
+		}
+
+		targetId, resolved := link.Resolve(notesById, notesByTitle)
+		if !resolved {
+			graph.Dangling = append(graph.Dangling, &model.DanglingLinkApi{
+				Source: link.SourceNoteId,
+				Target: link.Target(),
+			})
+			continue
+		}
+
+		edge := model.GraphEdgeApi{Source: link.SourceNoteId, Target: targetId}
+		if edges[edge] {
+			continue
+		}
+
+		edges[edge] = true
+		graph.Edges = append(graph.Edges, &edge)
+	}
+
+	return graph
+}
+
+func indexNotes(notes []*model.Note) (map[int]*model.Note, map[string]*model.Note) {
+	notesById := make(map[int]*model.Note, len(notes))
+	notesByTitle := make(map[string]*model.Note, len(notes))
+
+	for _, note := range notes {
+		notesById[note.Id] = note
+		notesByTitle[note.Title] = note
+	}
+
+	return notesById, notesByTitle
+}
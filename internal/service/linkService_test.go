@@ -0,0 +1,141 @@
+package service
+
+import (
+	"Notes/internal/model"
+	mocks "Notes/internal/service/mock"
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	"testing"
+)
+
+func initLinkServiceTest(t *testing.T) (AbstractLinkService, *mocks.MockAbstractRepository) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockAbstractRepository(ctrl)
+
+	return NewConcreteLinkService(mockRepository), mockRepository
+}
+
+func getTestLinkedNotes() []*model.Note {
+	folderId := 5
+
+	return []*model.Note{
+		{Id: 3, Title: "third", Content: "[[first]]", UserId: 1, IsArchived: true},
+		{Id: 1, Title: "first", Content: "[[second]] [[missing]]", UserId: 1, FolderId: &folderId},
+		{Id: 2, Title: "second", Content: "[[note:1]] [[first]] [[note:42]]", UserId: 1},
+	}
+}
+
+func getTestNoteLinks() []*model.NoteLink {
+	return []*model.NoteLink{
+		{Id: 1, SourceNoteId: 1, UserId: 1, Kind: model.NoteLinkKindTitle, TargetTitle: "second"},
+		{Id: 2, SourceNoteId: 1, UserId: 1, Kind: model.NoteLinkKindTitle, TargetTitle: "missing"},
+		{Id: 3, SourceNoteId: 2, UserId: 1, Kind: model.NoteLinkKindId, TargetNoteId: intPointer(1)},
+		{Id: 4, SourceNoteId: 2, UserId: 1, Kind: model.NoteLinkKindTitle, TargetTitle: "first"},
+		{Id: 5, SourceNoteId: 2, UserId: 1, Kind: model.NoteLinkKindId, TargetNoteId: intPointer(42)},
+		{Id: 6, SourceNoteId: 3, UserId: 1, Kind: model.NoteLinkKindTitle, TargetTitle: "first"},
+	}
+}
+
+func TestConcreteLinkService_GetBacklinks(t *testing.T) {
+	linkService, repo := initLinkServiceTest(t)
+
+	tests := []struct {
+		name    string
+		mock    func()
+		noteId  int
+		want    []*model.BacklinkApi
+		wantErr *model.ApplicationError
+	}{
+		{
+			name: "note not found",
+			mock: func() {
+				repo.EXPECT().GetNoteById(7, 1).Return(nil, model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil))
+			},
+			noteId:  7,
+			wantErr: model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil),
+		},
+		{
+			name: "backlinks by title and id including archived notes",
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(&model.Note{Id: 1, Title: "first", UserId: 1}, nil)
+				repo.EXPECT().GetNotesByUserId(1).Return(getTestLinkedNotes())
+				repo.EXPECT().GetNoteLinksByUserId(1).Return(getTestNoteLinks())
+			},
+			noteId: 1,
+			want: []*model.BacklinkApi{
+				{NoteId: 2, Title: "second"},
+				{NoteId: 3, Title: "third", IsArchived: true},
+			},
+		},
+		{
+			name: "no backlinks",
+			mock: func() {
+				repo.EXPECT().GetNoteById(3, 1).Return(&model.Note{Id: 3, Title: "third", UserId: 1}, nil)
+				repo.EXPECT().GetNotesByUserId(1).Return(getTestLinkedNotes())
+				repo.EXPECT().GetNoteLinksByUserId(1).Return(getTestNoteLinks())
+			},
+			noteId: 3,
+			want:   []*model.BacklinkApi{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := linkService.GetBacklinks(1, tt.noteId)
+			if tt.wantErr != nil {
+				if err == nil || err.Type != tt.wantErr.Type || err.Message != tt.wantErr.Message {
+					t.Errorf("LinkService.GetBacklinks() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("LinkService.GetBacklinks() unexpected error = %v", err)
+				return
+			}
+
+			gotJson, _ := json.Marshal(got)
+			expectedJson, _ := json.Marshal(tt.want)
+			if string(gotJson) != string(expectedJson) {
+				t.Errorf("LinkService.GetBacklinks() = %v, want %v", string(gotJson), string(expectedJson))
+			}
+		})
+	}
+}
+
+func TestConcreteLinkService_GetGraph(t *testing.T) {
+	linkService, repo := initLinkServiceTest(t)
+
+	repo.EXPECT().GetNotesByUserId(1).Return(getTestLinkedNotes())
+	repo.EXPECT().GetNoteLinksByUserId(1).Return(getTestNoteLinks())
+
+	folderId := 5
+	want := &model.GraphApi{
+		Nodes: []*model.GraphNodeApi{
+			{Id: 1, Title: "first", FolderId: &folderId},
+			{Id: 2, Title: "second"},
+			{Id: 3, Title: "third", IsArchived: true},
+		},
+		Edges: []*model.GraphEdgeApi{
+			{Source: 1, Target: 2},
+			{Source: 2, Target: 1},
+			{Source: 3, Target: 1},
+		},
+		Dangling: []*model.DanglingLinkApi{
+			{Source: 1, Target: "missing"},
+			{Source: 2, Target: "note:42"},
+		},
+	}
+
+	got := linkService.GetGraph(1)
+
+	gotJson, _ := json.Marshal(got)
+	expectedJson, _ := json.Marshal(want)
+	if string(gotJson) != string(expectedJson) {
+		t.Errorf("LinkService.GetGraph() = %v, want %v", string(gotJson), string(expectedJson))
+	}
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/repository"
+	"log"
+)
+
+// notifyMentions кладёт во входящие упомянутых пользователей уведомление об упоминании в заметке
+// или в комментарии к ней. Уведомляются только те, кому доступна заметка; автор не уведомляет сам себя.
+// Ошибки только записываются в лог: заметка или комментарий к этому моменту уже сохранены.
+func notifyMentions(repo repository.AbstractRepository, actorId int, note *model.Note, comment *model.Comment, logins []string) {
+	if len(logins) == 0 {
+		return
+	}
+
+	for _, user := range repo.GetUsersByLogins(logins) {
+		if user.Id == actorId {
+			continue
+		}
+
+		if _, err := getAccessibleNote(repo, user.Id, note.Id); err != nil {
+			if err.Type != model.ErrorTypeNotFound {
+				log.Printf("Не удалось проверить доступ пользователя %d к заметке %d: %v", user.Id, note.Id, err)
+			}
+			continue
+		}
+
+		notification := model.NewMentionNotification(user.Id, user.Login, actorId, note, comment)
+		if err := deliverToInbox(repo, notification); err != nil {
+			log.Printf("Не удалось уведомить пользователя %d об упоминании в заметке %d: %v", user.Id, note.Id, err)
+		}
+	}
+}
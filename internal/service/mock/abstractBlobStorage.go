@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: abstractBlobStorage.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "Notes/internal/model"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAbstractBlobStorage is a mock of AbstractBlobStorage interface.
+type MockAbstractBlobStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockAbstractBlobStorageMockRecorder
+}
+
+// MockAbstractBlobStorageMockRecorder is the mock recorder for MockAbstractBlobStorage.
+type MockAbstractBlobStorageMockRecorder struct {
+	mock *MockAbstractBlobStorage
+}
+
+// NewMockAbstractBlobStorage creates a new mock instance.
+func NewMockAbstractBlobStorage(ctrl *gomock.Controller) *MockAbstractBlobStorage {
+	mock := &MockAbstractBlobStorage{ctrl: ctrl}
+	mock.recorder = &MockAbstractBlobStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAbstractBlobStorage) EXPECT() *MockAbstractBlobStorageMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockAbstractBlobStorage) Delete(key string) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", key)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAbstractBlobStorageMockRecorder) Delete(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAbstractBlobStorage)(nil).Delete), key)
+}
+
+// Get mocks base method.
+func (m *MockAbstractBlobStorage) Get(key string) (io.ReadCloser, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", key)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAbstractBlobStorageMockRecorder) Get(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAbstractBlobStorage)(nil).Get), key)
+}
+
+// Put mocks base method.
+func (m *MockAbstractBlobStorage) Put(key string, content io.Reader) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", key, content)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockAbstractBlobStorageMockRecorder) Put(key, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockAbstractBlobStorage)(nil).Put), key, content)
+}
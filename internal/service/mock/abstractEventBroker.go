@@ -0,0 +1,63 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: abstractEventBroker.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAbstractEventBroker is a mock of AbstractEventBroker interface.
+type MockAbstractEventBroker struct {
+	ctrl     *gomock.Controller
+	recorder *MockAbstractEventBrokerMockRecorder
+}
+
+// MockAbstractEventBrokerMockRecorder is the mock recorder for MockAbstractEventBroker.
+type MockAbstractEventBrokerMockRecorder struct {
+	mock *MockAbstractEventBroker
+}
+
+// NewMockAbstractEventBroker creates a new mock instance.
+func NewMockAbstractEventBroker(ctrl *gomock.Controller) *MockAbstractEventBroker {
+	mock := &MockAbstractEventBroker{ctrl: ctrl}
+	mock.recorder = &MockAbstractEventBrokerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAbstractEventBroker) EXPECT() *MockAbstractEventBrokerMockRecorder {
+	return m.recorder
+}
+
+// Listen mocks base method.
+func (m *MockAbstractEventBroker) Listen(ctx context.Context, handle func([]byte)) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Listen", ctx, handle)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Listen indicates an expected call of Listen.
+func (mr *MockAbstractEventBrokerMockRecorder) Listen(ctx, handle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Listen", reflect.TypeOf((*MockAbstractEventBroker)(nil).Listen), ctx, handle)
+}
+
+// Publish mocks base method.
+func (m *MockAbstractEventBroker) Publish(payload []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockAbstractEventBrokerMockRecorder) Publish(payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockAbstractEventBroker)(nil).Publish), payload)
+}
@@ -6,7 +6,9 @@ package mock
 
 import (
 	model "Notes/internal/model"
+	repository "Notes/internal/repository"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -34,6 +36,95 @@ func (m *MockAbstractRepository) EXPECT() *MockAbstractRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AcquireNoteLock mocks base method.
+func (m *MockAbstractRepository) AcquireNoteLock(lock *model.NoteLock, now time.Time) (*model.NoteLock, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireNoteLock", lock, now)
+	ret0, _ := ret[0].(*model.NoteLock)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// AcquireNoteLock indicates an expected call of AcquireNoteLock.
+func (mr *MockAbstractRepositoryMockRecorder) AcquireNoteLock(lock, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireNoteLock", reflect.TypeOf((*MockAbstractRepository)(nil).AcquireNoteLock), lock, now)
+}
+
+// ClaimDailyNote mocks base method.
+func (m *MockAbstractRepository) ClaimDailyNote(dailyNote *model.DailyNote) (*model.DailyNote, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDailyNote", dailyNote)
+	ret0, _ := ret[0].(*model.DailyNote)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// ClaimDailyNote indicates an expected call of ClaimDailyNote.
+func (mr *MockAbstractRepositoryMockRecorder) ClaimDailyNote(dailyNote interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDailyNote", reflect.TypeOf((*MockAbstractRepository)(nil).ClaimDailyNote), dailyNote)
+}
+
+// ClaimDueReminder mocks base method.
+func (m *MockAbstractRepository) ClaimDueReminder(now time.Time, claim func(*model.Reminder)) (*model.Reminder, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDueReminder", now, claim)
+	ret0, _ := ret[0].(*model.Reminder)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// ClaimDueReminder indicates an expected call of ClaimDueReminder.
+func (mr *MockAbstractRepositoryMockRecorder) ClaimDueReminder(now, claim interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDueReminder", reflect.TypeOf((*MockAbstractRepository)(nil).ClaimDueReminder), now, claim)
+}
+
+// ClaimImportJob mocks base method.
+func (m *MockAbstractRepository) ClaimImportJob(id int, staleBefore time.Time) (*model.ImportJob, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimImportJob", id, staleBefore)
+	ret0, _ := ret[0].(*model.ImportJob)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// ClaimImportJob indicates an expected call of ClaimImportJob.
+func (mr *MockAbstractRepositoryMockRecorder) ClaimImportJob(id, staleBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimImportJob", reflect.TypeOf((*MockAbstractRepository)(nil).ClaimImportJob), id, staleBefore)
+}
+
+// ClaimWebhookDeliveries mocks base method.
+func (m *MockAbstractRepository) ClaimWebhookDeliveries(now, leaseUntil time.Time, limit int) ([]*model.WebhookDelivery, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimWebhookDeliveries", now, leaseUntil, limit)
+	ret0, _ := ret[0].([]*model.WebhookDelivery)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// ClaimWebhookDeliveries indicates an expected call of ClaimWebhookDeliveries.
+func (mr *MockAbstractRepositoryMockRecorder) ClaimWebhookDeliveries(now, leaseUntil, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimWebhookDeliveries", reflect.TypeOf((*MockAbstractRepository)(nil).ClaimWebhookDeliveries), now, leaseUntil, limit)
+}
+
+// CountUnreadNotifications mocks base method.
+func (m *MockAbstractRepository) CountUnreadNotifications(userId int) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnreadNotifications", userId)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// CountUnreadNotifications indicates an expected call of CountUnreadNotifications.
+func (mr *MockAbstractRepositoryMockRecorder) CountUnreadNotifications(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnreadNotifications", reflect.TypeOf((*MockAbstractRepository)(nil).CountUnreadNotifications), userId)
+}
+
 // DeleteEntity mocks base method.
 func (m *MockAbstractRepository) DeleteEntity(entity model.BusinessEntity) *model.ApplicationError {
 	m.ctrl.T.Helper()
@@ -42,39 +133,483 @@ func (m *MockAbstractRepository) DeleteEntity(entity model.BusinessEntity) *mode
 	return ret0
 }
 
-// DeleteEntity indicates an expected call of DeleteEntity.
-func (mr *MockAbstractRepositoryMockRecorder) DeleteEntity(entity interface{}) *gomock.Call {
+// DeleteEntity indicates an expected call of DeleteEntity.
+func (mr *MockAbstractRepositoryMockRecorder) DeleteEntity(entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEntity", reflect.TypeOf((*MockAbstractRepository)(nil).DeleteEntity), entity)
+}
+
+// DeleteExpiredNoteLocks mocks base method.
+func (m *MockAbstractRepository) DeleteExpiredNoteLocks(now time.Time) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredNoteLocks", now)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// DeleteExpiredNoteLocks indicates an expected call of DeleteExpiredNoteLocks.
+func (mr *MockAbstractRepositoryMockRecorder) DeleteExpiredNoteLocks(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredNoteLocks", reflect.TypeOf((*MockAbstractRepository)(nil).DeleteExpiredNoteLocks), now)
+}
+
+// DeleteOrphanedBlobs mocks base method.
+func (m *MockAbstractRepository) DeleteOrphanedBlobs(ids []int) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrphanedBlobs", ids)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// DeleteOrphanedBlobs indicates an expected call of DeleteOrphanedBlobs.
+func (mr *MockAbstractRepositoryMockRecorder) DeleteOrphanedBlobs(ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrphanedBlobs", reflect.TypeOf((*MockAbstractRepository)(nil).DeleteOrphanedBlobs), ids)
+}
+
+// DeleteWebhookDeliveriesBefore mocks base method.
+func (m *MockAbstractRepository) DeleteWebhookDeliveriesBefore(before time.Time) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhookDeliveriesBefore", before)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// DeleteWebhookDeliveriesBefore indicates an expected call of DeleteWebhookDeliveriesBefore.
+func (mr *MockAbstractRepositoryMockRecorder) DeleteWebhookDeliveriesBefore(before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhookDeliveriesBefore", reflect.TypeOf((*MockAbstractRepository)(nil).DeleteWebhookDeliveriesBefore), before)
+}
+
+// EnqueueWebhookDeliveries mocks base method.
+func (m *MockAbstractRepository) EnqueueWebhookDeliveries(userId int, eventType model.EventType, payload string) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnqueueWebhookDeliveries", userId, eventType, payload)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// EnqueueWebhookDeliveries indicates an expected call of EnqueueWebhookDeliveries.
+func (mr *MockAbstractRepositoryMockRecorder) EnqueueWebhookDeliveries(userId, eventType, payload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnqueueWebhookDeliveries", reflect.TypeOf((*MockAbstractRepository)(nil).EnqueueWebhookDeliveries), userId, eventType, payload)
+}
+
+// GetActiveRemindersByUserId mocks base method.
+func (m *MockAbstractRepository) GetActiveRemindersByUserId(userId int) []*model.Reminder {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveRemindersByUserId", userId)
+	ret0, _ := ret[0].([]*model.Reminder)
+	return ret0
+}
+
+// GetActiveRemindersByUserId indicates an expected call of GetActiveRemindersByUserId.
+func (mr *MockAbstractRepositoryMockRecorder) GetActiveRemindersByUserId(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveRemindersByUserId", reflect.TypeOf((*MockAbstractRepository)(nil).GetActiveRemindersByUserId), userId)
+}
+
+// GetAttachmentById mocks base method.
+func (m *MockAbstractRepository) GetAttachmentById(id int) (*model.Attachment, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachmentById", id)
+	ret0, _ := ret[0].(*model.Attachment)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// GetAttachmentById indicates an expected call of GetAttachmentById.
+func (mr *MockAbstractRepositoryMockRecorder) GetAttachmentById(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachmentById", reflect.TypeOf((*MockAbstractRepository)(nil).GetAttachmentById), id)
+}
+
+// GetAttachmentThumbnail mocks base method.
+func (m *MockAbstractRepository) GetAttachmentThumbnail(attachmentId, size int) (*model.AttachmentThumbnail, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachmentThumbnail", attachmentId, size)
+	ret0, _ := ret[0].(*model.AttachmentThumbnail)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// GetAttachmentThumbnail indicates an expected call of GetAttachmentThumbnail.
+func (mr *MockAbstractRepositoryMockRecorder) GetAttachmentThumbnail(attachmentId, size interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachmentThumbnail", reflect.TypeOf((*MockAbstractRepository)(nil).GetAttachmentThumbnail), attachmentId, size)
+}
+
+// GetAttachmentThumbnails mocks base method.
+func (m *MockAbstractRepository) GetAttachmentThumbnails(attachmentId int) []*model.AttachmentThumbnail {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachmentThumbnails", attachmentId)
+	ret0, _ := ret[0].([]*model.AttachmentThumbnail)
+	return ret0
+}
+
+// GetAttachmentThumbnails indicates an expected call of GetAttachmentThumbnails.
+func (mr *MockAbstractRepositoryMockRecorder) GetAttachmentThumbnails(attachmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachmentThumbnails", reflect.TypeOf((*MockAbstractRepository)(nil).GetAttachmentThumbnails), attachmentId)
+}
+
+// GetAttachmentsByNoteId mocks base method.
+func (m *MockAbstractRepository) GetAttachmentsByNoteId(noteId int) []*model.Attachment {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachmentsByNoteId", noteId)
+	ret0, _ := ret[0].([]*model.Attachment)
+	return ret0
+}
+
+// GetAttachmentsByNoteId indicates an expected call of GetAttachmentsByNoteId.
+func (mr *MockAbstractRepositoryMockRecorder) GetAttachmentsByNoteId(noteId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachmentsByNoteId", reflect.TypeOf((*MockAbstractRepository)(nil).GetAttachmentsByNoteId), noteId)
+}
+
+// GetAttachmentsByThumbnailStatus mocks base method.
+func (m *MockAbstractRepository) GetAttachmentsByThumbnailStatus(status model.ThumbnailStatus) []*model.Attachment {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachmentsByThumbnailStatus", status)
+	ret0, _ := ret[0].([]*model.Attachment)
+	return ret0
+}
+
+// GetAttachmentsByThumbnailStatus indicates an expected call of GetAttachmentsByThumbnailStatus.
+func (mr *MockAbstractRepositoryMockRecorder) GetAttachmentsByThumbnailStatus(status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachmentsByThumbnailStatus", reflect.TypeOf((*MockAbstractRepository)(nil).GetAttachmentsByThumbnailStatus), status)
+}
+
+// GetAuditEntries mocks base method.
+func (m *MockAbstractRepository) GetAuditEntries(filter model.AuditFilter) []*model.AuditEntry {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditEntries", filter)
+	ret0, _ := ret[0].([]*model.AuditEntry)
+	return ret0
+}
+
+// GetAuditEntries indicates an expected call of GetAuditEntries.
+func (mr *MockAbstractRepositoryMockRecorder) GetAuditEntries(filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditEntries", reflect.TypeOf((*MockAbstractRepository)(nil).GetAuditEntries), filter)
+}
+
+// GetCalendarFeedByTokenHash mocks base method.
+func (m *MockAbstractRepository) GetCalendarFeedByTokenHash(tokenHash string) (*model.CalendarFeed, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCalendarFeedByTokenHash", tokenHash)
+	ret0, _ := ret[0].(*model.CalendarFeed)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// GetCalendarFeedByTokenHash indicates an expected call of GetCalendarFeedByTokenHash.
+func (mr *MockAbstractRepositoryMockRecorder) GetCalendarFeedByTokenHash(tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCalendarFeedByTokenHash", reflect.TypeOf((*MockAbstractRepository)(nil).GetCalendarFeedByTokenHash), tokenHash)
+}
+
+// GetCalendarFeedByUserId mocks base method.
+func (m *MockAbstractRepository) GetCalendarFeedByUserId(userId int) (*model.CalendarFeed, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCalendarFeedByUserId", userId)
+	ret0, _ := ret[0].(*model.CalendarFeed)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// GetCalendarFeedByUserId indicates an expected call of GetCalendarFeedByUserId.
+func (mr *MockAbstractRepositoryMockRecorder) GetCalendarFeedByUserId(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCalendarFeedByUserId", reflect.TypeOf((*MockAbstractRepository)(nil).GetCalendarFeedByUserId), userId)
+}
+
+// GetChecklistItemsByNoteId mocks base method.
+func (m *MockAbstractRepository) GetChecklistItemsByNoteId(noteId int) []*model.ChecklistItem {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChecklistItemsByNoteId", noteId)
+	ret0, _ := ret[0].([]*model.ChecklistItem)
+	return ret0
+}
+
+// GetChecklistItemsByNoteId indicates an expected call of GetChecklistItemsByNoteId.
+func (mr *MockAbstractRepositoryMockRecorder) GetChecklistItemsByNoteId(noteId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChecklistItemsByNoteId", reflect.TypeOf((*MockAbstractRepository)(nil).GetChecklistItemsByNoteId), noteId)
+}
+
+// GetChecklistItemsByNoteIds mocks base method.
+func (m *MockAbstractRepository) GetChecklistItemsByNoteIds(noteIds []int) []*model.ChecklistItem {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChecklistItemsByNoteIds", noteIds)
+	ret0, _ := ret[0].([]*model.ChecklistItem)
+	return ret0
+}
+
+// GetChecklistItemsByNoteIds indicates an expected call of GetChecklistItemsByNoteIds.
+func (mr *MockAbstractRepositoryMockRecorder) GetChecklistItemsByNoteIds(noteIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChecklistItemsByNoteIds", reflect.TypeOf((*MockAbstractRepository)(nil).GetChecklistItemsByNoteIds), noteIds)
+}
+
+// GetChecklistItemsByUserId mocks base method.
+func (m *MockAbstractRepository) GetChecklistItemsByUserId(userId int) []*model.ChecklistItem {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChecklistItemsByUserId", userId)
+	ret0, _ := ret[0].([]*model.ChecklistItem)
+	return ret0
+}
+
+// GetChecklistItemsByUserId indicates an expected call of GetChecklistItemsByUserId.
+func (mr *MockAbstractRepositoryMockRecorder) GetChecklistItemsByUserId(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChecklistItemsByUserId", reflect.TypeOf((*MockAbstractRepository)(nil).GetChecklistItemsByUserId), userId)
+}
+
+// GetCommentById mocks base method.
+func (m *MockAbstractRepository) GetCommentById(id int) (*model.Comment, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentById", id)
+	ret0, _ := ret[0].(*model.Comment)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// GetCommentById indicates an expected call of GetCommentById.
+func (mr *MockAbstractRepositoryMockRecorder) GetCommentById(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentById", reflect.TypeOf((*MockAbstractRepository)(nil).GetCommentById), id)
+}
+
+// GetCommentCounts mocks base method.
+func (m *MockAbstractRepository) GetCommentCounts(noteIds []int) map[int]int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentCounts", noteIds)
+	ret0, _ := ret[0].(map[int]int)
+	return ret0
+}
+
+// GetCommentCounts indicates an expected call of GetCommentCounts.
+func (mr *MockAbstractRepositoryMockRecorder) GetCommentCounts(noteIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentCounts", reflect.TypeOf((*MockAbstractRepository)(nil).GetCommentCounts), noteIds)
+}
+
+// GetCommentReplies mocks base method.
+func (m *MockAbstractRepository) GetCommentReplies(threadIds []int) []*model.Comment {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentReplies", threadIds)
+	ret0, _ := ret[0].([]*model.Comment)
+	return ret0
+}
+
+// GetCommentReplies indicates an expected call of GetCommentReplies.
+func (mr *MockAbstractRepositoryMockRecorder) GetCommentReplies(threadIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentReplies", reflect.TypeOf((*MockAbstractRepository)(nil).GetCommentReplies), threadIds)
+}
+
+// GetCommentThreads mocks base method.
+func (m *MockAbstractRepository) GetCommentThreads(noteId, afterId, limit int) []*model.Comment {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommentThreads", noteId, afterId, limit)
+	ret0, _ := ret[0].([]*model.Comment)
+	return ret0
+}
+
+// GetCommentThreads indicates an expected call of GetCommentThreads.
+func (mr *MockAbstractRepositoryMockRecorder) GetCommentThreads(noteId, afterId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommentThreads", reflect.TypeOf((*MockAbstractRepository)(nil).GetCommentThreads), noteId, afterId, limit)
+}
+
+// GetDailyNote mocks base method.
+func (m *MockAbstractRepository) GetDailyNote(userId int, date time.Time) (*model.DailyNote, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDailyNote", userId, date)
+	ret0, _ := ret[0].(*model.DailyNote)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// GetDailyNote indicates an expected call of GetDailyNote.
+func (mr *MockAbstractRepositoryMockRecorder) GetDailyNote(userId, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailyNote", reflect.TypeOf((*MockAbstractRepository)(nil).GetDailyNote), userId, date)
+}
+
+// GetDailyNoteSettings mocks base method.
+func (m *MockAbstractRepository) GetDailyNoteSettings(userId int) (*model.DailyNoteSettings, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDailyNoteSettings", userId)
+	ret0, _ := ret[0].(*model.DailyNoteSettings)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// GetDailyNoteSettings indicates an expected call of GetDailyNoteSettings.
+func (mr *MockAbstractRepositoryMockRecorder) GetDailyNoteSettings(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailyNoteSettings", reflect.TypeOf((*MockAbstractRepository)(nil).GetDailyNoteSettings), userId)
+}
+
+// GetEventsAfter mocks base method.
+func (m *MockAbstractRepository) GetEventsAfter(userId, afterId, limit int) []*model.Event {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEventsAfter", userId, afterId, limit)
+	ret0, _ := ret[0].([]*model.Event)
+	return ret0
+}
+
+// GetEventsAfter indicates an expected call of GetEventsAfter.
+func (mr *MockAbstractRepositoryMockRecorder) GetEventsAfter(userId, afterId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEventsAfter", reflect.TypeOf((*MockAbstractRepository)(nil).GetEventsAfter), userId, afterId, limit)
+}
+
+// GetFolderByClientId mocks base method.
+func (m *MockAbstractRepository) GetFolderByClientId(clientId string, userId int) (*model.Folder, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFolderByClientId", clientId, userId)
+	ret0, _ := ret[0].(*model.Folder)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// GetFolderByClientId indicates an expected call of GetFolderByClientId.
+func (mr *MockAbstractRepositoryMockRecorder) GetFolderByClientId(clientId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFolderByClientId", reflect.TypeOf((*MockAbstractRepository)(nil).GetFolderByClientId), clientId, userId)
+}
+
+// GetFolderById mocks base method.
+func (m *MockAbstractRepository) GetFolderById(id, userId int) (*model.Folder, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFolderById", id, userId)
+	ret0, _ := ret[0].(*model.Folder)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// GetFolderById indicates an expected call of GetFolderById.
+func (mr *MockAbstractRepositoryMockRecorder) GetFolderById(id, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFolderById", reflect.TypeOf((*MockAbstractRepository)(nil).GetFolderById), id, userId)
+}
+
+// GetFoldersByUserId mocks base method.
+func (m *MockAbstractRepository) GetFoldersByUserId(userId int) []*model.Folder {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFoldersByUserId", userId)
+	ret0, _ := ret[0].([]*model.Folder)
+	return ret0
+}
+
+// GetFoldersByUserId indicates an expected call of GetFoldersByUserId.
+func (mr *MockAbstractRepositoryMockRecorder) GetFoldersByUserId(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFoldersByUserId", reflect.TypeOf((*MockAbstractRepository)(nil).GetFoldersByUserId), userId)
+}
+
+// GetFoldersChangedAfter mocks base method.
+func (m *MockAbstractRepository) GetFoldersChangedAfter(userId int, changeSeq int64) []*model.Folder {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFoldersChangedAfter", userId, changeSeq)
+	ret0, _ := ret[0].([]*model.Folder)
+	return ret0
+}
+
+// GetFoldersChangedAfter indicates an expected call of GetFoldersChangedAfter.
+func (mr *MockAbstractRepositoryMockRecorder) GetFoldersChangedAfter(userId, changeSeq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFoldersChangedAfter", reflect.TypeOf((*MockAbstractRepository)(nil).GetFoldersChangedAfter), userId, changeSeq)
+}
+
+// GetImageAttachmentsByUserId mocks base method.
+func (m *MockAbstractRepository) GetImageAttachmentsByUserId(userId int) []*model.Attachment {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImageAttachmentsByUserId", userId)
+	ret0, _ := ret[0].([]*model.Attachment)
+	return ret0
+}
+
+// GetImageAttachmentsByUserId indicates an expected call of GetImageAttachmentsByUserId.
+func (mr *MockAbstractRepositoryMockRecorder) GetImageAttachmentsByUserId(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImageAttachmentsByUserId", reflect.TypeOf((*MockAbstractRepository)(nil).GetImageAttachmentsByUserId), userId)
+}
+
+// GetImportJobById mocks base method.
+func (m *MockAbstractRepository) GetImportJobById(id, userId int) (*model.ImportJob, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImportJobById", id, userId)
+	ret0, _ := ret[0].(*model.ImportJob)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// GetImportJobById indicates an expected call of GetImportJobById.
+func (mr *MockAbstractRepositoryMockRecorder) GetImportJobById(id, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImportJobById", reflect.TypeOf((*MockAbstractRepository)(nil).GetImportJobById), id, userId)
+}
+
+// GetImportJobFiles mocks base method.
+func (m *MockAbstractRepository) GetImportJobFiles(jobId int) []*model.ImportJobFile {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImportJobFiles", jobId)
+	ret0, _ := ret[0].([]*model.ImportJobFile)
+	return ret0
+}
+
+// GetImportJobFiles indicates an expected call of GetImportJobFiles.
+func (mr *MockAbstractRepositoryMockRecorder) GetImportJobFiles(jobId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImportJobFiles", reflect.TypeOf((*MockAbstractRepository)(nil).GetImportJobFiles), jobId)
+}
+
+// GetImportJobsToProcess mocks base method.
+func (m *MockAbstractRepository) GetImportJobsToProcess(staleBefore time.Time) []*model.ImportJob {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImportJobsToProcess", staleBefore)
+	ret0, _ := ret[0].([]*model.ImportJob)
+	return ret0
+}
+
+// GetImportJobsToProcess indicates an expected call of GetImportJobsToProcess.
+func (mr *MockAbstractRepositoryMockRecorder) GetImportJobsToProcess(staleBefore interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEntity", reflect.TypeOf((*MockAbstractRepository)(nil).DeleteEntity), entity)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImportJobsToProcess", reflect.TypeOf((*MockAbstractRepository)(nil).GetImportJobsToProcess), staleBefore)
 }
 
-// GetFolderById mocks base method.
-func (m *MockAbstractRepository) GetFolderById(id, userId int) (*model.Folder, *model.ApplicationError) {
+// GetLastEventId mocks base method.
+func (m *MockAbstractRepository) GetLastEventId(userId int) int {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFolderById", id, userId)
-	ret0, _ := ret[0].(*model.Folder)
-	ret1, _ := ret[1].(*model.ApplicationError)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "GetLastEventId", userId)
+	ret0, _ := ret[0].(int)
+	return ret0
 }
 
-// GetFolderById indicates an expected call of GetFolderById.
-func (mr *MockAbstractRepositoryMockRecorder) GetFolderById(id, userId interface{}) *gomock.Call {
+// GetLastEventId indicates an expected call of GetLastEventId.
+func (mr *MockAbstractRepositoryMockRecorder) GetLastEventId(userId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFolderById", reflect.TypeOf((*MockAbstractRepository)(nil).GetFolderById), id, userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastEventId", reflect.TypeOf((*MockAbstractRepository)(nil).GetLastEventId), userId)
 }
 
-// GetFoldersByUserId mocks base method.
-func (m *MockAbstractRepository) GetFoldersByUserId(userId int) []*model.Folder {
+// GetNoteByClientId mocks base method.
+func (m *MockAbstractRepository) GetNoteByClientId(clientId string, userId int) (*model.Note, *model.ApplicationError) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFoldersByUserId", userId)
-	ret0, _ := ret[0].([]*model.Folder)
-	return ret0
+	ret := m.ctrl.Call(m, "GetNoteByClientId", clientId, userId)
+	ret0, _ := ret[0].(*model.Note)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
 }
 
-// GetFoldersByUserId indicates an expected call of GetFoldersByUserId.
-func (mr *MockAbstractRepositoryMockRecorder) GetFoldersByUserId(userId interface{}) *gomock.Call {
+// GetNoteByClientId indicates an expected call of GetNoteByClientId.
+func (mr *MockAbstractRepositoryMockRecorder) GetNoteByClientId(clientId, userId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFoldersByUserId", reflect.TypeOf((*MockAbstractRepository)(nil).GetFoldersByUserId), userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNoteByClientId", reflect.TypeOf((*MockAbstractRepository)(nil).GetNoteByClientId), clientId, userId)
 }
 
 // GetNoteById mocks base method.
@@ -92,6 +627,49 @@ func (mr *MockAbstractRepositoryMockRecorder) GetNoteById(id, userId interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNoteById", reflect.TypeOf((*MockAbstractRepository)(nil).GetNoteById), id, userId)
 }
 
+// GetNoteLinksByUserId mocks base method.
+func (m *MockAbstractRepository) GetNoteLinksByUserId(userId int) []*model.NoteLink {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNoteLinksByUserId", userId)
+	ret0, _ := ret[0].([]*model.NoteLink)
+	return ret0
+}
+
+// GetNoteLinksByUserId indicates an expected call of GetNoteLinksByUserId.
+func (mr *MockAbstractRepositoryMockRecorder) GetNoteLinksByUserId(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNoteLinksByUserId", reflect.TypeOf((*MockAbstractRepository)(nil).GetNoteLinksByUserId), userId)
+}
+
+// GetNoteLock mocks base method.
+func (m *MockAbstractRepository) GetNoteLock(noteId int, now time.Time) (*model.NoteLock, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNoteLock", noteId, now)
+	ret0, _ := ret[0].(*model.NoteLock)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// GetNoteLock indicates an expected call of GetNoteLock.
+func (mr *MockAbstractRepositoryMockRecorder) GetNoteLock(noteId, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNoteLock", reflect.TypeOf((*MockAbstractRepository)(nil).GetNoteLock), noteId, now)
+}
+
+// GetNoteLocks mocks base method.
+func (m *MockAbstractRepository) GetNoteLocks(noteIds []int, now time.Time) map[int]*model.NoteLock {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNoteLocks", noteIds, now)
+	ret0, _ := ret[0].(map[int]*model.NoteLock)
+	return ret0
+}
+
+// GetNoteLocks indicates an expected call of GetNoteLocks.
+func (mr *MockAbstractRepositoryMockRecorder) GetNoteLocks(noteIds, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNoteLocks", reflect.TypeOf((*MockAbstractRepository)(nil).GetNoteLocks), noteIds, now)
+}
+
 // GetNotesByUserId mocks base method.
 func (m *MockAbstractRepository) GetNotesByUserId(userId int) []*model.Note {
 	m.ctrl.T.Helper()
@@ -106,6 +684,149 @@ func (mr *MockAbstractRepositoryMockRecorder) GetNotesByUserId(userId interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotesByUserId", reflect.TypeOf((*MockAbstractRepository)(nil).GetNotesByUserId), userId)
 }
 
+// GetNotesByUserIdInBatches mocks base method.
+func (m *MockAbstractRepository) GetNotesByUserIdInBatches(userId, batchSize int, handle func([]*model.Note) *model.ApplicationError) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotesByUserIdInBatches", userId, batchSize, handle)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// GetNotesByUserIdInBatches indicates an expected call of GetNotesByUserIdInBatches.
+func (mr *MockAbstractRepositoryMockRecorder) GetNotesByUserIdInBatches(userId, batchSize, handle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotesByUserIdInBatches", reflect.TypeOf((*MockAbstractRepository)(nil).GetNotesByUserIdInBatches), userId, batchSize, handle)
+}
+
+// GetNotesChangedAfter mocks base method.
+func (m *MockAbstractRepository) GetNotesChangedAfter(userId int, changeSeq int64) []*model.Note {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotesChangedAfter", userId, changeSeq)
+	ret0, _ := ret[0].([]*model.Note)
+	return ret0
+}
+
+// GetNotesChangedAfter indicates an expected call of GetNotesChangedAfter.
+func (mr *MockAbstractRepositoryMockRecorder) GetNotesChangedAfter(userId, changeSeq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotesChangedAfter", reflect.TypeOf((*MockAbstractRepository)(nil).GetNotesChangedAfter), userId, changeSeq)
+}
+
+// GetNotifications mocks base method.
+func (m *MockAbstractRepository) GetNotifications(userId, beforeId, limit int, unreadOnly bool) []*model.Notification {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", userId, beforeId, limit, unreadOnly)
+	ret0, _ := ret[0].([]*model.Notification)
+	return ret0
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *MockAbstractRepositoryMockRecorder) GetNotifications(userId, beforeId, limit, unreadOnly interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*MockAbstractRepository)(nil).GetNotifications), userId, beforeId, limit, unreadOnly)
+}
+
+// GetOrphanedBlobs mocks base method.
+func (m *MockAbstractRepository) GetOrphanedBlobs(limit int) []*model.OrphanedBlob {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrphanedBlobs", limit)
+	ret0, _ := ret[0].([]*model.OrphanedBlob)
+	return ret0
+}
+
+// GetOrphanedBlobs indicates an expected call of GetOrphanedBlobs.
+func (mr *MockAbstractRepositoryMockRecorder) GetOrphanedBlobs(limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrphanedBlobs", reflect.TypeOf((*MockAbstractRepository)(nil).GetOrphanedBlobs), limit)
+}
+
+// GetPreferences mocks base method.
+func (m *MockAbstractRepository) GetPreferences(userId int) (*model.Preferences, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferences", userId)
+	ret0, _ := ret[0].(*model.Preferences)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockAbstractRepositoryMockRecorder) GetPreferences(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*MockAbstractRepository)(nil).GetPreferences), userId)
+}
+
+// GetReminderByNoteId mocks base method.
+func (m *MockAbstractRepository) GetReminderByNoteId(noteId, userId int) (*model.Reminder, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReminderByNoteId", noteId, userId)
+	ret0, _ := ret[0].(*model.Reminder)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// GetReminderByNoteId indicates an expected call of GetReminderByNoteId.
+func (mr *MockAbstractRepositoryMockRecorder) GetReminderByNoteId(noteId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReminderByNoteId", reflect.TypeOf((*MockAbstractRepository)(nil).GetReminderByNoteId), noteId, userId)
+}
+
+// GetRemindersByUserId mocks base method.
+func (m *MockAbstractRepository) GetRemindersByUserId(userId int) []*model.Reminder {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRemindersByUserId", userId)
+	ret0, _ := ret[0].([]*model.Reminder)
+	return ret0
+}
+
+// GetRemindersByUserId indicates an expected call of GetRemindersByUserId.
+func (mr *MockAbstractRepositoryMockRecorder) GetRemindersByUserId(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemindersByUserId", reflect.TypeOf((*MockAbstractRepository)(nil).GetRemindersByUserId), userId)
+}
+
+// GetTemplateById mocks base method.
+func (m *MockAbstractRepository) GetTemplateById(id, userId int) (*model.Template, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplateById", id, userId)
+	ret0, _ := ret[0].(*model.Template)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// GetTemplateById indicates an expected call of GetTemplateById.
+func (mr *MockAbstractRepositoryMockRecorder) GetTemplateById(id, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateById", reflect.TypeOf((*MockAbstractRepository)(nil).GetTemplateById), id, userId)
+}
+
+// GetTemplatesByUserId mocks base method.
+func (m *MockAbstractRepository) GetTemplatesByUserId(userId int) []*model.Template {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplatesByUserId", userId)
+	ret0, _ := ret[0].([]*model.Template)
+	return ret0
+}
+
+// GetTemplatesByUserId indicates an expected call of GetTemplatesByUserId.
+func (mr *MockAbstractRepositoryMockRecorder) GetTemplatesByUserId(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplatesByUserId", reflect.TypeOf((*MockAbstractRepository)(nil).GetTemplatesByUserId), userId)
+}
+
+// GetTombstonesAfter mocks base method.
+func (m *MockAbstractRepository) GetTombstonesAfter(userId int, changeSeq int64) []*model.Tombstone {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTombstonesAfter", userId, changeSeq)
+	ret0, _ := ret[0].([]*model.Tombstone)
+	return ret0
+}
+
+// GetTombstonesAfter indicates an expected call of GetTombstonesAfter.
+func (mr *MockAbstractRepositoryMockRecorder) GetTombstonesAfter(userId, changeSeq interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTombstonesAfter", reflect.TypeOf((*MockAbstractRepository)(nil).GetTombstonesAfter), userId, changeSeq)
+}
+
 // GetUser mocks base method.
 func (m *MockAbstractRepository) GetUser(login, password string) (*model.User, *model.ApplicationError) {
 	m.ctrl.T.Helper()
@@ -136,6 +857,21 @@ func (mr *MockAbstractRepositoryMockRecorder) GetUserById(id interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserById", reflect.TypeOf((*MockAbstractRepository)(nil).GetUserById), id)
 }
 
+// GetUserByLogin mocks base method.
+func (m *MockAbstractRepository) GetUserByLogin(login string) (*model.User, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByLogin", login)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// GetUserByLogin indicates an expected call of GetUserByLogin.
+func (mr *MockAbstractRepositoryMockRecorder) GetUserByLogin(login interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByLogin", reflect.TypeOf((*MockAbstractRepository)(nil).GetUserByLogin), login)
+}
+
 // GetUsers mocks base method.
 func (m *MockAbstractRepository) GetUsers() []*model.User {
 	m.ctrl.T.Helper()
@@ -150,6 +886,176 @@ func (mr *MockAbstractRepositoryMockRecorder) GetUsers() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockAbstractRepository)(nil).GetUsers))
 }
 
+// GetUsersByLogins mocks base method.
+func (m *MockAbstractRepository) GetUsersByLogins(logins []string) []*model.User {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersByLogins", logins)
+	ret0, _ := ret[0].([]*model.User)
+	return ret0
+}
+
+// GetUsersByLogins indicates an expected call of GetUsersByLogins.
+func (mr *MockAbstractRepositoryMockRecorder) GetUsersByLogins(logins interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersByLogins", reflect.TypeOf((*MockAbstractRepository)(nil).GetUsersByLogins), logins)
+}
+
+// GetWebhookAttempts mocks base method.
+func (m *MockAbstractRepository) GetWebhookAttempts(deliveryIds []int) []*model.WebhookAttempt {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookAttempts", deliveryIds)
+	ret0, _ := ret[0].([]*model.WebhookAttempt)
+	return ret0
+}
+
+// GetWebhookAttempts indicates an expected call of GetWebhookAttempts.
+func (mr *MockAbstractRepositoryMockRecorder) GetWebhookAttempts(deliveryIds interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookAttempts", reflect.TypeOf((*MockAbstractRepository)(nil).GetWebhookAttempts), deliveryIds)
+}
+
+// GetWebhookById mocks base method.
+func (m *MockAbstractRepository) GetWebhookById(id, userId int) (*model.Webhook, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookById", id, userId)
+	ret0, _ := ret[0].(*model.Webhook)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// GetWebhookById indicates an expected call of GetWebhookById.
+func (mr *MockAbstractRepositoryMockRecorder) GetWebhookById(id, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookById", reflect.TypeOf((*MockAbstractRepository)(nil).GetWebhookById), id, userId)
+}
+
+// GetWebhookDeliveries mocks base method.
+func (m *MockAbstractRepository) GetWebhookDeliveries(webhookId, limit int) []*model.WebhookDelivery {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDeliveries", webhookId, limit)
+	ret0, _ := ret[0].([]*model.WebhookDelivery)
+	return ret0
+}
+
+// GetWebhookDeliveries indicates an expected call of GetWebhookDeliveries.
+func (mr *MockAbstractRepositoryMockRecorder) GetWebhookDeliveries(webhookId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDeliveries", reflect.TypeOf((*MockAbstractRepository)(nil).GetWebhookDeliveries), webhookId, limit)
+}
+
+// GetWebhookDeliveryById mocks base method.
+func (m *MockAbstractRepository) GetWebhookDeliveryById(id, webhookId int) (*model.WebhookDelivery, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDeliveryById", id, webhookId)
+	ret0, _ := ret[0].(*model.WebhookDelivery)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// GetWebhookDeliveryById indicates an expected call of GetWebhookDeliveryById.
+func (mr *MockAbstractRepositoryMockRecorder) GetWebhookDeliveryById(id, webhookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDeliveryById", reflect.TypeOf((*MockAbstractRepository)(nil).GetWebhookDeliveryById), id, webhookId)
+}
+
+// GetWebhooksByUserId mocks base method.
+func (m *MockAbstractRepository) GetWebhooksByUserId(userId int) []*model.Webhook {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooksByUserId", userId)
+	ret0, _ := ret[0].([]*model.Webhook)
+	return ret0
+}
+
+// GetWebhooksByUserId indicates an expected call of GetWebhooksByUserId.
+func (mr *MockAbstractRepositoryMockRecorder) GetWebhooksByUserId(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooksByUserId", reflect.TypeOf((*MockAbstractRepository)(nil).GetWebhooksByUserId), userId)
+}
+
+// LockUserChanges mocks base method.
+func (m *MockAbstractRepository) LockUserChanges(userId int) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockUserChanges", userId)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// LockUserChanges indicates an expected call of LockUserChanges.
+func (mr *MockAbstractRepositoryMockRecorder) LockUserChanges(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockUserChanges", reflect.TypeOf((*MockAbstractRepository)(nil).LockUserChanges), userId)
+}
+
+// MarkAllNotificationsRead mocks base method.
+func (m *MockAbstractRepository) MarkAllNotificationsRead(userId int) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllNotificationsRead", userId)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// MarkAllNotificationsRead indicates an expected call of MarkAllNotificationsRead.
+func (mr *MockAbstractRepositoryMockRecorder) MarkAllNotificationsRead(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllNotificationsRead", reflect.TypeOf((*MockAbstractRepository)(nil).MarkAllNotificationsRead), userId)
+}
+
+// MarkNotificationRead mocks base method.
+func (m *MockAbstractRepository) MarkNotificationRead(id, userId int) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationRead", id, userId)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// MarkNotificationRead indicates an expected call of MarkNotificationRead.
+func (mr *MockAbstractRepositoryMockRecorder) MarkNotificationRead(id, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationRead", reflect.TypeOf((*MockAbstractRepository)(nil).MarkNotificationRead), id, userId)
+}
+
+// ReleaseNoteLock mocks base method.
+func (m *MockAbstractRepository) ReleaseNoteLock(noteId int, token string) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseNoteLock", noteId, token)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// ReleaseNoteLock indicates an expected call of ReleaseNoteLock.
+func (mr *MockAbstractRepositoryMockRecorder) ReleaseNoteLock(noteId, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseNoteLock", reflect.TypeOf((*MockAbstractRepository)(nil).ReleaseNoteLock), noteId, token)
+}
+
+// ReplaceNoteLinks mocks base method.
+func (m *MockAbstractRepository) ReplaceNoteLinks(noteId int, links []*model.NoteLink) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceNoteLinks", noteId, links)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// ReplaceNoteLinks indicates an expected call of ReplaceNoteLinks.
+func (mr *MockAbstractRepositoryMockRecorder) ReplaceNoteLinks(noteId, links interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceNoteLinks", reflect.TypeOf((*MockAbstractRepository)(nil).ReplaceNoteLinks), noteId, links)
+}
+
+// SaveChecklist mocks base method.
+func (m *MockAbstractRepository) SaveChecklist(note *model.Note, items []*model.ChecklistItem) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveChecklist", note, items)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// SaveChecklist indicates an expected call of SaveChecklist.
+func (mr *MockAbstractRepositoryMockRecorder) SaveChecklist(note, items interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveChecklist", reflect.TypeOf((*MockAbstractRepository)(nil).SaveChecklist), note, items)
+}
+
 // SaveEntity mocks base method.
 func (m *MockAbstractRepository) SaveEntity(entity model.BusinessEntity) (int, *model.ApplicationError) {
 	m.ctrl.T.Helper()
@@ -164,3 +1070,59 @@ func (mr *MockAbstractRepositoryMockRecorder) SaveEntity(entity interface{}) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveEntity", reflect.TypeOf((*MockAbstractRepository)(nil).SaveEntity), entity)
 }
+
+// SetFolderArchived mocks base method.
+func (m *MockAbstractRepository) SetFolderArchived(folder *model.Folder, isArchived bool) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFolderArchived", folder, isArchived)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// SetFolderArchived indicates an expected call of SetFolderArchived.
+func (mr *MockAbstractRepositoryMockRecorder) SetFolderArchived(folder, isArchived interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFolderArchived", reflect.TypeOf((*MockAbstractRepository)(nil).SetFolderArchived), folder, isArchived)
+}
+
+// SetNoteTimestamp mocks base method.
+func (m *MockAbstractRepository) SetNoteTimestamp(noteId int, timestamp time.Time) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetNoteTimestamp", noteId, timestamp)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// SetNoteTimestamp indicates an expected call of SetNoteTimestamp.
+func (mr *MockAbstractRepositoryMockRecorder) SetNoteTimestamp(noteId, timestamp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetNoteTimestamp", reflect.TypeOf((*MockAbstractRepository)(nil).SetNoteTimestamp), noteId, timestamp)
+}
+
+// SnapshotTransaction mocks base method.
+func (m *MockAbstractRepository) SnapshotTransaction(fn func(repository.AbstractRepository) *model.ApplicationError) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SnapshotTransaction", fn)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// SnapshotTransaction indicates an expected call of SnapshotTransaction.
+func (mr *MockAbstractRepositoryMockRecorder) SnapshotTransaction(fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SnapshotTransaction", reflect.TypeOf((*MockAbstractRepository)(nil).SnapshotTransaction), fn)
+}
+
+// Transaction mocks base method.
+func (m *MockAbstractRepository) Transaction(fn func(repository.AbstractRepository) *model.ApplicationError) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transaction", fn)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// Transaction indicates an expected call of Transaction.
+func (mr *MockAbstractRepositoryMockRecorder) Transaction(fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transaction", reflect.TypeOf((*MockAbstractRepository)(nil).Transaction), fn)
+}
@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: attachmentService.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "Notes/internal/model"
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAbstractAttachmentService is a mock of AbstractAttachmentService interface.
+type MockAbstractAttachmentService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAbstractAttachmentServiceMockRecorder
+}
+
+// MockAbstractAttachmentServiceMockRecorder is the mock recorder for MockAbstractAttachmentService.
+type MockAbstractAttachmentServiceMockRecorder struct {
+	mock *MockAbstractAttachmentService
+}
+
+// NewMockAbstractAttachmentService creates a new mock instance.
+func NewMockAbstractAttachmentService(ctrl *gomock.Controller) *MockAbstractAttachmentService {
+	mock := &MockAbstractAttachmentService{ctrl: ctrl}
+	mock.recorder = &MockAbstractAttachmentServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAbstractAttachmentService) EXPECT() *MockAbstractAttachmentServiceMockRecorder {
+	return m.recorder
+}
+
+// DeleteAttachment mocks base method.
+func (m *MockAbstractAttachmentService) DeleteAttachment(userId, noteId, id int) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAttachment", userId, noteId, id)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// DeleteAttachment indicates an expected call of DeleteAttachment.
+func (mr *MockAbstractAttachmentServiceMockRecorder) DeleteAttachment(userId, noteId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAttachment", reflect.TypeOf((*MockAbstractAttachmentService)(nil).DeleteAttachment), userId, noteId, id)
+}
+
+// GetAttachmentContent mocks base method.
+func (m *MockAbstractAttachmentService) GetAttachmentContent(userId, noteId, id int) (*model.Attachment, io.ReadCloser, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachmentContent", userId, noteId, id)
+	ret0, _ := ret[0].(*model.Attachment)
+	ret1, _ := ret[1].(io.ReadCloser)
+	ret2, _ := ret[2].(*model.ApplicationError)
+	return ret0, ret1, ret2
+}
+
+// GetAttachmentContent indicates an expected call of GetAttachmentContent.
+func (mr *MockAbstractAttachmentServiceMockRecorder) GetAttachmentContent(userId, noteId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachmentContent", reflect.TypeOf((*MockAbstractAttachmentService)(nil).GetAttachmentContent), userId, noteId, id)
+}
+
+// GetAttachments mocks base method.
+func (m *MockAbstractAttachmentService) GetAttachments(userId, noteId int) ([]*model.AttachmentApi, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachments", userId, noteId)
+	ret0, _ := ret[0].([]*model.AttachmentApi)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// GetAttachments indicates an expected call of GetAttachments.
+func (mr *MockAbstractAttachmentServiceMockRecorder) GetAttachments(userId, noteId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachments", reflect.TypeOf((*MockAbstractAttachmentService)(nil).GetAttachments), userId, noteId)
+}
+
+// GetThumbnail mocks base method.
+func (m *MockAbstractAttachmentService) GetThumbnail(userId, noteId, id, size int) (*model.AttachmentThumbnail, io.ReadCloser, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetThumbnail", userId, noteId, id, size)
+	ret0, _ := ret[0].(*model.AttachmentThumbnail)
+	ret1, _ := ret[1].(io.ReadCloser)
+	ret2, _ := ret[2].(*model.ApplicationError)
+	return ret0, ret1, ret2
+}
+
+// GetThumbnail indicates an expected call of GetThumbnail.
+func (mr *MockAbstractAttachmentServiceMockRecorder) GetThumbnail(userId, noteId, id, size interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetThumbnail", reflect.TypeOf((*MockAbstractAttachmentService)(nil).GetThumbnail), userId, noteId, id, size)
+}
+
+// Run mocks base method.
+func (m *MockAbstractAttachmentService) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockAbstractAttachmentServiceMockRecorder) Run(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockAbstractAttachmentService)(nil).Run), ctx)
+}
+
+// UploadAttachment mocks base method.
+func (m *MockAbstractAttachmentService) UploadAttachment(userId, noteId int, fileName string, size int64, content io.Reader) (int, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadAttachment", userId, noteId, fileName, size, content)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// UploadAttachment indicates an expected call of UploadAttachment.
+func (mr *MockAbstractAttachmentServiceMockRecorder) UploadAttachment(userId, noteId, fileName, size, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadAttachment", reflect.TypeOf((*MockAbstractAttachmentService)(nil).UploadAttachment), userId, noteId, fileName, size, content)
+}
@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: auditService.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "Notes/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAbstractAuditService is a mock of AbstractAuditService interface.
+type MockAbstractAuditService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAbstractAuditServiceMockRecorder
+}
+
+// MockAbstractAuditServiceMockRecorder is the mock recorder for MockAbstractAuditService.
+type MockAbstractAuditServiceMockRecorder struct {
+	mock *MockAbstractAuditService
+}
+
+// NewMockAbstractAuditService creates a new mock instance.
+func NewMockAbstractAuditService(ctrl *gomock.Controller) *MockAbstractAuditService {
+	mock := &MockAbstractAuditService{ctrl: ctrl}
+	mock.recorder = &MockAbstractAuditServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAbstractAuditService) EXPECT() *MockAbstractAuditServiceMockRecorder {
+	return m.recorder
+}
+
+// GetUserAudit mocks base method.
+func (m *MockAbstractAuditService) GetUserAudit(userId int, filter model.AuditFilter) ([]*model.AuditEntryApi, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAudit", userId, filter)
+	ret0, _ := ret[0].([]*model.AuditEntryApi)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// GetUserAudit indicates an expected call of GetUserAudit.
+func (mr *MockAbstractAuditServiceMockRecorder) GetUserAudit(userId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAudit", reflect.TypeOf((*MockAbstractAuditService)(nil).GetUserAudit), userId, filter)
+}
+
+// QueryAudit mocks base method.
+func (m *MockAbstractAuditService) QueryAudit(userId int, filter model.AuditFilter) ([]*model.AuditEntryApi, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryAudit", userId, filter)
+	ret0, _ := ret[0].([]*model.AuditEntryApi)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// QueryAudit indicates an expected call of QueryAudit.
+func (mr *MockAbstractAuditServiceMockRecorder) QueryAudit(userId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryAudit", reflect.TypeOf((*MockAbstractAuditService)(nil).QueryAudit), userId, filter)
+}
+
+// RecordLogin mocks base method.
+func (m *MockAbstractAuditService) RecordLogin(entry *model.AuditEntry, login string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordLogin", entry, login)
+}
+
+// RecordLogin indicates an expected call of RecordLogin.
+func (mr *MockAbstractAuditServiceMockRecorder) RecordLogin(entry, login interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordLogin", reflect.TypeOf((*MockAbstractAuditService)(nil).RecordLogin), entry, login)
+}
+
+// MockoriginBinder is a mock of originBinder interface.
+type MockoriginBinder struct {
+	ctrl     *gomock.Controller
+	recorder *MockoriginBinderMockRecorder
+}
+
+// MockoriginBinderMockRecorder is the mock recorder for MockoriginBinder.
+type MockoriginBinderMockRecorder struct {
+	mock *MockoriginBinder
+}
+
+// NewMockoriginBinder creates a new mock instance.
+func NewMockoriginBinder(ctrl *gomock.Controller) *MockoriginBinder {
+	mock := &MockoriginBinder{ctrl: ctrl}
+	mock.recorder = &MockoriginBinderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockoriginBinder) EXPECT() *MockoriginBinderMockRecorder {
+	return m.recorder
+}
+
+// withOrigin mocks base method.
+func (m *MockoriginBinder) withOrigin(origin model.AuditOrigin) any {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "withOrigin", origin)
+	ret0, _ := ret[0].(any)
+	return ret0
+}
+
+// withOrigin indicates an expected call of withOrigin.
+func (mr *MockoriginBinderMockRecorder) withOrigin(origin interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "withOrigin", reflect.TypeOf((*MockoriginBinder)(nil).withOrigin), origin)
+}
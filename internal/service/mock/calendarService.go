@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: calendarService.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "Notes/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAbstractCalendarService is a mock of AbstractCalendarService interface.
+type MockAbstractCalendarService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAbstractCalendarServiceMockRecorder
+}
+
+// MockAbstractCalendarServiceMockRecorder is the mock recorder for MockAbstractCalendarService.
+type MockAbstractCalendarServiceMockRecorder struct {
+	mock *MockAbstractCalendarService
+}
+
+// NewMockAbstractCalendarService creates a new mock instance.
+func NewMockAbstractCalendarService(ctrl *gomock.Controller) *MockAbstractCalendarService {
+	mock := &MockAbstractCalendarService{ctrl: ctrl}
+	mock.recorder = &MockAbstractCalendarServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAbstractCalendarService) EXPECT() *MockAbstractCalendarServiceMockRecorder {
+	return m.recorder
+}
+
+// GetFeed mocks base method.
+func (m *MockAbstractCalendarService) GetFeed(token string) (string, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeed", token)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// GetFeed indicates an expected call of GetFeed.
+func (mr *MockAbstractCalendarServiceMockRecorder) GetFeed(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeed", reflect.TypeOf((*MockAbstractCalendarService)(nil).GetFeed), token)
+}
+
+// RegenerateFeedUrl mocks base method.
+func (m *MockAbstractCalendarService) RegenerateFeedUrl(userId int) (string, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegenerateFeedUrl", userId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// RegenerateFeedUrl indicates an expected call of RegenerateFeedUrl.
+func (mr *MockAbstractCalendarServiceMockRecorder) RegenerateFeedUrl(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegenerateFeedUrl", reflect.TypeOf((*MockAbstractCalendarService)(nil).RegenerateFeedUrl), userId)
+}
+
+// RevokeFeed mocks base method.
+func (m *MockAbstractCalendarService) RevokeFeed(userId int) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeFeed", userId)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// RevokeFeed indicates an expected call of RevokeFeed.
+func (mr *MockAbstractCalendarServiceMockRecorder) RevokeFeed(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeFeed", reflect.TypeOf((*MockAbstractCalendarService)(nil).RevokeFeed), userId)
+}
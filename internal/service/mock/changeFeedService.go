@@ -0,0 +1,63 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: changeFeedService.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "Notes/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAbstractChangeFeedService is a mock of AbstractChangeFeedService interface.
+type MockAbstractChangeFeedService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAbstractChangeFeedServiceMockRecorder
+}
+
+// MockAbstractChangeFeedServiceMockRecorder is the mock recorder for MockAbstractChangeFeedService.
+type MockAbstractChangeFeedServiceMockRecorder struct {
+	mock *MockAbstractChangeFeedService
+}
+
+// NewMockAbstractChangeFeedService creates a new mock instance.
+func NewMockAbstractChangeFeedService(ctrl *gomock.Controller) *MockAbstractChangeFeedService {
+	mock := &MockAbstractChangeFeedService{ctrl: ctrl}
+	mock.recorder = &MockAbstractChangeFeedServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAbstractChangeFeedService) EXPECT() *MockAbstractChangeFeedServiceMockRecorder {
+	return m.recorder
+}
+
+// GetChanges mocks base method.
+func (m *MockAbstractChangeFeedService) GetChanges(userId, afterId int) []*model.Event {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChanges", userId, afterId)
+	ret0, _ := ret[0].([]*model.Event)
+	return ret0
+}
+
+// GetChanges indicates an expected call of GetChanges.
+func (mr *MockAbstractChangeFeedServiceMockRecorder) GetChanges(userId, afterId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChanges", reflect.TypeOf((*MockAbstractChangeFeedService)(nil).GetChanges), userId, afterId)
+}
+
+// GetLastChangeId mocks base method.
+func (m *MockAbstractChangeFeedService) GetLastChangeId(userId int) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastChangeId", userId)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetLastChangeId indicates an expected call of GetLastChangeId.
+func (mr *MockAbstractChangeFeedServiceMockRecorder) GetLastChangeId(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastChangeId", reflect.TypeOf((*MockAbstractChangeFeedService)(nil).GetLastChangeId), userId)
+}
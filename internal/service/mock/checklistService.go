@@ -0,0 +1,106 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: checklistService.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "Notes/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAbstractChecklistService is a mock of AbstractChecklistService interface.
+type MockAbstractChecklistService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAbstractChecklistServiceMockRecorder
+}
+
+// MockAbstractChecklistServiceMockRecorder is the mock recorder for MockAbstractChecklistService.
+type MockAbstractChecklistServiceMockRecorder struct {
+	mock *MockAbstractChecklistService
+}
+
+// NewMockAbstractChecklistService creates a new mock instance.
+func NewMockAbstractChecklistService(ctrl *gomock.Controller) *MockAbstractChecklistService {
+	mock := &MockAbstractChecklistService{ctrl: ctrl}
+	mock.recorder = &MockAbstractChecklistServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAbstractChecklistService) EXPECT() *MockAbstractChecklistServiceMockRecorder {
+	return m.recorder
+}
+
+// AddItem mocks base method.
+func (m *MockAbstractChecklistService) AddItem(userId, noteId int, text string, position *int, lockToken string) (int, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddItem", userId, noteId, text, position, lockToken)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// AddItem indicates an expected call of AddItem.
+func (mr *MockAbstractChecklistServiceMockRecorder) AddItem(userId, noteId, text, position, lockToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItem", reflect.TypeOf((*MockAbstractChecklistService)(nil).AddItem), userId, noteId, text, position, lockToken)
+}
+
+// ChangeNoteType mocks base method.
+func (m *MockAbstractChecklistService) ChangeNoteType(userId, noteId int, noteType model.NoteType, lockToken string) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeNoteType", userId, noteId, noteType, lockToken)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// ChangeNoteType indicates an expected call of ChangeNoteType.
+func (mr *MockAbstractChecklistServiceMockRecorder) ChangeNoteType(userId, noteId, noteType, lockToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeNoteType", reflect.TypeOf((*MockAbstractChecklistService)(nil).ChangeNoteType), userId, noteId, noteType, lockToken)
+}
+
+// DeleteItem mocks base method.
+func (m *MockAbstractChecklistService) DeleteItem(userId, noteId, itemId int, lockToken string) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteItem", userId, noteId, itemId, lockToken)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// DeleteItem indicates an expected call of DeleteItem.
+func (mr *MockAbstractChecklistServiceMockRecorder) DeleteItem(userId, noteId, itemId, lockToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteItem", reflect.TypeOf((*MockAbstractChecklistService)(nil).DeleteItem), userId, noteId, itemId, lockToken)
+}
+
+// MoveItem mocks base method.
+func (m *MockAbstractChecklistService) MoveItem(userId, noteId, itemId, position int, lockToken string) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveItem", userId, noteId, itemId, position, lockToken)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// MoveItem indicates an expected call of MoveItem.
+func (mr *MockAbstractChecklistServiceMockRecorder) MoveItem(userId, noteId, itemId, position, lockToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveItem", reflect.TypeOf((*MockAbstractChecklistService)(nil).MoveItem), userId, noteId, itemId, position, lockToken)
+}
+
+// SetItemChecked mocks base method.
+func (m *MockAbstractChecklistService) SetItemChecked(userId, noteId, itemId int, isChecked bool, lockToken string) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetItemChecked", userId, noteId, itemId, isChecked, lockToken)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// SetItemChecked indicates an expected call of SetItemChecked.
+func (mr *MockAbstractChecklistServiceMockRecorder) SetItemChecked(userId, noteId, itemId, isChecked, lockToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetItemChecked", reflect.TypeOf((*MockAbstractChecklistService)(nil).SetItemChecked), userId, noteId, itemId, isChecked, lockToken)
+}
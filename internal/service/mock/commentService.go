@@ -0,0 +1,107 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: commentService.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "Notes/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAbstractCommentService is a mock of AbstractCommentService interface.
+type MockAbstractCommentService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAbstractCommentServiceMockRecorder
+}
+
+// MockAbstractCommentServiceMockRecorder is the mock recorder for MockAbstractCommentService.
+type MockAbstractCommentServiceMockRecorder struct {
+	mock *MockAbstractCommentService
+}
+
+// NewMockAbstractCommentService creates a new mock instance.
+func NewMockAbstractCommentService(ctrl *gomock.Controller) *MockAbstractCommentService {
+	mock := &MockAbstractCommentService{ctrl: ctrl}
+	mock.recorder = &MockAbstractCommentServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAbstractCommentService) EXPECT() *MockAbstractCommentServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateComment mocks base method.
+func (m *MockAbstractCommentService) CreateComment(userId, noteId int, parentId *int, content string, anchor *model.CommentAnchor) (int, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateComment", userId, noteId, parentId, content, anchor)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// CreateComment indicates an expected call of CreateComment.
+func (mr *MockAbstractCommentServiceMockRecorder) CreateComment(userId, noteId, parentId, content, anchor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComment", reflect.TypeOf((*MockAbstractCommentService)(nil).CreateComment), userId, noteId, parentId, content, anchor)
+}
+
+// DeleteComment mocks base method.
+func (m *MockAbstractCommentService) DeleteComment(userId, commentId int) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteComment", userId, commentId)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// DeleteComment indicates an expected call of DeleteComment.
+func (mr *MockAbstractCommentServiceMockRecorder) DeleteComment(userId, commentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComment", reflect.TypeOf((*MockAbstractCommentService)(nil).DeleteComment), userId, commentId)
+}
+
+// GetComments mocks base method.
+func (m *MockAbstractCommentService) GetComments(userId, noteId, afterId, limit int) (*model.CommentThreadsApi, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComments", userId, noteId, afterId, limit)
+	ret0, _ := ret[0].(*model.CommentThreadsApi)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// GetComments indicates an expected call of GetComments.
+func (mr *MockAbstractCommentServiceMockRecorder) GetComments(userId, noteId, afterId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComments", reflect.TypeOf((*MockAbstractCommentService)(nil).GetComments), userId, noteId, afterId, limit)
+}
+
+// ResolveComment mocks base method.
+func (m *MockAbstractCommentService) ResolveComment(userId, commentId int, isResolved bool) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveComment", userId, commentId, isResolved)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// ResolveComment indicates an expected call of ResolveComment.
+func (mr *MockAbstractCommentServiceMockRecorder) ResolveComment(userId, commentId, isResolved interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveComment", reflect.TypeOf((*MockAbstractCommentService)(nil).ResolveComment), userId, commentId, isResolved)
+}
+
+// UpdateComment mocks base method.
+func (m *MockAbstractCommentService) UpdateComment(userId, commentId int, content string) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComment", userId, commentId, content)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// UpdateComment indicates an expected call of UpdateComment.
+func (mr *MockAbstractCommentServiceMockRecorder) UpdateComment(userId, commentId, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComment", reflect.TypeOf((*MockAbstractCommentService)(nil).UpdateComment), userId, commentId, content)
+}
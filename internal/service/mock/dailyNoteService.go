@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: dailyNoteService.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "Notes/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAbstractDailyNoteService is a mock of AbstractDailyNoteService interface.
+type MockAbstractDailyNoteService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAbstractDailyNoteServiceMockRecorder
+}
+
+// MockAbstractDailyNoteServiceMockRecorder is the mock recorder for MockAbstractDailyNoteService.
+type MockAbstractDailyNoteServiceMockRecorder struct {
+	mock *MockAbstractDailyNoteService
+}
+
+// NewMockAbstractDailyNoteService creates a new mock instance.
+func NewMockAbstractDailyNoteService(ctrl *gomock.Controller) *MockAbstractDailyNoteService {
+	mock := &MockAbstractDailyNoteService{ctrl: ctrl}
+	mock.recorder = &MockAbstractDailyNoteServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAbstractDailyNoteService) EXPECT() *MockAbstractDailyNoteServiceMockRecorder {
+	return m.recorder
+}
+
+// GetDailyNote mocks base method.
+func (m *MockAbstractDailyNoteService) GetDailyNote(userId int, date string) (*model.NoteApi, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDailyNote", userId, date)
+	ret0, _ := ret[0].(*model.NoteApi)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// GetDailyNote indicates an expected call of GetDailyNote.
+func (mr *MockAbstractDailyNoteServiceMockRecorder) GetDailyNote(userId, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailyNote", reflect.TypeOf((*MockAbstractDailyNoteService)(nil).GetDailyNote), userId, date)
+}
+
+// GetSettings mocks base method.
+func (m *MockAbstractDailyNoteService) GetSettings(userId int) (*model.DailyNoteSettingsApi, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSettings", userId)
+	ret0, _ := ret[0].(*model.DailyNoteSettingsApi)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// GetSettings indicates an expected call of GetSettings.
+func (mr *MockAbstractDailyNoteServiceMockRecorder) GetSettings(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSettings", reflect.TypeOf((*MockAbstractDailyNoteService)(nil).GetSettings), userId)
+}
+
+// UpdateSettings mocks base method.
+func (m *MockAbstractDailyNoteService) UpdateSettings(userId int, templateId, folderId *int) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSettings", userId, templateId, folderId)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// UpdateSettings indicates an expected call of UpdateSettings.
+func (mr *MockAbstractDailyNoteServiceMockRecorder) UpdateSettings(userId, templateId, folderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSettings", reflect.TypeOf((*MockAbstractDailyNoteService)(nil).UpdateSettings), userId, templateId, folderId)
+}
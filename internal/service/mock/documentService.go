@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: documentService.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "Notes/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAbstractDocumentService is a mock of AbstractDocumentService interface.
+type MockAbstractDocumentService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAbstractDocumentServiceMockRecorder
+}
+
+// MockAbstractDocumentServiceMockRecorder is the mock recorder for MockAbstractDocumentService.
+type MockAbstractDocumentServiceMockRecorder struct {
+	mock *MockAbstractDocumentService
+}
+
+// NewMockAbstractDocumentService creates a new mock instance.
+func NewMockAbstractDocumentService(ctrl *gomock.Controller) *MockAbstractDocumentService {
+	mock := &MockAbstractDocumentService{ctrl: ctrl}
+	mock.recorder = &MockAbstractDocumentServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAbstractDocumentService) EXPECT() *MockAbstractDocumentServiceMockRecorder {
+	return m.recorder
+}
+
+// ExportFolder mocks base method.
+func (m *MockAbstractDocumentService) ExportFolder(userId, folderId int, format model.DocumentFormat) (*model.DocumentFile, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportFolder", userId, folderId, format)
+	ret0, _ := ret[0].(*model.DocumentFile)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// ExportFolder indicates an expected call of ExportFolder.
+func (mr *MockAbstractDocumentServiceMockRecorder) ExportFolder(userId, folderId, format interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportFolder", reflect.TypeOf((*MockAbstractDocumentService)(nil).ExportFolder), userId, folderId, format)
+}
+
+// ExportNote mocks base method.
+func (m *MockAbstractDocumentService) ExportNote(userId, noteId int, format model.DocumentFormat) (*model.DocumentFile, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportNote", userId, noteId, format)
+	ret0, _ := ret[0].(*model.DocumentFile)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// ExportNote indicates an expected call of ExportNote.
+func (mr *MockAbstractDocumentServiceMockRecorder) ExportNote(userId, noteId, format interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportNote", reflect.TypeOf((*MockAbstractDocumentService)(nil).ExportNote), userId, noteId, format)
+}
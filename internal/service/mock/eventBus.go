@@ -0,0 +1,74 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: eventBus.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "Notes/internal/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAbstractEventBus is a mock of AbstractEventBus interface.
+type MockAbstractEventBus struct {
+	ctrl     *gomock.Controller
+	recorder *MockAbstractEventBusMockRecorder
+}
+
+// MockAbstractEventBusMockRecorder is the mock recorder for MockAbstractEventBus.
+type MockAbstractEventBusMockRecorder struct {
+	mock *MockAbstractEventBus
+}
+
+// NewMockAbstractEventBus creates a new mock instance.
+func NewMockAbstractEventBus(ctrl *gomock.Controller) *MockAbstractEventBus {
+	mock := &MockAbstractEventBus{ctrl: ctrl}
+	mock.recorder = &MockAbstractEventBusMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAbstractEventBus) EXPECT() *MockAbstractEventBusMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockAbstractEventBus) Publish(event *model.Event) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", event)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockAbstractEventBusMockRecorder) Publish(event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockAbstractEventBus)(nil).Publish), event)
+}
+
+// Run mocks base method.
+func (m *MockAbstractEventBus) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockAbstractEventBusMockRecorder) Run(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockAbstractEventBus)(nil).Run), ctx)
+}
+
+// Subscribe mocks base method.
+func (m *MockAbstractEventBus) Subscribe(userId int) *model.EventSubscription {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", userId)
+	ret0, _ := ret[0].(*model.EventSubscription)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockAbstractEventBusMockRecorder) Subscribe(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockAbstractEventBus)(nil).Subscribe), userId)
+}
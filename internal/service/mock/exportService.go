@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: exportService.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "Notes/internal/model"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAbstractExportService is a mock of AbstractExportService interface.
+type MockAbstractExportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAbstractExportServiceMockRecorder
+}
+
+// MockAbstractExportServiceMockRecorder is the mock recorder for MockAbstractExportService.
+type MockAbstractExportServiceMockRecorder struct {
+	mock *MockAbstractExportService
+}
+
+// NewMockAbstractExportService creates a new mock instance.
+func NewMockAbstractExportService(ctrl *gomock.Controller) *MockAbstractExportService {
+	mock := &MockAbstractExportService{ctrl: ctrl}
+	mock.recorder = &MockAbstractExportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAbstractExportService) EXPECT() *MockAbstractExportServiceMockRecorder {
+	return m.recorder
+}
+
+// Export mocks base method.
+func (m *MockAbstractExportService) Export(userId int, format model.ExportFormat, w io.Writer) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", userId, format, w)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockAbstractExportServiceMockRecorder) Export(userId, format, w interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockAbstractExportService)(nil).Export), userId, format, w)
+}
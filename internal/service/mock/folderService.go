@@ -34,6 +34,20 @@ func (m *MockAbstractFolderService) EXPECT() *MockAbstractFolderServiceMockRecor
 	return m.recorder
 }
 
+// ArchiveFolder mocks base method.
+func (m *MockAbstractFolderService) ArchiveFolder(userId, folderId int) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveFolder", userId, folderId)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// ArchiveFolder indicates an expected call of ArchiveFolder.
+func (mr *MockAbstractFolderServiceMockRecorder) ArchiveFolder(userId, folderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveFolder", reflect.TypeOf((*MockAbstractFolderService)(nil).ArchiveFolder), userId, folderId)
+}
+
 // CreateFolder mocks base method.
 func (m *MockAbstractFolderService) CreateFolder(userId int, title string) (int, *model.ApplicationError) {
 	m.ctrl.T.Helper()
@@ -63,6 +77,34 @@ func (mr *MockAbstractFolderServiceMockRecorder) DeleteFolder(userId, folderId i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFolder", reflect.TypeOf((*MockAbstractFolderService)(nil).DeleteFolder), userId, folderId)
 }
 
+// ReorderFolder mocks base method.
+func (m *MockAbstractFolderService) ReorderFolder(userId, folderId int, afterId *int) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderFolder", userId, folderId, afterId)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// ReorderFolder indicates an expected call of ReorderFolder.
+func (mr *MockAbstractFolderServiceMockRecorder) ReorderFolder(userId, folderId, afterId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderFolder", reflect.TypeOf((*MockAbstractFolderService)(nil).ReorderFolder), userId, folderId, afterId)
+}
+
+// UnarchiveFolder mocks base method.
+func (m *MockAbstractFolderService) UnarchiveFolder(userId, folderId int) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnarchiveFolder", userId, folderId)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// UnarchiveFolder indicates an expected call of UnarchiveFolder.
+func (mr *MockAbstractFolderServiceMockRecorder) UnarchiveFolder(userId, folderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnarchiveFolder", reflect.TypeOf((*MockAbstractFolderService)(nil).UnarchiveFolder), userId, folderId)
+}
+
 // UpdateFolder mocks base method.
 func (m *MockAbstractFolderService) UpdateFolder(userId, folderId int, title string) *model.ApplicationError {
 	m.ctrl.T.Helper()
@@ -0,0 +1,145 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: importService.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "Notes/internal/model"
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAbstractImportService is a mock of AbstractImportService interface.
+type MockAbstractImportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAbstractImportServiceMockRecorder
+}
+
+// MockAbstractImportServiceMockRecorder is the mock recorder for MockAbstractImportService.
+type MockAbstractImportServiceMockRecorder struct {
+	mock *MockAbstractImportService
+}
+
+// NewMockAbstractImportService creates a new mock instance.
+func NewMockAbstractImportService(ctrl *gomock.Controller) *MockAbstractImportService {
+	mock := &MockAbstractImportService{ctrl: ctrl}
+	mock.recorder = &MockAbstractImportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAbstractImportService) EXPECT() *MockAbstractImportServiceMockRecorder {
+	return m.recorder
+}
+
+// GetImportJob mocks base method.
+func (m *MockAbstractImportService) GetImportJob(userId, jobId int) (*model.ImportJobApi, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetImportJob", userId, jobId)
+	ret0, _ := ret[0].(*model.ImportJobApi)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// GetImportJob indicates an expected call of GetImportJob.
+func (mr *MockAbstractImportServiceMockRecorder) GetImportJob(userId, jobId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetImportJob", reflect.TypeOf((*MockAbstractImportService)(nil).GetImportJob), userId, jobId)
+}
+
+// ProcessJob mocks base method.
+func (m *MockAbstractImportService) ProcessJob(jobId int) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessJob", jobId)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// ProcessJob indicates an expected call of ProcessJob.
+func (mr *MockAbstractImportServiceMockRecorder) ProcessJob(jobId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessJob", reflect.TypeOf((*MockAbstractImportService)(nil).ProcessJob), jobId)
+}
+
+// Run mocks base method.
+func (m *MockAbstractImportService) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockAbstractImportServiceMockRecorder) Run(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockAbstractImportService)(nil).Run), ctx)
+}
+
+// StartImport mocks base method.
+func (m *MockAbstractImportService) StartImport(userId int, source model.ImportSource, strategy, fileName string, size int64, content io.Reader) (int, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartImport", userId, source, strategy, fileName, size, content)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// StartImport indicates an expected call of StartImport.
+func (mr *MockAbstractImportServiceMockRecorder) StartImport(userId, source, strategy, fileName, size, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartImport", reflect.TypeOf((*MockAbstractImportService)(nil).StartImport), userId, source, strategy, fileName, size, content)
+}
+
+// MockimportContent is a mock of importContent interface.
+type MockimportContent struct {
+	ctrl     *gomock.Controller
+	recorder *MockimportContentMockRecorder
+}
+
+// MockimportContentMockRecorder is the mock recorder for MockimportContent.
+type MockimportContentMockRecorder struct {
+	mock *MockimportContent
+}
+
+// NewMockimportContent creates a new mock instance.
+func NewMockimportContent(ctrl *gomock.Controller) *MockimportContent {
+	mock := &MockimportContent{ctrl: ctrl}
+	mock.recorder = &MockimportContentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockimportContent) EXPECT() *MockimportContentMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockimportContent) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockimportContentMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockimportContent)(nil).Close))
+}
+
+// ReadAt mocks base method.
+func (m *MockimportContent) ReadAt(p []byte, off int64) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadAt", p, off)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadAt indicates an expected call of ReadAt.
+func (mr *MockimportContentMockRecorder) ReadAt(p, off interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAt", reflect.TypeOf((*MockimportContent)(nil).ReadAt), p, off)
+}
@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: linkService.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "Notes/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAbstractLinkService is a mock of AbstractLinkService interface.
+type MockAbstractLinkService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAbstractLinkServiceMockRecorder
+}
+
+// MockAbstractLinkServiceMockRecorder is the mock recorder for MockAbstractLinkService.
+type MockAbstractLinkServiceMockRecorder struct {
+	mock *MockAbstractLinkService
+}
+
+// NewMockAbstractLinkService creates a new mock instance.
+func NewMockAbstractLinkService(ctrl *gomock.Controller) *MockAbstractLinkService {
+	mock := &MockAbstractLinkService{ctrl: ctrl}
+	mock.recorder = &MockAbstractLinkServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAbstractLinkService) EXPECT() *MockAbstractLinkServiceMockRecorder {
+	return m.recorder
+}
+
+// GetBacklinks mocks base method.
+func (m *MockAbstractLinkService) GetBacklinks(userId, noteId int) ([]*model.BacklinkApi, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBacklinks", userId, noteId)
+	ret0, _ := ret[0].([]*model.BacklinkApi)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// GetBacklinks indicates an expected call of GetBacklinks.
+func (mr *MockAbstractLinkServiceMockRecorder) GetBacklinks(userId, noteId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBacklinks", reflect.TypeOf((*MockAbstractLinkService)(nil).GetBacklinks), userId, noteId)
+}
+
+// GetGraph mocks base method.
+func (m *MockAbstractLinkService) GetGraph(userId int) *model.GraphApi {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGraph", userId)
+	ret0, _ := ret[0].(*model.GraphApi)
+	return ret0
+}
+
+// GetGraph indicates an expected call of GetGraph.
+func (mr *MockAbstractLinkServiceMockRecorder) GetGraph(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGraph", reflect.TypeOf((*MockAbstractLinkService)(nil).GetGraph), userId)
+}
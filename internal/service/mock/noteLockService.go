@@ -0,0 +1,77 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: noteLockService.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "Notes/internal/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAbstractNoteLockService is a mock of AbstractNoteLockService interface.
+type MockAbstractNoteLockService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAbstractNoteLockServiceMockRecorder
+}
+
+// MockAbstractNoteLockServiceMockRecorder is the mock recorder for MockAbstractNoteLockService.
+type MockAbstractNoteLockServiceMockRecorder struct {
+	mock *MockAbstractNoteLockService
+}
+
+// NewMockAbstractNoteLockService creates a new mock instance.
+func NewMockAbstractNoteLockService(ctrl *gomock.Controller) *MockAbstractNoteLockService {
+	mock := &MockAbstractNoteLockService{ctrl: ctrl}
+	mock.recorder = &MockAbstractNoteLockServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAbstractNoteLockService) EXPECT() *MockAbstractNoteLockServiceMockRecorder {
+	return m.recorder
+}
+
+// AcquireLock mocks base method.
+func (m *MockAbstractNoteLockService) AcquireLock(userId, noteId int, token string) (*model.NoteLockGrantApi, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireLock", userId, noteId, token)
+	ret0, _ := ret[0].(*model.NoteLockGrantApi)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// AcquireLock indicates an expected call of AcquireLock.
+func (mr *MockAbstractNoteLockServiceMockRecorder) AcquireLock(userId, noteId, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireLock", reflect.TypeOf((*MockAbstractNoteLockService)(nil).AcquireLock), userId, noteId, token)
+}
+
+// ReleaseLock mocks base method.
+func (m *MockAbstractNoteLockService) ReleaseLock(userId, noteId int, token string) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseLock", userId, noteId, token)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// ReleaseLock indicates an expected call of ReleaseLock.
+func (mr *MockAbstractNoteLockServiceMockRecorder) ReleaseLock(userId, noteId, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseLock", reflect.TypeOf((*MockAbstractNoteLockService)(nil).ReleaseLock), userId, noteId, token)
+}
+
+// Run mocks base method.
+func (m *MockAbstractNoteLockService) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockAbstractNoteLockServiceMockRecorder) Run(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockAbstractNoteLockService)(nil).Run), ctx)
+}
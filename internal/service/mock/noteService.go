@@ -35,17 +35,31 @@ func (m *MockAbstractNoteService) EXPECT() *MockAbstractNoteServiceMockRecorder
 }
 
 // AddToFavorites mocks base method.
-func (m *MockAbstractNoteService) AddToFavorites(userId, id int) *model.ApplicationError {
+func (m *MockAbstractNoteService) AddToFavorites(userId, id int, lockToken string) *model.ApplicationError {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddToFavorites", userId, id)
+	ret := m.ctrl.Call(m, "AddToFavorites", userId, id, lockToken)
 	ret0, _ := ret[0].(*model.ApplicationError)
 	return ret0
 }
 
 // AddToFavorites indicates an expected call of AddToFavorites.
-func (mr *MockAbstractNoteServiceMockRecorder) AddToFavorites(userId, id interface{}) *gomock.Call {
+func (mr *MockAbstractNoteServiceMockRecorder) AddToFavorites(userId, id, lockToken interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToFavorites", reflect.TypeOf((*MockAbstractNoteService)(nil).AddToFavorites), userId, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToFavorites", reflect.TypeOf((*MockAbstractNoteService)(nil).AddToFavorites), userId, id, lockToken)
+}
+
+// ArchiveNote mocks base method.
+func (m *MockAbstractNoteService) ArchiveNote(userId, id int, lockToken string) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveNote", userId, id, lockToken)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// ArchiveNote indicates an expected call of ArchiveNote.
+func (mr *MockAbstractNoteServiceMockRecorder) ArchiveNote(userId, id, lockToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveNote", reflect.TypeOf((*MockAbstractNoteService)(nil).ArchiveNote), userId, id, lockToken)
 }
 
 // CreateNote mocks base method.
@@ -64,52 +78,66 @@ func (mr *MockAbstractNoteServiceMockRecorder) CreateNote(userId, title, content
 }
 
 // DeleteFromFavorites mocks base method.
-func (m *MockAbstractNoteService) DeleteFromFavorites(userId, id int) *model.ApplicationError {
+func (m *MockAbstractNoteService) DeleteFromFavorites(userId, id int, lockToken string) *model.ApplicationError {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteFromFavorites", userId, id)
+	ret := m.ctrl.Call(m, "DeleteFromFavorites", userId, id, lockToken)
 	ret0, _ := ret[0].(*model.ApplicationError)
 	return ret0
 }
 
 // DeleteFromFavorites indicates an expected call of DeleteFromFavorites.
-func (mr *MockAbstractNoteServiceMockRecorder) DeleteFromFavorites(userId, id interface{}) *gomock.Call {
+func (mr *MockAbstractNoteServiceMockRecorder) DeleteFromFavorites(userId, id, lockToken interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFromFavorites", reflect.TypeOf((*MockAbstractNoteService)(nil).DeleteFromFavorites), userId, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFromFavorites", reflect.TypeOf((*MockAbstractNoteService)(nil).DeleteFromFavorites), userId, id, lockToken)
 }
 
 // DeleteNote mocks base method.
-func (m *MockAbstractNoteService) DeleteNote(userId, id int) *model.ApplicationError {
+func (m *MockAbstractNoteService) DeleteNote(userId, id int, lockToken string) *model.ApplicationError {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteNote", userId, id)
+	ret := m.ctrl.Call(m, "DeleteNote", userId, id, lockToken)
 	ret0, _ := ret[0].(*model.ApplicationError)
 	return ret0
 }
 
 // DeleteNote indicates an expected call of DeleteNote.
-func (mr *MockAbstractNoteServiceMockRecorder) DeleteNote(userId, id interface{}) *gomock.Call {
+func (mr *MockAbstractNoteServiceMockRecorder) DeleteNote(userId, id, lockToken interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNote", reflect.TypeOf((*MockAbstractNoteService)(nil).DeleteNote), userId, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNote", reflect.TypeOf((*MockAbstractNoteService)(nil).DeleteNote), userId, id, lockToken)
 }
 
 // FindNotesByQueryPhrase mocks base method.
-func (m *MockAbstractNoteService) FindNotesByQueryPhrase(userId int, query string) []*model.Note {
+func (m *MockAbstractNoteService) FindNotesByQueryPhrase(userId int, query string, includeArchived bool) []*model.NoteApi {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindNotesByQueryPhrase", userId, query)
-	ret0, _ := ret[0].([]*model.Note)
+	ret := m.ctrl.Call(m, "FindNotesByQueryPhrase", userId, query, includeArchived)
+	ret0, _ := ret[0].([]*model.NoteApi)
 	return ret0
 }
 
 // FindNotesByQueryPhrase indicates an expected call of FindNotesByQueryPhrase.
-func (mr *MockAbstractNoteServiceMockRecorder) FindNotesByQueryPhrase(userId, query interface{}) *gomock.Call {
+func (mr *MockAbstractNoteServiceMockRecorder) FindNotesByQueryPhrase(userId, query, includeArchived interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindNotesByQueryPhrase", reflect.TypeOf((*MockAbstractNoteService)(nil).FindNotesByQueryPhrase), userId, query, includeArchived)
+}
+
+// GetArchivedNotes mocks base method.
+func (m *MockAbstractNoteService) GetArchivedNotes(userId int) []*model.NoteApi {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArchivedNotes", userId)
+	ret0, _ := ret[0].([]*model.NoteApi)
+	return ret0
+}
+
+// GetArchivedNotes indicates an expected call of GetArchivedNotes.
+func (mr *MockAbstractNoteServiceMockRecorder) GetArchivedNotes(userId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindNotesByQueryPhrase", reflect.TypeOf((*MockAbstractNoteService)(nil).FindNotesByQueryPhrase), userId, query)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchivedNotes", reflect.TypeOf((*MockAbstractNoteService)(nil).GetArchivedNotes), userId)
 }
 
 // GetFavoriteNotes mocks base method.
-func (m *MockAbstractNoteService) GetFavoriteNotes(userId int) []*model.Note {
+func (m *MockAbstractNoteService) GetFavoriteNotes(userId int) []*model.NoteApi {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetFavoriteNotes", userId)
-	ret0, _ := ret[0].([]*model.Note)
+	ret0, _ := ret[0].([]*model.NoteApi)
 	return ret0
 }
 
@@ -120,29 +148,85 @@ func (mr *MockAbstractNoteServiceMockRecorder) GetFavoriteNotes(userId interface
 }
 
 // MoveToFolder mocks base method.
-func (m *MockAbstractNoteService) MoveToFolder(userId, id int, folderId *int) *model.ApplicationError {
+func (m *MockAbstractNoteService) MoveToFolder(userId, id int, folderId *int, lockToken string) *model.ApplicationError {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MoveToFolder", userId, id, folderId)
+	ret := m.ctrl.Call(m, "MoveToFolder", userId, id, folderId, lockToken)
 	ret0, _ := ret[0].(*model.ApplicationError)
 	return ret0
 }
 
 // MoveToFolder indicates an expected call of MoveToFolder.
-func (mr *MockAbstractNoteServiceMockRecorder) MoveToFolder(userId, id, folderId interface{}) *gomock.Call {
+func (mr *MockAbstractNoteServiceMockRecorder) MoveToFolder(userId, id, folderId, lockToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveToFolder", reflect.TypeOf((*MockAbstractNoteService)(nil).MoveToFolder), userId, id, folderId, lockToken)
+}
+
+// PinNote mocks base method.
+func (m *MockAbstractNoteService) PinNote(userId, id int, lockToken string) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PinNote", userId, id, lockToken)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// PinNote indicates an expected call of PinNote.
+func (mr *MockAbstractNoteServiceMockRecorder) PinNote(userId, id, lockToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PinNote", reflect.TypeOf((*MockAbstractNoteService)(nil).PinNote), userId, id, lockToken)
+}
+
+// ReorderNote mocks base method.
+func (m *MockAbstractNoteService) ReorderNote(userId, id int, afterId *int, lockToken string) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderNote", userId, id, afterId, lockToken)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// ReorderNote indicates an expected call of ReorderNote.
+func (mr *MockAbstractNoteServiceMockRecorder) ReorderNote(userId, id, afterId, lockToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderNote", reflect.TypeOf((*MockAbstractNoteService)(nil).ReorderNote), userId, id, afterId, lockToken)
+}
+
+// UnarchiveNote mocks base method.
+func (m *MockAbstractNoteService) UnarchiveNote(userId, id int, lockToken string) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnarchiveNote", userId, id, lockToken)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// UnarchiveNote indicates an expected call of UnarchiveNote.
+func (mr *MockAbstractNoteServiceMockRecorder) UnarchiveNote(userId, id, lockToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnarchiveNote", reflect.TypeOf((*MockAbstractNoteService)(nil).UnarchiveNote), userId, id, lockToken)
+}
+
+// UnpinNote mocks base method.
+func (m *MockAbstractNoteService) UnpinNote(userId, id int, lockToken string) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnpinNote", userId, id, lockToken)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// UnpinNote indicates an expected call of UnpinNote.
+func (mr *MockAbstractNoteServiceMockRecorder) UnpinNote(userId, id, lockToken interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveToFolder", reflect.TypeOf((*MockAbstractNoteService)(nil).MoveToFolder), userId, id, folderId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnpinNote", reflect.TypeOf((*MockAbstractNoteService)(nil).UnpinNote), userId, id, lockToken)
 }
 
 // UpdateNote mocks base method.
-func (m *MockAbstractNoteService) UpdateNote(userId, id int, title, content string, tags *[]string) *model.ApplicationError {
+func (m *MockAbstractNoteService) UpdateNote(userId, id int, title, content string, tags *[]string, rewriteLinks bool, lockToken string) *model.ApplicationError {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateNote", userId, id, title, content, tags)
+	ret := m.ctrl.Call(m, "UpdateNote", userId, id, title, content, tags, rewriteLinks, lockToken)
 	ret0, _ := ret[0].(*model.ApplicationError)
 	return ret0
 }
 
 // UpdateNote indicates an expected call of UpdateNote.
-func (mr *MockAbstractNoteServiceMockRecorder) UpdateNote(userId, id, title, content, tags interface{}) *gomock.Call {
+func (mr *MockAbstractNoteServiceMockRecorder) UpdateNote(userId, id, title, content, tags, rewriteLinks, lockToken interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNote", reflect.TypeOf((*MockAbstractNoteService)(nil).UpdateNote), userId, id, title, content, tags)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNote", reflect.TypeOf((*MockAbstractNoteService)(nil).UpdateNote), userId, id, title, content, tags, rewriteLinks, lockToken)
 }
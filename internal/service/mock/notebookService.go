@@ -35,15 +35,16 @@ func (m *MockAbstractNotebookService) EXPECT() *MockAbstractNotebookServiceMockR
 }
 
 // GetUserNotebook mocks base method.
-func (m *MockAbstractNotebookService) GetUserNotebook(userId int) model.Notebook {
+func (m *MockAbstractNotebookService) GetUserNotebook(userId, page int) (model.Notebook, *model.ApplicationError) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserNotebook", userId)
+	ret := m.ctrl.Call(m, "GetUserNotebook", userId, page)
 	ret0, _ := ret[0].(model.Notebook)
-	return ret0
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
 }
 
 // GetUserNotebook indicates an expected call of GetUserNotebook.
-func (mr *MockAbstractNotebookServiceMockRecorder) GetUserNotebook(userId interface{}) *gomock.Call {
+func (mr *MockAbstractNotebookServiceMockRecorder) GetUserNotebook(userId, page interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserNotebook", reflect.TypeOf((*MockAbstractNotebookService)(nil).GetUserNotebook), userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserNotebook", reflect.TypeOf((*MockAbstractNotebookService)(nil).GetUserNotebook), userId, page)
 }
@@ -0,0 +1,93 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: notificationService.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "Notes/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAbstractNotificationService is a mock of AbstractNotificationService interface.
+type MockAbstractNotificationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAbstractNotificationServiceMockRecorder
+}
+
+// MockAbstractNotificationServiceMockRecorder is the mock recorder for MockAbstractNotificationService.
+type MockAbstractNotificationServiceMockRecorder struct {
+	mock *MockAbstractNotificationService
+}
+
+// NewMockAbstractNotificationService creates a new mock instance.
+func NewMockAbstractNotificationService(ctrl *gomock.Controller) *MockAbstractNotificationService {
+	mock := &MockAbstractNotificationService{ctrl: ctrl}
+	mock.recorder = &MockAbstractNotificationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAbstractNotificationService) EXPECT() *MockAbstractNotificationServiceMockRecorder {
+	return m.recorder
+}
+
+// GetNotifications mocks base method.
+func (m *MockAbstractNotificationService) GetNotifications(userId, beforeId, limit int, unreadOnly bool) (*model.NotificationsApi, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifications", userId, beforeId, limit, unreadOnly)
+	ret0, _ := ret[0].(*model.NotificationsApi)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// GetNotifications indicates an expected call of GetNotifications.
+func (mr *MockAbstractNotificationServiceMockRecorder) GetNotifications(userId, beforeId, limit, unreadOnly interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifications", reflect.TypeOf((*MockAbstractNotificationService)(nil).GetNotifications), userId, beforeId, limit, unreadOnly)
+}
+
+// GetUnreadCount mocks base method.
+func (m *MockAbstractNotificationService) GetUnreadCount(userId int) (*model.UnreadNotificationsApi, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnreadCount", userId)
+	ret0, _ := ret[0].(*model.UnreadNotificationsApi)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// GetUnreadCount indicates an expected call of GetUnreadCount.
+func (mr *MockAbstractNotificationServiceMockRecorder) GetUnreadCount(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnreadCount", reflect.TypeOf((*MockAbstractNotificationService)(nil).GetUnreadCount), userId)
+}
+
+// MarkAllRead mocks base method.
+func (m *MockAbstractNotificationService) MarkAllRead(userId int) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllRead", userId)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// MarkAllRead indicates an expected call of MarkAllRead.
+func (mr *MockAbstractNotificationServiceMockRecorder) MarkAllRead(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllRead", reflect.TypeOf((*MockAbstractNotificationService)(nil).MarkAllRead), userId)
+}
+
+// MarkRead mocks base method.
+func (m *MockAbstractNotificationService) MarkRead(userId, id int) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", userId, id)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockAbstractNotificationServiceMockRecorder) MarkRead(userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockAbstractNotificationService)(nil).MarkRead), userId, id)
+}
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: preferenceService.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "Notes/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAbstractPreferenceService is a mock of AbstractPreferenceService interface.
+type MockAbstractPreferenceService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAbstractPreferenceServiceMockRecorder
+}
+
+// MockAbstractPreferenceServiceMockRecorder is the mock recorder for MockAbstractPreferenceService.
+type MockAbstractPreferenceServiceMockRecorder struct {
+	mock *MockAbstractPreferenceService
+}
+
+// NewMockAbstractPreferenceService creates a new mock instance.
+func NewMockAbstractPreferenceService(ctrl *gomock.Controller) *MockAbstractPreferenceService {
+	mock := &MockAbstractPreferenceService{ctrl: ctrl}
+	mock.recorder = &MockAbstractPreferenceServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAbstractPreferenceService) EXPECT() *MockAbstractPreferenceServiceMockRecorder {
+	return m.recorder
+}
+
+// GetLocale mocks base method.
+func (m *MockAbstractPreferenceService) GetLocale(userId int) (model.Locale, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLocale", userId)
+	ret0, _ := ret[0].(model.Locale)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// GetLocale indicates an expected call of GetLocale.
+func (mr *MockAbstractPreferenceServiceMockRecorder) GetLocale(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLocale", reflect.TypeOf((*MockAbstractPreferenceService)(nil).GetLocale), userId)
+}
+
+// GetPreferences mocks base method.
+func (m *MockAbstractPreferenceService) GetPreferences(userId int) (*model.PreferencesApi, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferences", userId)
+	ret0, _ := ret[0].(*model.PreferencesApi)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockAbstractPreferenceServiceMockRecorder) GetPreferences(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*MockAbstractPreferenceService)(nil).GetPreferences), userId)
+}
+
+// UpdatePreferences mocks base method.
+func (m *MockAbstractPreferenceService) UpdatePreferences(userId int, patch model.PreferencesPatch) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePreferences", userId, patch)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// UpdatePreferences indicates an expected call of UpdatePreferences.
+func (mr *MockAbstractPreferenceServiceMockRecorder) UpdatePreferences(userId, patch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePreferences", reflect.TypeOf((*MockAbstractPreferenceService)(nil).UpdatePreferences), userId, patch)
+}
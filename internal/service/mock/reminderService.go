@@ -0,0 +1,121 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reminderService.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "Notes/internal/model"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAbstractReminderService is a mock of AbstractReminderService interface.
+type MockAbstractReminderService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAbstractReminderServiceMockRecorder
+}
+
+// MockAbstractReminderServiceMockRecorder is the mock recorder for MockAbstractReminderService.
+type MockAbstractReminderServiceMockRecorder struct {
+	mock *MockAbstractReminderService
+}
+
+// NewMockAbstractReminderService creates a new mock instance.
+func NewMockAbstractReminderService(ctrl *gomock.Controller) *MockAbstractReminderService {
+	mock := &MockAbstractReminderService{ctrl: ctrl}
+	mock.recorder = &MockAbstractReminderServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAbstractReminderService) EXPECT() *MockAbstractReminderServiceMockRecorder {
+	return m.recorder
+}
+
+// DeleteReminder mocks base method.
+func (m *MockAbstractReminderService) DeleteReminder(userId, noteId int) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReminder", userId, noteId)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// DeleteReminder indicates an expected call of DeleteReminder.
+func (mr *MockAbstractReminderServiceMockRecorder) DeleteReminder(userId, noteId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReminder", reflect.TypeOf((*MockAbstractReminderService)(nil).DeleteReminder), userId, noteId)
+}
+
+// FireDueReminders mocks base method.
+func (m *MockAbstractReminderService) FireDueReminders(now time.Time) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FireDueReminders", now)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// FireDueReminders indicates an expected call of FireDueReminders.
+func (mr *MockAbstractReminderServiceMockRecorder) FireDueReminders(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FireDueReminders", reflect.TypeOf((*MockAbstractReminderService)(nil).FireDueReminders), now)
+}
+
+// GetReminder mocks base method.
+func (m *MockAbstractReminderService) GetReminder(userId, noteId int) (*model.ReminderApi, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReminder", userId, noteId)
+	ret0, _ := ret[0].(*model.ReminderApi)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// GetReminder indicates an expected call of GetReminder.
+func (mr *MockAbstractReminderServiceMockRecorder) GetReminder(userId, noteId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReminder", reflect.TypeOf((*MockAbstractReminderService)(nil).GetReminder), userId, noteId)
+}
+
+// GetUpcomingReminders mocks base method.
+func (m *MockAbstractReminderService) GetUpcomingReminders(userId int, from, to time.Time) ([]*model.ReminderOccurrenceApi, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUpcomingReminders", userId, from, to)
+	ret0, _ := ret[0].([]*model.ReminderOccurrenceApi)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// GetUpcomingReminders indicates an expected call of GetUpcomingReminders.
+func (mr *MockAbstractReminderServiceMockRecorder) GetUpcomingReminders(userId, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpcomingReminders", reflect.TypeOf((*MockAbstractReminderService)(nil).GetUpcomingReminders), userId, from, to)
+}
+
+// Run mocks base method.
+func (m *MockAbstractReminderService) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockAbstractReminderServiceMockRecorder) Run(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockAbstractReminderService)(nil).Run), ctx)
+}
+
+// SetReminder mocks base method.
+func (m *MockAbstractReminderService) SetReminder(userId, noteId int, settings model.ReminderSettings) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetReminder", userId, noteId, settings)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// SetReminder indicates an expected call of SetReminder.
+func (mr *MockAbstractReminderServiceMockRecorder) SetReminder(userId, noteId, settings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetReminder", reflect.TypeOf((*MockAbstractReminderService)(nil).SetReminder), userId, noteId, settings)
+}
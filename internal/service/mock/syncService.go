@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: syncService.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "Notes/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAbstractSyncService is a mock of AbstractSyncService interface.
+type MockAbstractSyncService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAbstractSyncServiceMockRecorder
+}
+
+// MockAbstractSyncServiceMockRecorder is the mock recorder for MockAbstractSyncService.
+type MockAbstractSyncServiceMockRecorder struct {
+	mock *MockAbstractSyncService
+}
+
+// NewMockAbstractSyncService creates a new mock instance.
+func NewMockAbstractSyncService(ctrl *gomock.Controller) *MockAbstractSyncService {
+	mock := &MockAbstractSyncService{ctrl: ctrl}
+	mock.recorder = &MockAbstractSyncServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAbstractSyncService) EXPECT() *MockAbstractSyncServiceMockRecorder {
+	return m.recorder
+}
+
+// ApplyMutations mocks base method.
+func (m *MockAbstractSyncService) ApplyMutations(userId int, mutations []*model.SyncMutation) ([]*model.SyncResult, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyMutations", userId, mutations)
+	ret0, _ := ret[0].([]*model.SyncResult)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// ApplyMutations indicates an expected call of ApplyMutations.
+func (mr *MockAbstractSyncServiceMockRecorder) ApplyMutations(userId, mutations interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyMutations", reflect.TypeOf((*MockAbstractSyncService)(nil).ApplyMutations), userId, mutations)
+}
+
+// GetChanges mocks base method.
+func (m *MockAbstractSyncService) GetChanges(userId int, token string) (*model.SyncChanges, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChanges", userId, token)
+	ret0, _ := ret[0].(*model.SyncChanges)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// GetChanges indicates an expected call of GetChanges.
+func (mr *MockAbstractSyncServiceMockRecorder) GetChanges(userId, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChanges", reflect.TypeOf((*MockAbstractSyncService)(nil).GetChanges), userId, token)
+}
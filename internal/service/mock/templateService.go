@@ -0,0 +1,122 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: templateService.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "Notes/internal/model"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAbstractTemplateService is a mock of AbstractTemplateService interface.
+type MockAbstractTemplateService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAbstractTemplateServiceMockRecorder
+}
+
+// MockAbstractTemplateServiceMockRecorder is the mock recorder for MockAbstractTemplateService.
+type MockAbstractTemplateServiceMockRecorder struct {
+	mock *MockAbstractTemplateService
+}
+
+// NewMockAbstractTemplateService creates a new mock instance.
+func NewMockAbstractTemplateService(ctrl *gomock.Controller) *MockAbstractTemplateService {
+	mock := &MockAbstractTemplateService{ctrl: ctrl}
+	mock.recorder = &MockAbstractTemplateServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAbstractTemplateService) EXPECT() *MockAbstractTemplateServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateNoteFromTemplate mocks base method.
+func (m *MockAbstractTemplateService) CreateNoteFromTemplate(userId, id int, values map[string]string) (int, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNoteFromTemplate", userId, id, values)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// CreateNoteFromTemplate indicates an expected call of CreateNoteFromTemplate.
+func (mr *MockAbstractTemplateServiceMockRecorder) CreateNoteFromTemplate(userId, id, values interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNoteFromTemplate", reflect.TypeOf((*MockAbstractTemplateService)(nil).CreateNoteFromTemplate), userId, id, values)
+}
+
+// CreateTemplate mocks base method.
+func (m *MockAbstractTemplateService) CreateTemplate(userId int, settings model.TemplateSettings) (int, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTemplate", userId, settings)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// CreateTemplate indicates an expected call of CreateTemplate.
+func (mr *MockAbstractTemplateServiceMockRecorder) CreateTemplate(userId, settings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTemplate", reflect.TypeOf((*MockAbstractTemplateService)(nil).CreateTemplate), userId, settings)
+}
+
+// DeleteTemplate mocks base method.
+func (m *MockAbstractTemplateService) DeleteTemplate(userId, id int) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemplate", userId, id)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// DeleteTemplate indicates an expected call of DeleteTemplate.
+func (mr *MockAbstractTemplateServiceMockRecorder) DeleteTemplate(userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemplate", reflect.TypeOf((*MockAbstractTemplateService)(nil).DeleteTemplate), userId, id)
+}
+
+// GetTemplate mocks base method.
+func (m *MockAbstractTemplateService) GetTemplate(userId, id int) (*model.TemplateApi, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplate", userId, id)
+	ret0, _ := ret[0].(*model.TemplateApi)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// GetTemplate indicates an expected call of GetTemplate.
+func (mr *MockAbstractTemplateServiceMockRecorder) GetTemplate(userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplate", reflect.TypeOf((*MockAbstractTemplateService)(nil).GetTemplate), userId, id)
+}
+
+// GetTemplates mocks base method.
+func (m *MockAbstractTemplateService) GetTemplates(userId int) []*model.TemplateApi {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplates", userId)
+	ret0, _ := ret[0].([]*model.TemplateApi)
+	return ret0
+}
+
+// GetTemplates indicates an expected call of GetTemplates.
+func (mr *MockAbstractTemplateServiceMockRecorder) GetTemplates(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplates", reflect.TypeOf((*MockAbstractTemplateService)(nil).GetTemplates), userId)
+}
+
+// UpdateTemplate mocks base method.
+func (m *MockAbstractTemplateService) UpdateTemplate(userId, id int, settings model.TemplateSettings) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTemplate", userId, id, settings)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// UpdateTemplate indicates an expected call of UpdateTemplate.
+func (mr *MockAbstractTemplateServiceMockRecorder) UpdateTemplate(userId, id, settings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTemplate", reflect.TypeOf((*MockAbstractTemplateService)(nil).UpdateTemplate), userId, id, settings)
+}
@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: thumbnailService.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "Notes/internal/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAbstractThumbnailService is a mock of AbstractThumbnailService interface.
+type MockAbstractThumbnailService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAbstractThumbnailServiceMockRecorder
+}
+
+// MockAbstractThumbnailServiceMockRecorder is the mock recorder for MockAbstractThumbnailService.
+type MockAbstractThumbnailServiceMockRecorder struct {
+	mock *MockAbstractThumbnailService
+}
+
+// NewMockAbstractThumbnailService creates a new mock instance.
+func NewMockAbstractThumbnailService(ctrl *gomock.Controller) *MockAbstractThumbnailService {
+	mock := &MockAbstractThumbnailService{ctrl: ctrl}
+	mock.recorder = &MockAbstractThumbnailServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAbstractThumbnailService) EXPECT() *MockAbstractThumbnailServiceMockRecorder {
+	return m.recorder
+}
+
+// Enqueue mocks base method.
+func (m *MockAbstractThumbnailService) Enqueue(attachmentId int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Enqueue", attachmentId)
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockAbstractThumbnailServiceMockRecorder) Enqueue(attachmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockAbstractThumbnailService)(nil).Enqueue), attachmentId)
+}
+
+// GenerateThumbnails mocks base method.
+func (m *MockAbstractThumbnailService) GenerateThumbnails(attachmentId int) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateThumbnails", attachmentId)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// GenerateThumbnails indicates an expected call of GenerateThumbnails.
+func (mr *MockAbstractThumbnailServiceMockRecorder) GenerateThumbnails(attachmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateThumbnails", reflect.TypeOf((*MockAbstractThumbnailService)(nil).GenerateThumbnails), attachmentId)
+}
+
+// GetDefaultSize mocks base method.
+func (m *MockAbstractThumbnailService) GetDefaultSize() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDefaultSize")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// GetDefaultSize indicates an expected call of GetDefaultSize.
+func (mr *MockAbstractThumbnailServiceMockRecorder) GetDefaultSize() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultSize", reflect.TypeOf((*MockAbstractThumbnailService)(nil).GetDefaultSize))
+}
+
+// IsSizeSupported mocks base method.
+func (m *MockAbstractThumbnailService) IsSizeSupported(size int) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSizeSupported", size)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsSizeSupported indicates an expected call of IsSizeSupported.
+func (mr *MockAbstractThumbnailServiceMockRecorder) IsSizeSupported(size interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSizeSupported", reflect.TypeOf((*MockAbstractThumbnailService)(nil).IsSizeSupported), size)
+}
+
+// Run mocks base method.
+func (m *MockAbstractThumbnailService) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockAbstractThumbnailServiceMockRecorder) Run(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockAbstractThumbnailService)(nil).Run), ctx)
+}
@@ -79,15 +79,15 @@ func (mr *MockAbstractUserServiceMockRecorder) GetUser(userId interface{}) *gomo
 }
 
 // UpdateUser mocks base method.
-func (m *MockAbstractUserService) UpdateUser(id int, login, password, name, surname string) *model.ApplicationError {
+func (m *MockAbstractUserService) UpdateUser(id int, login, password, name, surname, timezone string) *model.ApplicationError {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateUser", id, login, password, name, surname)
+	ret := m.ctrl.Call(m, "UpdateUser", id, login, password, name, surname, timezone)
 	ret0, _ := ret[0].(*model.ApplicationError)
 	return ret0
 }
 
 // UpdateUser indicates an expected call of UpdateUser.
-func (mr *MockAbstractUserServiceMockRecorder) UpdateUser(id, login, password, name, surname interface{}) *gomock.Call {
+func (mr *MockAbstractUserServiceMockRecorder) UpdateUser(id, login, password, name, surname, timezone interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockAbstractUserService)(nil).UpdateUser), id, login, password, name, surname)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockAbstractUserService)(nil).UpdateUser), id, login, password, name, surname, timezone)
 }
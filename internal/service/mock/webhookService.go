@@ -0,0 +1,180 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webhookService.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "Notes/internal/model"
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAbstractWebhookService is a mock of AbstractWebhookService interface.
+type MockAbstractWebhookService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAbstractWebhookServiceMockRecorder
+}
+
+// MockAbstractWebhookServiceMockRecorder is the mock recorder for MockAbstractWebhookService.
+type MockAbstractWebhookServiceMockRecorder struct {
+	mock *MockAbstractWebhookService
+}
+
+// NewMockAbstractWebhookService creates a new mock instance.
+func NewMockAbstractWebhookService(ctrl *gomock.Controller) *MockAbstractWebhookService {
+	mock := &MockAbstractWebhookService{ctrl: ctrl}
+	mock.recorder = &MockAbstractWebhookServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAbstractWebhookService) EXPECT() *MockAbstractWebhookServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateWebhook mocks base method.
+func (m *MockAbstractWebhookService) CreateWebhook(userId int, settings model.WebhookSettings) (int, string, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", userId, settings)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(*model.ApplicationError)
+	return ret0, ret1, ret2
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockAbstractWebhookServiceMockRecorder) CreateWebhook(userId, settings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockAbstractWebhookService)(nil).CreateWebhook), userId, settings)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockAbstractWebhookService) DeleteWebhook(userId, webhookId int) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", userId, webhookId)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockAbstractWebhookServiceMockRecorder) DeleteWebhook(userId, webhookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockAbstractWebhookService)(nil).DeleteWebhook), userId, webhookId)
+}
+
+// DeliverDue mocks base method.
+func (m *MockAbstractWebhookService) DeliverDue(now time.Time) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliverDue", now)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// DeliverDue indicates an expected call of DeliverDue.
+func (mr *MockAbstractWebhookServiceMockRecorder) DeliverDue(now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliverDue", reflect.TypeOf((*MockAbstractWebhookService)(nil).DeliverDue), now)
+}
+
+// GetDeliveries mocks base method.
+func (m *MockAbstractWebhookService) GetDeliveries(userId, webhookId int) ([]*model.WebhookDeliveryApi, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveries", userId, webhookId)
+	ret0, _ := ret[0].([]*model.WebhookDeliveryApi)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// GetDeliveries indicates an expected call of GetDeliveries.
+func (mr *MockAbstractWebhookServiceMockRecorder) GetDeliveries(userId, webhookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveries", reflect.TypeOf((*MockAbstractWebhookService)(nil).GetDeliveries), userId, webhookId)
+}
+
+// GetWebhook mocks base method.
+func (m *MockAbstractWebhookService) GetWebhook(userId, webhookId int) (*model.WebhookApi, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhook", userId, webhookId)
+	ret0, _ := ret[0].(*model.WebhookApi)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// GetWebhook indicates an expected call of GetWebhook.
+func (mr *MockAbstractWebhookServiceMockRecorder) GetWebhook(userId, webhookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhook", reflect.TypeOf((*MockAbstractWebhookService)(nil).GetWebhook), userId, webhookId)
+}
+
+// GetWebhooks mocks base method.
+func (m *MockAbstractWebhookService) GetWebhooks(userId int) []*model.WebhookApi {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooks", userId)
+	ret0, _ := ret[0].([]*model.WebhookApi)
+	return ret0
+}
+
+// GetWebhooks indicates an expected call of GetWebhooks.
+func (mr *MockAbstractWebhookServiceMockRecorder) GetWebhooks(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooks", reflect.TypeOf((*MockAbstractWebhookService)(nil).GetWebhooks), userId)
+}
+
+// RetryDelivery mocks base method.
+func (m *MockAbstractWebhookService) RetryDelivery(userId, webhookId, deliveryId int) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryDelivery", userId, webhookId, deliveryId)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// RetryDelivery indicates an expected call of RetryDelivery.
+func (mr *MockAbstractWebhookServiceMockRecorder) RetryDelivery(userId, webhookId, deliveryId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryDelivery", reflect.TypeOf((*MockAbstractWebhookService)(nil).RetryDelivery), userId, webhookId, deliveryId)
+}
+
+// Run mocks base method.
+func (m *MockAbstractWebhookService) Run(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockAbstractWebhookServiceMockRecorder) Run(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockAbstractWebhookService)(nil).Run), ctx)
+}
+
+// SendTestEvent mocks base method.
+func (m *MockAbstractWebhookService) SendTestEvent(userId, webhookId int) (*model.WebhookDeliveryApi, *model.ApplicationError) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendTestEvent", userId, webhookId)
+	ret0, _ := ret[0].(*model.WebhookDeliveryApi)
+	ret1, _ := ret[1].(*model.ApplicationError)
+	return ret0, ret1
+}
+
+// SendTestEvent indicates an expected call of SendTestEvent.
+func (mr *MockAbstractWebhookServiceMockRecorder) SendTestEvent(userId, webhookId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendTestEvent", reflect.TypeOf((*MockAbstractWebhookService)(nil).SendTestEvent), userId, webhookId)
+}
+
+// UpdateWebhook mocks base method.
+func (m *MockAbstractWebhookService) UpdateWebhook(userId, webhookId int, settings model.WebhookSettings) *model.ApplicationError {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhook", userId, webhookId, settings)
+	ret0, _ := ret[0].(*model.ApplicationError)
+	return ret0
+}
+
+// UpdateWebhook indicates an expected call of UpdateWebhook.
+func (mr *MockAbstractWebhookServiceMockRecorder) UpdateWebhook(userId, webhookId, settings interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhook", reflect.TypeOf((*MockAbstractWebhookService)(nil).UpdateWebhook), userId, webhookId, settings)
+}
@@ -0,0 +1,14 @@
+package service
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/repository"
+)
+
+// getAccessibleNote возвращает заметку, если пользователь может её читать и обсуждать.
+// Через эту функцию проверяют доступ все, кто работает с заметкой по её id без учёта владельца
+// (комментарии и всё, что на них опирается). Сейчас заметка доступна только владельцу;
+// общий доступ к заметкам нужно будет учесть здесь.
+func getAccessibleNote(repo repository.AbstractRepository, userId int, noteId int) (*model.Note, *model.ApplicationError) {
+	return repo.GetNoteById(noteId, userId)
+}
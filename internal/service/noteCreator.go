@@ -0,0 +1,28 @@
+package service
+
+import (
+	"Notes/internal/constants"
+	"Notes/internal/model"
+)
+
+// createNoteInFolder создаёт заметку через NoteService и переносит её в папку folderId.
+// Если заметку нельзя поместить в папку, она удаляется, чтобы не остаться в корне блокнота.
+func createNoteInFolder(noteService AbstractNoteService, userId int, title string, content string, tags []string, folderId *int) (int, *model.ApplicationError) {
+	noteId, err := noteService.CreateNote(userId, title, content, &tags)
+	if err != nil {
+		return constants.FakeId, err
+	}
+
+	if folderId == nil {
+		return noteId, nil
+	}
+
+	if err = noteService.MoveToFolder(userId, noteId, folderId, ""); err != nil {
+		if deleteErr := noteService.DeleteNote(userId, noteId, ""); deleteErr != nil {
+			return constants.FakeId, deleteErr
+		}
+		return constants.FakeId, err
+	}
+
+	return noteId, nil
+}
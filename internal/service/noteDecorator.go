@@ -0,0 +1,31 @@
+package service
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/repository"
+	"time"
+)
+
+// decorateNotes дополняет заметки данными из связанных таблиц: превью вложений, количеством комментариев,
+// держателями аренды и пунктами списков
+func decorateNotes(repo repository.AbstractRepository, userId int, notes []*model.NoteApi) {
+	if len(notes) == 0 {
+		return
+	}
+
+	model.SetPreviews(notes, repo.GetImageAttachmentsByUserId(userId))
+
+	noteIds := make([]int, 0, len(notes))
+	for _, note := range notes {
+		noteIds = append(noteIds, note.Id)
+	}
+	model.SetCommentCounts(notes, repo.GetCommentCounts(noteIds))
+	model.SetNoteLocks(notes, repo.GetNoteLocks(noteIds, time.Now().UTC()))
+
+	for _, note := range notes {
+		if note.Type == model.NoteTypeChecklist {
+			model.SetChecklists(notes, repo.GetChecklistItemsByUserId(userId))
+			return
+		}
+	}
+}
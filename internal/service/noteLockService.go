@@ -0,0 +1,112 @@
+package service
+
+//go:generate mockgen -source=noteLockService.go -destination=mock/noteLockService.go -package=mock
+
+import (
+	"Notes/config"
+	"Notes/internal/model"
+	"Notes/internal/repository"
+	"context"
+	"github.com/google/uuid"
+	"log"
+	"time"
+)
+
+const defaultNoteLockLease = 2 * time.Minute
+const noteLockCleanupInterval = time.Minute
+
+type AbstractNoteLockService interface {
+	AcquireLock(userId int, noteId int, token string) (*model.NoteLockGrantApi, *model.ApplicationError)
+	ReleaseLock(userId int, noteId int, token string) *model.ApplicationError
+	Run(ctx context.Context)
+}
+
+type NoteLockService struct {
+	repo  repository.AbstractRepository
+	lease time.Duration
+}
+
+func NewConcreteNoteLockService(repository repository.AbstractRepository, cfg *config.Config) AbstractNoteLockService {
+	lease := time.Duration(cfg.NoteLocks.LeaseSeconds) * time.Second
+	if lease <= 0 {
+		lease = defaultNoteLockLease
+	}
+
+	return &NoteLockService{repo: repository, lease: lease}
+}
+
+// AcquireLock выдаёт аренду заметки или продлевает её, если token - токен действующей аренды.
+// Если заметку арендовал другой клиент, возвращается ошибка с временем окончания его аренды.
+func (s *NoteLockService) AcquireLock(userId int, noteId int, token string) (*model.NoteLockGrantApi, *model.ApplicationError) {
+	if _, err := getAccessibleNote(s.repo, userId, noteId); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	current, err := s.repo.GetNoteLock(noteId, now)
+	if err != nil && err.Type != model.ErrorTypeNotFound {
+		return nil, err
+	}
+
+	// Токен выдаёт сервер: клиент может только продлить уже полученную аренду
+	if current == nil || token == "" || current.Token != token {
+		token = uuid.New().String()
+	}
+
+	lock, err := s.repo.AcquireNoteLock(model.NewNoteLock(noteId, userId, token, now.Add(s.lease)), now)
+	if err != nil {
+		return nil, err
+	}
+
+	if lock.Token != token {
+		return nil, lock.LockedError()
+	}
+
+	return &model.NoteLockGrantApi{Token: lock.Token, ExpiresAt: lock.ExpiresAt}, nil
+}
+
+// ReleaseLock снимает аренду с токеном token. Снять чужую аренду нельзя, а свободную заметку снимать не нужно.
+func (s *NoteLockService) ReleaseLock(userId int, noteId int, token string) *model.ApplicationError {
+	if _, err := getAccessibleNote(s.repo, userId, noteId); err != nil {
+		return err
+	}
+
+	if err := checkNoteLock(s.repo, noteId, token); err != nil {
+		return err
+	}
+
+	return s.repo.ReleaseNoteLock(noteId, token)
+}
+
+// Run удаляет истёкшие аренды до отмены контекста. Истёкшая аренда и без этого не мешает изменять заметку.
+func (s *NoteLockService) Run(ctx context.Context) {
+	ticker := time.NewTicker(noteLockCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.repo.DeleteExpiredNoteLocks(now.UTC()); err != nil {
+				log.Printf("Не удалось удалить истёкшие блокировки заметок: %v", err)
+			}
+		}
+	}
+}
+
+// checkNoteLock возвращает ошибку, если у заметки есть действующая аренда с другим токеном
+func checkNoteLock(repo repository.AbstractRepository, noteId int, token string) *model.ApplicationError {
+	lock, err := repo.GetNoteLock(noteId, time.Now().UTC())
+	if err != nil {
+		if err.Type == model.ErrorTypeNotFound {
+			return nil
+		}
+		return err
+	}
+
+	if lock.Token != token {
+		return lock.LockedError()
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"Notes/config"
+	"Notes/internal/model"
+	"Notes/internal/repository"
+	mocks "Notes/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"testing"
+	"time"
+)
+
+func initNoteLockServiceTest(t *testing.T) (AbstractNoteLockService, *mocks.MockAbstractRepository) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockAbstractRepository(ctrl)
+
+	return NewConcreteNoteLockService(mockRepository, &config.Config{}), mockRepository
+}
+
+// acquireAs имитирует условную запись аренды: выдаётся lock или остаётся held
+func acquireAs(held *model.NoteLock) func(lock *model.NoteLock, now time.Time) (*model.NoteLock, *model.ApplicationError) {
+	return func(lock *model.NoteLock, now time.Time) (*model.NoteLock, *model.ApplicationError) {
+		if held != nil && held.ExpiresAt.After(now) && held.Token != lock.Token {
+			return held, nil
+		}
+		return lock, nil
+	}
+}
+
+// expectNoNoteLock разрешает проверку аренды при сохранении заметки, когда заметка никем не арендована
+func expectNoNoteLock(repo *mocks.MockAbstractRepository) {
+	repo.EXPECT().GetNoteLock(gomock.Any(), gomock.Any()).Return(nil, repository.EntityNotFoundError).AnyTimes()
+}
+
+func TestConcreteNoteLockService_AcquireLock(t *testing.T) {
+	noteLockService, repo := initNoteLockServiceTest(t)
+	held := model.NewNoteLock(5, 1, "held-token", time.Now().UTC().Add(time.Minute))
+
+	tests := []struct {
+		name      string
+		token     string
+		current   *model.NoteLock
+		wantToken func(token string) bool
+		wantErr   model.ErrorType
+	}{
+		{
+			name:      "free note is locked with a new token",
+			wantToken: func(token string) bool { return token != "" },
+		},
+		{
+			name:      "holder renews the lock with its token",
+			token:     "held-token",
+			current:   held,
+			wantToken: func(token string) bool { return token == "held-token" },
+		},
+		{
+			name:      "unknown token does not become the lock token",
+			token:     "chosen-by-client",
+			wantToken: func(token string) bool { return token != "chosen-by-client" && token != "" },
+		},
+		{
+			name:    "note locked by another client",
+			token:   "other-token",
+			current: held,
+			wantErr: model.ErrorTypeLocked,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo.EXPECT().GetNoteById(5, 1).Return(&model.Note{Id: 5, UserId: 1}, nil)
+			if tt.current != nil {
+				repo.EXPECT().GetNoteLock(5, gomock.Any()).Return(tt.current, nil)
+			} else {
+				repo.EXPECT().GetNoteLock(5, gomock.Any()).Return(nil, repository.EntityNotFoundError)
+			}
+			repo.EXPECT().AcquireNoteLock(gomock.Any(), gomock.Any()).DoAndReturn(acquireAs(tt.current))
+
+			grant, err := noteLockService.AcquireLock(1, 5, tt.token)
+			if tt.wantErr != "" {
+				if err == nil || err.Type != tt.wantErr {
+					t.Errorf("AcquireLock() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("AcquireLock() error = %v", err)
+			}
+			if !tt.wantToken(grant.Token) || !grant.ExpiresAt.After(time.Now()) {
+				t.Errorf("AcquireLock() = %+v", grant)
+			}
+		})
+	}
+}
+
+func TestConcreteNoteLockService_ReleaseLock(t *testing.T) {
+	noteLockService, repo := initNoteLockServiceTest(t)
+	held := model.NewNoteLock(5, 1, "held-token", time.Now().UTC().Add(time.Minute))
+
+	t.Run("holder releases the lock", func(t *testing.T) {
+		repo.EXPECT().GetNoteById(5, 1).Return(&model.Note{Id: 5, UserId: 1}, nil)
+		repo.EXPECT().GetNoteLock(5, gomock.Any()).Return(held, nil)
+		repo.EXPECT().ReleaseNoteLock(5, "held-token").Return(nil)
+
+		if err := noteLockService.ReleaseLock(1, 5, "held-token"); err != nil {
+			t.Errorf("ReleaseLock() error = %v", err)
+		}
+	})
+
+	t.Run("another client cannot release the lock", func(t *testing.T) {
+		repo.EXPECT().GetNoteById(5, 1).Return(&model.Note{Id: 5, UserId: 1}, nil)
+		repo.EXPECT().GetNoteLock(5, gomock.Any()).Return(held, nil)
+
+		if err := noteLockService.ReleaseLock(1, 5, "other-token"); err == nil || err.Type != model.ErrorTypeLocked {
+			t.Errorf("ReleaseLock() error = %v, want a locked error", err)
+		}
+	})
+}
@@ -11,25 +11,40 @@ import (
 
 type AbstractNoteService interface {
 	CreateNote(userId int, title string, content string, tags *[]string) (int, *model.ApplicationError)
-	DeleteNote(userId int, id int) *model.ApplicationError
-	UpdateNote(userId int, id int, title string, content string, tags *[]string) *model.ApplicationError
-	MoveToFolder(userId int, id int, folderId *int) *model.ApplicationError
-	AddToFavorites(userId int, id int) *model.ApplicationError
-	DeleteFromFavorites(userId int, id int) *model.ApplicationError
-	FindNotesByQueryPhrase(userId int, query string) []*model.NoteApi
+	DeleteNote(userId int, id int, lockToken string) *model.ApplicationError
+	UpdateNote(userId int, id int, title string, content string, tags *[]string, rewriteLinks bool, lockToken string) *model.ApplicationError
+	MoveToFolder(userId int, id int, folderId *int, lockToken string) *model.ApplicationError
+	ReorderNote(userId int, id int, afterId *int, lockToken string) *model.ApplicationError
+	PinNote(userId int, id int, lockToken string) *model.ApplicationError
+	UnpinNote(userId int, id int, lockToken string) *model.ApplicationError
+	ArchiveNote(userId int, id int, lockToken string) *model.ApplicationError
+	UnarchiveNote(userId int, id int, lockToken string) *model.ApplicationError
+	AddToFavorites(userId int, id int, lockToken string) *model.ApplicationError
+	DeleteFromFavorites(userId int, id int, lockToken string) *model.ApplicationError
+	FindNotesByQueryPhrase(userId int, query string, includeArchived bool) []*model.NoteApi
 	GetFavoriteNotes(userId int) []*model.NoteApi
+	GetArchivedNotes(userId int) []*model.NoteApi
 }
 
 type NoteService struct {
-	repo repository.AbstractRepository
+	repo   repository.AbstractRepository
+	events AbstractEventBus
+	origin model.AuditOrigin
 }
 
-func NewConcreteNoteService(repository repository.AbstractRepository) AbstractNoteService {
+func NewConcreteNoteService(repository repository.AbstractRepository, events AbstractEventBus) AbstractNoteService {
 	return &NoteService{
-		repo: repository,
+		repo:   repository,
+		events: events,
 	}
 }
 
+func (n *NoteService) withOrigin(origin model.AuditOrigin) any {
+	service := *n
+	service.origin = origin
+	return &service
+}
+
 func (n *NoteService) CreateNote(userId int, title string, content string, tags *[]string) (int, *model.ApplicationError) {
 	newNote, err := model.NewNote(title, content, userId, tags)
 
@@ -37,14 +52,53 @@ func (n *NoteService) CreateNote(userId int, title string, content string, tags
 		return constants.FakeId, err
 	}
 
-	if !n.isTitleFree(newNote.Title, userId, 0) {
-		return constants.FakeId, model.NewApplicationError(model.ErrorTypeValidation, constants.NoteNameIsNotFree, nil)
+	userNotes := n.repo.GetNotesByUserId(userId)
+
+	if !isNoteTitleFree(userNotes, newNote.Title, 0) {
+		return constants.FakeId, model.NewLocalizedError(model.ErrorTypeConflict, model.CodeNoteTitleTaken, nil, nil)
 	}
 
-	return n.repo.SaveEntity(newNote)
+	newNote.FolderId, err = n.getDefaultFolderId(userId)
+	if err != nil {
+		return constants.FakeId, err
+	}
+
+	position, err := getPositionAtEnd(getNoteSiblings(userNotes, newNote.FolderId, false, 0))
+	if err != nil {
+		return constants.FakeId, err
+	}
+
+	newNote.Position = position
+
+	err = commitChanges(n.repo, n.events, n.origin, func(tx repository.AbstractRepository) ([]*model.Event, *model.ApplicationError) {
+		id, err := tx.SaveEntity(newNote)
+		if err != nil {
+			return nil, err
+		}
+
+		newNote.Id = id
+
+		links := model.ParseNoteLinks(id, userId, newNote.Content)
+		if len(links) > 0 {
+			if err = tx.ReplaceNoteLinks(id, links); err != nil {
+				return nil, err
+			}
+		}
+
+		return []*model.Event{model.NewNoteEvent(model.EventNoteCreated, newNote)}, nil
+	})
+
+	if err != nil {
+		return constants.FakeId, err
+	}
+
+	notifyMentions(n.repo, userId, newNote, nil, model.ParseMentions(newNote.Content))
+
+	return newNote.Id, nil
 }
 
-func (n *NoteService) DeleteNote(userId int, id int) *model.ApplicationError {
+// DeleteNote удаляет заметку. Арендованную заметку можно удалить только с токеном аренды lockToken.
+func (n *NoteService) DeleteNote(userId int, id int, lockToken string) *model.ApplicationError {
 	note, err := n.repo.GetNoteById(id, userId)
 
 	if err != nil {
@@ -55,18 +109,30 @@ func (n *NoteService) DeleteNote(userId int, id int) *model.ApplicationError {
 		return err
 	}
 
-	return n.repo.DeleteEntity(note)
+	return commitChanges(n.repo, n.events, n.origin, func(tx repository.AbstractRepository) ([]*model.Event, *model.ApplicationError) {
+		if err := deleteNote(tx, note, lockToken); err != nil {
+			return nil, err
+		}
+
+		return []*model.Event{model.NewNoteEvent(model.EventNoteDeleted, note)}, nil
+	})
 }
 
-func (n *NoteService) UpdateNote(userId int, id int, title string, content string, tags *[]string) *model.ApplicationError {
+// UpdateNote обновляет заметку. При rewriteLinks и смене названия ссылки вида [[Название]]
+// в других заметках пользователя переписываются на новое название. Если заметка арендована на время
+// редактирования, изменить её можно только с токеном аренды lockToken. Если арендована одна из заметок,
+// ссылки в которых нужно переписать, заметка не сохраняется.
+func (n *NoteService) UpdateNote(userId int, id int, title string, content string, tags *[]string, rewriteLinks bool, lockToken string) *model.ApplicationError {
 	noteModel, err := model.NewNote(title, content, userId, tags)
 
 	if err != nil {
 		return err
 	}
 
-	if !n.isTitleFree(title, userId, id) {
-		return model.NewApplicationError(model.ErrorTypeValidation, constants.NoteNameIsNotFree, nil)
+	userNotes := n.repo.GetNotesByUserId(userId)
+
+	if !isNoteTitleFree(userNotes, title, id) {
+		return model.NewLocalizedError(model.ErrorTypeConflict, model.CodeNoteTitleTaken, nil, nil)
 	}
 
 	noteDb, err := n.repo.GetNoteById(id, userId)
@@ -75,15 +141,63 @@ func (n *NoteService) UpdateNote(userId int, id int, title string, content strin
 		return err
 	}
 
+	// Тексты ссылающихся заметок проверяются до сохранения, чтобы переименование не применилось частично
+	referringNotes := make([]*model.Note, 0)
+	if rewriteLinks && noteDb.Title != noteModel.Title {
+		for _, note := range userNotes {
+			if note.Id == noteDb.Id {
+				continue
+			}
+
+			changed, renameErr := note.RenameLinkTarget(noteDb.Title, noteModel.Title)
+			if renameErr != nil {
+				return renameErr
+			}
+
+			if changed {
+				referringNotes = append(referringNotes, note)
+			}
+		}
+	}
+
+	mentions := model.NewMentions(noteDb.Content, noteModel.Content)
+
 	noteDb.Title = noteModel.Title
 	noteDb.Content = noteModel.Content
 	noteDb.Tags = noteModel.Tags
 
-	_, saveErr := n.repo.SaveEntity(noteDb)
-	return saveErr
+	err = commitChanges(n.repo, n.events, n.origin, func(tx repository.AbstractRepository) ([]*model.Event, *model.ApplicationError) {
+		changes := make([]*model.Event, 0, len(referringNotes)+1)
+
+		if err := saveNoteWithLinks(tx, noteDb, lockToken); err != nil {
+			return nil, err
+		}
+		changes = append(changes, model.NewNoteEvent(model.EventNoteUpdated, noteDb))
+
+		// Если ссылающуюся заметку редактирует другой клиент, переименование отменяется целиком,
+		// чтобы не перезаписать его правки и не оставить ссылку на старое название
+		for _, note := range referringNotes {
+			if err := saveNoteWithLinks(tx, note, ""); err != nil {
+				if err.Type == model.ErrorTypeLocked {
+					return nil, model.NewLocalizedError(model.ErrorTypeLocked, model.CodeNoteLinkRewriteLocked, model.ErrorParams{"title": note.Title}, err)
+				}
+				return nil, err
+			}
+			changes = append(changes, model.NewNoteEvent(model.EventNoteUpdated, note))
+		}
+
+		return changes, nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	notifyMentions(n.repo, userId, noteDb, nil, mentions)
+	return nil
 }
 
-func (n *NoteService) MoveToFolder(userId int, id int, folderId *int) *model.ApplicationError {
+func (n *NoteService) MoveToFolder(userId int, id int, folderId *int, lockToken string) *model.ApplicationError {
 	note, err := n.repo.GetNoteById(id, userId)
 
 	if err != nil {
@@ -91,76 +205,172 @@ func (n *NoteService) MoveToFolder(userId int, id int, folderId *int) *model.App
 	}
 
 	if folderId != nil {
-		_, folderErr := n.repo.GetFolderById(*folderId, userId)
+		folder, folderErr := n.repo.GetFolderById(*folderId, userId)
 
 		if folderErr != nil {
 			return folderErr
 		}
+
+		if folder.IsArchived {
+			return model.NewLocalizedError(model.ErrorTypeValidation, model.CodeFolderArchived, nil, nil)
+		}
+	}
+
+	// Закрепление действует в пределах папки, поэтому в новой папке заметка встаёт в конец незакреплённых
+	if !isSameFolder(note.FolderId, folderId) {
+		position, positionErr := getPositionAtEnd(getNoteSiblings(n.repo.GetNotesByUserId(userId), folderId, false, note.Id))
+		if positionErr != nil {
+			return positionErr
+		}
+
+		note.IsPinned = false
+		note.Position = position
 	}
 
 	note.FolderId = folderId
 
-	_, errSave := n.repo.SaveEntity(note)
+	return n.saveNote(note, model.EventNoteMoved, lockToken)
+}
+
+func (n *NoteService) ReorderNote(userId int, id int, afterId *int, lockToken string) *model.ApplicationError {
+	note, err := n.repo.GetNoteById(id, userId)
+
+	if err != nil {
+		return err
+	}
 
-	if errSave != nil {
-		return errSave
+	if afterId != nil && *afterId == id {
+		return nil
 	}
 
-	return nil
+	siblings := getNoteSiblings(n.repo.GetNotesByUserId(userId), note.FolderId, note.IsPinned, note.Id)
+
+	position, err := getPositionAfter(siblings, afterId)
+	if err != nil {
+		return err
+	}
+
+	note.Position = position
+
+	return n.saveNote(note, model.EventNoteUpdated, lockToken)
+}
+
+func (n *NoteService) PinNote(userId int, id int, lockToken string) *model.ApplicationError {
+	return n.setPinned(userId, id, true, lockToken)
+}
+
+func (n *NoteService) UnpinNote(userId int, id int, lockToken string) *model.ApplicationError {
+	return n.setPinned(userId, id, false, lockToken)
 }
 
-func (n *NoteService) AddToFavorites(userId int, id int) *model.ApplicationError {
+func (n *NoteService) setPinned(userId int, id int, isPinned bool, lockToken string) *model.ApplicationError {
 	note, err := n.repo.GetNoteById(id, userId)
 
 	if err != nil {
 		return err
 	}
 
-	note.IsFavorite = true
+	if note.IsPinned == isPinned {
+		return nil
+	}
+
+	note.IsPinned = isPinned
+
+	return n.saveNote(note, model.EventNoteUpdated, lockToken)
+}
 
-	_, errSave := n.repo.SaveEntity(note)
+func (n *NoteService) ArchiveNote(userId int, id int, lockToken string) *model.ApplicationError {
+	note, err := n.repo.GetNoteById(id, userId)
 
-	if errSave != nil {
-		return errSave
+	if err != nil {
+		return err
 	}
 
-	return nil
+	if note.IsArchived {
+		return nil
+	}
+
+	note.IsArchived = true
+
+	return n.saveNote(note, model.EventNoteUpdated, lockToken)
 }
 
-func (n *NoteService) DeleteFromFavorites(userId int, id int) *model.ApplicationError {
+// UnarchiveNote восстанавливает заметку. Если её папка по-прежнему в архиве,
+// заметка переносится в корень блокнота, иначе она осталась бы скрытой.
+func (n *NoteService) UnarchiveNote(userId int, id int, lockToken string) *model.ApplicationError {
 	note, err := n.repo.GetNoteById(id, userId)
 
 	if err != nil {
 		return err
 	}
 
-	note.IsFavorite = false
+	if !note.IsArchived {
+		return nil
+	}
 
-	_, errSave := n.repo.SaveEntity(note)
+	if note.FolderId != nil {
+		folder, folderErr := n.repo.GetFolderById(*note.FolderId, userId)
+		if folderErr != nil && folderErr.Type != model.ErrorTypeNotFound {
+			return folderErr
+		}
+
+		if folderErr != nil || folder.IsArchived {
+			position, positionErr := getPositionAtEnd(getNoteSiblings(n.repo.GetNotesByUserId(userId), nil, false, note.Id))
+			if positionErr != nil {
+				return positionErr
+			}
 
-	if errSave != nil {
-		return errSave
+			note.FolderId = nil
+			note.IsPinned = false
+			note.Position = position
+		}
 	}
 
-	return nil
+	note.IsArchived = false
+
+	return n.saveNote(note, model.EventNoteUpdated, lockToken)
 }
 
-func (n *NoteService) FindNotesByQueryPhrase(userId int, query string) []*model.NoteApi {
-	userNotes := n.repo.GetNotesByUserId(userId)
+func (n *NoteService) AddToFavorites(userId int, id int, lockToken string) *model.ApplicationError {
+	note, err := n.repo.GetNoteById(id, userId)
+
+	if err != nil {
+		return err
+	}
+
+	note.IsFavorite = true
+
+	return n.saveNote(note, model.EventNoteFavorite, lockToken)
+}
 
-	if query == "" {
-		return model.ToNotesApi(userNotes)
+func (n *NoteService) DeleteFromFavorites(userId int, id int, lockToken string) *model.ApplicationError {
+	note, err := n.repo.GetNoteById(id, userId)
+
+	if err != nil {
+		return err
 	}
 
+	note.IsFavorite = false
+
+	return n.saveNote(note, model.EventNoteFavorite, lockToken)
+}
+
+func (n *NoteService) FindNotesByQueryPhrase(userId int, query string, includeArchived bool) []*model.NoteApi {
+	userNotes := n.repo.GetNotesByUserId(userId)
+
 	relatedNotes := make([]*model.NoteApi, 0)
 
 	for _, note := range userNotes {
-		if strings.Contains(note.Title, query) || strings.Contains(note.Content, query) || n.containsTag(note.Tags, query) {
+		if note.IsArchived && !includeArchived {
+			continue
+		}
+
+		if query == "" || strings.Contains(note.Title, query) || strings.Contains(note.Content, query) || n.containsTag(note.Tags, query) {
 			relatedNotes = append(relatedNotes, model.ToNoteApi(note))
 		}
 	}
 
-	return relatedNotes
+	return n.decorate(userId, relatedNotes)
 }
 
 func (n *NoteService) GetFavoriteNotes(userId int) []*model.NoteApi {
@@ -169,12 +379,114 @@ func (n *NoteService) GetFavoriteNotes(userId int) []*model.NoteApi {
 	favoriteNotes := make([]*model.NoteApi, 0)
 
 	for _, note := range userNotes {
-		if note.IsFavorite {
+		if note.IsFavorite && !note.IsArchived {
 			favoriteNotes = append(favoriteNotes, model.ToNoteApi(note))
 		}
 	}
 
-	return favoriteNotes
+	return n.decorate(userId, favoriteNotes)
+}
+
+func (n *NoteService) GetArchivedNotes(userId int) []*model.NoteApi {
+	userNotes := n.repo.GetNotesByUserId(userId)
+
+	archivedNotes := make([]*model.NoteApi, 0)
+
+	for _, note := range userNotes {
+		if note.IsArchived {
+			archivedNotes = append(archivedNotes, model.ToNoteApi(note))
+		}
+	}
+
+	return n.decorate(userId, archivedNotes)
+}
+
+// getDefaultFolderId возвращает папку для новых заметок из настроек пользователя.
+// Если папка удалена или находится в архиве, заметка создаётся в корне блокнота.
+func (n *NoteService) getDefaultFolderId(userId int) (*int, *model.ApplicationError) {
+	preferences, err := getPreferences(n.repo, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if preferences.DefaultFolderId == nil {
+		return nil, nil
+	}
+
+	folder, err := n.repo.GetFolderById(*preferences.DefaultFolderId, userId)
+	if err != nil {
+		if err.Type == model.ErrorTypeNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if folder.IsArchived {
+		return nil, nil
+	}
+
+	return &folder.Id, nil
+}
+
+// saveNote сохраняет заметку вместе с записью в журнале изменений и сообщает клиентам об изменении.
+// Заметка сохраняется целиком, поэтому арендованную заметку можно изменить только с токеном аренды lockToken,
+// иначе прочитанные до аренды название и текст перезаписали бы правки её держателя.
+func (n *NoteService) saveNote(note *model.Note, eventType model.EventType, lockToken string) *model.ApplicationError {
+	return commitChanges(n.repo, n.events, n.origin, func(tx repository.AbstractRepository) ([]*model.Event, *model.ApplicationError) {
+		if err := checkNoteLock(tx, note.Id, lockToken); err != nil {
+			return nil, err
+		}
+
+		if _, err := tx.SaveEntity(note); err != nil {
+			return nil, err
+		}
+
+		return []*model.Event{model.NewNoteEvent(eventType, note)}, nil
+	})
+}
+
+// saveNoteWithLinks сохраняет заметку и пересобирает индекс её исходящих ссылок. Арендованную заметку
+// можно изменить только с токеном аренды lockToken, аренда проверяется в транзакции записи.
+func saveNoteWithLinks(repo repository.AbstractRepository, note *model.Note, lockToken string) *model.ApplicationError {
+	if err := checkNoteLock(repo, note.Id, lockToken); err != nil {
+		return err
+	}
+
+	if note.IsChecklist() {
+		if err := updateChecklist(repo, note); err != nil {
+			return err
+		}
+	} else if _, err := repo.SaveEntity(note); err != nil {
+		return err
+	}
+
+	return repo.ReplaceNoteLinks(note.Id, model.ParseNoteLinks(note.Id, note.UserId, note.Content))
+}
+
+// deleteNote удаляет заметку. Арендованную заметку можно удалить только с токеном аренды lockToken.
+func deleteNote(repo repository.AbstractRepository, note *model.Note, lockToken string) *model.ApplicationError {
+	if err := checkNoteLock(repo, note.Id, lockToken); err != nil {
+		return err
+	}
+
+	return repo.DeleteEntity(note)
+}
+
+func (n *NoteService) decorate(userId int, notes []*model.NoteApi) []*model.NoteApi {
+	decorateNotes(n.repo, userId, notes)
+	return notes
+}
+
+// updateChecklist пересобирает пункты списка из нового текста, сохраняя отметки у неизменившихся пунктов
+func updateChecklist(repo repository.AbstractRepository, note *model.Note) *model.ApplicationError {
+	items, err := model.ChecklistFromContent(note.Id, note.Content, repo.GetChecklistItemsByNoteId(note.Id))
+	if err != nil {
+		return err
+	}
+
+	note.Content = model.RenderChecklist(items)
+
+	return repo.SaveChecklist(note, items)
 }
 
 func (n *NoteService) containsTag(tags []string, tag string) bool {
@@ -191,9 +503,7 @@ func (n *NoteService) containsTag(tags []string, tag string) bool {
 	return false
 }
 
-func (n *NoteService) isTitleFree(title string, userId int, noteId int) bool {
-	notes := n.repo.GetNotesByUserId(userId)
-
+func isNoteTitleFree(notes []*model.Note, title string, noteId int) bool {
 	for _, note := range notes {
 		if note.Title == title && note.Id != noteId {
 			return false
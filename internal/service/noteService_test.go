@@ -3,23 +3,27 @@ package service
 import (
 	"Notes/internal/constants"
 	"Notes/internal/model"
+	"Notes/internal/repository"
 	mocks "Notes/internal/service/mock"
 	"encoding/json"
 	"fmt"
 	"github.com/golang/mock/gomock"
 	"github.com/lib/pq"
+	"strings"
 	"testing"
 	"time"
 )
 
 type noteTestArgs struct {
-	userId   int
-	title    string
-	content  string
-	tags     *[]string
-	noteId   int
-	folderId *int
-	query    string
+	userId          int
+	title           string
+	content         string
+	tags            *[]string
+	noteId          int
+	folderId        *int
+	query           string
+	includeArchived bool
+	rewriteLinks    bool
 }
 
 type noteTestExpect struct {
@@ -33,8 +37,11 @@ func initNoteServiceTest(t *testing.T) (AbstractNoteService, *mocks.MockAbstract
 	defer ctrl.Finish()
 
 	mockRepository := mocks.NewMockAbstractRepository(ctrl)
+	expectChangeLog(mockRepository)
+	mockEventBus := mocks.NewMockAbstractEventBus(ctrl)
+	mockEventBus.EXPECT().Publish(gomock.Any()).AnyTimes()
 
-	return NewConcreteNoteService(mockRepository), mockRepository
+	return NewConcreteNoteService(mockRepository, mockEventBus), mockRepository
 }
 
 func TestConcreteNoteService_CreateNote(t *testing.T) {
@@ -129,7 +136,7 @@ func TestConcreteNoteService_CreateNote(t *testing.T) {
 			},
 			want: noteTestExpect{
 				id:    -1,
-				error: model.NewApplicationError(model.ErrorTypeValidation, constants.NoteNameIsNotFree, nil),
+				error: model.NewLocalizedError(model.ErrorTypeConflict, model.CodeNoteTitleTaken, nil, nil),
 			},
 			wantErr: true,
 		},
@@ -151,8 +158,10 @@ func TestConcreteNoteService_CreateNote(t *testing.T) {
 						UserId:     1,
 						IsFavorite: false,
 						Timestamp:  time.Time{},
+						Position:   "a0",
 					},
 				})
+				repo.EXPECT().GetPreferences(1).Return(nil, model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil))
 				repo.EXPECT().SaveEntity(&model.Note{
 					Title:      "title",
 					Content:    "content",
@@ -161,6 +170,94 @@ func TestConcreteNoteService_CreateNote(t *testing.T) {
 					Tags:       make(pq.StringArray, 0),
 					Timestamp:  time.Time{},
 					FolderId:   nil,
+					Position:   "a1",
+				}).Return(2, nil)
+			},
+			want: noteTestExpect{
+				id:    2,
+				error: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "note with links saved with link index",
+			args: noteTestArgs{
+				userId:  1,
+				title:   "title",
+				content: "see [[title2]], [[note:1|first]] and [[title2]]",
+				tags:    nil,
+			},
+			mock: func() {
+				repo.EXPECT().GetNotesByUserId(1).Return([]*model.Note{})
+				repo.EXPECT().GetPreferences(1).Return(nil, model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil))
+				repo.EXPECT().SaveEntity(&model.Note{
+					Title:    "title",
+					Content:  "see [[title2]], [[note:1|first]] and [[title2]]",
+					UserId:   1,
+					Tags:     make(pq.StringArray, 0),
+					Position: "a0",
+				}).Return(2, nil)
+				repo.EXPECT().ReplaceNoteLinks(2, []*model.NoteLink{
+					{SourceNoteId: 2, UserId: 1, Kind: model.NoteLinkKindTitle, TargetTitle: "title2"},
+					{SourceNoteId: 2, UserId: 1, Kind: model.NoteLinkKindId, TargetNoteId: intPointer(1)},
+				}).Return(nil)
+			},
+			want: noteTestExpect{
+				id:    2,
+				error: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "note saved to default folder from preferences",
+			args: noteTestArgs{
+				userId:  1,
+				title:   "title",
+				content: "content",
+				tags:    nil,
+			},
+			mock: func() {
+				folderId := 5
+				repo.EXPECT().GetNotesByUserId(1).Return([]*model.Note{
+					{Id: 1, Title: "root", Content: "content", UserId: 1, Position: "a0"},
+					{Id: 3, Title: "in folder", Content: "content", UserId: 1, FolderId: &folderId, Position: "a5"},
+				})
+				repo.EXPECT().GetPreferences(1).Return(&model.Preferences{UserId: 1, DefaultFolderId: &folderId}, nil)
+				repo.EXPECT().GetFolderById(5, 1).Return(&model.Folder{Id: 5, UserId: 1}, nil)
+				repo.EXPECT().SaveEntity(&model.Note{
+					Title:    "title",
+					Content:  "content",
+					UserId:   1,
+					Tags:     make(pq.StringArray, 0),
+					FolderId: &folderId,
+					Position: "a6",
+				}).Return(2, nil)
+			},
+			want: noteTestExpect{
+				id:    2,
+				error: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "note saved to root when default folder is archived",
+			args: noteTestArgs{
+				userId:  1,
+				title:   "title",
+				content: "content",
+				tags:    nil,
+			},
+			mock: func() {
+				folderId := 5
+				repo.EXPECT().GetNotesByUserId(1).Return([]*model.Note{})
+				repo.EXPECT().GetPreferences(1).Return(&model.Preferences{UserId: 1, DefaultFolderId: &folderId}, nil)
+				repo.EXPECT().GetFolderById(5, 1).Return(&model.Folder{Id: 5, UserId: 1, IsArchived: true}, nil)
+				repo.EXPECT().SaveEntity(&model.Note{
+					Title:    "title",
+					Content:  "content",
+					UserId:   1,
+					Tags:     make(pq.StringArray, 0),
+					Position: "a0",
 				}).Return(2, nil)
 			},
 			want: noteTestExpect{
@@ -190,8 +287,29 @@ func TestConcreteNoteService_CreateNote(t *testing.T) {
 	}
 }
 
+func TestConcreteNoteService_CreateNote_FieldErrors(t *testing.T) {
+	noteService, _ := initNoteServiceTest(t)
+
+	_, err := noteService.CreateNote(1, "", "", &[]string{"a", "b", "c", "d"})
+	if err == nil {
+		t.Fatalf("NoteService.CreateNote() expected error")
+	}
+
+	want := []model.ErrorCode{model.CodeNoteTitleEmpty, model.CodeNoteContentEmpty, model.CodeNoteTooManyTags}
+	if len(err.Fields) != len(want) {
+		t.Fatalf("NoteService.CreateNote() fields = %d, want %d", len(err.Fields), len(want))
+	}
+
+	for i, field := range err.Fields {
+		if field.Code != want[i] {
+			t.Errorf("NoteService.CreateNote() field %s code = %v, want %v", field.Field, field.Code, want[i])
+		}
+	}
+}
+
 func TestConcreteNoteService_UpdateNote(t *testing.T) {
 	noteService, repo := initNoteServiceTest(t)
+	expectNoNoteLock(repo)
 
 	tests := []struct {
 		name    string
@@ -277,7 +395,7 @@ func TestConcreteNoteService_UpdateNote(t *testing.T) {
 				})
 			},
 			want: noteTestExpect{
-				error: model.NewApplicationError(model.ErrorTypeValidation, constants.NoteNameIsNotFree, nil),
+				error: model.NewLocalizedError(model.ErrorTypeConflict, model.CodeNoteTitleTaken, nil, nil),
 			},
 			wantErr: true,
 		},
@@ -350,12 +468,116 @@ func TestConcreteNoteService_UpdateNote(t *testing.T) {
 					FolderId: nil,
 					Tags:     make(pq.StringArray, 0),
 				}).Return(2, nil)
+				repo.EXPECT().ReplaceNoteLinks(2, []*model.NoteLink{}).Return(nil)
+			},
+			want: noteTestExpect{
+				error: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "checklist note items rebuilt from content",
+			args: noteTestArgs{
+				userId:  1,
+				title:   "title",
+				content: "milk\n\nbread",
+				tags:    nil,
+				noteId:  2,
+			},
+			mock: func() {
+				repo.EXPECT().GetNotesByUserId(1).Return([]*model.Note{})
+				repo.EXPECT().GetNoteById(2, 1).Return(&model.Note{
+					Id:      2,
+					Title:   "title",
+					Content: "bread\neggs",
+					UserId:  1,
+					Type:    model.NoteTypeChecklist,
+				}, nil)
+				repo.EXPECT().GetChecklistItemsByNoteId(2).Return([]*model.ChecklistItem{
+					{Id: 10, NoteId: 2, Text: "bread", IsChecked: true, Position: 0},
+					{Id: 11, NoteId: 2, Text: "eggs", Position: 1},
+				})
+				repo.EXPECT().SaveChecklist(&model.Note{
+					Id:      2,
+					Title:   "title",
+					Content: "milk\nbread",
+					UserId:  1,
+					Tags:    make(pq.StringArray, 0),
+					Type:    model.NoteTypeChecklist,
+				}, []*model.ChecklistItem{
+					{Id: 0, NoteId: 2, Text: "milk", Position: 0},
+					{Id: 10, NoteId: 2, Text: "bread", IsChecked: true, Position: 1},
+				}).Return(nil)
+				repo.EXPECT().ReplaceNoteLinks(2, []*model.NoteLink{}).Return(nil)
+			},
+			want: noteTestExpect{
+				error: nil,
+			},
+			wantErr: false,
+		},
+		{
+			name: "renamed note rewrites links in referring notes",
+			args: noteTestArgs{
+				userId:       1,
+				title:        "new title",
+				content:      "content",
+				tags:         nil,
+				noteId:       2,
+				rewriteLinks: true,
+			},
+			mock: func() {
+				repo.EXPECT().GetNotesByUserId(1).Return([]*model.Note{
+					{Id: 1, Title: "first", Content: "see [[old title|alias]] and [[other]]", UserId: 1},
+					{Id: 2, Title: "old title", Content: "content", UserId: 1},
+					{Id: 3, Title: "third", Content: "no links", UserId: 1},
+				})
+				repo.EXPECT().GetNoteById(2, 1).Return(&model.Note{Id: 2, Title: "old title", Content: "content", UserId: 1}, nil)
+				repo.EXPECT().SaveEntity(&model.Note{
+					Id:      2,
+					Title:   "new title",
+					Content: "content",
+					UserId:  1,
+					Tags:    make(pq.StringArray, 0),
+				}).Return(2, nil)
+				repo.EXPECT().ReplaceNoteLinks(2, []*model.NoteLink{}).Return(nil)
+				repo.EXPECT().SaveEntity(&model.Note{
+					Id:      1,
+					Title:   "first",
+					Content: "see [[new title|alias]] and [[other]]",
+					UserId:  1,
+				}).Return(1, nil)
+				repo.EXPECT().ReplaceNoteLinks(1, []*model.NoteLink{
+					{SourceNoteId: 1, UserId: 1, Kind: model.NoteLinkKindTitle, TargetTitle: "new title"},
+					{SourceNoteId: 1, UserId: 1, Kind: model.NoteLinkKindTitle, TargetTitle: "other"},
+				}).Return(nil)
 			},
 			want: noteTestExpect{
 				error: nil,
 			},
 			wantErr: false,
 		},
+		{
+			name: "rename is rejected when rewritten link exceeds content length",
+			args: noteTestArgs{
+				userId:       1,
+				title:        strings.Repeat("t", constants.MaxContentLength),
+				content:      "content",
+				tags:         nil,
+				noteId:       2,
+				rewriteLinks: true,
+			},
+			mock: func() {
+				repo.EXPECT().GetNotesByUserId(1).Return([]*model.Note{
+					{Id: 1, Title: "first", Content: "see [[old title]]", UserId: 1},
+					{Id: 2, Title: "old title", Content: "content", UserId: 1},
+				})
+				repo.EXPECT().GetNoteById(2, 1).Return(&model.Note{Id: 2, Title: "old title", Content: "content", UserId: 1}, nil)
+			},
+			want: noteTestExpect{
+				error: model.NewApplicationError(model.ErrorTypeValidation, fmt.Sprintf("Длина заметки не может превышать %d символов", constants.MaxContentLength), nil),
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -364,7 +586,7 @@ func TestConcreteNoteService_UpdateNote(t *testing.T) {
 
 			tt.mock()
 
-			err := noteService.UpdateNote(tt.args.userId, tt.args.noteId, tt.args.title, tt.args.content, tt.args.tags)
+			err := noteService.UpdateNote(tt.args.userId, tt.args.noteId, tt.args.title, tt.args.content, tt.args.tags, tt.args.rewriteLinks, "")
 			if (err != nil) != tt.wantErr {
 				t.Errorf("NoteService.CreateNote() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -377,8 +599,107 @@ func TestConcreteNoteService_UpdateNote(t *testing.T) {
 	}
 }
 
+func TestConcreteNoteService_UpdateNote_Locked(t *testing.T) {
+	noteService, repo := initNoteServiceTest(t)
+	lock := model.NewNoteLock(2, 1, "held-token", time.Now().UTC().Add(time.Minute))
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{name: "lock holder updates the note", token: "held-token"},
+		{name: "request without the lock token is rejected", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo.EXPECT().GetNotesByUserId(1).Return([]*model.Note{{Id: 2, Title: "title", Content: "old", UserId: 1}})
+			repo.EXPECT().GetNoteById(2, 1).Return(&model.Note{Id: 2, Title: "title", Content: "old", UserId: 1}, nil)
+			repo.EXPECT().GetNoteLock(2, gomock.Any()).Return(lock, nil)
+			if !tt.wantErr {
+				repo.EXPECT().SaveEntity(gomock.AssignableToTypeOf(&model.Note{})).Return(2, nil)
+				repo.EXPECT().ReplaceNoteLinks(2, gomock.Any()).Return(nil)
+			}
+
+			err := noteService.UpdateNote(1, 2, "title", "new", nil, false, tt.token)
+			if tt.wantErr {
+				if err == nil || model.GetAppropriateApiError(err).Code != 423 {
+					t.Errorf("UpdateNote() error = %v, want 423 Locked", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("UpdateNote() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestConcreteNoteService_PinNote_Locked(t *testing.T) {
+	noteService, repo := initNoteServiceTest(t)
+	lock := model.NewNoteLock(1, 1, "held-token", time.Now().UTC().Add(time.Minute))
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{name: "lock holder pins the note", token: "held-token"},
+		{name: "request without the lock token is rejected", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo.EXPECT().GetNoteById(1, 1).Return(&model.Note{Id: 1, Title: "title", Content: "stale", UserId: 1}, nil)
+			repo.EXPECT().GetNoteLock(1, gomock.Any()).Return(lock, nil)
+			if !tt.wantErr {
+				repo.EXPECT().SaveEntity(gomock.AssignableToTypeOf(&model.Note{})).Return(1, nil)
+			}
+
+			err := noteService.PinNote(1, 1, tt.token)
+			if tt.wantErr {
+				if err == nil || model.GetAppropriateApiError(err).Code != 423 {
+					t.Errorf("PinNote() error = %v, want 423 Locked", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("PinNote() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestConcreteNoteService_UpdateNote_ReferringNoteLocked(t *testing.T) {
+	noteService, repo := initNoteServiceTest(t)
+	lock := model.NewNoteLock(1, 1, "held-token", time.Now().UTC().Add(time.Minute))
+
+	repo.EXPECT().GetNotesByUserId(1).Return([]*model.Note{
+		{Id: 1, Title: "first", Content: "see [[old title]]", UserId: 1},
+		{Id: 2, Title: "old title", Content: "content", UserId: 1},
+	})
+	repo.EXPECT().GetNoteById(2, 1).Return(&model.Note{Id: 2, Title: "old title", Content: "content", UserId: 1}, nil)
+	repo.EXPECT().GetNoteLock(2, gomock.Any()).Return(nil, repository.EntityNotFoundError)
+	repo.EXPECT().SaveEntity(gomock.AssignableToTypeOf(&model.Note{})).Return(2, nil)
+	repo.EXPECT().ReplaceNoteLinks(2, gomock.Any()).Return(nil)
+	repo.EXPECT().GetNoteLock(1, gomock.Any()).Return(lock, nil)
+
+	err := noteService.UpdateNote(1, 2, "new title", "content", nil, true, "")
+	if err == nil || err.Code != model.CodeNoteLinkRewriteLocked {
+		t.Fatalf("UpdateNote() error = %v, want %v", err, model.CodeNoteLinkRewriteLocked)
+	}
+
+	if model.GetAppropriateApiError(err).Code != 423 {
+		t.Errorf("UpdateNote() status = %d, want 423 Locked", model.GetAppropriateApiError(err).Code)
+	}
+}
+
 func TestConcreteNoteService_DeleteNote(t *testing.T) {
 	noteService, repo := initNoteServiceTest(t)
+	expectNoNoteLock(repo)
 
 	tests := []struct {
 		name    string
@@ -438,7 +759,7 @@ func TestConcreteNoteService_DeleteNote(t *testing.T) {
 
 			tt.mock()
 
-			err := noteService.DeleteNote(tt.args.userId, tt.args.noteId)
+			err := noteService.DeleteNote(tt.args.userId, tt.args.noteId, "")
 			if (err != nil) != tt.wantErr {
 				t.Errorf("NoteService.DeleteNote() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -453,6 +774,7 @@ func TestConcreteNoteService_DeleteNote(t *testing.T) {
 
 func TestConcreteNoteService_MoveToFolder(t *testing.T) {
 	noteService, repo := initNoteServiceTest(t)
+	expectNoNoteLock(repo)
 
 	testFolderId := 2
 
@@ -499,6 +821,22 @@ func TestConcreteNoteService_MoveToFolder(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "attempt to move note to archived folder returns error",
+			args: noteTestArgs{
+				userId:   1,
+				noteId:   1,
+				folderId: &testFolderId,
+			},
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(&model.Note{Id: 1, Title: "title", UserId: 1}, nil)
+				repo.EXPECT().GetFolderById(testFolderId, 1).Return(&model.Folder{Id: 2, Title: "folder", UserId: 1, IsArchived: true}, nil)
+			},
+			want: noteTestExpect{
+				error: model.NewApplicationError(model.ErrorTypeValidation, "Папка находится в архиве", nil),
+			},
+			wantErr: true,
+		},
 		{
 			name: "error while save to db returns error",
 			args: noteTestArgs{
@@ -513,12 +851,19 @@ func TestConcreteNoteService_MoveToFolder(t *testing.T) {
 					Content:    "content",
 					UserId:     1,
 					IsFavorite: false,
+					IsPinned:   true,
+					Position:   "a0",
 				}, nil)
 				repo.EXPECT().GetFolderById(testFolderId, 1).Return(&model.Folder{
 					Id:     2,
 					Title:  "folder",
 					UserId: 1,
 				}, nil)
+				repo.EXPECT().GetNotesByUserId(1).Return([]*model.Note{
+					{Id: 1, Title: "title", UserId: 1, IsPinned: true, Position: "a0"},
+					{Id: 2, Title: "title2", UserId: 1, FolderId: &testFolderId, Position: "a5"},
+					{Id: 3, Title: "title3", UserId: 1, FolderId: &testFolderId, IsPinned: true, Position: "a7"},
+				})
 				repo.EXPECT().SaveEntity(&model.Note{
 					Id:         1,
 					Title:      "title",
@@ -526,6 +871,7 @@ func TestConcreteNoteService_MoveToFolder(t *testing.T) {
 					UserId:     1,
 					IsFavorite: false,
 					FolderId:   &testFolderId,
+					Position:   "a6",
 				}).Return(constants.FakeId, model.NewApplicationError(model.ErrorTypeDatabase, " внутрення ошибка БД", nil))
 			},
 			want: noteTestExpect{
@@ -547,12 +893,19 @@ func TestConcreteNoteService_MoveToFolder(t *testing.T) {
 					Content:    "content",
 					UserId:     1,
 					IsFavorite: false,
+					IsPinned:   true,
+					Position:   "a0",
 				}, nil)
 				repo.EXPECT().GetFolderById(testFolderId, 1).Return(&model.Folder{
 					Id:     2,
 					Title:  "folder",
 					UserId: 1,
 				}, nil)
+				repo.EXPECT().GetNotesByUserId(1).Return([]*model.Note{
+					{Id: 1, Title: "title", UserId: 1, IsPinned: true, Position: "a0"},
+					{Id: 2, Title: "title2", UserId: 1, FolderId: &testFolderId, Position: "a5"},
+					{Id: 3, Title: "title3", UserId: 1, FolderId: &testFolderId, IsPinned: true, Position: "a7"},
+				})
 				repo.EXPECT().SaveEntity(&model.Note{
 					Id:         1,
 					Title:      "title",
@@ -560,6 +913,7 @@ func TestConcreteNoteService_MoveToFolder(t *testing.T) {
 					UserId:     1,
 					IsFavorite: false,
 					FolderId:   &testFolderId,
+					Position:   "a6",
 				}).Return(1, nil)
 			},
 			want: noteTestExpect{
@@ -604,7 +958,7 @@ func TestConcreteNoteService_MoveToFolder(t *testing.T) {
 
 			tt.mock()
 
-			err := noteService.MoveToFolder(tt.args.userId, tt.args.noteId, tt.args.folderId)
+			err := noteService.MoveToFolder(tt.args.userId, tt.args.noteId, tt.args.folderId, "")
 			if (err != nil) != tt.wantErr {
 				t.Errorf("NoteService.MoveToFolder() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -619,6 +973,7 @@ func TestConcreteNoteService_MoveToFolder(t *testing.T) {
 
 func TestConcreteNoteService_AddToFavorites(t *testing.T) {
 	noteService, repo := initNoteServiceTest(t)
+	expectNoNoteLock(repo)
 
 	tests := []struct {
 		name    string
@@ -678,7 +1033,7 @@ func TestConcreteNoteService_AddToFavorites(t *testing.T) {
 
 			tt.mock()
 
-			err := noteService.AddToFavorites(tt.args.userId, tt.args.noteId)
+			err := noteService.AddToFavorites(tt.args.userId, tt.args.noteId, "")
 			if (err != nil) != tt.wantErr {
 				t.Errorf("NoteService.AddToFavorites() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -693,6 +1048,7 @@ func TestConcreteNoteService_AddToFavorites(t *testing.T) {
 
 func TestConcreteNoteService_DeleteFromFavorites(t *testing.T) {
 	noteService, repo := initNoteServiceTest(t)
+	expectNoNoteLock(repo)
 
 	tests := []struct {
 		name    string
@@ -752,7 +1108,7 @@ func TestConcreteNoteService_DeleteFromFavorites(t *testing.T) {
 
 			tt.mock()
 
-			err := noteService.DeleteFromFavorites(tt.args.userId, tt.args.noteId)
+			err := noteService.DeleteFromFavorites(tt.args.userId, tt.args.noteId, "")
 			if (err != nil) != tt.wantErr {
 				t.Errorf("NoteService.DeleteFromFavorites() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -806,6 +1162,9 @@ func TestConcreteNoteService_FindNotesByQueryPhrase(t *testing.T) {
 						UserId:  1,
 					},
 				})
+				repo.EXPECT().GetCommentCounts(gomock.Any()).Return(map[int]int{})
+				repo.EXPECT().GetNoteLocks(gomock.Any(), gomock.Any()).Return(map[int]*model.NoteLock{})
+				repo.EXPECT().GetImageAttachmentsByUserId(1).Return([]*model.Attachment{})
 			},
 			args: noteTestArgs{
 				userId: 1,
@@ -873,6 +1232,9 @@ func TestConcreteNoteService_FindNotesByQueryPhrase(t *testing.T) {
 						UserId:  1,
 					},
 				})
+				repo.EXPECT().GetCommentCounts(gomock.Any()).Return(map[int]int{})
+				repo.EXPECT().GetNoteLocks(gomock.Any(), gomock.Any()).Return(map[int]*model.NoteLock{})
+				repo.EXPECT().GetImageAttachmentsByUserId(1).Return([]*model.Attachment{})
 			},
 			args: noteTestArgs{
 				userId: 1,
@@ -907,6 +1269,9 @@ func TestConcreteNoteService_FindNotesByQueryPhrase(t *testing.T) {
 						UserId:  1,
 					},
 				})
+				repo.EXPECT().GetCommentCounts(gomock.Any()).Return(map[int]int{})
+				repo.EXPECT().GetNoteLocks(gomock.Any(), gomock.Any()).Return(map[int]*model.NoteLock{})
+				repo.EXPECT().GetImageAttachmentsByUserId(1).Return([]*model.Attachment{})
 			},
 			args: noteTestArgs{
 				userId: 1,
@@ -944,6 +1309,9 @@ func TestConcreteNoteService_FindNotesByQueryPhrase(t *testing.T) {
 						UserId:  1,
 					},
 				})
+				repo.EXPECT().GetCommentCounts(gomock.Any()).Return(map[int]int{})
+				repo.EXPECT().GetNoteLocks(gomock.Any(), gomock.Any()).Return(map[int]*model.NoteLock{})
+				repo.EXPECT().GetImageAttachmentsByUserId(1).Return([]*model.Attachment{})
 			},
 			args: noteTestArgs{
 				userId: 1,
@@ -964,6 +1332,100 @@ func TestConcreteNoteService_FindNotesByQueryPhrase(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "find checklist notes by item text",
+			mock: func() {
+				repo.EXPECT().GetNotesByUserId(1).Return([]*model.Note{
+					{
+						Id:      1,
+						Title:   "shopping",
+						Content: "milk\nbread",
+						UserId:  1,
+						Type:    model.NoteTypeChecklist,
+					},
+					{
+						Id:      2,
+						Title:   "title2",
+						Content: "content2",
+						UserId:  1,
+					},
+				})
+				repo.EXPECT().GetCommentCounts(gomock.Any()).Return(map[int]int{})
+				repo.EXPECT().GetNoteLocks(gomock.Any(), gomock.Any()).Return(map[int]*model.NoteLock{})
+				repo.EXPECT().GetImageAttachmentsByUserId(1).Return([]*model.Attachment{})
+				repo.EXPECT().GetChecklistItemsByUserId(1).Return([]*model.ChecklistItem{
+					{Id: 10, NoteId: 1, Text: "milk", IsChecked: true, Position: 0},
+					{Id: 11, NoteId: 1, Text: "bread", Position: 1},
+				})
+			},
+			args: noteTestArgs{
+				userId: 1,
+				query:  "bread",
+			},
+			want: noteTestExpect{
+				notes: []*model.NoteApi{
+					{
+						Id:      1,
+						Title:   "shopping",
+						Content: "milk\nbread",
+						UserId:  1,
+						Type:    model.NoteTypeChecklist,
+						Items: []*model.ChecklistItemApi{
+							{Id: 10, Text: "milk", IsChecked: true, Position: 0},
+							{Id: 11, Text: "bread", Position: 1},
+						},
+						Completion: intPointer(50),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "archived notes are excluded by default",
+			mock: func() {
+				repo.EXPECT().GetNotesByUserId(1).Return([]*model.Note{
+					{Id: 1, Title: "report", Content: "content", UserId: 1},
+					{Id: 2, Title: "old report", Content: "content", UserId: 1, IsArchived: true},
+				})
+				repo.EXPECT().GetCommentCounts(gomock.Any()).Return(map[int]int{})
+				repo.EXPECT().GetNoteLocks(gomock.Any(), gomock.Any()).Return(map[int]*model.NoteLock{})
+				repo.EXPECT().GetImageAttachmentsByUserId(1).Return([]*model.Attachment{})
+			},
+			args: noteTestArgs{
+				userId: 1,
+				query:  "report",
+			},
+			want: noteTestExpect{
+				notes: []*model.NoteApi{
+					{Id: 1, Title: "report", Content: "content", UserId: 1},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "archived notes are found when requested",
+			mock: func() {
+				repo.EXPECT().GetNotesByUserId(1).Return([]*model.Note{
+					{Id: 1, Title: "report", Content: "content", UserId: 1},
+					{Id: 2, Title: "old report", Content: "content", UserId: 1, IsArchived: true},
+				})
+				repo.EXPECT().GetCommentCounts(gomock.Any()).Return(map[int]int{})
+				repo.EXPECT().GetNoteLocks(gomock.Any(), gomock.Any()).Return(map[int]*model.NoteLock{})
+				repo.EXPECT().GetImageAttachmentsByUserId(1).Return([]*model.Attachment{})
+			},
+			args: noteTestArgs{
+				userId:          1,
+				query:           "report",
+				includeArchived: true,
+			},
+			want: noteTestExpect{
+				notes: []*model.NoteApi{
+					{Id: 1, Title: "report", Content: "content", UserId: 1},
+					{Id: 2, Title: "old report", Content: "content", UserId: 1, IsArchived: true},
+				},
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -972,7 +1434,7 @@ func TestConcreteNoteService_FindNotesByQueryPhrase(t *testing.T) {
 
 			tt.mock()
 
-			got := noteService.FindNotesByQueryPhrase(tt.args.userId, tt.args.query)
+			got := noteService.FindNotesByQueryPhrase(tt.args.userId, tt.args.query, tt.args.includeArchived)
 			gotJson, _ := json.Marshal(got)
 			expectedJson, _ := json.Marshal(tt.want.notes)
 			if fmt.Sprintf("%v", string(gotJson)) != fmt.Sprintf("%v", string(expectedJson)) {
@@ -1049,6 +1511,9 @@ func TestConcreteNoteService_GetFavoriteNotes(t *testing.T) {
 						UserId:  1,
 					},
 				})
+				repo.EXPECT().GetCommentCounts(gomock.Any()).Return(map[int]int{})
+				repo.EXPECT().GetNoteLocks(gomock.Any(), gomock.Any()).Return(map[int]*model.NoteLock{})
+				repo.EXPECT().GetImageAttachmentsByUserId(1).Return([]*model.Attachment{})
 			},
 			args: noteTestArgs{
 				userId: 1,
@@ -1084,3 +1549,368 @@ func TestConcreteNoteService_GetFavoriteNotes(t *testing.T) {
 		})
 	}
 }
+
+func TestConcreteNoteService_ReorderNote(t *testing.T) {
+	noteService, repo := initNoteServiceTest(t)
+	expectNoNoteLock(repo)
+	folderId := 2
+
+	getFolderNotes := func() []*model.Note {
+		return []*model.Note{
+			{Id: 1, Title: "first", UserId: 1, FolderId: &folderId, Position: "a0"},
+			{Id: 2, Title: "second", UserId: 1, FolderId: &folderId, Position: "a1"},
+			{Id: 3, Title: "third", UserId: 1, FolderId: &folderId, Position: "a2"},
+			{Id: 4, Title: "pinned", UserId: 1, FolderId: &folderId, IsPinned: true, Position: "a0"},
+			{Id: 5, Title: "root", UserId: 1, Position: "a0"},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		args    noteTestArgs
+		afterId *int
+		mock    func()
+		want    noteTestExpect
+		wantErr bool
+	}{
+		{
+			name:    "unexisted note",
+			args:    noteTestArgs{userId: 1, noteId: 10},
+			afterId: nil,
+			mock: func() {
+				repo.EXPECT().GetNoteById(10, 1).Return(nil, model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil))
+			},
+			want: noteTestExpect{
+				error: model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil),
+			},
+			wantErr: true,
+		},
+		{
+			name:    "move to the top of folder",
+			args:    noteTestArgs{userId: 1, noteId: 3},
+			afterId: nil,
+			mock: func() {
+				notes := getFolderNotes()
+				repo.EXPECT().GetNoteById(3, 1).Return(notes[2], nil)
+				repo.EXPECT().GetNotesByUserId(1).Return(notes)
+				repo.EXPECT().SaveEntity(&model.Note{Id: 3, Title: "third", UserId: 1, FolderId: &folderId, Position: "Zz"}).Return(3, nil)
+			},
+			want:    noteTestExpect{},
+			wantErr: false,
+		},
+		{
+			name:    "move between two notes touches only moved note",
+			args:    noteTestArgs{userId: 1, noteId: 3},
+			afterId: intPointer(1),
+			mock: func() {
+				notes := getFolderNotes()
+				repo.EXPECT().GetNoteById(3, 1).Return(notes[2], nil)
+				repo.EXPECT().GetNotesByUserId(1).Return(notes)
+				repo.EXPECT().SaveEntity(&model.Note{Id: 3, Title: "third", UserId: 1, FolderId: &folderId, Position: "a0V"}).Return(3, nil)
+			},
+			want:    noteTestExpect{},
+			wantErr: false,
+		},
+		{
+			name:    "move to the end of folder",
+			args:    noteTestArgs{userId: 1, noteId: 1},
+			afterId: intPointer(3),
+			mock: func() {
+				notes := getFolderNotes()
+				repo.EXPECT().GetNoteById(1, 1).Return(notes[0], nil)
+				repo.EXPECT().GetNotesByUserId(1).Return(notes)
+				repo.EXPECT().SaveEntity(&model.Note{Id: 1, Title: "first", UserId: 1, FolderId: &folderId, Position: "a3"}).Return(1, nil)
+			},
+			want:    noteTestExpect{},
+			wantErr: false,
+		},
+		{
+			name:    "note after itself is not moved",
+			args:    noteTestArgs{userId: 1, noteId: 1},
+			afterId: intPointer(1),
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(getFolderNotes()[0], nil)
+			},
+			want:    noteTestExpect{},
+			wantErr: false,
+		},
+		{
+			name:    "previous note is pinned",
+			args:    noteTestArgs{userId: 1, noteId: 1},
+			afterId: intPointer(4),
+			mock: func() {
+				notes := getFolderNotes()
+				repo.EXPECT().GetNoteById(1, 1).Return(notes[0], nil)
+				repo.EXPECT().GetNotesByUserId(1).Return(notes)
+			},
+			want: noteTestExpect{
+				error: model.NewApplicationError(model.ErrorTypeValidation, "Элемент 4 не найден среди соседних", nil),
+			},
+			wantErr: true,
+		},
+		{
+			name:    "previous note is in another folder",
+			args:    noteTestArgs{userId: 1, noteId: 1},
+			afterId: intPointer(5),
+			mock: func() {
+				notes := getFolderNotes()
+				repo.EXPECT().GetNoteById(1, 1).Return(notes[0], nil)
+				repo.EXPECT().GetNotesByUserId(1).Return(notes)
+			},
+			want: noteTestExpect{
+				error: model.NewApplicationError(model.ErrorTypeValidation, "Элемент 5 не найден среди соседних", nil),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			noteService := noteService
+
+			tt.mock()
+
+			err := noteService.ReorderNote(tt.args.userId, tt.args.noteId, tt.afterId, "")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NoteService.ReorderNote() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil && (err.Type != tt.want.error.Type || err.Message != tt.want.error.Message) {
+				t.Errorf("NoteService.ReorderNote() unexpected error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestConcreteNoteService_PinNote(t *testing.T) {
+	noteService, repo := initNoteServiceTest(t)
+	expectNoNoteLock(repo)
+
+	tests := []struct {
+		name    string
+		args    noteTestArgs
+		mock    func()
+		want    noteTestExpect
+		wantErr bool
+	}{
+		{
+			name: "attempt to pin unexisted note",
+			args: noteTestArgs{userId: 1, noteId: 1},
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(nil, model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil))
+			},
+			want: noteTestExpect{
+				error: model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil),
+			},
+			wantErr: true,
+		},
+		{
+			name: "pin note keeps its position",
+			args: noteTestArgs{userId: 1, noteId: 1},
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(&model.Note{Id: 1, Title: "title", UserId: 1, Position: "a3"}, nil)
+				repo.EXPECT().SaveEntity(&model.Note{Id: 1, Title: "title", UserId: 1, IsPinned: true, Position: "a3"}).Return(1, nil)
+			},
+			want:    noteTestExpect{},
+			wantErr: false,
+		},
+		{
+			name: "already pinned note is not saved",
+			args: noteTestArgs{userId: 1, noteId: 1},
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(&model.Note{Id: 1, Title: "title", UserId: 1, IsPinned: true}, nil)
+			},
+			want:    noteTestExpect{},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			noteService := noteService
+
+			tt.mock()
+
+			err := noteService.PinNote(tt.args.userId, tt.args.noteId, "")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NoteService.PinNote() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil && (err.Type != tt.want.error.Type || err.Message != tt.want.error.Message) {
+				t.Errorf("NoteService.PinNote() unexpected error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestConcreteNoteService_GetArchivedNotes(t *testing.T) {
+	noteService, repo := initNoteServiceTest(t)
+
+	repo.EXPECT().GetNotesByUserId(1).Return([]*model.Note{
+		{Id: 1, Title: "active", Content: "content", UserId: 1, IsFavorite: true},
+		{Id: 2, Title: "archived", Content: "content", UserId: 1, IsFavorite: true, IsArchived: true},
+	}).Times(2)
+	repo.EXPECT().GetCommentCounts(gomock.Any()).Return(map[int]int{}).Times(2)
+	repo.EXPECT().GetNoteLocks(gomock.Any(), gomock.Any()).Return(map[int]*model.NoteLock{}).Times(2)
+	repo.EXPECT().GetImageAttachmentsByUserId(1).Return([]*model.Attachment{}).Times(2)
+
+	archived := noteService.GetArchivedNotes(1)
+	if len(archived) != 1 || archived[0].Id != 2 || !archived[0].IsArchived {
+		t.Errorf("NoteService.GetArchivedNotes() = %v, want only note 2", archived)
+	}
+
+	favorites := noteService.GetFavoriteNotes(1)
+	if len(favorites) != 1 || favorites[0].Id != 1 {
+		t.Errorf("NoteService.GetFavoriteNotes() = %v, want only note 1", favorites)
+	}
+}
+
+func TestConcreteNoteService_UnarchiveNote(t *testing.T) {
+	noteService, repo := initNoteServiceTest(t)
+	expectNoNoteLock(repo)
+	folderId := 2
+
+	tests := []struct {
+		name    string
+		args    noteTestArgs
+		mock    func()
+		want    noteTestExpect
+		wantErr bool
+	}{
+		{
+			name: "attempt to restore unexisted note",
+			args: noteTestArgs{userId: 1, noteId: 1},
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(nil, model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil))
+			},
+			want: noteTestExpect{
+				error: model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil),
+			},
+			wantErr: true,
+		},
+		{
+			name: "note is restored to its folder",
+			args: noteTestArgs{userId: 1, noteId: 1},
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(&model.Note{Id: 1, Title: "title", UserId: 1, FolderId: &folderId, Position: "a3", IsArchived: true}, nil)
+				repo.EXPECT().GetFolderById(folderId, 1).Return(&model.Folder{Id: folderId, Title: "folder", UserId: 1}, nil)
+				repo.EXPECT().SaveEntity(&model.Note{Id: 1, Title: "title", UserId: 1, FolderId: &folderId, Position: "a3"}).Return(1, nil)
+			},
+			want:    noteTestExpect{},
+			wantErr: false,
+		},
+		{
+			name: "note from archived folder is restored to notebook root",
+			args: noteTestArgs{userId: 1, noteId: 1},
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(&model.Note{Id: 1, Title: "title", UserId: 1, FolderId: &folderId, IsPinned: true, Position: "a3", IsArchived: true}, nil)
+				repo.EXPECT().GetFolderById(folderId, 1).Return(&model.Folder{Id: folderId, Title: "folder", UserId: 1, IsArchived: true}, nil)
+				repo.EXPECT().GetNotesByUserId(1).Return([]*model.Note{
+					{Id: 1, Title: "title", UserId: 1, FolderId: &folderId, IsPinned: true, Position: "a3", IsArchived: true},
+					{Id: 2, Title: "root", UserId: 1, Position: "a0"},
+				})
+				repo.EXPECT().SaveEntity(&model.Note{Id: 1, Title: "title", UserId: 1, Position: "a1"}).Return(1, nil)
+			},
+			want:    noteTestExpect{},
+			wantErr: false,
+		},
+		{
+			name: "active note is not saved",
+			args: noteTestArgs{userId: 1, noteId: 1},
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(&model.Note{Id: 1, Title: "title", UserId: 1}, nil)
+			},
+			want:    noteTestExpect{},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			noteService := noteService
+
+			tt.mock()
+
+			err := noteService.UnarchiveNote(tt.args.userId, tt.args.noteId, "")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NoteService.UnarchiveNote() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil && (err.Type != tt.want.error.Type || err.Message != tt.want.error.Message) {
+				t.Errorf("NoteService.UnarchiveNote() unexpected error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func intPointer(value int) *int {
+	return &value
+}
+
+func TestConcreteNoteService_PublishesEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockAbstractRepository(ctrl)
+	expectChangeLog(repo)
+	expectNoNoteLock(repo)
+	eventBus := NewConcreteEventBus(nil)
+	noteService := NewConcreteNoteService(repo, eventBus)
+
+	subscription := eventBus.Subscribe(1)
+	defer subscription.Close()
+
+	folderId := 2
+	repo.EXPECT().GetNoteById(1, 1).DoAndReturn(func(id int, userId int) (*model.Note, *model.ApplicationError) {
+		return &model.Note{Id: 1, Title: "title", UserId: 1}, nil
+	}).Times(4)
+	repo.EXPECT().GetFolderById(2, 1).Return(&model.Folder{Id: 2, UserId: 1}, nil)
+	repo.EXPECT().GetNotesByUserId(1).Return([]*model.Note{})
+	repo.EXPECT().SaveEntity(gomock.Any()).Return(1, nil).Times(2)
+	repo.EXPECT().SaveEntity(gomock.Any()).Return(constants.FakeId, model.NewApplicationError(model.ErrorTypeDatabase, "ошибка", nil))
+	repo.EXPECT().DeleteEntity(gomock.Any()).Return(nil)
+
+	if err := noteService.MoveToFolder(1, 1, &folderId, ""); err != nil {
+		t.Fatalf("MoveToFolder() error = %v", err)
+	}
+	if err := noteService.AddToFavorites(1, 1, ""); err != nil {
+		t.Fatalf("AddToFavorites() error = %v", err)
+	}
+	// Несохранённое изменение не публикуется
+	if err := noteService.DeleteFromFavorites(1, 1, ""); err == nil {
+		t.Fatalf("DeleteFromFavorites() error = nil, want error")
+	}
+	if err := noteService.DeleteNote(1, 1, ""); err != nil {
+		t.Fatalf("DeleteNote() error = %v", err)
+	}
+
+	wants := []struct {
+		eventType  model.EventType
+		folderId   *int
+		isFavorite *bool
+	}{
+		{eventType: model.EventNoteMoved, folderId: &folderId},
+		{eventType: model.EventNoteFavorite, isFavorite: func() *bool { isFavorite := true; return &isFavorite }()},
+		{eventType: model.EventNoteDeleted},
+	}
+
+	for _, want := range wants {
+		event := receiveEvent(t, subscription)
+		if event.Type != want.eventType || *event.NoteId != 1 {
+			t.Errorf("event = %+v, want %s of note 1", event, want.eventType)
+		}
+		if want.folderId != nil && (event.FolderId == nil || *event.FolderId != *want.folderId) {
+			t.Errorf("event %s FolderId = %v, want %d", event.Type, event.FolderId, *want.folderId)
+		}
+		if want.isFavorite != nil && (event.IsFavorite == nil || *event.IsFavorite != *want.isFavorite) {
+			t.Errorf("event %s IsFavorite = %v, want %v", event.Type, event.IsFavorite, *want.isFavorite)
+		}
+	}
+
+	if len(subscription.Events) != 0 {
+		t.Errorf("unexpected events: %d", len(subscription.Events))
+	}
+}
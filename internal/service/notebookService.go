@@ -8,7 +8,7 @@ import (
 )
 
 type AbstractNotebookService interface {
-	GetUserNotebook(userId int) model.Notebook
+	GetUserNotebook(userId int, page int) (model.Notebook, *model.ApplicationError)
 }
 
 type ConcreteNotebookService struct {
@@ -21,17 +21,77 @@ func NewConcreteNotebookService(repository repository.AbstractRepository) Abstra
 	}
 }
 
-func (n *ConcreteNotebookService) GetUserNotebook(userId int) model.Notebook {
-	folders := n.repo.GetFoldersByUserId(userId)
-	notes := n.repo.GetNotesByUserId(userId)
+// GetUserNotebook возвращает блокнот в порядке сортировки из настроек пользователя. Если в настройках
+// задан размер страницы, каждый список заметок (корень и каждая папка) возвращается постранично.
+func (n *ConcreteNotebookService) GetUserNotebook(userId int, page int) (model.Notebook, *model.ApplicationError) {
+	preferences, err := getPreferences(n.repo, userId)
+	if err != nil {
+		return model.Notebook{}, err
+	}
+
+	folders := n.getActiveFolders(n.repo.GetFoldersByUserId(userId))
+	notes := n.getActiveNotes(n.repo.GetNotesByUserId(userId))
 
 	mappedNotes := model.ToNotesApi(notes)
 	mappedFolders := model.ToFoldersApi(folders)
+	model.SortNotesApiBy(mappedNotes, preferences.SortOrder)
+	model.SortFoldersApiBy(mappedFolders, preferences.SortOrder)
 
-	return model.Notebook{
+	notebook := model.Notebook{
 		Folders: n.getFoldersWithNotes(mappedFolders, mappedNotes),
 		Notes:   n.getNotesRelatedToFolder(mappedNotes, nil),
 	}
+
+	if preferences.PageSize > 0 {
+		n.paginate(&notebook, page, preferences.PageSize)
+	}
+
+	decorateNotes(n.repo, userId, n.getNotebookNotes(notebook))
+
+	return notebook, nil
+}
+
+func (n *ConcreteNotebookService) paginate(notebook *model.Notebook, page int, pageSize int) {
+	notebook.Page = page
+	notebook.PageSize = pageSize
+
+	paginateNotes := func(notes []model.NoteApi) []model.NoteApi {
+		start := (page - 1) * pageSize
+		if start >= len(notes) {
+			return []model.NoteApi{}
+		}
+
+		end := start + pageSize
+		if end < len(notes) {
+			notebook.HasMore = true
+		} else {
+			end = len(notes)
+		}
+
+		return notes[start:end]
+	}
+
+	for i := range notebook.Folders {
+		notebook.Folders[i].Notes = paginateNotes(notebook.Folders[i].Notes)
+	}
+	notebook.Notes = paginateNotes(notebook.Notes)
+}
+
+// getNotebookNotes возвращает указатели на все заметки блокнота, чтобы дополнить только попавшие на страницу
+func (n *ConcreteNotebookService) getNotebookNotes(notebook model.Notebook) []*model.NoteApi {
+	notes := make([]*model.NoteApi, 0)
+
+	for i := range notebook.Folders {
+		for j := range notebook.Folders[i].Notes {
+			notes = append(notes, &notebook.Folders[i].Notes[j])
+		}
+	}
+
+	for i := range notebook.Notes {
+		notes = append(notes, &notebook.Notes[i])
+	}
+
+	return notes
 }
 
 func (n *ConcreteNotebookService) getFoldersWithNotes(folders []*model.FolderApi, notes []*model.NoteApi) []model.FolderApi {
@@ -59,3 +119,27 @@ func (n *ConcreteNotebookService) getNotesRelatedToFolder(notes []*model.NoteApi
 
 	return notesRelatedToFolder
 }
+
+// getActiveFolders исключает архивные папки: в блокноте они не показываются
+func (n *ConcreteNotebookService) getActiveFolders(folders []*model.Folder) []*model.Folder {
+	activeFolders := make([]*model.Folder, 0, len(folders))
+	for _, folder := range folders {
+		if !folder.IsArchived {
+			activeFolders = append(activeFolders, folder)
+		}
+	}
+
+	return activeFolders
+}
+
+// getActiveNotes исключает архивные заметки, они доступны через отдельный список архива
+func (n *ConcreteNotebookService) getActiveNotes(notes []*model.Note) []*model.Note {
+	activeNotes := make([]*model.Note, 0, len(notes))
+	for _, note := range notes {
+		if !note.IsArchived {
+			activeNotes = append(activeNotes, note)
+		}
+	}
+
+	return activeNotes
+}
@@ -33,6 +33,7 @@ func TestConcreteNotebookService_GetUserNotebook(t *testing.T) {
 		{
 			name: "no notes and folders",
 			mock: func() {
+				repo.EXPECT().GetPreferences(1).Return(nil, model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil))
 				repo.EXPECT().GetFoldersByUserId(1).Return([]*model.Folder{})
 				repo.EXPECT().GetNotesByUserId(1).Return([]*model.Note{})
 			},
@@ -46,6 +47,7 @@ func TestConcreteNotebookService_GetUserNotebook(t *testing.T) {
 		{
 			name: "no notes and one folder",
 			mock: func() {
+				repo.EXPECT().GetPreferences(1).Return(nil, model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil))
 				repo.EXPECT().GetFoldersByUserId(1).Return([]*model.Folder{
 					{
 						Id:        1,
@@ -75,6 +77,7 @@ func TestConcreteNotebookService_GetUserNotebook(t *testing.T) {
 		{
 			name: "one folder and one note without folder",
 			mock: func() {
+				repo.EXPECT().GetPreferences(1).Return(nil, model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil))
 				repo.EXPECT().GetFoldersByUserId(1).Return([]*model.Folder{
 					{
 						Id:        1,
@@ -95,6 +98,9 @@ func TestConcreteNotebookService_GetUserNotebook(t *testing.T) {
 						Tags:       nil,
 					},
 				})
+				repo.EXPECT().GetCommentCounts(gomock.Any()).Return(map[int]int{})
+				repo.EXPECT().GetNoteLocks(gomock.Any(), gomock.Any()).Return(map[int]*model.NoteLock{})
+				repo.EXPECT().GetImageAttachmentsByUserId(1).Return([]*model.Attachment{})
 			},
 			args: 1,
 			want: model.Notebook{
@@ -124,6 +130,7 @@ func TestConcreteNotebookService_GetUserNotebook(t *testing.T) {
 		{
 			name: "one folder and one note in folder",
 			mock: func() {
+				repo.EXPECT().GetPreferences(1).Return(nil, model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil))
 				repo.EXPECT().GetFoldersByUserId(1).Return([]*model.Folder{
 					{
 						Id:        1,
@@ -148,6 +155,9 @@ func TestConcreteNotebookService_GetUserNotebook(t *testing.T) {
 					note,
 				})
 
+				repo.EXPECT().GetCommentCounts(gomock.Any()).Return(map[int]int{})
+				repo.EXPECT().GetNoteLocks(gomock.Any(), gomock.Any()).Return(map[int]*model.NoteLock{})
+				repo.EXPECT().GetImageAttachmentsByUserId(1).Return([]*model.Attachment{})
 			},
 			args: 1,
 			want: model.Notebook{
@@ -174,6 +184,150 @@ func TestConcreteNotebookService_GetUserNotebook(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "note with image attachment has preview",
+			mock: func() {
+				repo.EXPECT().GetPreferences(1).Return(nil, model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil))
+				repo.EXPECT().GetFoldersByUserId(1).Return([]*model.Folder{})
+				repo.EXPECT().GetNotesByUserId(1).Return([]*model.Note{
+					{
+						Id:        1,
+						Title:     "title",
+						Content:   "content",
+						UserId:    1,
+						Timestamp: fixedTime,
+					},
+					{
+						Id:        2,
+						Title:     "title2",
+						Content:   "content",
+						UserId:    1,
+						Timestamp: fixedTime,
+					},
+				})
+				repo.EXPECT().GetCommentCounts(gomock.Any()).Return(map[int]int{})
+				repo.EXPECT().GetNoteLocks(gomock.Any(), gomock.Any()).Return(map[int]*model.NoteLock{})
+				repo.EXPECT().GetImageAttachmentsByUserId(1).Return([]*model.Attachment{
+					{Id: 3, NoteId: 1, ThumbnailStatus: model.ThumbnailStatusPending},
+					{Id: 4, NoteId: 1, ThumbnailStatus: model.ThumbnailStatusReady},
+					{Id: 5, NoteId: 1, ThumbnailStatus: model.ThumbnailStatusReady},
+				})
+			},
+			args: 1,
+			want: model.Notebook{
+				Folders: []model.FolderApi{},
+				Notes: []model.NoteApi{
+					{
+						Id:           1,
+						Title:        "title",
+						Content:      "content",
+						UserId:       1,
+						Timestamp:    fixedTime,
+						ThumbnailUrl: stringPointer("/api/notes/1/attachments/4/thumbnail"),
+					},
+					{
+						Id:        2,
+						Title:     "title2",
+						Content:   "content",
+						UserId:    1,
+						Timestamp: fixedTime,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "pinned notes first and manual order of notes and folders",
+			mock: func() {
+				repo.EXPECT().GetPreferences(1).Return(nil, model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil))
+				repo.EXPECT().GetFoldersByUserId(1).Return([]*model.Folder{
+					{Id: 1, Title: "first", Timestamp: fixedTime, UserId: 1, Position: "a1"},
+					{Id: 2, Title: "second", Timestamp: fixedTime, UserId: 1, Position: "a0"},
+				})
+				folderId := 1
+				repo.EXPECT().GetNotesByUserId(1).Return([]*model.Note{
+					{Id: 1, Title: "1", Content: "content", UserId: 1, Timestamp: fixedTime, FolderId: &folderId, Position: "a0"},
+					{Id: 2, Title: "2", Content: "content", UserId: 1, Timestamp: fixedTime, FolderId: &folderId, Position: "a0V"},
+					{Id: 3, Title: "3", Content: "content", UserId: 1, Timestamp: fixedTime, FolderId: &folderId, Position: "a1", IsPinned: true},
+					{Id: 4, Title: "4", Content: "content", UserId: 1, Timestamp: fixedTime, Position: "a1"},
+					{Id: 5, Title: "5", Content: "content", UserId: 1, Timestamp: fixedTime, Position: "Zz"},
+				})
+				repo.EXPECT().GetCommentCounts(gomock.Any()).Return(map[int]int{})
+				repo.EXPECT().GetNoteLocks(gomock.Any(), gomock.Any()).Return(map[int]*model.NoteLock{})
+				repo.EXPECT().GetImageAttachmentsByUserId(1).Return([]*model.Attachment{})
+			},
+			args: 1,
+			want: model.Notebook{
+				Folders: []model.FolderApi{
+					{Id: 2, Title: "second", Timestamp: fixedTime, UserId: 1, Notes: []model.NoteApi{}, Position: "a0"},
+					{
+						Id:        1,
+						Title:     "first",
+						Timestamp: fixedTime,
+						UserId:    1,
+						Position:  "a1",
+						Notes: []model.NoteApi{
+							{Id: 3, Title: "3", Content: "content", UserId: 1, Timestamp: fixedTime, IsPinned: true, Position: "a1"},
+							{Id: 1, Title: "1", Content: "content", UserId: 1, Timestamp: fixedTime, Position: "a0"},
+							{Id: 2, Title: "2", Content: "content", UserId: 1, Timestamp: fixedTime, Position: "a0V"},
+						},
+					},
+				},
+				Notes: []model.NoteApi{
+					{Id: 5, Title: "5", Content: "content", UserId: 1, Timestamp: fixedTime, Position: "Zz"},
+					{Id: 4, Title: "4", Content: "content", UserId: 1, Timestamp: fixedTime, Position: "a1"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "archived notes and folders are hidden",
+			mock: func() {
+				repo.EXPECT().GetPreferences(1).Return(nil, model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil))
+				repo.EXPECT().GetFoldersByUserId(1).Return([]*model.Folder{
+					{Id: 1, Title: "archived", Timestamp: fixedTime, UserId: 1, IsArchived: true},
+				})
+				folderId := 1
+				repo.EXPECT().GetNotesByUserId(1).Return([]*model.Note{
+					{Id: 1, Title: "1", Content: "content", UserId: 1, Timestamp: fixedTime, FolderId: &folderId, IsArchived: true},
+					{Id: 2, Title: "2", Content: "content", UserId: 1, Timestamp: fixedTime, IsArchived: true},
+				})
+			},
+			args: 1,
+			want: model.Notebook{
+				Folders: []model.FolderApi{},
+				Notes:   []model.NoteApi{},
+			},
+			wantErr: false,
+		},
+		{
+			name: "notes sorted by title and paginated by preferences",
+			mock: func() {
+				repo.EXPECT().GetPreferences(1).Return(&model.Preferences{UserId: 1, SortOrder: model.SortOrderTitle, PageSize: 2}, nil)
+				repo.EXPECT().GetFoldersByUserId(1).Return([]*model.Folder{})
+				repo.EXPECT().GetNotesByUserId(1).Return([]*model.Note{
+					{Id: 1, Title: "c", Content: "content", UserId: 1, Timestamp: fixedTime},
+					{Id: 2, Title: "a", Content: "content", UserId: 1, Timestamp: fixedTime},
+					{Id: 3, Title: "d", Content: "content", UserId: 1, Timestamp: fixedTime, IsPinned: true},
+					{Id: 4, Title: "b", Content: "content", UserId: 1, Timestamp: fixedTime},
+				})
+				repo.EXPECT().GetCommentCounts(gomock.Any()).Return(map[int]int{})
+				repo.EXPECT().GetNoteLocks(gomock.Any(), gomock.Any()).Return(map[int]*model.NoteLock{})
+				repo.EXPECT().GetImageAttachmentsByUserId(1).Return([]*model.Attachment{})
+			},
+			args: 1,
+			want: model.Notebook{
+				Folders: []model.FolderApi{},
+				Notes: []model.NoteApi{
+					{Id: 3, Title: "d", Content: "content", UserId: 1, Timestamp: fixedTime, IsPinned: true},
+					{Id: 2, Title: "a", Content: "content", UserId: 1, Timestamp: fixedTime},
+				},
+				Page:     1,
+				PageSize: 2,
+				HasMore:  true,
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -182,7 +336,11 @@ func TestConcreteNotebookService_GetUserNotebook(t *testing.T) {
 
 			tt.mock()
 
-			got := notebookService.GetUserNotebook(tt.args)
+			got, err := notebookService.GetUserNotebook(tt.args, 1)
+			if err != nil {
+				t.Errorf("notebookService.GetUserNotebook() unexpected error = %v", err)
+				return
+			}
 
 			gotJson, _ := json.Marshal(got)
 			expectedJson, _ := json.Marshal(tt.want)
@@ -192,3 +350,7 @@ func TestConcreteNotebookService_GetUserNotebook(t *testing.T) {
 		})
 	}
 }
+
+func stringPointer(value string) *string {
+	return &value
+}
@@ -0,0 +1,61 @@
+package service
+
+//go:generate mockgen -source=notificationService.go -destination=mock/notificationService.go -package=mock
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/repository"
+)
+
+const defaultNotificationsLimit = 20
+const maxNotificationsLimit = 100
+
+type AbstractNotificationService interface {
+	GetNotifications(userId int, beforeId int, limit int, unreadOnly bool) (*model.NotificationsApi, *model.ApplicationError)
+	GetUnreadCount(userId int) (*model.UnreadNotificationsApi, *model.ApplicationError)
+	MarkRead(userId int, id int) *model.ApplicationError
+	MarkAllRead(userId int) *model.ApplicationError
+}
+
+type NotificationService struct {
+	repo repository.AbstractRepository
+}
+
+func NewConcreteNotificationService(repository repository.AbstractRepository) AbstractNotificationService {
+	return &NotificationService{repo: repository}
+}
+
+// GetNotifications возвращает входящие пользователя, новые первыми, не больше limit после beforeId
+func (s *NotificationService) GetNotifications(userId int, beforeId int, limit int, unreadOnly bool) (*model.NotificationsApi, *model.ApplicationError) {
+	if limit <= 0 {
+		limit = defaultNotificationsLimit
+	}
+	if limit > maxNotificationsLimit {
+		limit = maxNotificationsLimit
+	}
+
+	// Лишнее уведомление показывает, что есть следующая страница
+	notifications := s.repo.GetNotifications(userId, beforeId, limit+1, unreadOnly)
+
+	result := &model.NotificationsApi{}
+	if len(notifications) > limit {
+		notifications = notifications[:limit]
+		nextBeforeId := notifications[limit-1].Id
+		result.NextBeforeId = &nextBeforeId
+	}
+
+	result.Notifications = model.ToNotificationsApi(notifications)
+	return result, nil
+}
+
+func (s *NotificationService) GetUnreadCount(userId int) (*model.UnreadNotificationsApi, *model.ApplicationError) {
+	return &model.UnreadNotificationsApi{Count: s.repo.CountUnreadNotifications(userId)}, nil
+}
+
+func (s *NotificationService) MarkRead(userId int, id int) *model.ApplicationError {
+	return s.repo.MarkNotificationRead(id, userId)
+}
+
+func (s *NotificationService) MarkAllRead(userId int) *model.ApplicationError {
+	return s.repo.MarkAllNotificationsRead(userId)
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"Notes/internal/model"
+	mocks "Notes/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"testing"
+)
+
+func initNotificationServiceTest(t *testing.T) (AbstractNotificationService, *mocks.MockAbstractRepository) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockAbstractRepository(ctrl)
+
+	return NewConcreteNotificationService(mockRepository), mockRepository
+}
+
+func TestConcreteNotificationService_GetNotifications(t *testing.T) {
+	notificationService, repo := initNotificationServiceTest(t)
+
+	tests := []struct {
+		name             string
+		limit            int
+		mock             func()
+		wantCount        int
+		wantNextBeforeId *int
+	}{
+		{
+			name:  "full page points to the next one",
+			limit: 2,
+			mock: func() {
+				repo.EXPECT().GetNotifications(1, 0, 3, true).Return([]*model.Notification{{Id: 9}, {Id: 7}, {Id: 4}})
+			},
+			wantCount:        2,
+			wantNextBeforeId: intPointer(7),
+		},
+		{
+			name:  "last page",
+			limit: 500,
+			mock: func() {
+				repo.EXPECT().GetNotifications(1, 0, maxNotificationsLimit+1, true).Return([]*model.Notification{{Id: 9}})
+			},
+			wantCount: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			page, err := notificationService.GetNotifications(1, 0, tt.limit, true)
+			if err != nil {
+				t.Fatalf("GetNotifications() error = %v", err)
+			}
+
+			if len(page.Notifications) != tt.wantCount {
+				t.Errorf("GetNotifications() returned %d notifications, want %d", len(page.Notifications), tt.wantCount)
+			}
+			if (page.NextBeforeId == nil) != (tt.wantNextBeforeId == nil) ||
+				(page.NextBeforeId != nil && *page.NextBeforeId != *tt.wantNextBeforeId) {
+				t.Errorf("GetNotifications() NextBeforeId = %v, want %v", page.NextBeforeId, tt.wantNextBeforeId)
+			}
+		})
+	}
+}
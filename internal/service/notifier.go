@@ -0,0 +1,48 @@
+package service
+
+//go:generate mockgen -source=notifier.go -destination=mock/notifier.go -package=mock
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/repository"
+)
+
+// Notifier доставляет уведомление пользователю по одному каналу
+type Notifier interface {
+	Channel() model.ReminderChannel
+	Notify(notification *model.Notification, target model.NotificationTarget) *model.ApplicationError
+}
+
+type InboxNotifier struct {
+	repo repository.AbstractRepository
+}
+
+func NewInboxNotifier(repository repository.AbstractRepository) Notifier {
+	return &InboxNotifier{repo: repository}
+}
+
+func (i *InboxNotifier) Channel() model.ReminderChannel {
+	return model.ReminderChannelInbox
+}
+
+func (i *InboxNotifier) Notify(notification *model.Notification, _ model.NotificationTarget) *model.ApplicationError {
+	inboxNotification := *notification
+	inboxNotification.Id = 0
+
+	return deliverToInbox(i.repo, &inboxNotification)
+}
+
+// deliverToInbox сохраняет уведомление во входящих, если пользователь не отключил уведомления этого типа
+func deliverToInbox(repo repository.AbstractRepository, notification *model.Notification) *model.ApplicationError {
+	preferences, err := getPreferences(repo, notification.UserId)
+	if err != nil {
+		return err
+	}
+
+	if !preferences.IsNotificationEnabled(notification.Type) {
+		return nil
+	}
+
+	_, err = repo.SaveEntity(notification)
+	return err
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/utils"
+)
+
+// positionedItem - элемент упорядоченного списка (заметка в папке или папка в блокноте)
+type positionedItem struct {
+	id       int
+	position string
+}
+
+// getPositionAtEnd возвращает позицию после последнего элемента списка, упорядоченного по позиции
+func getPositionAtEnd(siblings []positionedItem) (string, *model.ApplicationError) {
+	last := ""
+	if len(siblings) > 0 {
+		last = siblings[len(siblings)-1].position
+	}
+
+	return utils.PositionBetween(last, "")
+}
+
+// getPositionAfter возвращает позицию сразу после элемента afterId (nil - в начало списка).
+// Меняется только позиция перемещаемого элемента, соседние записи не трогаются.
+func getPositionAfter(siblings []positionedItem, afterId *int) (string, *model.ApplicationError) {
+	before := ""
+	after := ""
+
+	if afterId == nil {
+		if len(siblings) > 0 {
+			after = siblings[0].position
+		}
+	} else {
+		index := -1
+		for i, sibling := range siblings {
+			if sibling.id == *afterId {
+				index = i
+				break
+			}
+		}
+
+		if index < 0 {
+			params := model.ErrorParams{"id": *afterId}
+			return "", model.NewLocalizedError(model.ErrorTypeValidation, model.CodePositionAnchorNotFound, params, nil)
+		}
+
+		before = siblings[index].position
+		if index+1 < len(siblings) {
+			after = siblings[index+1].position
+		}
+	}
+
+	// Совпадающие позиции (одновременная вставка) не позволяют вставить ключ между ними,
+	// поэтому элемент ставится после всей группы совпадающих
+	if before != "" && after != "" && after <= before {
+		after = ""
+	}
+
+	return utils.PositionBetween(before, after)
+}
+
+// getNoteSiblings возвращает упорядоченные заметки папки folderId с тем же признаком закрепления,
+// кроме заметки excludeId
+func getNoteSiblings(notes []*model.Note, folderId *int, isPinned bool, excludeId int) []positionedItem {
+	folderNotes := make([]*model.Note, 0)
+	for _, note := range notes {
+		if note.Id != excludeId && note.IsPinned == isPinned && isSameFolder(note.FolderId, folderId) {
+			folderNotes = append(folderNotes, note)
+		}
+	}
+
+	model.SortNotes(folderNotes)
+
+	siblings := make([]positionedItem, 0, len(folderNotes))
+	for _, note := range folderNotes {
+		siblings = append(siblings, positionedItem{id: note.Id, position: note.Position})
+	}
+
+	return siblings
+}
+
+func getFolderSiblings(folders []*model.Folder, excludeId int) []positionedItem {
+	otherFolders := make([]*model.Folder, 0)
+	for _, folder := range folders {
+		if folder.Id != excludeId {
+			otherFolders = append(otherFolders, folder)
+		}
+	}
+
+	model.SortFolders(otherFolders)
+
+	siblings := make([]positionedItem, 0, len(otherFolders))
+	for _, folder := range otherFolders {
+		siblings = append(siblings, positionedItem{id: folder.Id, position: folder.Position})
+	}
+
+	return siblings
+}
+
+func isSameFolder(first *int, second *int) bool {
+	if first == nil || second == nil {
+		return first == nil && second == nil
+	}
+
+	return *first == *second
+}
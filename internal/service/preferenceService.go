@@ -0,0 +1,120 @@
+package service
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/repository"
+)
+
+//go:generate mockgen -source=preferenceService.go -destination=mock/preferenceService.go -package=mock
+
+type AbstractPreferenceService interface {
+	GetPreferences(userId int) (*model.PreferencesApi, *model.ApplicationError)
+	UpdatePreferences(userId int, patch model.PreferencesPatch) *model.ApplicationError
+	GetLocale(userId int) (model.Locale, *model.ApplicationError)
+}
+
+type PreferenceService struct {
+	repo repository.AbstractRepository
+}
+
+func NewConcretePreferenceService(repository repository.AbstractRepository) AbstractPreferenceService {
+	return &PreferenceService{
+		repo: repository,
+	}
+}
+
+func (p *PreferenceService) GetPreferences(userId int) (*model.PreferencesApi, *model.ApplicationError) {
+	user, err := p.repo.GetUserById(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences, err := getPreferences(p.repo, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.ToPreferencesApi(preferences, user), nil
+}
+
+func (p *PreferenceService) UpdatePreferences(userId int, patch model.PreferencesPatch) *model.ApplicationError {
+	user, err := p.repo.GetUserById(userId)
+	if err != nil {
+		return err
+	}
+
+	preferences, err := getPreferences(p.repo, userId)
+	if err != nil {
+		return err
+	}
+
+	if err = preferences.Apply(patch); err != nil {
+		return err
+	}
+
+	if patch.DefaultFolderId != nil {
+		if err = p.setDefaultFolder(preferences, *patch.DefaultFolderId); err != nil {
+			return err
+		}
+	}
+
+	timezoneChanged := patch.Timezone != nil && *patch.Timezone != user.Timezone
+	if timezoneChanged {
+		if err = user.SetTimezone(*patch.Timezone); err != nil {
+			return err
+		}
+	}
+
+	// Часовой пояс хранится у пользователя, поэтому обе записи сохраняются вместе
+	return p.repo.Transaction(func(tx repository.AbstractRepository) *model.ApplicationError {
+		if timezoneChanged {
+			if _, err := tx.SaveEntity(user); err != nil {
+				return err
+			}
+		}
+
+		_, err := tx.SaveEntity(preferences)
+		return err
+	})
+}
+
+func (p *PreferenceService) GetLocale(userId int) (model.Locale, *model.ApplicationError) {
+	preferences, err := getPreferences(p.repo, userId)
+	if err != nil {
+		return model.DefaultLocale, err
+	}
+
+	return preferences.Locale, nil
+}
+
+func (p *PreferenceService) setDefaultFolder(preferences *model.Preferences, folderId int) *model.ApplicationError {
+	if folderId == 0 {
+		preferences.DefaultFolderId = nil
+		return nil
+	}
+
+	folder, err := p.repo.GetFolderById(folderId, preferences.UserId)
+	if err != nil {
+		return err
+	}
+
+	if folder.IsArchived {
+		return model.NewLocalizedError(model.ErrorTypeValidation, model.CodeFolderArchived, nil, nil)
+	}
+
+	preferences.DefaultFolderId = &folder.Id
+	return nil
+}
+
+// getPreferences возвращает настройки пользователя или настройки по умолчанию, если он их не менял
+func getPreferences(repo repository.AbstractRepository, userId int) (*model.Preferences, *model.ApplicationError) {
+	preferences, err := repo.GetPreferences(userId)
+	if err != nil {
+		if err.Type != model.ErrorTypeNotFound {
+			return nil, err
+		}
+		return model.DefaultPreferences(userId), nil
+	}
+
+	return preferences, nil
+}
@@ -0,0 +1,318 @@
+package service
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/repository"
+	mocks "Notes/internal/service/mock"
+	"encoding/json"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"testing"
+)
+
+func initPreferenceServiceTest(t *testing.T) (AbstractPreferenceService, *mocks.MockAbstractRepository) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockAbstractRepository(ctrl)
+	mockRepository.EXPECT().Transaction(gomock.Any()).DoAndReturn(func(fn func(tx repository.AbstractRepository) *model.ApplicationError) *model.ApplicationError {
+		return fn(mockRepository)
+	}).AnyTimes()
+
+	return NewConcretePreferenceService(mockRepository), mockRepository
+}
+
+func TestConcretePreferenceService_GetPreferences(t *testing.T) {
+	preferenceService, repo := initPreferenceServiceTest(t)
+
+	tests := []struct {
+		name string
+		mock func()
+		want *model.PreferencesApi
+	}{
+		{
+			name: "defaults for user without preferences",
+			mock: func() {
+				repo.EXPECT().GetUserById(1).Return(&model.User{Id: 1}, nil)
+				repo.EXPECT().GetPreferences(1).Return(nil, model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil))
+			},
+			want: &model.PreferencesApi{
+				Timezone:      "UTC",
+				Locale:        model.LocaleRu,
+				SortOrder:     model.SortOrderManual,
+				EditorFormat:  model.EditorFormatMarkdown,
+				Notifications: map[model.NotificationType]bool{model.NotificationTypeReminder: true, model.NotificationTypeMention: true},
+			},
+		},
+		{
+			name: "stored preferences",
+			mock: func() {
+				repo.EXPECT().GetUserById(1).Return(&model.User{Id: 1, Timezone: "Europe/Moscow"}, nil)
+				repo.EXPECT().GetPreferences(1).Return(&model.Preferences{
+					Id:                 2,
+					UserId:             1,
+					Locale:             model.LocaleEn,
+					SortOrder:          model.SortOrderUpdated,
+					DefaultFolderId:    intPointer(5),
+					PageSize:           20,
+					EditorFormat:       model.EditorFormatPlain,
+					MutedNotifications: pq.StringArray{"mention"},
+				}, nil)
+			},
+			want: &model.PreferencesApi{
+				Timezone:        "Europe/Moscow",
+				Locale:          model.LocaleEn,
+				SortOrder:       model.SortOrderUpdated,
+				DefaultFolderId: intPointer(5),
+				PageSize:        20,
+				EditorFormat:    model.EditorFormatPlain,
+				Notifications:   map[model.NotificationType]bool{model.NotificationTypeReminder: true, model.NotificationTypeMention: false},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := preferenceService.GetPreferences(1)
+			if err != nil {
+				t.Errorf("PreferenceService.GetPreferences() unexpected error = %v", err)
+				return
+			}
+
+			gotJson, _ := json.Marshal(got)
+			expectedJson, _ := json.Marshal(tt.want)
+			if string(gotJson) != string(expectedJson) {
+				t.Errorf("PreferenceService.GetPreferences() = %v, want %v", string(gotJson), string(expectedJson))
+			}
+		})
+	}
+}
+
+func TestConcretePreferenceService_UpdatePreferences(t *testing.T) {
+	preferenceService, repo := initPreferenceServiceTest(t)
+
+	tests := []struct {
+		name    string
+		mock    func()
+		patch   model.PreferencesPatch
+		wantErr *model.ApplicationError
+	}{
+		{
+			name: "unknown sort order",
+			mock: func() {
+				repo.EXPECT().GetUserById(1).Return(&model.User{Id: 1}, nil)
+				repo.EXPECT().GetPreferences(1).Return(nil, model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil))
+			},
+			patch:   model.PreferencesPatch{SortOrder: stringPointer("random")},
+			wantErr: model.NewApplicationError(model.ErrorTypeValidation, "Неизвестный порядок сортировки: random", nil),
+		},
+		{
+			name: "page size too large",
+			mock: func() {
+				repo.EXPECT().GetUserById(1).Return(&model.User{Id: 1}, nil)
+				repo.EXPECT().GetPreferences(1).Return(nil, model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil))
+			},
+			patch:   model.PreferencesPatch{PageSize: intPointer(1000)},
+			wantErr: model.NewApplicationError(model.ErrorTypeValidation, "Размер страницы должен быть от 0 до 100", nil),
+		},
+		{
+			name: "archived default folder",
+			mock: func() {
+				repo.EXPECT().GetUserById(1).Return(&model.User{Id: 1}, nil)
+				repo.EXPECT().GetPreferences(1).Return(nil, model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil))
+				repo.EXPECT().GetFolderById(5, 1).Return(&model.Folder{Id: 5, UserId: 1, IsArchived: true}, nil)
+			},
+			patch:   model.PreferencesPatch{DefaultFolderId: intPointer(5)},
+			wantErr: model.NewLocalizedError(model.ErrorTypeValidation, model.CodeFolderArchived, nil, nil),
+		},
+		{
+			name: "first change creates preferences and updates timezone",
+			mock: func() {
+				repo.EXPECT().GetUserById(1).Return(&model.User{Id: 1, Login: "login", Timezone: "UTC"}, nil)
+				repo.EXPECT().GetPreferences(1).Return(nil, model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil))
+				repo.EXPECT().GetFolderById(5, 1).Return(&model.Folder{Id: 5, UserId: 1}, nil)
+				repo.EXPECT().SaveEntity(&model.User{Id: 1, Login: "login", Timezone: "Asia/Tokyo"}).Return(1, nil)
+				repo.EXPECT().SaveEntity(&model.Preferences{
+					UserId:             1,
+					Locale:             model.LocaleEn,
+					SortOrder:          model.SortOrderManual,
+					DefaultFolderId:    intPointer(5),
+					EditorFormat:       model.EditorFormatMarkdown,
+					MutedNotifications: pq.StringArray{},
+				}).Return(3, nil)
+			},
+			patch: model.PreferencesPatch{
+				Timezone:        stringPointer("Asia/Tokyo"),
+				Locale:          stringPointer("en"),
+				DefaultFolderId: intPointer(5),
+			},
+		},
+		{
+			name: "zero default folder resets it",
+			mock: func() {
+				repo.EXPECT().GetUserById(1).Return(&model.User{Id: 1, Timezone: "UTC"}, nil)
+				repo.EXPECT().GetPreferences(1).Return(&model.Preferences{
+					Id:              3,
+					UserId:          1,
+					Locale:          model.LocaleRu,
+					SortOrder:       model.SortOrderTitle,
+					DefaultFolderId: intPointer(5),
+					EditorFormat:    model.EditorFormatMarkdown,
+				}, nil)
+				repo.EXPECT().SaveEntity(&model.Preferences{
+					Id:           3,
+					UserId:       1,
+					Locale:       model.LocaleRu,
+					SortOrder:    model.SortOrderTitle,
+					EditorFormat: model.EditorFormatMarkdown,
+				}).Return(3, nil)
+			},
+			patch: model.PreferencesPatch{DefaultFolderId: intPointer(0)},
+		},
+		{
+			name: "unknown notification type",
+			mock: func() {
+				repo.EXPECT().GetUserById(1).Return(&model.User{Id: 1}, nil)
+				repo.EXPECT().GetPreferences(1).Return(nil, model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil))
+			},
+			patch:   model.PreferencesPatch{Notifications: map[string]bool{"digest": false}},
+			wantErr: model.NewApplicationError(model.ErrorTypeValidation, "Неизвестный тип уведомлений: digest", nil),
+		},
+		{
+			name: "notification types are muted and unmuted",
+			mock: func() {
+				repo.EXPECT().GetUserById(1).Return(&model.User{Id: 1, Timezone: "UTC"}, nil)
+				repo.EXPECT().GetPreferences(1).Return(&model.Preferences{
+					Id:                 3,
+					UserId:             1,
+					MutedNotifications: pq.StringArray{"reminder"},
+				}, nil)
+				repo.EXPECT().SaveEntity(&model.Preferences{
+					Id:                 3,
+					UserId:             1,
+					MutedNotifications: pq.StringArray{"mention"},
+				}).Return(3, nil)
+			},
+			patch: model.PreferencesPatch{Notifications: map[string]bool{"reminder": true, "mention": false}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			err := preferenceService.UpdatePreferences(1, tt.patch)
+			if tt.wantErr != nil {
+				if err == nil || err.Type != tt.wantErr.Type || err.Message != tt.wantErr.Message {
+					t.Errorf("PreferenceService.UpdatePreferences() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("PreferenceService.UpdatePreferences() unexpected error = %v", err)
+			}
+		})
+	}
+}
+
+func TestConcretePreferenceService_GetLocale(t *testing.T) {
+	preferenceService, repo := initPreferenceServiceTest(t)
+
+	tests := []struct {
+		name    string
+		mock    func()
+		want    model.Locale
+		wantErr bool
+	}{
+		{
+			name: "default locale for user without preferences",
+			mock: func() {
+				repo.EXPECT().GetPreferences(1).Return(nil, model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil))
+			},
+			want: model.LocaleRu,
+		},
+		{
+			name: "stored locale",
+			mock: func() {
+				repo.EXPECT().GetPreferences(1).Return(&model.Preferences{UserId: 1, Locale: model.LocaleEn}, nil)
+			},
+			want: model.LocaleEn,
+		},
+		{
+			name: "database error falls back to default locale",
+			mock: func() {
+				repo.EXPECT().GetPreferences(1).Return(nil, model.NewApplicationError(model.ErrorTypeDatabase, " внутрення ошибка БД", nil))
+			},
+			want:    model.LocaleRu,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := preferenceService.GetLocale(1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PreferenceService.GetLocale() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if got != tt.want {
+				t.Errorf("PreferenceService.GetLocale() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConcretePreferenceService_LocalizedErrors(t *testing.T) {
+	preferenceService, repo := initPreferenceServiceTest(t)
+
+	tests := []struct {
+		name       string
+		locale     model.Locale
+		wantCode   model.ErrorCode
+		wantParams model.ErrorParams
+		want       string
+	}{
+		{
+			name:       "default locale",
+			locale:     model.LocaleRu,
+			wantCode:   model.CodePageSizeOutOfRange,
+			wantParams: model.ErrorParams{"max": model.MaxNotebookPageSize},
+			want:       "Размер страницы должен быть от 0 до 100",
+		},
+		{
+			name:       "english",
+			locale:     model.LocaleEn,
+			wantCode:   model.CodePageSizeOutOfRange,
+			wantParams: model.ErrorParams{"max": model.MaxNotebookPageSize},
+			want:       "Page size must be between 0 and 100",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo.EXPECT().GetUserById(1).Return(&model.User{Id: 1}, nil)
+			repo.EXPECT().GetPreferences(1).Return(nil, model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil))
+
+			err := preferenceService.UpdatePreferences(1, model.PreferencesPatch{PageSize: intPointer(500)})
+			if err == nil {
+				t.Fatalf("PreferenceService.UpdatePreferences() expected error")
+			}
+
+			gotParams, _ := json.Marshal(err.Params)
+			wantParams, _ := json.Marshal(tt.wantParams)
+			if err.Code != tt.wantCode || string(gotParams) != string(wantParams) {
+				t.Errorf("PreferenceService.UpdatePreferences() code = %v %s, want %v %s", err.Code, gotParams, tt.wantCode, wantParams)
+			}
+
+			if got := model.GetAppropriateApiError(err).LocalizedMessage(tt.locale); got != tt.want {
+				t.Errorf("ApiError.LocalizedMessage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"Notes/internal/model"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+)
+
+// Адреса webhook задаёт пользователь, поэтому запросы по ним не должны уходить во внутреннюю сеть сервера.
+// Адрес проверяется при сохранении и ещё раз при каждом соединении, уже после разрешения имени:
+// иначе DNS может вернуть внутренний адрес между проверкой и запросом.
+
+var errInternalAddress = errors.New("соединение с адресом во внутренней сети запрещено")
+
+// isPublicIp сообщает, что адрес не относится к локальному узлу, частной сети или локальному сегменту
+func isPublicIp(ip net.IP) bool {
+	return !ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!ip.IsUnspecified()
+}
+
+// validateWebhookHost разрешает имя хоста из адреса webhook и отклоняет адрес, если хотя бы один
+// из полученных IP не публичный
+func validateWebhookHost(rawUrl string) *model.ApplicationError {
+	parsed, err := url.Parse(rawUrl)
+	if err != nil || parsed.Hostname() == "" {
+		return model.NewLocalizedError(model.ErrorTypeValidation, model.CodeWebhookUrlInvalid, nil, nil)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+	defer cancel()
+
+	addresses, err := net.DefaultResolver.LookupIPAddr(ctx, parsed.Hostname())
+	if err != nil || len(addresses) == 0 {
+		return model.NewLocalizedError(model.ErrorTypeValidation, model.CodeWebhookUrlInvalid, nil, nil)
+	}
+
+	for _, address := range addresses {
+		if !isPublicIp(address.IP) {
+			return model.NewLocalizedError(model.ErrorTypeValidation, model.CodeWebhookUrlForbidden, nil, nil)
+		}
+	}
+
+	return nil
+}
+
+// newPublicHttpClient создаёт клиент, который соединяется только с публичными адресами.
+// Прокси из окружения не используется: через него проверка адреса теряет смысл.
+func newPublicHttpClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: webhookTimeout,
+		// Control вызывается для уже разрешённого адреса перед каждым соединением
+		Control: func(network string, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			if ip := net.ParseIP(host); ip == nil || !isPublicIp(ip) {
+				return errInternalAddress
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   webhookTimeout,
+		Transport: transport,
+	}
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"Notes/internal/model"
+	"errors"
+	"net"
+	"testing"
+)
+
+func TestIsPublicIp(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{ip: "203.0.113.10", want: true},
+		{ip: "2001:db8::1", want: true},
+		{ip: "127.0.0.1", want: false},
+		{ip: "::1", want: false},
+		{ip: "10.1.2.3", want: false},
+		{ip: "172.16.0.1", want: false},
+		{ip: "192.168.1.1", want: false},
+		{ip: "fd00::1", want: false},
+		{ip: "169.254.169.254", want: false},
+		{ip: "fe80::1", want: false},
+		{ip: "0.0.0.0", want: false},
+		{ip: "::", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			if got := isPublicIp(net.ParseIP(tt.ip)); got != tt.want {
+				t.Errorf("isPublicIp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateWebhookHost(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want model.ErrorCode
+	}{
+		{name: "public address", url: "https://203.0.113.10/hook"},
+		{name: "loopback", url: "http://127.0.0.1:8080/hook", want: model.CodeWebhookUrlForbidden},
+		{name: "cloud metadata", url: "http://169.254.169.254/latest", want: model.CodeWebhookUrlForbidden},
+		{name: "private ipv6", url: "http://[fd00::1]/hook", want: model.CodeWebhookUrlForbidden},
+		{name: "localhost", url: "http://localhost/hook", want: model.CodeWebhookUrlForbidden},
+		{name: "no host", url: "http:///hook", want: model.CodeWebhookUrlInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateWebhookHost(tt.url)
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("validateWebhookHost() error = %v, want nil", err)
+				}
+				return
+			}
+
+			if err == nil || err.Code != tt.want {
+				t.Errorf("validateWebhookHost() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewPublicHttpClient_RefusesInternalAddress(t *testing.T) {
+	_, err := newPublicHttpClient().Get("http://127.0.0.1:1/hook")
+	if !errors.Is(err, errInternalAddress) {
+		t.Errorf("newPublicHttpClient() error = %v, want %v", err, errInternalAddress)
+	}
+}
@@ -0,0 +1,225 @@
+package service
+
+//go:generate mockgen -source=reminderService.go -destination=mock/reminderService.go -package=mock
+
+import (
+	"Notes/config"
+	"Notes/internal/model"
+	"Notes/internal/repository"
+	"context"
+	"log"
+	"sort"
+	"time"
+)
+
+const defaultReminderPollInterval = 30 * time.Second
+const maxRemindersPerTick = 100
+const maxOccurrencesPerReminder = 100
+const maxRemindersRange = 366 * 24 * time.Hour
+
+type AbstractReminderService interface {
+	SetReminder(userId int, noteId int, settings model.ReminderSettings) *model.ApplicationError
+	GetReminder(userId int, noteId int) (*model.ReminderApi, *model.ApplicationError)
+	DeleteReminder(userId int, noteId int) *model.ApplicationError
+	GetUpcomingReminders(userId int, from time.Time, to time.Time) ([]*model.ReminderOccurrenceApi, *model.ApplicationError)
+	FireDueReminders(now time.Time) int
+	Run(ctx context.Context)
+}
+
+type ReminderService struct {
+	repo         repository.AbstractRepository
+	notifiers    map[model.ReminderChannel]Notifier
+	pollInterval time.Duration
+}
+
+func NewConcreteReminderService(repository repository.AbstractRepository, notifiers []Notifier, cfg *config.Config) AbstractReminderService {
+	notifiersByChannel := make(map[model.ReminderChannel]Notifier)
+	for _, notifier := range notifiers {
+		notifiersByChannel[notifier.Channel()] = notifier
+	}
+
+	pollInterval := time.Duration(cfg.Reminders.PollIntervalSeconds) * time.Second
+	if pollInterval <= 0 {
+		pollInterval = defaultReminderPollInterval
+	}
+
+	return &ReminderService{
+		repo:         repository,
+		notifiers:    notifiersByChannel,
+		pollInterval: pollInterval,
+	}
+}
+
+func (r *ReminderService) SetReminder(userId int, noteId int, settings model.ReminderSettings) *model.ApplicationError {
+	reminder, err := model.NewReminder(noteId, userId, settings)
+	if err != nil {
+		return err
+	}
+
+	for _, channel := range reminder.Channels {
+		if _, exists := r.notifiers[model.ReminderChannel(channel)]; !exists {
+			params := model.ErrorParams{"channel": channel}
+			return model.NewLocalizedError(model.ErrorTypeValidation, model.CodeNotificationChannelOff, params, nil)
+		}
+	}
+
+	if reminder.WebhookUrl != nil {
+		if err = validateWebhookHost(*reminder.WebhookUrl); err != nil {
+			return err
+		}
+	}
+
+	_, err = r.repo.GetNoteById(noteId, userId)
+	if err != nil {
+		return err
+	}
+
+	reminderDb, err := r.repo.GetReminderByNoteId(noteId, userId)
+	if err != nil && err.Type != model.ErrorTypeNotFound {
+		return err
+	}
+
+	// У заметки одно напоминание: повторная установка заменяет расписание целиком
+	if reminderDb != nil {
+		reminder.Id = reminderDb.Id
+	}
+
+	_, err = r.repo.SaveEntity(reminder)
+	return err
+}
+
+func (r *ReminderService) GetReminder(userId int, noteId int) (*model.ReminderApi, *model.ApplicationError) {
+	reminder, err := r.repo.GetReminderByNoteId(noteId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.ToReminderApi(reminder), nil
+}
+
+func (r *ReminderService) DeleteReminder(userId int, noteId int) *model.ApplicationError {
+	reminder, err := r.repo.GetReminderByNoteId(noteId, userId)
+	if err != nil {
+		if err.Type == model.ErrorTypeNotFound {
+			return nil
+		}
+		return err
+	}
+
+	return r.repo.DeleteEntity(reminder)
+}
+
+func (r *ReminderService) GetUpcomingReminders(userId int, from time.Time, to time.Time) ([]*model.ReminderOccurrenceApi, *model.ApplicationError) {
+	if !to.After(from) {
+		return nil, model.NewLocalizedError(model.ErrorTypeValidation, model.CodeIntervalInvalid, nil, nil)
+	}
+
+	if to.Sub(from) > maxRemindersRange {
+		return nil, model.NewLocalizedError(model.ErrorTypeValidation, model.CodeIntervalTooLong, nil, nil)
+	}
+
+	reminders := r.repo.GetActiveRemindersByUserId(userId)
+	occurrences := make([]*model.ReminderOccurrenceApi, 0)
+	if len(reminders) == 0 {
+		return occurrences, nil
+	}
+
+	titles := make(map[int]string)
+	for _, note := range r.repo.GetNotesByUserId(userId) {
+		titles[note.Id] = note.Title
+	}
+
+	for _, reminder := range reminders {
+		for _, remindAt := range reminder.Occurrences(from, to, maxOccurrencesPerReminder) {
+			occurrences = append(occurrences, &model.ReminderOccurrenceApi{
+				NoteId:     reminder.NoteId,
+				Title:      titles[reminder.NoteId],
+				RemindAt:   remindAt,
+				DueAt:      reminder.DueAt,
+				Recurrence: reminder.Recurrence,
+			})
+		}
+	}
+
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].RemindAt.Before(occurrences[j].RemindAt)
+	})
+
+	return occurrences, nil
+}
+
+// FireDueReminders отправляет все наступившие напоминания и возвращает их количество
+func (r *ReminderService) FireDueReminders(now time.Time) int {
+	fired := 0
+
+	for fired < maxRemindersPerTick {
+		// Напоминание переносится и сохраняется до отправки, чтобы сетевые вызовы не держали блокировку
+		reminder, err := r.repo.ClaimDueReminder(now, func(reminder *model.Reminder) {
+			reminder.Advance(now)
+		})
+
+		if err != nil {
+			log.Printf("Ошибка при обработке напоминаний: %v", err)
+			break
+		}
+
+		if reminder == nil {
+			break
+		}
+
+		r.deliver(reminder)
+		fired++
+	}
+
+	return fired
+}
+
+func (r *ReminderService) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			r.FireDueReminders(now.UTC())
+		}
+	}
+}
+
+// deliver отправляет напоминание по всем каналам. Ошибки доставки только логируются:
+// напоминание считается сработавшим, чтобы не отправить его повторно по уже отработавшим каналам.
+func (r *ReminderService) deliver(reminder *model.Reminder) {
+	note, err := r.repo.GetNoteById(reminder.NoteId, reminder.UserId)
+	if err != nil {
+		log.Printf("Не удалось получить заметку %d для напоминания %d: %v", reminder.NoteId, reminder.Id, err)
+		return
+	}
+
+	noteId := note.Id
+	notification := &model.Notification{
+		UserId: reminder.UserId,
+		Type:   model.NotificationTypeReminder,
+		Title:  note.Title,
+		Body:   note.Content,
+		NoteId: &noteId,
+	}
+
+	target := model.NotificationTarget{
+		Email:      reminder.Email,
+		WebhookUrl: reminder.WebhookUrl,
+	}
+
+	for _, channel := range reminder.Channels {
+		notifier, exists := r.notifiers[model.ReminderChannel(channel)]
+		if !exists {
+			log.Printf("Канал уведомлений %s не настроен, напоминание %d пропущено", channel, reminder.Id)
+			continue
+		}
+
+		if notifyErr := notifier.Notify(notification, target); notifyErr != nil {
+			log.Printf("Ошибка доставки напоминания %d по каналу %s: %v", reminder.Id, channel, notifyErr)
+		}
+	}
+}
@@ -0,0 +1,386 @@
+package service
+
+import (
+	"Notes/config"
+	"Notes/internal/model"
+	mocks "Notes/internal/service/mock"
+	"encoding/json"
+	"fmt"
+	"github.com/golang/mock/gomock"
+	"testing"
+	"time"
+)
+
+type reminderTestArgs struct {
+	userId   int
+	noteId   int
+	settings model.ReminderSettings
+	from     time.Time
+	to       time.Time
+}
+
+func initReminderServiceTest(t *testing.T) (AbstractReminderService, *mocks.MockAbstractRepository, *mocks.MockNotifier) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockAbstractRepository(ctrl)
+	mockNotifier := mocks.NewMockNotifier(ctrl)
+	mockNotifier.EXPECT().Channel().Return(model.ReminderChannelInbox).AnyTimes()
+
+	return NewConcreteReminderService(mockRepository, []Notifier{mockNotifier}, &config.Config{}), mockRepository, mockNotifier
+}
+
+func getTestReminder(settings model.ReminderSettings) *model.Reminder {
+	reminder, _ := model.NewReminder(1, 1, settings)
+	return reminder
+}
+
+func TestConcreteReminderService_SetReminder(t *testing.T) {
+	reminderService, repo, _ := initReminderServiceTest(t)
+	futureTime := time.Date(2100, 1, 31, 9, 0, 0, 0, time.UTC)
+	email := "user@example.com"
+
+	tests := []struct {
+		name    string
+		mock    func()
+		args    reminderTestArgs
+		want    *model.ApplicationError
+		wantErr bool
+	}{
+		{
+			name: "remind time in the past",
+			mock: func() {},
+			args: reminderTestArgs{
+				userId:   1,
+				noteId:   1,
+				settings: model.ReminderSettings{RemindAt: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)},
+			},
+			want:    model.NewApplicationError(model.ErrorTypeValidation, "Время напоминания должно быть в будущем", nil),
+			wantErr: true,
+		},
+		{
+			name: "unsupported recurrence",
+			mock: func() {},
+			args: reminderTestArgs{
+				userId:   1,
+				noteId:   1,
+				settings: model.ReminderSettings{RemindAt: futureTime, Recurrence: "FREQ=HOURLY"},
+			},
+			want:    model.NewApplicationError(model.ErrorTypeValidation, "Неподдерживаемое правило повторения: FREQ=HOURLY", nil),
+			wantErr: true,
+		},
+		{
+			name: "count and until together",
+			mock: func() {},
+			args: reminderTestArgs{
+				userId:   1,
+				noteId:   1,
+				settings: model.ReminderSettings{RemindAt: futureTime, Recurrence: "FREQ=DAILY;COUNT=2;UNTIL=21000101T000000Z"},
+			},
+			want:    model.NewApplicationError(model.ErrorTypeValidation, "В правиле повторения нельзя одновременно указывать COUNT и UNTIL", nil),
+			wantErr: true,
+		},
+		{
+			name: "email channel without address",
+			mock: func() {},
+			args: reminderTestArgs{
+				userId:   1,
+				noteId:   1,
+				settings: model.ReminderSettings{RemindAt: futureTime, Channels: []string{"email"}},
+			},
+			want:    model.NewApplicationError(model.ErrorTypeValidation, "Для отправки напоминания на почту укажите адрес", nil),
+			wantErr: true,
+		},
+		{
+			name: "channel without configured notifier",
+			mock: func() {},
+			args: reminderTestArgs{
+				userId:   1,
+				noteId:   1,
+				settings: model.ReminderSettings{RemindAt: futureTime, Channels: []string{"email"}, Email: &email},
+			},
+			want:    model.NewApplicationError(model.ErrorTypeValidation, "Канал уведомлений email не настроен", nil),
+			wantErr: true,
+		},
+		{
+			name: "note not found",
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(nil, model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil))
+			},
+			args: reminderTestArgs{
+				userId:   1,
+				noteId:   1,
+				settings: model.ReminderSettings{RemindAt: futureTime},
+			},
+			want:    model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil),
+			wantErr: true,
+		},
+		{
+			name: "new reminder saved",
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(&model.Note{Id: 1, UserId: 1}, nil)
+				repo.EXPECT().GetReminderByNoteId(1, 1).Return(nil, model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil))
+				repo.EXPECT().SaveEntity(getTestReminder(model.ReminderSettings{RemindAt: futureTime, Recurrence: "FREQ=MONTHLY"})).Return(5, nil)
+			},
+			args: reminderTestArgs{
+				userId:   1,
+				noteId:   1,
+				settings: model.ReminderSettings{RemindAt: futureTime, Recurrence: "monthly"},
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "existing reminder replaced",
+			mock: func() {
+				repo.EXPECT().GetNoteById(1, 1).Return(&model.Note{Id: 1, UserId: 1}, nil)
+				repo.EXPECT().GetReminderByNoteId(1, 1).Return(&model.Reminder{Id: 5, NoteId: 1, UserId: 1, FiredCount: 3}, nil)
+				reminder := getTestReminder(model.ReminderSettings{RemindAt: futureTime})
+				reminder.Id = 5
+				repo.EXPECT().SaveEntity(reminder).Return(5, nil)
+			},
+			args: reminderTestArgs{
+				userId:   1,
+				noteId:   1,
+				settings: model.ReminderSettings{RemindAt: futureTime},
+			},
+			want:    nil,
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reminderService := reminderService
+
+			tt.mock()
+
+			err := reminderService.SetReminder(tt.args.userId, tt.args.noteId, tt.args.settings)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReminderService.SetReminder() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if err != nil && (err.Type != tt.want.Type || err.Message != tt.want.Message) {
+				t.Errorf("ReminderService.SetReminder() unexpected error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestConcreteReminderService_GetUpcomingReminders(t *testing.T) {
+	reminderService, repo, _ := initReminderServiceTest(t)
+	from := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		mock    func()
+		args    reminderTestArgs
+		want    []*model.ReminderOccurrenceApi
+		wantErr bool
+	}{
+		{
+			name: "interval end before start",
+			mock: func() {},
+			args: reminderTestArgs{
+				userId: 1,
+				from:   from,
+				to:     from.Add(-time.Hour),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "interval longer than a year",
+			mock: func() {},
+			args: reminderTestArgs{
+				userId: 1,
+				from:   from,
+				to:     from.AddDate(2, 0, 0),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "no reminders",
+			mock: func() {
+				repo.EXPECT().GetActiveRemindersByUserId(1).Return([]*model.Reminder{})
+			},
+			args: reminderTestArgs{
+				userId: 1,
+				from:   from,
+				to:     from.AddDate(0, 1, 0),
+			},
+			want:    []*model.ReminderOccurrenceApi{},
+			wantErr: false,
+		},
+		{
+			name: "recurring and one-off reminders ordered by time",
+			mock: func() {
+				repo.EXPECT().GetActiveRemindersByUserId(1).Return([]*model.Reminder{
+					{
+						Id:         1,
+						NoteId:     1,
+						UserId:     1,
+						StartsAt:   time.Date(2029, 12, 25, 9, 0, 0, 0, time.UTC),
+						RemindAt:   time.Date(2030, 1, 8, 9, 0, 0, 0, time.UTC),
+						Recurrence: "FREQ=WEEKLY;INTERVAL=2",
+						FiredCount: 1,
+						IsActive:   true,
+					},
+					{
+						Id:       2,
+						NoteId:   2,
+						UserId:   1,
+						StartsAt: time.Date(2030, 1, 10, 12, 0, 0, 0, time.UTC),
+						RemindAt: time.Date(2030, 1, 10, 12, 0, 0, 0, time.UTC),
+						IsActive: true,
+					},
+				})
+				repo.EXPECT().GetNotesByUserId(1).Return([]*model.Note{
+					{Id: 1, Title: "standup", UserId: 1},
+					{Id: 2, Title: "report", UserId: 1},
+				})
+			},
+			args: reminderTestArgs{
+				userId: 1,
+				from:   from,
+				to:     from.AddDate(0, 1, 0),
+			},
+			want: []*model.ReminderOccurrenceApi{
+				{NoteId: 1, Title: "standup", RemindAt: time.Date(2030, 1, 8, 9, 0, 0, 0, time.UTC), Recurrence: "FREQ=WEEKLY;INTERVAL=2"},
+				{NoteId: 2, Title: "report", RemindAt: time.Date(2030, 1, 10, 12, 0, 0, 0, time.UTC)},
+				{NoteId: 1, Title: "standup", RemindAt: time.Date(2030, 1, 22, 9, 0, 0, 0, time.UTC), Recurrence: "FREQ=WEEKLY;INTERVAL=2"},
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reminderService := reminderService
+
+			tt.mock()
+
+			got, err := reminderService.GetUpcomingReminders(tt.args.userId, tt.args.from, tt.args.to)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ReminderService.GetUpcomingReminders() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			gotJson, _ := json.Marshal(got)
+			expectedJson, _ := json.Marshal(tt.want)
+			if fmt.Sprintf("%v", string(gotJson)) != fmt.Sprintf("%v", string(expectedJson)) {
+				t.Errorf("ReminderService.GetUpcomingReminders() = %v, want %v", string(gotJson), string(expectedJson))
+			}
+		})
+	}
+}
+
+func TestConcreteReminderService_FireDueReminders(t *testing.T) {
+	reminderService, repo, notifier := initReminderServiceTest(t)
+	now := time.Date(2030, 3, 1, 9, 0, 30, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		reminders []*model.Reminder
+		mock      func(reminders []*model.Reminder)
+		want      int
+		wantAfter []model.Reminder
+	}{
+		{
+			name:      "nothing is due",
+			reminders: []*model.Reminder{},
+			mock: func(reminders []*model.Reminder) {
+				repo.EXPECT().ClaimDueReminder(now, gomock.Any()).Return(nil, nil)
+			},
+			want:      0,
+			wantAfter: []model.Reminder{},
+		},
+		{
+			name: "one-off reminder is delivered and deactivated",
+			reminders: []*model.Reminder{
+				{Id: 1, NoteId: 1, UserId: 1, StartsAt: now.Add(-time.Minute), RemindAt: now.Add(-time.Minute), IsActive: true, Channels: []string{"inbox"}},
+			},
+			mock: func(reminders []*model.Reminder) {
+				fireReminders(repo, now, reminders)
+				repo.EXPECT().GetNoteById(1, 1).Return(&model.Note{Id: 1, Title: "title", Content: "content", UserId: 1}, nil)
+				noteId := 1
+				notifier.EXPECT().Notify(&model.Notification{
+					UserId: 1,
+					Type:   model.NotificationTypeReminder,
+					Title:  "title",
+					Body:   "content",
+					NoteId: &noteId,
+				}, model.NotificationTarget{}).Return(nil)
+			},
+			want: 1,
+			wantAfter: []model.Reminder{
+				{Id: 1, NoteId: 1, UserId: 1, StartsAt: now.Add(-time.Minute), RemindAt: now.Add(-time.Minute), FiredCount: 1, IsActive: false, LastFiredAt: &now, Channels: []string{"inbox"}},
+			},
+		},
+		{
+			name: "missed occurrences of recurring reminder are skipped and delivery errors do not block it",
+			reminders: []*model.Reminder{
+				{Id: 2, NoteId: 2, UserId: 1, StartsAt: time.Date(2030, 1, 31, 9, 0, 0, 0, time.UTC), RemindAt: time.Date(2030, 1, 31, 9, 0, 0, 0, time.UTC), Recurrence: "FREQ=MONTHLY", IsActive: true, Channels: []string{"inbox"}},
+			},
+			mock: func(reminders []*model.Reminder) {
+				fireReminders(repo, now, reminders)
+				repo.EXPECT().GetNoteById(2, 1).Return(&model.Note{Id: 2, Title: "title", UserId: 1}, nil)
+				notifier.EXPECT().Notify(gomock.Any(), gomock.Any()).Return(model.NewApplicationError(model.ErrorTypeDatabase, "внутрення ошибка БД", nil))
+			},
+			want: 1,
+			wantAfter: []model.Reminder{
+				{Id: 2, NoteId: 2, UserId: 1, StartsAt: time.Date(2030, 1, 31, 9, 0, 0, 0, time.UTC), RemindAt: time.Date(2030, 3, 31, 9, 0, 0, 0, time.UTC), Recurrence: "FREQ=MONTHLY", FiredCount: 2, IsActive: true, LastFiredAt: &now, Channels: []string{"inbox"}},
+			},
+		},
+		{
+			name: "last occurrence deactivates reminder",
+			reminders: []*model.Reminder{
+				{Id: 3, NoteId: 3, UserId: 1, StartsAt: now.AddDate(0, 0, -1), RemindAt: now, Recurrence: "FREQ=DAILY;COUNT=2", FiredCount: 1, IsActive: true, Channels: []string{"inbox"}},
+			},
+			mock: func(reminders []*model.Reminder) {
+				fireReminders(repo, now, reminders)
+				repo.EXPECT().GetNoteById(3, 1).Return(&model.Note{Id: 3, Title: "title", UserId: 1}, nil)
+				notifier.EXPECT().Notify(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			want: 1,
+			wantAfter: []model.Reminder{
+				{Id: 3, NoteId: 3, UserId: 1, StartsAt: now.AddDate(0, 0, -1), RemindAt: now, Recurrence: "FREQ=DAILY;COUNT=2", FiredCount: 2, IsActive: false, LastFiredAt: &now, Channels: []string{"inbox"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reminderService := reminderService
+
+			tt.mock(tt.reminders)
+
+			got := reminderService.FireDueReminders(now)
+			if got != tt.want {
+				t.Errorf("ReminderService.FireDueReminders() = %v, want %v", got, tt.want)
+			}
+
+			for i, reminder := range tt.reminders {
+				gotJson, _ := json.Marshal(reminder)
+				expectedJson, _ := json.Marshal(tt.wantAfter[i])
+				if fmt.Sprintf("%v", string(gotJson)) != fmt.Sprintf("%v", string(expectedJson)) {
+					t.Errorf("ReminderService.FireDueReminders() reminder = %v, want %v", string(gotJson), string(expectedJson))
+				}
+			}
+		})
+	}
+}
+
+// fireReminders имитирует выборку наступивших напоминаний: каждое забирается один раз
+func fireReminders(repo *mocks.MockAbstractRepository, now time.Time, reminders []*model.Reminder) {
+	for _, reminder := range reminders {
+		due := reminder
+		repo.EXPECT().ClaimDueReminder(now, gomock.Any()).DoAndReturn(func(_ time.Time, claim func(reminder *model.Reminder)) (*model.Reminder, *model.ApplicationError) {
+			claim(due)
+			return due, nil
+		})
+	}
+	repo.EXPECT().ClaimDueReminder(now, gomock.Any()).Return(nil, nil)
+}
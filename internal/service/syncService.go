@@ -0,0 +1,515 @@
+package service
+
+//go:generate mockgen -source=syncService.go -destination=mock/syncService.go -package=mock
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/repository"
+	"unicode/utf8"
+)
+
+// syncMaxMutations - сколько изменений клиент может передать за один раз
+const syncMaxMutations = 500
+
+// syncMaxClientIdLength - наибольшая длина ClientId, столько вмещает столбец client_id
+const syncMaxClientIdLength = 64
+
+type AbstractSyncService interface {
+	GetChanges(userId int, token string) (*model.SyncChanges, *model.ApplicationError)
+	ApplyMutations(userId int, mutations []*model.SyncMutation) ([]*model.SyncResult, *model.ApplicationError)
+}
+
+type SyncService struct {
+	repo   repository.AbstractRepository
+	events AbstractEventBus
+	origin model.AuditOrigin
+}
+
+func NewConcreteSyncService(repository repository.AbstractRepository, events AbstractEventBus) AbstractSyncService {
+	return &SyncService{
+		repo:   repository,
+		events: events,
+	}
+}
+
+func (s *SyncService) withOrigin(origin model.AuditOrigin) any {
+	service := *s
+	service.origin = origin
+	return &service
+}
+
+// GetChanges возвращает заметки, папки и удаления после позиции из токена. Без токена возвращается весь блокнот.
+// Всё читается из одного снимка базы: изменения пользователя фиксируются в порядке своих позиций, поэтому
+// изменение, не попавшее в снимок, получит позицию больше возвращённого токена и придёт при следующей синхронизации.
+func (s *SyncService) GetChanges(userId int, token string) (*model.SyncChanges, *model.ApplicationError) {
+	since, err := model.ParseSyncToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes *model.SyncChanges
+	err = s.repo.SnapshotTransaction(func(tx repository.AbstractRepository) *model.ApplicationError {
+		changes = readChanges(tx, userId, since)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func readChanges(repo repository.AbstractRepository, userId int, since int64) *model.SyncChanges {
+	lastSeq := since
+	changes := &model.SyncChanges{
+		Notes:      make([]*model.SyncNote, 0),
+		Folders:    make([]*model.SyncFolder, 0),
+		Tombstones: make([]*model.Tombstone, 0),
+	}
+
+	notes := repo.GetNotesChangedAfter(userId, since)
+	checklistIds := make([]int, 0)
+	for _, note := range notes {
+		changes.Notes = append(changes.Notes, model.ToSyncNote(note))
+		lastSeq = max(lastSeq, note.ChangeSeq)
+
+		if note.IsChecklist() {
+			checklistIds = append(checklistIds, note.Id)
+		}
+	}
+
+	if len(checklistIds) > 0 {
+		setSyncChecklists(changes.Notes, repo.GetChecklistItemsByNoteIds(checklistIds))
+	}
+
+	for _, folder := range repo.GetFoldersChangedAfter(userId, since) {
+		changes.Folders = append(changes.Folders, model.ToSyncFolder(folder))
+		lastSeq = max(lastSeq, folder.ChangeSeq)
+	}
+
+	// При первой синхронизации удалять на клиенте нечего
+	if since > 0 {
+		for _, tombstone := range repo.GetTombstonesAfter(userId, since) {
+			changes.Tombstones = append(changes.Tombstones, tombstone)
+			lastSeq = max(lastSeq, tombstone.ChangeSeq)
+		}
+	}
+
+	changes.Token = model.FormatSyncToken(lastSeq)
+	return changes
+}
+
+// ApplyMutations применяет изменения клиента по порядку в одной транзакции под блокировкой изменений
+// пользователя, так что версия не может измениться между сверкой и записью. Изменение с конфликтом версий
+// или ошибкой проверки пропускается и попадает в результат, остальные применяются. Ошибка базы данных
+// отменяет весь пакет.
+func (s *SyncService) ApplyMutations(userId int, mutations []*model.SyncMutation) ([]*model.SyncResult, *model.ApplicationError) {
+	if len(mutations) > syncMaxMutations {
+		return nil, model.NewLocalizedError(model.ErrorTypeValidation, model.CodeSyncTooManyMutations, model.ErrorParams{"max": syncMaxMutations}, nil)
+	}
+
+	var results []*model.SyncResult
+	err := commitChanges(s.repo, s.events, s.origin, func(tx repository.AbstractRepository) ([]*model.Event, *model.ApplicationError) {
+		// Версии сверяются с прочитанными записями, поэтому до конца пакета никто другой
+		// не должен изменить заметки и папки пользователя
+		if err := tx.LockUserChanges(userId); err != nil {
+			return nil, err
+		}
+
+		batch := &syncBatch{
+			repo:      tx,
+			userId:    userId,
+			noteIds:   make(map[string]int),
+			folderIds: make(map[string]int),
+		}
+
+		results = make([]*model.SyncResult, 0, len(mutations))
+		for _, mutation := range mutations {
+			result := model.NewSyncResult(mutation)
+			if err := batch.apply(mutation, result); err != nil {
+				if err.Type == model.ErrorTypeDatabase {
+					return nil, err
+				}
+				result.SetFailed(model.SyncStatusFailed, err)
+			}
+			results = append(results, result)
+		}
+
+		return batch.changes, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// syncBatch - состояние применения одного пакета изменений. noteIds и folderIds сопоставляют
+// ClientId созданных в пакете записей с их идентификаторами на сервере.
+type syncBatch struct {
+	repo      repository.AbstractRepository
+	userId    int
+	noteIds   map[string]int
+	folderIds map[string]int
+	changes   []*model.Event
+}
+
+func (b *syncBatch) apply(mutation *model.SyncMutation, result *model.SyncResult) *model.ApplicationError {
+	// Слишком длинный ClientId не поместится в столбец, и ошибка базы отменила бы весь пакет
+	for _, clientId := range []string{mutation.ClientId, mutation.FolderClientId} {
+		if utf8.RuneCountInString(clientId) > syncMaxClientIdLength {
+			return model.NewLocalizedError(model.ErrorTypeValidation, model.CodeSyncClientIdTooLong,
+				model.ErrorParams{"max": syncMaxClientIdLength}, nil)
+		}
+	}
+
+	switch mutation.Entity {
+	case model.SyncEntityNote:
+		switch mutation.Operation {
+		case model.SyncOperationCreate:
+			return b.createNote(mutation, result)
+		case model.SyncOperationUpdate:
+			return b.updateNote(mutation, result)
+		case model.SyncOperationDelete:
+			return b.deleteNote(mutation, result)
+		}
+	case model.SyncEntityFolder:
+		switch mutation.Operation {
+		case model.SyncOperationCreate:
+			return b.createFolder(mutation, result)
+		case model.SyncOperationUpdate:
+			return b.updateFolder(mutation, result)
+		case model.SyncOperationDelete:
+			return b.deleteFolder(mutation, result)
+		}
+	}
+
+	return model.NewLocalizedError(model.ErrorTypeValidation, model.CodeSyncMutationUnknown,
+		model.ErrorParams{"operation": mutation.Operation, "entity": mutation.Entity}, nil)
+}
+
+func (b *syncBatch) createNote(mutation *model.SyncMutation, result *model.SyncResult) *model.ApplicationError {
+	if mutation.ClientId == "" {
+		return model.NewLocalizedError(model.ErrorTypeValidation, model.CodeSyncClientIdRequired, nil, nil)
+	}
+
+	// Пакет мог уже прийти раньше, если клиент не дождался ответа
+	existing, err := b.repo.GetNoteByClientId(mutation.ClientId, b.userId)
+	if err == nil {
+		b.noteIds[mutation.ClientId] = existing.Id
+		result.SetEntity(existing.Id, existing.Version)
+		return nil
+	}
+	if err.Type != model.ErrorTypeNotFound {
+		return err
+	}
+
+	note, err := model.NewNote(mutation.Title, mutation.Content, b.userId, mutation.Tags)
+	if err != nil {
+		return err
+	}
+
+	notes := b.repo.GetNotesByUserId(b.userId)
+	if !isNoteTitleFree(notes, note.Title, 0) {
+		return model.NewLocalizedError(model.ErrorTypeConflict, model.CodeNoteTitleTaken, nil, nil)
+	}
+
+	if note.FolderId, err = b.resolveFolderId(mutation); err != nil {
+		return err
+	}
+
+	if note.Position, err = getPositionAtEnd(getNoteSiblings(notes, note.FolderId, false, 0)); err != nil {
+		return err
+	}
+
+	clientId := mutation.ClientId
+	note.ClientId = &clientId
+	note.IsFavorite = mutation.IsFavorite
+
+	if note.Id, err = b.repo.SaveEntity(note); err != nil {
+		return err
+	}
+
+	links := model.ParseNoteLinks(note.Id, b.userId, note.Content)
+	if len(links) > 0 {
+		if err = b.repo.ReplaceNoteLinks(note.Id, links); err != nil {
+			return err
+		}
+	}
+
+	b.noteIds[clientId] = note.Id
+	b.changes = append(b.changes, model.NewNoteEvent(model.EventNoteCreated, note))
+	result.SetEntity(note.Id, note.Version)
+	return nil
+}
+
+func (b *syncBatch) updateNote(mutation *model.SyncMutation, result *model.SyncResult) *model.ApplicationError {
+	note, err := b.findNote(mutation, result)
+	if err != nil || result.Status == model.SyncStatusConflict {
+		return err
+	}
+	if note == nil {
+		result.SetFailed(model.SyncStatusConflict, model.NewLocalizedError(model.ErrorTypeConflict, model.CodeSyncEntityDeleted, nil, nil))
+		return nil
+	}
+
+	noteModel, err := model.NewNote(mutation.Title, mutation.Content, b.userId, mutation.Tags)
+	if err != nil {
+		return err
+	}
+
+	notes := b.repo.GetNotesByUserId(b.userId)
+	if !isNoteTitleFree(notes, noteModel.Title, note.Id) {
+		return model.NewLocalizedError(model.ErrorTypeConflict, model.CodeNoteTitleTaken, nil, nil)
+	}
+
+	folderId, err := b.resolveFolderId(mutation)
+	if err != nil {
+		return err
+	}
+
+	// Как и при переносе через API, в другой папке заметка встаёт в конец незакреплённых
+	if !isSameFolder(note.FolderId, folderId) {
+		if note.Position, err = getPositionAtEnd(getNoteSiblings(notes, folderId, false, note.Id)); err != nil {
+			return err
+		}
+		note.IsPinned = false
+	}
+
+	note.Title = noteModel.Title
+	note.Content = noteModel.Content
+	note.Tags = noteModel.Tags
+	note.FolderId = folderId
+	note.IsFavorite = mutation.IsFavorite
+
+	if err = saveNoteWithLinks(b.repo, note, mutation.LockToken); err != nil {
+		return err
+	}
+
+	b.changes = append(b.changes, model.NewNoteEvent(model.EventNoteUpdated, note))
+	result.SetEntity(note.Id, note.Version)
+	return nil
+}
+
+func (b *syncBatch) deleteNote(mutation *model.SyncMutation, result *model.SyncResult) *model.ApplicationError {
+	note, err := b.findNote(mutation, result)
+	// Удаление уже удалённой заметки ничего не меняет
+	if err != nil || note == nil || result.Status == model.SyncStatusConflict {
+		return err
+	}
+
+	if err = deleteNote(b.repo, note, mutation.LockToken); err != nil {
+		return err
+	}
+
+	b.changes = append(b.changes, model.NewNoteEvent(model.EventNoteDeleted, note))
+	result.SetEntity(note.Id, note.Version)
+	return nil
+}
+
+// findNote находит заметку по Id или ClientId и сверяет её версию с версией клиента. Для удалённой
+// заметки возвращается nil, при другой версии в результат записывается конфликт с текущим состоянием.
+func (b *syncBatch) findNote(mutation *model.SyncMutation, result *model.SyncResult) (*model.Note, *model.ApplicationError) {
+	var note *model.Note
+	var err *model.ApplicationError
+
+	switch {
+	case mutation.Id != nil:
+		note, err = b.repo.GetNoteById(*mutation.Id, b.userId)
+	case mutation.ClientId != "":
+		if id, exists := b.noteIds[mutation.ClientId]; exists {
+			note, err = b.repo.GetNoteById(id, b.userId)
+		} else {
+			note, err = b.repo.GetNoteByClientId(mutation.ClientId, b.userId)
+		}
+	default:
+		return nil, model.NewLocalizedError(model.ErrorTypeValidation, model.CodeSyncClientIdRequired, nil, nil)
+	}
+
+	if err != nil {
+		if err.Type == model.ErrorTypeNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if note.Version != mutation.Version {
+		result.SetFailed(model.SyncStatusConflict, model.NewLocalizedError(model.ErrorTypeConflict, model.CodeSyncVersionConflict,
+			model.ErrorParams{"version": mutation.Version}, nil))
+		result.SetEntity(note.Id, note.Version)
+		result.Note = model.ToSyncNote(note)
+	}
+
+	return note, nil
+}
+
+// resolveFolderId возвращает папку заметки: созданную в пакете по FolderClientId, существующую по FolderId
+// или корень блокнота
+func (b *syncBatch) resolveFolderId(mutation *model.SyncMutation) (*int, *model.ApplicationError) {
+	var folder *model.Folder
+	var err *model.ApplicationError
+
+	switch {
+	case mutation.FolderClientId != "":
+		if id, exists := b.folderIds[mutation.FolderClientId]; exists {
+			folder, err = b.repo.GetFolderById(id, b.userId)
+		} else {
+			folder, err = b.repo.GetFolderByClientId(mutation.FolderClientId, b.userId)
+		}
+
+		if err != nil && err.Type == model.ErrorTypeNotFound {
+			return nil, model.NewLocalizedError(model.ErrorTypeValidation, model.CodeSyncClientIdUnknown,
+				model.ErrorParams{"clientId": mutation.FolderClientId}, nil)
+		}
+	case mutation.FolderId != nil:
+		folder, err = b.repo.GetFolderById(*mutation.FolderId, b.userId)
+	default:
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if folder.IsArchived {
+		return nil, model.NewLocalizedError(model.ErrorTypeValidation, model.CodeFolderArchived, nil, nil)
+	}
+
+	return &folder.Id, nil
+}
+
+func (b *syncBatch) createFolder(mutation *model.SyncMutation, result *model.SyncResult) *model.ApplicationError {
+	if mutation.ClientId == "" {
+		return model.NewLocalizedError(model.ErrorTypeValidation, model.CodeSyncClientIdRequired, nil, nil)
+	}
+
+	existing, err := b.repo.GetFolderByClientId(mutation.ClientId, b.userId)
+	if err == nil {
+		b.folderIds[mutation.ClientId] = existing.Id
+		result.SetEntity(existing.Id, existing.Version)
+		return nil
+	}
+	if err.Type != model.ErrorTypeNotFound {
+		return err
+	}
+
+	folder, err := model.NewFolder(mutation.Title, b.userId)
+	if err != nil {
+		return err
+	}
+
+	folders := b.repo.GetFoldersByUserId(b.userId)
+	if !isFolderTitleFree(folders, folder.Title, 0) {
+		return model.NewLocalizedError(model.ErrorTypeConflict, model.CodeFolderTitleTaken, nil, nil)
+	}
+
+	if folder.Position, err = getPositionAtEnd(getFolderSiblings(folders, 0)); err != nil {
+		return err
+	}
+
+	clientId := mutation.ClientId
+	folder.ClientId = &clientId
+
+	if folder.Id, err = b.repo.SaveEntity(folder); err != nil {
+		return err
+	}
+
+	b.folderIds[clientId] = folder.Id
+	b.changes = append(b.changes, model.NewFolderEvent(model.EventFolderCreated, folder))
+	result.SetEntity(folder.Id, folder.Version)
+	return nil
+}
+
+func (b *syncBatch) updateFolder(mutation *model.SyncMutation, result *model.SyncResult) *model.ApplicationError {
+	folder, err := b.findFolder(mutation, result)
+	if err != nil || result.Status == model.SyncStatusConflict {
+		return err
+	}
+	if folder == nil {
+		result.SetFailed(model.SyncStatusConflict, model.NewLocalizedError(model.ErrorTypeConflict, model.CodeSyncEntityDeleted, nil, nil))
+		return nil
+	}
+
+	folderModel, err := model.NewFolder(mutation.Title, b.userId)
+	if err != nil {
+		return err
+	}
+
+	if !isFolderTitleFree(b.repo.GetFoldersByUserId(b.userId), folderModel.Title, folder.Id) {
+		return model.NewLocalizedError(model.ErrorTypeConflict, model.CodeFolderTitleTaken, nil, nil)
+	}
+
+	folder.Title = folderModel.Title
+
+	if _, err = b.repo.SaveEntity(folder); err != nil {
+		return err
+	}
+
+	b.changes = append(b.changes, model.NewFolderEvent(model.EventFolderRenamed, folder))
+	result.SetEntity(folder.Id, folder.Version)
+	return nil
+}
+
+func (b *syncBatch) deleteFolder(mutation *model.SyncMutation, result *model.SyncResult) *model.ApplicationError {
+	folder, err := b.findFolder(mutation, result)
+	if err != nil || folder == nil || result.Status == model.SyncStatusConflict {
+		return err
+	}
+
+	if err = b.repo.DeleteEntity(folder); err != nil {
+		return err
+	}
+
+	b.changes = append(b.changes, model.NewFolderEvent(model.EventFolderDeleted, folder))
+	result.SetEntity(folder.Id, folder.Version)
+	return nil
+}
+
+// findFolder - то же, что findNote, для папок
+func (b *syncBatch) findFolder(mutation *model.SyncMutation, result *model.SyncResult) (*model.Folder, *model.ApplicationError) {
+	var folder *model.Folder
+	var err *model.ApplicationError
+
+	switch {
+	case mutation.Id != nil:
+		folder, err = b.repo.GetFolderById(*mutation.Id, b.userId)
+	case mutation.ClientId != "":
+		if id, exists := b.folderIds[mutation.ClientId]; exists {
+			folder, err = b.repo.GetFolderById(id, b.userId)
+		} else {
+			folder, err = b.repo.GetFolderByClientId(mutation.ClientId, b.userId)
+		}
+	default:
+		return nil, model.NewLocalizedError(model.ErrorTypeValidation, model.CodeSyncClientIdRequired, nil, nil)
+	}
+
+	if err != nil {
+		if err.Type == model.ErrorTypeNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if folder.Version != mutation.Version {
+		result.SetFailed(model.SyncStatusConflict, model.NewLocalizedError(model.ErrorTypeConflict, model.CodeSyncVersionConflict,
+			model.ErrorParams{"version": mutation.Version}, nil))
+		result.SetEntity(folder.Id, folder.Version)
+		result.Folder = model.ToSyncFolder(folder)
+	}
+
+	return folder, nil
+}
+
+// setSyncChecklists заполняет пункты заметок-списков. Пункты должны быть упорядочены по позиции.
+func setSyncChecklists(notes []*model.SyncNote, items []*model.ChecklistItem) {
+	itemsByNote := make(map[int][]*model.ChecklistItem)
+	for _, item := range items {
+		itemsByNote[item.NoteId] = append(itemsByNote[item.NoteId], item)
+	}
+
+	for _, note := range notes {
+		if note.Type == model.NoteTypeChecklist {
+			note.Items = model.ToChecklistItemsApi(itemsByNote[note.Id])
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package service
+
+import (
+	"Notes/internal/model"
+	"Notes/internal/repository"
+	mocks "Notes/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"strings"
+	"testing"
+	"time"
+)
+
+func initSyncServiceTest(t *testing.T) (AbstractSyncService, *mocks.MockAbstractRepository) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockAbstractRepository(ctrl)
+	expectChangeLog(mockRepository)
+	mockRepository.EXPECT().LockUserChanges(gomock.Any()).Return(nil).AnyTimes()
+	mockRepository.EXPECT().SnapshotTransaction(gomock.Any()).DoAndReturn(func(fn func(tx repository.AbstractRepository) *model.ApplicationError) *model.ApplicationError {
+		return fn(mockRepository)
+	}).AnyTimes()
+	mockEventBus := mocks.NewMockAbstractEventBus(ctrl)
+	mockEventBus.EXPECT().Publish(gomock.Any()).AnyTimes()
+
+	return NewConcreteSyncService(mockRepository, mockEventBus), mockRepository
+}
+
+// saveVersioned ведёт себя как репозиторий: назначает id новой записи и увеличивает версию
+func saveVersioned(id int) func(entity model.BusinessEntity) (int, *model.ApplicationError) {
+	return func(entity model.BusinessEntity) (int, *model.ApplicationError) {
+		if entity.GetId() == 0 {
+			entity.SetId(id)
+		}
+		entity.(model.VersionedEntity).SetChange(int64(id))
+		return entity.GetId(), nil
+	}
+}
+
+func TestConcreteSyncService_GetChanges(t *testing.T) {
+	syncService, repo := initSyncServiceTest(t)
+
+	tests := []struct {
+		name           string
+		mock           func()
+		token          string
+		wantToken      string
+		wantNotes      int
+		wantTombstones int
+		wantErr        bool
+	}{
+		{
+			name: "first sync returns the whole notebook without tombstones",
+			mock: func() {
+				repo.EXPECT().GetNotesChangedAfter(1, int64(0)).Return([]*model.Note{
+					{Id: 1, UserId: 1, Type: model.NoteTypeText, ChangeSeq: 5},
+					{Id: 2, UserId: 1, Type: model.NoteTypeChecklist, ChangeSeq: 9},
+				})
+				repo.EXPECT().GetChecklistItemsByNoteIds([]int{2}).Return([]*model.ChecklistItem{{Id: 20, NoteId: 2, Text: "milk"}})
+				repo.EXPECT().GetFoldersChangedAfter(1, int64(0)).Return([]*model.Folder{{Id: 3, UserId: 1, ChangeSeq: 7}})
+			},
+			token:     "",
+			wantToken: "9",
+			wantNotes: 2,
+		},
+		{
+			name: "tombstones after the token are returned",
+			mock: func() {
+				repo.EXPECT().GetNotesChangedAfter(1, int64(9)).Return(nil)
+				repo.EXPECT().GetFoldersChangedAfter(1, int64(9)).Return(nil)
+				repo.EXPECT().GetTombstonesAfter(1, int64(9)).Return([]*model.Tombstone{{Entity: model.SyncEntityNote, EntityId: 1, ChangeSeq: 12}})
+			},
+			token:          "9",
+			wantToken:      "12",
+			wantTombstones: 1,
+		},
+		{
+			name: "token is kept when nothing changed",
+			mock: func() {
+				repo.EXPECT().GetNotesChangedAfter(1, int64(12)).Return(nil)
+				repo.EXPECT().GetFoldersChangedAfter(1, int64(12)).Return(nil)
+				repo.EXPECT().GetTombstonesAfter(1, int64(12)).Return(nil)
+			},
+			token:     "12",
+			wantToken: "12",
+		},
+		{
+			name:    "invalid token",
+			mock:    func() {},
+			token:   "abc",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			changes, err := syncService.GetChanges(1, tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetChanges() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if changes.Token != tt.wantToken {
+				t.Errorf("Token = %s, want %s", changes.Token, tt.wantToken)
+			}
+			if len(changes.Notes) != tt.wantNotes || len(changes.Tombstones) != tt.wantTombstones {
+				t.Errorf("got %d notes and %d tombstones, want %d and %d",
+					len(changes.Notes), len(changes.Tombstones), tt.wantNotes, tt.wantTombstones)
+			}
+			for _, note := range changes.Notes {
+				if note.Type == model.NoteTypeChecklist && len(note.Items) != 1 {
+					t.Errorf("checklist note %d has %d items, want 1", note.Id, len(note.Items))
+				}
+			}
+		})
+	}
+}
+
+func TestConcreteSyncService_ApplyMutations(t *testing.T) {
+	syncService, repo := initSyncServiceTest(t)
+	expectNoNoteLock(repo)
+
+	noteId := 5
+
+	tests := []struct {
+		name       string
+		mock       func()
+		mutations  []*model.SyncMutation
+		wantStatus []model.SyncStatus
+		wantErr    bool
+	}{
+		{
+			name: "note is created in the folder created earlier in the batch",
+			mock: func() {
+				repo.EXPECT().GetFolderByClientId("f1", 1).Return(nil, repository.EntityNotFoundError)
+				repo.EXPECT().GetFoldersByUserId(1).Return([]*model.Folder{})
+				repo.EXPECT().SaveEntity(gomock.AssignableToTypeOf(&model.Folder{})).DoAndReturn(saveVersioned(10))
+				repo.EXPECT().GetNoteByClientId("n1", 1).Return(nil, repository.EntityNotFoundError)
+				repo.EXPECT().GetNotesByUserId(1).Return([]*model.Note{})
+				repo.EXPECT().GetFolderById(10, 1).Return(&model.Folder{Id: 10, UserId: 1}, nil)
+				repo.EXPECT().SaveEntity(gomock.AssignableToTypeOf(&model.Note{})).DoAndReturn(func(entity model.BusinessEntity) (int, *model.ApplicationError) {
+					note := entity.(*model.Note)
+					if note.FolderId == nil || *note.FolderId != 10 || note.ClientId == nil || *note.ClientId != "n1" {
+						t.Errorf("saved note = %+v, want note n1 in folder 10", note)
+					}
+					return saveVersioned(11)(entity)
+				})
+			},
+			mutations: []*model.SyncMutation{
+				{Entity: model.SyncEntityFolder, Operation: model.SyncOperationCreate, ClientId: "f1", Title: "Работа"},
+				{Entity: model.SyncEntityNote, Operation: model.SyncOperationCreate, ClientId: "n1", Title: "План", Content: "текст", FolderClientId: "f1"},
+			},
+			wantStatus: []model.SyncStatus{model.SyncStatusApplied, model.SyncStatusApplied},
+		},
+		{
+			name: "repeated create returns the existing note",
+			mock: func() {
+				repo.EXPECT().GetNoteByClientId("n1", 1).Return(&model.Note{Id: 11, UserId: 1, Version: 1}, nil)
+			},
+			mutations: []*model.SyncMutation{
+				{Entity: model.SyncEntityNote, Operation: model.SyncOperationCreate, ClientId: "n1", Title: "План", Content: "текст"},
+			},
+			wantStatus: []model.SyncStatus{model.SyncStatusApplied},
+		},
+		{
+			name: "update of an outdated version is a conflict, the rest of the batch is applied",
+			mock: func() {
+				repo.EXPECT().GetNoteById(5, 1).Return(&model.Note{Id: 5, UserId: 1, Title: "Старое", Content: "текст", Version: 3}, nil)
+				repo.EXPECT().GetNoteById(6, 1).Return(&model.Note{Id: 6, UserId: 1, Title: "Другое", Content: "текст", Version: 2}, nil)
+				repo.EXPECT().GetNotesByUserId(1).Return([]*model.Note{})
+				repo.EXPECT().SaveEntity(gomock.AssignableToTypeOf(&model.Note{})).DoAndReturn(saveVersioned(6))
+				repo.EXPECT().ReplaceNoteLinks(6, gomock.Any()).Return(nil)
+			},
+			mutations: []*model.SyncMutation{
+				{Entity: model.SyncEntityNote, Operation: model.SyncOperationUpdate, Id: &noteId, Version: 2, Title: "Новое", Content: "текст"},
+				{Entity: model.SyncEntityNote, Operation: model.SyncOperationUpdate, Id: func() *int { id := 6; return &id }(), Version: 2, Title: "Другое", Content: "новый текст"},
+			},
+			wantStatus: []model.SyncStatus{model.SyncStatusConflict, model.SyncStatusApplied},
+		},
+		{
+			name: "update of a deleted note is a conflict, delete of a deleted note is applied",
+			mock: func() {
+				repo.EXPECT().GetNoteById(5, 1).Return(nil, repository.EntityNotFoundError).Times(2)
+			},
+			mutations: []*model.SyncMutation{
+				{Entity: model.SyncEntityNote, Operation: model.SyncOperationUpdate, Id: &noteId, Version: 1, Title: "Новое", Content: "текст"},
+				{Entity: model.SyncEntityNote, Operation: model.SyncOperationDelete, Id: &noteId, Version: 1},
+			},
+			wantStatus: []model.SyncStatus{model.SyncStatusConflict, model.SyncStatusApplied},
+		},
+		{
+			name: "delete of a changed note is a conflict",
+			mock: func() {
+				repo.EXPECT().GetNoteById(5, 1).Return(&model.Note{Id: 5, UserId: 1, Version: 4}, nil)
+			},
+			mutations: []*model.SyncMutation{
+				{Entity: model.SyncEntityNote, Operation: model.SyncOperationDelete, Id: &noteId, Version: 3},
+			},
+			wantStatus: []model.SyncStatus{model.SyncStatusConflict},
+		},
+		{
+			name: "invalid changes fail without stopping the batch",
+			mock: func() {
+				repo.EXPECT().GetNoteById(5, 1).Return(&model.Note{Id: 5, UserId: 1, Version: 1}, nil)
+				repo.EXPECT().DeleteEntity(gomock.AssignableToTypeOf(&model.Note{})).Return(nil)
+			},
+			mutations: []*model.SyncMutation{
+				{Entity: model.SyncEntityNote, Operation: model.SyncOperationCreate, Title: "Без ClientId", Content: "текст"},
+				{Entity: "tag", Operation: model.SyncOperationCreate, ClientId: "t1"},
+				{Entity: model.SyncEntityNote, Operation: model.SyncOperationDelete, Id: &noteId, Version: 1},
+			},
+			wantStatus: []model.SyncStatus{model.SyncStatusFailed, model.SyncStatusFailed, model.SyncStatusApplied},
+		},
+		{
+			name: "too long ClientId fails without stopping the batch",
+			mock: func() {
+				repo.EXPECT().GetNoteById(5, 1).Return(&model.Note{Id: 5, UserId: 1, Version: 1}, nil)
+				repo.EXPECT().DeleteEntity(gomock.AssignableToTypeOf(&model.Note{})).Return(nil)
+			},
+			mutations: []*model.SyncMutation{
+				{Entity: model.SyncEntityNote, Operation: model.SyncOperationCreate, ClientId: strings.Repeat("n", syncMaxClientIdLength+1), Title: "План", Content: "текст"},
+				{Entity: model.SyncEntityNote, Operation: model.SyncOperationCreate, ClientId: "n2", Title: "План", Content: "текст", FolderClientId: strings.Repeat("f", syncMaxClientIdLength+1)},
+				{Entity: model.SyncEntityNote, Operation: model.SyncOperationDelete, Id: &noteId, Version: 1},
+			},
+			wantStatus: []model.SyncStatus{model.SyncStatusFailed, model.SyncStatusFailed, model.SyncStatusApplied},
+		},
+		{
+			name: "database error cancels the batch",
+			mock: func() {
+				repo.EXPECT().GetNoteById(5, 1).Return(&model.Note{Id: 5, UserId: 1, Version: 1}, nil)
+				repo.EXPECT().DeleteEntity(gomock.AssignableToTypeOf(&model.Note{})).Return(repository.DataBaseError)
+			},
+			mutations: []*model.SyncMutation{
+				{Entity: model.SyncEntityNote, Operation: model.SyncOperationDelete, Id: &noteId, Version: 1},
+			},
+			wantErr: true,
+		},
+		{
+			name:      "too many changes",
+			mock:      func() {},
+			mutations: make([]*model.SyncMutation, syncMaxMutations+1),
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			results, err := syncService.ApplyMutations(1, tt.mutations)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ApplyMutations() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if len(results) != len(tt.wantStatus) {
+				t.Fatalf("got %d results, want %d", len(results), len(tt.wantStatus))
+			}
+			for i, result := range results {
+				if result.Status != tt.wantStatus[i] {
+					t.Errorf("result %d = %+v, want status %s", i, result, tt.wantStatus[i])
+				}
+				if result.Status == model.SyncStatusApplied && result.Operation != model.SyncOperationDelete && result.Id == nil {
+					t.Errorf("result %d has no Id", i)
+				}
+			}
+		})
+	}
+}
+
+func TestConcreteSyncService_ApplyMutationsLocked(t *testing.T) {
+	syncService, repo := initSyncServiceTest(t)
+	lock := model.NewNoteLock(5, 1, "held-token", time.Now().UTC().Add(time.Minute))
+	noteId := 5
+
+	repo.EXPECT().GetNoteById(5, 1).Return(&model.Note{Id: 5, UserId: 1, Title: "Старое", Content: "текст", Version: 2}, nil).Times(3)
+	repo.EXPECT().GetNotesByUserId(1).Return([]*model.Note{}).Times(2)
+	repo.EXPECT().GetNoteLock(5, gomock.Any()).Return(lock, nil).Times(3)
+	repo.EXPECT().SaveEntity(gomock.AssignableToTypeOf(&model.Note{})).DoAndReturn(saveVersioned(5))
+	repo.EXPECT().ReplaceNoteLinks(5, gomock.Any()).Return(nil)
+
+	results, err := syncService.ApplyMutations(1, []*model.SyncMutation{
+		{Entity: model.SyncEntityNote, Operation: model.SyncOperationUpdate, Id: &noteId, Version: 2, Title: "Новое", Content: "текст"},
+		{Entity: model.SyncEntityNote, Operation: model.SyncOperationDelete, Id: &noteId, Version: 2},
+		{Entity: model.SyncEntityNote, Operation: model.SyncOperationUpdate, Id: &noteId, Version: 2, Title: "Новое", Content: "текст", LockToken: "held-token"},
+	})
+	if err != nil {
+		t.Fatalf("ApplyMutations() error = %v", err)
+	}
+
+	want := []model.SyncStatus{model.SyncStatusFailed, model.SyncStatusFailed, model.SyncStatusApplied}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(results), len(want))
+	}
+	for i, result := range results {
+		if result.Status != want[i] {
+			t.Errorf("result %d = %+v, want status %s", i, result, want[i])
+		}
+		if result.Status == model.SyncStatusFailed && result.Code != model.CodeNoteLocked {
+			t.Errorf("result %d code = %s, want %s", i, result.Code, model.CodeNoteLocked)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"Notes/internal/constants"
+	"Notes/internal/model"
+	"Notes/internal/repository"
+	"time"
+)
+
+//go:generate mockgen -source=templateService.go -destination=mock/templateService.go -package=mock
+
+type AbstractTemplateService interface {
+	CreateTemplate(userId int, settings model.TemplateSettings) (int, *model.ApplicationError)
+	UpdateTemplate(userId int, id int, settings model.TemplateSettings) *model.ApplicationError
+	DeleteTemplate(userId int, id int) *model.ApplicationError
+	GetTemplate(userId int, id int) (*model.TemplateApi, *model.ApplicationError)
+	GetTemplates(userId int) []*model.TemplateApi
+	CreateNoteFromTemplate(userId int, id int, values map[string]string) (int, *model.ApplicationError)
+}
+
+type TemplateService struct {
+	repo        repository.AbstractRepository
+	noteService AbstractNoteService
+	now         func() time.Time
+}
+
+func NewConcreteTemplateService(repository repository.AbstractRepository, noteService AbstractNoteService) AbstractTemplateService {
+	return &TemplateService{
+		repo:        repository,
+		noteService: noteService,
+		now:         time.Now,
+	}
+}
+
+func (t *TemplateService) withOrigin(origin model.AuditOrigin) any {
+	service := *t
+	service.noteService = WithOrigin(t.noteService, origin)
+	return &service
+}
+
+func (t *TemplateService) CreateTemplate(userId int, settings model.TemplateSettings) (int, *model.ApplicationError) {
+	template, err := model.NewTemplate(userId, settings)
+	if err != nil {
+		return constants.FakeId, err
+	}
+
+	if err = t.validateTemplate(template); err != nil {
+		return constants.FakeId, err
+	}
+
+	return t.repo.SaveEntity(template)
+}
+
+func (t *TemplateService) UpdateTemplate(userId int, id int, settings model.TemplateSettings) *model.ApplicationError {
+	templateModel, err := model.NewTemplate(userId, settings)
+	if err != nil {
+		return err
+	}
+
+	templateDb, err := t.repo.GetTemplateById(id, userId)
+	if err != nil {
+		return err
+	}
+
+	templateModel.Id = templateDb.Id
+
+	if err = t.validateTemplate(templateModel); err != nil {
+		return err
+	}
+
+	_, errSave := t.repo.SaveEntity(templateModel)
+	return errSave
+}
+
+func (t *TemplateService) DeleteTemplate(userId int, id int) *model.ApplicationError {
+	template, err := t.repo.GetTemplateById(id, userId)
+
+	if err != nil {
+		if err.Type == model.ErrorTypeNotFound {
+			return nil
+		}
+
+		return err
+	}
+
+	return t.repo.DeleteEntity(template)
+}
+
+func (t *TemplateService) GetTemplate(userId int, id int) (*model.TemplateApi, *model.ApplicationError) {
+	template, err := t.repo.GetTemplateById(id, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.ToTemplateApi(template), nil
+}
+
+func (t *TemplateService) GetTemplates(userId int) []*model.TemplateApi {
+	templates := make([]*model.TemplateApi, 0)
+
+	for _, template := range t.repo.GetTemplatesByUserId(userId) {
+		templates = append(templates, model.ToTemplateApi(template))
+	}
+
+	return templates
+}
+
+// CreateNoteFromTemplate создаёт заметку из шаблона через NoteService, поэтому к результату
+// применяются все обычные проверки заметки: длина текста, теги и уникальность названия.
+func (t *TemplateService) CreateNoteFromTemplate(userId int, id int, values map[string]string) (int, *model.ApplicationError) {
+	template, err := t.repo.GetTemplateById(id, userId)
+	if err != nil {
+		return constants.FakeId, err
+	}
+
+	user, err := t.repo.GetUserById(userId)
+	if err != nil {
+		return constants.FakeId, err
+	}
+
+	rendered, err := template.Render(model.TemplateContext{Now: t.now(), User: user}, values)
+	if err != nil {
+		return constants.FakeId, err
+	}
+
+	return createNoteInFolder(t.noteService, userId, rendered.Title, rendered.Content, rendered.Tags, rendered.FolderId)
+}
+
+func (t *TemplateService) validateTemplate(template *model.Template) *model.ApplicationError {
+	for _, item := range t.repo.GetTemplatesByUserId(template.UserId) {
+		if item.Name == template.Name && item.Id != template.Id {
+			return model.NewLocalizedError(model.ErrorTypeConflict, model.CodeTemplateNameTaken, nil, nil)
+		}
+	}
+
+	if template.FolderId != nil {
+		if _, err := t.repo.GetFolderById(*template.FolderId, template.UserId); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
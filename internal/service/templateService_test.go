@@ -0,0 +1,238 @@
+package service
+
+import (
+	"Notes/internal/constants"
+	"Notes/internal/model"
+	mocks "Notes/internal/service/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"testing"
+	"time"
+)
+
+type templateTestArgs struct {
+	id       int
+	settings model.TemplateSettings
+	values   map[string]string
+}
+
+func initTemplateServiceTest(t *testing.T) (AbstractTemplateService, *mocks.MockAbstractRepository, *mocks.MockAbstractNoteService) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockAbstractRepository(ctrl)
+	mockNoteService := mocks.NewMockAbstractNoteService(ctrl)
+
+	templateService := NewConcreteTemplateService(mockRepository, mockNoteService)
+	templateService.(*TemplateService).now = func() time.Time {
+		return time.Date(2026, 3, 4, 9, 30, 0, 0, time.UTC)
+	}
+
+	return templateService, mockRepository, mockNoteService
+}
+
+func TestConcreteTemplateService_CreateTemplate(t *testing.T) {
+	templateService, repo, _ := initTemplateServiceTest(t)
+
+	tests := []struct {
+		name    string
+		mock    func()
+		args    templateTestArgs
+		want    int
+		wantErr *model.ApplicationError
+	}{
+		{
+			name: "empty name",
+			mock: func() {},
+			args: templateTestArgs{
+				settings: model.TemplateSettings{Title: "title"},
+			},
+			want:    constants.FakeId,
+			wantErr: model.NewApplicationError(model.ErrorTypeValidation, "Название шаблона не может быть пустым", nil),
+		},
+		{
+			name: "unknown variable",
+			mock: func() {},
+			args: templateTestArgs{
+				settings: model.TemplateSettings{Name: "daily", Title: "{{weather}}"},
+			},
+			want:    constants.FakeId,
+			wantErr: model.NewApplicationError(model.ErrorTypeValidation, "Неизвестная переменная шаблона: {{weather}}", nil),
+		},
+		{
+			name: "duplicate name",
+			mock: func() {
+				repo.EXPECT().GetTemplatesByUserId(1).Return([]*model.Template{{Id: 3, UserId: 1, Name: "daily"}})
+			},
+			args: templateTestArgs{
+				settings: model.TemplateSettings{Name: "daily", Title: "{{date}}"},
+			},
+			want:    constants.FakeId,
+			wantErr: model.NewLocalizedError(model.ErrorTypeConflict, model.CodeTemplateNameTaken, nil, nil),
+		},
+		{
+			name: "folder not found",
+			mock: func() {
+				repo.EXPECT().GetTemplatesByUserId(1).Return([]*model.Template{})
+				repo.EXPECT().GetFolderById(5, 1).Return(nil, model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil))
+			},
+			args: templateTestArgs{
+				settings: model.TemplateSettings{Name: "daily", Title: "{{date}}", FolderId: intPointer(5)},
+			},
+			want:    constants.FakeId,
+			wantErr: model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil),
+		},
+		{
+			name: "template saved",
+			mock: func() {
+				repo.EXPECT().GetTemplatesByUserId(1).Return([]*model.Template{})
+				repo.EXPECT().GetFolderById(5, 1).Return(&model.Folder{Id: 5, UserId: 1}, nil)
+				repo.EXPECT().SaveEntity(&model.Template{
+					UserId:   1,
+					Name:     "daily",
+					Title:    "{{date}} {{prompt:Тема}}",
+					Content:  "{{user.name}}",
+					Tags:     pq.StringArray{"meeting"},
+					FolderId: intPointer(5),
+				}).Return(7, nil)
+			},
+			args: templateTestArgs{
+				settings: model.TemplateSettings{
+					Name:     "daily",
+					Title:    "{{date}} {{prompt:Тема}}",
+					Content:  "{{user.name}}",
+					Tags:     &[]string{"meeting"},
+					FolderId: intPointer(5),
+				},
+			},
+			want: 7,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := templateService.CreateTemplate(1, tt.args.settings)
+			if tt.wantErr != nil {
+				if err == nil || err.Type != tt.wantErr.Type || err.Message != tt.wantErr.Message {
+					t.Errorf("TemplateService.CreateTemplate() error = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Errorf("TemplateService.CreateTemplate() unexpected error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("TemplateService.CreateTemplate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConcreteTemplateService_CreateNoteFromTemplate(t *testing.T) {
+	templateService, repo, noteService := initTemplateServiceTest(t)
+
+	user := &model.User{Id: 1, Name: "Иван", Surname: "Петров", Login: "ivanpetrov"}
+	template := &model.Template{
+		Id:      2,
+		UserId:  1,
+		Name:    "meeting",
+		Title:   "Встреча {{date}}: {{ prompt:Тема }}",
+		Content: "{{time}} {{user.name}} {{user.surname}}, тема: {{prompt:Тема}}",
+		Tags:    pq.StringArray{"meeting"},
+	}
+	folderTemplate := &model.Template{Id: 3, UserId: 1, Name: "folder", Title: "{{date}}", FolderId: intPointer(5)}
+
+	tests := []struct {
+		name    string
+		mock    func()
+		args    templateTestArgs
+		want    int
+		wantErr *model.ApplicationError
+	}{
+		{
+			name: "template not found",
+			mock: func() {
+				repo.EXPECT().GetTemplateById(9, 1).Return(nil, model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil))
+			},
+			args:    templateTestArgs{id: 9},
+			want:    constants.FakeId,
+			wantErr: model.NewApplicationError(model.ErrorTypeNotFound, "сущность не найдена", nil),
+		},
+		{
+			name: "missing prompt value",
+			mock: func() {
+				repo.EXPECT().GetTemplateById(2, 1).Return(template, nil)
+				repo.EXPECT().GetUserById(1).Return(user, nil)
+			},
+			args:    templateTestArgs{id: 2},
+			want:    constants.FakeId,
+			wantErr: model.NewApplicationError(model.ErrorTypeValidation, "Не заполнены значения шаблона: Тема", nil),
+		},
+		{
+			name: "note created from rendered template",
+			mock: func() {
+				repo.EXPECT().GetTemplateById(2, 1).Return(template, nil)
+				repo.EXPECT().GetUserById(1).Return(user, nil)
+				noteService.EXPECT().CreateNote(1, "Встреча 2026-03-04: релиз", "09:30 Иван Петров, тема: релиз", &[]string{"meeting"}).Return(4, nil)
+			},
+			args: templateTestArgs{id: 2, values: map[string]string{"Тема": "релиз"}},
+			want: 4,
+		},
+		{
+			name: "note validation applies to rendered template",
+			mock: func() {
+				repo.EXPECT().GetTemplateById(2, 1).Return(template, nil)
+				repo.EXPECT().GetUserById(1).Return(user, nil)
+				noteService.EXPECT().CreateNote(1, "Встреча 2026-03-04: релиз", "09:30 Иван Петров, тема: релиз", &[]string{"meeting"}).
+					Return(constants.FakeId, model.NewLocalizedError(model.ErrorTypeConflict, model.CodeNoteTitleTaken, nil, nil))
+			},
+			args:    templateTestArgs{id: 2, values: map[string]string{"Тема": "релиз"}},
+			want:    constants.FakeId,
+			wantErr: model.NewLocalizedError(model.ErrorTypeConflict, model.CodeNoteTitleTaken, nil, nil),
+		},
+		{
+			name: "note moved to template folder",
+			mock: func() {
+				repo.EXPECT().GetTemplateById(3, 1).Return(folderTemplate, nil)
+				repo.EXPECT().GetUserById(1).Return(user, nil)
+				noteService.EXPECT().CreateNote(1, "2026-03-04", "", &[]string{}).Return(4, nil)
+				noteService.EXPECT().MoveToFolder(1, 4, intPointer(5), "").Return(nil)
+			},
+			args: templateTestArgs{id: 3},
+			want: 4,
+		},
+		{
+			name: "note removed when template folder is unavailable",
+			mock: func() {
+				repo.EXPECT().GetTemplateById(3, 1).Return(folderTemplate, nil)
+				repo.EXPECT().GetUserById(1).Return(user, nil)
+				noteService.EXPECT().CreateNote(1, "2026-03-04", "", &[]string{}).Return(4, nil)
+				noteService.EXPECT().MoveToFolder(1, 4, intPointer(5), "").Return(model.NewLocalizedError(model.ErrorTypeValidation, model.CodeFolderArchived, nil, nil))
+				noteService.EXPECT().DeleteNote(1, 4, "").Return(nil)
+			},
+			args:    templateTestArgs{id: 3},
+			want:    constants.FakeId,
+			wantErr: model.NewLocalizedError(model.ErrorTypeValidation, model.CodeFolderArchived, nil, nil),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			got, err := templateService.CreateNoteFromTemplate(1, tt.args.id, tt.args.values)
+			if tt.wantErr != nil {
+				if err == nil || err.Type != tt.wantErr.Type || err.Message != tt.wantErr.Message {
+					t.Errorf("TemplateService.CreateNoteFromTemplate() error = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Errorf("TemplateService.CreateNoteFromTemplate() unexpected error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("TemplateService.CreateNoteFromTemplate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
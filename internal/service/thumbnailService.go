@@ -0,0 +1,227 @@
+package service
+
+//go:generate mockgen -source=thumbnailService.go -destination=mock/thumbnailService.go -package=mock
+
+import (
+	"Notes/config"
+	"Notes/internal/model"
+	"Notes/internal/repository"
+	"Notes/internal/utils"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+const thumbnailQueueSize = 100
+const thumbnailRescanInterval = time.Minute
+
+type AbstractThumbnailService interface {
+	Run(ctx context.Context)
+	Enqueue(attachmentId int)
+	GenerateThumbnails(attachmentId int) *model.ApplicationError
+	GetDefaultSize() int
+	IsSizeSupported(size int) bool
+}
+
+type ConcreteThumbnailService struct {
+	repo     repository.AbstractRepository
+	storage  repository.AbstractBlobStorage
+	sizes    []int
+	workers  int
+	queue    chan int
+	mu       sync.Mutex
+	inFlight map[int]bool
+}
+
+func NewConcreteThumbnailService(repository repository.AbstractRepository, storage repository.AbstractBlobStorage, cfg *config.Config) AbstractThumbnailService {
+	sizes := append([]int(nil), cfg.Attachments.ThumbnailSizes...)
+	sort.Ints(sizes)
+
+	return &ConcreteThumbnailService{
+		repo:     repository,
+		storage:  storage,
+		sizes:    sizes,
+		workers:  max(1, cfg.Attachments.ThumbnailWorkers),
+		queue:    make(chan int, thumbnailQueueSize),
+		inFlight: make(map[int]bool),
+	}
+}
+
+// Run запускает воркеры и периодически подбирает вложения, оставшиеся в статусе pending
+// (переполненная очередь, перезапуск приложения). Блокируется до отмены ctx.
+func (t *ConcreteThumbnailService) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < t.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			t.work(ctx)
+		}()
+	}
+
+	ticker := time.NewTicker(thumbnailRescanInterval)
+	defer ticker.Stop()
+
+	t.enqueuePending()
+	for {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case <-ticker.C:
+			t.enqueuePending()
+		}
+	}
+}
+
+func (t *ConcreteThumbnailService) Enqueue(attachmentId int) {
+	select {
+	case t.queue <- attachmentId:
+	default:
+		// Очередь заполнена: вложение останется pending и будет подобрано при следующем проходе
+	}
+}
+
+func (t *ConcreteThumbnailService) GenerateThumbnails(attachmentId int) *model.ApplicationError {
+	attachment, err := t.repo.GetAttachmentById(attachmentId)
+
+	if err != nil {
+		if err.Type == model.ErrorTypeNotFound {
+			return nil
+		}
+		return err
+	}
+
+	if attachment.ThumbnailStatus != model.ThumbnailStatusPending {
+		return nil
+	}
+
+	generateErr := t.generate(attachment)
+
+	if generateErr != nil {
+		attachment.ThumbnailStatus = model.ThumbnailStatusFailed
+	} else {
+		attachment.ThumbnailStatus = model.ThumbnailStatusReady
+	}
+
+	_, saveErr := t.repo.SaveEntity(attachment)
+
+	if generateErr != nil {
+		return generateErr
+	}
+
+	return saveErr
+}
+
+func (t *ConcreteThumbnailService) GetDefaultSize() int {
+	if len(t.sizes) == 0 {
+		return 0
+	}
+
+	return t.sizes[0]
+}
+
+func (t *ConcreteThumbnailService) IsSizeSupported(size int) bool {
+	for _, item := range t.sizes {
+		if item == size {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *ConcreteThumbnailService) generate(attachment *model.Attachment) *model.ApplicationError {
+	reader, err := t.storage.Get(attachment.StorageKey)
+	if err != nil {
+		return err
+	}
+
+	original, readErr := io.ReadAll(reader)
+	reader.Close()
+	if readErr != nil {
+		return model.NewLocalizedError(model.ErrorTypeInternal, model.CodeAttachmentReadFailed, nil, readErr)
+	}
+
+	for _, size := range t.sizes {
+		if _, existsErr := t.repo.GetAttachmentThumbnail(attachment.Id, size); existsErr == nil {
+			continue
+		}
+
+		data, contentType, resizeErr := utils.ResizeImage(bytes.NewReader(original), size)
+		if resizeErr != nil {
+			return resizeErr
+		}
+
+		key := getThumbnailStorageKey(attachment.Id, size)
+		if putErr := t.storage.Put(key, bytes.NewReader(data)); putErr != nil {
+			return putErr
+		}
+
+		thumbnail := &model.AttachmentThumbnail{
+			AttachmentId: attachment.Id,
+			Size:         size,
+			ContentType:  contentType,
+			StorageKey:   key,
+		}
+
+		if _, saveErr := t.repo.SaveEntity(thumbnail); saveErr != nil {
+			return saveErr
+		}
+	}
+
+	return nil
+}
+
+func (t *ConcreteThumbnailService) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case attachmentId := <-t.queue:
+			if !t.acquire(attachmentId) {
+				continue
+			}
+
+			if err := t.GenerateThumbnails(attachmentId); err != nil {
+				log.Printf("Ошибка при формировании миниатюр вложения %d: %v", attachmentId, err)
+			}
+
+			t.release(attachmentId)
+		}
+	}
+}
+
+func (t *ConcreteThumbnailService) enqueuePending() {
+	for _, attachment := range t.repo.GetAttachmentsByThumbnailStatus(model.ThumbnailStatusPending) {
+		t.Enqueue(attachment.Id)
+	}
+}
+
+func (t *ConcreteThumbnailService) acquire(attachmentId int) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.inFlight[attachmentId] {
+		return false
+	}
+
+	t.inFlight[attachmentId] = true
+	return true
+}
+
+func (t *ConcreteThumbnailService) release(attachmentId int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.inFlight, attachmentId)
+}
+
+func getThumbnailStorageKey(attachmentId int, size int) string {
+	return fmt.Sprintf("thumbnails/%d/%d", attachmentId, size)
+}
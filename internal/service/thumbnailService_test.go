@@ -0,0 +1,139 @@
+package service
+
+import (
+	"Notes/config"
+	"Notes/internal/model"
+	"Notes/internal/repository"
+	mocks "Notes/internal/service/mock"
+	"bytes"
+	"github.com/golang/mock/gomock"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"testing"
+)
+
+func initThumbnailServiceTest(t *testing.T) (AbstractThumbnailService, *mocks.MockAbstractRepository, *mocks.MockAbstractBlobStorage) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockAbstractRepository(ctrl)
+	mockStorage := mocks.NewMockAbstractBlobStorage(ctrl)
+	cfg := &config.Config{Attachments: config.Attachments{ThumbnailSizes: []int{64, 16}}}
+
+	return NewConcreteThumbnailService(mockRepository, mockStorage, cfg), mockRepository, mockStorage
+}
+
+func getTestPng(width int, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 100, A: 255})
+		}
+	}
+
+	var buffer bytes.Buffer
+	_ = png.Encode(&buffer, img)
+	return buffer.Bytes()
+}
+
+func TestConcreteThumbnailService_GetDefaultSize(t *testing.T) {
+	thumbnailService, _, _ := initThumbnailServiceTest(t)
+
+	if got := thumbnailService.GetDefaultSize(); got != 16 {
+		t.Errorf("ThumbnailService.GetDefaultSize() = %v, want 16", got)
+	}
+
+	if thumbnailService.IsSizeSupported(32) {
+		t.Errorf("ThumbnailService.IsSizeSupported(32) = true, want false")
+	}
+}
+
+func TestConcreteThumbnailService_GenerateThumbnails(t *testing.T) {
+	thumbnailService, repo, storage := initThumbnailServiceTest(t)
+
+	tests := []struct {
+		name       string
+		mock       func()
+		args       int
+		wantStatus model.ThumbnailStatus
+		wantErr    bool
+	}{
+		{
+			name: "deleted attachment is skipped",
+			mock: func() {
+				repo.EXPECT().GetAttachmentById(1).Return(nil, repository.EntityNotFoundError)
+			},
+			args:    1,
+			wantErr: false,
+		},
+		{
+			name: "already processed attachment is skipped",
+			mock: func() {
+				repo.EXPECT().GetAttachmentById(1).Return(&model.Attachment{Id: 1, ThumbnailStatus: model.ThumbnailStatusReady}, nil)
+			},
+			args:    1,
+			wantErr: false,
+		},
+		{
+			name: "broken image marks attachment as failed",
+			mock: func() {
+				repo.EXPECT().GetAttachmentById(1).Return(&model.Attachment{Id: 1, StorageKey: "key", ThumbnailStatus: model.ThumbnailStatusPending}, nil)
+				storage.EXPECT().Get("key").Return(io.NopCloser(bytes.NewReader([]byte("not an image"))), nil)
+				repo.EXPECT().GetAttachmentThumbnail(1, 16).Return(nil, repository.EntityNotFoundError)
+				repo.EXPECT().SaveEntity(gomock.Any()).DoAndReturn(func(entity model.BusinessEntity) (int, *model.ApplicationError) {
+					if status := entity.(*model.Attachment).ThumbnailStatus; status != model.ThumbnailStatusFailed {
+						t.Errorf("attachment.ThumbnailStatus = %v, want failed", status)
+					}
+					return 1, nil
+				})
+			},
+			args:    1,
+			wantErr: true,
+		},
+		{
+			name: "thumbnails generated for every size",
+			mock: func() {
+				repo.EXPECT().GetAttachmentById(1).Return(&model.Attachment{Id: 1, StorageKey: "key", ThumbnailStatus: model.ThumbnailStatusPending}, nil)
+				storage.EXPECT().Get("key").Return(io.NopCloser(bytes.NewReader(getTestPng(200, 100))), nil)
+				repo.EXPECT().GetAttachmentThumbnail(1, 16).Return(&model.AttachmentThumbnail{Id: 2}, nil)
+				repo.EXPECT().GetAttachmentThumbnail(1, 64).Return(nil, repository.EntityNotFoundError)
+				storage.EXPECT().Put("thumbnails/1/64", gomock.Any()).DoAndReturn(func(key string, content io.Reader) *model.ApplicationError {
+					config, format, err := image.DecodeConfig(content)
+					if err != nil || format != "png" || config.Width != 64 || config.Height != 32 {
+						t.Errorf("thumbnail = %v %v %v, want png 64x32", config, format, err)
+					}
+					return nil
+				})
+				repo.EXPECT().SaveEntity(&model.AttachmentThumbnail{
+					AttachmentId: 1,
+					Size:         64,
+					ContentType:  "image/png",
+					StorageKey:   "thumbnails/1/64",
+				}).Return(3, nil)
+				repo.EXPECT().SaveEntity(gomock.Any()).DoAndReturn(func(entity model.BusinessEntity) (int, *model.ApplicationError) {
+					if status := entity.(*model.Attachment).ThumbnailStatus; status != model.ThumbnailStatusReady {
+						t.Errorf("attachment.ThumbnailStatus = %v, want ready", status)
+					}
+					return 1, nil
+				})
+			},
+			args:    1,
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			thumbnailService := thumbnailService
+
+			tt.mock()
+
+			err := thumbnailService.GenerateThumbnails(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ThumbnailService.GenerateThumbnails() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -6,13 +6,12 @@ import (
 	"Notes/internal/constants"
 	"Notes/internal/model"
 	"Notes/internal/repository"
+	"Notes/internal/utils"
 )
 
-const loginAlreadyUsedMessage = "пользователь с таким логином уже добавлен"
-
 type AbstractUserService interface {
 	CreateUser(login, password, name, surname string) (int, *model.ApplicationError)
-	UpdateUser(id int, login, password, name, surname string) *model.ApplicationError
+	UpdateUser(id int, login, password, name, surname, timezone string) *model.ApplicationError
 	GetUser(userId int) (*model.User, *model.ApplicationError)
 	DeleteUser(id int) *model.ApplicationError
 }
@@ -20,6 +19,7 @@ type AbstractUserService interface {
 type UserService struct {
 	repo        repository.AbstractRepository
 	hashService AbstractHashService
+	origin      model.AuditOrigin
 }
 
 func NewConcreteUserService(repository repository.AbstractRepository, hashService AbstractHashService) AbstractUserService {
@@ -29,6 +29,11 @@ func NewConcreteUserService(repository repository.AbstractRepository, hashServic
 	}
 }
 
+func (u UserService) withOrigin(origin model.AuditOrigin) any {
+	u.origin = origin
+	return &u
+}
+
 func (u UserService) CreateUser(login, password, name, surname string) (int, *model.ApplicationError) {
 	newUser, err := model.NewUser(name, surname, login, password)
 
@@ -37,7 +42,7 @@ func (u UserService) CreateUser(login, password, name, surname string) (int, *mo
 	}
 
 	if !u.isLoginFree(newUser.Login, newUser.GetId()) {
-		return constants.FakeId, model.NewApplicationError(model.ErrorTypeValidation, loginAlreadyUsedMessage, nil)
+		return constants.FakeId, model.NewLocalizedError(model.ErrorTypeConflict, model.CodeLoginTaken, nil, nil)
 	}
 
 	passwordHash, errHash := u.hashService.GetHash(newUser.Password)
@@ -56,7 +61,8 @@ func (u UserService) CreateUser(login, password, name, surname string) (int, *mo
 	return id, nil
 }
 
-func (u UserService) UpdateUser(id int, login, password, name, surname string) *model.ApplicationError {
+// UpdateUser обновляет профиль пользователя. Пустой timezone оставляет текущий часовой пояс.
+func (u UserService) UpdateUser(id int, login, password, name, surname, timezone string) *model.ApplicationError {
 	newUser, err := model.NewUser(name, surname, login, password)
 
 	if err != nil {
@@ -64,7 +70,7 @@ func (u UserService) UpdateUser(id int, login, password, name, surname string) *
 	}
 
 	if !u.isLoginFree(newUser.Login, id) {
-		return model.NewApplicationError(model.ErrorTypeValidation, loginAlreadyUsedMessage, nil)
+		return model.NewLocalizedError(model.ErrorTypeConflict, model.CodeLoginTaken, nil, nil)
 	}
 
 	passwordHash, errHash := u.hashService.GetHash(newUser.Password)
@@ -77,17 +83,33 @@ func (u UserService) UpdateUser(id int, login, password, name, surname string) *
 		return err
 	}
 
+	// Профиль всегда сохраняется вместе с паролем, поэтому смена пароля определяется сравнением с текущим
+	audit := model.NewAuditEntry(&id, model.AuditUserUpdated, model.AuditEntityUser, &id)
+	if !u.isCurrentPassword(userDb, password) {
+		audit.Action = model.AuditPasswordChanged
+	}
+	before := model.UserAuditSummary(userDb)
+
 	userDb.Login = login
 	userDb.Password = passwordHash
 	userDb.Name = name
 	userDb.Surname = surname
 
-	_, err = u.repo.SaveEntity(userDb)
-	if err != nil {
-		return err
+	if timezone != "" {
+		if err = userDb.SetTimezone(timezone); err != nil {
+			return err
+		}
 	}
 
-	return nil
+	audit.SetChange(before, model.UserAuditSummary(userDb))
+
+	return u.repo.Transaction(func(tx repository.AbstractRepository) *model.ApplicationError {
+		if _, err := tx.SaveEntity(userDb); err != nil {
+			return err
+		}
+
+		return saveAuditEntry(tx, u.origin, audit)
+	})
 }
 
 func (u UserService) GetUser(userId int) (*model.User, *model.ApplicationError) {
@@ -107,7 +129,22 @@ func (u UserService) DeleteUser(id int) *model.ApplicationError {
 		return err
 	}
 
-	return u.repo.DeleteEntity(user)
+	audit := model.NewAuditEntry(&id, model.AuditUserDeleted, model.AuditEntityUser, &id)
+	audit.SetChange(model.UserAuditSummary(user), nil)
+
+	return u.repo.Transaction(func(tx repository.AbstractRepository) *model.ApplicationError {
+		if err := saveAuditEntry(tx, u.origin, audit); err != nil {
+			return err
+		}
+
+		return tx.DeleteEntity(user)
+	})
+}
+
+// isCurrentPassword проверяет, совпадает ли password с текущим паролем пользователя
+func (u UserService) isCurrentPassword(user *model.User, password string) bool {
+	equal, _ := utils.CompareHashAndPassword(user.Password, password)
+	return equal
 }
 
 func (u UserService) isLoginFree(login string, userId int) bool {
@@ -3,10 +3,12 @@ package service
 import (
 	"Notes/internal/constants"
 	"Notes/internal/model"
+	"Notes/internal/repository"
 	mocks "Notes/internal/service/mock"
 	"encoding/json"
 	"fmt"
 	"github.com/golang/mock/gomock"
+	"strings"
 	"testing"
 	"time"
 )
@@ -17,6 +19,7 @@ type userTestArgs struct {
 	password string
 	name     string
 	surname  string
+	timezone string
 }
 
 type userTestExpect struct {
@@ -31,6 +34,7 @@ func initUserServiceTest(t *testing.T) (AbstractUserService, *mocks.MockAbstract
 
 	mockRepository := mocks.NewMockAbstractRepository(ctrl)
 	mockHashService := mocks.NewMockAbstractHashService(ctrl)
+	expectAuditLog(mockRepository)
 
 	return NewConcreteUserService(mockRepository, mockHashService), mockRepository, mockHashService
 }
@@ -204,7 +208,7 @@ func TestConcreteUserService_CreateUser(t *testing.T) {
 			},
 			want: userTestExpect{
 				id:    constants.FakeId,
-				error: model.NewApplicationError(model.ErrorTypeValidation, loginAlreadyUsedMessage, nil),
+				error: model.NewLocalizedError(model.ErrorTypeConflict, model.CodeLoginTaken, nil, nil),
 			},
 			wantErr: true,
 		},
@@ -460,7 +464,7 @@ func TestConcreteUserService_UpdateUser(t *testing.T) {
 				surname:  "surname",
 			},
 			want: userTestExpect{
-				error: model.NewApplicationError(model.ErrorTypeValidation, loginAlreadyUsedMessage, nil),
+				error: model.NewLocalizedError(model.ErrorTypeConflict, model.CodeLoginTaken, nil, nil),
 			},
 			wantErr: true,
 		},
@@ -566,6 +570,54 @@ func TestConcreteUserService_UpdateUser(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "unknown timezone returns error",
+			mock: func() {
+				repo.EXPECT().GetUsers().Return([]*model.User{})
+				hash.EXPECT().GetHash("New_password123$").Return("New_hashed_password123$", nil)
+				repo.EXPECT().GetUserById(1).Return(&model.User{Id: 1, Login: "initial_login"}, nil)
+			},
+			args: userTestArgs{
+				userId:   1,
+				login:    "new_login",
+				password: "New_password123$",
+				name:     "name",
+				surname:  "surname",
+				timezone: "Mars/Olympus",
+			},
+			want: userTestExpect{
+				error: model.NewApplicationError(model.ErrorTypeValidation, "Неизвестный часовой пояс: Mars/Olympus", nil),
+			},
+			wantErr: true,
+		},
+		{
+			name: "update user timezone",
+			mock: func() {
+				repo.EXPECT().GetUsers().Return([]*model.User{})
+				hash.EXPECT().GetHash("New_password123$").Return("New_hashed_password123$", nil)
+				repo.EXPECT().GetUserById(1).Return(&model.User{Id: 1, Login: "initial_login", Timezone: "UTC"}, nil)
+				repo.EXPECT().SaveEntity(&model.User{
+					Id:       1,
+					Name:     "name",
+					Surname:  "surname",
+					Login:    "new_login",
+					Password: "New_hashed_password123$",
+					Timezone: "Europe/Moscow",
+				}).Return(1, nil)
+			},
+			args: userTestArgs{
+				userId:   1,
+				login:    "new_login",
+				password: "New_password123$",
+				name:     "name",
+				surname:  "surname",
+				timezone: "Europe/Moscow",
+			},
+			want: userTestExpect{
+				error: nil,
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -574,7 +626,7 @@ func TestConcreteUserService_UpdateUser(t *testing.T) {
 
 			tt.mock()
 
-			err := userService.UpdateUser(tt.args.userId, tt.args.login, tt.args.password, tt.args.name, tt.args.surname)
+			err := userService.UpdateUser(tt.args.userId, tt.args.login, tt.args.password, tt.args.name, tt.args.surname, tt.args.timezone)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("userService.CreateUser() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -754,3 +806,95 @@ func TestConcreteUserService_DeleteUser(t *testing.T) {
 		})
 	}
 }
+
+func TestConcreteUserService_CreateUser_FieldErrors(t *testing.T) {
+	userService, _, _ := initUserServiceTest(t)
+
+	tests := []struct {
+		name string
+		args userTestArgs
+		want []model.FieldError
+	}{
+		{
+			name: "every invalid field is reported",
+			args: userTestArgs{login: "log", password: "pass"},
+			want: []model.FieldError{
+				{Field: "Name", Code: model.CodeUserNameEmpty},
+				{Field: "Surname", Code: model.CodeUserSurnameEmpty},
+				{Field: "Login", Code: model.CodeLoginTooShort, Params: model.ErrorParams{"min": model.MinLoginLength}},
+				{Field: "Password", Code: model.CodePasswordTooShort, Params: model.ErrorParams{"min": model.MinPasswordLength}},
+			},
+		},
+		{
+			name: "single invalid field keeps its code",
+			args: userTestArgs{login: "login1234", password: "Passwordpasss1", name: "name", surname: "surname"},
+			want: []model.FieldError{
+				{Field: "Password", Code: model.CodePasswordNoSpecial},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := userService.CreateUser(tt.args.login, tt.args.password, tt.args.name, tt.args.surname)
+			if err == nil {
+				t.Fatalf("userService.CreateUser() expected error")
+			}
+
+			if err.Type != model.ErrorTypeValidation || err.Code != tt.want[0].Code {
+				t.Errorf("userService.CreateUser() error = %v %v, want %v", err.Type, err.Code, tt.want[0].Code)
+			}
+
+			got := make([]model.FieldError, 0, len(err.Fields))
+			for _, field := range err.Fields {
+				got = append(got, model.FieldError{Field: field.Field, Code: field.Code, Params: field.Params})
+			}
+
+			gotJson, _ := json.Marshal(got)
+			wantJson, _ := json.Marshal(tt.want)
+			if string(gotJson) != string(wantJson) {
+				t.Errorf("userService.CreateUser() fields = %s, want %s", gotJson, wantJson)
+			}
+		})
+	}
+}
+
+func TestConcreteUserService_UpdateUser_Audit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockAbstractRepository(ctrl)
+	hash := mocks.NewMockAbstractHashService(ctrl)
+	origin := model.AuditOrigin{Ip: "203.0.113.5", TraceId: "trace-1"}
+	userService := WithOrigin(NewConcreteUserService(repo, hash), origin)
+
+	repo.EXPECT().GetUsers().Return([]*model.User{}).Times(2)
+	hash.EXPECT().GetHash("New_password123$").Return("New_hashed_password123$", nil).Times(2)
+	repo.EXPECT().GetUserById(1).DoAndReturn(func(id int) (*model.User, *model.ApplicationError) {
+		return &model.User{Id: 1, Login: "initial_login", Password: "initial_hashed_password123$"}, nil
+	}).Times(2)
+	repo.EXPECT().Transaction(gomock.Any()).DoAndReturn(func(fn func(tx repository.AbstractRepository) *model.ApplicationError) *model.ApplicationError {
+		return fn(repo)
+	}).Times(2)
+	repo.EXPECT().SaveEntity(gomock.AssignableToTypeOf(&model.User{})).Return(1, nil).Times(2)
+
+	repo.EXPECT().SaveEntity(gomock.AssignableToTypeOf(&model.AuditEntry{})).DoAndReturn(func(entity model.BusinessEntity) (int, *model.ApplicationError) {
+		entry := entity.(*model.AuditEntry)
+		if entry.Action != model.AuditPasswordChanged || entry.TraceId != "trace-1" || entry.Ip != "203.0.113.5" {
+			t.Errorf("entry = %+v, want password change with the request origin", entry)
+		}
+		if entry.Before == nil || entry.After == nil || strings.Contains(*entry.After, "hashed") {
+			t.Errorf("entry = %+v, want profile before and after without the password", entry)
+		}
+		return 1, nil
+	})
+	if err := userService.UpdateUser(1, "new_login", "New_password123$", "name", "surname", ""); err != nil {
+		t.Errorf("UpdateUser() error = %v", err)
+	}
+
+	// Без записи в журнал аудита профиль не сохраняется
+	repo.EXPECT().SaveEntity(gomock.AssignableToTypeOf(&model.AuditEntry{})).Return(constants.FakeId, repository.DataBaseError)
+	if err := userService.UpdateUser(1, "new_login", "New_password123$", "name", "surname", ""); err == nil {
+		t.Errorf("UpdateUser() error = nil, want the audit error")
+	}
+}
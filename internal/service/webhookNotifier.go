@@ -0,0 +1,61 @@
+package service
+
+import (
+	"Notes/internal/model"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+const webhookTimeout = 10 * time.Second
+
+type WebhookNotifier struct {
+	client *http.Client
+}
+
+type webhookNotificationPayload struct {
+	Type      model.NotificationType `json:"type"`
+	Title     string                 `json:"title"`
+	Body      string                 `json:"body"`
+	NoteId    *int                   `json:"noteId,omitempty"`
+	Timestamp time.Time              `json:"timestamp"`
+}
+
+func NewWebhookNotifier() Notifier {
+	return &WebhookNotifier{client: newPublicHttpClient()}
+}
+
+func (w *WebhookNotifier) Channel() model.ReminderChannel {
+	return model.ReminderChannelWebhook
+}
+
+func (w *WebhookNotifier) Notify(notification *model.Notification, target model.NotificationTarget) *model.ApplicationError {
+	if target.WebhookUrl == nil {
+		return model.NewLocalizedError(model.ErrorTypeValidation, model.CodeWebhookUrlMissing, nil, nil)
+	}
+
+	payload, err := json.Marshal(webhookNotificationPayload{
+		Type:      notification.Type,
+		Title:     notification.Title,
+		Body:      notification.Body,
+		NoteId:    notification.NoteId,
+		Timestamp: time.Now().UTC(),
+	})
+	if err != nil {
+		return model.NewLocalizedError(model.ErrorTypeInternal, model.CodeWebhookPayloadFailed, nil, err)
+	}
+
+	response, err := w.client.Post(*target.WebhookUrl, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return model.NewLocalizedError(model.ErrorTypeInternal, model.CodeWebhookCallFailed, nil, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		params := model.ErrorParams{"status": response.StatusCode}
+		return model.NewLocalizedError(model.ErrorTypeInternal, model.CodeWebhookBadStatus, params, nil)
+	}
+
+	return nil
+}
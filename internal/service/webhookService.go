@@ -0,0 +1,419 @@
+package service
+
+//go:generate mockgen -source=webhookService.go -destination=mock/webhookService.go -package=mock
+
+import (
+	"Notes/config"
+	"Notes/internal/model"
+	"Notes/internal/repository"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const maxWebhooksPerUser = 20
+const maxWebhookDeliveriesShown = 50
+const webhookDeliveriesPerClaim = 10
+const maxWebhookDeliveriesPerTick = 100
+const defaultWebhookPollInterval = 5 * time.Second
+const defaultWebhookMaxAttempts = 8
+const defaultWebhookRetention = 30 * 24 * time.Hour
+const webhookCleanupInterval = time.Hour
+const webhookRetryBase = 30 * time.Second
+const webhookRetryMax = 12 * time.Hour
+const webhookErrorMaxLength = 500
+
+// webhookLease - на сколько доставка откладывается, пока её отправляет одна из реплик.
+// Должно хватать на отправку всей забранной пачки.
+const webhookLease = webhookDeliveriesPerClaim*webhookTimeout + time.Minute
+
+type AbstractWebhookService interface {
+	CreateWebhook(userId int, settings model.WebhookSettings) (int, string, *model.ApplicationError)
+	GetWebhooks(userId int) []*model.WebhookApi
+	GetWebhook(userId int, webhookId int) (*model.WebhookApi, *model.ApplicationError)
+	UpdateWebhook(userId int, webhookId int, settings model.WebhookSettings) *model.ApplicationError
+	DeleteWebhook(userId int, webhookId int) *model.ApplicationError
+	GetDeliveries(userId int, webhookId int) ([]*model.WebhookDeliveryApi, *model.ApplicationError)
+	RetryDelivery(userId int, webhookId int, deliveryId int) *model.ApplicationError
+	SendTestEvent(userId int, webhookId int) (*model.WebhookDeliveryApi, *model.ApplicationError)
+	DeliverDue(now time.Time) int
+	Run(ctx context.Context)
+}
+
+type WebhookService struct {
+	repo         repository.AbstractRepository
+	client       *http.Client
+	pollInterval time.Duration
+	maxAttempts  int
+	retention    time.Duration
+	origin       model.AuditOrigin
+}
+
+func NewConcreteWebhookService(repository repository.AbstractRepository, cfg *config.Config) AbstractWebhookService {
+	pollInterval := time.Duration(cfg.Webhooks.PollIntervalSeconds) * time.Second
+	if pollInterval <= 0 {
+		pollInterval = defaultWebhookPollInterval
+	}
+
+	maxAttempts := cfg.Webhooks.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultWebhookMaxAttempts
+	}
+
+	retention := time.Duration(cfg.Webhooks.RetentionDays) * 24 * time.Hour
+	if retention <= 0 {
+		retention = defaultWebhookRetention
+	}
+
+	return &WebhookService{
+		repo:         repository,
+		client:       newWebhookClient(),
+		pollInterval: pollInterval,
+		maxAttempts:  maxAttempts,
+		retention:    retention,
+	}
+}
+
+// newWebhookClient создаёт клиент доставки, который соединяется только с публичными адресами
+func newWebhookClient() *http.Client {
+	client := newPublicHttpClient()
+	// Перенаправление не выполняется: подпись относится к адресу, который указал пользователь
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return client
+}
+
+func (w *WebhookService) withOrigin(origin model.AuditOrigin) any {
+	service := *w
+	service.origin = origin
+	return &service
+}
+
+// CreateWebhook создаёт webhook и возвращает его id и секрет подписи. Секрет больше нигде не показывается.
+func (w *WebhookService) CreateWebhook(userId int, settings model.WebhookSettings) (int, string, *model.ApplicationError) {
+	if len(w.repo.GetWebhooksByUserId(userId)) >= maxWebhooksPerUser {
+		params := model.ErrorParams{"max": maxWebhooksPerUser}
+		return 0, "", model.NewLocalizedError(model.ErrorTypeValidation, model.CodeWebhookTooMany, params, nil)
+	}
+
+	webhook, err := model.NewWebhook(userId, settings)
+	if err != nil {
+		return 0, "", err
+	}
+
+	if err = validateWebhookHost(webhook.Url); err != nil {
+		return 0, "", err
+	}
+
+	err = w.repo.Transaction(func(tx repository.AbstractRepository) *model.ApplicationError {
+		id, err := tx.SaveEntity(webhook)
+		if err != nil {
+			return err
+		}
+
+		webhook.Id = id
+
+		audit := model.NewAuditEntry(&userId, model.AuditWebhookCreated, model.AuditEntityWebhook, &webhook.Id)
+		audit.SetChange(nil, model.WebhookAuditSummary(webhook))
+		return saveAuditEntry(tx, w.origin, audit)
+	})
+	if err != nil {
+		return 0, "", err
+	}
+
+	return webhook.Id, webhook.Secret, nil
+}
+
+func (w *WebhookService) GetWebhooks(userId int) []*model.WebhookApi {
+	return model.ToWebhooksApi(w.repo.GetWebhooksByUserId(userId))
+}
+
+func (w *WebhookService) GetWebhook(userId int, webhookId int) (*model.WebhookApi, *model.ApplicationError) {
+	webhook, err := w.repo.GetWebhookById(webhookId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	return model.ToWebhookApi(webhook), nil
+}
+
+func (w *WebhookService) UpdateWebhook(userId int, webhookId int, settings model.WebhookSettings) *model.ApplicationError {
+	webhook, err := w.repo.GetWebhookById(webhookId, userId)
+	if err != nil {
+		return err
+	}
+
+	before := model.WebhookAuditSummary(webhook)
+
+	if err = webhook.Apply(settings); err != nil {
+		return err
+	}
+
+	if err = validateWebhookHost(webhook.Url); err != nil {
+		return err
+	}
+
+	return w.repo.Transaction(func(tx repository.AbstractRepository) *model.ApplicationError {
+		if _, err := tx.SaveEntity(webhook); err != nil {
+			return err
+		}
+
+		audit := model.NewAuditEntry(&userId, model.AuditWebhookUpdated, model.AuditEntityWebhook, &webhookId)
+		audit.SetChange(before, model.WebhookAuditSummary(webhook))
+		return saveAuditEntry(tx, w.origin, audit)
+	})
+}
+
+func (w *WebhookService) DeleteWebhook(userId int, webhookId int) *model.ApplicationError {
+	webhook, err := w.repo.GetWebhookById(webhookId, userId)
+	if err != nil {
+		return err
+	}
+
+	return w.repo.Transaction(func(tx repository.AbstractRepository) *model.ApplicationError {
+		if err := tx.DeleteEntity(webhook); err != nil {
+			return err
+		}
+
+		audit := model.NewAuditEntry(&userId, model.AuditWebhookDeleted, model.AuditEntityWebhook, &webhookId)
+		audit.SetChange(model.WebhookAuditSummary(webhook), nil)
+		return saveAuditEntry(tx, w.origin, audit)
+	})
+}
+
+// GetDeliveries возвращает последние доставки webhook вместе с попытками отправки
+func (w *WebhookService) GetDeliveries(userId int, webhookId int) ([]*model.WebhookDeliveryApi, *model.ApplicationError) {
+	if _, err := w.repo.GetWebhookById(webhookId, userId); err != nil {
+		return nil, err
+	}
+
+	deliveries := w.repo.GetWebhookDeliveries(webhookId, maxWebhookDeliveriesShown)
+
+	deliveryIds := make([]int, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		deliveryIds = append(deliveryIds, delivery.Id)
+	}
+	attempts := w.repo.GetWebhookAttempts(deliveryIds)
+
+	result := make([]*model.WebhookDeliveryApi, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		result = append(result, model.ToWebhookDeliveryApi(delivery, attempts))
+	}
+	return result, nil
+}
+
+// RetryDelivery возвращает доставку в очередь с полным набором попыток
+func (w *WebhookService) RetryDelivery(userId int, webhookId int, deliveryId int) *model.ApplicationError {
+	if _, err := w.repo.GetWebhookById(webhookId, userId); err != nil {
+		return err
+	}
+
+	delivery, err := w.repo.GetWebhookDeliveryById(deliveryId, webhookId)
+	if err != nil {
+		return err
+	}
+
+	delivery.Status = model.WebhookDeliveryStatusPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now()
+
+	_, err = w.repo.SaveEntity(delivery)
+	return err
+}
+
+// SendTestEvent сразу отправляет на webhook тестовое событие и возвращает результат попытки.
+// Неудачная тестовая доставка не повторяется.
+func (w *WebhookService) SendTestEvent(userId int, webhookId int) (*model.WebhookDeliveryApi, *model.ApplicationError) {
+	webhook, err := w.repo.GetWebhookById(webhookId, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, marshalErr := json.Marshal(&model.Event{
+		Type:      model.EventWebhookTest,
+		UserId:    userId,
+		Timestamp: time.Now(),
+	})
+	if marshalErr != nil {
+		return nil, model.NewLocalizedError(model.ErrorTypeInternal, model.CodeWebhookPayloadFailed, nil, marshalErr)
+	}
+
+	delivery := model.NewWebhookDelivery(webhook, model.EventWebhookTest, string(payload))
+	if _, err = w.repo.SaveEntity(delivery); err != nil {
+		return nil, err
+	}
+
+	attempt, err := w.attempt(webhook, delivery, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if delivery.Status == model.WebhookDeliveryStatusPending {
+		delivery.Status = model.WebhookDeliveryStatusDead
+	}
+
+	if _, err = w.repo.SaveEntity(delivery); err != nil {
+		return nil, err
+	}
+
+	return model.ToWebhookDeliveryApi(delivery, []*model.WebhookAttempt{attempt}), nil
+}
+
+// DeliverDue отправляет доставки, время попытки которых наступило, и возвращает их количество
+func (w *WebhookService) DeliverDue(now time.Time) int {
+	delivered := 0
+
+	for delivered < maxWebhookDeliveriesPerTick {
+		deliveries, err := w.repo.ClaimWebhookDeliveries(now, now.Add(webhookLease), webhookDeliveriesPerClaim)
+		if err != nil {
+			log.Printf("Ошибка при получении очереди webhook: %v", err)
+			break
+		}
+
+		for _, delivery := range deliveries {
+			w.deliver(delivery, now)
+		}
+
+		delivered += len(deliveries)
+		if len(deliveries) < webhookDeliveriesPerClaim {
+			break
+		}
+	}
+
+	return delivered
+}
+
+func (w *WebhookService) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	cleanupTicker := time.NewTicker(webhookCleanupInterval)
+	defer cleanupTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			w.DeliverDue(now.UTC())
+		case now := <-cleanupTicker.C:
+			if err := w.repo.DeleteWebhookDeliveriesBefore(now.Add(-w.retention)); err != nil {
+				log.Printf("Ошибка при очистке журнала webhook: %v", err)
+			}
+		}
+	}
+}
+
+// deliver выполняет одну попытку доставки из очереди. Ошибки сохранения только логируются:
+// доставка вернётся в очередь после окончания аренды.
+func (w *WebhookService) deliver(delivery *model.WebhookDelivery, now time.Time) {
+	webhook, err := w.repo.GetWebhookById(delivery.WebhookId, delivery.UserId)
+	if err != nil {
+		log.Printf("Не удалось получить webhook %d для доставки %d: %v", delivery.WebhookId, delivery.Id, err)
+		return
+	}
+
+	if !webhook.IsActive {
+		delivery.Status = model.WebhookDeliveryStatusDead
+		delivery.Error = model.NewLocalizedError(model.ErrorTypeValidation, model.CodeWebhookDisabled, nil, nil).Error()
+	} else if _, err = w.attempt(webhook, delivery, now); err != nil {
+		log.Printf("Не удалось сохранить попытку доставки %d: %v", delivery.Id, err)
+		return
+	}
+
+	if _, err = w.repo.SaveEntity(delivery); err != nil {
+		log.Printf("Не удалось сохранить доставку %d: %v", delivery.Id, err)
+	}
+}
+
+// attempt отправляет доставку, записывает попытку в журнал и переводит доставку в следующее состояние:
+// доставлена, ждёт повтора с экспоненциальной задержкой или исчерпала попытки.
+func (w *WebhookService) attempt(webhook *model.Webhook, delivery *model.WebhookDelivery, now time.Time) (*model.WebhookAttempt, *model.ApplicationError) {
+	started := time.Now()
+	statusCode, sendErr := w.send(webhook, delivery)
+
+	attempt := &model.WebhookAttempt{
+		DeliveryId: delivery.Id,
+		StatusCode: statusCode,
+		DurationMs: int(time.Since(started).Milliseconds()),
+	}
+	if sendErr != nil {
+		attempt.Error = truncateWebhookError(sendErr.Error())
+	}
+
+	if _, err := w.repo.SaveEntity(attempt); err != nil {
+		return nil, err
+	}
+
+	delivery.Attempts++
+	delivery.StatusCode = statusCode
+	delivery.Error = attempt.Error
+
+	switch {
+	case sendErr == nil:
+		deliveredAt := now
+		delivery.Status = model.WebhookDeliveryStatusDelivered
+		delivery.DeliveredAt = &deliveredAt
+	case delivery.Attempts >= w.maxAttempts:
+		delivery.Status = model.WebhookDeliveryStatusDead
+	default:
+		delivery.NextAttemptAt = now.Add(webhookRetryDelay(delivery.Attempts))
+	}
+
+	return attempt, nil
+}
+
+// send отправляет тело доставки с подписью. Успехом считается ответ 2xx.
+func (w *WebhookService) send(webhook *model.Webhook, delivery *model.WebhookDelivery) (*int, error) {
+	request, err := http.NewRequest(http.MethodPost, webhook.Url, strings.NewReader(delivery.Payload))
+	if err != nil {
+		return nil, err
+	}
+
+	timestamp := time.Now().Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Notes-Event", string(delivery.EventType))
+	request.Header.Set("X-Notes-Delivery", strconv.Itoa(delivery.Id))
+	request.Header.Set("X-Notes-Timestamp", strconv.FormatInt(timestamp, 10))
+	request.Header.Set("X-Notes-Signature", webhook.Sign(timestamp, delivery.Payload))
+
+	response, err := w.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 64*1024))
+
+	statusCode := response.StatusCode
+	if statusCode < 200 || statusCode >= 300 {
+		return &statusCode, errors.New(response.Status)
+	}
+	return &statusCode, nil
+}
+
+// webhookRetryDelay - задержка перед повтором: 30 секунд, удваиваясь после каждой неудачной попытки
+func webhookRetryDelay(attempts int) time.Duration {
+	delay := webhookRetryBase
+	for i := 1; i < attempts && delay < webhookRetryMax; i++ {
+		delay *= 2
+	}
+
+	if delay > webhookRetryMax {
+		return webhookRetryMax
+	}
+	return delay
+}
+
+func truncateWebhookError(message string) string {
+	if len(message) <= webhookErrorMaxLength {
+		return message
+	}
+	return message[:webhookErrorMaxLength]
+}
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"Notes/config"
+	"Notes/internal/model"
+	"Notes/internal/repository"
+	mocks "Notes/internal/service/mock"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/golang/mock/gomock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func initWebhookServiceTest(t *testing.T) (AbstractWebhookService, *mocks.MockAbstractRepository) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepository := mocks.NewMockAbstractRepository(ctrl)
+	expectAuditLog(mockRepository)
+
+	webhookService := NewConcreteWebhookService(mockRepository, &config.Config{})
+	// Тестовые серверы слушают loopback, с которым боевой клиент соединяться отказывается
+	webhookService.(*WebhookService).client = &http.Client{Timeout: webhookTimeout}
+
+	return webhookService, mockRepository
+}
+
+// newWebhookTestServer отвечает статусом status и проверяет подпись каждого запроса
+func newWebhookTestServer(t *testing.T, secret string, status int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(r.Header.Get("X-Notes-Timestamp") + "." + string(body)))
+		if want := "sha256=" + hex.EncodeToString(mac.Sum(nil)); r.Header.Get("X-Notes-Signature") != want {
+			t.Errorf("X-Notes-Signature = %s, want %s", r.Header.Get("X-Notes-Signature"), want)
+		}
+		if r.Header.Get("X-Notes-Event") == "" || r.Header.Get("X-Notes-Delivery") == "" {
+			t.Errorf("event headers are missing: %v", r.Header)
+		}
+
+		w.WriteHeader(status)
+	}))
+}
+
+func TestConcreteWebhookService_CreateWebhook(t *testing.T) {
+	webhookService, repo := initWebhookServiceTest(t)
+
+	tests := []struct {
+		name     string
+		mock     func()
+		settings model.WebhookSettings
+		wantErr  bool
+	}{
+		{
+			name: "webhook is created with a secret",
+			mock: func() {
+				repo.EXPECT().GetWebhooksByUserId(1).Return(nil)
+				repo.EXPECT().SaveEntity(gomock.AssignableToTypeOf(&model.Webhook{})).Return(3, nil)
+			},
+			settings: model.WebhookSettings{Url: "https://203.0.113.10/hook", Events: []string{"note.created", "note.moved"}, IsActive: true},
+		},
+		{
+			name: "unknown event",
+			mock: func() {
+				repo.EXPECT().GetWebhooksByUserId(1).Return(nil)
+			},
+			settings: model.WebhookSettings{Url: "https://203.0.113.10/hook", Events: []string{"note.shared"}},
+			wantErr:  true,
+		},
+		{
+			name: "no events",
+			mock: func() {
+				repo.EXPECT().GetWebhooksByUserId(1).Return(nil)
+			},
+			settings: model.WebhookSettings{Url: "https://203.0.113.10/hook"},
+			wantErr:  true,
+		},
+		{
+			name: "invalid address",
+			mock: func() {
+				repo.EXPECT().GetWebhooksByUserId(1).Return(nil)
+			},
+			settings: model.WebhookSettings{Url: "ftp://203.0.113.10/hook", Events: []string{"note.created"}},
+			wantErr:  true,
+		},
+		{
+			name: "internal address",
+			mock: func() {
+				repo.EXPECT().GetWebhooksByUserId(1).Return(nil)
+			},
+			settings: model.WebhookSettings{Url: "http://169.254.169.254/latest", Events: []string{"note.created"}},
+			wantErr:  true,
+		},
+		{
+			name: "too many webhooks",
+			mock: func() {
+				repo.EXPECT().GetWebhooksByUserId(1).Return(make([]*model.Webhook, maxWebhooksPerUser))
+			},
+			settings: model.WebhookSettings{Url: "https://203.0.113.10/hook", Events: []string{"note.created"}},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock()
+
+			id, secret, err := webhookService.CreateWebhook(1, tt.settings)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CreateWebhook() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (id != 3 || len(secret) != 64) {
+				t.Errorf("CreateWebhook() = %d, %q, want id 3 and a 64-character secret", id, secret)
+			}
+		})
+	}
+}
+
+func TestConcreteWebhookService_DeliverDue(t *testing.T) {
+	webhookService, repo := initWebhookServiceTest(t)
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	okServer := newWebhookTestServer(t, "secret", http.StatusNoContent)
+	defer okServer.Close()
+	failServer := newWebhookTestServer(t, "secret", http.StatusInternalServerError)
+	defer failServer.Close()
+
+	tests := []struct {
+		name          string
+		url           string
+		isActive      bool
+		attempts      int
+		wantSent      bool
+		wantStatus    model.WebhookDeliveryStatus
+		wantAttempts  int
+		wantNextRetry time.Duration
+	}{
+		{
+			name:         "successful delivery",
+			url:          okServer.URL,
+			isActive:     true,
+			wantSent:     true,
+			wantStatus:   model.WebhookDeliveryStatusDelivered,
+			wantAttempts: 1,
+		},
+		{
+			name:          "failed delivery is retried with backoff",
+			url:           failServer.URL,
+			isActive:      true,
+			attempts:      2,
+			wantSent:      true,
+			wantStatus:    model.WebhookDeliveryStatusPending,
+			wantAttempts:  3,
+			wantNextRetry: 2 * time.Minute,
+		},
+		{
+			name:         "last failed attempt moves the delivery to dead letters",
+			url:          failServer.URL,
+			isActive:     true,
+			attempts:     defaultWebhookMaxAttempts - 1,
+			wantSent:     true,
+			wantStatus:   model.WebhookDeliveryStatusDead,
+			wantAttempts: defaultWebhookMaxAttempts,
+		},
+		{
+			name:       "delivery to a disabled webhook is not sent",
+			url:        okServer.URL,
+			wantStatus: model.WebhookDeliveryStatusDead,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhook := &model.Webhook{Id: 2, UserId: 1, Url: tt.url, Secret: "secret", IsActive: tt.isActive}
+			delivery := &model.WebhookDelivery{
+				Id:        7,
+				WebhookId: 2,
+				UserId:    1,
+				EventType: model.EventNoteCreated,
+				Payload:   `{"Id":1,"Type":"note.created"}`,
+				Status:    model.WebhookDeliveryStatusPending,
+				Attempts:  tt.attempts,
+			}
+
+			repo.EXPECT().ClaimWebhookDeliveries(now, now.Add(webhookLease), webhookDeliveriesPerClaim).Return([]*model.WebhookDelivery{delivery}, nil)
+			repo.EXPECT().GetWebhookById(2, 1).Return(webhook, nil)
+			if tt.wantSent {
+				repo.EXPECT().SaveEntity(gomock.AssignableToTypeOf(&model.WebhookAttempt{})).Return(1, nil)
+			}
+			repo.EXPECT().SaveEntity(delivery).Return(7, nil)
+
+			if got := webhookService.DeliverDue(now); got != 1 {
+				t.Errorf("DeliverDue() = %d, want 1", got)
+			}
+
+			if delivery.Status != tt.wantStatus || delivery.Attempts != tt.wantAttempts {
+				t.Errorf("delivery = %s after %d attempts, want %s after %d", delivery.Status, delivery.Attempts, tt.wantStatus, tt.wantAttempts)
+			}
+			if tt.wantNextRetry != 0 && !delivery.NextAttemptAt.Equal(now.Add(tt.wantNextRetry)) {
+				t.Errorf("NextAttemptAt = %v, want %v", delivery.NextAttemptAt, now.Add(tt.wantNextRetry))
+			}
+			if tt.wantStatus == model.WebhookDeliveryStatusDelivered && delivery.DeliveredAt == nil {
+				t.Errorf("DeliveredAt is not set")
+			}
+		})
+	}
+}
+
+func TestConcreteWebhookService_SendTestEvent(t *testing.T) {
+	webhookService, repo := initWebhookServiceTest(t)
+
+	failServer := newWebhookTestServer(t, "secret", http.StatusBadGateway)
+	defer failServer.Close()
+
+	t.Run("failed test delivery is not retried", func(t *testing.T) {
+		repo.EXPECT().GetWebhookById(2, 1).Return(&model.Webhook{Id: 2, UserId: 1, Url: failServer.URL, Secret: "secret", IsActive: true}, nil)
+		repo.EXPECT().SaveEntity(gomock.AssignableToTypeOf(&model.WebhookDelivery{})).DoAndReturn(func(entity model.BusinessEntity) (int, *model.ApplicationError) {
+			entity.SetId(9)
+			return 9, nil
+		}).Times(2)
+		repo.EXPECT().SaveEntity(gomock.AssignableToTypeOf(&model.WebhookAttempt{})).Return(1, nil)
+
+		delivery, err := webhookService.SendTestEvent(1, 2)
+		if err != nil {
+			t.Fatalf("SendTestEvent() error = %v", err)
+		}
+
+		if delivery.Status != model.WebhookDeliveryStatusDead || delivery.StatusCode == nil || *delivery.StatusCode != http.StatusBadGateway {
+			t.Errorf("SendTestEvent() = %+v, want a dead delivery with status 502", delivery)
+		}
+		if len(delivery.Attempts) != 1 || delivery.EventType != model.EventWebhookTest {
+			t.Errorf("SendTestEvent() = %+v, want one webhook.test attempt", delivery)
+		}
+	})
+
+	t.Run("webhook of another user", func(t *testing.T) {
+		repo.EXPECT().GetWebhookById(2, 1).Return(nil, repository.EntityNotFoundError)
+
+		if _, err := webhookService.SendTestEvent(1, 2); err == nil {
+			t.Errorf("SendTestEvent() error = nil, want not found")
+		}
+	})
+}
+
+func TestWebhookRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: 30 * time.Second},
+		{attempts: 2, want: time.Minute},
+		{attempts: 5, want: 8 * time.Minute},
+		{attempts: 20, want: webhookRetryMax},
+	}
+
+	for _, tt := range tests {
+		if got := webhookRetryDelay(tt.attempts); got != tt.want {
+			t.Errorf("webhookRetryDelay(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
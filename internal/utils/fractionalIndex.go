@@ -0,0 +1,225 @@
+package utils
+
+import (
+	"Notes/internal/model"
+	"strings"
+)
+
+// Ключи дробной индексации (https://observablehq.com/@dgreensp/implementing-fractional-indexing):
+// строка из целой части (первый символ задаёт её длину) и дробной части в base62.
+// Между любыми двумя ключами можно вставить новый, поэтому перестановка меняет только одну запись.
+// Ключи сравниваются побайтно, как обычные строки Go.
+const positionDigits = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+const smallestPositionInteger = "A00000000000000000000000000"
+
+// PositionBetween возвращает ключ строго между before и after.
+// Пустой before означает начало списка, пустой after - конец.
+func PositionBetween(before string, after string) (string, *model.ApplicationError) {
+	if before != "" && !isValidPosition(before) || after != "" && !isValidPosition(after) {
+		return "", newPositionError()
+	}
+
+	if before != "" && after != "" && before >= after {
+		return "", newPositionError()
+	}
+
+	if before == "" {
+		if after == "" {
+			return "a0", nil
+		}
+
+		integerAfter := getPositionInteger(after)
+		fractionAfter := after[len(integerAfter):]
+		if integerAfter == smallestPositionInteger {
+			return integerAfter + positionMidpoint("", fractionAfter), nil
+		}
+		if integerAfter < after {
+			return integerAfter, nil
+		}
+
+		decremented, ok := decrementPositionInteger(integerAfter)
+		if !ok {
+			return "", newPositionError()
+		}
+		return decremented, nil
+	}
+
+	integerBefore := getPositionInteger(before)
+	fractionBefore := before[len(integerBefore):]
+
+	if after == "" {
+		incremented, ok := incrementPositionInteger(integerBefore)
+		if !ok {
+			return integerBefore + positionMidpoint(fractionBefore, ""), nil
+		}
+		return incremented, nil
+	}
+
+	integerAfter := getPositionInteger(after)
+	fractionAfter := after[len(integerAfter):]
+	if integerBefore == integerAfter {
+		return integerBefore + positionMidpoint(fractionBefore, fractionAfter), nil
+	}
+
+	incremented, ok := incrementPositionInteger(integerBefore)
+	if ok && incremented < after {
+		return incremented, nil
+	}
+
+	return integerBefore + positionMidpoint(fractionBefore, ""), nil
+}
+
+// positionMidpoint возвращает дробную часть между a и b (пустой b - верхняя граница)
+func positionMidpoint(a string, b string) string {
+	if b != "" {
+		common := 0
+		for common < len(b) && positionDigitAt(a, common) == b[common] {
+			common++
+		}
+		if common > 0 {
+			rest := ""
+			if common < len(a) {
+				rest = a[common:]
+			}
+			return b[:common] + positionMidpoint(rest, b[common:])
+		}
+	}
+
+	digitA := 0
+	if a != "" {
+		digitA = strings.IndexByte(positionDigits, a[0])
+	}
+	digitB := len(positionDigits)
+	if b != "" {
+		digitB = strings.IndexByte(positionDigits, b[0])
+	}
+
+	if digitB-digitA > 1 {
+		return string(positionDigits[(digitA+digitB+1)/2])
+	}
+
+	if len(b) > 1 {
+		return b[:1]
+	}
+
+	rest := ""
+	if len(a) > 1 {
+		rest = a[1:]
+	}
+	return string(positionDigits[digitA]) + positionMidpoint(rest, "")
+}
+
+func positionDigitAt(value string, index int) byte {
+	if index < len(value) {
+		return value[index]
+	}
+	return positionDigits[0]
+}
+
+func getPositionIntegerLength(head byte) int {
+	switch {
+	case head >= 'a' && head <= 'z':
+		return int(head-'a') + 2
+	case head >= 'A' && head <= 'Z':
+		return int('Z'-head) + 2
+	}
+	return 0
+}
+
+func getPositionInteger(position string) string {
+	return position[:getPositionIntegerLength(position[0])]
+}
+
+func isValidPosition(position string) bool {
+	if position == smallestPositionInteger {
+		return false
+	}
+
+	length := getPositionIntegerLength(position[0])
+	if length == 0 || length > len(position) {
+		return false
+	}
+
+	for i := 1; i < len(position); i++ {
+		if strings.IndexByte(positionDigits, position[i]) < 0 {
+			return false
+		}
+	}
+
+	return !strings.HasSuffix(position[length:], "0")
+}
+
+func incrementPositionInteger(integer string) (string, bool) {
+	head := integer[0]
+	digits := []byte(integer[1:])
+
+	carry := true
+	for i := len(digits) - 1; carry && i >= 0; i-- {
+		digit := strings.IndexByte(positionDigits, digits[i]) + 1
+		if digit == len(positionDigits) {
+			digits[i] = positionDigits[0]
+		} else {
+			digits[i] = positionDigits[digit]
+			carry = false
+		}
+	}
+
+	if !carry {
+		return string(head) + string(digits), true
+	}
+
+	switch head {
+	case 'Z':
+		return "a0", true
+	case 'z':
+		return "", false
+	}
+
+	head++
+	if head > 'a' {
+		digits = append(digits, positionDigits[0])
+	} else {
+		digits = digits[:len(digits)-1]
+	}
+	return string(head) + string(digits), true
+}
+
+func decrementPositionInteger(integer string) (string, bool) {
+	head := integer[0]
+	digits := []byte(integer[1:])
+
+	borrow := true
+	for i := len(digits) - 1; borrow && i >= 0; i-- {
+		digit := strings.IndexByte(positionDigits, digits[i]) - 1
+		if digit == -1 {
+			digits[i] = positionDigits[len(positionDigits)-1]
+		} else {
+			digits[i] = positionDigits[digit]
+			borrow = false
+		}
+	}
+
+	if !borrow {
+		return string(head) + string(digits), true
+	}
+
+	switch head {
+	case 'a':
+		return "Z" + string(positionDigits[len(positionDigits)-1]), true
+	case 'A':
+		return "", false
+	}
+
+	head--
+	if head < 'Z' {
+		digits = append(digits, positionDigits[len(positionDigits)-1])
+	} else {
+		digits = digits[:len(digits)-1]
+	}
+	return string(head) + string(digits), true
+}
+
+func newPositionError() *model.ApplicationError {
+	return model.NewLocalizedError(model.ErrorTypeInternal, model.CodePositionInvalid, nil, nil)
+}
@@ -2,14 +2,19 @@ package utils
 
 import (
 	"Notes/internal/model"
+	"errors"
 	"golang.org/x/crypto/bcrypt"
 )
 
 func CompareHashAndPassword(hash1, password string) (bool, *model.ApplicationError) {
 	err := bcrypt.CompareHashAndPassword([]byte(hash1), []byte(password))
 
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, nil
+	}
+
 	if err != nil {
-		return false, model.NewApplicationError(model.ErrorTypeInternal, "Ошибка при проверке хэша", err)
+		return false, model.NewLocalizedError(model.ErrorTypeInternal, model.CodeHashCheckFailed, nil, err)
 	}
 
 	return true, nil
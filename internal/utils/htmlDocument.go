@@ -0,0 +1,159 @@
+package utils
+
+import (
+	"Notes/internal/model"
+	"bytes"
+	"fmt"
+	"github.com/russross/blackfriday/v2"
+	"html/template"
+	"strings"
+)
+
+// documentMarkdownExtensions - расширения Markdown, с которыми заметки выводятся в HTML и PDF
+const documentMarkdownExtensions = blackfriday.CommonExtensions | blackfriday.HardLineBreak
+
+// Подписи документа
+const (
+	documentTagsLabel     = "Теги"
+	documentModifiedLabel = "Изменено"
+	documentTocLabel      = "Содержание"
+	documentTimeLayout    = "02.01.2006 15:04"
+)
+
+// htmlDocumentTemplate - самодостаточная страница: стили встроены, внешних ресурсов нет
+var htmlDocumentTemplate = template.Must(template.New("document").Parse(`<!DOCTYPE html>
+<html lang="ru">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Roboto, Arial, sans-serif; max-width: 800px; margin: 40px auto; padding: 0 20px; color: #222; line-height: 1.5; }
+h1, h2, h3, h4, h5, h6 { line-height: 1.25; }
+.note { margin-bottom: 48px; }
+.meta { color: #777; font-size: 0.9em; margin-bottom: 16px; }
+.toc ol { padding-left: 20px; }
+pre { background: #f5f5f5; padding: 12px; overflow-x: auto; }
+code { background: #f5f5f5; padding: 0 3px; }
+pre code { padding: 0; }
+blockquote { border-left: 4px solid #ddd; margin: 0; padding-left: 16px; color: #555; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; }
+li.task { list-style: none; }
+li.task input { margin: 0 6px 0 -20px; }
+</style>
+</head>
+<body>
+{{if .IsFolder}}<h1>{{.Title}}</h1>
+<nav class="toc">
+<h2>{{.TocLabel}}</h2>
+<ol>
+{{range .Notes}}<li><a href="#note-{{.Id}}">{{.Title}}</a></li>
+{{end}}</ol>
+</nav>
+{{end}}{{range .Notes}}<article class="note" id="note-{{.Id}}">
+{{if $.IsFolder}}<h2>{{.Title}}</h2>{{else}}<h1>{{.Title}}</h1>{{end}}
+<div class="meta">{{if .Tags}}{{$.TagsLabel}}: {{.Tags}} · {{end}}{{$.ModifiedLabel}}: {{.Modified}}</div>
+{{.Content}}
+</article>
+{{end}}</body>
+</html>
+`))
+
+type htmlDocumentView struct {
+	Title         string
+	IsFolder      bool
+	TocLabel      string
+	TagsLabel     string
+	ModifiedLabel string
+	Notes         []*htmlDocumentNote
+}
+
+type htmlDocumentNote struct {
+	Id       int
+	Title    string
+	Tags     string
+	Modified string
+	Content  template.HTML
+}
+
+// RenderHtmlDocument выводит документ в HTML. Содержимое заметок переводится из Markdown,
+// HTML из заметок отбрасывается, пункты чек-листов становятся неактивными флажками.
+// Для папки добавляется оглавление со ссылками на заметки.
+func RenderHtmlDocument(document *model.ExportDocument) ([]byte, error) {
+	view := &htmlDocumentView{
+		Title:         document.Title,
+		IsFolder:      document.IsFolder,
+		TocLabel:      documentTocLabel,
+		TagsLabel:     documentTagsLabel,
+		ModifiedLabel: documentModifiedLabel,
+		Notes:         make([]*htmlDocumentNote, 0, len(document.Notes)),
+	}
+
+	// Заголовки внутри заметки опускаются ниже заголовка самой заметки
+	headingOffset := 1
+	if document.IsFolder {
+		headingOffset = 2
+	}
+
+	for _, note := range document.Notes {
+		renderer := blackfriday.NewHTMLRenderer(blackfriday.HTMLRendererParameters{
+			Flags:              blackfriday.SkipHTML | blackfriday.SkipImages | blackfriday.Safelink | blackfriday.NofollowLinks | blackfriday.NoopenerLinks | blackfriday.HrefTargetBlank,
+			HeadingIDPrefix:    fmt.Sprintf("note-%d-", note.Id),
+			HeadingLevelOffset: headingOffset,
+		})
+
+		var content bytes.Buffer
+		root := parseDocumentMarkdown(note.Content)
+		renderer.RenderHeader(&content, root)
+		root.Walk(func(node *blackfriday.Node, entering bool) blackfriday.WalkStatus {
+			return renderer.RenderNode(&content, node, entering)
+		})
+		renderer.RenderFooter(&content, root)
+
+		view.Notes = append(view.Notes, &htmlDocumentNote{
+			Id:       note.Id,
+			Title:    note.Title,
+			Tags:     strings.Join(note.Tags, ", "),
+			Modified: note.Timestamp.Format(documentTimeLayout),
+			Content:  template.HTML(htmlTaskItems(content.Bytes())),
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := htmlDocumentTemplate.Execute(&buf, view); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// parseDocumentMarkdown разбирает Markdown заметки. Переводы строк внутри абзаца сохраняются,
+// перевод строки в конце абзаца или пункта списка отбрасывается.
+func parseDocumentMarkdown(content string) *blackfriday.Node {
+	root := blackfriday.New(blackfriday.WithExtensions(documentMarkdownExtensions)).Parse([]byte(content))
+
+	var trailing []*blackfriday.Node
+	root.Walk(func(node *blackfriday.Node, entering bool) blackfriday.WalkStatus {
+		if entering && node.Type == blackfriday.Hardbreak && node.Next == nil {
+			trailing = append(trailing, node)
+		}
+		return blackfriday.GoToNext
+	})
+
+	for _, node := range trailing {
+		node.Unlink()
+	}
+
+	return root
+}
+
+// htmlTaskItems заменяет отметки "[ ]" и "[x]" в начале пунктов списка на флажки
+func htmlTaskItems(content []byte) string {
+	replacer := strings.NewReplacer(
+		"<li>[ ] ", `<li class="task"><input type="checkbox" disabled> `,
+		"<li>[x] ", `<li class="task"><input type="checkbox" checked disabled> `,
+		"<li>[X] ", `<li class="task"><input type="checkbox" checked disabled> `,
+	)
+
+	return replacer.Replace(string(content))
+}
@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const icalendarTimeLayout = "20060102T150405Z"
+
+// RFC 5545: строки длиннее 75 октетов переносятся, продолжение начинается с пробела
+const icalendarLineLimit = 75
+
+// ICalendar формирует документ в формате iCalendar (RFC 5545)
+type ICalendar struct {
+	builder strings.Builder
+}
+
+func NewICalendar(productId string, name string) *ICalendar {
+	calendar := &ICalendar{}
+	calendar.Begin("VCALENDAR")
+	calendar.Property("VERSION", "2.0")
+	calendar.Property("PRODID", productId)
+	calendar.Property("CALSCALE", "GREGORIAN")
+	calendar.Property("METHOD", "PUBLISH")
+	calendar.Text("X-WR-CALNAME", name)
+
+	return calendar
+}
+
+func (c *ICalendar) Begin(component string) {
+	c.Property("BEGIN", component)
+}
+
+func (c *ICalendar) End(component string) {
+	c.Property("END", component)
+}
+
+// Property записывает значение как есть, без экранирования
+func (c *ICalendar) Property(name string, value string) {
+	c.writeLine(name + ":" + value)
+}
+
+// Text записывает текстовое значение с экранированием спецсимволов
+func (c *ICalendar) Text(name string, value string) {
+	c.Property(name, EscapeICalendarText(value))
+}
+
+// List записывает несколько текстовых значений через запятую
+func (c *ICalendar) List(name string, values []string) {
+	escaped := make([]string, 0, len(values))
+	for _, value := range values {
+		escaped = append(escaped, EscapeICalendarText(value))
+	}
+
+	c.Property(name, strings.Join(escaped, ","))
+}
+
+func (c *ICalendar) Time(name string, value time.Time) {
+	c.Property(name, value.UTC().Format(icalendarTimeLayout))
+}
+
+// String завершает календарь и возвращает документ
+func (c *ICalendar) String() string {
+	c.End("VCALENDAR")
+	return c.builder.String()
+}
+
+func EscapeICalendarText(value string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	)
+
+	return replacer.Replace(value)
+}
+
+func (c *ICalendar) writeLine(line string) {
+	limit := icalendarLineLimit
+	for len(line) > limit {
+		// Не разрываем многобайтовый символ UTF-8
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		c.builder.WriteString(line[:cut])
+		c.builder.WriteString("\r\n ")
+		line = line[cut:]
+		limit = icalendarLineLimit - 1
+	}
+
+	c.builder.WriteString(line)
+	c.builder.WriteString("\r\n")
+}
@@ -0,0 +1,71 @@
+package utils
+
+import (
+	"Notes/internal/model"
+	"bytes"
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+)
+
+// MaxImagePixels ограничивает размер декодируемого изображения, чтобы не упасть на "image bomb"
+const MaxImagePixels = 50_000_000
+
+const thumbnailJpegQuality = 85
+
+// ResizeImage вписывает изображение в квадрат size x size с сохранением пропорций.
+// Изображения с прозрачностью (PNG, WebP) кодируются в PNG, остальные в JPEG.
+func ResizeImage(content io.Reader, size int) ([]byte, string, *model.ApplicationError) {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return nil, "", model.NewLocalizedError(model.ErrorTypeInternal, model.CodeImageReadFailed, nil, err)
+	}
+
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", model.NewLocalizedError(model.ErrorTypeValidation, model.CodeImageFormatUnsupported, nil, err)
+	}
+
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > MaxImagePixels {
+		return nil, "", model.NewLocalizedError(model.ErrorTypeValidation, model.CodeImageTooLarge, nil, nil)
+	}
+
+	source, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", model.NewLocalizedError(model.ErrorTypeValidation, model.CodeImageDecodeFailed, nil, err)
+	}
+
+	width, height := fitInto(config.Width, config.Height, size)
+	target := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(target, target.Bounds(), source, source.Bounds(), draw.Src, nil)
+
+	var buffer bytes.Buffer
+	if format == "jpeg" {
+		err = jpeg.Encode(&buffer, target, &jpeg.Options{Quality: thumbnailJpegQuality})
+		if err != nil {
+			return nil, "", model.NewLocalizedError(model.ErrorTypeInternal, model.CodeThumbnailEncodeFailed, nil, err)
+		}
+		return buffer.Bytes(), "image/jpeg", nil
+	}
+
+	err = png.Encode(&buffer, target)
+	if err != nil {
+		return nil, "", model.NewLocalizedError(model.ErrorTypeInternal, model.CodeThumbnailEncodeFailed, nil, err)
+	}
+	return buffer.Bytes(), "image/png", nil
+}
+
+func fitInto(width int, height int, size int) (int, int) {
+	if width <= size && height <= size {
+		return width, height
+	}
+
+	if width >= height {
+		return size, max(1, height*size/width)
+	}
+
+	return max(1, width*size/height), size
+}
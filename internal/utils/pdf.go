@@ -0,0 +1,432 @@
+package utils
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+	"sort"
+	"strings"
+	"unicode/utf16"
+)
+
+// Размер страницы A4 в пунктах
+const (
+	PdfPageWidth  = 595.28
+	PdfPageHeight = 841.89
+)
+
+// pdfBfCharLimit - наибольшее число записей в одном блоке beginbfchar
+const pdfBfCharLimit = 100
+
+type PdfColor struct {
+	R, G, B float64
+}
+
+// Pdf формирует PDF-документ со встроенными шрифтами TrueType. Шрифты встраиваются целиком
+// и кодируются по номерам глифов (Identity-H), поэтому текст может быть на любом языке,
+// который поддерживает шрифт. Координаты отсчитываются от левого верхнего угла страницы.
+type Pdf struct {
+	title     string
+	fonts     []*pdfFont
+	fontNames map[string]*pdfFont
+	pages     []*pdfPage
+	current   int
+}
+
+type pdfFont struct {
+	id         string
+	name       string
+	data       []byte
+	font       *sfnt.Font
+	buf        sfnt.Buffer
+	unitsPerEm float64
+	glyphs     map[sfnt.GlyphIndex]rune
+	widths     map[sfnt.GlyphIndex]int
+}
+
+type pdfPage struct {
+	content bytes.Buffer
+	links   []*pdfLink
+}
+
+// pdfLink - ссылка на адрес (uri) или на место в документе (page, top)
+type pdfLink struct {
+	x, y, width, height float64
+	uri                 string
+	page                int
+	top                 float64
+}
+
+func NewPdf(title string) *Pdf {
+	return &Pdf{title: title, fontNames: make(map[string]*pdfFont)}
+}
+
+// AddFont регистрирует шрифт TrueType под именем name. В документ попадают только использованные шрифты.
+func (p *Pdf) AddFont(name string, ttf []byte) error {
+	parsed, err := sfnt.Parse(ttf)
+	if err != nil {
+		return err
+	}
+
+	pdfFont := &pdfFont{
+		id:         fmt.Sprintf("F%d", len(p.fonts)+1),
+		data:       ttf,
+		font:       parsed,
+		unitsPerEm: float64(parsed.UnitsPerEm()),
+		glyphs:     make(map[sfnt.GlyphIndex]rune),
+		widths:     make(map[sfnt.GlyphIndex]int),
+	}
+
+	pdfFont.name, err = parsed.Name(&pdfFont.buf, sfnt.NameIDPostScript)
+	if err != nil || pdfFont.name == "" {
+		pdfFont.name = name
+	}
+
+	p.fonts = append(p.fonts, pdfFont)
+	p.fontNames[name] = pdfFont
+	return nil
+}
+
+// AddPage добавляет страницу и возвращает её номер, начиная с нуля. Дальнейший вывод идёт на неё.
+func (p *Pdf) AddPage() int {
+	p.pages = append(p.pages, &pdfPage{})
+	p.current = len(p.pages) - 1
+	return p.current
+}
+
+// SetPage переключает вывод на ранее добавленную страницу, например чтобы дописать номера страниц
+func (p *Pdf) SetPage(page int) {
+	if page >= 0 && page < len(p.pages) {
+		p.current = page
+	}
+}
+
+func (p *Pdf) PageCount() int {
+	return len(p.pages)
+}
+
+// TextWidth возвращает ширину текста в пунктах
+func (p *Pdf) TextWidth(fontName string, size float64, text string) float64 {
+	f := p.fontNames[fontName]
+	if f == nil {
+		return 0
+	}
+
+	width := 0
+	for _, r := range text {
+		width += f.glyphWidth(f.glyphIndex(r))
+	}
+
+	return float64(width) * size / 1000
+}
+
+// Text выводит строку, y - базовая линия текста
+func (p *Pdf) Text(x float64, y float64, fontName string, size float64, color PdfColor, text string) {
+	f := p.fontNames[fontName]
+	if f == nil || text == "" {
+		return
+	}
+
+	var hex strings.Builder
+	for _, r := range text {
+		glyph := f.glyphIndex(r)
+		if _, exists := f.glyphs[glyph]; !exists {
+			f.glyphs[glyph] = r
+		}
+		f.glyphWidth(glyph)
+		fmt.Fprintf(&hex, "%04X", uint16(glyph))
+	}
+
+	fmt.Fprintf(&p.page().content, "BT %s rg /%s %s Tf %s %s Td <%s> Tj ET\n",
+		pdfColor(color), f.id, pdfNumber(size), pdfNumber(x), pdfNumber(PdfPageHeight-y), hex.String())
+}
+
+func (p *Pdf) Line(x1 float64, y1 float64, x2 float64, y2 float64, width float64, color PdfColor) {
+	fmt.Fprintf(&p.page().content, "%s RG %s w %s %s m %s %s l S\n",
+		pdfColor(color), pdfNumber(width), pdfNumber(x1), pdfNumber(PdfPageHeight-y1), pdfNumber(x2), pdfNumber(PdfPageHeight-y2))
+}
+
+// Rect закрашивает прямоугольник, (x, y) - левый верхний угол
+func (p *Pdf) Rect(x float64, y float64, width float64, height float64, color PdfColor) {
+	fmt.Fprintf(&p.page().content, "%s rg %s %s %s %s re f\n",
+		pdfColor(color), pdfNumber(x), pdfNumber(PdfPageHeight-y-height), pdfNumber(width), pdfNumber(height))
+}
+
+// LinkUri делает область страницы ссылкой на внешний адрес
+func (p *Pdf) LinkUri(x float64, y float64, width float64, height float64, uri string) {
+	page := p.page()
+	page.links = append(page.links, &pdfLink{x: x, y: y, width: width, height: height, uri: uri, page: -1})
+}
+
+// LinkPage делает область страницы ссылкой на место top страницы page
+func (p *Pdf) LinkPage(x float64, y float64, width float64, height float64, page int, top float64) {
+	current := p.page()
+	current.links = append(current.links, &pdfLink{x: x, y: y, width: width, height: height, page: page, top: top})
+}
+
+func (p *Pdf) page() *pdfPage {
+	if len(p.pages) == 0 {
+		p.AddPage()
+	}
+
+	return p.pages[p.current]
+}
+
+// Bytes собирает документ
+func (p *Pdf) Bytes() ([]byte, error) {
+	if len(p.pages) == 0 {
+		p.AddPage()
+	}
+
+	w := &pdfObjectWriter{}
+
+	// Номера объектов: 1 - каталог, 2 - дерево страниц, 3 - сведения о документе, далее страницы и шрифты
+	catalogId, pagesId, infoId := 1, 2, 3
+	next := 4
+
+	pageIds := make([]int, len(p.pages))
+	contentIds := make([]int, len(p.pages))
+	for i := range p.pages {
+		pageIds[i] = next
+		contentIds[i] = next + 1
+		next += 2
+	}
+
+	usedFonts := make([]*pdfFont, 0, len(p.fonts))
+	for _, f := range p.fonts {
+		if len(f.glyphs) > 0 {
+			usedFonts = append(usedFonts, f)
+		}
+	}
+
+	fontIds := make(map[*pdfFont]int, len(usedFonts))
+	for _, f := range usedFonts {
+		fontIds[f] = next
+		next += 5
+	}
+
+	w.object(catalogId, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pagesId))
+
+	kids := make([]string, 0, len(pageIds))
+	for _, id := range pageIds {
+		kids = append(kids, fmt.Sprintf("%d 0 R", id))
+	}
+	w.object(pagesId, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pageIds)))
+
+	w.object(infoId, fmt.Sprintf("<< /Title %s /Producer (Notes) >>", pdfTextString(p.title)))
+
+	fontResources := make([]string, 0, len(usedFonts))
+	for _, f := range usedFonts {
+		fontResources = append(fontResources, fmt.Sprintf("/%s %d 0 R", f.id, fontIds[f]))
+	}
+
+	for i, page := range p.pages {
+		annots := make([]string, 0, len(page.links))
+		for _, link := range page.links {
+			action := ""
+			if link.page >= 0 && link.page < len(pageIds) {
+				action = fmt.Sprintf("/Dest [%d 0 R /XYZ 0 %s 0]", pageIds[link.page], pdfNumber(PdfPageHeight-link.top))
+			} else if link.uri != "" {
+				action = fmt.Sprintf("/A << /S /URI /URI %s >>", pdfLiteralString(link.uri))
+			} else {
+				continue
+			}
+
+			annots = append(annots, fmt.Sprintf("<< /Type /Annot /Subtype /Link /Rect [%s %s %s %s] /Border [0 0 0] %s >>",
+				pdfNumber(link.x), pdfNumber(PdfPageHeight-link.y-link.height), pdfNumber(link.x+link.width), pdfNumber(PdfPageHeight-link.y), action))
+		}
+
+		w.object(pageIds[i], fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %s %s] /Resources << /Font << %s >> >> /Contents %d 0 R /Annots [%s] >>",
+			pagesId, pdfNumber(PdfPageWidth), pdfNumber(PdfPageHeight), strings.Join(fontResources, " "), contentIds[i], strings.Join(annots, " ")))
+
+		if err := w.stream(contentIds[i], "", page.content.Bytes()); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, f := range usedFonts {
+		if err := f.write(w, fontIds[f]); err != nil {
+			return nil, err
+		}
+	}
+
+	return w.finish(catalogId, infoId), nil
+}
+
+func (f *pdfFont) glyphIndex(r rune) sfnt.GlyphIndex {
+	glyph, err := f.font.GlyphIndex(&f.buf, r)
+	if err != nil {
+		return 0
+	}
+
+	return glyph
+}
+
+// glyphWidth возвращает ширину глифа в тысячных долях кегля
+func (f *pdfFont) glyphWidth(glyph sfnt.GlyphIndex) int {
+	if width, exists := f.widths[glyph]; exists {
+		return width
+	}
+
+	advance, err := f.font.GlyphAdvance(&f.buf, glyph, fixed.I(int(f.unitsPerEm)), font.HintingNone)
+	width := 0
+	if err == nil {
+		width = int(float64(advance) / 64 * 1000 / f.unitsPerEm)
+	}
+
+	f.widths[glyph] = width
+	return width
+}
+
+func (f *pdfFont) scale(value float64) int {
+	return int(value * 1000 / f.unitsPerEm)
+}
+
+// write записывает шрифт Type0 из пяти объектов начиная с id: сам шрифт, CIDFont,
+// описание шрифта, файл шрифта и таблицу соответствия глифов символам для копирования текста
+func (f *pdfFont) write(w *pdfObjectWriter, id int) error {
+	cidFontId, descriptorId, fileId, toUnicodeId := id+1, id+2, id+3, id+4
+	ppem := fixed.I(int(f.unitsPerEm))
+
+	bounds, err := f.font.Bounds(&f.buf, ppem, font.HintingNone)
+	if err != nil {
+		return err
+	}
+
+	metrics, err := f.font.Metrics(&f.buf, ppem, font.HintingNone)
+	if err != nil {
+		return err
+	}
+
+	glyphs := make([]sfnt.GlyphIndex, 0, len(f.glyphs))
+	for glyph := range f.glyphs {
+		glyphs = append(glyphs, glyph)
+	}
+	sort.Slice(glyphs, func(i, j int) bool { return glyphs[i] < glyphs[j] })
+
+	widths := make([]string, 0, len(glyphs))
+	for _, glyph := range glyphs {
+		widths = append(widths, fmt.Sprintf("%d [%d]", glyph, f.widths[glyph]))
+	}
+
+	w.object(id, fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H /DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>",
+		f.name, cidFontId, toUnicodeId))
+
+	w.object(cidFontId, fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> /FontDescriptor %d 0 R /DW 1000 /W [%s] /CIDToGIDMap /Identity >>",
+		f.name, descriptorId, strings.Join(widths, " ")))
+
+	w.object(descriptorId, fmt.Sprintf("<< /Type /FontDescriptor /FontName /%s /Flags 32 /FontBBox [%d %d %d %d] /ItalicAngle 0 /Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 %d 0 R >>",
+		f.name,
+		f.scale(float64(bounds.Min.X)/64), f.scale(float64(-bounds.Max.Y)/64), f.scale(float64(bounds.Max.X)/64), f.scale(float64(-bounds.Min.Y)/64),
+		f.scale(float64(metrics.Ascent)/64), -f.scale(float64(metrics.Descent)/64), f.scale(float64(metrics.CapHeight)/64), fileId))
+
+	if err = w.stream(fileId, fmt.Sprintf("/Length1 %d", len(f.data)), f.data); err != nil {
+		return err
+	}
+
+	return w.stream(toUnicodeId, "", f.toUnicode(glyphs))
+}
+
+func (f *pdfFont) toUnicode(glyphs []sfnt.GlyphIndex) []byte {
+	var cmap strings.Builder
+	cmap.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n")
+	cmap.WriteString("/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n")
+	cmap.WriteString("/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n")
+	cmap.WriteString("1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+
+	for start := 0; start < len(glyphs); start += pdfBfCharLimit {
+		block := glyphs[start:min(start+pdfBfCharLimit, len(glyphs))]
+		fmt.Fprintf(&cmap, "%d beginbfchar\n", len(block))
+		for _, glyph := range block {
+			fmt.Fprintf(&cmap, "<%04X> <%s>\n", uint16(glyph), utf16Hex(string(f.glyphs[glyph])))
+		}
+		cmap.WriteString("endbfchar\n")
+	}
+
+	cmap.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n")
+	return []byte(cmap.String())
+}
+
+// pdfObjectWriter записывает объекты и таблицу их смещений (xref)
+type pdfObjectWriter struct {
+	buf     bytes.Buffer
+	offsets map[int]int
+}
+
+func (w *pdfObjectWriter) object(id int, body string) {
+	w.begin(id)
+	w.buf.WriteString(body)
+	w.buf.WriteString("\nendobj\n")
+}
+
+// stream записывает поток, сжатый FlateDecode. extra - дополнительные ключи словаря потока.
+func (w *pdfObjectWriter) stream(id int, extra string, data []byte) error {
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	if _, err := zw.Write(data); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	w.begin(id)
+	fmt.Fprintf(&w.buf, "<< /Length %d /Filter /FlateDecode %s >>\nstream\n", compressed.Len(), extra)
+	w.buf.Write(compressed.Bytes())
+	w.buf.WriteString("\nendstream\nendobj\n")
+	return nil
+}
+
+func (w *pdfObjectWriter) begin(id int) {
+	if w.offsets == nil {
+		w.offsets = make(map[int]int)
+		// Двоичный комментарий во второй строке подсказывает программам, что файл не текстовый
+		w.buf.WriteString("%PDF-1.7\n%\xe2\xe3\xcf\xd3\n")
+	}
+
+	w.offsets[id] = w.buf.Len()
+	fmt.Fprintf(&w.buf, "%d 0 obj\n", id)
+}
+
+func (w *pdfObjectWriter) finish(rootId int, infoId int) []byte {
+	size := len(w.offsets) + 1
+	xref := w.buf.Len()
+
+	fmt.Fprintf(&w.buf, "xref\n0 %d\n0000000000 65535 f \n", size)
+	for id := 1; id < size; id++ {
+		fmt.Fprintf(&w.buf, "%010d 00000 n \n", w.offsets[id])
+	}
+
+	fmt.Fprintf(&w.buf, "trailer\n<< /Size %d /Root %d 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", size, rootId, infoId, xref)
+	return w.buf.Bytes()
+}
+
+func pdfNumber(value float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", value), "0"), ".")
+}
+
+func pdfColor(color PdfColor) string {
+	return pdfNumber(color.R) + " " + pdfNumber(color.G) + " " + pdfNumber(color.B)
+}
+
+// pdfTextString кодирует текст в UTF-16BE с BOM, как требуется для строк вне содержимого страниц
+func pdfTextString(text string) string {
+	return "<FEFF" + utf16Hex(text) + ">"
+}
+
+func pdfLiteralString(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`)
+	return "(" + replacer.Replace(text) + ")"
+}
+
+func utf16Hex(text string) string {
+	var hex strings.Builder
+	for _, unit := range utf16.Encode([]rune(text)) {
+		fmt.Fprintf(&hex, "%04X", unit)
+	}
+
+	return hex.String()
+}
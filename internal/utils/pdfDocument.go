@@ -0,0 +1,642 @@
+package utils
+
+import (
+	"Notes/internal/model"
+	"fmt"
+	"github.com/russross/blackfriday/v2"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/gobolditalic"
+	"golang.org/x/image/font/gofont/goitalic"
+	"golang.org/x/image/font/gofont/gomono"
+	"golang.org/x/image/font/gofont/goregular"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Шрифты PDF-документа
+const (
+	pdfFontRegular    = "regular"
+	pdfFontBold       = "bold"
+	pdfFontItalic     = "italic"
+	pdfFontBoldItalic = "bolditalic"
+	pdfFontMono       = "mono"
+)
+
+// Размеры в пунктах
+const (
+	pdfMargin       = 56.0
+	pdfContentWidth = PdfPageWidth - 2*pdfMargin
+	pdfBodySize     = 11.0
+	pdfCodeSize     = 9.5
+	pdfMetaSize     = 9.0
+	pdfTitleSize    = 20.0
+	pdfLineFactor   = 1.45
+	pdfListIndent   = 18.0
+	pdfQuoteIndent  = 14.0
+	pdfCellPadding  = 4.0
+)
+
+var (
+	pdfTextColor   = PdfColor{R: 0.13, G: 0.13, B: 0.13}
+	pdfMutedColor  = PdfColor{R: 0.47, G: 0.47, B: 0.47}
+	pdfLinkColor   = PdfColor{R: 0.05, G: 0.35, B: 0.75}
+	pdfRuleColor   = PdfColor{R: 0.8, G: 0.8, B: 0.8}
+	pdfShadeColor  = PdfColor{R: 0.96, G: 0.96, B: 0.96}
+	pdfHeadingSize = []float64{16, 14, 13, 12, 12, 12}
+)
+
+// pdfSpan - кусок текста одного начертания
+type pdfSpan struct {
+	text   string
+	font   string
+	size   float64
+	color  PdfColor
+	uri    string
+	strike bool
+}
+
+// pdfLine - строка после переноса. Координаты x кусков отсчитываются от начала строки.
+type pdfLine struct {
+	spans  []*pdfSpan
+	xs     []float64
+	width  float64
+	height float64
+}
+
+// pdfInlineStyle - начертание, унаследованное от родительских узлов Markdown
+type pdfInlineStyle struct {
+	bold   bool
+	italic bool
+	strike bool
+	muted  bool
+	uri    string
+	size   float64
+}
+
+// pdfLayout раскладывает документ по страницам. y - верх следующей строки на текущей странице.
+type pdfLayout struct {
+	pdf    *Pdf
+	y      float64
+	indent float64
+	quotes []float64
+}
+
+// pdfTocEntry - строка оглавления, номер страницы и ссылка в которой дописываются после вывода заметок
+type pdfTocEntry struct {
+	page int
+	y    float64
+}
+
+// RenderPdfDocument выводит документ в PDF. Используются встроенные шрифты Go, внешние программы не нужны.
+// Каждая заметка папки начинается с новой страницы, перед заметками выводится оглавление.
+func RenderPdfDocument(document *model.ExportDocument) ([]byte, error) {
+	pdf := NewPdf(document.Title)
+
+	for name, ttf := range map[string][]byte{
+		pdfFontRegular:    goregular.TTF,
+		pdfFontBold:       gobold.TTF,
+		pdfFontItalic:     goitalic.TTF,
+		pdfFontBoldItalic: gobolditalic.TTF,
+		pdfFontMono:       gomono.TTF,
+	} {
+		if err := pdf.AddFont(name, ttf); err != nil {
+			return nil, err
+		}
+	}
+
+	layout := &pdfLayout{pdf: pdf}
+	layout.newPage()
+
+	var toc []*pdfTocEntry
+	if document.IsFolder {
+		layout.paragraph([]*pdfSpan{{text: document.Title, font: pdfFontBold, size: pdfTitleSize + 4, color: pdfTextColor}}, 12)
+		layout.paragraph([]*pdfSpan{{text: documentTocLabel, font: pdfFontBold, size: pdfHeadingSize[1], color: pdfTextColor}}, 6)
+		toc = layout.toc(document.Notes)
+	}
+
+	notePages := make([]int, 0, len(document.Notes))
+	for i, note := range document.Notes {
+		if document.IsFolder || i > 0 {
+			layout.newPage()
+		}
+
+		notePages = append(notePages, pdf.current)
+		layout.note(note)
+	}
+
+	for i, entry := range toc {
+		pdf.SetPage(entry.page)
+		height := pdfBodySize * pdfLineFactor
+		number := strconv.Itoa(notePages[i] + 1)
+		width := pdf.TextWidth(pdfFontRegular, pdfBodySize, number)
+		pdf.Text(pdfMargin+pdfContentWidth-width, entry.y+height*0.75, pdfFontRegular, pdfBodySize, pdfTextColor, number)
+		pdf.LinkPage(pdfMargin, entry.y, pdfContentWidth, height, notePages[i], pdfMargin)
+	}
+
+	total := pdf.PageCount()
+	for page := 0; page < total; page++ {
+		pdf.SetPage(page)
+		footer := fmt.Sprintf("%d / %d", page+1, total)
+		width := pdf.TextWidth(pdfFontRegular, pdfMetaSize, footer)
+		pdf.Text((PdfPageWidth-width)/2, PdfPageHeight-pdfMargin/2, pdfFontRegular, pdfMetaSize, pdfMutedColor, footer)
+	}
+
+	return pdf.Bytes()
+}
+
+func (l *pdfLayout) newPage() {
+	l.pdf.AddPage()
+	l.y = pdfMargin
+}
+
+// ensure переходит на новую страницу, если блок высотой height не помещается на текущей
+func (l *pdfLayout) ensure(height float64) {
+	if l.y+height > PdfPageHeight-pdfMargin && l.y > pdfMargin {
+		l.newPage()
+	}
+}
+
+func (l *pdfLayout) space(height float64) {
+	if l.y > pdfMargin {
+		l.y += height
+	}
+}
+
+// toc выводит названия заметок, оставляя место для номеров страниц
+func (l *pdfLayout) toc(notes []*model.DocumentNote) []*pdfTocEntry {
+	entries := make([]*pdfTocEntry, 0, len(notes))
+	height := pdfBodySize * pdfLineFactor
+	maxWidth := pdfContentWidth - l.pdf.TextWidth(pdfFontRegular, pdfBodySize, " 0000")
+
+	for i, note := range notes {
+		l.ensure(height)
+		text := l.truncate(fmt.Sprintf("%d. %s", i+1, note.Title), pdfFontRegular, pdfBodySize, maxWidth)
+		l.pdf.Text(pdfMargin, l.y+height*0.75, pdfFontRegular, pdfBodySize, pdfLinkColor, text)
+		entries = append(entries, &pdfTocEntry{page: l.pdf.current, y: l.y})
+		l.y += height
+	}
+
+	return entries
+}
+
+func (l *pdfLayout) note(note *model.DocumentNote) {
+	l.paragraph([]*pdfSpan{{text: note.Title, font: pdfFontBold, size: pdfTitleSize, color: pdfTextColor}}, 2)
+
+	meta := documentModifiedLabel + ": " + note.Timestamp.Format(documentTimeLayout)
+	if len(note.Tags) > 0 {
+		meta = documentTagsLabel + ": " + strings.Join(note.Tags, ", ") + " · " + meta
+	}
+	l.paragraph([]*pdfSpan{{text: meta, font: pdfFontRegular, size: pdfMetaSize, color: pdfMutedColor}}, 4)
+
+	l.pdf.Line(pdfMargin, l.y, pdfMargin+pdfContentWidth, l.y, 0.5, pdfRuleColor)
+	l.y += 10
+
+	root := parseDocumentMarkdown(note.Content)
+	for child := root.FirstChild; child != nil; child = child.Next {
+		l.block(child)
+	}
+}
+
+func (l *pdfLayout) block(node *blackfriday.Node) {
+	switch node.Type {
+	case blackfriday.Paragraph:
+		after := 6.0
+		if node.Parent != nil && node.Parent.Type == blackfriday.Item && node.Parent.Parent != nil && node.Parent.Parent.Tight {
+			after = 1
+		}
+		l.paragraph(pdfInline(node, pdfInlineStyle{size: pdfBodySize}), after)
+	case blackfriday.Heading:
+		size := pdfHeadingSize[min(max(node.Level, 1), len(pdfHeadingSize))-1]
+		l.space(6)
+		l.paragraph(pdfInline(node, pdfInlineStyle{bold: true, size: size}), 4)
+	case blackfriday.List:
+		l.list(node)
+	case blackfriday.BlockQuote:
+		l.quotes = append(l.quotes, pdfMargin+l.indent)
+		l.indent += pdfQuoteIndent
+		for child := node.FirstChild; child != nil; child = child.Next {
+			l.block(child)
+		}
+		l.indent -= pdfQuoteIndent
+		l.quotes = l.quotes[:len(l.quotes)-1]
+	case blackfriday.CodeBlock:
+		l.code(string(node.Literal))
+	case blackfriday.HorizontalRule:
+		l.ensure(12)
+		l.y += 6
+		l.pdf.Line(pdfMargin+l.indent, l.y, pdfMargin+pdfContentWidth, l.y, 0.5, pdfRuleColor)
+		l.y += 8
+	case blackfriday.Table:
+		l.table(node)
+	}
+	// HTML из заметок не выводится, как и в HTML-документе
+}
+
+func (l *pdfLayout) list(node *blackfriday.Node) {
+	number := 1
+
+	for item := node.FirstChild; item != nil; item = item.Next {
+		marker := "•"
+		if node.ListFlags&blackfriday.ListTypeOrdered != 0 {
+			marker = strconv.Itoa(number) + "."
+			number++
+		}
+
+		checked, isTask := pdfTaskItem(item)
+
+		height := pdfBodySize * pdfLineFactor
+		l.ensure(height)
+		x := pdfMargin + l.indent
+		baseline := l.y + height*0.75
+
+		if isTask {
+			l.checkbox(x+2, baseline-8, 8, checked)
+		} else {
+			l.pdf.Text(x, baseline, pdfFontRegular, pdfBodySize, pdfTextColor, marker)
+		}
+
+		l.indent += pdfListIndent
+		for child := item.FirstChild; child != nil; child = child.Next {
+			l.block(child)
+		}
+		l.indent -= pdfListIndent
+	}
+
+	if node.Parent == nil || node.Parent.Type != blackfriday.Item {
+		l.space(4)
+	}
+}
+
+func (l *pdfLayout) checkbox(x float64, y float64, size float64, checked bool) {
+	l.pdf.Line(x, y, x+size, y, 0.7, pdfTextColor)
+	l.pdf.Line(x+size, y, x+size, y+size, 0.7, pdfTextColor)
+	l.pdf.Line(x+size, y+size, x, y+size, 0.7, pdfTextColor)
+	l.pdf.Line(x, y+size, x, y, 0.7, pdfTextColor)
+
+	if checked {
+		l.pdf.Line(x+1.5, y+size/2, x+size/2.5, y+size-1.5, 1, pdfTextColor)
+		l.pdf.Line(x+size/2.5, y+size-1.5, x+size-1.5, y+1.5, 1, pdfTextColor)
+	}
+}
+
+func (l *pdfLayout) code(text string) {
+	height := pdfCodeSize * pdfLineFactor
+	width := pdfContentWidth - l.indent - 2*pdfCellPadding
+
+	l.space(2)
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		for _, part := range l.splitRunes(strings.ReplaceAll(line, "\t", "    "), pdfFontMono, pdfCodeSize, width) {
+			l.ensure(height)
+			l.pdf.Rect(pdfMargin+l.indent, l.y, pdfContentWidth-l.indent, height, pdfShadeColor)
+			l.drawQuotes(height)
+			l.pdf.Text(pdfMargin+l.indent+pdfCellPadding, l.y+height*0.75, pdfFontMono, pdfCodeSize, pdfTextColor, part)
+			l.y += height
+		}
+	}
+	l.y += 8
+}
+
+func (l *pdfLayout) table(node *blackfriday.Node) {
+	var rows []*blackfriday.Node
+	node.Walk(func(child *blackfriday.Node, entering bool) blackfriday.WalkStatus {
+		if entering && child.Type == blackfriday.TableRow {
+			rows = append(rows, child)
+			return blackfriday.SkipChildren
+		}
+		return blackfriday.GoToNext
+	})
+
+	if len(rows) == 0 || rows[0].FirstChild == nil {
+		return
+	}
+
+	columns := 0
+	for cell := rows[0].FirstChild; cell != nil; cell = cell.Next {
+		columns++
+	}
+
+	left := pdfMargin + l.indent
+	columnWidth := (pdfContentWidth - l.indent) / float64(columns)
+
+	l.space(2)
+	for _, row := range rows {
+		cells := make([][]*pdfLine, 0, columns)
+		rowHeight := 0.0
+		header := false
+
+		for cell := row.FirstChild; cell != nil && len(cells) < columns; cell = cell.Next {
+			header = cell.IsHeader
+			lines := l.wrap(pdfInline(cell, pdfInlineStyle{bold: cell.IsHeader, size: pdfBodySize - 1}), columnWidth-2*pdfCellPadding)
+			cells = append(cells, lines)
+
+			height := 0.0
+			for _, line := range lines {
+				height += line.height
+			}
+			rowHeight = max(rowHeight, height)
+		}
+		rowHeight += 2 * pdfCellPadding
+
+		l.ensure(rowHeight)
+		if header {
+			l.pdf.Rect(left, l.y, columnWidth*float64(columns), rowHeight, pdfShadeColor)
+		}
+
+		for i, lines := range cells {
+			y := l.y + pdfCellPadding
+			for _, line := range lines {
+				l.drawLine(line, left+float64(i)*columnWidth+pdfCellPadding, y)
+				y += line.height
+			}
+		}
+
+		for i := 0; i <= columns; i++ {
+			x := left + float64(i)*columnWidth
+			l.pdf.Line(x, l.y, x, l.y+rowHeight, 0.5, pdfRuleColor)
+		}
+		l.pdf.Line(left, l.y, left+columnWidth*float64(columns), l.y, 0.5, pdfRuleColor)
+		l.pdf.Line(left, l.y+rowHeight, left+columnWidth*float64(columns), l.y+rowHeight, 0.5, pdfRuleColor)
+
+		l.y += rowHeight
+	}
+	l.y += 8
+}
+
+// paragraph переносит текст по словам и выводит его с текущим отступом
+func (l *pdfLayout) paragraph(spans []*pdfSpan, after float64) {
+	for _, line := range l.wrap(spans, pdfContentWidth-l.indent) {
+		l.ensure(line.height)
+		l.drawQuotes(line.height)
+		l.drawLine(line, pdfMargin+l.indent, l.y)
+		l.y += line.height
+	}
+
+	l.y += after
+}
+
+func (l *pdfLayout) drawQuotes(height float64) {
+	for _, x := range l.quotes {
+		l.pdf.Rect(x, l.y, 2.5, height, pdfRuleColor)
+	}
+}
+
+func (l *pdfLayout) drawLine(line *pdfLine, x float64, y float64) {
+	baseline := y + line.height*0.75
+
+	for i, span := range line.spans {
+		spanX := x + line.xs[i]
+		l.pdf.Text(spanX, baseline, span.font, span.size, span.color, span.text)
+
+		if span.strike || span.uri != "" {
+			width := l.pdf.TextWidth(span.font, span.size, span.text)
+			if span.strike {
+				l.pdf.Line(spanX, baseline-span.size*0.3, spanX+width, baseline-span.size*0.3, 0.6, span.color)
+			}
+			if span.uri != "" {
+				l.pdf.LinkUri(spanX, y, width, line.height, span.uri)
+			}
+		}
+	}
+}
+
+// wrap разбивает куски текста на строки не шире width. Слово, которое не помещается в строку целиком,
+// разрезается по символам. Перевод строки внутри куска начинает новую строку.
+func (l *pdfLayout) wrap(spans []*pdfSpan, width float64) []*pdfLine {
+	lines := make([]*pdfLine, 0, 1)
+	line := &pdfLine{}
+	spaceWidth := 0.0
+
+	flush := func() {
+		if line.height == 0 {
+			line.height = pdfBodySize * pdfLineFactor
+		}
+		lines = append(lines, line)
+		line = &pdfLine{}
+		spaceWidth = 0
+	}
+
+	add := func(span *pdfSpan, text string, textWidth float64) {
+		// Слова одного начертания выводятся одной строкой вместе с пробелами, чтобы текст копировался из PDF целиком
+		if last := len(line.spans) - 1; last >= 0 && spaceWidth > 0 && line.spans[last].sameStyle(span) {
+			line.spans[last].text += " " + text
+			line.width += l.pdf.TextWidth(span.font, span.size, " ") + textWidth
+			spaceWidth = 0
+			return
+		}
+
+		piece := *span
+		piece.text = text
+		line.spans = append(line.spans, &piece)
+		line.xs = append(line.xs, line.width+spaceWidth)
+		line.width += spaceWidth + textWidth
+		line.height = max(line.height, span.size*pdfLineFactor)
+		spaceWidth = 0
+	}
+
+	for _, span := range spans {
+		for i, segment := range strings.Split(span.text, "\n") {
+			if i > 0 {
+				flush()
+			}
+
+			for _, word := range pdfWords(segment) {
+				if strings.TrimSpace(word) == "" {
+					if len(line.spans) > 0 {
+						spaceWidth += l.pdf.TextWidth(span.font, span.size, word)
+					}
+					continue
+				}
+
+				wordWidth := l.pdf.TextWidth(span.font, span.size, word)
+				if len(line.spans) > 0 && line.width+spaceWidth+wordWidth > width {
+					flush()
+				}
+
+				if wordWidth <= width {
+					add(span, word, wordWidth)
+					continue
+				}
+
+				parts := l.splitRunes(word, span.font, span.size, width-line.width)
+				for j, part := range parts {
+					if j > 0 {
+						flush()
+					}
+					add(span, part, l.pdf.TextWidth(span.font, span.size, part))
+				}
+			}
+		}
+	}
+
+	if len(line.spans) > 0 || len(lines) == 0 {
+		flush()
+	}
+
+	return lines
+}
+
+// splitRunes режет текст по символам на части не шире width. Первая часть может быть уже: first - ширина до конца строки.
+func (l *pdfLayout) splitRunes(text string, font string, size float64, first float64) []string {
+	full := pdfContentWidth - l.indent
+	limit := first
+	if limit <= 0 {
+		limit = full
+	}
+
+	parts := make([]string, 0, 1)
+	var part strings.Builder
+	partWidth := 0.0
+
+	for _, r := range text {
+		runeWidth := l.pdf.TextWidth(font, size, string(r))
+		if part.Len() > 0 && partWidth+runeWidth > limit {
+			parts = append(parts, part.String())
+			part.Reset()
+			partWidth = 0
+			limit = full
+		}
+		part.WriteRune(r)
+		partWidth += runeWidth
+	}
+
+	return append(parts, part.String())
+}
+
+func (l *pdfLayout) truncate(text string, font string, size float64, width float64) string {
+	if l.pdf.TextWidth(font, size, text) <= width {
+		return text
+	}
+
+	runes := []rune(text)
+	for len(runes) > 0 && l.pdf.TextWidth(font, size, string(runes)+"…") > width {
+		runes = runes[:len(runes)-1]
+	}
+
+	return string(runes) + "…"
+}
+
+// pdfInline собирает текст узла и его потомков в куски с начертанием
+func pdfInline(node *blackfriday.Node, style pdfInlineStyle) []*pdfSpan {
+	spans := make([]*pdfSpan, 0)
+
+	var walk func(node *blackfriday.Node, style pdfInlineStyle)
+	walk = func(node *blackfriday.Node, style pdfInlineStyle) {
+		for child := node.FirstChild; child != nil; child = child.Next {
+			switch child.Type {
+			case blackfriday.Text:
+				spans = append(spans, style.span(pdfFontName(style), strings.ReplaceAll(string(child.Literal), "\n", " ")))
+			case blackfriday.Code:
+				spans = append(spans, style.span(pdfFontMono, string(child.Literal)))
+			case blackfriday.Softbreak:
+				spans = append(spans, style.span(pdfFontName(style), " "))
+			case blackfriday.Hardbreak:
+				spans = append(spans, style.span(pdfFontName(style), "\n"))
+			case blackfriday.Strong:
+				childStyle := style
+				childStyle.bold = true
+				walk(child, childStyle)
+			case blackfriday.Emph:
+				childStyle := style
+				childStyle.italic = true
+				walk(child, childStyle)
+			case blackfriday.Del:
+				childStyle := style
+				childStyle.strike = true
+				walk(child, childStyle)
+			case blackfriday.Link:
+				childStyle := style
+				if pdfExternalLink(string(child.Destination)) {
+					childStyle.uri = string(child.Destination)
+				}
+				walk(child, childStyle)
+			case blackfriday.Image:
+				// Изображения не встраиваются, вместо них выводится подпись
+				childStyle := style
+				childStyle.italic = true
+				childStyle.muted = true
+				spans = append(spans, childStyle.span(pdfFontName(childStyle), "["))
+				walk(child, childStyle)
+				spans = append(spans, childStyle.span(pdfFontName(childStyle), "]"))
+			case blackfriday.Paragraph:
+				walk(child, style)
+			}
+		}
+	}
+
+	walk(node, style)
+	return spans
+}
+
+func (s pdfInlineStyle) span(font string, text string) *pdfSpan {
+	color := pdfTextColor
+	if s.uri != "" {
+		color = pdfLinkColor
+	} else if s.muted {
+		color = pdfMutedColor
+	}
+
+	return &pdfSpan{text: text, font: font, size: s.size, color: color, uri: s.uri, strike: s.strike}
+}
+
+func (s *pdfSpan) sameStyle(other *pdfSpan) bool {
+	return s.font == other.font && s.size == other.size && s.color == other.color && s.uri == other.uri && s.strike == other.strike
+}
+
+func pdfFontName(style pdfInlineStyle) string {
+	switch {
+	case style.bold && style.italic:
+		return pdfFontBoldItalic
+	case style.bold:
+		return pdfFontBold
+	case style.italic:
+		return pdfFontItalic
+	}
+
+	return pdfFontRegular
+}
+
+// pdfTaskItem определяет пункт чек-листа "[ ] " или "[x] " и убирает отметку из текста пункта
+func pdfTaskItem(item *blackfriday.Node) (bool, bool) {
+	paragraph := item.FirstChild
+	if paragraph == nil || paragraph.Type != blackfriday.Paragraph || paragraph.FirstChild == nil || paragraph.FirstChild.Type != blackfriday.Text {
+		return false, false
+	}
+
+	text := paragraph.FirstChild
+	for prefix, checked := range map[string]bool{"[ ] ": false, "[x] ": true, "[X] ": true} {
+		if strings.HasPrefix(string(text.Literal), prefix) {
+			text.Literal = text.Literal[len(prefix):]
+			return checked, true
+		}
+	}
+
+	return false, false
+}
+
+func pdfExternalLink(destination string) bool {
+	lower := strings.ToLower(destination)
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://") || strings.HasPrefix(lower, "mailto:")
+}
+
+// pdfWords делит текст на слова и промежутки между ними, сохраняя промежутки
+func pdfWords(text string) []string {
+	words := make([]string, 0)
+	start := 0
+	inSpace := false
+
+	for i, r := range text {
+		isSpace := unicode.IsSpace(r)
+		if i > start && isSpace != inSpace {
+			words = append(words, text[start:i])
+			start = i
+		}
+		inSpace = isSpace
+	}
+
+	if start < len(text) {
+		words = append(words, text[start:])
+	}
+
+	return words
+}
@@ -0,0 +1,28 @@
+CREATE TABLE attachments (
+                             id SERIAL PRIMARY KEY,
+                             note_id INTEGER NOT NULL,
+                             user_id INTEGER NOT NULL,
+                             file_name VARCHAR(255) NOT NULL,
+                             content_type VARCHAR(255) NOT NULL,
+                             size BIGINT NOT NULL,
+                             storage_key VARCHAR(255) NOT NULL,
+                             thumbnail_status VARCHAR(32) NOT NULL DEFAULT 'none',
+                             timestamp TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                             FOREIGN KEY (note_id) REFERENCES notes(id) ON DELETE CASCADE,
+                             FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_attachments_note_id ON attachments(note_id);
+CREATE INDEX idx_attachments_user_id ON attachments(user_id);
+CREATE INDEX idx_attachments_thumbnail_status ON attachments(thumbnail_status) WHERE thumbnail_status = 'pending';
+
+CREATE TABLE attachment_thumbnails (
+                                       id SERIAL PRIMARY KEY,
+                                       attachment_id INTEGER NOT NULL,
+                                       size INTEGER NOT NULL,
+                                       content_type VARCHAR(255) NOT NULL,
+                                       storage_key VARCHAR(255) NOT NULL,
+                                       timestamp TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                       FOREIGN KEY (attachment_id) REFERENCES attachments(id) ON DELETE CASCADE,
+                                       UNIQUE (attachment_id, size)
+);
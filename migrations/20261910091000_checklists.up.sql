@@ -0,0 +1,13 @@
+ALTER TABLE notes ADD COLUMN type VARCHAR(32) NOT NULL DEFAULT 'text';
+
+CREATE TABLE checklist_items (
+                                 id SERIAL PRIMARY KEY,
+                                 note_id INTEGER NOT NULL,
+                                 text VARCHAR(255) NOT NULL,
+                                 is_checked BOOLEAN NOT NULL DEFAULT FALSE,
+                                 position INTEGER NOT NULL DEFAULT 0,
+                                 timestamp TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                 FOREIGN KEY (note_id) REFERENCES notes(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_checklist_items_note_id_position ON checklist_items(note_id, position);
@@ -0,0 +1,36 @@
+CREATE TABLE reminders (
+                           id SERIAL PRIMARY KEY,
+                           note_id INTEGER NOT NULL UNIQUE,
+                           user_id INTEGER NOT NULL,
+                           starts_at TIMESTAMPTZ NOT NULL,
+                           remind_at TIMESTAMPTZ NOT NULL,
+                           due_at TIMESTAMPTZ,
+                           recurrence VARCHAR(255) NOT NULL DEFAULT '',
+                           fired_count INTEGER NOT NULL DEFAULT 0,
+                           is_active BOOLEAN NOT NULL DEFAULT TRUE,
+                           last_fired_at TIMESTAMPTZ,
+                           channels TEXT[] NOT NULL DEFAULT '{inbox}',
+                           email VARCHAR(255),
+                           webhook_url TEXT,
+                           timestamp TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                           FOREIGN KEY (note_id) REFERENCES notes(id) ON DELETE CASCADE,
+                           FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_reminders_user_id ON reminders(user_id);
+CREATE INDEX idx_reminders_due ON reminders(remind_at) WHERE is_active = true;
+
+CREATE TABLE notifications (
+                               id SERIAL PRIMARY KEY,
+                               user_id INTEGER NOT NULL,
+                               type VARCHAR(64) NOT NULL,
+                               title VARCHAR(255) NOT NULL,
+                               body TEXT NOT NULL DEFAULT '',
+                               note_id INTEGER,
+                               is_read BOOLEAN NOT NULL DEFAULT FALSE,
+                               timestamp TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                               FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+                               FOREIGN KEY (note_id) REFERENCES notes(id) ON DELETE SET NULL
+);
+
+CREATE INDEX idx_notifications_user_id ON notifications(user_id, is_read);
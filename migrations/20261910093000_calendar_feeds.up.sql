@@ -0,0 +1,7 @@
+CREATE TABLE calendar_feeds (
+                                id SERIAL PRIMARY KEY,
+                                user_id INTEGER NOT NULL UNIQUE,
+                                token_hash VARCHAR(64) NOT NULL UNIQUE,
+                                timestamp TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
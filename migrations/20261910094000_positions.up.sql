@@ -0,0 +1,20 @@
+-- Позиции - ключи дробной индексации, сравниваются побайтно, поэтому COLLATE "C"
+ALTER TABLE notes ADD COLUMN is_pinned BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE notes ADD COLUMN position VARCHAR(255) COLLATE "C" NOT NULL DEFAULT '';
+ALTER TABLE folders ADD COLUMN position VARCHAR(255) COLLATE "C" NOT NULL DEFAULT '';
+
+-- Существующим записям назначаются ключи c000, c001, ... в порядке создания:
+-- 'c' задаёт целую часть из трёх цифр base62
+UPDATE notes SET position = 'c'
+    || substr('0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz', (ordered.rn / 3844 % 62)::INT + 1, 1)
+    || substr('0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz', (ordered.rn / 62 % 62)::INT + 1, 1)
+    || substr('0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz', (ordered.rn % 62)::INT + 1, 1)
+FROM (SELECT id, row_number() OVER (PARTITION BY user_id, folder_id ORDER BY id) - 1 AS rn FROM notes) AS ordered
+WHERE notes.id = ordered.id;
+
+UPDATE folders SET position = 'c'
+    || substr('0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz', (ordered.rn / 3844 % 62)::INT + 1, 1)
+    || substr('0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz', (ordered.rn / 62 % 62)::INT + 1, 1)
+    || substr('0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz', (ordered.rn % 62)::INT + 1, 1)
+FROM (SELECT id, row_number() OVER (PARTITION BY user_id ORDER BY id) - 1 AS rn FROM folders) AS ordered
+WHERE folders.id = ordered.id;
@@ -0,0 +1,4 @@
+ALTER TABLE notes ADD COLUMN is_archived BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE folders ADD COLUMN is_archived BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX idx_notes_user_id_archived ON notes(user_id) WHERE is_archived = true;
@@ -0,0 +1,14 @@
+CREATE TABLE note_links (
+                            id SERIAL PRIMARY KEY,
+                            source_note_id INTEGER NOT NULL,
+                            user_id INTEGER NOT NULL,
+                            kind VARCHAR(16) NOT NULL,
+                            target_note_id INTEGER,
+                            target_title VARCHAR(255) NOT NULL DEFAULT '',
+                            timestamp TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                            FOREIGN KEY (source_note_id) REFERENCES notes(id) ON DELETE CASCADE,
+                            FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_note_links_user_id ON note_links(user_id);
+CREATE INDEX idx_note_links_source_note_id ON note_links(source_note_id);
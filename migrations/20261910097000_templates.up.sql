@@ -0,0 +1,13 @@
+CREATE TABLE templates (
+                           id SERIAL PRIMARY KEY,
+                           user_id INTEGER NOT NULL,
+                           name VARCHAR(255) NOT NULL,
+                           title VARCHAR(255) NOT NULL,
+                           content TEXT NOT NULL DEFAULT '',
+                           tags TEXT[] DEFAULT '{}',
+                           folder_id INTEGER,
+                           timestamp TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                           FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+                           FOREIGN KEY (folder_id) REFERENCES folders(id) ON DELETE SET NULL,
+                           UNIQUE (user_id, name)
+);
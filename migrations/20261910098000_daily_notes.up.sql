@@ -0,0 +1,23 @@
+ALTER TABLE users ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+
+CREATE TABLE daily_notes (
+                             id SERIAL PRIMARY KEY,
+                             user_id INTEGER NOT NULL,
+                             date DATE NOT NULL,
+                             note_id INTEGER NOT NULL,
+                             timestamp TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                             FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+                             FOREIGN KEY (note_id) REFERENCES notes(id) ON DELETE CASCADE,
+                             UNIQUE (user_id, date)
+);
+
+CREATE TABLE daily_note_settings (
+                                     id SERIAL PRIMARY KEY,
+                                     user_id INTEGER NOT NULL UNIQUE,
+                                     template_id INTEGER,
+                                     folder_id INTEGER,
+                                     timestamp TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                     FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+                                     FOREIGN KEY (template_id) REFERENCES templates(id) ON DELETE SET NULL,
+                                     FOREIGN KEY (folder_id) REFERENCES folders(id) ON DELETE SET NULL
+);
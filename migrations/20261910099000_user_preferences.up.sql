@@ -0,0 +1,12 @@
+CREATE TABLE preferences (
+                             id SERIAL PRIMARY KEY,
+                             user_id INTEGER NOT NULL UNIQUE,
+                             locale VARCHAR(8) NOT NULL DEFAULT 'ru',
+                             sort_order VARCHAR(16) NOT NULL DEFAULT 'manual',
+                             default_folder_id INTEGER,
+                             page_size INTEGER NOT NULL DEFAULT 0,
+                             editor_format VARCHAR(16) NOT NULL DEFAULT 'markdown',
+                             timestamp TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                             FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+                             FOREIGN KEY (default_folder_id) REFERENCES folders(id) ON DELETE SET NULL
+);
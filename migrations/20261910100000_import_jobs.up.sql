@@ -0,0 +1,34 @@
+CREATE TABLE import_jobs (
+                             id SERIAL PRIMARY KEY,
+                             user_id INTEGER NOT NULL,
+                             source VARCHAR(16) NOT NULL,
+                             strategy VARCHAR(16) NOT NULL DEFAULT 'skip',
+                             status VARCHAR(16) NOT NULL DEFAULT 'pending',
+                             storage_key VARCHAR(255) NOT NULL,
+                             total INTEGER NOT NULL DEFAULT 0,
+                             processed INTEGER NOT NULL DEFAULT 0,
+                             imported INTEGER NOT NULL DEFAULT 0,
+                             skipped INTEGER NOT NULL DEFAULT 0,
+                             failed INTEGER NOT NULL DEFAULT 0,
+                             error TEXT NOT NULL DEFAULT '',
+                             finished_at TIMESTAMP,
+                             timestamp TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                             FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX import_jobs_status_idx ON import_jobs (status);
+
+CREATE TABLE import_job_files (
+                                  id SERIAL PRIMARY KEY,
+                                  job_id INTEGER NOT NULL,
+                                  path TEXT NOT NULL,
+                                  status VARCHAR(16) NOT NULL,
+                                  note_id INTEGER,
+                                  code VARCHAR(64) NOT NULL DEFAULT '',
+                                  message TEXT NOT NULL DEFAULT '',
+                                  timestamp TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                  FOREIGN KEY (job_id) REFERENCES import_jobs(id) ON DELETE CASCADE,
+                                  FOREIGN KEY (note_id) REFERENCES notes(id) ON DELETE SET NULL
+);
+
+CREATE INDEX import_job_files_job_id_idx ON import_job_files (job_id);
@@ -0,0 +1 @@
+ALTER TABLE import_jobs ADD COLUMN file_name VARCHAR(255) NOT NULL DEFAULT '';
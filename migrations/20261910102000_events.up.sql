@@ -0,0 +1,13 @@
+CREATE TABLE events (
+                        id SERIAL PRIMARY KEY,
+                        user_id INTEGER NOT NULL,
+                        type VARCHAR(32) NOT NULL,
+                        note_id INTEGER,
+                        folder_id INTEGER,
+                        title VARCHAR(255) NOT NULL DEFAULT '',
+                        is_favorite BOOLEAN,
+                        timestamp TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                        FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX events_user_id_idx ON events (user_id, id);
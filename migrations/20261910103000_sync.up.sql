@@ -0,0 +1,26 @@
+CREATE SEQUENCE change_seq;
+
+ALTER TABLE notes ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE notes ADD COLUMN change_seq BIGINT NOT NULL DEFAULT nextval('change_seq');
+ALTER TABLE notes ADD COLUMN client_id VARCHAR(64);
+
+ALTER TABLE folders ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE folders ADD COLUMN change_seq BIGINT NOT NULL DEFAULT nextval('change_seq');
+ALTER TABLE folders ADD COLUMN client_id VARCHAR(64);
+
+CREATE INDEX notes_user_id_change_seq_idx ON notes (user_id, change_seq);
+CREATE INDEX folders_user_id_change_seq_idx ON folders (user_id, change_seq);
+CREATE UNIQUE INDEX notes_user_id_client_id_idx ON notes (user_id, client_id) WHERE client_id IS NOT NULL;
+CREATE UNIQUE INDEX folders_user_id_client_id_idx ON folders (user_id, client_id) WHERE client_id IS NOT NULL;
+
+CREATE TABLE tombstones (
+                            id SERIAL PRIMARY KEY,
+                            user_id INTEGER NOT NULL,
+                            entity VARCHAR(16) NOT NULL,
+                            entity_id INTEGER NOT NULL,
+                            change_seq BIGINT NOT NULL,
+                            timestamp TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                            FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX tombstones_user_id_change_seq_idx ON tombstones (user_id, change_seq);
@@ -0,0 +1,43 @@
+CREATE TABLE webhooks (
+                          id SERIAL PRIMARY KEY,
+                          user_id INTEGER NOT NULL,
+                          url TEXT NOT NULL,
+                          secret VARCHAR(64) NOT NULL,
+                          events TEXT[] NOT NULL,
+                          is_active BOOLEAN NOT NULL DEFAULT TRUE,
+                          timestamp TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                          FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX webhooks_user_id_idx ON webhooks (user_id);
+
+CREATE TABLE webhook_deliveries (
+                                    id SERIAL PRIMARY KEY,
+                                    webhook_id INTEGER NOT NULL,
+                                    user_id INTEGER NOT NULL,
+                                    event_type VARCHAR(32) NOT NULL,
+                                    payload TEXT NOT NULL,
+                                    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+                                    attempts INTEGER NOT NULL DEFAULT 0,
+                                    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                    status_code INTEGER,
+                                    error TEXT NOT NULL DEFAULT '',
+                                    delivered_at TIMESTAMPTZ,
+                                    timestamp TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                    FOREIGN KEY (webhook_id) REFERENCES webhooks(id) ON DELETE CASCADE
+);
+
+CREATE INDEX webhook_deliveries_due_idx ON webhook_deliveries (next_attempt_at) WHERE status = 'pending';
+CREATE INDEX webhook_deliveries_webhook_id_idx ON webhook_deliveries (webhook_id, id);
+
+CREATE TABLE webhook_attempts (
+                                  id SERIAL PRIMARY KEY,
+                                  delivery_id INTEGER NOT NULL,
+                                  status_code INTEGER,
+                                  error TEXT NOT NULL DEFAULT '',
+                                  duration_ms INTEGER NOT NULL DEFAULT 0,
+                                  timestamp TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                  FOREIGN KEY (delivery_id) REFERENCES webhook_deliveries(id) ON DELETE CASCADE
+);
+
+CREATE INDEX webhook_attempts_delivery_id_idx ON webhook_attempts (delivery_id);
@@ -0,0 +1,29 @@
+-- Журнал аудита только дополняется: записи не ссылаются на пользователей, чтобы пережить удаление
+-- учётной записи, а изменение и удаление строк запрещено триггером.
+CREATE TABLE audit_entries (
+                           id BIGSERIAL PRIMARY KEY,
+                           actor_id INTEGER,
+                           action VARCHAR(32) NOT NULL,
+                           entity VARCHAR(16) NOT NULL,
+                           entity_id INTEGER,
+                           ip VARCHAR(64) NOT NULL DEFAULT '',
+                           user_agent TEXT NOT NULL DEFAULT '',
+                           trace_id VARCHAR(64) NOT NULL DEFAULT '',
+                           before JSONB,
+                           after JSONB,
+                           timestamp TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX audit_entries_actor_id_idx ON audit_entries (actor_id, id);
+CREATE INDEX audit_entries_entity_idx ON audit_entries (entity, entity_id);
+CREATE INDEX audit_entries_timestamp_idx ON audit_entries (timestamp);
+
+CREATE FUNCTION audit_entries_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_entries is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_entries_append_only
+    BEFORE UPDATE OR DELETE ON audit_entries
+    FOR EACH ROW EXECUTE FUNCTION audit_entries_append_only();
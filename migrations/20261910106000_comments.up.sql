@@ -0,0 +1,22 @@
+CREATE TABLE comments (
+                          id SERIAL PRIMARY KEY,
+                          note_id INTEGER NOT NULL,
+                          user_id INTEGER NOT NULL,
+                          parent_id INTEGER,
+                          content TEXT NOT NULL,
+                          anchor_start INTEGER,
+                          anchor_end INTEGER,
+                          anchor_text TEXT,
+                          is_resolved BOOLEAN NOT NULL DEFAULT FALSE,
+                          resolved_by INTEGER,
+                          resolved_at TIMESTAMPTZ,
+                          edited_at TIMESTAMPTZ,
+                          timestamp TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                          FOREIGN KEY (note_id) REFERENCES notes(id) ON DELETE CASCADE,
+                          FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+                          FOREIGN KEY (parent_id) REFERENCES comments(id) ON DELETE CASCADE,
+                          FOREIGN KEY (resolved_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX comments_note_id_idx ON comments (note_id, id);
+CREATE INDEX comments_parent_id_idx ON comments (parent_id, id);
@@ -0,0 +1,8 @@
+ALTER TABLE notifications
+    ADD COLUMN comment_id INTEGER REFERENCES comments(id) ON DELETE CASCADE,
+    ADD COLUMN actor_id INTEGER REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_notifications_user_id_id ON notifications(user_id, id DESC);
+
+ALTER TABLE preferences
+    ADD COLUMN muted_notifications TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,10 @@
+CREATE TABLE note_locks (
+                            note_id INTEGER PRIMARY KEY,
+                            user_id INTEGER NOT NULL,
+                            token VARCHAR(64) NOT NULL,
+                            expires_at TIMESTAMP NOT NULL,
+                            FOREIGN KEY (note_id) REFERENCES notes(id) ON DELETE CASCADE,
+                            FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_note_locks_expires_at ON note_locks(expires_at);
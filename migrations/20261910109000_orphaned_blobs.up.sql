@@ -0,0 +1,22 @@
+-- Файлы вложений и миниатюр лежат вне базы, поэтому строки удаляются каскадом вместе с заметкой
+-- или пользователем, а файлы остаются. Триггер ставит ключ удалённого файла в очередь на удаление.
+CREATE TABLE orphaned_blobs (
+                                id BIGSERIAL PRIMARY KEY,
+                                storage_key VARCHAR(255) NOT NULL,
+                                timestamp TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE FUNCTION enqueue_orphaned_blob() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO orphaned_blobs (storage_key) VALUES (OLD.storage_key);
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER attachments_orphaned_blob
+    AFTER DELETE ON attachments
+    FOR EACH ROW EXECUTE FUNCTION enqueue_orphaned_blob();
+
+CREATE TRIGGER attachment_thumbnails_orphaned_blob
+    AFTER DELETE ON attachment_thumbnails
+    FOR EACH ROW EXECUTE FUNCTION enqueue_orphaned_blob();
@@ -0,0 +1 @@
+ALTER TABLE note_locks ALTER COLUMN expires_at TYPE TIMESTAMPTZ USING expires_at AT TIME ZONE 'UTC';